@@ -28,7 +28,7 @@ func main() {
 	}
 
 	// Create database connection
-	db, err := database.NewConnection(&cfg.Database, database.DefaultConnectionOptions())
+	db, err := database.NewConnection(&cfg.Database, database.ConnectionOptionsFromConfig(&cfg.Database))
 	if err != nil {
 		log.Fatalf("Failed to create database connection: %v", err)
 	}
@@ -70,6 +70,8 @@ func main() {
 		fmt.Printf("Latency: %v\n", status.Latency)
 		fmt.Printf("Timestamp: %s\n", status.Timestamp.Format(time.RFC3339))
 		fmt.Printf("Connections:\n")
+		fmt.Printf("  Max Open: %d\n", status.Connections.MaxOpenConns)
+		fmt.Printf("  Max Idle: %d\n", status.Connections.MaxIdleConns)
 		fmt.Printf("  Open: %d\n", status.Connections.OpenConnections)
 		fmt.Printf("  In Use: %d\n", status.Connections.InUseConnections)
 		fmt.Printf("  Idle: %d\n", status.Connections.IdleConnections)
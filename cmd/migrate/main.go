@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"go-templ-template/internal/config"
 	"go-templ-template/internal/shared/database"
@@ -13,13 +17,15 @@ import (
 
 func main() {
 	var (
-		action         = flag.String("action", "up", "Migration action: up, down, steps, version, force, status, list, create, validate, to")
+		action         = flag.String("action", "up", "Migration action: up, down, steps, version, force, status, list, create, validate, to, baseline, seed")
 		steps          = flag.Int("steps", 0, "Number of steps for 'steps' action")
 		version        = flag.Int("version", 0, "Version for 'force' or 'to' action")
 		migrationsPath = flag.String("migrations", "./migrations", "Path to migrations directory")
-		name           = flag.String("name", "", "Name for 'create' action")
+		seedsPath      = flag.String("seeds", "./seeds", "Path to seeds directory")
+		name           = flag.String("name", "", "Name for 'create' action, or seed set for 'seed' action")
 		format         = flag.String("format", "text", "Output format: text, json")
 		verbose        = flag.Bool("verbose", false, "Verbose output")
+		dryRun         = flag.Bool("dry-run", false, "Print the migrations an up, down, steps or to action would run without executing them")
 	)
 	flag.Parse()
 
@@ -29,6 +35,11 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	if *dryRun {
+		planMigrations(cfg, *migrationsPath, *action, *steps, *version, *format)
+		return
+	}
+
 	// Execute the requested action
 	switch *action {
 	case "up":
@@ -51,6 +62,10 @@ func main() {
 		validateMigrations(cfg, *migrationsPath, *verbose)
 	case "to":
 		migrateTo(cfg, *migrationsPath, *version, *verbose)
+	case "baseline":
+		baselineMigrations(cfg, *migrationsPath, *verbose)
+	case "seed":
+		runSeeds(cfg, *seedsPath, *name, *verbose)
 	default:
 		log.Fatalf("Unknown action: %s", *action)
 	}
@@ -58,46 +73,46 @@ func main() {
 
 func runMigrationsUp(cfg *config.Config, migrationsPath string, verbose bool) {
 	if verbose {
-		log.Println("Creating migration runner...")
+		log.Println("Creating migration manager...")
 	}
 
-	runner, err := database.NewMigrationRunner(&cfg.Database, migrationsPath)
+	manager, err := database.NewMigrationManager(&cfg.Database, migrationsPath)
 	if err != nil {
-		log.Fatalf("Failed to create migration runner: %v", err)
+		log.Fatalf("Failed to create migration manager: %v", err)
 	}
-	defer runner.Close()
+	defer manager.Close()
 
 	log.Println("Running migrations up...")
-	err = runner.Up()
+	err = manager.MigrateUp()
 	if err != nil {
 		log.Fatalf("Failed to run migrations up: %v", err)
 	}
 	log.Println("Migrations completed successfully")
 
 	// Show final version
-	showFinalVersion(runner, verbose)
+	showFinalVersion(manager, verbose)
 }
 
 func runMigrationsDown(cfg *config.Config, migrationsPath string, verbose bool) {
 	if verbose {
-		log.Println("Creating migration runner...")
+		log.Println("Creating migration manager...")
 	}
 
-	runner, err := database.NewMigrationRunner(&cfg.Database, migrationsPath)
+	manager, err := database.NewMigrationManager(&cfg.Database, migrationsPath)
 	if err != nil {
-		log.Fatalf("Failed to create migration runner: %v", err)
+		log.Fatalf("Failed to create migration manager: %v", err)
 	}
-	defer runner.Close()
+	defer manager.Close()
 
 	log.Println("Running migrations down...")
-	err = runner.Down()
+	err = manager.MigrateDown()
 	if err != nil {
 		log.Fatalf("Failed to run migrations down: %v", err)
 	}
 	log.Println("Migrations rolled back successfully")
 
 	// Show final version
-	showFinalVersion(runner, verbose)
+	showFinalVersion(manager, verbose)
 }
 
 func runMigrationSteps(cfg *config.Config, migrationsPath string, steps int, verbose bool) {
@@ -106,14 +121,14 @@ func runMigrationSteps(cfg *config.Config, migrationsPath string, steps int, ver
 	}
 
 	if verbose {
-		log.Println("Creating migration runner...")
+		log.Println("Creating migration manager...")
 	}
 
-	runner, err := database.NewMigrationRunner(&cfg.Database, migrationsPath)
+	manager, err := database.NewMigrationManager(&cfg.Database, migrationsPath)
 	if err != nil {
-		log.Fatalf("Failed to create migration runner: %v", err)
+		log.Fatalf("Failed to create migration manager: %v", err)
 	}
-	defer runner.Close()
+	defer manager.Close()
 
 	direction := "up"
 	if steps < 0 {
@@ -121,14 +136,14 @@ func runMigrationSteps(cfg *config.Config, migrationsPath string, steps int, ver
 	}
 
 	log.Printf("Running %d migration steps %s...", abs(steps), direction)
-	err = runner.Steps(steps)
+	err = manager.MigrateSteps(steps)
 	if err != nil {
 		log.Fatalf("Failed to run migration steps: %v", err)
 	}
 	log.Println("Migration steps completed successfully")
 
 	// Show final version
-	showFinalVersion(runner, verbose)
+	showFinalVersion(manager, verbose)
 }
 
 func showVersion(cfg *config.Config, migrationsPath string, format string) {
@@ -160,24 +175,24 @@ func forceMigrationVersion(cfg *config.Config, migrationsPath string, version in
 	}
 
 	if verbose {
-		log.Println("Creating migration runner...")
+		log.Println("Creating migration manager...")
 	}
 
-	runner, err := database.NewMigrationRunner(&cfg.Database, migrationsPath)
+	manager, err := database.NewMigrationManager(&cfg.Database, migrationsPath)
 	if err != nil {
-		log.Fatalf("Failed to create migration runner: %v", err)
+		log.Fatalf("Failed to create migration manager: %v", err)
 	}
-	defer runner.Close()
+	defer manager.Close()
 
 	log.Printf("Forcing migration version to %d...", version)
-	err = runner.Force(version)
+	err = manager.Force(version)
 	if err != nil {
 		log.Fatalf("Failed to force migration version: %v", err)
 	}
 	log.Println("Migration version forced successfully")
 
 	// Show final version
-	showFinalVersion(runner, verbose)
+	showFinalVersion(manager, verbose)
 }
 
 func showStatus(cfg *config.Config, migrationsPath string, format string) {
@@ -203,6 +218,17 @@ func showStatus(cfg *config.Config, migrationsPath string, format string) {
 		fmt.Printf("  Pending:         %d migrations\n", status.PendingCount)
 		fmt.Printf("  Status:          %s\n", status.String())
 
+		if len(status.AppliedMigrations) > 0 {
+			fmt.Println("\nApplied Migrations:")
+			for _, migration := range status.AppliedMigrations {
+				appliedAt := "unknown"
+				if migration.AppliedAt != nil {
+					appliedAt = migration.AppliedAt.Format(time.RFC3339)
+				}
+				fmt.Printf("  - %03d: %-30s %s\n", migration.Version, migration.Name, appliedAt)
+			}
+		}
+
 		if len(status.PendingMigrations) > 0 {
 			fmt.Println("\nPending Migrations:")
 			for _, migration := range status.PendingMigrations {
@@ -348,8 +374,117 @@ func migrateTo(cfg *config.Config, migrationsPath string, targetVersion int, ver
 	fmt.Printf("Successfully migrated to version %d\n", targetVersion)
 }
 
-func showFinalVersion(runner *database.MigrationRunner, verbose bool) {
-	v, dirty, err := runner.Version()
+func planMigrations(cfg *config.Config, migrationsPath string, action string, steps int, targetVersion int, format string) {
+	manager, err := database.NewMigrationManager(&cfg.Database, migrationsPath)
+	if err != nil {
+		log.Fatalf("Failed to create migration manager: %v", err)
+	}
+	defer manager.Close()
+
+	var plan []database.PlannedMigration
+	switch action {
+	case "up":
+		migrations, listErr := manager.ListMigrations()
+		if listErr != nil {
+			log.Fatalf("Failed to list migrations: %v", listErr)
+		}
+		var latest uint
+		if len(migrations) > 0 {
+			latest = migrations[len(migrations)-1].Version
+		}
+		plan, err = manager.PlanMigration(latest)
+	case "down":
+		plan, err = manager.PlanMigration(0)
+	case "steps":
+		if steps == 0 {
+			log.Fatal("Steps count must be specified with -steps flag")
+		}
+		plan, err = manager.PlanSteps(steps)
+	case "to":
+		if targetVersion < 0 {
+			log.Fatal("Target version must be non-negative")
+		}
+		plan, err = manager.PlanMigration(uint(targetVersion))
+	default:
+		log.Fatalf("Dry run is not supported for action: %s", action)
+	}
+	if err != nil {
+		log.Fatalf("Failed to plan migrations: %v", err)
+	}
+
+	if format == "json" {
+		json.NewEncoder(os.Stdout).Encode(plan)
+		return
+	}
+
+	if len(plan) == 0 {
+		fmt.Println("Dry run: no migrations would be applied")
+		return
+	}
+
+	fmt.Printf("Dry run: %d migrations would be applied:\n", len(plan))
+	for _, migration := range plan {
+		fmt.Printf("\n-- %s (%s)\n", migration.File, migration.Direction)
+		fmt.Println(strings.TrimSpace(migration.SQL))
+	}
+}
+
+func baselineMigrations(cfg *config.Config, migrationsPath string, verbose bool) {
+	if verbose {
+		log.Println("Creating migration manager...")
+	}
+
+	manager, err := database.NewMigrationManager(&cfg.Database, migrationsPath)
+	if err != nil {
+		log.Fatalf("Failed to create migration manager: %v", err)
+	}
+	defer manager.Close()
+
+	baseline, err := manager.Baseline(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to baseline migrations: %v", err)
+	}
+
+	fmt.Printf("Created baseline migration files:\n")
+	fmt.Printf("  Up:   %s\n", baseline.UpFile)
+	fmt.Printf("  Down: %s\n", baseline.DownFile)
+	fmt.Printf("Version: %d\n", baseline.Version)
+	fmt.Printf("%s\n", baseline.Description)
+
+	if verbose {
+		fmt.Printf("Squashed migration files moved to: %s\n", filepath.Join(migrationsPath, database.MigrationArchiveDir))
+	}
+}
+
+func runSeeds(cfg *config.Config, seedsPath string, set string, verbose bool) {
+	if set == "" {
+		log.Fatal("Seed set must be specified with -name flag")
+	}
+
+	if verbose {
+		log.Println("Creating seed runner...")
+	}
+
+	runner, err := database.NewSeedRunner(&cfg.Database, seedsPath)
+	if err != nil {
+		log.Fatalf("Failed to create seed runner: %v", err)
+	}
+	defer runner.Close()
+
+	log.Printf("Running seed set %s...", set)
+	files, err := runner.Run(context.Background(), set)
+	if err != nil {
+		log.Fatalf("Failed to run seed set %s: %v", set, err)
+	}
+
+	for _, file := range files {
+		fmt.Printf("  %s\n", file.Name)
+	}
+	fmt.Printf("Ran %d seed files from %s\n", len(files), filepath.Join(seedsPath, set))
+}
+
+func showFinalVersion(manager *database.MigrationManager, verbose bool) {
+	v, dirty, err := manager.GetCurrentVersion()
 	if err != nil {
 		if verbose {
 			log.Printf("Warning: could not get final migration version: %v", err)
@@ -385,6 +520,8 @@ func init() {
 		fmt.Fprintf(os.Stderr, "  create    - Create new migration files (use -name flag)\n")
 		fmt.Fprintf(os.Stderr, "  validate  - Validate all migration files\n")
 		fmt.Fprintf(os.Stderr, "  to        - Migrate to specific version (use -version flag)\n")
+		fmt.Fprintf(os.Stderr, "  baseline  - Squash all migrations into one baseline migration\n")
+		fmt.Fprintf(os.Stderr, "  seed      - Run a seed set from the seeds directory (use -name flag)\n")
 		fmt.Fprintf(os.Stderr, "\nOptions:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
@@ -393,5 +530,8 @@ func init() {
 		fmt.Fprintf(os.Stderr, "  %s -action=create -name=\"add user roles\"\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -action=steps -steps=2\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s -action=to -version=3\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -action=up -dry-run\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -action=baseline\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -action=seed -name=dev\n", os.Args[0])
 	}
 }
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"go-templ-template/internal/config"
+	"go-templ-template/internal/shared/audit"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/events"
+)
+
+func main() {
+	var (
+		source   = flag.String("source", "outbox", "Where to read events from: outbox, audit")
+		types    = flag.String("types", "", "Comma-separated event types to replay; empty replays all types")
+		since    = flag.String("since", "", "Replay events that occurred at or after this RFC 3339 time")
+		until    = flag.String("until", "", "Replay events that occurred at or before this RFC 3339 time")
+		exchange = flag.String("exchange", "", "Exchange to publish to; empty uses the configured exchange")
+		dryRun   = flag.Bool("dry-run", false, "Count the matching events without publishing them")
+		format   = flag.String("format", "text", "Output format: text, json")
+		timeout  = flag.Duration("timeout", 10*time.Minute, "Timeout for the replay")
+	)
+	flag.Parse()
+
+	filter, err := parseFilter(*types, *since, *until)
+	if err != nil {
+		log.Fatalf("Invalid filter: %v", err)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Create database connection
+	db, err := database.NewConnection(&cfg.Database, database.ConnectionOptionsFromConfig(&cfg.Database))
+	if err != nil {
+		log.Fatalf("Failed to create database connection: %v", err)
+	}
+	defer db.Close()
+
+	var store EventStore
+	switch *source {
+	case "outbox":
+		store = newOutboxStore(db)
+	case "audit":
+		store = newAuditStore(audit.NewAuditLogger(db))
+	default:
+		log.Fatalf("Unknown source: %s", *source)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	var bus events.EventBus
+	if !*dryRun {
+		bus = newReplayEventBus(cfg, *exchange)
+		if err := bus.Start(ctx); err != nil {
+			log.Fatalf("Failed to start event bus: %v", err)
+		}
+		defer func() {
+			if err := bus.Stop(context.Background()); err != nil {
+				log.Printf("Failed to stop event bus: %v", err)
+			}
+		}()
+	}
+
+	result, err := NewReplayer(store, bus).Run(ctx, filter, *dryRun)
+	if result != nil {
+		printResult(result, *dryRun, *format)
+	}
+	if err != nil {
+		log.Fatalf("Replay failed: %v", err)
+	}
+}
+
+// parseFilter builds the replay filter from the command line flags
+func parseFilter(types, since, until string) (Filter, error) {
+	var filter Filter
+
+	for _, eventType := range strings.Split(types, ",") {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			filter.EventTypes = append(filter.EventTypes, eventType)
+		}
+	}
+
+	if since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return Filter{}, fmt.Errorf("since must be an RFC 3339 time: %w", err)
+		}
+		filter.Since = parsed
+	}
+
+	if until != "" {
+		parsed, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			return Filter{}, fmt.Errorf("until must be an RFC 3339 time: %w", err)
+		}
+		filter.Until = parsed
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Until.Before(filter.Since) {
+		return Filter{}, fmt.Errorf("until must not be before since")
+	}
+
+	return filter, nil
+}
+
+// newReplayEventBus creates a RabbitMQ event bus publishing to exchange, or
+// to the configured exchange if it is empty. Publishing to a dedicated
+// replay exchange delivers events only to consumers bound to it.
+func newReplayEventBus(cfg *config.Config, exchange string) events.EventBus {
+	if exchange == "" {
+		exchange = cfg.RabbitMQ.Exchange
+	}
+
+	eventBusConfig := events.RabbitMQConfig{
+		URL:          cfg.RabbitMQ.URL,
+		Exchange:     exchange,
+		ExchangeType: "topic",
+		QueuePrefix:  cfg.RabbitMQ.QueuePrefix,
+		Durable:      cfg.RabbitMQ.Durable,
+	}
+
+	// Use default URL if not provided
+	if eventBusConfig.URL == "" {
+		eventBusConfig.URL = fmt.Sprintf("amqp://%s:%s@%s:%s/",
+			cfg.RabbitMQ.User, cfg.RabbitMQ.Password, cfg.RabbitMQ.Host, cfg.RabbitMQ.Port)
+	}
+
+	return events.NewRabbitMQEventBus(eventBusConfig)
+}
+
+// printResult prints the replay summary in format
+func printResult(result *Result, dryRun bool, format string) {
+	switch format {
+	case "json":
+		jsonData, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			log.Fatalf("Failed to marshal JSON: %v", err)
+		}
+		fmt.Println(string(jsonData))
+
+	default:
+		if dryRun {
+			fmt.Printf("Dry run: %d events match\n", result.Matched)
+		} else {
+			fmt.Printf("Replayed %d of %d matching events\n", result.Published, result.Matched)
+		}
+
+		eventTypes := make([]string, 0, len(result.CountsByType))
+		for eventType := range result.CountsByType {
+			eventTypes = append(eventTypes, eventType)
+		}
+		sort.Strings(eventTypes)
+		for _, eventType := range eventTypes {
+			fmt.Printf("  %s: %d\n", eventType, result.CountsByType[eventType])
+		}
+	}
+}
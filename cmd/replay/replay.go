@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-templ-template/internal/shared/audit"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/events"
+
+	"github.com/lib/pq"
+)
+
+// storeBatchSize is how many events a store loads per query
+const storeBatchSize = 500
+
+// Filter selects the events to replay. Zero-valued fields are ignored; Since
+// and Until are inclusive bounds on when the event occurred.
+type Filter struct {
+	EventTypes []string
+	Since      time.Time
+	Until      time.Time
+}
+
+// Matches reports whether event is selected by the filter
+func (f Filter) Matches(event events.DomainEvent) bool {
+	if len(f.EventTypes) > 0 {
+		matched := false
+		for _, eventType := range f.EventTypes {
+			if event.EventType() == eventType {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	occurredAt := event.OccurredAt()
+	if !f.Since.IsZero() && occurredAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && occurredAt.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// EventStore reads historical events
+type EventStore interface {
+	// Events returns the stored events selected by filter, oldest first.
+	// Stores may narrow the query with the filter, but callers must not rely
+	// on them applying all of it.
+	Events(ctx context.Context, filter Filter) ([]events.DomainEvent, error)
+}
+
+// Result summarises a replay
+type Result struct {
+	// Matched is the number of stored events selected by the filter
+	Matched int `json:"matched"`
+
+	// Published is the number of events published; zero on a dry run
+	Published int `json:"published"`
+
+	// CountsByType is the number of matched events of each type
+	CountsByType map[string]int `json:"counts_by_type"`
+}
+
+// Replayer re-publishes stored events through an event bus
+type Replayer struct {
+	store EventStore
+	bus   events.EventBus
+}
+
+// NewReplayer creates a replayer reading from store and publishing to bus;
+// bus may be nil for dry runs
+func NewReplayer(store EventStore, bus events.EventBus) *Replayer {
+	return &Replayer{
+		store: store,
+		bus:   bus,
+	}
+}
+
+// Run publishes the stored events selected by filter in the order they
+// occurred, or only counts them when dryRun is set. It stops at the first
+// event that fails to publish, returning the counts so far with the error.
+// Events keep their original IDs, so consumers wrapped in an
+// events.IdempotentHandler skip events they already processed successfully.
+func (r *Replayer) Run(ctx context.Context, filter Filter, dryRun bool) (*Result, error) {
+	stored, err := r.store.Events(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+
+	result := &Result{CountsByType: make(map[string]int)}
+	var matched []events.DomainEvent
+	for _, event := range stored {
+		if !filter.Matches(event) {
+			continue
+		}
+		matched = append(matched, event)
+		result.Matched++
+		result.CountsByType[event.EventType()]++
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	if r.bus == nil {
+		return result, fmt.Errorf("no event bus to publish to")
+	}
+
+	for _, event := range matched {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := r.bus.Publish(ctx, event); err != nil {
+			return result, fmt.Errorf("failed to publish event %s: %w", event.EventID(), err)
+		}
+		result.Published++
+	}
+
+	return result, nil
+}
+
+// outboxStore reads events from the event_outbox table, which keeps every
+// event published inside a transaction, whether or not it was sent
+type outboxStore struct {
+	db *database.DB
+}
+
+// outboxEventRow is a stored event in the event_outbox table
+type outboxEventRow struct {
+	ID      int64  `db:"id"`
+	EventID string `db:"event_id"`
+	Payload []byte `db:"payload"`
+}
+
+// newOutboxStore creates a store reading from the event_outbox table
+func newOutboxStore(db *database.DB) *outboxStore {
+	return &outboxStore{db: db}
+}
+
+// Events returns the outbox events selected by filter in insertion order.
+// Rows are narrowed by type and by when they were written, which is no
+// earlier than when the event occurred; Run applies the exact time range.
+func (s *outboxStore) Events(ctx context.Context, filter Filter) ([]events.DomainEvent, error) {
+	var result []events.DomainEvent
+	var afterID int64
+
+	for {
+		query := `
+			SELECT id, event_id, payload
+			FROM event_outbox
+			WHERE id > $1`
+		args := []interface{}{afterID}
+
+		if len(filter.EventTypes) > 0 {
+			args = append(args, pq.Array(filter.EventTypes))
+			query += fmt.Sprintf(" AND event_type = ANY($%d)", len(args))
+		}
+		if !filter.Since.IsZero() {
+			args = append(args, filter.Since)
+			query += fmt.Sprintf(" AND created_at >= $%d", len(args))
+		}
+
+		args = append(args, storeBatchSize)
+		query += fmt.Sprintf(" ORDER BY id LIMIT $%d", len(args))
+
+		var rows []outboxEventRow
+		if err := s.db.SelectContext(ctx, &rows, query, args...); err != nil {
+			return nil, fmt.Errorf("failed to load outbox events: %w", err)
+		}
+
+		for _, row := range rows {
+			var envelope events.SerializableEventEnvelope
+			if err := json.Unmarshal(row.Payload, &envelope); err != nil {
+				return nil, fmt.Errorf("failed to deserialize outbox event %s: %w", row.EventID, err)
+			}
+			result = append(result, envelope.Event)
+			afterID = row.ID
+		}
+
+		if len(rows) < storeBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// auditStore reads events from the audit log, which records the user
+// lifecycle and authentication events handled by the audit handlers
+type auditStore struct {
+	logger audit.AuditLogger
+}
+
+// newAuditStore creates a store reading from the audit log
+func newAuditStore(logger audit.AuditLogger) *auditStore {
+	return &auditStore{logger: logger}
+}
+
+// Events returns the audited events selected by filter, oldest first. The
+// audit log keeps an event's details rather than its original data, so
+// replayed events carry the details as their data. Events audited by more
+// than one handler are returned once.
+func (s *auditStore) Events(ctx context.Context, filter Filter) ([]events.DomainEvent, error) {
+	eventTypes := filter.EventTypes
+	if len(eventTypes) == 0 {
+		eventTypes = []string{""}
+	}
+
+	var result []events.DomainEvent
+	seen := make(map[string]bool)
+	for _, eventType := range eventTypes {
+		auditFilter := &audit.AuditFilter{
+			EventType: eventType,
+			StartTime: filter.Since,
+			EndTime:   filter.Until,
+			Limit:     storeBatchSize,
+			Ascending: true,
+		}
+
+		for {
+			page, err := s.logger.GetEvents(ctx, auditFilter)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load audit events: %w", err)
+			}
+
+			for _, auditEvent := range page {
+				if seen[auditEvent.EventID] {
+					continue
+				}
+				seen[auditEvent.EventID] = true
+				result = append(result, &events.SerializableEvent{
+					ID:        auditEvent.EventID,
+					Type:      auditEvent.EventType,
+					AggID:     auditEvent.AggregateID,
+					AggType:   auditEvent.AggregateType,
+					Timestamp: auditEvent.OccurredAt,
+					Ver:       1,
+					Meta:      auditEvent.Metadata,
+					Data:      auditEvent.Details,
+				})
+			}
+
+			if len(page) < storeBatchSize {
+				break
+			}
+			auditFilter.Offset += len(page)
+		}
+	}
+
+	// Events queried per type are merged back into the order they occurred
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].OccurredAt().Before(result[j].OccurredAt())
+	})
+	return result, nil
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/shared/audit"
+	"go-templ-template/internal/shared/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockEventStore returns the stored events unfiltered
+type mockEventStore struct {
+	events []events.DomainEvent
+	err    error
+}
+
+func (s *mockEventStore) Events(ctx context.Context, filter Filter) ([]events.DomainEvent, error) {
+	return s.events, s.err
+}
+
+// MockEventBus is a mock implementation of EventBus
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Publish(ctx context.Context, event events.DomainEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockEventBus) Subscribe(eventType string, handler events.EventHandler) error {
+	args := m.Called(eventType, handler)
+	return args.Error(0)
+}
+
+func (m *MockEventBus) Unsubscribe(eventType string, handler events.EventHandler) error {
+	args := m.Called(eventType, handler)
+	return args.Error(0)
+}
+
+func (m *MockEventBus) Start(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockEventBus) Stop(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockEventBus) Health() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// MockAuditLogger is a mock implementation of AuditLogger
+type MockAuditLogger struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogger) LogEvent(ctx context.Context, event *audit.AuditEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogger) GetEvents(ctx context.Context, filter *audit.AuditFilter) ([]*audit.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*audit.AuditEvent), args.Error(1)
+}
+
+var replayBaseTime = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// storedEvent creates an event of eventType that occurred offset after replayBaseTime
+func storedEvent(id, eventType string, offset time.Duration) *events.SerializableEvent {
+	return &events.SerializableEvent{
+		ID:        id,
+		Type:      eventType,
+		AggID:     "user-1",
+		AggType:   "User",
+		Timestamp: replayBaseTime.Add(offset),
+		Ver:       1,
+	}
+}
+
+func TestFilter_Matches(t *testing.T) {
+	event := storedEvent("event-1", "user.created", 0)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"empty filter", Filter{}, true},
+		{"matching type", Filter{EventTypes: []string{"user.deleted", "user.created"}}, true},
+		{"other type", Filter{EventTypes: []string{"user.deleted"}}, false},
+		{"since is inclusive", Filter{Since: replayBaseTime}, true},
+		{"before since", Filter{Since: replayBaseTime.Add(time.Second)}, false},
+		{"until is inclusive", Filter{Until: replayBaseTime}, true},
+		{"after until", Filter{Until: replayBaseTime.Add(-time.Second)}, false},
+		{"within range", Filter{Since: replayBaseTime.Add(-time.Hour), Until: replayBaseTime.Add(time.Hour)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Matches(event))
+		})
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	filter, err := parseFilter(" user.created, ,user.deleted", "2024-03-01T00:00:00Z", "2024-03-02T00:00:00Z")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user.created", "user.deleted"}, filter.EventTypes)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), filter.Since)
+	assert.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), filter.Until)
+
+	filter, err = parseFilter("", "", "")
+	require.NoError(t, err)
+	assert.Empty(t, filter.EventTypes)
+	assert.True(t, filter.Since.IsZero())
+	assert.True(t, filter.Until.IsZero())
+
+	_, err = parseFilter("", "yesterday", "")
+	assert.Error(t, err)
+
+	_, err = parseFilter("", "", "2024-03-01")
+	assert.Error(t, err)
+
+	_, err = parseFilter("", "2024-03-02T00:00:00Z", "2024-03-01T00:00:00Z")
+	assert.Error(t, err)
+}
+
+func TestReplayer_DryRunCountsMatches(t *testing.T) {
+	store := &mockEventStore{events: []events.DomainEvent{
+		storedEvent("event-1", "user.created", 0),
+		storedEvent("event-2", "user.deleted", time.Minute),
+		storedEvent("event-3", "user.created", 2*time.Minute),
+		storedEvent("event-4", "user.created", 2*time.Hour),
+	}}
+	bus := &MockEventBus{}
+	filter := Filter{EventTypes: []string{"user.created"}, Until: replayBaseTime.Add(time.Hour)}
+
+	result, err := NewReplayer(store, bus).Run(context.Background(), filter, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Matched)
+	assert.Equal(t, 0, result.Published)
+	assert.Equal(t, map[string]int{"user.created": 2}, result.CountsByType)
+	bus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestReplayer_PublishesMatchesInOrder(t *testing.T) {
+	first := storedEvent("event-1", "user.created", 0)
+	skipped := storedEvent("event-2", "user.deleted", time.Minute)
+	second := storedEvent("event-3", "user.activated", 2*time.Minute)
+	store := &mockEventStore{events: []events.DomainEvent{first, skipped, second}}
+
+	var published []string
+	bus := &MockEventBus{}
+	bus.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		published = append(published, args.Get(1).(events.DomainEvent).EventID())
+	}).Return(nil)
+	filter := Filter{EventTypes: []string{"user.created", "user.activated"}}
+
+	result, err := NewReplayer(store, bus).Run(context.Background(), filter, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Matched)
+	assert.Equal(t, 2, result.Published)
+	assert.Equal(t, []string{"event-1", "event-3"}, published)
+}
+
+func TestReplayer_StopsAtFirstPublishError(t *testing.T) {
+	first := storedEvent("event-1", "user.created", 0)
+	failing := storedEvent("event-2", "user.created", time.Minute)
+	last := storedEvent("event-3", "user.created", 2*time.Minute)
+	store := &mockEventStore{events: []events.DomainEvent{first, failing, last}}
+
+	bus := &MockEventBus{}
+	bus.On("Publish", mock.Anything, first).Return(nil)
+	bus.On("Publish", mock.Anything, failing).Return(errors.New("connection closed"))
+
+	result, err := NewReplayer(store, bus).Run(context.Background(), Filter{}, false)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "event-2")
+	assert.Equal(t, 3, result.Matched)
+	assert.Equal(t, 1, result.Published)
+	bus.AssertNotCalled(t, "Publish", mock.Anything, last)
+}
+
+func TestReplayer_StoreError(t *testing.T) {
+	store := &mockEventStore{err: errors.New("db down")}
+
+	result, err := NewReplayer(store, &MockEventBus{}).Run(context.Background(), Filter{}, true)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestReplayer_RequiresBusToPublish(t *testing.T) {
+	store := &mockEventStore{events: []events.DomainEvent{storedEvent("event-1", "user.created", 0)}}
+
+	result, err := NewReplayer(store, nil).Run(context.Background(), Filter{}, false)
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, result.Matched)
+}
+
+func TestAuditStore_Events(t *testing.T) {
+	ctx := context.Background()
+	logger := &MockAuditLogger{}
+	logger.On("GetEvents", ctx, mock.MatchedBy(func(filter *audit.AuditFilter) bool {
+		return filter.EventType == "user.deleted" && filter.Ascending
+	})).Return([]*audit.AuditEvent{
+		{EventID: "event-2", EventType: "user.deleted", OccurredAt: replayBaseTime.Add(time.Minute), Action: "delete"},
+		{EventID: "event-2", EventType: "user.deleted", OccurredAt: replayBaseTime.Add(time.Minute), Action: "sessions_revoked"},
+	}, nil)
+	logger.On("GetEvents", ctx, mock.MatchedBy(func(filter *audit.AuditFilter) bool {
+		return filter.EventType == "user.created" && filter.Ascending
+	})).Return([]*audit.AuditEvent{
+		{
+			EventID:     "event-1",
+			EventType:   "user.created",
+			AggregateID: "user-1",
+			OccurredAt:  replayBaseTime,
+			Details:     map[string]interface{}{"email": "user@example.com"},
+		},
+	}, nil)
+
+	stored, err := newAuditStore(logger).Events(ctx, Filter{EventTypes: []string{"user.deleted", "user.created"}})
+
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	assert.Equal(t, "event-1", stored[0].EventID())
+	assert.Equal(t, "user-1", stored[0].AggregateID())
+	assert.Equal(t, map[string]interface{}{"email": "user@example.com"}, stored[0].EventData())
+	assert.Equal(t, "event-2", stored[1].EventID())
+}
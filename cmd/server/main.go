@@ -2,25 +2,33 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"go-templ-template/internal/config"
 	"go-templ-template/internal/modules/auth"
+	authHandlers "go-templ-template/internal/modules/auth/handlers"
 	"go-templ-template/internal/modules/user"
+	userDomain "go-templ-template/internal/modules/user/domain"
 	"go-templ-template/internal/shared"
 	"go-templ-template/internal/shared/database"
+	appErrors "go-templ-template/internal/shared/errors"
 	"go-templ-template/internal/shared/events"
+	"go-templ-template/internal/shared/flash"
 	"go-templ-template/internal/shared/handlers"
+	"go-templ-template/internal/shared/metrics"
 	errorMiddleware "go-templ-template/internal/shared/middleware"
+	"go-templ-template/internal/shared/notifications"
 
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
 )
 
 func main() {
@@ -29,6 +37,12 @@ func main() {
 	if err != nil {
 		log.Fatal("Failed to load configuration:", err)
 	}
+	if problems := cfg.Validate(); problems != nil {
+		for _, problem := range problems.Errors {
+			log.Println("Invalid configuration:", problem.Message)
+		}
+		log.Fatalf("Failed to validate configuration: %d problem(s) found", len(problems.Errors))
+	}
 
 	// Create application
 	app, err := NewApp(cfg)
@@ -49,6 +63,16 @@ func main() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
+	// Re-read the log level on SIGHUP, so it can be raised to debug without
+	// a restart
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			app.ReloadLogLevel()
+		}
+	}()
+
 	log.Printf("Server started on %s:%s", cfg.Server.Host, cfg.Server.Port)
 	log.Println("Press Ctrl+C to shutdown")
 
@@ -77,8 +101,15 @@ type App struct {
 	router         *echo.Echo
 	server         *http.Server
 	dbManager      *database.Manager
+	migrations     database.MigrationSummarizer
 	eventBus       events.EventBus
 	moduleRegistry *shared.ModuleRegistry
+	metrics        *metrics.Registry
+	health         *shared.HealthAggregator
+	coreHealth     *shared.HealthAggregator
+	readiness      *shared.ReadinessState
+	notifications  *notifications.Hub
+	logger         *appErrors.StructuredLogger
 }
 
 // NewApp creates a new application instance with all dependencies
@@ -95,17 +126,77 @@ func NewApp(cfg *config.Config) (*App, error) {
 	if cfg.Server.Env == "development" {
 		errorConfig.ShowStackTrace = true
 	}
+	errorConfig.Format = errorMiddleware.ErrorFormat(cfg.Server.ErrorFormat)
+
+	// Create the structured logger; its level can be changed at runtime
+	loggingConfig := appErrors.DefaultLoggingConfig()
+	if cfg.Log.Level != "" {
+		loggingConfig.Level = cfg.Log.Level
+	}
+	if cfg.Server.Env != "" {
+		loggingConfig.Environment = cfg.Server.Env
+	}
+	logger, err := appErrors.NewStructuredLogger(loggingConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	// Collect metrics when enabled; HTTP metrics are recorded before errors
+	// are rendered so failed requests count with their response status
+	var metricsRegistry *metrics.Registry
+	var eventObserver events.EventBusObserver
+	if cfg.Metrics.Enabled {
+		metricsRegistry = metrics.NewRegistry()
+		eventObserver = metrics.NewEventMetrics(metricsRegistry)
+	}
 
 	// Add middleware
-	router.Use(middleware.Logger())
+	router.Use(errorMiddleware.RequestMetadata)
+	router.Use(errorMiddleware.Tracing)
+	router.Use(errorMiddleware.Logging(logger))
+	if metricsRegistry != nil {
+		router.Use(metrics.NewHTTPMetrics(metricsRegistry).Middleware)
+	}
+	accessLogConfig := errorMiddleware.DefaultAccessLogConfig()
+	accessLogConfig.SuccessSampleEvery = cfg.Log.AccessSampleEvery
+	router.Use(errorMiddleware.AccessLog(logger, accessLogConfig))
+	router.Use(errorMiddleware.CompressionMiddleware(errorMiddleware.DefaultCompressionConfig()))
+	router.Use(errorMiddleware.ETagMiddleware(errorMiddleware.DefaultETagMaxBytes))
 	router.Use(errorMiddleware.RecoveryHandler(errorConfig))
 	router.Use(errorMiddleware.ErrorHandler(errorConfig))
-	router.Use(middleware.CORS())
+	router.Use(errorMiddleware.BodyLimitMiddleware(cfg.Server.MaxBodyBytes))
+	router.Use(errorMiddleware.TimeoutMiddleware(time.Duration(cfg.Server.RequestTimeoutSeconds) * time.Second))
+	corsConfig := errorMiddleware.DefaultCORSConfig()
+	corsConfig.AllowedOrigins = cfg.CORS.AllowedOrigins
+	if len(cfg.CORS.AllowedMethods) > 0 {
+		corsConfig.AllowedMethods = cfg.CORS.AllowedMethods
+	}
+	if len(cfg.CORS.AllowedHeaders) > 0 {
+		corsConfig.AllowedHeaders = cfg.CORS.AllowedHeaders
+	}
+	corsConfig.AllowCredentials = cfg.CORS.AllowCredentials
+	corsConfig.MaxAge = cfg.CORS.MaxAgeSeconds
+	router.Use(errorMiddleware.CORSMiddleware(corsConfig))
+	securityHeadersConfig := errorMiddleware.DefaultSecurityHeadersConfig()
+	if cfg.Server.ContentSecurityPolicy != "" {
+		securityHeadersConfig.ContentSecurityPolicy = cfg.Server.ContentSecurityPolicy
+	}
+	router.Use(errorMiddleware.SecurityHeadersMiddleware(securityHeadersConfig))
+	if cfg.Server.Secret != "" {
+		flash.SetSigningSecret([]byte(cfg.Server.Secret))
+	}
+	router.Use(flash.Middleware)
 
 	// Set up error page routing
 	errorRouter := handlers.NewErrorPageRouter()
 	errorRouter.RegisterRoutes(router)
 
+	// Serve avatars when they are stored locally. Other blobs, such as data
+	// exports, are private and only served through authenticated endpoints.
+	if cfg.Storage.Driver == "" || cfg.Storage.Driver == "local" {
+		router.Static(strings.TrimSuffix(cfg.Storage.LocalURL, "/")+"/avatars", filepath.Join(cfg.Storage.LocalDir, "avatars"))
+	}
+
 	// Create database manager
 	migrationsPath := "migrations"
 	// If running from cmd/server directory, adjust path
@@ -117,29 +208,22 @@ func NewApp(cfg *config.Config) (*App, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create database manager: %w", err)
 	}
+	dbManager.DB.SetQueryLogger(logger)
 
-	// Create event bus
-	eventBusConfig := events.RabbitMQConfig{
-		URL:          cfg.RabbitMQ.URL,
-		Exchange:     cfg.RabbitMQ.Exchange,
-		ExchangeType: "topic",
-		QueuePrefix:  cfg.RabbitMQ.QueuePrefix,
-		Durable:      cfg.RabbitMQ.Durable,
-		AutoDelete:   false,
-		Exclusive:    false,
-		NoWait:       false,
+	if metricsRegistry != nil {
+		metricsRegistry.Register(metrics.NewDBStatsCollector(dbManager.DB.Stats))
 	}
 
-	// Use default URL if not provided
-	if eventBusConfig.URL == "" {
-		eventBusConfig.URL = fmt.Sprintf("amqp://%s:%s@%s:%s/",
-			cfg.RabbitMQ.User, cfg.RabbitMQ.Password, cfg.RabbitMQ.Host, cfg.RabbitMQ.Port)
+	// Create event bus
+	eventBus, err := newEventBus(cfg, eventObserver)
+	if err != nil {
+		return nil, err
 	}
-
-	eventBus := events.NewRabbitMQEventBus(eventBusConfig)
+	eventBus = wrapEventBus(cfg, eventBus, dbManager.DB, eventObserver)
 
 	// Create module registry
-	moduleRegistry := shared.NewModuleRegistry(eventBus, dbManager.DB, cfg, router)
+	moduleRegistry := shared.NewModuleRegistry(eventBus, dbManager.DB, cfg, router).
+		WithAPIPrefix(cfg.Server.APIPrefix)
 
 	// Register modules
 	userModule := user.NewUserModule()
@@ -161,14 +245,112 @@ func NewApp(cfg *config.Config) (*App, error) {
 		IdleTimeout:  60 * time.Second,
 	}
 
-	return &App{
+	app := &App{
 		config:         cfg,
 		router:         router,
 		server:         server,
 		dbManager:      dbManager,
+		migrations:     dbManager.Migrations(),
 		eventBus:       eventBus,
 		moduleRegistry: moduleRegistry,
-	}, nil
+		metrics:        metricsRegistry,
+		readiness:      shared.NewReadinessState(),
+		notifications:  notifications.NewHub(),
+		logger:         logger,
+	}
+
+	if err := app.registerHealthChecks(); err != nil {
+		return nil, fmt.Errorf("failed to register health checks: %w", err)
+	}
+
+	return app, nil
+}
+
+// ReloadLogLevel re-reads the configuration and applies its log level. The
+// previous level is kept if the configuration can't be loaded.
+func (a *App) ReloadLogLevel() {
+	cfg, err := config.Load()
+	if err != nil {
+		a.logger.WithError(err).Error("Failed to reload configuration")
+		return
+	}
+
+	previous := a.logger.Level()
+	if err := a.logger.SetLevel(cfg.Log.Level); err != nil {
+		a.logger.WithError(err).Error("Failed to change log level")
+		return
+	}
+	a.logger.WithFields(map[string]interface{}{
+		"previous_level": previous,
+		"level":          a.logger.Level(),
+	}).Warn("Log level reloaded")
+}
+
+// newEventBus creates the event bus selected by the EVENT_BUS setting,
+// reporting to observer if it is not nil
+func newEventBus(cfg *config.Config, observer events.EventBusObserver) (events.EventBus, error) {
+	switch cfg.EventBus.Driver {
+	case "memory":
+		return events.NewInMemoryEventBus().WithObserver(observer), nil
+	case "rabbitmq", "":
+	default:
+		return nil, fmt.Errorf("unknown event bus driver: %s", cfg.EventBus.Driver)
+	}
+
+	eventBusConfig := events.RabbitMQConfig{
+		URL:          cfg.RabbitMQ.URL,
+		Exchange:     cfg.RabbitMQ.Exchange,
+		ExchangeType: "topic",
+		QueuePrefix:  cfg.RabbitMQ.QueuePrefix,
+		Durable:      cfg.RabbitMQ.Durable,
+		AutoDelete:   false,
+		Exclusive:    false,
+		NoWait:       false,
+
+		DeadLetterExchange:  cfg.RabbitMQ.DeadLetterExchange,
+		MaxDeliveryAttempts: cfg.RabbitMQ.MaxDeliveryAttempts,
+
+		ConsumerConcurrency: cfg.RabbitMQ.ConsumerConcurrency,
+		Concurrency:         cfg.RabbitMQ.Concurrency,
+
+		Compression:          cfg.RabbitMQ.Compression,
+		CompressionThreshold: cfg.RabbitMQ.CompressionThreshold,
+
+		Observer: observer,
+	}
+
+	if cfg.RabbitMQ.Codec != "" {
+		codec, err := events.CodecByName(cfg.RabbitMQ.Codec)
+		if err != nil {
+			return nil, err
+		}
+		eventBusConfig.Codec = codec
+	}
+
+	// Use default URL if not provided
+	if eventBusConfig.URL == "" {
+		eventBusConfig.URL = fmt.Sprintf("amqp://%s:%s@%s:%s/",
+			cfg.RabbitMQ.User, cfg.RabbitMQ.Password, cfg.RabbitMQ.Host, cfg.RabbitMQ.Port)
+	}
+
+	return events.NewRabbitMQEventBus(eventBusConfig), nil
+}
+
+// wrapEventBus adds the outbox and degraded mode to bus when they are
+// enabled
+func wrapEventBus(cfg *config.Config, bus events.EventBus, db *database.DB, observer events.EventBusObserver) events.EventBus {
+	// Publish events raised inside transactions only after they commit
+	if cfg.EventBus.Outbox {
+		bus = events.NewOutboxEventBus(bus, db, events.DefaultOutboxConfig())
+	}
+
+	// Keep serving while the broker is down, buffering events in the outbox
+	// if it is enabled
+	if cfg.EventBus.Degraded {
+		bus = events.NewDegradedEventBus(bus, events.DefaultDegradedConfig(), observer)
+	}
+
+	return bus
 }
 
 // Start initializes and starts the application
@@ -182,8 +364,8 @@ func (a *App) Start(ctx context.Context) error {
 	}
 
 	// Start event bus
-	if err := a.eventBus.Start(ctx); err != nil {
-		return fmt.Errorf("failed to start event bus: %w", err)
+	if err := a.startEventBus(ctx); err != nil {
+		return err
 	}
 
 	// Start modules (initialize, register routes, register event handlers)
@@ -194,6 +376,14 @@ func (a *App) Start(ctx context.Context) error {
 	// Register health check endpoints
 	a.registerHealthEndpoints()
 
+	// Register admin endpoints
+	a.registerAdminEndpoints()
+
+	// Register real-time notification endpoints
+	if err := a.registerNotificationEndpoints(); err != nil {
+		return fmt.Errorf("failed to register notification endpoints: %w", err)
+	}
+
 	// Start HTTP server in a goroutine
 	go func() {
 		log.Printf("HTTP server listening on %s", a.server.Addr)
@@ -206,12 +396,28 @@ func (a *App) Start(ctx context.Context) error {
 	return nil
 }
 
+// startEventBus starts the event bus. In degraded mode this succeeds while
+// the broker is unavailable and the event bus health check reports degraded.
+func (a *App) startEventBus(ctx context.Context) error {
+	if err := a.eventBus.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start event bus: %w", err)
+	}
+	return nil
+}
+
 // Shutdown gracefully shuts down the application
 func (a *App) Shutdown(ctx context.Context) error {
 	log.Println("Shutting down application...")
 
 	var lastErr error
 
+	// Fail readiness probes first, so load balancers stop routing here
+	a.drain(ctx)
+
+	// End notification streams, which would otherwise keep the HTTP server
+	// from shutting down, and WebSocket connections, which it doesn't track
+	a.notifications.Close()
+
 	// Shutdown HTTP server
 	log.Println("Shutting down HTTP server...")
 	if err := a.server.Shutdown(ctx); err != nil {
@@ -249,6 +455,27 @@ func (a *App) Shutdown(ctx context.Context) error {
 	return lastErr
 }
 
+// drain marks the application as not ready and waits for the configured
+// grace period, or until ctx is done, while the server keeps serving
+// requests already routed here
+func (a *App) drain(ctx context.Context) {
+	a.readiness.Drain()
+
+	grace := time.Duration(a.config.Server.ShutdownGraceSeconds) * time.Second
+	if grace <= 0 {
+		return
+	}
+
+	log.Printf("Draining connections for %v...", grace)
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
 // registerHealthEndpoints registers health check and monitoring endpoints
 func (a *App) registerHealthEndpoints() {
 	// Health check endpoint
@@ -263,33 +490,96 @@ func (a *App) registerHealthEndpoints() {
 	// Liveness probe endpoint
 	a.router.GET("/live", a.livenessHandler)
 
+	// Prometheus metrics endpoint
+	if a.metrics != nil {
+		a.router.GET("/metrics", a.metrics.Handler())
+	}
+
 	log.Println("Health check endpoints registered:")
 	log.Println("  GET /health - Basic health check")
 	log.Println("  GET /health/detailed - Detailed health status")
 	log.Println("  GET /ready - Readiness probe")
 	log.Println("  GET /live - Liveness probe")
+	if a.metrics != nil {
+		log.Println("  GET /metrics - Prometheus metrics")
+	}
 }
 
-// healthHandler provides a basic health check endpoint
-func (a *App) healthHandler(c echo.Context) error {
-	ctx := c.Request().Context()
+// registerAdminEndpoints registers operational endpoints restricted to
+// admins. They are only registered when the auth module is available to
+// authenticate them.
+func (a *App) registerAdminEndpoints() {
+	module, ok := a.moduleRegistry.GetModule("auth")
+	authModule, isAuth := module.(*auth.AuthModule)
+	if !ok || !isAuth || authModule.GetAuthService() == nil {
+		log.Println("Auth module unavailable, admin endpoints not registered")
+		return
+	}
+
+	authMiddleware := authHandlers.GetAuthMiddleware(authModule.GetAuthService())
+	admin := a.router.Group("/admin", authMiddleware.RequireAuth, errorMiddleware.RequireRole(string(userDomain.UserRoleAdmin)))
 
-	// Check database health
-	dbHealth := a.dbManager.GetHealthStatus(ctx)
+	// Event handler subscriptions
+	admin.GET("/events/handlers", a.eventHandlersHandler)
 
-	// Check event bus health
-	eventBusHealthy := a.eventBus.Health() == nil
+	log.Println("Admin endpoints registered:")
+	log.Println("  GET /admin/events/handlers - Event handler subscriptions")
+}
+
+// registerNotificationEndpoints subscribes the notification hub to the
+// event bus and registers the WebSocket and Server-Sent Events endpoints
+// pushing events to signed-in users. Like the admin endpoints, it needs the auth module to authenticate
+// connections.
+func (a *App) registerNotificationEndpoints() error {
+	module, ok := a.moduleRegistry.GetModule("auth")
+	authModule, isAuth := module.(*auth.AuthModule)
+	if !ok || !isAuth || authModule.GetAuthService() == nil {
+		log.Println("Auth module unavailable, notification endpoints not registered")
+		return nil
+	}
+
+	if err := a.eventBus.Subscribe(a.notifications.EventType(), a.notifications); err != nil {
+		return err
+	}
 
-	// Determine overall health
-	healthy := dbHealth.Status == "healthy" && eventBusHealthy
+	authMiddleware := authHandlers.GetAuthMiddleware(authModule.GetAuthService())
+	config := notifications.DefaultConfig()
+	a.router.GET("/ws/notifications", notifications.Handler(a.notifications, config), authMiddleware.RequireAuth)
+	a.router.GET("/sse/notifications", notifications.SSEHandler(a.notifications, config), authMiddleware.RequireAuth)
 
-	status := "healthy"
-	if !healthy {
-		status = "unhealthy"
+	log.Println("Notification endpoints registered:")
+	log.Println("  GET /ws/notifications - WebSocket feed of the signed-in user's events")
+	log.Println("  GET /sse/notifications - Server-Sent Events feed of the same events")
+	return nil
+}
+
+// eventHandlersHandler lists the names of the handlers subscribed to each
+// event type
+func (a *App) eventHandlersHandler(c echo.Context) error {
+	lister, ok := a.eventBus.(events.SubscriptionLister)
+	if !ok {
+		return appErrors.NewInternalError("SUBSCRIPTIONS_UNAVAILABLE", "Event bus does not report its subscriptions")
 	}
 
+	subscriptions := lister.Subscriptions()
+	if subscriptions == nil {
+		subscriptions = map[string][]string{}
+	}
+	return c.JSON(http.StatusOK, subscriptions)
+}
+
+// healthHandler provides a basic health check endpoint
+func (a *App) healthHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// Check the database and event bus, reusing a recent check so frequent
+	// probes don't hammer them. A degraded event bus still lets requests be
+	// served, so it reports a degraded status rather than failing the probe.
+	report := a.coreHealth.Check(ctx)
+	healthy := report.Serving()
+
 	response := map[string]interface{}{
-		"status":    status,
+		"status":    report.Status,
 		"timestamp": time.Now().UTC(),
 		"version":   "1.0.0", // You might want to get this from build info
 	}
@@ -301,67 +591,120 @@ func (a *App) healthHandler(c echo.Context) error {
 	}
 }
 
-// detailedHealthHandler provides detailed health information
-func (a *App) detailedHealthHandler(c echo.Context) error {
-	ctx := c.Request().Context()
+// coreTableProbes are the queries the detailed health check runs to verify
+// that critical tables are reachable, not just the connection
+var coreTableProbes = map[string]string{
+	"users":    "SELECT 1 FROM users LIMIT 1",
+	"sessions": "SELECT 1 FROM sessions LIMIT 1",
+}
+
+// Timeouts for the component checks of the detailed health endpoint
+const (
+	healthCheckTimeout         = 5 * time.Second
+	eventBusHealthCheckTimeout = 2 * time.Second
+	moduleHealthCheckTimeout   = 2 * time.Second
+)
 
-	// Check database health
-	dbHealth := a.dbManager.GetHealthStatus(ctx)
+// registerHealthChecks registers the component checks run by the health
+// endpoints: the database and event bus for the health and readiness probes,
+// and every component for the detailed health endpoint. Results are cached
+// for the configured time so frequent probes reuse a recent check.
+func (a *App) registerHealthChecks() error {
+	cacheTTL := time.Duration(a.config.Server.HealthCacheMilliseconds) * time.Millisecond
 
-	// Check event bus health
-	eventBusErr := a.eventBus.Health()
-	eventBusHealth := map[string]interface{}{
-		"status": "healthy",
+	a.coreHealth = shared.NewHealthAggregator(healthCheckTimeout)
+	a.coreHealth.SetCacheTTL(cacheTTL)
+	if err := a.coreHealth.Register("database", 0, a.checkDatabase); err != nil {
+		return err
 	}
-	if eventBusErr != nil {
-		eventBusHealth["status"] = "unhealthy"
-		eventBusHealth["error"] = eventBusErr.Error()
+	if err := a.coreHealth.Register("eventbus", eventBusHealthCheckTimeout, a.checkEventBus); err != nil {
+		return err
 	}
 
-	// Check module health
-	moduleHealth := a.moduleRegistry.Health(ctx)
+	a.health = shared.NewHealthAggregator(healthCheckTimeout)
+	a.health.SetCacheTTL(cacheTTL)
 
-	// Determine overall health
-	overallHealthy := dbHealth.Status == "healthy" && eventBusErr == nil
-	for _, err := range moduleHealth {
-		if err != nil {
-			overallHealthy = false
-			break
+	if err := a.health.Register("database", 0, a.checkDatabase); err != nil {
+		return err
+	}
+	for table, query := range coreTableProbes {
+		if err := a.health.Register("table."+table, 0, a.checkQuery(query)); err != nil {
+			return err
 		}
 	}
+	if err := a.health.Register("migrations", 0, func(ctx context.Context) error {
+		_, err := a.checkMigrations()
+		return err
+	}); err != nil {
+		return err
+	}
+	if err := a.health.Register("eventbus", eventBusHealthCheckTimeout, a.checkEventBus); err != nil {
+		return err
+	}
 
-	status := "healthy"
-	if !overallHealthy {
-		status = "unhealthy"
+	return a.moduleRegistry.RegisterHealthChecks(a.health, moduleHealthCheckTimeout)
+}
+
+// checkDatabase reports whether the database is responding
+func (a *App) checkDatabase(ctx context.Context) error {
+	status := a.dbManager.GetHealthStatus(ctx)
+	if status.Status != "healthy" {
+		return errors.New(status.Message)
 	}
+	return nil
+}
 
-	// Convert module health to response format
-	moduleHealthResponse := make(map[string]interface{})
-	for moduleName, err := range moduleHealth {
-		if err != nil {
-			moduleHealthResponse[moduleName] = map[string]interface{}{
-				"status": "unhealthy",
-				"error":  err.Error(),
-			}
-		} else {
-			moduleHealthResponse[moduleName] = map[string]interface{}{
-				"status": "healthy",
-			}
+// checkEventBus reports whether the event bus is connected, and whether it
+// is degraded because it has yet to connect
+func (a *App) checkEventBus(ctx context.Context) error {
+	err := a.eventBus.Health()
+	if errors.Is(err, events.ErrEventBusDegraded) {
+		return fmt.Errorf("%w: %w", shared.ErrHealthDegraded, err)
+	}
+	return err
+}
+
+// checkMigrations returns the database's migration summary, and an error
+// if it is dirty or behind the migrations the application was built with
+func (a *App) checkMigrations() (*database.MigrationSummary, error) {
+	if a.migrations == nil {
+		return nil, nil
+	}
+
+	summary, err := a.migrations.Summary()
+	if err != nil {
+		return nil, err
+	}
+	return summary, summary.Err()
+}
+
+// checkQuery returns a check that runs query against the database
+func (a *App) checkQuery(query string) shared.HealthCheckFunc {
+	return func(ctx context.Context) error {
+		status := a.dbManager.HealthChecker.CheckWithQuery(ctx, query)
+		if status.Status != "healthy" {
+			return errors.New(status.Message)
 		}
+		return nil
 	}
+}
+
+// detailedHealthHandler provides detailed health information, checking each
+// component concurrently within its own timeout
+func (a *App) detailedHealthHandler(c echo.Context) error {
+	report := a.health.Check(c.Request().Context())
 
 	response := map[string]interface{}{
-		"status":    status,
-		"timestamp": time.Now().UTC(),
-		"version":   "1.0.0",
-		"components": map[string]interface{}{
-			"database": dbHealth,
-			"eventbus": eventBusHealth,
-			"modules":  moduleHealthResponse,
-		},
+		"status":     report.Status,
+		"timestamp":  time.Now().UTC(),
+		"version":    "1.0.0",
+		"components": report.Checks,
+	}
+	if migrations, _ := a.checkMigrations(); migrations != nil {
+		response["migrations"] = migrations
 	}
 
-	if overallHealthy {
+	if report.Serving() {
 		return c.JSON(http.StatusOK, response)
 	} else {
 		return c.JSON(http.StatusServiceUnavailable, response)
@@ -370,18 +713,35 @@ func (a *App) detailedHealthHandler(c echo.Context) error {
 
 // readinessHandler indicates if the application is ready to serve traffic
 func (a *App) readinessHandler(c echo.Context) error {
+	if a.readiness.Draining() {
+		return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{
+			"ready":     false,
+			"draining":  true,
+			"timestamp": time.Now().UTC(),
+		})
+	}
+
 	ctx := c.Request().Context()
 
 	// Check if all critical components are ready
-	dbHealth := a.dbManager.GetHealthStatus(ctx)
-	eventBusHealthy := a.eventBus.Health() == nil
+	report := a.coreHealth.Check(ctx)
+
+	// A dirty database or pending migrations mean the schema may not match
+	// what this build expects
+	migrations, migrationsErr := a.checkMigrations()
 
-	ready := dbHealth.Status == "healthy" && eventBusHealthy
+	ready := report.Serving() && migrationsErr == nil
 
 	response := map[string]interface{}{
 		"ready":     ready,
 		"timestamp": time.Now().UTC(),
 	}
+	if migrations != nil {
+		response["migrations"] = migrations
+	}
+	if migrationsErr != nil {
+		response["migrations_error"] = migrationsErr.Error()
+	}
 
 	if ready {
 		return c.JSON(http.StatusOK, response)
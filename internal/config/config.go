@@ -1,84 +1,375 @@
 package config
 
 import (
+	"fmt"
 	"os"
-	"strconv"
 )
 
+// Config is the application configuration. Load layers it, each layer
+// overriding the one before:
+//
+//  1. the defaults from Default
+//  2. the YAML file named by APP_CONFIG_FILE, if set
+//  3. environment variables
+//
+// Every setting can be set with the variable APP_<SECTION>_<FIELD>, named
+// after its keys in the config file, e.g. APP_DATABASE_HOST or
+// APP_SERVER_MAX_BODY_BYTES. Settings also keep their original variable
+// names, such as DB_HOST, given by the env tags below; when both are set
+// the APP_ variable wins.
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	RabbitMQ RabbitMQConfig
+	Server   ServerConfig   `yaml:"server"`
+	Database DatabaseConfig `yaml:"database"`
+	RabbitMQ RabbitMQConfig `yaml:"rabbitmq"`
+	EventBus EventBusConfig `yaml:"event_bus"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Email    EmailConfig    `yaml:"email"`
+	Storage  StorageConfig  `yaml:"storage"`
+	Metrics  MetricsConfig  `yaml:"metrics"`
+	Log      LogConfig      `yaml:"log"`
+	CORS     CORSConfig     `yaml:"cors"`
 }
 
 type ServerConfig struct {
-	Port string
-	Host string
-	Env  string
+	Port string `yaml:"port" env:"SERVER_PORT"`
+	Host string `yaml:"host" env:"SERVER_HOST"`
+	Env  string `yaml:"env" env:"ENVIRONMENT"`
+
+	// MaxBodyBytes is the largest request body accepted, in bytes
+	MaxBodyBytes int64 `yaml:"max_body_bytes" env:"SERVER_MAX_BODY_BYTES"`
+
+	// APIPrefix is the path module API routes are served under, e.g. /api/v1
+	APIPrefix string `yaml:"api_prefix" env:"API_PREFIX"`
+
+	// ShutdownGraceSeconds is how long readiness probes fail on shutdown
+	// before the server stops accepting requests, so load balancers can
+	// deregister the instance; zero shuts down immediately
+	ShutdownGraceSeconds int `yaml:"shutdown_grace_seconds" env:"SERVER_SHUTDOWN_GRACE_SECONDS"`
+
+	// RequestTimeoutSeconds is how long a request may take before it fails
+	// with a timeout error; zero disables the limit
+	RequestTimeoutSeconds int `yaml:"request_timeout_seconds" env:"SERVER_REQUEST_TIMEOUT_SECONDS"`
+
+	// HealthCacheMilliseconds is how long health and readiness probes reuse
+	// a recent check of the dependencies instead of checking them again;
+	// zero checks on every probe
+	HealthCacheMilliseconds int `yaml:"health_cache_milliseconds" env:"SERVER_HEALTH_CACHE_MILLISECONDS"`
+
+	// ContentSecurityPolicy is the Content-Security-Policy header sent with
+	// responses; empty uses a policy allowing only the app's own resources
+	ContentSecurityPolicy string `yaml:"content_security_policy" env:"SERVER_CONTENT_SECURITY_POLICY"`
+
+	// ErrorFormat is the format API errors are rendered in: empty for the
+	// default format, "jsonapi" for JSON:API errors documents or "problem"
+	// for RFC 7807 Problem Details
+	ErrorFormat string `yaml:"error_format" env:"SERVER_ERROR_FORMAT"`
+
+	// Secret signs cookies the application reads back, such as flash
+	// messages. Every instance must share it; empty, outside production,
+	// uses a random key per process.
+	Secret string `yaml:"secret" env:"SERVER_SECRET"`
 }
 
 type DatabaseConfig struct {
-	URL      string
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Name     string
-	SSLMode  string
+	URL      string `yaml:"url" env:"DATABASE_URL"`
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     string `yaml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASSWORD"`
+	Name     string `yaml:"name" env:"DB_NAME"`
+	SSLMode  string `yaml:"ssl_mode" env:"DB_SSLMODE"`
+
+	// Connection pool tuning; zero or negative values use the defaults
+	MaxOpenConns           int `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns           int `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetimeSeconds int `yaml:"conn_max_lifetime_seconds" env:"DB_CONN_MAX_LIFETIME_SECONDS"`
+	ConnMaxIdleTimeSeconds int `yaml:"conn_max_idle_time_seconds" env:"DB_CONN_MAX_IDLE_TIME_SECONDS"`
+
+	// SlowQueryMilliseconds is how long a timed query may take before it is
+	// logged as slow; zero disables slow query logging
+	SlowQueryMilliseconds int `yaml:"slow_query_milliseconds" env:"DB_SLOW_QUERY_MILLISECONDS"`
 }
 
 type RabbitMQConfig struct {
-	URL         string
-	Host        string
-	Port        string
-	User        string
-	Password    string
-	Exchange    string
-	QueuePrefix string
-	Durable     bool
+	URL         string `yaml:"url" env:"RABBITMQ_URL"`
+	Host        string `yaml:"host" env:"RABBITMQ_HOST"`
+	Port        string `yaml:"port" env:"RABBITMQ_PORT"`
+	User        string `yaml:"user" env:"RABBITMQ_USER"`
+	Password    string `yaml:"password" env:"RABBITMQ_PASSWORD"`
+	Exchange    string `yaml:"exchange" env:"RABBITMQ_EXCHANGE"`
+	QueuePrefix string `yaml:"queue_prefix" env:"RABBITMQ_QUEUE_PREFIX"`
+	Durable     bool   `yaml:"durable" env:"RABBITMQ_DURABLE"`
+
+	DeadLetterExchange  string `yaml:"dead_letter_exchange" env:"RABBITMQ_DEAD_LETTER_EXCHANGE"`
+	MaxDeliveryAttempts int    `yaml:"max_delivery_attempts" env:"RABBITMQ_MAX_DELIVERY_ATTEMPTS"`
+
+	// ConsumerConcurrency is the number of deliveries of each event type
+	// handled at once
+	ConsumerConcurrency int `yaml:"consumer_concurrency" env:"RABBITMQ_CONSUMER_CONCURRENCY"`
+
+	// Concurrency overrides ConsumerConcurrency for individual event types.
+	// In environment variables it is written as a list of type=count pairs,
+	// e.g. "user.export_requested=4,user.created=1".
+	Concurrency map[string]int `yaml:"concurrency" env:"RABBITMQ_CONCURRENCY"`
+
+	// Codec serializes published events: "json" (the default) or "gob"
+	Codec string `yaml:"codec" env:"RABBITMQ_CODEC"`
+
+	// Compression gzips published events larger than CompressionThreshold
+	// bytes; zero uses the event bus default
+	Compression          bool `yaml:"compression" env:"RABBITMQ_COMPRESSION"`
+	CompressionThreshold int  `yaml:"compression_threshold" env:"RABBITMQ_COMPRESSION_THRESHOLD"`
 }
 
-func Load() (*Config, error) {
+type EventBusConfig struct {
+	// Driver selects the event bus implementation: "rabbitmq" or "memory"
+	Driver string `yaml:"driver" env:"EVENT_BUS"`
+
+	// Outbox routes events published inside database transactions through
+	// the event_outbox table
+	Outbox bool `yaml:"outbox" env:"EVENT_BUS_OUTBOX"`
+
+	// Degraded lets the application start while the broker is unavailable,
+	// retrying it in the background and reporting degraded health meanwhile
+	Degraded bool `yaml:"degraded" env:"EVENT_BUS_DEGRADED"`
+}
+
+type AuthConfig struct {
+	// LoginMaxFailedAttempts is the number of failed logins allowed per email
+	// and IP address before logins are refused; zero disables the lockout
+	LoginMaxFailedAttempts int `yaml:"login_max_failed_attempts" env:"AUTH_LOGIN_MAX_FAILED_ATTEMPTS"`
+
+	// LoginLockoutMinutes is how long failed logins are counted and a lockout lasts
+	LoginLockoutMinutes int `yaml:"login_lockout_minutes" env:"AUTH_LOGIN_LOCKOUT_MINUTES"`
+
+	// AccountLockMaxFailedAttempts is the number of consecutive failed logins
+	// after which a user's account is locked until unlocked; zero disables it
+	AccountLockMaxFailedAttempts int `yaml:"account_lock_max_failed_attempts" env:"AUTH_ACCOUNT_LOCK_MAX_FAILED_ATTEMPTS"`
+
+	// VerificationTokenHours is how long email verification tokens stay valid
+	VerificationTokenHours int `yaml:"verification_token_hours" env:"AUTH_VERIFICATION_TOKEN_HOURS"`
+
+	// PasswordResetTokenMinutes is how long password reset tokens stay valid
+	PasswordResetTokenMinutes int `yaml:"password_reset_token_minutes" env:"AUTH_PASSWORD_RESET_TOKEN_MINUTES"`
+
+	// ReauthWindowMinutes is how recently a user must have signed in to
+	// perform sensitive actions such as deleting their account
+	ReauthWindowMinutes int `yaml:"reauth_window_minutes" env:"AUTH_REAUTH_WINDOW_MINUTES"`
+
+	// PasswordMinLength overrides the minimum password length when positive
+	PasswordMinLength int `yaml:"password_min_length" env:"AUTH_PASSWORD_MIN_LENGTH"`
+
+	// PasswordRequireSpecial requires passwords to contain a special character
+	PasswordRequireSpecial bool `yaml:"password_require_special" env:"AUTH_PASSWORD_REQUIRE_SPECIAL"`
+
+	// PasswordHashAlgorithm selects how new passwords are hashed: "bcrypt" or
+	// "argon2id". Hashes from either algorithm are always accepted.
+	PasswordHashAlgorithm string `yaml:"password_hash_algorithm" env:"AUTH_PASSWORD_HASH_ALGORITHM"`
+
+	// NormalizeGmailAliases stores and looks up Gmail addresses without
+	// dots or +tags, so aliases of one mailbox can't register twice. Users
+	// stored before it was enabled are still found by any alias.
+	NormalizeGmailAliases bool `yaml:"normalize_gmail_aliases" env:"AUTH_NORMALIZE_GMAIL_ALIASES"`
+
+	// JWTSecret enables bearer token authentication alongside sessions:
+	// logins also issue an access and refresh token signed with it. Empty
+	// disables tokens.
+	JWTSecret string `yaml:"jwt_secret" env:"AUTH_JWT_SECRET"`
+
+	// JWTAccessTokenMinutes is how long access tokens are accepted
+	JWTAccessTokenMinutes int `yaml:"jwt_access_token_minutes" env:"AUTH_JWT_ACCESS_TOKEN_MINUTES"`
+
+	// JWTRefreshTokenHours is how long refresh tokens can be exchanged for
+	// new tokens
+	JWTRefreshTokenHours int `yaml:"jwt_refresh_token_hours" env:"AUTH_JWT_REFRESH_TOKEN_HOURS"`
+
+	// SessionCookiePath and SessionCookieDomain scope the session cookie; an
+	// empty domain limits it to the host that set it
+	SessionCookiePath   string `yaml:"session_cookie_path" env:"AUTH_SESSION_COOKIE_PATH"`
+	SessionCookieDomain string `yaml:"session_cookie_domain" env:"AUTH_SESSION_COOKIE_DOMAIN"`
+
+	// SessionCookieSameSite is the session cookie's SameSite attribute:
+	// "strict", "lax" or "none"
+	SessionCookieSameSite string `yaml:"session_cookie_same_site" env:"AUTH_SESSION_COOKIE_SAME_SITE"`
+
+	// SessionCookieSecure always marks the session cookie Secure, e.g. behind
+	// a proxy terminating TLS. Otherwise it is Secure on HTTPS requests only.
+	SessionCookieSecure bool `yaml:"session_cookie_secure" env:"AUTH_SESSION_COOKIE_SECURE"`
+}
+
+type EmailConfig struct {
+	// Driver selects the email sender: "log" writes emails to the log and
+	// "smtp" delivers them
+	Driver string `yaml:"driver" env:"EMAIL_DRIVER"`
+
+	// From is the sender address of outgoing emails
+	From string `yaml:"from" env:"EMAIL_FROM"`
+
+	// BaseURL is the public URL of the application, used for links in emails
+	BaseURL string `yaml:"base_url" env:"APP_BASE_URL"`
+
+	SMTPHost     string `yaml:"smtp_host" env:"SMTP_HOST"`
+	SMTPPort     string `yaml:"smtp_port" env:"SMTP_PORT"`
+	SMTPUser     string `yaml:"smtp_user" env:"SMTP_USER"`
+	SMTPPassword string `yaml:"smtp_password" env:"SMTP_PASSWORD"`
+}
+
+type StorageConfig struct {
+	// Driver selects where uploaded files are stored: "local" writes them
+	// under LocalDir and "s3" uploads them to an S3-compatible bucket
+	Driver string `yaml:"driver" env:"STORAGE_DRIVER"`
+
+	// LocalDir is the directory uploaded files are stored in
+	LocalDir string `yaml:"local_dir" env:"STORAGE_LOCAL_DIR"`
+
+	// LocalURL is the URL path uploaded files are served from
+	LocalURL string `yaml:"local_url" env:"STORAGE_LOCAL_URL"`
+
+	S3Endpoint        string `yaml:"s3_endpoint" env:"S3_ENDPOINT"`
+	S3Region          string `yaml:"s3_region" env:"S3_REGION"`
+	S3Bucket          string `yaml:"s3_bucket" env:"S3_BUCKET"`
+	S3AccessKeyID     string `yaml:"s3_access_key_id" env:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `yaml:"s3_secret_access_key" env:"S3_SECRET_ACCESS_KEY"`
+
+	// S3PublicURL is the base URL objects are served from, e.g. a CDN;
+	// empty serves them from the bucket
+	S3PublicURL string `yaml:"s3_public_url" env:"S3_PUBLIC_URL"`
+
+	// S3UsePathStyle addresses the bucket in the URL path, as MinIO requires
+	S3UsePathStyle bool `yaml:"s3_use_path_style" env:"S3_USE_PATH_STYLE"`
+
+	// AvatarMaxBytes is the largest avatar image accepted, in bytes
+	AvatarMaxBytes int64 `yaml:"avatar_max_bytes" env:"STORAGE_AVATAR_MAX_BYTES"`
+}
+
+type CORSConfig struct {
+	// AllowedOrigins are the origins browsers may call the server from,
+	// e.g. https://app.example.com, or * for any; empty allows none
+	AllowedOrigins []string `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+
+	// AllowedMethods are the methods allowed in cross-origin requests; empty
+	// allows the usual REST methods
+	AllowedMethods []string `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+
+	// AllowedHeaders are the request headers allowed in cross-origin
+	// requests; empty allows Content-Type, Authorization, X-Request-ID and
+	// X-CSRF-Token
+	AllowedHeaders []string `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+
+	// AllowCredentials lets cross-origin requests include cookies; it can't
+	// be combined with the * origin
+	AllowCredentials bool `yaml:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS"`
+
+	// MaxAgeSeconds is how long browsers may cache preflight responses
+	MaxAgeSeconds int `yaml:"max_age_seconds" env:"CORS_MAX_AGE_SECONDS"`
+}
+
+type MetricsConfig struct {
+	// Enabled exposes Prometheus metrics at /metrics
+	Enabled bool `yaml:"enabled" env:"METRICS_ENABLED"`
+}
+
+type LogConfig struct {
+	// Level is the minimum level logged: "debug", "info", "warn" or "error".
+	// It is re-read when the server receives SIGHUP.
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+
+	// AccessSampleEvery logs one in every AccessSampleEvery successful
+	// requests in the access log; zero or one logs them all
+	AccessSampleEvery int `yaml:"access_sample_every" env:"LOG_ACCESS_SAMPLE_EVERY"`
+}
+
+// Default returns the configuration used when nothing overrides it
+func Default() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "8080"),
-			Host: getEnv("SERVER_HOST", "0.0.0.0"),
-			Env:  getEnv("ENVIRONMENT", "development"),
+			Port: "8080",
+			Host: "0.0.0.0",
+			Env:  "development",
+
+			MaxBodyBytes: 1 << 20,
+			APIPrefix:    "/api/v1",
+
+			ShutdownGraceSeconds:    5,
+			RequestTimeoutSeconds:   10,
+			HealthCacheMilliseconds: 2000,
 		},
 		Database: DatabaseConfig{
-			URL:      getEnv("DATABASE_URL", ""),
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			Name:     getEnv("DB_NAME", "go_templ_template"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Host:     "localhost",
+			Port:     "5432",
+			User:     "postgres",
+			Password: "postgres",
+			Name:     "go_templ_template",
+			SSLMode:  "disable",
+
+			SlowQueryMilliseconds: 500,
 		},
 		RabbitMQ: RabbitMQConfig{
-			URL:         getEnv("RABBITMQ_URL", ""),
-			Host:        getEnv("RABBITMQ_HOST", "localhost"),
-			Port:        getEnv("RABBITMQ_PORT", "5672"),
-			User:        getEnv("RABBITMQ_USER", "guest"),
-			Password:    getEnv("RABBITMQ_PASSWORD", "guest"),
-			Exchange:    getEnv("RABBITMQ_EXCHANGE", "go_templ_template"),
-			QueuePrefix: getEnv("RABBITMQ_QUEUE_PREFIX", "go_templ_template"),
-			Durable:     getEnvBool("RABBITMQ_DURABLE", true),
+			Host:        "localhost",
+			Port:        "5672",
+			User:        "guest",
+			Password:    "guest",
+			Exchange:    "go_templ_template",
+			QueuePrefix: "go_templ_template",
+			Durable:     true,
+
+			ConsumerConcurrency: 1,
 		},
-	}, nil
-}
+		EventBus: EventBusConfig{
+			Driver: "rabbitmq",
+		},
+		Auth: AuthConfig{
+			LoginMaxFailedAttempts:    5,
+			LoginLockoutMinutes:       15,
+			VerificationTokenHours:    24,
+			PasswordResetTokenMinutes: 60,
+			ReauthWindowMinutes:       15,
+			PasswordRequireSpecial:    true,
+			PasswordHashAlgorithm:     "bcrypt",
+			JWTAccessTokenMinutes:     15,
+			JWTRefreshTokenHours:      720,
+			SessionCookiePath:         "/",
+			SessionCookieSameSite:     "strict",
+		},
+		Email: EmailConfig{
+			Driver:  "log",
+			From:    "no-reply@localhost",
+			BaseURL: "http://localhost:8080",
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+			SMTPHost: "localhost",
+			SMTPPort: "587",
+		},
+		Storage: StorageConfig{
+			Driver:         "local",
+			LocalDir:       "uploads",
+			LocalURL:       "/uploads",
+			AvatarMaxBytes: 512 << 10,
+
+			S3Region: "us-east-1",
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		CORS: CORSConfig{
+			MaxAgeSeconds: 600,
+		},
 	}
-	return defaultValue
 }
 
-func getEnvBool(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
-		if parsed, err := strconv.ParseBool(value); err == nil {
-			return parsed
+// Load returns the defaults overridden by the config file named by
+// APP_CONFIG_FILE, if any, and then by environment variables
+func Load() (*Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv(ConfigFileEnv); path != "" {
+		if err := cfg.LoadFile(path); err != nil {
+			return nil, err
 		}
 	}
-	return defaultValue
+
+	if err := cfg.ApplyEnv(os.LookupEnv); err != nil {
+		return nil, fmt.Errorf("failed to apply environment variables: %w", err)
+	}
+
+	return cfg, nil
 }
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigFileEnv names the environment variable holding the path of the
+	// YAML config file
+	ConfigFileEnv = "APP_CONFIG_FILE"
+
+	// EnvPrefix prefixes the APP_<SECTION>_<FIELD> environment variables
+	EnvPrefix = "APP"
+)
+
+// LookupEnvFunc looks up an environment variable, like os.LookupEnv
+type LookupEnvFunc func(key string) (string, bool)
+
+// LoadFile overrides the configuration with the settings in the YAML file at
+// path. Settings missing from the file are left unchanged; unknown keys are
+// an error, so typos don't go unnoticed.
+func (c *Config) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// ApplyEnv overrides the configuration with the environment variables
+// returned by lookup. Each setting is read from its original variable, such
+// as DB_HOST, and then from APP_<SECTION>_<FIELD>, such as APP_DATABASE_HOST.
+// Empty variables are ignored.
+func (c *Config) ApplyEnv(lookup LookupEnvFunc) error {
+	sections := reflect.ValueOf(c).Elem()
+	for i := 0; i < sections.NumField(); i++ {
+		section := sections.Field(i)
+		sectionKey := yamlKey(sections.Type().Field(i))
+
+		for j := 0; j < section.NumField(); j++ {
+			field := section.Type().Field(j)
+			names := []string{field.Tag.Get("env"), EnvName(sectionKey, yamlKey(field))}
+
+			for _, name := range names {
+				value, ok := lookup(name)
+				if name == "" || !ok || value == "" {
+					continue
+				}
+				if err := setField(section.Field(j), value); err != nil {
+					return fmt.Errorf("invalid value for %s: %w", name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// EnvName returns the APP_<SECTION>_<FIELD> variable for the setting with
+// the given config file keys
+func EnvName(section, field string) string {
+	return strings.ToUpper(EnvPrefix + "_" + section + "_" + field)
+}
+
+// yamlKey returns the config file key of a struct field
+func yamlKey(field reflect.StructField) string {
+	if key, _, _ := strings.Cut(field.Tag.Get("yaml"), ","); key != "" {
+		return key
+	}
+	return strings.ToLower(field.Name)
+}
+
+// setField parses value into field according to its type
+func setField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", value)
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		field.SetInt(parsed)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported setting type %s", field.Type())
+		}
+		var parsed []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				parsed = append(parsed, item)
+			}
+		}
+		field.Set(reflect.ValueOf(parsed))
+	case reflect.Map:
+		if field.Type().Key().Kind() != reflect.String || field.Type().Elem().Kind() != reflect.Int {
+			return fmt.Errorf("unsupported setting type %s", field.Type())
+		}
+		parsed := reflect.MakeMap(field.Type())
+		for _, pair := range strings.Split(value, ",") {
+			key, count, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if !ok || key == "" {
+				return fmt.Errorf("%q is not a list of key=value pairs", value)
+			}
+			n, err := strconv.Atoi(count)
+			if err != nil {
+				return fmt.Errorf("%q is not an integer for %s", count, key)
+			}
+			parsed.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(n))
+		}
+		field.Set(parsed)
+	default:
+		return fmt.Errorf("unsupported setting type %s", field.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFile writes a YAML config file and points APP_CONFIG_FILE at it
+func writeConfigFile(t *testing.T, contents string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	t.Setenv(ConfigFileEnv, path)
+}
+
+// envLookup returns a LookupEnvFunc reading from vars
+func envLookup(vars map[string]string) LookupEnvFunc {
+	return func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	assert.Equal(t, "APP_DATABASE_HOST", EnvName("database", "host"))
+	assert.Equal(t, "APP_SERVER_MAX_BODY_BYTES", EnvName("server", "max_body_bytes"))
+}
+
+func TestLoad_UnsetEnvKeepsDefaults(t *testing.T) {
+	cfg := Default()
+	require.NoError(t, cfg.ApplyEnv(envLookup(nil)))
+
+	assert.Equal(t, Default(), cfg)
+}
+
+func TestLoad_FileOverridesDefaults(t *testing.T) {
+	writeConfigFile(t, `
+server:
+  port: "9000"
+database:
+  host: db.internal
+  max_open_conns: 50
+rabbitmq:
+  durable: false
+`)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "9000", cfg.Server.Port)
+	assert.Equal(t, "db.internal", cfg.Database.Host)
+	assert.Equal(t, 50, cfg.Database.MaxOpenConns)
+	assert.False(t, cfg.RabbitMQ.Durable)
+
+	// Settings missing from the file keep their defaults
+	assert.Equal(t, "0.0.0.0", cfg.Server.Host)
+	assert.Equal(t, "5432", cfg.Database.Port)
+}
+
+func TestLoad_EnvOverridesFile(t *testing.T) {
+	writeConfigFile(t, `
+server:
+  port: "9000"
+database:
+  host: db.internal
+auth:
+  login_lockout_minutes: 30
+`)
+	t.Setenv("APP_SERVER_PORT", "9100")
+	t.Setenv("APP_DATABASE_HOST", "db.override")
+	t.Setenv("APP_AUTH_LOGIN_LOCKOUT_MINUTES", "45")
+	t.Setenv("APP_METRICS_ENABLED", "true")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "9100", cfg.Server.Port)
+	assert.Equal(t, "db.override", cfg.Database.Host)
+	assert.Equal(t, 45, cfg.Auth.LoginLockoutMinutes)
+	assert.True(t, cfg.Metrics.Enabled)
+}
+
+func TestApplyEnv_LegacyNames(t *testing.T) {
+	cfg := Default()
+	require.NoError(t, cfg.ApplyEnv(envLookup(map[string]string{
+		"DB_HOST":               "legacy-db",
+		"SERVER_MAX_BODY_BYTES": "2048",
+		"EVENT_BUS":             "memory",
+	})))
+
+	assert.Equal(t, "legacy-db", cfg.Database.Host)
+	assert.Equal(t, int64(2048), cfg.Server.MaxBodyBytes)
+	assert.Equal(t, "memory", cfg.EventBus.Driver)
+}
+
+func TestApplyEnv_PrefixedNameWinsOverLegacyName(t *testing.T) {
+	cfg := Default()
+	require.NoError(t, cfg.ApplyEnv(envLookup(map[string]string{
+		"DB_HOST":           "legacy-db",
+		"APP_DATABASE_HOST": "prefixed-db",
+	})))
+
+	assert.Equal(t, "prefixed-db", cfg.Database.Host)
+}
+
+func TestApplyEnv_EmptyValueIgnored(t *testing.T) {
+	cfg := Default()
+	require.NoError(t, cfg.ApplyEnv(envLookup(map[string]string{"APP_SERVER_PORT": ""})))
+
+	assert.Equal(t, "8080", cfg.Server.Port)
+}
+
+func TestApplyEnv_InvalidValue(t *testing.T) {
+	cfg := Default()
+	err := cfg.ApplyEnv(envLookup(map[string]string{"APP_RABBITMQ_DURABLE": "sometimes"}))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "APP_RABBITMQ_DURABLE")
+}
+
+func TestApplyEnv_MapValue(t *testing.T) {
+	cfg := Default()
+	require.NoError(t, cfg.ApplyEnv(envLookup(map[string]string{
+		"RABBITMQ_CONCURRENCY": "user.export_requested=4, user.created=1",
+	})))
+
+	assert.Equal(t, map[string]int{"user.export_requested": 4, "user.created": 1}, cfg.RabbitMQ.Concurrency)
+
+	for _, value := range []string{"user.created", "user.created=many", "=2"} {
+		err := Default().ApplyEnv(envLookup(map[string]string{"RABBITMQ_CONCURRENCY": value}))
+		assert.Error(t, err, value)
+	}
+}
+
+func TestApplyEnv_SliceValue(t *testing.T) {
+	cfg := Default()
+	require.NoError(t, cfg.ApplyEnv(envLookup(map[string]string{
+		"CORS_ALLOWED_ORIGINS": "https://app.example.com, https://admin.example.com,",
+	})))
+
+	assert.Equal(t, []string{"https://app.example.com", "https://admin.example.com"}, cfg.CORS.AllowedOrigins)
+}
+
+func TestLoadFile_UnknownKey(t *testing.T) {
+	writeConfigFile(t, `
+database:
+  hostname: db.internal
+`)
+
+	_, err := Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "hostname")
+}
+
+func TestLoadFile_Missing(t *testing.T) {
+	t.Setenv(ConfigFileEnv, filepath.Join(t.TempDir(), "missing.yaml"))
+
+	_, err := Load()
+	assert.Error(t, err)
+}
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	appErrors "go-templ-template/internal/shared/errors"
+)
+
+// Environments the server may run in
+var validEnvironments = []string{"development", "test", "staging", "production"}
+
+// Validate checks that required settings are present and well formed,
+// returning every problem found at once, or nil if the configuration is
+// usable. Each error's details name the environment variable to fix.
+func (c *Config) Validate() *appErrors.ErrorList {
+	v := &configValidator{errors: &appErrors.ErrorList{}}
+
+	// Server
+	v.port("SERVER_PORT", c.Server.Port)
+	v.required("SERVER_HOST", c.Server.Host)
+	v.oneOf("ENVIRONMENT", c.Server.Env, validEnvironments...)
+	v.positive("SERVER_MAX_BODY_BYTES", c.Server.MaxBodyBytes)
+	v.nonNegative("SERVER_SHUTDOWN_GRACE_SECONDS", int64(c.Server.ShutdownGraceSeconds))
+	v.nonNegative("SERVER_REQUEST_TIMEOUT_SECONDS", int64(c.Server.RequestTimeoutSeconds))
+	v.nonNegative("SERVER_HEALTH_CACHE_MILLISECONDS", int64(c.Server.HealthCacheMilliseconds))
+	v.oneOf("SERVER_ERROR_FORMAT", c.Server.ErrorFormat, "", "jsonapi", "problem")
+	if c.Server.APIPrefix != "" && !strings.HasPrefix(c.Server.APIPrefix, "/") {
+		v.invalid("API_PREFIX", c.Server.APIPrefix, "must start with /")
+	}
+	if c.Server.Env == "production" {
+		// Instances behind a load balancer must sign cookies with one secret
+		v.required("SERVER_SECRET", c.Server.Secret)
+	}
+	if c.Server.Secret != "" && len(c.Server.Secret) < 32 {
+		// The secret is left out of the message
+		v.add("CONFIG_INVALID", "SERVER_SECRET", "must be at least 32 bytes")
+	}
+
+	// Database; DATABASE_URL replaces the individual settings
+	if c.Database.URL != "" {
+		v.url("DATABASE_URL", c.Database.URL, "postgres", "postgresql")
+	} else {
+		v.required("DB_HOST", c.Database.Host)
+		v.port("DB_PORT", c.Database.Port)
+		v.required("DB_USER", c.Database.User)
+		v.required("DB_NAME", c.Database.Name)
+	}
+
+	// Event bus; RabbitMQ settings only matter when it is used
+	v.oneOf("EVENT_BUS", c.EventBus.Driver, "", "rabbitmq", "memory")
+	if c.EventBus.Driver != "memory" {
+		if c.RabbitMQ.URL != "" {
+			v.url("RABBITMQ_URL", c.RabbitMQ.URL, "amqp", "amqps")
+		} else {
+			v.required("RABBITMQ_HOST", c.RabbitMQ.Host)
+			v.port("RABBITMQ_PORT", c.RabbitMQ.Port)
+		}
+		v.required("RABBITMQ_EXCHANGE", c.RabbitMQ.Exchange)
+		v.nonNegative("RABBITMQ_MAX_DELIVERY_ATTEMPTS", int64(c.RabbitMQ.MaxDeliveryAttempts))
+		v.positive("RABBITMQ_CONSUMER_CONCURRENCY", int64(c.RabbitMQ.ConsumerConcurrency))
+		v.oneOf("RABBITMQ_CODEC", c.RabbitMQ.Codec, "", "json", "gob")
+		v.nonNegative("RABBITMQ_COMPRESSION_THRESHOLD", int64(c.RabbitMQ.CompressionThreshold))
+		for eventType, n := range c.RabbitMQ.Concurrency {
+			if n <= 0 {
+				v.invalid("RABBITMQ_CONCURRENCY", fmt.Sprintf("%s=%d", eventType, n), "must be greater than zero")
+			}
+		}
+	}
+
+	// Auth durations and limits
+	v.nonNegative("AUTH_LOGIN_MAX_FAILED_ATTEMPTS", int64(c.Auth.LoginMaxFailedAttempts))
+	if c.Auth.LoginMaxFailedAttempts > 0 {
+		v.positive("AUTH_LOGIN_LOCKOUT_MINUTES", int64(c.Auth.LoginLockoutMinutes))
+	}
+	v.nonNegative("AUTH_ACCOUNT_LOCK_MAX_FAILED_ATTEMPTS", int64(c.Auth.AccountLockMaxFailedAttempts))
+	v.positive("AUTH_VERIFICATION_TOKEN_HOURS", int64(c.Auth.VerificationTokenHours))
+	v.positive("AUTH_PASSWORD_RESET_TOKEN_MINUTES", int64(c.Auth.PasswordResetTokenMinutes))
+	v.positive("AUTH_REAUTH_WINDOW_MINUTES", int64(c.Auth.ReauthWindowMinutes))
+	v.nonNegative("AUTH_PASSWORD_MIN_LENGTH", int64(c.Auth.PasswordMinLength))
+	v.oneOf("AUTH_PASSWORD_HASH_ALGORITHM", c.Auth.PasswordHashAlgorithm, "", "bcrypt", "argon2id")
+	if c.Auth.JWTSecret != "" {
+		// The secret is left out of the message
+		if len(c.Auth.JWTSecret) < 32 {
+			v.add("CONFIG_INVALID", "AUTH_JWT_SECRET", "must be at least 32 bytes")
+		}
+		v.positive("AUTH_JWT_ACCESS_TOKEN_MINUTES", int64(c.Auth.JWTAccessTokenMinutes))
+		v.positive("AUTH_JWT_REFRESH_TOKEN_HOURS", int64(c.Auth.JWTRefreshTokenHours))
+	}
+	if !strings.HasPrefix(c.Auth.SessionCookiePath, "/") {
+		v.invalid("AUTH_SESSION_COOKIE_PATH", c.Auth.SessionCookiePath, "must start with /")
+	}
+	v.oneOf("AUTH_SESSION_COOKIE_SAME_SITE", c.Auth.SessionCookieSameSite, "strict", "lax", "none")
+	if c.Auth.SessionCookieSameSite == "none" && !c.Auth.SessionCookieSecure {
+		// Browsers reject SameSite=None cookies that aren't Secure
+		v.invalid("AUTH_SESSION_COOKIE_SAME_SITE", c.Auth.SessionCookieSameSite, "requires AUTH_SESSION_COOKIE_SECURE")
+	}
+
+	// Email
+	v.oneOf("EMAIL_DRIVER", c.Email.Driver, "", "log", "smtp")
+	v.url("APP_BASE_URL", c.Email.BaseURL, "http", "https")
+	if c.Email.Driver == "smtp" {
+		v.required("EMAIL_FROM", c.Email.From)
+		v.required("SMTP_HOST", c.Email.SMTPHost)
+		v.port("SMTP_PORT", c.Email.SMTPPort)
+	}
+
+	// Storage
+	v.oneOf("STORAGE_DRIVER", c.Storage.Driver, "", "local", "s3")
+	v.positive("STORAGE_AVATAR_MAX_BYTES", c.Storage.AvatarMaxBytes)
+	switch c.Storage.Driver {
+	case "", "local":
+		v.required("STORAGE_LOCAL_DIR", c.Storage.LocalDir)
+	case "s3":
+		v.required("S3_BUCKET", c.Storage.S3Bucket)
+		v.required("S3_REGION", c.Storage.S3Region)
+		if c.Storage.S3Endpoint != "" {
+			v.url("S3_ENDPOINT", c.Storage.S3Endpoint, "http", "https")
+		}
+	}
+
+	// Logging
+	v.oneOf("LOG_LEVEL", c.Log.Level, "trace", "debug", "info", "warn", "warning", "error")
+	v.nonNegative("LOG_ACCESS_SAMPLE_EVERY", int64(c.Log.AccessSampleEvery))
+
+	// CORS; credentials are never shared with every origin
+	for _, origin := range c.CORS.AllowedOrigins {
+		switch {
+		case origin == "*":
+			if c.CORS.AllowCredentials {
+				v.invalid("CORS_ALLOWED_ORIGINS", origin, "can't be * when CORS_ALLOW_CREDENTIALS is true")
+			}
+		case strings.HasSuffix(origin, "/"):
+			v.invalid("CORS_ALLOWED_ORIGINS", origin, "must not end with /")
+		default:
+			v.url("CORS_ALLOWED_ORIGINS", origin, "http", "https")
+		}
+	}
+	v.nonNegative("CORS_MAX_AGE_SECONDS", int64(c.CORS.MaxAgeSeconds))
+
+	// Database pool tuning; zero uses the defaults
+	v.nonNegative("DB_MAX_OPEN_CONNS", int64(c.Database.MaxOpenConns))
+	v.nonNegative("DB_MAX_IDLE_CONNS", int64(c.Database.MaxIdleConns))
+	v.nonNegative("DB_CONN_MAX_LIFETIME_SECONDS", int64(c.Database.ConnMaxLifetimeSeconds))
+	v.nonNegative("DB_CONN_MAX_IDLE_TIME_SECONDS", int64(c.Database.ConnMaxIdleTimeSeconds))
+	v.nonNegative("DB_SLOW_QUERY_MILLISECONDS", int64(c.Database.SlowQueryMilliseconds))
+
+	if !v.errors.HasErrors() {
+		return nil
+	}
+	return v.errors
+}
+
+// configValidator collects configuration problems
+type configValidator struct {
+	errors *appErrors.ErrorList
+}
+
+func (v *configValidator) add(code, setting, message string) {
+	v.errors.Add(appErrors.NewValidationErrorWithDetails(code,
+		fmt.Sprintf("%s %s", setting, message),
+		map[string]interface{}{"setting": setting}))
+}
+
+func (v *configValidator) invalid(setting, value, reason string) {
+	v.add("CONFIG_INVALID", setting, fmt.Sprintf("%q is invalid: %s", value, reason))
+}
+
+func (v *configValidator) required(setting, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add("CONFIG_REQUIRED", setting, "is required")
+	}
+}
+
+func (v *configValidator) port(setting, value string) {
+	if value == "" {
+		v.required(setting, value)
+		return
+	}
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		v.invalid(setting, value, "must be a port between 1 and 65535")
+	}
+}
+
+func (v *configValidator) oneOf(setting, value string, allowed ...string) {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return
+		}
+	}
+
+	var named []string
+	for _, candidate := range allowed {
+		if candidate != "" {
+			named = append(named, candidate)
+		}
+	}
+	v.invalid(setting, value, "must be one of "+strings.Join(named, ", "))
+}
+
+func (v *configValidator) url(setting, value string, schemes ...string) {
+	if value == "" {
+		v.required(setting, value)
+		return
+	}
+	// URLs may hold credentials, so they are left out of the message
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" {
+		v.add("CONFIG_INVALID", setting, "must be an absolute URL")
+		return
+	}
+	for _, scheme := range schemes {
+		if parsed.Scheme == scheme {
+			return
+		}
+	}
+	v.add("CONFIG_INVALID", setting, "must use one of the schemes "+strings.Join(schemes, ", "))
+}
+
+func (v *configValidator) positive(setting string, value int64) {
+	if value <= 0 {
+		v.add("CONFIG_INVALID", setting, fmt.Sprintf("must be greater than zero, got %d", value))
+	}
+}
+
+func (v *configValidator) nonNegative(setting string, value int64) {
+	if value < 0 {
+		v.add("CONFIG_INVALID", setting, fmt.Sprintf("must not be negative, got %d", value))
+	}
+}
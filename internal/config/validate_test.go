@@ -0,0 +1,129 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validConfig returns the defaults Load uses with no environment set
+func validConfig(t *testing.T) *Config {
+	t.Helper()
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	return cfg
+}
+
+// problemSettings returns the settings named by each validation error
+func problemSettings(t *testing.T, cfg *Config) []string {
+	t.Helper()
+
+	problems := cfg.Validate()
+	require.NotNil(t, problems)
+
+	settings := make([]string, 0, len(problems.Errors))
+	for _, problem := range problems.Errors {
+		settings = append(settings, problem.Details["setting"].(string))
+	}
+	return settings
+}
+
+func TestValidate_ValidConfig(t *testing.T) {
+	assert.Nil(t, validConfig(t).Validate())
+}
+
+func TestValidate_MemoryEventBusIgnoresRabbitMQ(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.EventBus.Driver = "memory"
+	cfg.RabbitMQ.Host = ""
+	cfg.RabbitMQ.Port = ""
+
+	assert.Nil(t, cfg.Validate())
+}
+
+func TestValidate_InvalidConfigs(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *Config)
+		setting string
+		code    string
+	}{
+		{"missing port", func(cfg *Config) { cfg.Server.Port = "" }, "SERVER_PORT", "CONFIG_REQUIRED"},
+		{"port out of range", func(cfg *Config) { cfg.Server.Port = "70000" }, "SERVER_PORT", "CONFIG_INVALID"},
+		{"non-numeric port", func(cfg *Config) { cfg.Database.Port = "postgres" }, "DB_PORT", "CONFIG_INVALID"},
+		{"bad environment", func(cfg *Config) { cfg.Server.Env = "prod" }, "ENVIRONMENT", "CONFIG_INVALID"},
+		{"missing database host", func(cfg *Config) { cfg.Database.Host = "" }, "DB_HOST", "CONFIG_REQUIRED"},
+		{"wrong database URL scheme", func(cfg *Config) { cfg.Database.URL = "mysql://db:3306/app" }, "DATABASE_URL", "CONFIG_INVALID"},
+		{"missing RabbitMQ host", func(cfg *Config) { cfg.RabbitMQ.Host = "" }, "RABBITMQ_HOST", "CONFIG_REQUIRED"},
+		{"relative RabbitMQ URL", func(cfg *Config) { cfg.RabbitMQ.URL = "rabbitmq" }, "RABBITMQ_URL", "CONFIG_INVALID"},
+		{"unknown RabbitMQ codec", func(cfg *Config) { cfg.RabbitMQ.Codec = "msgpack" }, "RABBITMQ_CODEC", "CONFIG_INVALID"},
+		{"negative compression threshold", func(cfg *Config) { cfg.RabbitMQ.CompressionThreshold = -1 }, "RABBITMQ_COMPRESSION_THRESHOLD", "CONFIG_INVALID"},
+		{"unknown event bus", func(cfg *Config) { cfg.EventBus.Driver = "kafka" }, "EVENT_BUS", "CONFIG_INVALID"},
+		{"zero token lifetime", func(cfg *Config) { cfg.Auth.PasswordResetTokenMinutes = 0 }, "AUTH_PASSWORD_RESET_TOKEN_MINUTES", "CONFIG_INVALID"},
+		{"short JWT secret", func(cfg *Config) { cfg.Auth.JWTSecret = "too-short" }, "AUTH_JWT_SECRET", "CONFIG_INVALID"},
+		{"zero access token lifetime", func(cfg *Config) {
+			cfg.Auth.JWTSecret = "0123456789abcdef0123456789abcdef"
+			cfg.Auth.JWTAccessTokenMinutes = 0
+		}, "AUTH_JWT_ACCESS_TOKEN_MINUTES", "CONFIG_INVALID"},
+		{"unknown cookie SameSite", func(cfg *Config) { cfg.Auth.SessionCookieSameSite = "relaxed" }, "AUTH_SESSION_COOKIE_SAME_SITE", "CONFIG_INVALID"},
+		{"SameSite none without Secure", func(cfg *Config) { cfg.Auth.SessionCookieSameSite = "none" }, "AUTH_SESSION_COOKIE_SAME_SITE", "CONFIG_INVALID"},
+		{"relative cookie path", func(cfg *Config) { cfg.Auth.SessionCookiePath = "app" }, "AUTH_SESSION_COOKIE_PATH", "CONFIG_INVALID"},
+		{"negative pool size", func(cfg *Config) { cfg.Database.MaxOpenConns = -1 }, "DB_MAX_OPEN_CONNS", "CONFIG_INVALID"},
+		{"negative slow query threshold", func(cfg *Config) { cfg.Database.SlowQueryMilliseconds = -1 }, "DB_SLOW_QUERY_MILLISECONDS", "CONFIG_INVALID"},
+		{"smtp without host", func(cfg *Config) { cfg.Email.Driver = "smtp"; cfg.Email.SMTPHost = "" }, "SMTP_HOST", "CONFIG_REQUIRED"},
+		{"s3 without bucket", func(cfg *Config) { cfg.Storage.Driver = "s3" }, "S3_BUCKET", "CONFIG_REQUIRED"},
+		{"relative API prefix", func(cfg *Config) { cfg.Server.APIPrefix = "api/v2" }, "API_PREFIX", "CONFIG_INVALID"},
+		{"negative shutdown grace", func(cfg *Config) { cfg.Server.ShutdownGraceSeconds = -1 }, "SERVER_SHUTDOWN_GRACE_SECONDS", "CONFIG_INVALID"},
+		{"negative request timeout", func(cfg *Config) { cfg.Server.RequestTimeoutSeconds = -1 }, "SERVER_REQUEST_TIMEOUT_SECONDS", "CONFIG_INVALID"},
+		{"negative health cache", func(cfg *Config) { cfg.Server.HealthCacheMilliseconds = -1 }, "SERVER_HEALTH_CACHE_MILLISECONDS", "CONFIG_INVALID"},
+		{"unknown error format", func(cfg *Config) { cfg.Server.ErrorFormat = "xml" }, "SERVER_ERROR_FORMAT", "CONFIG_INVALID"},
+		{"production without secret", func(cfg *Config) { cfg.Server.Env = "production" }, "SERVER_SECRET", "CONFIG_REQUIRED"},
+		{"short server secret", func(cfg *Config) { cfg.Server.Secret = "too-short" }, "SERVER_SECRET", "CONFIG_INVALID"},
+		{"negative access log sampling", func(cfg *Config) { cfg.Log.AccessSampleEvery = -1 }, "LOG_ACCESS_SAMPLE_EVERY", "CONFIG_INVALID"},
+		{"wildcard CORS origin with credentials", func(cfg *Config) { cfg.CORS.AllowedOrigins = []string{"*"}; cfg.CORS.AllowCredentials = true }, "CORS_ALLOWED_ORIGINS", "CONFIG_INVALID"},
+		{"CORS origin with path", func(cfg *Config) { cfg.CORS.AllowedOrigins = []string{"https://app.example.com/"} }, "CORS_ALLOWED_ORIGINS", "CONFIG_INVALID"},
+		{"CORS origin without scheme", func(cfg *Config) { cfg.CORS.AllowedOrigins = []string{"app.example.com"} }, "CORS_ALLOWED_ORIGINS", "CONFIG_INVALID"},
+		{"zero consumer concurrency", func(cfg *Config) { cfg.RabbitMQ.ConsumerConcurrency = 0 }, "RABBITMQ_CONSUMER_CONCURRENCY", "CONFIG_INVALID"},
+		{"zero event type concurrency", func(cfg *Config) { cfg.RabbitMQ.Concurrency = map[string]int{"user.created": 0} }, "RABBITMQ_CONCURRENCY", "CONFIG_INVALID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			tt.mutate(cfg)
+
+			problems := cfg.Validate()
+			require.NotNil(t, problems)
+			require.Len(t, problems.Errors, 1)
+			assert.Equal(t, tt.code, problems.Errors[0].Code)
+			assert.Equal(t, tt.setting, problems.Errors[0].Details["setting"])
+			assert.Contains(t, problems.Errors[0].Message, tt.setting)
+		})
+	}
+}
+
+func TestValidate_CollectsAllErrors(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.Server.Port = ""
+	cfg.Server.Env = "prod"
+	cfg.Database.Host = ""
+	cfg.Auth.VerificationTokenHours = -1
+
+	assert.ElementsMatch(t, []string{
+		"SERVER_PORT",
+		"ENVIRONMENT",
+		"DB_HOST",
+		"AUTH_VERIFICATION_TOKEN_HOURS",
+	}, problemSettings(t, cfg))
+}
+
+func TestValidate_DoesNotEchoURLs(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.Database.URL = "mysql://admin:secret@db:3306/app"
+
+	problems := cfg.Validate()
+	require.NotNil(t, problems)
+	assert.NotContains(t, problems.Errors[0].Message, "secret")
+}
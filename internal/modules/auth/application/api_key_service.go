@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-templ-template/internal/modules/auth/domain"
+	"go-templ-template/internal/modules/user/application"
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/database"
+)
+
+// apiKeyLastUsedInterval is how stale a key's last use may get before an
+// authentication records it again, so busy keys don't write on every request
+const apiKeyLastUsedInterval = time.Minute
+
+// APIKeyService defines the interface for managing and authenticating with
+// API keys
+type APIKeyService interface {
+	// CreateAPIKey creates a key for the command's user. The returned key's
+	// Key holds the plaintext key, which can't be retrieved again.
+	CreateAPIKey(ctx context.Context, cmd *CreateAPIKeyCommand) (*domain.APIKey, error)
+
+	// ListAPIKeys returns a user's keys that have not been revoked
+	ListAPIKeys(ctx context.Context, userID string) ([]*domain.APIKey, error)
+
+	// RevokeAPIKey revokes one of a user's keys
+	RevokeAPIKey(ctx context.Context, cmd *RevokeAPIKeyCommand) error
+
+	// Authenticate returns the active user a plaintext key belongs to, and
+	// the key, recording its use
+	Authenticate(ctx context.Context, key string) (*userDomain.User, *domain.APIKey, error)
+}
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) error
+	GetByKeyHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	ListByUserID(ctx context.Context, userID string) ([]*domain.APIKey, error)
+	// Revoke revokes one of a user's keys, returning database.ErrNotFound if
+	// the user has no such unrevoked key
+	Revoke(ctx context.Context, keyID, userID string, revokedAt time.Time) error
+	UpdateLastUsed(ctx context.Context, keyID string, usedAt time.Time) error
+}
+
+// apiKeyServiceImpl implements the APIKeyService interface
+type apiKeyServiceImpl struct {
+	keyRepo     APIKeyRepository
+	userService application.UserService
+}
+
+// NewAPIKeyService creates a new API key service instance
+func NewAPIKeyService(keyRepo APIKeyRepository, userService application.UserService) APIKeyService {
+	return &apiKeyServiceImpl{
+		keyRepo:     keyRepo,
+		userService: userService,
+	}
+}
+
+// CreateAPIKey creates an API key
+func (s *apiKeyServiceImpl) CreateAPIKey(ctx context.Context, cmd *CreateAPIKeyCommand) (*domain.APIKey, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if cmd.ExpiresIn > 0 {
+		expiry := time.Now().UTC().Add(cmd.ExpiresIn)
+		expiresAt = &expiry
+	}
+
+	key, err := domain.NewAPIKey(cmd.UserID, cmd.Name, cmd.Scopes, expiresAt)
+	if err != nil {
+		return nil, NewInternalError(err.Error())
+	}
+
+	if err := s.keyRepo.Create(ctx, key); err != nil {
+		return nil, NewInternalError(fmt.Sprintf("failed to save API key: %v", err))
+	}
+
+	return key, nil
+}
+
+// ListAPIKeys returns a user's unrevoked API keys
+func (s *apiKeyServiceImpl) ListAPIKeys(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	if userID == "" {
+		return nil, NewValidationError("user_id", "user ID is required")
+	}
+
+	keys, err := s.keyRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, NewInternalError(fmt.Sprintf("failed to list API keys: %v", err))
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes an API key
+func (s *apiKeyServiceImpl) RevokeAPIKey(ctx context.Context, cmd *RevokeAPIKeyCommand) error {
+	if err := cmd.Validate(); err != nil {
+		return err
+	}
+
+	// Only the key's owner can revoke it, and other users' keys are reported
+	// as missing so their IDs can't be probed
+	if err := s.keyRepo.Revoke(ctx, cmd.KeyID, cmd.UserID, time.Now().UTC()); err != nil {
+		if database.IsNotFoundError(err) {
+			return NewAPIKeyNotFoundError(cmd.KeyID)
+		}
+		return NewInternalError(fmt.Sprintf("failed to revoke API key: %v", err))
+	}
+
+	return nil
+}
+
+// Authenticate returns the user a plaintext API key belongs to
+func (s *apiKeyServiceImpl) Authenticate(ctx context.Context, key string) (*userDomain.User, *domain.APIKey, error) {
+	if !domain.IsAPIKey(key) {
+		return nil, nil, NewAPIKeyInvalidError()
+	}
+
+	apiKey, err := s.keyRepo.GetByKeyHash(ctx, domain.HashAPIKey(key))
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, nil, NewAPIKeyInvalidError()
+		}
+		return nil, nil, NewInternalError(fmt.Sprintf("failed to get API key: %v", err))
+	}
+
+	if !apiKey.IsValid() {
+		return nil, nil, NewAPIKeyInvalidError()
+	}
+
+	user, err := s.userService.GetUser(ctx, &application.GetUserQuery{ID: apiKey.UserID})
+	if err != nil {
+		if application.IsUserNotFoundError(err) {
+			return nil, nil, NewAPIKeyInvalidError()
+		}
+		return nil, nil, NewInternalError(fmt.Sprintf("failed to get user: %v", err))
+	}
+
+	// Keys of suspended or locked users stop working with their account
+	if !user.IsActive() {
+		return nil, nil, NewAPIKeyInvalidError()
+	}
+
+	now := time.Now().UTC()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedInterval {
+		// Recording use is best effort and doesn't fail the request
+		if err := s.keyRepo.UpdateLastUsed(ctx, apiKey.ID, now); err == nil {
+			apiKey.LastUsedAt = &now
+		}
+	}
+
+	return user, apiKey, nil
+}
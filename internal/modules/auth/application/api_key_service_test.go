@@ -0,0 +1,236 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/auth/domain"
+	"go-templ-template/internal/modules/user/application"
+	"go-templ-template/internal/shared/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAPIKeyRepository is an in-memory APIKeyRepository with the same
+// semantics as the SQL implementation
+type memoryAPIKeyRepository struct {
+	mu   sync.Mutex
+	keys map[string]*domain.APIKey
+}
+
+func newMemoryAPIKeyRepository() *memoryAPIKeyRepository {
+	return &memoryAPIKeyRepository{keys: make(map[string]*domain.APIKey)}
+}
+
+func (r *memoryAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *key
+	stored.Key = ""
+	r.keys[key.ID] = &stored
+	return nil
+}
+
+func (r *memoryAPIKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.keys {
+		if stored.KeyHash == keyHash {
+			found := *stored
+			return &found, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+func (r *memoryAPIKeyRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var keys []*domain.APIKey
+	for _, stored := range r.keys {
+		if stored.UserID == userID && stored.RevokedAt == nil {
+			found := *stored
+			keys = append(keys, &found)
+		}
+	}
+	return keys, nil
+}
+
+func (r *memoryAPIKeyRepository) Revoke(ctx context.Context, keyID, userID string, revokedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.keys[keyID]
+	if !ok || stored.UserID != userID || stored.RevokedAt != nil {
+		return database.ErrNotFound
+	}
+	stored.RevokedAt = &revokedAt
+	return nil
+}
+
+func (r *memoryAPIKeyRepository) UpdateLastUsed(ctx context.Context, keyID string, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.keys[keyID]; ok {
+		stored.LastUsedAt = &usedAt
+	}
+	return nil
+}
+
+func newAPIKeyTestService(userService *mockUserService) (APIKeyService, *memoryAPIKeyRepository) {
+	keyRepo := newMemoryAPIKeyRepository()
+	return NewAPIKeyService(keyRepo, userService), keyRepo
+}
+
+func TestAPIKeyService_CreateAPIKey(t *testing.T) {
+	service, keyRepo := newAPIKeyTestService(&mockUserService{})
+
+	key, err := service.CreateAPIKey(context.Background(), &CreateAPIKeyCommand{
+		UserID:    "user-123",
+		Name:      " CI deploys ",
+		Scopes:    []string{"users:read"},
+		ExpiresIn: 24 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	// The plaintext key is returned once and only its hash is stored
+	assert.True(t, strings.HasPrefix(key.Key, domain.APIKeyPrefix))
+	assert.True(t, strings.HasPrefix(key.Key, key.Prefix))
+	assert.Equal(t, "CI deploys", key.Name)
+	assert.Equal(t, []string{"users:read"}, []string(key.Scopes))
+	require.NotNil(t, key.ExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), *key.ExpiresAt, time.Minute)
+
+	stored := keyRepo.keys[key.ID]
+	require.NotNil(t, stored)
+	assert.Empty(t, stored.Key)
+	assert.Equal(t, domain.HashAPIKey(key.Key), stored.KeyHash)
+
+	keys, err := service.ListAPIKeys(context.Background(), "user-123")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Empty(t, keys[0].Key)
+}
+
+func TestAPIKeyService_CreateAPIKey_Validation(t *testing.T) {
+	service, keyRepo := newAPIKeyTestService(&mockUserService{})
+
+	tests := []struct {
+		name  string
+		cmd   *CreateAPIKeyCommand
+		field string
+	}{
+		{"missing name", &CreateAPIKeyCommand{UserID: "user-123", Name: "  "}, "name"},
+		{"scope with spaces", &CreateAPIKeyCommand{UserID: "user-123", Name: "CI", Scopes: []string{"users read"}}, "scopes"},
+		{"negative expiry", &CreateAPIKeyCommand{UserID: "user-123", Name: "CI", ExpiresIn: -time.Hour}, "expires_in"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateAPIKey(context.Background(), tt.cmd)
+			require.True(t, IsValidationError(err))
+			assert.Equal(t, tt.field, err.(*AuthError).Field)
+		})
+	}
+	assert.Empty(t, keyRepo.keys)
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	user := createTestUser()
+	userService := &mockUserService{}
+	userService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: user.ID}).Return(user, nil)
+	service, keyRepo := newAPIKeyTestService(userService)
+
+	key, err := service.CreateAPIKey(context.Background(), &CreateAPIKeyCommand{UserID: user.ID, Name: "CI"})
+	require.NoError(t, err)
+
+	authenticated, apiKey, err := service.Authenticate(context.Background(), key.Key)
+	require.NoError(t, err)
+	assert.Equal(t, user, authenticated)
+	assert.Equal(t, key.ID, apiKey.ID)
+
+	// The key's use is recorded
+	require.NotNil(t, keyRepo.keys[key.ID].LastUsedAt)
+	assert.WithinDuration(t, time.Now(), *keyRepo.keys[key.ID].LastUsedAt, time.Minute)
+}
+
+func TestAPIKeyService_AuthenticateRejectsUnusableKeys(t *testing.T) {
+	user := createTestUser()
+	userService := &mockUserService{}
+	userService.On("GetUser", mock.Anything, mock.Anything).Return(user, nil)
+	service, keyRepo := newAPIKeyTestService(userService)
+
+	newKey := func() *domain.APIKey {
+		key, err := service.CreateAPIKey(context.Background(), &CreateAPIKeyCommand{UserID: user.ID, Name: "CI"})
+		require.NoError(t, err)
+		return key
+	}
+
+	revoked := newKey()
+	require.NoError(t, service.RevokeAPIKey(context.Background(), &RevokeAPIKeyCommand{UserID: user.ID, KeyID: revoked.ID}))
+
+	expired := newKey()
+	past := time.Now().UTC().Add(-time.Minute)
+	keyRepo.keys[expired.ID].ExpiresAt = &past
+
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{"revoked", revoked.Key},
+		{"expired", expired.Key},
+		{"unknown", domain.APIKeyPrefix + "0000000000000000"},
+		{"not an API key", "session-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := service.Authenticate(context.Background(), tt.key)
+			require.Error(t, err)
+			assert.Equal(t, ErrorCodeAPIKeyInvalid, err.(*AuthError).Code)
+			assert.True(t, IsAuthenticationError(err))
+		})
+	}
+}
+
+func TestAPIKeyService_AuthenticateRejectsInactiveUsers(t *testing.T) {
+	user := createTestUser()
+	require.NoError(t, user.Suspend())
+	userService := &mockUserService{}
+	userService.On("GetUser", mock.Anything, mock.Anything).Return(user, nil)
+	service, _ := newAPIKeyTestService(userService)
+
+	key, err := service.CreateAPIKey(context.Background(), &CreateAPIKeyCommand{UserID: user.ID, Name: "CI"})
+	require.NoError(t, err)
+
+	_, _, err = service.Authenticate(context.Background(), key.Key)
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeAPIKeyInvalid, err.(*AuthError).Code)
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	service, _ := newAPIKeyTestService(&mockUserService{})
+
+	key, err := service.CreateAPIKey(context.Background(), &CreateAPIKeyCommand{UserID: "user-123", Name: "CI"})
+	require.NoError(t, err)
+
+	// Other users can't revoke the key
+	err = service.RevokeAPIKey(context.Background(), &RevokeAPIKeyCommand{UserID: "user-456", KeyID: key.ID})
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeAPIKeyNotFound, err.(*AuthError).Code)
+
+	require.NoError(t, service.RevokeAPIKey(context.Background(), &RevokeAPIKeyCommand{UserID: "user-123", KeyID: key.ID}))
+
+	keys, err := service.ListAPIKeys(context.Background(), "user-123")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	// Revoking twice reports the key as missing
+	err = service.RevokeAPIKey(context.Background(), &RevokeAPIKeyCommand{UserID: "user-123", KeyID: key.ID})
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeAPIKeyNotFound, err.(*AuthError).Code)
+}
@@ -1,7 +1,12 @@
 package application
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"go-templ-template/internal/modules/auth/domain"
+	appErrors "go-templ-template/internal/shared/errors"
 )
 
 // LoginCommand represents a command to authenticate a user
@@ -10,6 +15,17 @@ type LoginCommand struct {
 	Password  string `json:"password" validate:"required"`
 	IPAddress string `json:"ip_address,omitempty"`
 	UserAgent string `json:"user_agent,omitempty"`
+
+	// RememberMe creates a persistent session lasting SessionConfig.MaxDuration
+	RememberMe bool `json:"remember_me,omitempty"`
+}
+
+// NewSession creates the session for a login, persistent if RememberMe is set
+func (c *LoginCommand) NewSession(userID string, config domain.SessionConfig) (*domain.Session, error) {
+	if c.RememberMe {
+		return domain.NewPersistentSession(userID, c.IPAddress, c.UserAgent, config)
+	}
+	return domain.NewSession(userID, c.IPAddress, c.UserAgent, config)
 }
 
 // Validate performs validation on the LoginCommand
@@ -36,8 +52,15 @@ type RegisterCommand struct {
 	UserAgent string `json:"user_agent,omitempty"`
 }
 
-// Validate performs validation on the RegisterCommand
+// Validate performs validation on the RegisterCommand using the default
+// password policy
 func (c *RegisterCommand) Validate() error {
+	return c.ValidateWithPolicy(domain.DefaultPasswordPolicy())
+}
+
+// ValidateWithPolicy performs validation on the RegisterCommand, checking the
+// password against policy
+func (c *RegisterCommand) ValidateWithPolicy(policy domain.PasswordPolicy) error {
 	if c.Email == "" {
 		return NewValidationError("email", "email is required")
 	}
@@ -57,17 +80,7 @@ func (c *RegisterCommand) Validate() error {
 	}
 
 	// Validate password strength
-	validator := domain.NewPasswordValidator()
-	if err := validator.Validate(c.Password); err != nil {
-		return NewValidationError("password", err.Error())
-	}
-
-	// Check for common passwords
-	if domain.IsCommonPassword(c.Password) {
-		return NewValidationError("password", "password is too common, please choose a stronger password")
-	}
-
-	return nil
+	return validatePasswordField("password", c.Password, policy)
 }
 
 // LogoutCommand represents a command to logout a user
@@ -123,8 +136,15 @@ type ChangePasswordCommand struct {
 	UserAgent   string `json:"user_agent,omitempty"`
 }
 
-// Validate performs validation on the ChangePasswordCommand
+// Validate performs validation on the ChangePasswordCommand using the default
+// password policy
 func (c *ChangePasswordCommand) Validate() error {
+	return c.ValidateWithPolicy(domain.DefaultPasswordPolicy())
+}
+
+// ValidateWithPolicy performs validation on the ChangePasswordCommand,
+// checking the new password against policy
+func (c *ChangePasswordCommand) ValidateWithPolicy(policy domain.PasswordPolicy) error {
 	if c.UserID == "" {
 		return NewValidationError("user_id", "user ID is required")
 	}
@@ -135,21 +155,165 @@ func (c *ChangePasswordCommand) Validate() error {
 		return NewValidationError("new_password", "new password is required")
 	}
 
+	// Ensure new password is different from old password
+	if c.OldPassword == c.NewPassword {
+		return NewValidationError("new_password", "new password must be different from the current password")
+	}
+
 	// Validate new password strength
-	validator := domain.NewPasswordValidator()
-	if err := validator.Validate(c.NewPassword); err != nil {
-		return NewValidationError("new_password", err.Error())
+	return validatePasswordField("new_password", c.NewPassword, policy)
+}
+
+// validatePasswordField checks password against policy, returning an
+// *errors.ErrorList of violations tagged with the request field they refer to
+func validatePasswordField(field, password string, policy domain.PasswordPolicy) error {
+	err := domain.ValidatePassword(password, policy)
+	if violations, ok := err.(*appErrors.ErrorList); ok {
+		for _, violation := range violations.Errors {
+			violation.WithDetails(map[string]interface{}{"field": field})
+		}
 	}
+	return err
+}
 
-	// Check for common passwords
-	if domain.IsCommonPassword(c.NewPassword) {
-		return NewValidationError("new_password", "password is too common, please choose a stronger password")
+// RevokeSessionCommand represents a command to revoke one of a user's sessions
+type RevokeSessionCommand struct {
+	UserID    string `json:"user_id" validate:"required"`
+	SessionID string `json:"session_id" validate:"required"`
+}
+
+// Validate performs validation on the RevokeSessionCommand
+func (c *RevokeSessionCommand) Validate() error {
+	if c.UserID == "" {
+		return NewValidationError("user_id", "user ID is required")
+	}
+	if c.SessionID == "" {
+		return NewValidationError("session_id", "session ID is required")
 	}
+	return nil
+}
 
-	// Ensure new password is different from old password
-	if c.OldPassword == c.NewPassword {
-		return NewValidationError("new_password", "new password must be different from the current password")
+// RevokeOtherSessionsCommand represents a command to revoke all of a user's
+// sessions except the current one
+type RevokeOtherSessionsCommand struct {
+	UserID           string `json:"user_id" validate:"required"`
+	CurrentSessionID string `json:"current_session_id" validate:"required"`
+}
+
+// Validate performs validation on the RevokeOtherSessionsCommand
+func (c *RevokeOtherSessionsCommand) Validate() error {
+	if c.UserID == "" {
+		return NewValidationError("user_id", "user ID is required")
 	}
+	if c.CurrentSessionID == "" {
+		return NewValidationError("current_session_id", "current session ID is required")
+	}
+	return nil
+}
 
+// RequestPasswordResetCommand represents a command to request a password
+// reset token for an email address
+type RequestPasswordResetCommand struct {
+	Email     string `json:"email" validate:"required,email,max=255"`
+	IPAddress string `json:"ip_address,omitempty"`
+}
+
+// Validate performs validation on the RequestPasswordResetCommand
+func (c *RequestPasswordResetCommand) Validate() error {
+	if c.Email == "" {
+		return NewValidationError("email", "email is required")
+	}
+	if err := domain.ValidateEmail(c.Email); err != nil {
+		return NewValidationError("email", err.Error())
+	}
+	return nil
+}
+
+// ResetPasswordCommand represents a command to set a new password using a
+// password reset token
+type ResetPasswordCommand struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8,max=128"`
+	IPAddress   string `json:"ip_address,omitempty"`
+	UserAgent   string `json:"user_agent,omitempty"`
+}
+
+// Validate performs validation on the ResetPasswordCommand using the default
+// password policy
+func (c *ResetPasswordCommand) Validate() error {
+	return c.ValidateWithPolicy(domain.DefaultPasswordPolicy())
+}
+
+// ValidateWithPolicy performs validation on the ResetPasswordCommand,
+// checking the new password against policy
+func (c *ResetPasswordCommand) ValidateWithPolicy(policy domain.PasswordPolicy) error {
+	if c.Token == "" {
+		return NewValidationError("token", "reset token is required")
+	}
+	if c.NewPassword == "" {
+		return NewValidationError("new_password", "new password is required")
+	}
+
+	// Validate new password strength
+	return validatePasswordField("new_password", c.NewPassword, policy)
+}
+
+// CreateAPIKeyCommand represents a command to create an API key for a user
+type CreateAPIKeyCommand struct {
+	UserID string   `json:"user_id" validate:"required"`
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes,omitempty"`
+
+	// ExpiresIn is how long the key is valid for; zero creates a key that
+	// never expires
+	ExpiresIn time.Duration `json:"expires_in,omitempty"`
+}
+
+// Validate performs validation on the CreateAPIKeyCommand
+func (c *CreateAPIKeyCommand) Validate() error {
+	if c.UserID == "" {
+		return NewValidationError("user_id", "user ID is required")
+	}
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		return NewValidationError("name", "name is required")
+	}
+	if len(name) > 100 {
+		return NewValidationError("name", "name cannot exceed 100 characters")
+	}
+	if err := validateScopes(c.Scopes); err != nil {
+		return err
+	}
+	if c.ExpiresIn < 0 {
+		return NewValidationError("expires_in", "expiry cannot be negative")
+	}
+	return nil
+}
+
+// validateScopes checks that scopes can be granted to an API key or token:
+// non-empty names without whitespace or commas
+func validateScopes(scopes []string) error {
+	for _, scope := range scopes {
+		if scope == "" || len(scope) > 50 || strings.ContainsAny(scope, " \t\r\n,") {
+			return NewValidationError("scopes", fmt.Sprintf("invalid scope %q", scope))
+		}
+	}
+	return nil
+}
+
+// RevokeAPIKeyCommand represents a command to revoke one of a user's API keys
+type RevokeAPIKeyCommand struct {
+	UserID string `json:"user_id" validate:"required"`
+	KeyID  string `json:"key_id" validate:"required"`
+}
+
+// Validate performs validation on the RevokeAPIKeyCommand
+func (c *RevokeAPIKeyCommand) Validate() error {
+	if c.UserID == "" {
+		return NewValidationError("user_id", "user ID is required")
+	}
+	if c.KeyID == "" {
+		return NewValidationError("key_id", "key ID is required")
+	}
 	return nil
 }
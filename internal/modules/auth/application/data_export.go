@@ -0,0 +1,41 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"go-templ-template/internal/modules/user/application"
+)
+
+// exportedSession is the part of a session included in a user's data export.
+// Session IDs are left out as they authenticate the user.
+type exportedSession struct {
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	IsActive  bool      `json:"is_active"`
+}
+
+// NewSessionsExportSource creates a data export source listing a user's
+// active sessions
+func NewSessionsExportSource(sessionRepo SessionRepository) application.DataExportSource {
+	return application.NewDataExportSource("sessions", func(ctx context.Context, userID string) (interface{}, error) {
+		sessions, err := sessionRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		exported := make([]exportedSession, 0, len(sessions))
+		for _, session := range sessions {
+			exported = append(exported, exportedSession{
+				CreatedAt: session.CreatedAt,
+				ExpiresAt: session.ExpiresAt,
+				IPAddress: session.IPAddress,
+				UserAgent: session.UserAgent,
+				IsActive:  session.IsActive,
+			})
+		}
+		return exported, nil
+	})
+}
@@ -63,6 +63,13 @@ const (
 	ErrorCodeRateLimitExceeded  = "RATE_LIMIT_EXCEEDED"
 	ErrorCodeAccountLocked      = "ACCOUNT_LOCKED"
 	ErrorCodeAccountSuspended   = "ACCOUNT_SUSPENDED"
+	ErrorCodeResetTokenInvalid  = "RESET_TOKEN_INVALID"
+	ErrorCodeResetTokenExpired  = "RESET_TOKEN_EXPIRED"
+	ErrorCodeResetTokenUsed     = "RESET_TOKEN_USED"
+	ErrorCodeTokenInvalid       = "TOKEN_INVALID"
+	ErrorCodeTokenExpired       = "TOKEN_EXPIRED"
+	ErrorCodeAPIKeyInvalid      = "API_KEY_INVALID"
+	ErrorCodeAPIKeyNotFound     = "API_KEY_NOT_FOUND"
 	ErrorCodeValidationFailed   = "VALIDATION_FAILED"
 	ErrorCodeInternalError      = "INTERNAL_ERROR"
 )
@@ -169,6 +176,36 @@ func NewAccountSuspendedError() *AuthError {
 	}
 }
 
+// NewResetTokenInvalidError creates a new invalid password reset token error
+func NewResetTokenInvalidError() *AuthError {
+	return &AuthError{
+		Code:    ErrorCodeResetTokenInvalid,
+		Message: "Password reset token is invalid",
+		Type:    ErrorTypeValidation,
+		Field:   "token",
+	}
+}
+
+// NewResetTokenExpiredError creates a new expired password reset token error
+func NewResetTokenExpiredError() *AuthError {
+	return &AuthError{
+		Code:    ErrorCodeResetTokenExpired,
+		Message: "Password reset token has expired, please request a new one",
+		Type:    ErrorTypeValidation,
+		Field:   "token",
+	}
+}
+
+// NewResetTokenUsedError creates a new used password reset token error
+func NewResetTokenUsedError() *AuthError {
+	return &AuthError{
+		Code:    ErrorCodeResetTokenUsed,
+		Message: "Password reset token has already been used",
+		Type:    ErrorTypeValidation,
+		Field:   "token",
+	}
+}
+
 // NewInternalError creates a new internal error
 func NewInternalError(message string) *AuthError {
 	return &AuthError{
@@ -178,6 +215,42 @@ func NewInternalError(message string) *AuthError {
 	}
 }
 
+// NewTokenInvalidError creates a new invalid access or refresh token error
+func NewTokenInvalidError() *AuthError {
+	return &AuthError{
+		Code:    ErrorCodeTokenInvalid,
+		Message: "Token is invalid",
+		Type:    ErrorTypeAuthentication,
+	}
+}
+
+// NewTokenExpiredError creates a new expired access or refresh token error
+func NewTokenExpiredError() *AuthError {
+	return &AuthError{
+		Code:    ErrorCodeTokenExpired,
+		Message: "Token has expired",
+		Type:    ErrorTypeAuthentication,
+	}
+}
+
+// NewAPIKeyInvalidError creates a new unknown, revoked or expired API key error
+func NewAPIKeyInvalidError() *AuthError {
+	return &AuthError{
+		Code:    ErrorCodeAPIKeyInvalid,
+		Message: "API key is invalid, revoked or expired",
+		Type:    ErrorTypeAuthentication,
+	}
+}
+
+// NewAPIKeyNotFoundError creates a new API key not found error
+func NewAPIKeyNotFoundError(keyID string) *AuthError {
+	return &AuthError{
+		Code:    ErrorCodeAPIKeyNotFound,
+		Message: "API key not found",
+		Type:    ErrorTypeNotFound,
+	}
+}
+
 // IsValidationError checks if the error is a validation error
 func IsValidationError(err error) bool {
 	if authErr, ok := err.(*AuthError); ok {
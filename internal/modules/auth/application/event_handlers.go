@@ -225,9 +225,9 @@ func (h *UserUpdatedEventHandler) handleUserStatusChange(ctx context.Context, us
 
 	newStatus, _ := statusMap["new"].(string)
 
-	// If user is suspended or deactivated, invalidate all their sessions
-	if newStatus == "suspended" || newStatus == "inactive" {
-		h.logger.Info("User status changed to inactive/suspended, invalidating sessions",
+	// If user is suspended, locked or deactivated, invalidate all their sessions
+	if newStatus == "suspended" || newStatus == "locked" || newStatus == "inactive" {
+		h.logger.Info("User status changed to inactive/suspended/locked, invalidating sessions",
 			"user_id", userID,
 			"new_status", newStatus,
 		)
@@ -49,6 +49,11 @@ func (m *MockAuthService) RefreshSession(ctx context.Context, cmd *RefreshSessio
 	return args.Get(0).(*domain.Session), args.Error(1)
 }
 
+func (m *MockAuthService) TouchSession(ctx context.Context, session *domain.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
 func (m *MockAuthService) ChangePassword(ctx context.Context, cmd *ChangePasswordCommand) error {
 	args := m.Called(ctx, cmd)
 	return args.Error(0)
@@ -64,6 +69,16 @@ func (m *MockAuthService) GetUserSessions(ctx context.Context, userID string) ([
 	return args.Get(0).([]*domain.Session), args.Error(1)
 }
 
+func (m *MockAuthService) RevokeSession(ctx context.Context, cmd *RevokeSessionCommand) error {
+	args := m.Called(ctx, cmd)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) RevokeOtherSessions(ctx context.Context, cmd *RevokeOtherSessionsCommand) (int, error) {
+	args := m.Called(ctx, cmd)
+	return args.Int(0), args.Error(1)
+}
+
 // MockAuditLogger is a mock implementation of AuditLogger for testing
 type MockAuditLogger struct {
 	mock.Mock
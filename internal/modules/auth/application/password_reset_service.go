@@ -0,0 +1,218 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-templ-template/internal/modules/auth/domain"
+	"go-templ-template/internal/modules/user/application"
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/events"
+)
+
+// PasswordResetService defines the interface for resetting forgotten passwords
+type PasswordResetService interface {
+	// RequestPasswordReset issues a reset token for the account with the
+	// command's email. Unknown and inactive accounts are ignored so callers
+	// can't tell which emails are registered; no token is returned for them.
+	RequestPasswordReset(ctx context.Context, cmd *RequestPasswordResetCommand) (*domain.PasswordResetToken, error)
+
+	// ResetPassword sets a new password using a reset token and revokes all
+	// of the user's sessions
+	ResetPassword(ctx context.Context, cmd *ResetPasswordCommand) error
+}
+
+// PasswordResetTokenRepository defines the interface for password reset
+// token data access
+type PasswordResetTokenRepository interface {
+	Create(ctx context.Context, token *domain.PasswordResetToken) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error)
+	// MarkUsed records a token's use, returning database.ErrNotFound if it
+	// was already used
+	MarkUsed(ctx context.Context, tokenID string, usedAt time.Time) error
+	DeleteByUserID(ctx context.Context, userID string) error
+	DeleteExpired(ctx context.Context) error
+}
+
+// passwordResetServiceImpl implements the PasswordResetService interface
+type passwordResetServiceImpl struct {
+	tokenRepo       PasswordResetTokenRepository
+	sessionRepo     SessionRepository
+	userService     application.UserService
+	eventBus        events.EventBus
+	db              *database.DB
+	rateLimiter     RateLimiter
+	passwordPolicy  domain.PasswordPolicy
+	emailNormalizer userDomain.EmailNormalizer
+	tokenDuration   time.Duration
+}
+
+// NewPasswordResetService creates a new password reset service instance
+func NewPasswordResetService(
+	tokenRepo PasswordResetTokenRepository,
+	sessionRepo SessionRepository,
+	userService application.UserService,
+	eventBus events.EventBus,
+	db *database.DB,
+	rateLimiter RateLimiter,
+	passwordPolicy domain.PasswordPolicy,
+	emailNormalizer userDomain.EmailNormalizer,
+	tokenDuration time.Duration,
+) PasswordResetService {
+	if tokenDuration <= 0 {
+		tokenDuration = time.Hour // Default 1 hour
+	}
+
+	return &passwordResetServiceImpl{
+		tokenRepo:       tokenRepo,
+		sessionRepo:     sessionRepo,
+		userService:     userService,
+		eventBus:        eventBus,
+		db:              db,
+		rateLimiter:     rateLimiter,
+		passwordPolicy:  passwordPolicy,
+		emailNormalizer: emailNormalizer,
+		tokenDuration:   tokenDuration,
+	}
+}
+
+// RequestPasswordReset issues a password reset token
+func (s *passwordResetServiceImpl) RequestPasswordReset(ctx context.Context, cmd *RequestPasswordResetCommand) (*domain.PasswordResetToken, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Rate limiting by email, so an address can't be flooded with emails
+	rateLimitKey := fmt.Sprintf("password_reset:%s", s.emailNormalizer.Normalize(cmd.Email))
+	allowed, err := s.rateLimiter.Allow(ctx, rateLimitKey)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, err // Rate limiter already returns appropriate error
+	}
+
+	// Get user by email
+	userQuery := &application.GetUserByEmailQuery{Email: cmd.Email}
+	user, err := s.userService.GetUserByEmail(ctx, userQuery)
+	if err != nil {
+		if application.IsUserNotFoundError(err) {
+			// Don't reveal whether user exists or not
+			return nil, nil
+		}
+		return nil, NewInternalError(fmt.Sprintf("failed to get user: %v", err))
+	}
+
+	// Only active accounts can reset their password
+	if !user.IsActive() {
+		return nil, nil
+	}
+
+	var token *domain.PasswordResetToken
+	// Execute in transaction
+	err = database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
+		// Replace any outstanding tokens for this user
+		if err := s.tokenRepo.DeleteByUserID(txCtx, user.ID); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to delete existing reset tokens: %v", err))
+		}
+
+		var err error
+		token, err = domain.NewPasswordResetToken(user.ID, s.tokenDuration)
+		if err != nil {
+			return NewInternalError(err.Error())
+		}
+
+		if err := s.tokenRepo.Create(txCtx, token); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to save reset token: %v", err))
+		}
+
+		// Publish password reset requested event, which delivers the token
+		event := domain.NewPasswordResetRequestedEvent(user.ID, user.Email, token, cmd.IPAddress)
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish password reset requested event: %v", err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ResetPassword sets a new password using a reset token
+func (s *passwordResetServiceImpl) ResetPassword(ctx context.Context, cmd *ResetPasswordCommand) error {
+	if err := cmd.ValidateWithPolicy(s.passwordPolicy); err != nil {
+		return err
+	}
+
+	// Execute in transaction
+	return database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
+		// Get reset token
+		token, err := s.tokenRepo.GetByTokenHash(txCtx, domain.HashPasswordResetToken(cmd.Token))
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return NewResetTokenInvalidError()
+			}
+			return NewInternalError(fmt.Sprintf("failed to get reset token: %v", err))
+		}
+
+		// Validate token
+		if token.IsUsed() {
+			return NewResetTokenUsedError()
+		}
+		if token.IsExpired() {
+			return NewResetTokenExpiredError()
+		}
+
+		// Mark token as used first, which fails if a concurrent request used it
+		if err := s.tokenRepo.MarkUsed(txCtx, token.ID, time.Now().UTC()); err != nil {
+			if database.IsNotFoundError(err) {
+				return NewResetTokenUsedError()
+			}
+			return NewInternalError(fmt.Sprintf("failed to update reset token: %v", err))
+		}
+
+		// Set the new password using user service
+		resetCmd := &application.ResetUserPasswordCommand{
+			ID:          token.UserID,
+			NewPassword: cmd.NewPassword,
+		}
+		if _, err := s.userService.ResetUserPassword(txCtx, resetCmd); err != nil {
+			switch {
+			case application.IsUserNotFoundError(err):
+				return NewResetTokenInvalidError()
+			case application.IsValidationError(err):
+				return NewValidationError("new_password", err.Error())
+			default:
+				return NewInternalError(fmt.Sprintf("failed to reset password: %v", err))
+			}
+		}
+
+		// Revoke all sessions, which may belong to whoever knew the old password
+		sessions, err := s.sessionRepo.GetByUserID(txCtx, token.UserID)
+		if err != nil {
+			return NewInternalError(fmt.Sprintf("failed to get user sessions: %v", err))
+		}
+		if err := s.sessionRepo.DeleteByUserID(txCtx, token.UserID); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to delete user sessions: %v", err))
+		}
+		for _, session := range sessions {
+			event := domain.NewUserLoggedOutEvent(session.UserID, session.ID, "forced")
+			if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+				return NewInternalError(fmt.Sprintf("failed to publish logout event: %v", err))
+			}
+		}
+
+		// Publish password changed event
+		event := domain.NewPasswordChangedEvent(token.UserID, cmd.IPAddress, cmd.UserAgent)
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish password changed event: %v", err))
+		}
+
+		return nil
+	})
+}
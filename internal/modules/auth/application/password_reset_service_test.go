@@ -0,0 +1,302 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/auth/domain"
+	"go-templ-template/internal/modules/user/application"
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/modules/user/infrastructure"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryPasswordResetTokenRepository is an in-memory
+// PasswordResetTokenRepository with the same single-use semantics as the SQL
+// implementation
+type memoryPasswordResetTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[string]*domain.PasswordResetToken
+}
+
+func newMemoryPasswordResetTokenRepository() *memoryPasswordResetTokenRepository {
+	return &memoryPasswordResetTokenRepository{tokens: make(map[string]*domain.PasswordResetToken)}
+}
+
+func (r *memoryPasswordResetTokenRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *token
+	stored.Token = ""
+	r.tokens[token.TokenHash] = &stored
+	return nil
+}
+
+func (r *memoryPasswordResetTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.tokens[tokenHash]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	found := *stored
+	return &found, nil
+}
+
+func (r *memoryPasswordResetTokenRepository) MarkUsed(ctx context.Context, tokenID string, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, stored := range r.tokens {
+		if stored.ID == tokenID && stored.UsedAt == nil {
+			stored.UsedAt = &usedAt
+			return nil
+		}
+	}
+	return database.ErrNotFound
+}
+
+func (r *memoryPasswordResetTokenRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, stored := range r.tokens {
+		if stored.UserID == userID {
+			delete(r.tokens, key)
+		}
+	}
+	return nil
+}
+
+func (r *memoryPasswordResetTokenRepository) DeleteExpired(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, stored := range r.tokens {
+		if stored.IsExpired() {
+			delete(r.tokens, key)
+		}
+	}
+	return nil
+}
+
+func newPasswordResetTestService(db *database.DB, userService *mockUserService, sessionRepo *mockSessionRepository) (PasswordResetService, *memoryPasswordResetTokenRepository) {
+	eventBus := &mockEventBus{}
+	eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+	tokenRepo := newMemoryPasswordResetTokenRepository()
+	service := NewPasswordResetService(
+		tokenRepo,
+		sessionRepo,
+		userService,
+		eventBus,
+		db,
+		NewInMemoryRateLimiter(DefaultRateLimiterConfig()),
+		domain.DefaultPasswordPolicy(),
+		userDomain.NewEmailNormalizer(false),
+		time.Hour,
+	)
+	return service, tokenRepo
+}
+
+// newPasswordResetTestDB returns a stub database, which the service needs
+// for the transaction around each operation
+func newPasswordResetTestDB(t *testing.T) *database.DB {
+	t.Helper()
+
+	return database.NewStubDB().DB
+}
+
+// resetUserRepository is a user repository holding a single user, enough
+// for the real user service to look it up and reset its password
+type resetUserRepository struct {
+	infrastructure.UserRepository
+	user *userDomain.User
+}
+
+func (r *resetUserRepository) GetByID(ctx context.Context, id string) (*userDomain.User, error) {
+	if id != r.user.ID {
+		return nil, database.ErrNotFound
+	}
+	return r.user, nil
+}
+
+func (r *resetUserRepository) GetByEmail(ctx context.Context, email string) (*userDomain.User, error) {
+	if email != r.user.Email {
+		return nil, database.ErrNotFound
+	}
+	return r.user, nil
+}
+
+func (r *resetUserRepository) Update(ctx context.Context, user *userDomain.User) error {
+	r.user = user
+	return nil
+}
+
+func TestPasswordResetService_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	userService := &mockUserService{}
+	userService.On("GetUserByEmail", mock.Anything, mock.Anything).
+		Return(nil, application.NewUserNotFoundError("unknown@example.com"))
+
+	service, tokenRepo := newPasswordResetTestService(nil, userService, &mockSessionRepository{})
+
+	token, err := service.RequestPasswordReset(context.Background(), &RequestPasswordResetCommand{Email: "unknown@example.com"})
+	require.NoError(t, err)
+	assert.Nil(t, token)
+	assert.Empty(t, tokenRepo.tokens)
+}
+
+func TestPasswordResetService_RequestPasswordReset_InactiveUser(t *testing.T) {
+	user := createTestUser()
+	require.NoError(t, user.Suspend())
+
+	userService := &mockUserService{}
+	userService.On("GetUserByEmail", mock.Anything, mock.Anything).Return(user, nil)
+
+	service, tokenRepo := newPasswordResetTestService(nil, userService, &mockSessionRepository{})
+
+	token, err := service.RequestPasswordReset(context.Background(), &RequestPasswordResetCommand{Email: user.Email})
+	require.NoError(t, err)
+	assert.Nil(t, token)
+	assert.Empty(t, tokenRepo.tokens)
+}
+
+func TestPasswordResetService_ResetPassword_RevokesSessions(t *testing.T) {
+	db := newPasswordResetTestDB(t)
+
+	user := createTestUser()
+	sessions := []*domain.Session{createTestSession(user.ID), createTestSession(user.ID)}
+
+	userService := &mockUserService{}
+	userService.On("GetUserByEmail", mock.Anything, mock.Anything).Return(user, nil)
+	userService.On("ResetUserPassword", mock.Anything, mock.MatchedBy(func(cmd *application.ResetUserPasswordCommand) bool {
+		return cmd.ID == user.ID && cmd.NewPassword == "NewPassword456!"
+	})).Return(user, nil).Once()
+
+	sessionRepo := &mockSessionRepository{}
+	sessionRepo.On("GetByUserID", mock.Anything, user.ID).Return(sessions, nil)
+	sessionRepo.On("DeleteByUserID", mock.Anything, user.ID).Return(nil).Once()
+
+	service, tokenRepo := newPasswordResetTestService(db, userService, sessionRepo)
+
+	token, err := service.RequestPasswordReset(context.Background(), &RequestPasswordResetCommand{Email: user.Email})
+	require.NoError(t, err)
+	require.NotNil(t, token)
+	assert.NotEqual(t, token.Token, token.TokenHash)
+
+	err = service.ResetPassword(context.Background(), &ResetPasswordCommand{Token: token.Token, NewPassword: "NewPassword456!"})
+	require.NoError(t, err)
+
+	stored, err := tokenRepo.GetByTokenHash(context.Background(), token.TokenHash)
+	require.NoError(t, err)
+	assert.True(t, stored.IsUsed())
+	userService.AssertExpectations(t)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestPasswordResetService_ResetPassword_OutboxBus(t *testing.T) {
+	db := database.NewStubDB()
+	user := createTestUser()
+
+	inner := &mockEventBus{}
+	eventBus := events.NewOutboxEventBus(inner, db.DB, events.DefaultOutboxConfig())
+	userService := application.NewUserService(&resetUserRepository{user: user}, eventBus, db.DB)
+
+	sessionRepo := &mockSessionRepository{}
+	sessionRepo.On("GetByUserID", mock.Anything, user.ID).Return([]*domain.Session{createTestSession(user.ID)}, nil)
+	sessionRepo.On("DeleteByUserID", mock.Anything, user.ID).Return(nil)
+
+	service := NewPasswordResetService(
+		newMemoryPasswordResetTokenRepository(),
+		sessionRepo,
+		userService,
+		eventBus,
+		db.DB,
+		NewInMemoryRateLimiter(DefaultRateLimiterConfig()),
+		domain.DefaultPasswordPolicy(),
+		userDomain.NewEmailNormalizer(false),
+		time.Hour,
+	)
+
+	ctx := context.Background()
+	token, err := service.RequestPasswordReset(ctx, &RequestPasswordResetCommand{Email: user.Email})
+	require.NoError(t, err)
+	require.NotNil(t, token)
+
+	err = service.ResetPassword(ctx, &ResetPasswordCommand{Token: token.Token, NewPassword: "NewPassword456!"})
+	require.NoError(t, err)
+
+	// Every event, including the one the user service raises in the nested
+	// transaction, is written to the outbox before its transaction commits
+	var written []string
+	for _, statement := range db.Statements() {
+		if strings.Contains(statement, "INSERT INTO event_outbox") {
+			statement = "outbox"
+		}
+		written = append(written, statement)
+	}
+	assert.Equal(t, []string{"outbox", "COMMIT", "outbox", "outbox", "outbox", "COMMIT"}, written)
+	inner.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestPasswordResetService_ResetPassword_RejectedTokens(t *testing.T) {
+	db := newPasswordResetTestDB(t)
+
+	tests := []struct {
+		name    string
+		prepare func(token *domain.PasswordResetToken)
+		code    string
+	}{
+		{
+			name: "expired token",
+			prepare: func(token *domain.PasswordResetToken) {
+				token.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+			},
+			code: ErrorCodeResetTokenExpired,
+		},
+		{
+			name: "used token",
+			prepare: func(token *domain.PasswordResetToken) {
+				usedAt := time.Now().UTC()
+				token.UsedAt = &usedAt
+			},
+			code: ErrorCodeResetTokenUsed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := createTestUser()
+			userService := &mockUserService{}
+			userService.On("GetUserByEmail", mock.Anything, mock.Anything).Return(user, nil)
+			sessionRepo := &mockSessionRepository{}
+
+			service, tokenRepo := newPasswordResetTestService(db, userService, sessionRepo)
+
+			token, err := service.RequestPasswordReset(context.Background(), &RequestPasswordResetCommand{Email: user.Email})
+			require.NoError(t, err)
+			tt.prepare(tokenRepo.tokens[token.TokenHash])
+
+			err = service.ResetPassword(context.Background(), &ResetPasswordCommand{Token: token.Token, NewPassword: "NewPassword456!"})
+			require.Error(t, err)
+			assert.Equal(t, tt.code, err.(*AuthError).Code)
+			userService.AssertNotCalled(t, "ResetUserPassword", mock.Anything, mock.Anything)
+			sessionRepo.AssertNotCalled(t, "DeleteByUserID", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPasswordResetService_ResetPassword_UnknownToken(t *testing.T) {
+	db := newPasswordResetTestDB(t)
+
+	service, _ := newPasswordResetTestService(db, &mockUserService{}, &mockSessionRepository{})
+
+	err := service.ResetPassword(context.Background(), &ResetPasswordCommand{Token: "unknown-token", NewPassword: "NewPassword456!"})
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeResetTokenInvalid, err.(*AuthError).Code)
+}
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 )
@@ -19,6 +20,21 @@ type RateLimiter interface {
 	GetAttempts(ctx context.Context, key string) (int, error)
 }
 
+// RateLimitQuota describes the state of the rate limit for a key
+type RateLimitQuota struct {
+	Limit      int           // Maximum requests allowed per Window
+	Remaining  int           // Requests left before the key is blocked
+	Window     time.Duration // Period the limit applies to
+	ResetAt    time.Time     // When the full quota is restored
+	RetryAfter time.Duration // How long a blocked key must wait, zero if not blocked
+}
+
+// QuotaReporter is implemented by rate limiters that can report the quota
+// for a key, e.g. for X-RateLimit-* response headers
+type QuotaReporter interface {
+	Quota(ctx context.Context, key string) (RateLimitQuota, error)
+}
+
 // InMemoryRateLimiter implements RateLimiter using in-memory storage
 type InMemoryRateLimiter struct {
 	attempts map[string]*attemptRecord
@@ -125,7 +141,8 @@ func (r *InMemoryRateLimiter) Reset(ctx context.Context, key string) error {
 	return nil
 }
 
-// GetAttempts returns the current number of attempts for the given key
+// GetAttempts returns the number of attempts for the given key in the
+// current window or lockout
 func (r *InMemoryRateLimiter) GetAttempts(ctx context.Context, key string) (int, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
@@ -135,9 +152,54 @@ func (r *InMemoryRateLimiter) GetAttempts(ctx context.Context, key string) (int,
 		return 0, nil
 	}
 
+	// Expired windows and lockouts no longer count against the key
+	now := time.Now()
+	if record.lockedUntil != nil {
+		if now.Before(*record.lockedUntil) {
+			return record.count, nil
+		}
+		return 0, nil
+	}
+	if now.Sub(record.firstAttempt) > r.config.Window {
+		return 0, nil
+	}
+
 	return record.count, nil
 }
 
+// Quota returns the quota for the given key
+func (r *InMemoryRateLimiter) Quota(ctx context.Context, key string) (RateLimitQuota, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	now := time.Now()
+	quota := RateLimitQuota{
+		Limit:     r.config.MaxAttempts,
+		Remaining: r.config.MaxAttempts,
+		Window:    r.config.Window,
+		ResetAt:   now,
+	}
+
+	record, exists := r.attempts[key]
+	if !exists {
+		return quota, nil
+	}
+
+	if record.lockedUntil != nil && now.Before(*record.lockedUntil) {
+		quota.Remaining = 0
+		quota.ResetAt = *record.lockedUntil
+		quota.RetryAfter = record.lockedUntil.Sub(now)
+		return quota, nil
+	}
+
+	if now.Sub(record.firstAttempt) <= r.config.Window {
+		quota.Remaining = max(r.config.MaxAttempts-record.count, 0)
+		quota.ResetAt = record.firstAttempt.Add(r.config.Window)
+	}
+
+	return quota, nil
+}
+
 // cleanup removes expired entries from the attempts map
 func (r *InMemoryRateLimiter) cleanup() {
 	ticker := time.NewTicker(time.Minute * 5) // Cleanup every 5 minutes
@@ -159,6 +221,144 @@ func (r *InMemoryRateLimiter) cleanup() {
 	}
 }
 
+// TokenBucketRateLimiter implements RateLimiter with a token bucket per key.
+// Each key starts with Capacity tokens, every request consumes one, and a
+// token is added back every RefillInterval. Unlike InMemoryRateLimiter it
+// smooths bursts rather than locking keys out, which suits per-request HTTP
+// throttling.
+type TokenBucketRateLimiter struct {
+	buckets map[string]*tokenBucket
+	mutex   sync.Mutex
+	config  TokenBucketConfig
+	now     func() time.Time
+}
+
+// TokenBucketConfig holds configuration for token bucket rate limiting
+type TokenBucketConfig struct {
+	Capacity       int           // Maximum burst of requests
+	RefillInterval time.Duration // Time to regain one token
+}
+
+// tokenBucket tracks the tokens available for a specific key
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// NewTokenBucketRateLimiter creates a new in-memory token bucket rate limiter
+func NewTokenBucketRateLimiter(config TokenBucketConfig) *TokenBucketRateLimiter {
+	limiter := &TokenBucketRateLimiter{
+		buckets: make(map[string]*tokenBucket),
+		config:  config,
+		now:     time.Now,
+	}
+
+	// Start cleanup goroutine
+	go limiter.cleanup()
+
+	return limiter
+}
+
+// Allow consumes a token for the given key if one is available
+func (r *TokenBucketRateLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	bucket := r.refill(key)
+	if bucket.tokens < 1 {
+		return false, NewRateLimitExceededError(
+			fmt.Sprintf("Too many requests. Try again after %v",
+				r.retryAfter(bucket).Round(time.Second)))
+	}
+
+	bucket.tokens--
+	return true, nil
+}
+
+// Reset refills the bucket for the given key
+func (r *TokenBucketRateLimiter) Reset(ctx context.Context, key string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.buckets, key)
+	return nil
+}
+
+// GetAttempts returns the number of tokens consumed from the given key's bucket
+func (r *TokenBucketRateLimiter) GetAttempts(ctx context.Context, key string) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	bucket := r.refill(key)
+	return r.config.Capacity - int(math.Floor(bucket.tokens)), nil
+}
+
+// Quota returns the quota for the given key
+func (r *TokenBucketRateLimiter) Quota(ctx context.Context, key string) (RateLimitQuota, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	bucket := r.refill(key)
+	missing := float64(r.config.Capacity) - bucket.tokens
+
+	return RateLimitQuota{
+		Limit:      r.config.Capacity,
+		Remaining:  int(math.Floor(bucket.tokens)),
+		Window:     time.Duration(r.config.Capacity) * r.config.RefillInterval,
+		ResetAt:    bucket.lastRefill.Add(time.Duration(missing * float64(r.config.RefillInterval))),
+		RetryAfter: r.retryAfter(bucket),
+	}, nil
+}
+
+// refill tops up the bucket for key with the tokens earned since its last
+// refill, creating a full bucket for new keys. Callers hold r.mutex.
+func (r *TokenBucketRateLimiter) refill(key string) *tokenBucket {
+	now := r.now()
+	capacity := float64(r.config.Capacity)
+
+	bucket, exists := r.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: capacity, lastRefill: now}
+		r.buckets[key] = bucket
+		return bucket
+	}
+
+	if r.config.RefillInterval > 0 {
+		earned := float64(now.Sub(bucket.lastRefill)) / float64(r.config.RefillInterval)
+		bucket.tokens = math.Min(capacity, bucket.tokens+earned)
+	}
+	bucket.lastRefill = now
+
+	return bucket
+}
+
+// retryAfter returns how long until the bucket holds a whole token
+func (r *TokenBucketRateLimiter) retryAfter(bucket *tokenBucket) time.Duration {
+	if bucket.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - bucket.tokens) * float64(r.config.RefillInterval))
+}
+
+// cleanup removes buckets that have refilled completely
+func (r *TokenBucketRateLimiter) cleanup() {
+	ticker := time.NewTicker(time.Minute * 5) // Cleanup every 5 minutes
+	defer ticker.Stop()
+
+	for range ticker.C {
+		r.mutex.Lock()
+		maxAge := time.Duration(r.config.Capacity) * r.config.RefillInterval
+
+		for key, bucket := range r.buckets {
+			if r.now().Sub(bucket.lastRefill) > maxAge {
+				delete(r.buckets, key)
+			}
+		}
+
+		r.mutex.Unlock()
+	}
+}
+
 // DefaultRateLimiterConfig returns a default rate limiter configuration
 func DefaultRateLimiterConfig() RateLimiterConfig {
 	return RateLimiterConfig{
@@ -292,3 +292,142 @@ func TestInMemoryRateLimiter_ConcurrentAccess(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, numGoroutines*attemptsPerGoroutine, attempts)
 }
+
+func TestInMemoryRateLimiter_Quota(t *testing.T) {
+	config := RateLimiterConfig{
+		MaxAttempts: 2,
+		Window:      time.Minute,
+		LockoutTime: time.Minute * 5,
+	}
+	limiter := NewInMemoryRateLimiter(config)
+	ctx := context.Background()
+
+	quota, err := limiter.Quota(ctx, "test-key")
+	require.NoError(t, err)
+	assert.Equal(t, 2, quota.Limit)
+	assert.Equal(t, 2, quota.Remaining)
+	assert.Equal(t, time.Minute, quota.Window)
+
+	_, _ = limiter.Allow(ctx, "test-key")
+	quota, err = limiter.Quota(ctx, "test-key")
+	require.NoError(t, err)
+	assert.Equal(t, 1, quota.Remaining)
+	assert.Zero(t, quota.RetryAfter)
+
+	_, _ = limiter.Allow(ctx, "test-key")
+	_, _ = limiter.Allow(ctx, "test-key")
+	quota, err = limiter.Quota(ctx, "test-key")
+	require.NoError(t, err)
+	assert.Equal(t, 0, quota.Remaining)
+	assert.InDelta(t, (time.Minute * 5).Seconds(), quota.RetryAfter.Seconds(), 1)
+}
+
+// newTestTokenBucket creates a token bucket limiter driven by a fake clock
+func newTestTokenBucket(capacity int, refill time.Duration) (*TokenBucketRateLimiter, *time.Time) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewTokenBucketRateLimiter(TokenBucketConfig{
+		Capacity:       capacity,
+		RefillInterval: refill,
+	})
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestTokenBucketRateLimiter_Allow(t *testing.T) {
+	limiter, _ := newTestTokenBucket(3, time.Second)
+	ctx := context.Background()
+
+	// Burst up to capacity
+	for i := 1; i <= 3; i++ {
+		allowed, err := limiter.Allow(ctx, "test-key")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	allowed, err := limiter.Allow(ctx, "test-key")
+	assert.False(t, allowed)
+	require.Error(t, err)
+	assert.True(t, IsRateLimitError(err))
+
+	attempts, err := limiter.GetAttempts(ctx, "test-key")
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestTokenBucketRateLimiter_Refill(t *testing.T) {
+	limiter, now := newTestTokenBucket(2, time.Second)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, _ = limiter.Allow(ctx, "test-key")
+	}
+	allowed, _ := limiter.Allow(ctx, "test-key")
+	assert.False(t, allowed)
+
+	quota, err := limiter.Quota(ctx, "test-key")
+	require.NoError(t, err)
+	assert.Equal(t, 0, quota.Remaining)
+	assert.Equal(t, time.Second, quota.RetryAfter)
+	assert.Equal(t, now.Add(2*time.Second), quota.ResetAt)
+
+	// One interval restores one token
+	*now = now.Add(time.Second)
+	allowed, err = limiter.Allow(ctx, "test-key")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, _ = limiter.Allow(ctx, "test-key")
+	assert.False(t, allowed)
+
+	// Tokens never exceed capacity
+	*now = now.Add(time.Hour)
+	quota, err = limiter.Quota(ctx, "test-key")
+	require.NoError(t, err)
+	assert.Equal(t, 2, quota.Remaining)
+}
+
+func TestTokenBucketRateLimiter_ResetAndKeys(t *testing.T) {
+	limiter, _ := newTestTokenBucket(1, time.Minute)
+	ctx := context.Background()
+
+	allowed, err := limiter.Allow(ctx, "key-1")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// Other keys have their own bucket
+	allowed, err = limiter.Allow(ctx, "key-2")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, _ = limiter.Allow(ctx, "key-1")
+	assert.False(t, allowed)
+
+	require.NoError(t, limiter.Reset(ctx, "key-1"))
+	allowed, err = limiter.Allow(ctx, "key-1")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestInMemoryRateLimiter_GetAttempts_WindowExpired(t *testing.T) {
+	config := RateLimiterConfig{
+		MaxAttempts: 5,
+		Window:      time.Millisecond * 100,
+		LockoutTime: time.Minute,
+	}
+	limiter := NewInMemoryRateLimiter(config)
+	ctx := context.Background()
+
+	limiter.Allow(ctx, "test-key")
+	limiter.Allow(ctx, "test-key")
+
+	attempts, err := limiter.GetAttempts(ctx, "test-key")
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	// Wait for window to expire
+	time.Sleep(time.Millisecond * 150)
+
+	attempts, err = limiter.GetAttempts(ctx, "test-key")
+	require.NoError(t, err)
+	assert.Equal(t, 0, attempts)
+}
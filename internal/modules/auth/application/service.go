@@ -29,14 +29,25 @@ type AuthService interface {
 	// RefreshSession extends a session's expiration time
 	RefreshSession(ctx context.Context, cmd *RefreshSessionCommand) (*domain.Session, error)
 
+	// TouchSession records activity on a validated session, sliding its
+	// expiry up to the configured maximum duration
+	TouchSession(ctx context.Context, session *domain.Session) error
+
 	// ChangePassword changes a user's password
 	ChangePassword(ctx context.Context, cmd *ChangePasswordCommand) error
 
 	// CleanupExpiredSessions removes expired sessions from storage
 	CleanupExpiredSessions(ctx context.Context) error
 
-	// GetUserSessions returns all active sessions for a user (for testing/admin purposes)
+	// GetUserSessions returns all active sessions for a user
 	GetUserSessions(ctx context.Context, userID string) ([]*domain.Session, error)
+
+	// RevokeSession terminates one of a user's sessions
+	RevokeSession(ctx context.Context, cmd *RevokeSessionCommand) error
+
+	// RevokeOtherSessions terminates all of a user's sessions except the
+	// current one and returns how many were revoked
+	RevokeOtherSessions(ctx context.Context, cmd *RevokeOtherSessionsCommand) (int, error)
 }
 
 // AuthResult represents the result of a successful authentication
@@ -58,6 +69,7 @@ type SessionRepository interface {
 	GetByID(ctx context.Context, sessionID string) (*domain.Session, error)
 	GetByUserID(ctx context.Context, userID string) ([]*domain.Session, error)
 	Update(ctx context.Context, session *domain.Session) error
+	UpdateActivity(ctx context.Context, session *domain.Session) error
 	Delete(ctx context.Context, sessionID string) error
 	DeleteByUserID(ctx context.Context, userID string) error
 	DeleteExpired(ctx context.Context) error
@@ -66,13 +78,24 @@ type SessionRepository interface {
 
 // authServiceImpl implements the AuthService interface
 type authServiceImpl struct {
-	sessionRepo   SessionRepository
-	userService   application.UserService
-	eventBus      events.EventBus
-	db            *database.DB
-	rateLimiter   RateLimiter
-	sessionConfig domain.SessionConfig
-	hasher        *domain.PasswordHasher
+	sessionRepo    SessionRepository
+	userService    application.UserService
+	eventBus       events.EventBus
+	db             *database.DB
+	rateLimiter    RateLimiter
+	sessionConfig  domain.SessionConfig
+	passwordPolicy domain.PasswordPolicy
+	accountLockout AccountLockoutConfig
+	hasher         *domain.PasswordHasher
+}
+
+// AccountLockoutConfig configures locking user accounts after repeated
+// failed logins
+type AccountLockoutConfig struct {
+	// MaxFailedAttempts is the number of failed logins within the login rate
+	// limit window after which the account is locked; zero disables locking.
+	// It must not exceed the rate limiter's MaxAttempts to take effect.
+	MaxFailedAttempts int
 }
 
 // NewAuthService creates a new auth service instance
@@ -83,15 +106,19 @@ func NewAuthService(
 	db *database.DB,
 	rateLimiter RateLimiter,
 	sessionConfig domain.SessionConfig,
+	passwordPolicy domain.PasswordPolicy,
+	accountLockout AccountLockoutConfig,
 ) AuthService {
 	return &authServiceImpl{
-		sessionRepo:   sessionRepo,
-		userService:   userService,
-		eventBus:      eventBus,
-		db:            db,
-		rateLimiter:   rateLimiter,
-		sessionConfig: sessionConfig,
-		hasher:        domain.NewPasswordHasher(),
+		sessionRepo:    sessionRepo,
+		userService:    userService,
+		eventBus:       eventBus,
+		db:             db,
+		rateLimiter:    rateLimiter,
+		sessionConfig:  sessionConfig,
+		passwordPolicy: passwordPolicy,
+		accountLockout: accountLockout,
+		hasher:         domain.NewPasswordHasher(),
 	}
 }
 
@@ -124,6 +151,8 @@ func (s *authServiceImpl) Login(ctx context.Context, cmd *LoginCommand) (*AuthRe
 		switch user.Status {
 		case userDomain.UserStatusSuspended:
 			return nil, NewAccountSuspendedError()
+		case userDomain.UserStatusLocked:
+			return nil, NewAccountLockedError()
 		case userDomain.UserStatusInactive:
 			return nil, NewInvalidCredentialsError()
 		default:
@@ -133,6 +162,9 @@ func (s *authServiceImpl) Login(ctx context.Context, cmd *LoginCommand) (*AuthRe
 
 	// Verify password
 	if !user.CheckPassword(cmd.Password) {
+		if s.lockAfterFailedLogin(ctx, user, rateLimitKey) {
+			return nil, NewAccountLockedError()
+		}
 		return nil, NewInvalidCredentialsError()
 	}
 
@@ -146,7 +178,7 @@ func (s *authServiceImpl) Login(ctx context.Context, cmd *LoginCommand) (*AuthRe
 	// Execute in transaction
 	err = database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
 		// Create new session
-		session, err := domain.NewSession(user.ID, cmd.IPAddress, cmd.UserAgent, s.sessionConfig)
+		session, err := cmd.NewSession(user.ID, s.sessionConfig)
 		if err != nil {
 			return NewInternalError(fmt.Sprintf("failed to create session: %v", err))
 		}
@@ -156,14 +188,24 @@ func (s *authServiceImpl) Login(ctx context.Context, cmd *LoginCommand) (*AuthRe
 			return NewInternalError(fmt.Sprintf("failed to save session: %v", err))
 		}
 
-		// Publish user logged in event
+		// Record the login on the user, at the time published in the event
 		event := domain.NewUserLoggedInEvent(user.ID, session.ID, cmd.IPAddress, cmd.UserAgent)
+		loggedInUser, err := s.userService.RecordLogin(txCtx, &application.RecordLoginCommand{
+			ID:        user.ID,
+			IPAddress: cmd.IPAddress,
+			LoginAt:   event.LoginAt,
+		})
+		if err != nil {
+			return NewInternalError(fmt.Sprintf("failed to record login: %v", err))
+		}
+
+		// Publish user logged in event
 		if err := s.eventBus.Publish(txCtx, event); err != nil {
 			return NewInternalError(fmt.Sprintf("failed to publish login event: %v", err))
 		}
 
 		result = &AuthResult{
-			User:    user,
+			User:    loggedInUser,
 			Session: session,
 		}
 
@@ -176,9 +218,41 @@ func (s *authServiceImpl) Login(ctx context.Context, cmd *LoginCommand) (*AuthRe
 	return result, nil
 }
 
+// lockAfterFailedLogin locks the user's account once the failed logins
+// counted under rateLimitKey reach the lockout threshold. Locking publishes a
+// user.status_changed event, which revokes the user's sessions. It reports
+// whether the account was locked.
+func (s *authServiceImpl) lockAfterFailedLogin(ctx context.Context, user *userDomain.User, rateLimitKey string) bool {
+	if s.accountLockout.MaxFailedAttempts <= 0 {
+		return false
+	}
+
+	// Successful logins reset the counter, so attempts are consecutive failures
+	attempts, err := s.rateLimiter.GetAttempts(ctx, rateLimitKey)
+	if err != nil || attempts < s.accountLockout.MaxFailedAttempts {
+		return false
+	}
+
+	lockCmd := &application.LockUserCommand{
+		ID:        user.ID,
+		ChangedBy: "system",
+		Reason:    "too many failed login attempts",
+	}
+	if _, err := s.userService.LockUser(ctx, lockCmd); err != nil {
+		return false
+	}
+
+	// Start counting afresh once the account is unlocked
+	if err := s.rateLimiter.Reset(ctx, rateLimitKey); err != nil {
+		// Log error but don't fail the lockout
+	}
+
+	return true
+}
+
 // Register creates a new user account and logs them in
 func (s *authServiceImpl) Register(ctx context.Context, cmd *RegisterCommand) (*AuthResult, error) {
-	if err := cmd.Validate(); err != nil {
+	if err := cmd.ValidateWithPolicy(s.passwordPolicy); err != nil {
 		return nil, err
 	}
 
@@ -401,9 +475,27 @@ func (s *authServiceImpl) RefreshSession(ctx context.Context, cmd *RefreshSessio
 	return session, nil
 }
 
+// TouchSession records activity on a validated session, sliding its expiry up
+// to SessionConfig.MaxDuration. Writes are throttled by
+// SessionConfig.ActivityInterval so most requests don't touch the database
+func (s *authServiceImpl) TouchSession(ctx context.Context, session *domain.Session) error {
+	if !session.Touch(time.Now(), s.sessionConfig) {
+		return nil
+	}
+
+	if err := s.sessionRepo.UpdateActivity(ctx, session); err != nil {
+		if database.IsNotFoundError(err) {
+			return NewSessionNotFoundError(session.ID)
+		}
+		return NewInternalError(fmt.Sprintf("failed to update session activity: %v", err))
+	}
+
+	return nil
+}
+
 // ChangePassword changes a user's password
 func (s *authServiceImpl) ChangePassword(ctx context.Context, cmd *ChangePasswordCommand) error {
-	if err := cmd.Validate(); err != nil {
+	if err := cmd.ValidateWithPolicy(s.passwordPolicy); err != nil {
 		return err
 	}
 
@@ -444,9 +536,9 @@ func (s *authServiceImpl) ChangePassword(ctx context.Context, cmd *ChangePasswor
 			return NewInternalError(fmt.Sprintf("failed to change password: %v", err))
 		}
 
-		// Publish password changed event
+		// Publish password changed event once the change commits
 		event := domain.NewPasswordChangedEvent(cmd.UserID, cmd.IPAddress, cmd.UserAgent)
-		if err := s.eventBus.Publish(txCtx, event); err != nil {
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
 			return NewInternalError(fmt.Sprintf("failed to publish password changed event: %v", err))
 		}
 
@@ -486,11 +578,89 @@ func (s *authServiceImpl) GetUserSessions(ctx context.Context, userID string) ([
 	return sessions, nil
 }
 
+// RevokeSession terminates one of a user's sessions, e.g. a login on another device
+func (s *authServiceImpl) RevokeSession(ctx context.Context, cmd *RevokeSessionCommand) error {
+	if err := cmd.Validate(); err != nil {
+		return err
+	}
+
+	return database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
+		session, err := s.sessionRepo.GetByID(txCtx, cmd.SessionID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return NewSessionNotFoundError(cmd.SessionID)
+			}
+			return NewInternalError(fmt.Sprintf("failed to get session: %v", err))
+		}
+
+		// Other users' sessions are reported as not found so IDs can't be probed
+		if session.UserID != cmd.UserID {
+			return NewSessionNotFoundError(cmd.SessionID)
+		}
+
+		if err := s.sessionRepo.Delete(txCtx, session.ID); err != nil {
+			if database.IsNotFoundError(err) {
+				return NewSessionNotFoundError(cmd.SessionID)
+			}
+			return NewInternalError(fmt.Sprintf("failed to delete session: %v", err))
+		}
+
+		event := domain.NewUserLoggedOutEvent(session.UserID, session.ID, "forced")
+		if err := s.eventBus.Publish(txCtx, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish logout event: %v", err))
+		}
+
+		return nil
+	})
+}
+
+// RevokeOtherSessions terminates all of a user's sessions except the current one
+func (s *authServiceImpl) RevokeOtherSessions(ctx context.Context, cmd *RevokeOtherSessionsCommand) (int, error) {
+	if err := cmd.Validate(); err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	err := database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
+		sessions, err := s.sessionRepo.GetByUserID(txCtx, cmd.UserID)
+		if err != nil {
+			return NewInternalError(fmt.Sprintf("failed to get user sessions: %v", err))
+		}
+
+		for _, session := range sessions {
+			if session.ID == cmd.CurrentSessionID {
+				continue
+			}
+
+			if err := s.sessionRepo.Delete(txCtx, session.ID); err != nil {
+				if database.IsNotFoundError(err) {
+					continue // Already gone, e.g. logged out concurrently
+				}
+				return NewInternalError(fmt.Sprintf("failed to delete session: %v", err))
+			}
+
+			event := domain.NewUserLoggedOutEvent(session.UserID, session.ID, "forced")
+			if err := s.eventBus.Publish(txCtx, event); err != nil {
+				return NewInternalError(fmt.Sprintf("failed to publish logout event: %v", err))
+			}
+			revoked++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return revoked, nil
+}
+
 // DefaultSessionConfig returns a default session configuration
 func DefaultSessionConfig() domain.SessionConfig {
 	return domain.SessionConfig{
-		DefaultDuration: time.Hour * 24,     // 24 hours
-		MaxDuration:     time.Hour * 24 * 7, // 7 days
-		CleanupInterval: time.Hour,          // cleanup every hour
+		DefaultDuration:  time.Hour * 24,     // 24 hours
+		MaxDuration:      time.Hour * 24 * 7, // 7 days
+		CleanupInterval:  time.Hour,          // cleanup every hour
+		ActivityInterval: time.Minute * 5,    // record activity at most every 5 minutes
 	}
 }
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go-templ-template/internal/modules/auth/domain"
 	"go-templ-template/internal/modules/user/application"
@@ -67,6 +68,8 @@ func (s *SimpleAuthService) Login(ctx context.Context, cmd *LoginCommand) (*Auth
 		switch user.Status {
 		case userDomain.UserStatusSuspended:
 			return nil, NewAccountSuspendedError()
+		case userDomain.UserStatusLocked:
+			return nil, NewAccountLockedError()
 		case userDomain.UserStatusInactive:
 			return nil, NewInvalidCredentialsError()
 		default:
@@ -85,7 +88,7 @@ func (s *SimpleAuthService) Login(ctx context.Context, cmd *LoginCommand) (*Auth
 	}
 
 	// Create new session
-	session, err := domain.NewSession(user.ID, cmd.IPAddress, cmd.UserAgent, s.sessionConfig)
+	session, err := cmd.NewSession(user.ID, s.sessionConfig)
 	if err != nil {
 		return nil, NewInternalError(fmt.Sprintf("failed to create session: %v", err))
 	}
@@ -95,14 +98,24 @@ func (s *SimpleAuthService) Login(ctx context.Context, cmd *LoginCommand) (*Auth
 		return nil, NewInternalError(fmt.Sprintf("failed to save session: %v", err))
 	}
 
-	// Publish user logged in event
+	// Record the login on the user, at the time published in the event
 	event := domain.NewUserLoggedInEvent(user.ID, session.ID, cmd.IPAddress, cmd.UserAgent)
+	loggedInUser, err := s.userService.RecordLogin(ctx, &application.RecordLoginCommand{
+		ID:        user.ID,
+		IPAddress: cmd.IPAddress,
+		LoginAt:   event.LoginAt,
+	})
+	if err != nil {
+		return nil, NewInternalError(fmt.Sprintf("failed to record login: %v", err))
+	}
+
+	// Publish user logged in event
 	if err := s.eventBus.Publish(ctx, event); err != nil {
 		return nil, NewInternalError(fmt.Sprintf("failed to publish login event: %v", err))
 	}
 
 	return &AuthResult{
-		User:    user,
+		User:    loggedInUser,
 		Session: session,
 	}, nil
 }
@@ -291,6 +304,19 @@ func (s *SimpleAuthService) RefreshSession(ctx context.Context, cmd *RefreshSess
 	return session, nil
 }
 
+// TouchSession records activity on a validated session, sliding its expiry
+func (s *SimpleAuthService) TouchSession(ctx context.Context, session *domain.Session) error {
+	if !session.Touch(time.Now(), s.sessionConfig) {
+		return nil
+	}
+
+	if err := s.sessionRepo.UpdateActivity(ctx, session); err != nil {
+		return NewInternalError(fmt.Sprintf("failed to update session activity: %v", err))
+	}
+
+	return nil
+}
+
 // ChangePassword changes a user's password
 func (s *SimpleAuthService) ChangePassword(ctx context.Context, cmd *ChangePasswordCommand) error {
 	if err := cmd.Validate(); err != nil {
@@ -367,3 +393,57 @@ func (s *SimpleAuthService) GetUserSessions(ctx context.Context, userID string)
 	}
 	return sessions, nil
 }
+
+// RevokeSession terminates one of a user's sessions
+func (s *SimpleAuthService) RevokeSession(ctx context.Context, cmd *RevokeSessionCommand) error {
+	if err := cmd.Validate(); err != nil {
+		return err
+	}
+
+	session, err := s.sessionRepo.GetByID(ctx, cmd.SessionID)
+	if err != nil || session.UserID != cmd.UserID {
+		return NewSessionNotFoundError(cmd.SessionID)
+	}
+
+	if err := s.sessionRepo.Delete(ctx, session.ID); err != nil {
+		return NewInternalError(fmt.Sprintf("failed to delete session: %v", err))
+	}
+
+	event := domain.NewUserLoggedOutEvent(session.UserID, session.ID, "forced")
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return NewInternalError(fmt.Sprintf("failed to publish logout event: %v", err))
+	}
+
+	return nil
+}
+
+// RevokeOtherSessions terminates all of a user's sessions except the current one
+func (s *SimpleAuthService) RevokeOtherSessions(ctx context.Context, cmd *RevokeOtherSessionsCommand) (int, error) {
+	if err := cmd.Validate(); err != nil {
+		return 0, err
+	}
+
+	sessions, err := s.sessionRepo.GetByUserID(ctx, cmd.UserID)
+	if err != nil {
+		return 0, NewInternalError(fmt.Sprintf("failed to get user sessions: %v", err))
+	}
+
+	revoked := 0
+	for _, session := range sessions {
+		if session.ID == cmd.CurrentSessionID {
+			continue
+		}
+
+		if err := s.sessionRepo.Delete(ctx, session.ID); err != nil {
+			return revoked, NewInternalError(fmt.Sprintf("failed to delete session: %v", err))
+		}
+
+		event := domain.NewUserLoggedOutEvent(session.UserID, session.ID, "forced")
+		if err := s.eventBus.Publish(ctx, event); err != nil {
+			return revoked, NewInternalError(fmt.Sprintf("failed to publish logout event: %v", err))
+		}
+		revoked++
+	}
+
+	return revoked, nil
+}
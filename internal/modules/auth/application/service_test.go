@@ -2,16 +2,19 @@ package application
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"go-templ-template/internal/modules/auth/domain"
 	"go-templ-template/internal/modules/user/application"
 	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/database"
 	"go-templ-template/internal/shared/events"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock implementations
@@ -45,6 +48,11 @@ func (m *mockSessionRepository) Update(ctx context.Context, session *domain.Sess
 	return args.Error(0)
 }
 
+func (m *mockSessionRepository) UpdateActivity(ctx context.Context, session *domain.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
 func (m *mockSessionRepository) Delete(ctx context.Context, sessionID string) error {
 	args := m.Called(ctx, sessionID)
 	return args.Error(0)
@@ -117,6 +125,14 @@ func (m *mockUserService) ChangeUserPassword(ctx context.Context, cmd *applicati
 	return args.Get(0).(*userDomain.User), args.Error(1)
 }
 
+func (m *mockUserService) ResetUserPassword(ctx context.Context, cmd *application.ResetUserPasswordCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
 func (m *mockUserService) ChangeUserStatus(ctx context.Context, cmd *application.ChangeUserStatusCommand) (*userDomain.User, error) {
 	args := m.Called(ctx, cmd)
 	if args.Get(0) == nil {
@@ -125,6 +141,30 @@ func (m *mockUserService) ChangeUserStatus(ctx context.Context, cmd *application
 	return args.Get(0).(*userDomain.User), args.Error(1)
 }
 
+func (m *mockUserService) LockUser(ctx context.Context, cmd *application.LockUserCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *mockUserService) UnlockUser(ctx context.Context, cmd *application.UnlockUserCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *mockUserService) RecordLogin(ctx context.Context, cmd *application.RecordLoginCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
 func (m *mockUserService) DeleteUser(ctx context.Context, cmd *application.DeleteUserCommand) error {
 	args := m.Called(ctx, cmd)
 	return args.Error(0)
@@ -350,6 +390,51 @@ func TestLogoutCommand_Validate(t *testing.T) {
 	}
 }
 
+func TestRevokeSessionCommands_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     interface{ Validate() error }
+		wantErr bool
+	}{
+		{
+			name:    "valid revoke session",
+			cmd:     &RevokeSessionCommand{UserID: "user-123", SessionID: "session-123"},
+			wantErr: false,
+		},
+		{
+			name:    "revoke session without user",
+			cmd:     &RevokeSessionCommand{SessionID: "session-123"},
+			wantErr: true,
+		},
+		{
+			name:    "revoke session without session",
+			cmd:     &RevokeSessionCommand{UserID: "user-123"},
+			wantErr: true,
+		},
+		{
+			name:    "valid revoke other sessions",
+			cmd:     &RevokeOtherSessionsCommand{UserID: "user-123", CurrentSessionID: "session-123"},
+			wantErr: false,
+		},
+		{
+			name:    "revoke other sessions without current session",
+			cmd:     &RevokeOtherSessionsCommand{UserID: "user-123"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cmd.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateSessionQuery_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -449,12 +534,415 @@ func TestChangePasswordCommand_Validate(t *testing.T) {
 	}
 }
 
+// newLockoutTestService builds the auth service for exercising failed logins,
+// which fail before any transaction is opened
+func newLockoutTestService(userService *mockUserService, maxFailedAttempts int) AuthService {
+	return NewAuthService(
+		&mockSessionRepository{},
+		userService,
+		&mockEventBus{},
+		nil,
+		NewInMemoryRateLimiter(DefaultRateLimiterConfig()),
+		DefaultSessionConfig(),
+		domain.DefaultPasswordPolicy(),
+		AccountLockoutConfig{MaxFailedAttempts: maxFailedAttempts},
+	)
+}
+
+func TestAuthService_Login_LocksAccountAfterRepeatedFailures(t *testing.T) {
+	user, err := userDomain.NewUser("user-123", "lockout@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+
+	userService := &mockUserService{}
+	userService.On("GetUserByEmail", mock.Anything, mock.Anything).Return(user, nil)
+	userService.On("LockUser", mock.Anything, mock.MatchedBy(func(cmd *application.LockUserCommand) bool {
+		return cmd.ID == user.ID && cmd.ChangedBy == "system" && cmd.Reason == "too many failed login attempts"
+	})).Return(user, nil).Once()
+
+	service := newLockoutTestService(userService, 3)
+	cmd := &LoginCommand{Email: "lockout@example.com", Password: "WrongPassword1"}
+
+	for i := 0; i < 2; i++ {
+		_, err := service.Login(context.Background(), cmd)
+		require.Error(t, err)
+		assert.Equal(t, ErrorCodeInvalidCredentials, err.(*AuthError).Code)
+	}
+	userService.AssertNotCalled(t, "LockUser", mock.Anything, mock.Anything)
+
+	_, err = service.Login(context.Background(), cmd)
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeAccountLocked, err.(*AuthError).Code)
+	userService.AssertExpectations(t)
+}
+
+func TestAuthService_Login_LockoutDisabled(t *testing.T) {
+	user, err := userDomain.NewUser("user-123", "lockout@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+
+	userService := &mockUserService{}
+	userService.On("GetUserByEmail", mock.Anything, mock.Anything).Return(user, nil)
+
+	service := newLockoutTestService(userService, 0)
+	cmd := &LoginCommand{Email: "lockout@example.com", Password: "WrongPassword1"}
+
+	for i := 0; i < DefaultRateLimiterConfig().MaxAttempts; i++ {
+		_, err := service.Login(context.Background(), cmd)
+		require.Error(t, err)
+		assert.Equal(t, ErrorCodeInvalidCredentials, err.(*AuthError).Code)
+	}
+	userService.AssertNotCalled(t, "LockUser", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_LockedAccount(t *testing.T) {
+	user, err := userDomain.NewUser("user-123", "lockout@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+	require.NoError(t, user.Lock())
+
+	userService := &mockUserService{}
+	userService.On("GetUserByEmail", mock.Anything, mock.Anything).Return(user, nil)
+
+	service := newLockoutTestService(userService, 3)
+
+	_, err = service.Login(context.Background(), &LoginCommand{Email: "lockout@example.com", Password: "Password123"})
+	require.Error(t, err)
+	assert.Equal(t, ErrorCodeAccountLocked, err.(*AuthError).Code)
+}
+
+func TestAuthService_Login_RecordsLastLogin(t *testing.T) {
+	// A database is needed because the service opens a transaction around
+	// the session and last login writes
+	database.SkipIfNoDatabase(t)
+	tdb := database.NewTestDatabase(t)
+	t.Cleanup(tdb.Close)
+
+	user, err := userDomain.NewUser("user-123", "login@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+
+	userService := &mockUserService{}
+	userService.On("GetUserByEmail", mock.Anything, mock.Anything).Return(user, nil)
+	userService.On("RecordLogin", mock.Anything, mock.MatchedBy(func(cmd *application.RecordLoginCommand) bool {
+		return cmd.ID == user.ID && cmd.IPAddress == "203.0.113.7"
+	})).Run(func(args mock.Arguments) {
+		cmd := args.Get(1).(*application.RecordLoginCommand)
+		require.NoError(t, user.RecordLogin(cmd.LoginAt, cmd.IPAddress))
+	}).Return(user, nil)
+
+	sessionRepo := &mockSessionRepository{}
+	sessionRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	var published *domain.UserLoggedInEvent
+	eventBus := &mockEventBus{}
+	eventBus.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		published, _ = args.Get(1).(*domain.UserLoggedInEvent)
+	}).Return(nil)
+
+	service := NewAuthService(
+		sessionRepo,
+		userService,
+		eventBus,
+		tdb.DB,
+		NewInMemoryRateLimiter(DefaultRateLimiterConfig()),
+		DefaultSessionConfig(),
+		domain.DefaultPasswordPolicy(),
+		AccountLockoutConfig{},
+	)
+
+	before := time.Now()
+	result, err := service.Login(context.Background(), &LoginCommand{
+		Email:     "login@example.com",
+		Password:  "Password123",
+		IPAddress: "203.0.113.7",
+		UserAgent: "test-agent",
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, result.User.LastLoginAt)
+	assert.WithinDuration(t, before, *result.User.LastLoginAt, time.Minute)
+	assert.Equal(t, "203.0.113.7", result.User.LastLoginIP)
+
+	// The event carries the IP address and the recorded login time
+	require.NotNil(t, published)
+	assert.Equal(t, "203.0.113.7", published.IPAddress)
+	assert.True(t, published.LoginAt.Equal(*result.User.LastLoginAt))
+	userService.AssertExpectations(t)
+}
+
+func TestAuthService_TouchSession(t *testing.T) {
+	config := DefaultSessionConfig()
+	newService := func(sessionRepo *mockSessionRepository) AuthService {
+		return NewAuthService(
+			sessionRepo,
+			&mockUserService{},
+			&mockEventBus{},
+			nil,
+			NewInMemoryRateLimiter(DefaultRateLimiterConfig()),
+			config,
+			domain.DefaultPasswordPolicy(),
+			AccountLockoutConfig{},
+		)
+	}
+	newTestSession := func(lastActivity time.Duration) *domain.Session {
+		now := time.Now()
+		return &domain.Session{
+			ID:             "session-123",
+			CreatedAt:      now.Add(-lastActivity),
+			LastActivityAt: now.Add(-lastActivity),
+			ExpiresAt:      now.Add(-lastActivity).Add(config.DefaultDuration),
+			IsActive:       true,
+		}
+	}
+
+	t.Run("slides expiry of an active session", func(t *testing.T) {
+		sessionRepo := &mockSessionRepository{}
+		session := newTestSession(time.Hour)
+		sessionRepo.On("UpdateActivity", mock.Anything, session).Return(nil)
+
+		require.NoError(t, newService(sessionRepo).TouchSession(context.Background(), session))
+		assert.WithinDuration(t, time.Now(), session.LastActivityAt, time.Second)
+		assert.WithinDuration(t, time.Now().Add(config.DefaultDuration), session.ExpiresAt, time.Second)
+		sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("skips write within activity interval", func(t *testing.T) {
+		sessionRepo := &mockSessionRepository{}
+		session := newTestSession(time.Minute)
+
+		require.NoError(t, newService(sessionRepo).TouchSession(context.Background(), session))
+		sessionRepo.AssertNotCalled(t, "UpdateActivity", mock.Anything, mock.Anything)
+	})
+
+	t.Run("session revoked meanwhile", func(t *testing.T) {
+		sessionRepo := &mockSessionRepository{}
+		session := newTestSession(time.Hour)
+		sessionRepo.On("UpdateActivity", mock.Anything, session).Return(database.ErrNotFound)
+
+		err := newService(sessionRepo).TouchSession(context.Background(), session)
+		var authErr *AuthError
+		require.ErrorAs(t, err, &authErr)
+		assert.Equal(t, ErrorCodeSessionNotFound, authErr.Code)
+	})
+}
+
+// memorySessionRepository is an in-memory SessionRepository for tests that
+// check which sessions still validate after an operation
+type memorySessionRepository struct {
+	sessions map[string]*domain.Session
+}
+
+func newMemorySessionRepository(sessions ...*domain.Session) *memorySessionRepository {
+	repo := &memorySessionRepository{sessions: make(map[string]*domain.Session)}
+	for _, session := range sessions {
+		repo.sessions[session.ID] = session
+	}
+	return repo
+}
+
+func (r *memorySessionRepository) Create(ctx context.Context, session *domain.Session) error {
+	r.sessions[session.ID] = session
+	return nil
+}
+
+func (r *memorySessionRepository) GetByID(ctx context.Context, sessionID string) (*domain.Session, error) {
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return session, nil
+}
+
+func (r *memorySessionRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.Session, error) {
+	var sessions []*domain.Session
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.IsValid() {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *memorySessionRepository) Update(ctx context.Context, session *domain.Session) error {
+	if _, ok := r.sessions[session.ID]; !ok {
+		return database.ErrNotFound
+	}
+	r.sessions[session.ID] = session
+	return nil
+}
+
+func (r *memorySessionRepository) UpdateActivity(ctx context.Context, session *domain.Session) error {
+	return r.Update(ctx, session)
+}
+
+func (r *memorySessionRepository) Delete(ctx context.Context, sessionID string) error {
+	if _, ok := r.sessions[sessionID]; !ok {
+		return database.ErrNotFound
+	}
+	delete(r.sessions, sessionID)
+	return nil
+}
+
+func (r *memorySessionRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	for sessionID, session := range r.sessions {
+		if session.UserID == userID {
+			delete(r.sessions, sessionID)
+		}
+	}
+	return nil
+}
+
+func (r *memorySessionRepository) DeleteExpired(ctx context.Context) error {
+	for sessionID, session := range r.sessions {
+		if !session.IsValid() {
+			delete(r.sessions, sessionID)
+		}
+	}
+	return nil
+}
+
+func (r *memorySessionRepository) ExistsByID(ctx context.Context, sessionID string) (bool, error) {
+	_, ok := r.sessions[sessionID]
+	return ok, nil
+}
+
+// assertChangePasswordRevokesAllSessions changes the password of a user
+// signed in on two devices and checks neither session validates afterwards,
+// while other users stay signed in
+func assertChangePasswordRevokesAllSessions(t *testing.T, newService func(SessionRepository, application.UserService, events.EventBus) AuthService) {
+	user := createTestUser()
+	currentSession := createTestSession(user.ID)
+	otherDeviceSession := createTestSession(user.ID)
+	otherUserSession := createTestSession("user-456")
+	sessionRepo := newMemorySessionRepository(currentSession, otherDeviceSession, otherUserSession)
+
+	otherUser, err := userDomain.NewUser("user-456", "other@example.com", "Password123!", "Jane", "Doe")
+	require.NoError(t, err)
+
+	userService := &mockUserService{}
+	userService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: user.ID}).Return(user, nil)
+	userService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: otherUser.ID}).Return(otherUser, nil)
+	userService.On("ChangeUserPassword", mock.Anything, mock.MatchedBy(func(cmd *application.ChangeUserPasswordCommand) bool {
+		return cmd.ID == user.ID && cmd.NewPassword == "NewPassword456!"
+	})).Return(user, nil)
+
+	var published []events.DomainEvent
+	eventBus := &mockEventBus{}
+	eventBus.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		assert.Nil(t, database.GetTxFromContext(args.Get(0).(context.Context)), "event should be published after commit")
+		published = append(published, args.Get(1).(events.DomainEvent))
+	}).Return(nil)
+
+	service := newService(sessionRepo, userService, eventBus)
+	ctx := context.Background()
+
+	result, err := service.ValidateSession(ctx, &ValidateSessionQuery{SessionID: otherDeviceSession.ID})
+	require.NoError(t, err)
+	require.True(t, result.Valid)
+
+	err = service.ChangePassword(ctx, &ChangePasswordCommand{
+		UserID:      user.ID,
+		OldPassword: "Password123!",
+		NewPassword: "NewPassword456!",
+		IPAddress:   "192.168.1.1",
+		UserAgent:   "test-agent",
+	})
+	require.NoError(t, err)
+
+	// Every session of the user is deleted, not just the current one
+	remaining, err := sessionRepo.GetByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+
+	for _, session := range []*domain.Session{currentSession, otherDeviceSession} {
+		result, err := service.ValidateSession(ctx, &ValidateSessionQuery{SessionID: session.ID})
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+	}
+
+	result, err = service.ValidateSession(ctx, &ValidateSessionQuery{SessionID: otherUserSession.ID})
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	require.Len(t, published, 1)
+	assert.Equal(t, domain.EventTypePasswordChanged, published[0].EventType())
+	assert.Equal(t, user.ID, published[0].AggregateID())
+	userService.AssertExpectations(t)
+}
+
+func TestSimpleAuthService_ChangePassword_RevokesAllSessions(t *testing.T) {
+	assertChangePasswordRevokesAllSessions(t, func(sessionRepo SessionRepository, userService application.UserService, eventBus events.EventBus) AuthService {
+		return NewSimpleAuthService(
+			sessionRepo,
+			userService,
+			eventBus,
+			NewInMemoryRateLimiter(DefaultRateLimiterConfig()),
+			DefaultSessionConfig(),
+		)
+	})
+}
+
+func TestAuthService_ChangePassword_RevokesAllSessions(t *testing.T) {
+	// The service changes the password and deletes the sessions in one
+	// transaction, run on a stub database
+	assertChangePasswordRevokesAllSessions(t, func(sessionRepo SessionRepository, userService application.UserService, eventBus events.EventBus) AuthService {
+		return NewAuthService(
+			sessionRepo,
+			userService,
+			eventBus,
+			database.NewStubDB().DB,
+			NewInMemoryRateLimiter(DefaultRateLimiterConfig()),
+			DefaultSessionConfig(),
+			domain.DefaultPasswordPolicy(),
+			AccountLockoutConfig{},
+		)
+	})
+}
+
+// failingSessionDeletionRepository fails to delete a user's sessions
+type failingSessionDeletionRepository struct {
+	*memorySessionRepository
+}
+
+func (r *failingSessionDeletionRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	return errors.New("sessions unavailable")
+}
+
+func TestAuthService_ChangePassword_RollbackPublishesNothing(t *testing.T) {
+	user := createTestUser()
+	sessionRepo := &failingSessionDeletionRepository{newMemorySessionRepository(createTestSession(user.ID))}
+
+	userService := &mockUserService{}
+	userService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: user.ID}).Return(user, nil)
+	userService.On("ChangeUserPassword", mock.Anything, mock.Anything).Return(user, nil)
+	eventBus := &mockEventBus{}
+
+	service := NewAuthService(
+		sessionRepo,
+		userService,
+		eventBus,
+		database.NewStubDB().DB,
+		NewInMemoryRateLimiter(DefaultRateLimiterConfig()),
+		DefaultSessionConfig(),
+		domain.DefaultPasswordPolicy(),
+		AccountLockoutConfig{},
+	)
+
+	err := service.ChangePassword(context.Background(), &ChangePasswordCommand{
+		UserID:      user.ID,
+		OldPassword: "Password123!",
+		NewPassword: "NewPassword456!",
+	})
+	require.Error(t, err)
+
+	// The change is rolled back, so subscribers never hear of it
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
 func TestDefaultSessionConfig(t *testing.T) {
 	config := DefaultSessionConfig()
 
 	assert.Equal(t, time.Hour*24, config.DefaultDuration)
 	assert.Equal(t, time.Hour*24*7, config.MaxDuration)
 	assert.Equal(t, time.Hour, config.CleanupInterval)
+	assert.Equal(t, time.Minute*5, config.ActivityInterval)
 }
 
 func TestAuthError_Error(t *testing.T) {
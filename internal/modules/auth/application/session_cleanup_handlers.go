@@ -56,9 +56,9 @@ func (h *UserStatusChangedSessionCleanupHandler) Handle(ctx context.Context, eve
 	changedBy, _ := eventData["changed_by"].(string)
 	reason, _ := eventData["reason"].(string)
 
-	// Only cleanup sessions if user is being deactivated or suspended
-	if newStatus == "inactive" || newStatus == "suspended" {
-		h.logger.Info("User status changed to inactive/suspended, cleaning up sessions",
+	// Only cleanup sessions if user is being deactivated, suspended or locked
+	if newStatus == "inactive" || newStatus == "suspended" || newStatus == "locked" {
+		h.logger.Info("User status changed to inactive/suspended/locked, cleaning up sessions",
 			"user_id", userID,
 			"new_status", newStatus,
 			"previous_status", previousStatus,
@@ -0,0 +1,33 @@
+package application
+
+import (
+	"context"
+
+	"go-templ-template/internal/modules/user/application"
+)
+
+// sessionRevoker revokes every session of a user for the user module, e.g.
+// when they delete their account
+type sessionRevoker struct {
+	sessionRepo SessionRepository
+}
+
+// NewSessionRevoker creates a session revoker backed by sessionRepo
+func NewSessionRevoker(sessionRepo SessionRepository) application.SessionRevoker {
+	return &sessionRevoker{sessionRepo: sessionRepo}
+}
+
+// RevokeAllSessions deletes all of a user's sessions and returns how many
+// were active
+func (r *sessionRevoker) RevokeAllSessions(ctx context.Context, userID string) (int, error) {
+	sessions, err := r.sessionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.sessionRepo.DeleteByUserID(ctx, userID); err != nil {
+		return 0, err
+	}
+
+	return len(sessions), nil
+}
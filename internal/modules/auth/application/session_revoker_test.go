@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-templ-template/internal/modules/auth/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionRevoker_RevokeAllSessions(t *testing.T) {
+	sessionRepo := &mockSessionRepository{}
+	sessionRepo.On("GetByUserID", context.Background(), "user-123").
+		Return([]*domain.Session{{ID: "session-1"}, {ID: "session-2"}}, nil)
+	sessionRepo.On("DeleteByUserID", context.Background(), "user-123").Return(nil)
+
+	revoked, err := NewSessionRevoker(sessionRepo).RevokeAllSessions(context.Background(), "user-123")
+	require.NoError(t, err)
+	assert.Equal(t, 2, revoked)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestSessionRevoker_RevokeAllSessions_DeleteFails(t *testing.T) {
+	sessionRepo := &mockSessionRepository{}
+	sessionRepo.On("GetByUserID", context.Background(), "user-123").
+		Return([]*domain.Session{{ID: "session-1"}}, nil)
+	sessionRepo.On("DeleteByUserID", context.Background(), "user-123").Return(errors.New("database unavailable"))
+
+	revoked, err := NewSessionRevoker(sessionRepo).RevokeAllSessions(context.Background(), "user-123")
+	assert.Error(t, err)
+	assert.Zero(t, revoked)
+}
@@ -0,0 +1,226 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go-templ-template/internal/modules/user/application"
+	userDomain "go-templ-template/internal/modules/user/domain"
+
+	"github.com/google/uuid"
+)
+
+// Token types, stored in the typ claim
+const (
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+)
+
+// TokenConfig configures the tokens issued for stateless authentication
+type TokenConfig struct {
+	// Secret signs and verifies tokens; it must be kept private and should
+	// be at least 32 bytes
+	Secret []byte
+
+	// Issuer is stored in tokens' iss claim and required when verifying them
+	Issuer string
+
+	// AccessTokenTTL is how long access tokens are accepted
+	AccessTokenTTL time.Duration
+
+	// RefreshTokenTTL is how long refresh tokens can be exchanged for new tokens
+	RefreshTokenTTL time.Duration
+}
+
+// DefaultTokenConfig returns a token configuration with short-lived access
+// tokens and month-long refresh tokens signed with secret
+func DefaultTokenConfig(secret []byte) TokenConfig {
+	return TokenConfig{
+		Secret:          secret,
+		Issuer:          "go-templ-template",
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: 30 * 24 * time.Hour,
+	}
+}
+
+// TokenPair is an access token and the refresh token that renews it
+type TokenPair struct {
+	AccessToken      string
+	RefreshToken     string
+	AccessExpiresAt  time.Time
+	RefreshExpiresAt time.Time
+}
+
+// TokenService issues and verifies JWTs for clients that authenticate with
+// bearer tokens instead of session cookies
+type TokenService interface {
+	// IssueTokens issues an access and refresh token for user, acting with
+	// the user's full access
+	IssueTokens(user *userDomain.User) (*TokenPair, error)
+
+	// IssueScopedTokens issues an access and refresh token for user that
+	// only grant scopes
+	IssueScopedTokens(user *userDomain.User, scopes []string) (*TokenPair, error)
+
+	// Authenticate returns the active user an access token was issued to,
+	// and the scopes it grants; they are nil for a token without scopes
+	Authenticate(ctx context.Context, accessToken string) (*userDomain.User, []string, error)
+
+	// Refresh exchanges a refresh token for a new token pair with the same
+	// scopes, as long as its user is still active and hasn't changed their
+	// password since it was issued
+	Refresh(ctx context.Context, refreshToken string) (*TokenPair, *userDomain.User, error)
+}
+
+// tokenServiceImpl implements TokenService with HS256 signed JWTs. Tokens are
+// not stored, so they stay valid until they expire or their user changes
+// their password.
+type tokenServiceImpl struct {
+	config      TokenConfig
+	userService application.UserService
+	now         func() time.Time
+}
+
+// NewTokenService creates a token service issuing tokens for the users of
+// userService
+func NewTokenService(config TokenConfig, userService application.UserService) TokenService {
+	return &tokenServiceImpl{
+		config:      config,
+		userService: userService,
+		now:         time.Now,
+	}
+}
+
+// IssueTokens issues an access and refresh token for user
+func (s *tokenServiceImpl) IssueTokens(user *userDomain.User) (*TokenPair, error) {
+	return s.issue(user.ID, "")
+}
+
+// IssueScopedTokens issues an access and refresh token for user that only
+// grant scopes
+func (s *tokenServiceImpl) IssueScopedTokens(user *userDomain.User, scopes []string) (*TokenPair, error) {
+	if len(scopes) == 0 {
+		return nil, NewValidationError("scopes", "at least one scope is required")
+	}
+	if err := validateScopes(scopes); err != nil {
+		return nil, err
+	}
+	return s.issue(user.ID, strings.Join(scopes, " "))
+}
+
+// issue issues an access and refresh token for userID with the
+// space-separated scope, or without scopes when it is empty
+func (s *tokenServiceImpl) issue(userID, scope string) (*TokenPair, error) {
+	now := s.now()
+	pair := &TokenPair{
+		AccessExpiresAt:  now.Add(s.config.AccessTokenTTL),
+		RefreshExpiresAt: now.Add(s.config.RefreshTokenTTL),
+	}
+
+	var err error
+	pair.AccessToken, err = s.sign(userID, accessTokenType, scope, now, pair.AccessExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	pair.RefreshToken, err = s.sign(userID, refreshTokenType, scope, now, pair.RefreshExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// Authenticate returns the active user an access token was issued to and
+// the scopes it grants
+func (s *tokenServiceImpl) Authenticate(ctx context.Context, accessToken string) (*userDomain.User, []string, error) {
+	claims, err := s.verify(accessToken, accessTokenType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.activeUser(ctx, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var scopes []string
+	if claims.Scope != "" {
+		scopes = strings.Fields(claims.Scope)
+	}
+	return user, scopes, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair
+func (s *tokenServiceImpl) Refresh(ctx context.Context, refreshToken string) (*TokenPair, *userDomain.User, error) {
+	claims, err := s.verify(refreshToken, refreshTokenType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.activeUser(ctx, claims)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The new tokens keep the refresh token's scopes, so refreshing can't
+	// widen a scoped token's access
+	pair, err := s.issue(user.ID, claims.Scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pair, user, nil
+}
+
+// sign issues a token of tokenType for userID
+func (s *tokenServiceImpl) sign(userID, tokenType, scope string, issuedAt, expiresAt time.Time) (string, error) {
+	token, err := signJWT(&tokenClaims{
+		Subject:   userID,
+		Issuer:    s.config.Issuer,
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		Type:      tokenType,
+		ID:        uuid.New().String(),
+		Scope:     scope,
+	}, s.config.Secret)
+	if err != nil {
+		return "", NewInternalError("Failed to issue token")
+	}
+	return token, nil
+}
+
+// verify returns the claims of token when it is a valid, unexpired token of
+// tokenType issued by this service
+func (s *tokenServiceImpl) verify(token, tokenType string) (*tokenClaims, error) {
+	claims, err := parseJWT(token, s.config.Secret)
+	if err != nil {
+		return nil, NewTokenInvalidError()
+	}
+	if claims.Type != tokenType || claims.Issuer != s.config.Issuer || claims.Subject == "" {
+		return nil, NewTokenInvalidError()
+	}
+	if !s.now().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, NewTokenExpiredError()
+	}
+	return claims, nil
+}
+
+// activeUser returns the user a token was issued to, rejecting the token if
+// the user has since been deleted or deactivated, or changed their password
+func (s *tokenServiceImpl) activeUser(ctx context.Context, claims *tokenClaims) (*userDomain.User, error) {
+	user, err := s.userService.GetUser(ctx, &application.GetUserQuery{ID: claims.Subject})
+	if err != nil {
+		if application.IsUserNotFoundError(err) {
+			return nil, NewTokenInvalidError()
+		}
+		return nil, NewInternalError("Failed to load token user")
+	}
+	if !user.IsActive() {
+		return nil, NewTokenInvalidError()
+	}
+	// iat only has second precision, so a token issued in the second the
+	// password changed is still accepted
+	if user.PasswordChangedAt != nil && time.Unix(claims.IssuedAt, 0).Before(user.PasswordChangedAt.Truncate(time.Second)) {
+		return nil, NewTokenInvalidError()
+	}
+	return user, nil
+}
@@ -0,0 +1,228 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/user/application"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testTokenSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// newTestTokenService returns a token service whose clock the test controls
+func newTestTokenService(userService application.UserService) (*tokenServiceImpl, *time.Time) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := NewTokenService(DefaultTokenConfig(testTokenSecret), userService).(*tokenServiceImpl)
+	service.now = func() time.Time { return now }
+	return service, &now
+}
+
+func assertTokenError(t *testing.T, err error, code string) {
+	t.Helper()
+
+	authErr, ok := err.(*AuthError)
+	require.True(t, ok, "expected an *AuthError, got %T", err)
+	assert.Equal(t, code, authErr.Code)
+	assert.Equal(t, ErrorTypeAuthentication, authErr.Type)
+}
+
+func TestTokenService_IssueTokens(t *testing.T) {
+	service, now := newTestTokenService(new(mockUserService))
+	user := createTestUser()
+
+	pair, err := service.IssueTokens(user)
+	require.NoError(t, err)
+
+	assert.Equal(t, now.Add(15*time.Minute), pair.AccessExpiresAt)
+	assert.Equal(t, now.Add(30*24*time.Hour), pair.RefreshExpiresAt)
+	assert.NotEqual(t, pair.AccessToken, pair.RefreshToken)
+
+	claims, err := parseJWT(pair.AccessToken, testTokenSecret)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, claims.Subject)
+	assert.Equal(t, "go-templ-template", claims.Issuer)
+	assert.Equal(t, accessTokenType, claims.Type)
+	assert.Equal(t, pair.AccessExpiresAt.Unix(), claims.ExpiresAt)
+	assert.NotEmpty(t, claims.ID)
+}
+
+func TestTokenService_Authenticate(t *testing.T) {
+	userService := new(mockUserService)
+	service, _ := newTestTokenService(userService)
+	user := createTestUser()
+	userService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: user.ID}).Return(user, nil)
+
+	pair, err := service.IssueTokens(user)
+	require.NoError(t, err)
+
+	authenticated, scopes, err := service.Authenticate(context.Background(), pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, user, authenticated)
+	assert.Nil(t, scopes)
+}
+
+func TestTokenService_AuthenticateRejectsInvalidTokens(t *testing.T) {
+	service, now := newTestTokenService(new(mockUserService))
+	pair, err := service.IssueTokens(createTestUser())
+	require.NoError(t, err)
+
+	// Swap the payload for one naming another user, keeping the signature
+	parts := strings.Split(pair.AccessToken, ".")
+	forged := base64.RawURLEncoding.EncodeToString(
+		[]byte(`{"sub":"admin-1","iss":"go-templ-template","exp":9999999999,"typ":"access"}`))
+	tampered := parts[0] + "." + forged + "." + parts[2]
+
+	otherService := NewTokenService(DefaultTokenConfig([]byte("another-secret-another-secret-00")), nil)
+	foreign, err := otherService.IssueTokens(createTestUser())
+	require.NoError(t, err)
+
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + "."
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"tampered payload", tampered},
+		{"signed with another secret", foreign.AccessToken},
+		{"unsigned", unsigned},
+		{"refresh token", pair.RefreshToken},
+		{"malformed", "not-a-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := service.Authenticate(context.Background(), tt.token)
+			assertTokenError(t, err, ErrorCodeTokenInvalid)
+		})
+	}
+
+	t.Run("expired", func(t *testing.T) {
+		*now = now.Add(15 * time.Minute)
+		_, _, err := service.Authenticate(context.Background(), pair.AccessToken)
+		assertTokenError(t, err, ErrorCodeTokenExpired)
+	})
+}
+
+func TestTokenService_AuthenticateRejectsInactiveUsers(t *testing.T) {
+	userService := new(mockUserService)
+	service, _ := newTestTokenService(userService)
+	user := createTestUser()
+	pair, err := service.IssueTokens(user)
+	require.NoError(t, err)
+
+	suspended := createTestUser()
+	require.NoError(t, suspended.Suspend())
+	userService.On("GetUser", mock.Anything, mock.Anything).Return(suspended, nil)
+
+	_, _, err = service.Authenticate(context.Background(), pair.AccessToken)
+	assertTokenError(t, err, ErrorCodeTokenInvalid)
+}
+
+func TestTokenService_Refresh(t *testing.T) {
+	userService := new(mockUserService)
+	service, now := newTestTokenService(userService)
+	user := createTestUser()
+	userService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: user.ID}).Return(user, nil)
+
+	pair, err := service.IssueTokens(user)
+	require.NoError(t, err)
+
+	// The access token has expired, but the refresh token still renews it
+	*now = now.Add(time.Hour)
+	_, _, err = service.Authenticate(context.Background(), pair.AccessToken)
+	assertTokenError(t, err, ErrorCodeTokenExpired)
+
+	refreshed, refreshedUser, err := service.Refresh(context.Background(), pair.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, user, refreshedUser)
+	assert.Equal(t, now.Add(15*time.Minute), refreshed.AccessExpiresAt)
+
+	authenticated, _, err := service.Authenticate(context.Background(), refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, user, authenticated)
+}
+
+func TestTokenService_RefreshRejectsInvalidTokens(t *testing.T) {
+	userService := new(mockUserService)
+	service, now := newTestTokenService(userService)
+	user := createTestUser()
+	pair, err := service.IssueTokens(user)
+	require.NoError(t, err)
+
+	// Access tokens can't be used to refresh
+	_, _, err = service.Refresh(context.Background(), pair.AccessToken)
+	assertTokenError(t, err, ErrorCodeTokenInvalid)
+
+	// Refresh tokens for deleted users are rejected
+	userService.On("GetUser", mock.Anything, mock.Anything).
+		Return(nil, application.NewUserNotFoundError(user.ID)).Once()
+	_, _, err = service.Refresh(context.Background(), pair.RefreshToken)
+	assertTokenError(t, err, ErrorCodeTokenInvalid)
+
+	*now = now.Add(31 * 24 * time.Hour)
+	_, _, err = service.Refresh(context.Background(), pair.RefreshToken)
+	assertTokenError(t, err, ErrorCodeTokenExpired)
+}
+
+func TestTokenService_RejectsTokensIssuedBeforePasswordChange(t *testing.T) {
+	userService := new(mockUserService)
+	service, now := newTestTokenService(userService)
+	user := createTestUser()
+	userService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: user.ID}).Return(user, nil)
+
+	pair, err := service.IssueTokens(user)
+	require.NoError(t, err)
+
+	// Changing the password logs out every client holding earlier tokens
+	*now = now.Add(time.Minute)
+	changedAt := now.Add(500 * time.Millisecond)
+	user.PasswordChangedAt = &changedAt
+
+	_, _, err = service.Authenticate(context.Background(), pair.AccessToken)
+	assertTokenError(t, err, ErrorCodeTokenInvalid)
+	_, _, err = service.Refresh(context.Background(), pair.RefreshToken)
+	assertTokenError(t, err, ErrorCodeTokenInvalid)
+
+	// Tokens issued after the change, even within the same second, are valid
+	relogin, err := service.IssueTokens(user)
+	require.NoError(t, err)
+	_, _, err = service.Authenticate(context.Background(), relogin.AccessToken)
+	require.NoError(t, err)
+	_, _, err = service.Refresh(context.Background(), relogin.RefreshToken)
+	require.NoError(t, err)
+}
+
+func TestTokenService_ScopedTokens(t *testing.T) {
+	userService := new(mockUserService)
+	service, _ := newTestTokenService(userService)
+	user := createTestUser()
+	userService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: user.ID}).Return(user, nil)
+
+	pair, err := service.IssueScopedTokens(user, []string{"users:read", "reports:read"})
+	require.NoError(t, err)
+
+	_, scopes, err := service.Authenticate(context.Background(), pair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users:read", "reports:read"}, scopes)
+
+	// Refreshing keeps the scopes
+	refreshed, _, err := service.Refresh(context.Background(), pair.RefreshToken)
+	require.NoError(t, err)
+	_, scopes, err = service.Authenticate(context.Background(), refreshed.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users:read", "reports:read"}, scopes)
+
+	// Scoped tokens need valid scopes
+	for _, invalid := range [][]string{nil, {"users read"}} {
+		_, err = service.IssueScopedTokens(user, invalid)
+		require.True(t, IsValidationError(err))
+		assert.Equal(t, "scopes", err.(*AuthError).Field)
+	}
+}
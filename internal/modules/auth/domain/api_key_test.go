@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, err := NewAPIKey("user-123", "CI", []string{"users:read"}, nil)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, key.ID)
+	assert.True(t, IsAPIKey(key.Key))
+	assert.Len(t, key.Key, len(APIKeyPrefix)+64)
+	assert.True(t, strings.HasPrefix(key.Key, key.Prefix))
+	assert.Equal(t, HashAPIKey(key.Key), key.KeyHash)
+	assert.NotContains(t, key.KeyHash, key.Key)
+	assert.True(t, key.HasScope("users:read"))
+	assert.False(t, key.HasScope("users:write"))
+	assert.True(t, key.IsValid())
+
+	other, err := NewAPIKey("user-123", "CI", nil, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, key.Key, other.Key)
+	assert.NotNil(t, other.Scopes)
+}
+
+func TestAPIKey_Validity(t *testing.T) {
+	expiresAt := time.Now().UTC().Add(time.Hour)
+	key, err := NewAPIKey("user-123", "CI", nil, &expiresAt)
+	require.NoError(t, err)
+	assert.True(t, key.IsValid())
+
+	revokedAt := time.Now().UTC()
+	key.RevokedAt = &revokedAt
+	assert.True(t, key.IsRevoked())
+	assert.False(t, key.IsValid())
+
+	key.RevokedAt = nil
+	expired := time.Now().UTC().Add(-time.Minute)
+	key.ExpiresAt = &expired
+	assert.True(t, key.IsExpired())
+	assert.False(t, key.IsValid())
+}
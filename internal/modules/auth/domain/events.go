@@ -16,6 +16,8 @@ const (
 	EventTypeUserLoggedOut   = "auth.user.logged_out"
 	EventTypeSessionExpired  = "auth.session.expired"
 	EventTypePasswordChanged = "auth.password.changed"
+
+	EventTypePasswordResetRequested = "auth.password.reset_requested"
 )
 
 // UserLoggedInEvent represents a user login event
@@ -292,6 +294,63 @@ func (e PasswordChangedEvent) Metadata() events.EventMetadata {
 	}
 }
 
+// PasswordResetRequestedEvent represents a password reset request. It carries
+// the plaintext token so a subscriber can email the reset link.
+type PasswordResetRequestedEvent struct {
+	UserID      string    `json:"user_id"`
+	Email       string    `json:"email"`
+	Token       string    `json:"token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	RequestedAt time.Time `json:"requested_at"`
+	IPAddress   string    `json:"ip_address"`
+	eventID     string
+	metadata    map[string]interface{}
+}
+
+// EventType returns the event type
+func (e PasswordResetRequestedEvent) EventType() string {
+	return EventTypePasswordResetRequested
+}
+
+// AggregateID returns the aggregate ID (user ID)
+func (e PasswordResetRequestedEvent) AggregateID() string {
+	return e.UserID
+}
+
+// AggregateType returns the aggregate type
+func (e PasswordResetRequestedEvent) AggregateType() string {
+	return "User"
+}
+
+// OccurredAt returns when the event occurred
+func (e PasswordResetRequestedEvent) OccurredAt() time.Time {
+	return e.RequestedAt
+}
+
+// EventData returns the event data
+func (e PasswordResetRequestedEvent) EventData() interface{} {
+	return e
+}
+
+// EventID returns the unique event ID
+func (e PasswordResetRequestedEvent) EventID() string {
+	return e.eventID
+}
+
+// Version returns the event version
+func (e PasswordResetRequestedEvent) Version() int {
+	return 1
+}
+
+// Metadata returns event metadata
+func (e PasswordResetRequestedEvent) Metadata() events.EventMetadata {
+	return events.EventMetadata{
+		CorrelationID: e.eventID,
+		Source:        "auth-service",
+		Custom:        e.metadata,
+	}
+}
+
 // NewUserLoggedInEvent creates a new user logged in event
 func NewUserLoggedInEvent(userID, sessionID, ipAddress, userAgent string) *UserLoggedInEvent {
 	return &UserLoggedInEvent{
@@ -356,6 +415,20 @@ func NewPasswordChangedEvent(userID, ipAddress, userAgent string) *PasswordChang
 	}
 }
 
+// NewPasswordResetRequestedEvent creates a new password reset requested event
+func NewPasswordResetRequestedEvent(userID, email string, token *PasswordResetToken, ipAddress string) *PasswordResetRequestedEvent {
+	return &PasswordResetRequestedEvent{
+		UserID:      userID,
+		Email:       email,
+		Token:       token.Token,
+		ExpiresAt:   token.ExpiresAt,
+		RequestedAt: time.Now(),
+		IPAddress:   ipAddress,
+		eventID:     uuid.New().String(),
+		metadata:    make(map[string]interface{}),
+	}
+}
+
 // MarshalJSON implements json.Marshaler for all events
 func (e UserLoggedInEvent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
@@ -444,3 +517,23 @@ func (e PasswordChangedEvent) MarshalJSON() ([]byte, error) {
 		UserAgent: e.UserAgent,
 	})
 }
+
+func (e PasswordResetRequestedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		EventType   string    `json:"event_type"`
+		UserID      string    `json:"user_id"`
+		Email       string    `json:"email"`
+		Token       string    `json:"token"`
+		ExpiresAt   time.Time `json:"expires_at"`
+		RequestedAt time.Time `json:"requested_at"`
+		IPAddress   string    `json:"ip_address"`
+	}{
+		EventType:   e.EventType(),
+		UserID:      e.UserID,
+		Email:       e.Email,
+		Token:       e.Token,
+		ExpiresAt:   e.ExpiresAt,
+		RequestedAt: e.RequestedAt,
+		IPAddress:   e.IPAddress,
+	})
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	appErrors "go-templ-template/internal/shared/errors"
+)
+
+// BreachedPasswordChecker reports whether a password has appeared in a known
+// data breach, e.g. by querying a breach corpus service
+type BreachedPasswordChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// PasswordPolicy configures the rules passwords are validated against
+type PasswordPolicy struct {
+	MinLength        int
+	MaxLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSpecial   bool
+
+	// RejectCommon rejects passwords from the common password list
+	RejectCommon bool
+
+	// BreachedChecker optionally rejects breached passwords. Checker failures
+	// are ignored so an unavailable service doesn't block sign-ups.
+	BreachedChecker BreachedPasswordChecker
+}
+
+// DefaultPasswordPolicy returns the password policy used for registration
+// and password changes
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:        MinPasswordLength,
+		MaxLength:        MaxPasswordLength,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSpecial:   true,
+		RejectCommon:     true,
+	}
+}
+
+// ValidatePassword checks password against policy and returns an
+// *errors.ErrorList holding every violation, or nil if the password is valid
+func ValidatePassword(password string, policy PasswordPolicy) error {
+	violations := &appErrors.ErrorList{}
+
+	length := utf8.RuneCountInString(password)
+	if policy.MinLength > 0 && length < policy.MinLength {
+		violations.Add(appErrors.NewValidationErrorWithDetails("PASSWORD_TOO_SHORT",
+			fmt.Sprintf("password must be at least %d characters long", policy.MinLength),
+			map[string]interface{}{"min_length": policy.MinLength}))
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		violations.Add(appErrors.NewValidationErrorWithDetails("PASSWORD_TOO_LONG",
+			fmt.Sprintf("password must be no more than %d characters long", policy.MaxLength),
+			map[string]interface{}{"max_length": policy.MaxLength}))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsNumber(char):
+			hasDigit = true
+		case unicode.IsPunct(char) || unicode.IsSymbol(char):
+			hasSpecial = true
+		}
+	}
+
+	if policy.RequireUppercase && !hasUpper {
+		violations.Add(appErrors.NewValidationError("PASSWORD_MISSING_UPPERCASE",
+			"password must contain at least one uppercase letter"))
+	}
+	if policy.RequireLowercase && !hasLower {
+		violations.Add(appErrors.NewValidationError("PASSWORD_MISSING_LOWERCASE",
+			"password must contain at least one lowercase letter"))
+	}
+	if policy.RequireDigit && !hasDigit {
+		violations.Add(appErrors.NewValidationError("PASSWORD_MISSING_DIGIT",
+			"password must contain at least one digit"))
+	}
+	if policy.RequireSpecial && !hasSpecial {
+		violations.Add(appErrors.NewValidationError("PASSWORD_MISSING_SPECIAL",
+			"password must contain at least one special character"))
+	}
+
+	if policy.RejectCommon && IsCommonPassword(password) {
+		violations.Add(appErrors.NewValidationError("PASSWORD_TOO_COMMON",
+			"password is too common, please choose a stronger password"))
+	}
+
+	if policy.BreachedChecker != nil {
+		if breached, err := policy.BreachedChecker.IsBreached(password); err == nil && breached {
+			violations.Add(appErrors.NewValidationError("PASSWORD_BREACHED",
+				"password has appeared in a data breach, please choose a different password"))
+		}
+	}
+
+	if violations.HasErrors() {
+		return violations
+	}
+	return nil
+}
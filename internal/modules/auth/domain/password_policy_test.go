@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	appErrors "go-templ-template/internal/shared/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubBreachedChecker reports the configured passwords as breached
+type stubBreachedChecker struct {
+	breached map[string]bool
+	err      error
+}
+
+func (s *stubBreachedChecker) IsBreached(password string) (bool, error) {
+	return s.breached[password], s.err
+}
+
+// violationCodes returns the codes of the violations in err
+func violationCodes(t *testing.T, err error) []string {
+	t.Helper()
+
+	violations, ok := err.(*appErrors.ErrorList)
+	require.True(t, ok, "expected *errors.ErrorList, got %T", err)
+
+	codes := make([]string, 0, len(violations.Errors))
+	for _, violation := range violations.Errors {
+		assert.Equal(t, appErrors.ErrorTypeValidation, violation.Type)
+		codes = append(codes, violation.Code)
+	}
+	return codes
+}
+
+func TestValidatePassword_Rules(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+
+	tests := []struct {
+		name     string
+		password string
+		expected string
+	}{
+		{"too short", "Ab1!", "PASSWORD_TOO_SHORT"},
+		{"no digit", "Password!", "PASSWORD_MISSING_DIGIT"},
+		{"no uppercase", "password1!", "PASSWORD_MISSING_UPPERCASE"},
+		{"no lowercase", "PASSWORD1!", "PASSWORD_MISSING_LOWERCASE"},
+		{"no special character", "Password1", "PASSWORD_MISSING_SPECIAL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePassword(tt.password, policy)
+			require.Error(t, err)
+			assert.Equal(t, []string{tt.expected}, violationCodes(t, err))
+		})
+	}
+}
+
+func TestValidatePassword_TooLong(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+	policy.MaxLength = 12
+
+	err := ValidatePassword("Password123!Password123!", policy)
+	assert.Equal(t, []string{"PASSWORD_TOO_LONG"}, violationCodes(t, err))
+}
+
+func TestValidatePassword_AggregatesViolations(t *testing.T) {
+	err := ValidatePassword("abc", DefaultPasswordPolicy())
+
+	assert.Equal(t, []string{
+		"PASSWORD_TOO_SHORT",
+		"PASSWORD_MISSING_UPPERCASE",
+		"PASSWORD_MISSING_DIGIT",
+		"PASSWORD_MISSING_SPECIAL",
+	}, violationCodes(t, err))
+
+	violations := err.(*appErrors.ErrorList)
+	assert.Equal(t, 8, violations.Errors[0].Details["min_length"])
+}
+
+func TestValidatePassword_StrongPassword(t *testing.T) {
+	assert.NoError(t, ValidatePassword("C0rrect-Horse-Battery", DefaultPasswordPolicy()))
+}
+
+func TestValidatePassword_ConfigurablePolicy(t *testing.T) {
+	policy := PasswordPolicy{MinLength: 12, RequireDigit: true}
+
+	assert.NoError(t, ValidatePassword("plain words 1", policy))
+	assert.Equal(t, []string{"PASSWORD_TOO_SHORT"}, violationCodes(t, ValidatePassword("short 1", policy)))
+}
+
+func TestValidatePassword_CommonPassword(t *testing.T) {
+	policy := PasswordPolicy{RejectCommon: true}
+
+	assert.Equal(t, []string{"PASSWORD_TOO_COMMON"}, violationCodes(t, ValidatePassword("password123", policy)))
+}
+
+func TestValidatePassword_BreachedChecker(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+	policy.BreachedChecker = &stubBreachedChecker{
+		breached: map[string]bool{"Summer2024!": true},
+	}
+
+	assert.Equal(t, []string{"PASSWORD_BREACHED"}, violationCodes(t, ValidatePassword("Summer2024!", policy)))
+	assert.NoError(t, ValidatePassword("C0rrect-Horse-Battery", policy))
+
+	// Checker failures don't block the password
+	policy.BreachedChecker = &stubBreachedChecker{err: errors.New("service unavailable")}
+	assert.NoError(t, ValidatePassword("Summer2024!", policy))
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetToken represents a single-use token for resetting a forgotten
+// password. Only a hash of the token is stored, so a leaked table can't be
+// used to reset passwords.
+type PasswordResetToken struct {
+	ID        string     `db:"id" json:"id"`
+	UserID    string     `db:"user_id" json:"user_id"`
+	TokenHash string     `db:"token_hash" json:"-"`
+	ExpiresAt time.Time  `db:"expires_at" json:"expires_at"`
+	UsedAt    *time.Time `db:"used_at" json:"used_at,omitempty"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+
+	// Token is the plaintext token sent to the user. It is only set on newly
+	// issued tokens and is never stored.
+	Token string `db:"-" json:"-"`
+}
+
+// NewPasswordResetToken issues a reset token for userID valid for duration
+func NewPasswordResetToken(userID string, duration time.Duration) (*PasswordResetToken, error) {
+	bytes := make([]byte, 32) // 256 bits
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+	token := hex.EncodeToString(bytes)
+
+	now := time.Now().UTC()
+	return &PasswordResetToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		TokenHash: HashPasswordResetToken(token),
+		ExpiresAt: now.Add(duration),
+		CreatedAt: now,
+		Token:     token,
+	}, nil
+}
+
+// HashPasswordResetToken returns the stored form of a plaintext reset token
+func HashPasswordResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired checks if the reset token is expired
+func (t *PasswordResetToken) IsExpired() bool {
+	return time.Now().UTC().After(t.ExpiresAt)
+}
+
+// IsUsed checks if the reset token has been used
+func (t *PasswordResetToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsValid checks if the reset token is valid (not expired and not used)
+func (t *PasswordResetToken) IsValid() bool {
+	return !t.IsExpired() && !t.IsUsed()
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPasswordResetToken(t *testing.T) {
+	token, err := NewPasswordResetToken("user-123", time.Hour)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, token.ID)
+	assert.Equal(t, "user-123", token.UserID)
+	assert.Len(t, token.Token, 64)
+	assert.Equal(t, HashPasswordResetToken(token.Token), token.TokenHash)
+	assert.NotEqual(t, token.Token, token.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Second)
+	assert.True(t, token.IsValid())
+
+	other, err := NewPasswordResetToken("user-123", time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, token.Token, other.Token)
+}
+
+func TestPasswordResetToken_Validity(t *testing.T) {
+	token, err := NewPasswordResetToken("user-123", time.Hour)
+	require.NoError(t, err)
+
+	usedAt := time.Now().UTC()
+	token.UsedAt = &usedAt
+	assert.True(t, token.IsUsed())
+	assert.False(t, token.IsValid())
+
+	token.UsedAt = nil
+	token.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+	assert.True(t, token.IsExpired())
+	assert.False(t, token.IsValid())
+}
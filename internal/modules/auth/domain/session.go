@@ -9,13 +9,14 @@ import (
 
 // Session represents a user authentication session
 type Session struct {
-	ID        string    `db:"id" json:"id"`
-	UserID    string    `db:"user_id" json:"user_id"`
-	ExpiresAt time.Time `db:"expires_at" json:"expires_at"`
-	CreatedAt time.Time `db:"created_at" json:"created_at"`
-	IPAddress string    `db:"ip_address" json:"ip_address"`
-	UserAgent string    `db:"user_agent" json:"user_agent"`
-	IsActive  bool      `db:"is_active" json:"is_active"`
+	ID             string    `db:"id" json:"id"`
+	UserID         string    `db:"user_id" json:"user_id"`
+	ExpiresAt      time.Time `db:"expires_at" json:"expires_at"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+	LastActivityAt time.Time `db:"last_activity_at" json:"last_activity_at"`
+	IPAddress      string    `db:"ip_address" json:"ip_address"`
+	UserAgent      string    `db:"user_agent" json:"user_agent"`
+	IsActive       bool      `db:"is_active" json:"is_active"`
 }
 
 // SessionConfig holds configuration for session management
@@ -23,26 +24,41 @@ type SessionConfig struct {
 	DefaultDuration time.Duration
 	MaxDuration     time.Duration
 	CleanupInterval time.Duration
+	// ActivityInterval is the minimum time between recorded activity on a
+	// session, so active users don't cause a write on every request
+	ActivityInterval time.Duration
 }
 
 // NewSession creates a new session with security features
 func NewSession(userID, ipAddress, userAgent string, config SessionConfig) (*Session, error) {
+	return newSession(userID, ipAddress, userAgent, config.DefaultDuration)
+}
+
+// NewPersistentSession creates a "remember me" session that lasts for the
+// configured maximum duration
+func NewPersistentSession(userID, ipAddress, userAgent string, config SessionConfig) (*Session, error) {
+	return newSession(userID, ipAddress, userAgent, config.MaxDuration)
+}
+
+// newSession creates a new session that expires after duration
+func newSession(userID, ipAddress, userAgent string, duration time.Duration) (*Session, error) {
 	sessionID, err := generateSecureSessionID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate session ID: %w", err)
 	}
 
 	now := time.Now()
-	expiresAt := now.Add(config.DefaultDuration)
+	expiresAt := now.Add(duration)
 
 	return &Session{
-		ID:        sessionID,
-		UserID:    userID,
-		ExpiresAt: expiresAt,
-		CreatedAt: now,
-		IPAddress: ipAddress,
-		UserAgent: userAgent,
-		IsActive:  true,
+		ID:             sessionID,
+		UserID:         userID,
+		ExpiresAt:      expiresAt,
+		CreatedAt:      now,
+		LastActivityAt: now,
+		IPAddress:      ipAddress,
+		UserAgent:      userAgent,
+		IsActive:       true,
 	}, nil
 }
 
@@ -61,6 +77,31 @@ func (s *Session) Extend(duration time.Duration) {
 	s.ExpiresAt = time.Now().Add(duration)
 }
 
+// Touch records activity on the session at now and slides its expiry to
+// DefaultDuration from now, capped at MaxDuration after the session was
+// created. It never shortens the expiry, e.g. of a "remember me" session, and
+// does nothing for sessions that are inactive, already expired or touched less
+// than ActivityInterval ago. It reports whether the session changed.
+func (s *Session) Touch(now time.Time, config SessionConfig) bool {
+	if !s.IsActive || now.After(s.ExpiresAt) {
+		return false
+	}
+	if now.Sub(s.LastActivityAt) < config.ActivityInterval {
+		return false
+	}
+
+	s.LastActivityAt = now
+
+	expiresAt := now.Add(config.DefaultDuration)
+	if maxExpiresAt := s.CreatedAt.Add(config.MaxDuration); expiresAt.After(maxExpiresAt) {
+		expiresAt = maxExpiresAt
+	}
+	if expiresAt.After(s.ExpiresAt) {
+		s.ExpiresAt = expiresAt
+	}
+	return true
+}
+
 // Invalidate marks the session as inactive
 func (s *Session) Invalidate() {
 	s.IsActive = false
@@ -33,6 +33,23 @@ func TestNewSession(t *testing.T) {
 	// Check that expiration is set correctly
 	expectedExpiry := time.Now().Add(config.DefaultDuration)
 	assert.WithinDuration(t, expectedExpiry, session.ExpiresAt, time.Second)
+	assert.Equal(t, session.CreatedAt, session.LastActivityAt)
+}
+
+func TestNewPersistentSession(t *testing.T) {
+	config := SessionConfig{
+		DefaultDuration: time.Hour,
+		MaxDuration:     time.Hour * 24 * 30,
+	}
+
+	session, err := NewPersistentSession("user-123", "192.168.1.1", "Mozilla/5.0", config)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, session.ID)
+	assert.True(t, session.IsValid())
+
+	expectedExpiry := time.Now().Add(config.MaxDuration)
+	assert.WithinDuration(t, expectedExpiry, session.ExpiresAt, time.Second)
 }
 
 func TestSession_IsExpired(t *testing.T) {
@@ -82,6 +99,83 @@ func TestSession_Extend(t *testing.T) {
 	assert.True(t, session.ExpiresAt.After(originalExpiry))
 }
 
+func TestSession_Touch(t *testing.T) {
+	config := SessionConfig{
+		DefaultDuration:  time.Hour,
+		MaxDuration:      4 * time.Hour,
+		ActivityInterval: 5 * time.Minute,
+	}
+	createdAt := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	newTestSession := func() *Session {
+		return &Session{
+			ID:             "session-123",
+			CreatedAt:      createdAt,
+			LastActivityAt: createdAt,
+			ExpiresAt:      createdAt.Add(config.DefaultDuration),
+			IsActive:       true,
+		}
+	}
+
+	t.Run("extends expiry within max duration", func(t *testing.T) {
+		session := newTestSession()
+		now := createdAt.Add(30 * time.Minute)
+
+		assert.True(t, session.Touch(now, config))
+		assert.Equal(t, now, session.LastActivityAt)
+		assert.Equal(t, now.Add(config.DefaultDuration), session.ExpiresAt)
+	})
+
+	t.Run("caps expiry at max duration", func(t *testing.T) {
+		session := newTestSession()
+		// Keep the session alive with regular activity past its max duration
+		for now := createdAt.Add(30 * time.Minute); now.Before(createdAt.Add(5 * time.Hour)); now = now.Add(30 * time.Minute) {
+			if now.After(session.ExpiresAt) {
+				break
+			}
+			session.Touch(now, config)
+		}
+
+		assert.Equal(t, createdAt.Add(config.MaxDuration), session.ExpiresAt)
+		assert.False(t, session.Touch(createdAt.Add(config.MaxDuration).Add(time.Minute), config))
+	})
+
+	t.Run("throttles activity within interval", func(t *testing.T) {
+		session := newTestSession()
+		now := createdAt.Add(config.ActivityInterval - time.Second)
+
+		assert.False(t, session.Touch(now, config))
+		assert.Equal(t, createdAt, session.LastActivityAt)
+		assert.Equal(t, createdAt.Add(config.DefaultDuration), session.ExpiresAt)
+	})
+
+	t.Run("idle session still expires", func(t *testing.T) {
+		session := newTestSession()
+		now := createdAt.Add(config.DefaultDuration).Add(time.Minute)
+
+		assert.False(t, session.Touch(now, config))
+		assert.Equal(t, createdAt.Add(config.DefaultDuration), session.ExpiresAt)
+		assert.True(t, session.IsExpired())
+	})
+
+	t.Run("never shortens expiry", func(t *testing.T) {
+		session := newTestSession()
+		session.ExpiresAt = createdAt.Add(config.MaxDuration)
+		now := createdAt.Add(30 * time.Minute)
+
+		assert.True(t, session.Touch(now, config))
+		assert.Equal(t, now, session.LastActivityAt)
+		assert.Equal(t, createdAt.Add(config.MaxDuration), session.ExpiresAt)
+	})
+
+	t.Run("ignores inactive session", func(t *testing.T) {
+		session := newTestSession()
+		session.Invalidate()
+
+		assert.False(t, session.Touch(createdAt.Add(30*time.Minute), config))
+		assert.Equal(t, createdAt, session.LastActivityAt)
+	})
+}
+
 func TestSession_Invalidate(t *testing.T) {
 	config := SessionConfig{DefaultDuration: time.Hour}
 	session, err := NewSession("user-123", "192.168.1.1", "Mozilla/5.0", config)
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"go-templ-template/internal/modules/auth/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WithAPIKeys enables the endpoints for managing the signed-in user's API
+// keys, backed by apiKeyService
+func (h *AuthHandler) WithAPIKeys(apiKeyService application.APIKeyService) *AuthHandler {
+	h.apiKeyService = apiKeyService
+	return h
+}
+
+// apiKeysEnabled reports whether the API key endpoints should be registered
+func (h *AuthHandler) apiKeysEnabled() bool {
+	return h != nil && h.apiKeyService != nil
+}
+
+// CreateAPIKey handles POST /api/v1/auth/api-keys
+func (h *AuthHandler) CreateAPIKey(c echo.Context) error {
+	user, _, ok := getAuthContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "Authentication required",
+		})
+	}
+
+	var req CreateAPIKeyRequest
+	if err := BindAndValidate(c, &req); err != nil {
+		return h.handleValidationError(c, err)
+	}
+
+	cmd := &application.CreateAPIKeyCommand{
+		UserID:    user.ID,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresIn: time.Duration(req.ExpiresInDays) * 24 * time.Hour,
+	}
+
+	key, err := h.apiKeyService.CreateAPIKey(c.Request().Context(), cmd)
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, &CreatedAPIKeyResponse{
+		APIKeyResponse: ToAPIKeyResponse(key),
+		Key:            key.Key,
+	})
+}
+
+// ListAPIKeys handles GET /api/v1/auth/api-keys
+func (h *AuthHandler) ListAPIKeys(c echo.Context) error {
+	user, _, ok := getAuthContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "Authentication required",
+		})
+	}
+
+	keys, err := h.apiKeyService.ListAPIKeys(c.Request().Context(), user.ID)
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, ToAPIKeyListResponse(keys))
+}
+
+// RevokeAPIKey handles DELETE /api/v1/auth/api-keys/:id
+func (h *AuthHandler) RevokeAPIKey(c echo.Context) error {
+	user, _, ok := getAuthContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "Authentication required",
+		})
+	}
+
+	cmd := &application.RevokeAPIKeyCommand{
+		UserID: user.ID,
+		KeyID:  c.Param("id"),
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(c.Request().Context(), cmd); err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "API key revoked successfully",
+	})
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/auth/application"
+	"go-templ-template/internal/modules/auth/domain"
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockAPIKeyService is a mock APIKeyService for testing
+type mockAPIKeyService struct {
+	mock.Mock
+}
+
+func (m *mockAPIKeyService) CreateAPIKey(ctx context.Context, cmd *application.CreateAPIKeyCommand) (*domain.APIKey, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.APIKey), args.Error(1)
+}
+
+func (m *mockAPIKeyService) ListAPIKeys(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.APIKey), args.Error(1)
+}
+
+func (m *mockAPIKeyService) RevokeAPIKey(ctx context.Context, cmd *application.RevokeAPIKeyCommand) error {
+	args := m.Called(ctx, cmd)
+	return args.Error(0)
+}
+
+func (m *mockAPIKeyService) Authenticate(ctx context.Context, key string) (*userDomain.User, *domain.APIKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*userDomain.User), args.Get(1).(*domain.APIKey), args.Error(2)
+}
+
+func newAPIKeyHandler() (*AuthHandler, *mockAPIKeyService) {
+	apiKeyService := new(mockAPIKeyService)
+	handler := NewAuthHandler(new(mockAuthService)).WithAPIKeys(apiKeyService)
+	return handler, apiKeyService
+}
+
+// doAPIKeyRequest calls handler as the signed-in test user
+func doAPIKeyRequest(handler func(echo.Context) error, method, body string, params ...string) *httptest.ResponseRecorder {
+	e := setupEcho()
+	req := httptest.NewRequest(method, "/api/v1/auth/api-keys", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	if len(params) == 2 {
+		c.SetParamNames(params[0])
+		c.SetParamValues(params[1])
+	}
+	c.Set(middleware.UserContextKey, createTestUser())
+	c.Set(middleware.SessionContextKey, createTestSession())
+
+	if err := handler(c); err != nil {
+		e.HTTPErrorHandler(err, c)
+	}
+	return rec
+}
+
+func TestAuthHandler_CreateAPIKey_ReturnsKeyOnce(t *testing.T) {
+	handler, apiKeyService := newAPIKeyHandler()
+
+	key, err := domain.NewAPIKey("user-123", "CI", []string{"users:read"}, nil)
+	require.NoError(t, err)
+	apiKeyService.On("CreateAPIKey", mock.Anything, mock.MatchedBy(func(cmd *application.CreateAPIKeyCommand) bool {
+		return cmd.UserID == "user-123" && cmd.Name == "CI" && cmd.ExpiresIn == 30*24*time.Hour
+	})).Return(key, nil)
+
+	rec := doAPIKeyRequest(handler.CreateAPIKey, http.MethodPost, `{"name":"CI","scopes":["users:read"],"expires_in_days":30}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var created CreatedAPIKeyResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, key.Key, created.Key)
+	assert.Equal(t, key.ID, created.ID)
+	assert.Equal(t, key.Prefix, created.Prefix)
+	assert.Equal(t, []string{"users:read"}, created.Scopes)
+	assert.NotContains(t, rec.Body.String(), key.KeyHash)
+
+	// Listing never shows the key again
+	key.Key = ""
+	apiKeyService.On("ListAPIKeys", mock.Anything, "user-123").Return([]*domain.APIKey{key}, nil)
+
+	rec = doAPIKeyRequest(handler.ListAPIKeys, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), created.Key)
+	assert.NotContains(t, rec.Body.String(), `"key"`)
+
+	var list APIKeyListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.APIKeys, 1)
+	assert.Equal(t, key.Prefix, list.APIKeys[0].Prefix)
+	apiKeyService.AssertExpectations(t)
+}
+
+func TestAuthHandler_CreateAPIKey_Validation(t *testing.T) {
+	handler, apiKeyService := newAPIKeyHandler()
+	apiKeyService.On("CreateAPIKey", mock.Anything, mock.Anything).
+		Return(nil, application.NewValidationError("scopes", `invalid scope "users read"`))
+
+	rec := doAPIKeyRequest(handler.CreateAPIKey, http.MethodPost, `{"scopes":["users:read"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "FIELD_REQUIRED")
+
+	rec = doAPIKeyRequest(handler.CreateAPIKey, http.MethodPost, `{"name":"CI","scopes":["users read"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"scopes"`)
+}
+
+func TestAuthHandler_RevokeAPIKey(t *testing.T) {
+	handler, apiKeyService := newAPIKeyHandler()
+	apiKeyService.On("RevokeAPIKey", mock.Anything, &application.RevokeAPIKeyCommand{UserID: "user-123", KeyID: "key-1"}).Return(nil)
+	apiKeyService.On("RevokeAPIKey", mock.Anything, &application.RevokeAPIKeyCommand{UserID: "user-123", KeyID: "key-2"}).
+		Return(application.NewAPIKeyNotFoundError("key-2"))
+
+	rec := doAPIKeyRequest(handler.RevokeAPIKey, http.MethodDelete, "", "id", "key-1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = doAPIKeyRequest(handler.RevokeAPIKey, http.MethodDelete, "", "id", "key-2")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), application.ErrorCodeAPIKeyNotFound)
+	apiKeyService.AssertExpectations(t)
+}
+
+func TestAuthHandler_APIKeys_RequireSession(t *testing.T) {
+	handler, _ := newAPIKeyHandler()
+
+	// Requests authenticated without a session, e.g. with an API key, can't
+	// manage keys
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/api-keys", strings.NewReader(`{"name":"CI"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := setupEcho().NewContext(req, rec)
+	c.Set(middleware.UserContextKey, createTestUser())
+
+	require.NoError(t, handler.CreateAPIKey(c))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
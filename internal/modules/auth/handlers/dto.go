@@ -3,22 +3,24 @@ package handlers
 import (
 	"time"
 
+	"go-templ-template/internal/modules/auth/application"
 	"go-templ-template/internal/modules/auth/domain"
 	userDomain "go-templ-template/internal/modules/user/domain"
 )
 
 // LoginRequest represents the request payload for user login
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email,max=255"`
-	Password string `json:"password" validate:"required"`
+	Email      string `json:"email" validate:"required,email,max=255"`
+	Password   string `json:"password" validate:"required"`
+	RememberMe bool   `json:"remember_me"`
 }
 
 // RegisterRequest represents the request payload for user registration
 type RegisterRequest struct {
 	Email     string `json:"email" validate:"required,email,max=255"`
-	Password  string `json:"password" validate:"required,min=8,max=128"`
-	FirstName string `json:"first_name" validate:"required,max=100"`
-	LastName  string `json:"last_name" validate:"required,max=100"`
+	Password  string `json:"password" validate:"required,min=8,max=128,password"`
+	FirstName string `json:"first_name" validate:"required,max=100,name"`
+	LastName  string `json:"last_name" validate:"required,max=100,name"`
 }
 
 // ChangePasswordRequest represents the request payload for changing password
@@ -27,13 +29,59 @@ type ChangePasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8,max=128"`
 }
 
+// VerificationRequest represents the request payload for requesting an email
+// verification token
+type VerificationRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+// PasswordResetRequest represents the request payload for requesting a
+// password reset token
+type PasswordResetRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+}
+
+// ResetPasswordRequest represents the request payload for setting a new
+// password with a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,max=128"`
+}
+
+// RefreshTokenRequest represents the request payload for exchanging a
+// refresh token for new tokens
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// CreateAPIKeyRequest represents the request payload for creating an API key
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes"`
+
+	// ExpiresInDays is how many days the key is valid for; zero or omitted
+	// creates a key that never expires
+	ExpiresInDays int `json:"expires_in_days"`
+}
+
 // AuthResponse represents the response payload for successful authentication
 type AuthResponse struct {
 	User    *UserResponse    `json:"user"`
 	Session *SessionResponse `json:"session"`
+	Tokens  *TokenResponse   `json:"tokens,omitempty"`
 	Message string           `json:"message"`
 }
 
+// TokenResponse represents the access and refresh tokens issued to clients
+// using bearer token authentication
+type TokenResponse struct {
+	AccessToken      string    `json:"access_token"`
+	RefreshToken     string    `json:"refresh_token"`
+	TokenType        string    `json:"token_type"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
 // UserResponse represents user information in auth responses
 type UserResponse struct {
 	ID        string                `json:"id"`
@@ -44,6 +92,9 @@ type UserResponse struct {
 	Status    userDomain.UserStatus `json:"status"`
 	CreatedAt time.Time             `json:"created_at"`
 	UpdatedAt time.Time             `json:"updated_at"`
+
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	LastLoginIP string     `json:"last_login_ip,omitempty"`
 }
 
 // SessionResponse represents session information in auth responses
@@ -53,6 +104,46 @@ type SessionResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// ActiveSessionResponse represents one of the user's active sessions
+type ActiveSessionResponse struct {
+	ID             string    `json:"id"`
+	IPAddress      string    `json:"ip_address"`
+	UserAgent      string    `json:"user_agent"`
+	CreatedAt      time.Time `json:"created_at"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Current        bool      `json:"current"`
+}
+
+// SessionListResponse represents the response for listing active sessions
+type SessionListResponse struct {
+	Sessions []*ActiveSessionResponse `json:"sessions"`
+}
+
+// APIKeyResponse represents one of the user's API keys. The key itself is
+// never included, only its prefix.
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse represents a newly created API key, including the
+// plaintext key, which is only ever shown in this response
+type CreatedAPIKeyResponse struct {
+	*APIKeyResponse
+	Key string `json:"key"`
+}
+
+// APIKeyListResponse represents the response for listing API keys
+type APIKeyListResponse struct {
+	APIKeys []*APIKeyResponse `json:"api_keys"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -77,6 +168,9 @@ func ToUserResponse(user *userDomain.User) *UserResponse {
 		Status:    user.Status,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
+
+		LastLoginAt: user.LastLoginAt,
+		LastLoginIP: user.LastLoginIP,
 	}
 }
 
@@ -89,6 +183,65 @@ func ToSessionResponse(session *domain.Session) *SessionResponse {
 	}
 }
 
+// ToSessionListResponse converts a user's sessions to SessionListResponse,
+// flagging the session the request was made with
+func ToSessionListResponse(sessions []*domain.Session, currentSessionID string) *SessionListResponse {
+	response := &SessionListResponse{
+		Sessions: make([]*ActiveSessionResponse, 0, len(sessions)),
+	}
+
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, &ActiveSessionResponse{
+			ID:             session.ID,
+			IPAddress:      session.IPAddress,
+			UserAgent:      session.UserAgent,
+			CreatedAt:      session.CreatedAt,
+			LastActivityAt: session.LastActivityAt,
+			ExpiresAt:      session.ExpiresAt,
+			Current:        session.ID == currentSessionID,
+		})
+	}
+
+	return response
+}
+
+// ToAPIKeyResponse converts a domain APIKey to APIKeyResponse
+func ToAPIKeyResponse(key *domain.APIKey) *APIKeyResponse {
+	return &APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		LastUsedAt: key.LastUsedAt,
+		ExpiresAt:  key.ExpiresAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// ToAPIKeyListResponse converts a user's API keys to APIKeyListResponse
+func ToAPIKeyListResponse(keys []*domain.APIKey) *APIKeyListResponse {
+	response := &APIKeyListResponse{
+		APIKeys: make([]*APIKeyResponse, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		response.APIKeys = append(response.APIKeys, ToAPIKeyResponse(key))
+	}
+
+	return response
+}
+
+// ToTokenResponse converts an issued token pair to TokenResponse
+func ToTokenResponse(tokens *application.TokenPair) *TokenResponse {
+	return &TokenResponse{
+		AccessToken:      tokens.AccessToken,
+		RefreshToken:     tokens.RefreshToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        tokens.AccessExpiresAt,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
+	}
+}
+
 // ToAuthResponse converts auth result to AuthResponse
 func ToAuthResponse(user *userDomain.User, session *domain.Session, message string) *AuthResponse {
 	return &AuthResponse{
@@ -1,12 +1,16 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"time"
 
 	"go-templ-template/internal/modules/auth/application"
 	"go-templ-template/internal/modules/auth/domain"
+	userApplication "go-templ-template/internal/modules/user/application"
 	userDomain "go-templ-template/internal/modules/user/domain"
+	appErrors "go-templ-template/internal/shared/errors"
 	"go-templ-template/internal/shared/middleware"
 
 	"github.com/labstack/echo/v4"
@@ -14,7 +18,30 @@ import (
 
 // AuthHandler handles HTTP requests for authentication operations
 type AuthHandler struct {
-	authService application.AuthService
+	authService       application.AuthService
+	loginLimiter      application.RateLimiter
+	loginProtection   LoginProtectionConfig
+	activationService userApplication.ActivationService
+	resetService      application.PasswordResetService
+	tokenService      application.TokenService
+	apiKeyService     application.APIKeyService
+	idempotencyStore  middleware.IdempotencyStore
+}
+
+// LoginProtectionConfig configures brute-force protection for Login
+type LoginProtectionConfig struct {
+	// MaxFailedAttempts is the number of failed logins allowed per email and
+	// IP address before further logins are refused
+	MaxFailedAttempts int
+
+	// LockoutWindow is how long failed logins are counted for, starting at
+	// the first failure. A locked out email and IP can log in again once it
+	// has passed.
+	LockoutWindow time.Duration
+
+	// EmailNormalizer puts emails in the form failed logins are counted by,
+	// so aliases of one address share a count
+	EmailNormalizer userDomain.EmailNormalizer
 }
 
 // NewAuthHandler creates a new auth handler
@@ -24,6 +51,30 @@ func NewAuthHandler(authService application.AuthService) *AuthHandler {
 	}
 }
 
+// WithLoginProtection enables brute-force protection for Login. Failed
+// logins are recorded in limiter keyed by email and client IP, and cleared by
+// a successful login. The limiter should count attempts over
+// config.LockoutWindow, e.g. an InMemoryRateLimiter whose Window is the
+// lockout window. A non-positive MaxFailedAttempts disables the protection.
+func (h *AuthHandler) WithLoginProtection(limiter application.RateLimiter, config LoginProtectionConfig) *AuthHandler {
+	h.loginLimiter = limiter
+	h.loginProtection = config
+	return h
+}
+
+// WithIdempotency replays the responses to retried writes on the
+// authenticated routes that carry an Idempotency-Key header, storing them in
+// store
+func (h *AuthHandler) WithIdempotency(store middleware.IdempotencyStore) *AuthHandler {
+	h.idempotencyStore = store
+	return h
+}
+
+// idempotencyEnabled reports whether retried writes should be replayed
+func (h *AuthHandler) idempotencyEnabled() bool {
+	return h != nil && h.idempotencyStore != nil
+}
+
 // Login handles POST /api/v1/auth/login
 func (h *AuthHandler) Login(c echo.Context) error {
 	var req LoginRequest
@@ -31,23 +82,49 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		return h.handleValidationError(c, err)
 	}
 
+	ctx := c.Request().Context()
+	attemptKey := h.loginAttemptKey(req.Email, c.RealIP())
+	if h.isLoginLockedOut(ctx, attemptKey) {
+		return c.JSON(http.StatusTooManyRequests, ErrorResponse{
+			Error: application.ErrorCodeRateLimitExceeded,
+			Message: fmt.Sprintf("Too many failed login attempts. Please try again in %v",
+				h.loginProtection.LockoutWindow.Round(time.Second)),
+		})
+	}
+
 	cmd := &application.LoginCommand{
-		Email:     req.Email,
-		Password:  req.Password,
-		IPAddress: c.RealIP(),
-		UserAgent: c.Request().UserAgent(),
+		Email:      req.Email,
+		Password:   req.Password,
+		IPAddress:  c.RealIP(),
+		UserAgent:  c.Request().UserAgent(),
+		RememberMe: req.RememberMe,
 	}
 
-	result, err := h.authService.Login(c.Request().Context(), cmd)
+	result, err := h.authService.Login(ctx, cmd)
 	if err != nil {
+		if authErr, ok := err.(*application.AuthError); ok && authErr.Code == application.ErrorCodeInvalidCredentials {
+			h.recordFailedLogin(ctx, attemptKey)
+		}
 		return h.handleApplicationError(c, err)
 	}
 
-	// Set session cookie
-	sessionDuration := int(24 * time.Hour / time.Second) // 24 hours in seconds
-	middleware.SetSessionCookie(c, result.Session.ID, sessionDuration)
+	h.resetFailedLogins(ctx, attemptKey)
+
+	// Remembered logins outlive the browser session, others use a session cookie
+	if req.RememberMe {
+		middleware.SetPersistentSessionCookie(c, result.Session.ID, result.Session.ExpiresAt)
+	} else {
+		middleware.SetSessionCookie(c, result.Session.ID, 0)
+	}
 
 	response := ToAuthResponse(result.User, result.Session, "Login successful")
+	if h.tokenAuthEnabled() {
+		tokens, err := h.tokenService.IssueTokens(result.User)
+		if err != nil {
+			return h.handleApplicationError(c, err)
+		}
+		response.Tokens = ToTokenResponse(tokens)
+	}
 	return c.JSON(http.StatusOK, response)
 }
 
@@ -247,6 +324,79 @@ func (h *AuthHandler) ChangePassword(c echo.Context) error {
 	})
 }
 
+// ListSessions handles GET /api/v1/auth/sessions
+func (h *AuthHandler) ListSessions(c echo.Context) error {
+	user, session, ok := getAuthContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "Authentication required",
+		})
+	}
+
+	sessions, err := h.authService.GetUserSessions(c.Request().Context(), user.ID)
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, ToSessionListResponse(sessions, session.ID))
+}
+
+// RevokeSession handles DELETE /api/v1/auth/sessions/:id
+func (h *AuthHandler) RevokeSession(c echo.Context) error {
+	user, session, ok := getAuthContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "Authentication required",
+		})
+	}
+
+	cmd := &application.RevokeSessionCommand{
+		UserID:    user.ID,
+		SessionID: c.Param("id"),
+	}
+
+	if err := h.authService.RevokeSession(c.Request().Context(), cmd); err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	// Revoking the current session logs the user out
+	if cmd.SessionID == session.ID {
+		middleware.SetSessionCookie(c, "", -1)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Session revoked successfully",
+	})
+}
+
+// RevokeOtherSessions handles DELETE /api/v1/auth/sessions
+func (h *AuthHandler) RevokeOtherSessions(c echo.Context) error {
+	user, session, ok := getAuthContext(c)
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "Authentication required",
+		})
+	}
+
+	cmd := &application.RevokeOtherSessionsCommand{
+		UserID:           user.ID,
+		CurrentSessionID: session.ID,
+	}
+
+	revoked, err := h.authService.RevokeOtherSessions(c.Request().Context(), cmd)
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Other sessions revoked successfully",
+		Data:    map[string]int{"revoked": revoked},
+	})
+}
+
 // ValidateSession handles GET /api/v1/auth/validate
 func (h *AuthHandler) ValidateSession(c echo.Context) error {
 	sessionID := c.QueryParam("session_id")
@@ -293,8 +443,71 @@ func (h *AuthHandler) ValidateSession(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
-// handleValidationError handles validation errors
+// getAuthContext returns the user and session set by the auth middleware
+func getAuthContext(c echo.Context) (*userDomain.User, *domain.Session, bool) {
+	user, ok := middleware.GetUserFromContext(c).(*userDomain.User)
+	if !ok {
+		return nil, nil, false
+	}
+
+	session, ok := middleware.GetSessionFromContext(c).(*domain.Session)
+	if !ok {
+		return nil, nil, false
+	}
+
+	return user, session, true
+}
+
+// loginAttemptKey returns the key failed logins are tracked under
+func (h *AuthHandler) loginAttemptKey(email, ipAddress string) string {
+	return fmt.Sprintf("login_failures:%s:%s", h.loginProtection.EmailNormalizer.Normalize(email), ipAddress)
+}
+
+// loginProtectionEnabled reports whether failed logins are being tracked
+func (h *AuthHandler) loginProtectionEnabled() bool {
+	return h.loginLimiter != nil && h.loginProtection.MaxFailedAttempts > 0
+}
+
+// isLoginLockedOut reports whether key has reached the failed login threshold.
+// Limiter failures fail open so an unavailable limiter doesn't block logins.
+func (h *AuthHandler) isLoginLockedOut(ctx context.Context, key string) bool {
+	if !h.loginProtectionEnabled() {
+		return false
+	}
+
+	attempts, err := h.loginLimiter.GetAttempts(ctx, key)
+	if err != nil {
+		return false
+	}
+	return attempts >= h.loginProtection.MaxFailedAttempts
+}
+
+// recordFailedLogin counts a failed login against key
+func (h *AuthHandler) recordFailedLogin(ctx context.Context, key string) {
+	if !h.loginProtectionEnabled() {
+		return
+	}
+
+	// The limiter's verdict is ignored, lockout is decided by isLoginLockedOut
+	_, _ = h.loginLimiter.Allow(ctx, key)
+}
+
+// resetFailedLogins clears the failed logins counted against key
+func (h *AuthHandler) resetFailedLogins(ctx context.Context, key string) {
+	if !h.loginProtectionEnabled() {
+		return
+	}
+
+	_ = h.loginLimiter.Reset(ctx, key)
+}
+
+// handleValidationError handles validation errors. Field errors reported
+// as an *errors.ErrorList are returned for the error middleware to render.
 func (h *AuthHandler) handleValidationError(c echo.Context, err error) error {
+	if fieldErrs, ok := appErrors.AsErrorList(err); ok {
+		return fieldErrs
+	}
+
 	if validationErrs, ok := err.(ValidationErrors); ok {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{
 			"error":   "VALIDATION_ERROR",
@@ -320,6 +533,21 @@ func (h *AuthHandler) handleApplicationError(c echo.Context, err error) error {
 		})
 	}
 
+	// Password policy violations are reported together
+	if violations, ok := err.(*appErrors.ErrorList); ok {
+		details := make([]ValidationError, 0, len(violations.Errors))
+		for _, violation := range violations.Errors {
+			field, _ := violation.Details["field"].(string)
+			details = append(details, ValidationError{Field: field, Message: violation.Message})
+		}
+
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "VALIDATION_ERROR",
+			"message": "Validation failed",
+			"details": details,
+		})
+	}
+
 	// Handle unknown errors
 	return c.JSON(http.StatusInternalServerError, ErrorResponse{
 		Error:   "INTERNAL_ERROR",
@@ -330,7 +558,7 @@ func (h *AuthHandler) handleApplicationError(c echo.Context, err error) error {
 // getStatusCodeForError maps application error codes to HTTP status codes
 func (h *AuthHandler) getStatusCodeForError(errorCode string) int {
 	switch errorCode {
-	case "VALIDATION_ERROR":
+	case "VALIDATION_ERROR", "VALIDATION_FAILED":
 		return http.StatusBadRequest
 	case "INVALID_CREDENTIALS":
 		return http.StatusUnauthorized
@@ -346,8 +574,16 @@ func (h *AuthHandler) getStatusCodeForError(errorCode string) int {
 		return http.StatusUnauthorized
 	case "ACCOUNT_SUSPENDED":
 		return http.StatusForbidden
+	case "ACCOUNT_LOCKED":
+		return http.StatusForbidden
 	case "RATE_LIMIT_EXCEEDED":
 		return http.StatusTooManyRequests
+	case "RESET_TOKEN_INVALID", "RESET_TOKEN_EXPIRED", "RESET_TOKEN_USED":
+		return http.StatusBadRequest
+	case "TOKEN_INVALID", "TOKEN_EXPIRED", "API_KEY_INVALID":
+		return http.StatusUnauthorized
+	case "API_KEY_NOT_FOUND":
+		return http.StatusNotFound
 	case "INTERNAL_ERROR":
 		return http.StatusInternalServerError
 	default:
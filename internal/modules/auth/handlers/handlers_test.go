@@ -63,6 +63,11 @@ func (m *mockAuthService) RefreshSession(ctx context.Context, cmd *application.R
 	return args.Get(0).(*domain.Session), args.Error(1)
 }
 
+func (m *mockAuthService) TouchSession(ctx context.Context, session *domain.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
 func (m *mockAuthService) ChangePassword(ctx context.Context, cmd *application.ChangePasswordCommand) error {
 	args := m.Called(ctx, cmd)
 	return args.Error(0)
@@ -81,6 +86,16 @@ func (m *mockAuthService) GetUserSessions(ctx context.Context, userID string) ([
 	return args.Get(0).([]*domain.Session), args.Error(1)
 }
 
+func (m *mockAuthService) RevokeSession(ctx context.Context, cmd *application.RevokeSessionCommand) error {
+	args := m.Called(ctx, cmd)
+	return args.Error(0)
+}
+
+func (m *mockAuthService) RevokeOtherSessions(ctx context.Context, cmd *application.RevokeOtherSessionsCommand) (int, error) {
+	args := m.Called(ctx, cmd)
+	return args.Int(0), args.Error(1)
+}
+
 // Test helper functions
 func createTestUser() *userDomain.User {
 	user, _ := userDomain.NewUser("user-123", "test@example.com", "Password123", "John", "Doe")
@@ -99,6 +114,7 @@ func createTestSession() *domain.Session {
 
 func setupEcho() *echo.Echo {
 	e := echo.New()
+	e.HTTPErrorHandler = middleware.CustomErrorHandler(middleware.ErrorHandlerConfig{JSONAPIErrors: true})
 	return e
 }
 
@@ -217,13 +233,22 @@ func TestAuthHandler_Login_ValidationError(t *testing.T) {
 	err := handler.Login(c)
 
 	// Assert
-	require.NoError(t, err)
+	require.Error(t, err)
+	e.HTTPErrorHandler(err, c)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 
-	var response map[string]interface{}
+	var response struct {
+		Error struct {
+			Type    string                 `json:"type"`
+			Code    string                 `json:"code"`
+			Details map[string]interface{} `json:"details"`
+		} `json:"error"`
+	}
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	require.NoError(t, err)
-	assert.Equal(t, "VALIDATION_ERROR", response["error"])
+	assert.Equal(t, "validation", response.Error.Type)
+	assert.Equal(t, "INVALID_EMAIL", response.Error.Code)
+	assert.Equal(t, "email", response.Error.Details["field"])
 }
 
 func TestAuthHandler_Register_Success(t *testing.T) {
@@ -270,6 +295,53 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestAuthHandler_Register_FieldValidationErrors(t *testing.T) {
+	mockService := new(mockAuthService)
+	handler := NewAuthHandler(mockService)
+	e := setupEcho()
+
+	reqBody, _ := json.Marshal(RegisterRequest{
+		Email:     "not-an-email",
+		Password:  "short",
+		FirstName: "",
+		LastName:  "D0e",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	err := handler.Register(c)
+	require.Error(t, err)
+	e.HTTPErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response struct {
+		Errors []struct {
+			Code    string                 `json:"code"`
+			Message string                 `json:"message"`
+			Details map[string]interface{} `json:"details"`
+		} `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	codes := make(map[string]string)
+	for _, fieldErr := range response.Errors {
+		field, _ := fieldErr.Details["field"].(string)
+		codes[field] = fieldErr.Code
+		assert.NotEmpty(t, fieldErr.Message)
+	}
+	assert.Equal(t, map[string]string{
+		"email":      "INVALID_EMAIL",
+		"password":   "FIELD_TOO_SHORT",
+		"first_name": "FIELD_REQUIRED",
+		"last_name":  "INVALID_NAME",
+	}, codes)
+
+	mockService.AssertNotCalled(t, "Register", mock.Anything, mock.Anything)
+}
+
 func TestAuthHandler_Register_UserAlreadyExists(t *testing.T) {
 	// Setup
 	mockService := new(mockAuthService)
@@ -311,6 +383,53 @@ func TestAuthHandler_Register_UserAlreadyExists(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestAuthHandler_Register_PasswordPolicyViolations(t *testing.T) {
+	mockService := new(mockAuthService)
+	handler := NewAuthHandler(mockService)
+	e := setupEcho()
+
+	registerReq := RegisterRequest{
+		Email:     "new@example.com",
+		Password:  "Password123",
+		FirstName: "Jane",
+		LastName:  "Smith",
+	}
+
+	// The service rejects the password with the policy violations
+	policy := domain.DefaultPasswordPolicy()
+	policy.MinLength = 12
+	policyErr := (&application.RegisterCommand{
+		Email:     registerReq.Email,
+		Password:  registerReq.Password,
+		FirstName: registerReq.FirstName,
+		LastName:  registerReq.LastName,
+	}).ValidateWithPolicy(policy)
+	require.Error(t, policyErr)
+	mockService.On("Register", mock.Anything, mock.Anything).Return(nil, policyErr)
+
+	reqBody, _ := json.Marshal(registerReq)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	require.NoError(t, handler.Register(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var response struct {
+		Error   string            `json:"error"`
+		Details []ValidationError `json:"details"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "VALIDATION_ERROR", response.Error)
+	assert.Equal(t, []ValidationError{
+		{Field: "password", Message: "password must be at least 12 characters long"},
+		{Field: "password", Message: "password must contain at least one special character"},
+	}, response.Details)
+
+	mockService.AssertExpectations(t)
+}
+
 func TestAuthHandler_Logout_Success(t *testing.T) {
 	// Setup
 	mockService := new(mockAuthService)
@@ -634,6 +753,7 @@ func TestAuthHandler_Integration_LoginAndProtectedRoute(t *testing.T) {
 		Valid:   true,
 	}
 	mockService.On("ValidateSession", mock.Anything, mock.Anything).Return(validationResult, nil)
+	mockService.On("TouchSession", mock.Anything, mock.Anything).Return(nil)
 
 	// Test login
 	loginReq := LoginRequest{
@@ -717,6 +837,428 @@ func TestAuthHandler_EmptyRequestBody(t *testing.T) {
 	err := handler.Login(c)
 
 	// Assert
-	require.NoError(t, err)
+	require.Error(t, err)
+	e.HTTPErrorHandler(err, c)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
+
+type mockRateLimiter struct {
+	mock.Mock
+}
+
+func (m *mockRateLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockRateLimiter) Reset(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *mockRateLimiter) GetAttempts(ctx context.Context, key string) (int, error) {
+	args := m.Called(ctx, key)
+	return args.Int(0), args.Error(1)
+}
+
+// newProtectedLoginHandler creates a handler that locks out logins after
+// maxFailed failures, backed by a mock service that accepts "Password123"
+func newProtectedLoginHandler(maxFailed int) (*AuthHandler, *mockAuthService) {
+	mockService := new(mockAuthService)
+	mockService.On("Login", mock.Anything, mock.MatchedBy(func(cmd *application.LoginCommand) bool {
+		return cmd.Password == "Password123"
+	})).Return(&application.AuthResult{
+		User:    createTestUser(),
+		Session: createTestSession(),
+	}, nil)
+	mockService.On("Login", mock.Anything, mock.Anything).Return(nil, application.NewInvalidCredentialsError())
+
+	limiter := application.NewInMemoryRateLimiter(application.RateLimiterConfig{
+		MaxAttempts: maxFailed,
+		Window:      time.Minute * 15,
+		LockoutTime: time.Minute * 15,
+	})
+	handler := NewAuthHandler(mockService).WithLoginProtection(limiter, LoginProtectionConfig{
+		MaxFailedAttempts: maxFailed,
+		LockoutWindow:     time.Minute * 15,
+	})
+
+	return handler, mockService
+}
+
+func doLogin(t *testing.T, handler *AuthHandler, password, ip string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	reqBody, _ := json.Marshal(LoginRequest{
+		Email:    "test@example.com",
+		Password: password,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderXRealIP, ip)
+	rec := httptest.NewRecorder()
+	c := setupEcho().NewContext(req, rec)
+
+	require.NoError(t, handler.Login(c))
+	return rec
+}
+
+func TestAuthHandler_Login_LockoutAfterFailedAttempts(t *testing.T) {
+	handler, mockService := newProtectedLoginHandler(3)
+
+	for i := 0; i < 3; i++ {
+		rec := doLogin(t, handler, "wrongpassword", "192.168.1.1")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+
+	// Even the correct password is refused while locked out
+	rec := doLogin(t, handler, "Password123", "192.168.1.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "RATE_LIMIT_EXCEEDED", response.Error)
+	assert.Contains(t, response.Message, "Too many failed login attempts")
+
+	// The service is not consulted for locked out logins
+	mockService.AssertNumberOfCalls(t, "Login", 3)
+}
+
+func TestAuthHandler_Login_SuccessResetsFailedAttempts(t *testing.T) {
+	handler, _ := newProtectedLoginHandler(3)
+
+	for i := 0; i < 2; i++ {
+		rec := doLogin(t, handler, "wrongpassword", "192.168.1.1")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+
+	rec := doLogin(t, handler, "Password123", "192.168.1.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// The counter starts over, so two more failures don't lock out
+	for i := 0; i < 2; i++ {
+		rec := doLogin(t, handler, "wrongpassword", "192.168.1.1")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+
+	rec = doLogin(t, handler, "Password123", "192.168.1.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestAuthHandler_Login_TracksIPsIndependently(t *testing.T) {
+	handler, _ := newProtectedLoginHandler(2)
+
+	for i := 0; i < 2; i++ {
+		doLogin(t, handler, "wrongpassword", "192.168.1.1")
+	}
+
+	rec := doLogin(t, handler, "Password123", "192.168.1.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	rec = doLogin(t, handler, "Password123", "10.0.0.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestAuthHandler_Login_ProtectionKeysByEmailAndIP(t *testing.T) {
+	mockService := new(mockAuthService)
+	mockService.On("Login", mock.Anything, mock.Anything).Return(&application.AuthResult{
+		User:    createTestUser(),
+		Session: createTestSession(),
+	}, nil)
+
+	limiter := new(mockRateLimiter)
+	key := "login_failures:test@example.com:192.168.1.1"
+	limiter.On("GetAttempts", mock.Anything, key).Return(0, nil)
+	limiter.On("Reset", mock.Anything, key).Return(nil)
+
+	handler := NewAuthHandler(mockService).WithLoginProtection(limiter, LoginProtectionConfig{
+		MaxFailedAttempts: 5,
+		LockoutWindow:     time.Minute * 15,
+	})
+
+	rec := doLogin(t, handler, "Password123", "192.168.1.1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	limiter.AssertExpectations(t)
+	limiter.AssertNotCalled(t, "Allow", mock.Anything, mock.Anything)
+}
+
+func TestAuthHandler_Login_RememberMe(t *testing.T) {
+	sessionConfig := domain.SessionConfig{
+		DefaultDuration: time.Hour * 24,
+		MaxDuration:     time.Hour * 24 * 30,
+	}
+
+	tests := []struct {
+		name           string
+		rememberMe     bool
+		expectedExpiry time.Duration
+	}{
+		{"session cookie by default", false, sessionConfig.DefaultDuration},
+		{"persistent cookie when remembered", true, sessionConfig.MaxDuration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(mockAuthService)
+			handler := NewAuthHandler(mockService)
+
+			// The service creates the session the command asks for
+			session, err := (&application.LoginCommand{RememberMe: tt.rememberMe}).NewSession("user-123", sessionConfig)
+			require.NoError(t, err)
+			mockService.On("Login", mock.Anything, mock.MatchedBy(func(cmd *application.LoginCommand) bool {
+				return cmd.RememberMe == tt.rememberMe
+			})).Return(&application.AuthResult{User: createTestUser(), Session: session}, nil)
+
+			reqBody, _ := json.Marshal(LoginRequest{
+				Email:      "test@example.com",
+				Password:   "Password123",
+				RememberMe: tt.rememberMe,
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(reqBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := setupEcho().NewContext(req, rec)
+
+			require.NoError(t, handler.Login(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var response AuthResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.WithinDuration(t, time.Now().Add(tt.expectedExpiry), response.Session.ExpiresAt, time.Minute)
+
+			var sessionCookie *http.Cookie
+			for _, cookie := range rec.Result().Cookies() {
+				if cookie.Name == middleware.SessionCookieName {
+					sessionCookie = cookie
+				}
+			}
+			require.NotNil(t, sessionCookie)
+			assert.Equal(t, response.Session.ID, sessionCookie.Value)
+
+			if tt.rememberMe {
+				assert.InDelta(t, tt.expectedExpiry.Seconds(), float64(sessionCookie.MaxAge), 60)
+				assert.WithinDuration(t, response.Session.ExpiresAt, sessionCookie.Expires, time.Second)
+			} else {
+				assert.Zero(t, sessionCookie.MaxAge, "session cookie should not set Max-Age")
+				assert.True(t, sessionCookie.Expires.IsZero(), "session cookie should not set Expires")
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+// newSessionsContext creates a request context authenticated as user-123
+// with session "session-current"
+func newSessionsContext(method, path string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, path, nil)
+	rec := httptest.NewRecorder()
+	c := setupEcho().NewContext(req, rec)
+
+	session := createTestSession()
+	session.ID = "session-current"
+	c.Set(middleware.UserContextKey, createTestUser())
+	c.Set(middleware.SessionContextKey, session)
+
+	return c, rec
+}
+
+func TestAuthHandler_ListSessions(t *testing.T) {
+	mockService := new(mockAuthService)
+	handler := NewAuthHandler(mockService)
+
+	current := createTestSession()
+	current.ID = "session-current"
+	other := createTestSession()
+	other.ID = "session-other"
+	other.IPAddress = "10.0.0.1"
+	other.UserAgent = "other-agent"
+
+	mockService.On("GetUserSessions", mock.Anything, "user-123").
+		Return([]*domain.Session{current, other}, nil)
+
+	c, rec := newSessionsContext(http.MethodGet, "/api/v1/auth/sessions")
+	require.NoError(t, handler.ListSessions(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response SessionListResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.Len(t, response.Sessions, 2)
+
+	assert.Equal(t, "session-current", response.Sessions[0].ID)
+	assert.True(t, response.Sessions[0].Current)
+
+	assert.Equal(t, "session-other", response.Sessions[1].ID)
+	assert.False(t, response.Sessions[1].Current)
+	assert.Equal(t, "10.0.0.1", response.Sessions[1].IPAddress)
+	assert.Equal(t, "other-agent", response.Sessions[1].UserAgent)
+	assert.WithinDuration(t, other.CreatedAt, response.Sessions[1].CreatedAt, time.Second)
+	assert.WithinDuration(t, other.ExpiresAt, response.Sessions[1].ExpiresAt, time.Second)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_ListSessions_Unauthenticated(t *testing.T) {
+	handler := NewAuthHandler(new(mockAuthService))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/sessions", nil)
+	rec := httptest.NewRecorder()
+	c := setupEcho().NewContext(req, rec)
+
+	require.NoError(t, handler.ListSessions(c))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthHandler_RevokeSession(t *testing.T) {
+	mockService := new(mockAuthService)
+	handler := NewAuthHandler(mockService)
+
+	mockService.On("RevokeSession", mock.Anything, &application.RevokeSessionCommand{
+		UserID:    "user-123",
+		SessionID: "session-other",
+	}).Return(nil)
+
+	c, rec := newSessionsContext(http.MethodDelete, "/api/v1/auth/sessions/session-other")
+	c.SetParamNames("id")
+	c.SetParamValues("session-other")
+
+	require.NoError(t, handler.RevokeSession(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response SuccessResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "Session revoked successfully", response.Message)
+
+	// Revoking another session keeps the current one logged in
+	assert.Empty(t, rec.Result().Cookies())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_RevokeSession_OtherUsersSession(t *testing.T) {
+	mockService := new(mockAuthService)
+	handler := NewAuthHandler(mockService)
+
+	// The service reports sessions owned by other users as not found
+	mockService.On("RevokeSession", mock.Anything, mock.Anything).
+		Return(application.NewSessionNotFoundError("session-of-another-user"))
+
+	c, rec := newSessionsContext(http.MethodDelete, "/api/v1/auth/sessions/session-of-another-user")
+	c.SetParamNames("id")
+	c.SetParamValues("session-of-another-user")
+
+	require.NoError(t, handler.RevokeSession(c))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "SESSION_NOT_FOUND", response.Error)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAuthHandler_RevokeOtherSessions(t *testing.T) {
+	mockService := new(mockAuthService)
+	handler := NewAuthHandler(mockService)
+
+	mockService.On("RevokeOtherSessions", mock.Anything, &application.RevokeOtherSessionsCommand{
+		UserID:           "user-123",
+		CurrentSessionID: "session-current",
+	}).Return(2, nil)
+
+	c, rec := newSessionsContext(http.MethodDelete, "/api/v1/auth/sessions")
+	require.NoError(t, handler.RevokeOtherSessions(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Message string         `json:"message"`
+		Data    map[string]int `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Data["revoked"])
+
+	mockService.AssertExpectations(t)
+}
+
+// findSessionCookie returns the session cookie set on rec, if any
+func findSessionCookie(rec *httptest.ResponseRecorder) *http.Cookie {
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == middleware.SessionCookieName {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestAuthHandler_SessionCookieAttributes(t *testing.T) {
+	tests := []struct {
+		name   string
+		config middleware.SessionCookieConfig
+	}{
+		{"default", middleware.DefaultSessionCookieConfig()},
+		{"staging", middleware.SessionCookieConfig{Path: "/", SameSite: http.SameSiteLaxMode}},
+		{"production", middleware.SessionCookieConfig{
+			Path:     "/app",
+			Domain:   "example.com",
+			SameSite: http.SameSiteStrictMode,
+			Secure:   true,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware.SetSessionCookieConfig(tt.config)
+			t.Cleanup(func() { middleware.SetSessionCookieConfig(middleware.DefaultSessionCookieConfig()) })
+
+			assertAttributes := func(t *testing.T, cookie *http.Cookie) {
+				t.Helper()
+				require.NotNil(t, cookie)
+				assert.Equal(t, tt.config.Path, cookie.Path)
+				assert.Equal(t, tt.config.Domain, cookie.Domain)
+				assert.Equal(t, tt.config.SameSite, cookie.SameSite)
+				assert.Equal(t, tt.config.Secure, cookie.Secure)
+				assert.True(t, cookie.HttpOnly)
+			}
+
+			mockService := new(mockAuthService)
+			handler := NewAuthHandler(mockService)
+			e := setupEcho()
+			session := createTestSession()
+			session.ID = "session-123"
+
+			// Login sets the cookie with the configured attributes
+			mockService.On("Login", mock.Anything, mock.Anything).
+				Return(&application.AuthResult{User: createTestUser(), Session: session}, nil)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login",
+				strings.NewReader(`{"email":"test@example.com","password":"Password123"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			require.NoError(t, handler.Login(e.NewContext(req, rec)))
+			assertAttributes(t, findSessionCookie(rec))
+
+			// Refresh replaces it with the same attributes
+			mockService.On("RefreshSession", mock.Anything, mock.Anything).Return(session, nil)
+			rec = httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh", nil), rec)
+			c.Set(middleware.UserContextKey, createTestUser())
+			c.Set(middleware.SessionContextKey, session)
+			require.NoError(t, handler.RefreshSession(c))
+			assertAttributes(t, findSessionCookie(rec))
+
+			// Logout clears it with a matching Path and Domain, so browsers
+			// actually remove it
+			mockService.On("Logout", mock.Anything, mock.Anything).Return(nil)
+			rec = httptest.NewRecorder()
+			c = e.NewContext(httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout", nil), rec)
+			c.Set(middleware.UserContextKey, createTestUser())
+			c.Set(middleware.SessionContextKey, session)
+			require.NoError(t, handler.Logout(c))
+
+			cleared := findSessionCookie(rec)
+			assertAttributes(t, cleared)
+			assert.Empty(t, cleared.Value)
+			assert.Equal(t, -1, cleared.MaxAge)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-templ-template/internal/modules/auth/application"
+	"go-templ-template/internal/shared/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WithPasswordReset enables the password reset endpoints, backed by
+// resetService. Reset tokens are delivered by subscribers of the
+// auth.password.reset_requested event.
+func (h *AuthHandler) WithPasswordReset(resetService application.PasswordResetService) *AuthHandler {
+	h.resetService = resetService
+	return h
+}
+
+// passwordResetEnabled reports whether the password reset endpoints should be
+// registered
+func (h *AuthHandler) passwordResetEnabled() bool {
+	return h != nil && h.resetService != nil
+}
+
+// RequestPasswordReset handles POST /api/v1/auth/password/reset/request
+func (h *AuthHandler) RequestPasswordReset(c echo.Context) error {
+	var req PasswordResetRequest
+	if err := BindAndValidate(c, &req); err != nil {
+		return h.handleValidationError(c, err)
+	}
+
+	cmd := &application.RequestPasswordResetCommand{
+		Email:     req.Email,
+		IPAddress: c.RealIP(),
+	}
+
+	// Unknown and inactive accounts get the same response as active ones, so
+	// the endpoint can't be used to discover which emails are registered
+	_, err := h.resetService.RequestPasswordReset(c.Request().Context(), cmd)
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "If an account exists for this email, a password reset link has been sent",
+	})
+}
+
+// ConfirmPasswordReset handles POST /api/v1/auth/password/reset/confirm
+func (h *AuthHandler) ConfirmPasswordReset(c echo.Context) error {
+	var req ResetPasswordRequest
+	if err := BindAndValidate(c, &req); err != nil {
+		return h.handleValidationError(c, err)
+	}
+
+	cmd := &application.ResetPasswordCommand{
+		Token:       req.Token,
+		NewPassword: req.NewPassword,
+		IPAddress:   c.RealIP(),
+		UserAgent:   c.Request().UserAgent(),
+	}
+
+	if err := h.resetService.ResetPassword(c.Request().Context(), cmd); err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	// All sessions were revoked, including any this client had
+	middleware.SetSessionCookie(c, "", -1)
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Password reset successfully. Please log in with your new password.",
+	})
+}
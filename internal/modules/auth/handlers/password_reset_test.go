@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go-templ-template/internal/modules/auth/application"
+	"go-templ-template/internal/modules/auth/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockPasswordResetService is a mock PasswordResetService for testing
+type mockPasswordResetService struct {
+	mock.Mock
+}
+
+func (m *mockPasswordResetService) RequestPasswordReset(ctx context.Context, cmd *application.RequestPasswordResetCommand) (*domain.PasswordResetToken, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.PasswordResetToken), args.Error(1)
+}
+
+func (m *mockPasswordResetService) ResetPassword(ctx context.Context, cmd *application.ResetPasswordCommand) error {
+	args := m.Called(ctx, cmd)
+	return args.Error(0)
+}
+
+func newPasswordResetHandler() (*AuthHandler, *mockPasswordResetService) {
+	resetService := new(mockPasswordResetService)
+	handler := NewAuthHandler(new(mockAuthService)).WithPasswordReset(resetService)
+	return handler, resetService
+}
+
+func doPasswordResetRequest(handler func(echo.Context) error, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	_ = handler(setupEcho().NewContext(req, rec))
+	return rec
+}
+
+func TestAuthHandler_RequestPasswordReset_SameResponseForAnyEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		token *domain.PasswordResetToken
+	}{
+		{name: "existing email", token: &domain.PasswordResetToken{Token: "reset-token"}},
+		{name: "nonexistent email", token: nil},
+	}
+
+	var bodies []string
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, resetService := newPasswordResetHandler()
+			resetService.On("RequestPasswordReset", mock.Anything, mock.MatchedBy(func(cmd *application.RequestPasswordResetCommand) bool {
+				return cmd.Email == "test@example.com"
+			})).Return(tt.token, nil)
+
+			rec := doPasswordResetRequest(handler.RequestPasswordReset, "/api/v1/auth/password/reset/request", `{"email":"test@example.com"}`)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			// The token is only delivered by email
+			assert.NotContains(t, rec.Body.String(), "reset-token")
+			bodies = append(bodies, rec.Body.String())
+			resetService.AssertExpectations(t)
+		})
+	}
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestAuthHandler_RequestPasswordReset_InvalidEmail(t *testing.T) {
+	handler, resetService := newPasswordResetHandler()
+
+	rec := doPasswordResetRequest(handler.RequestPasswordReset, "/api/v1/auth/password/reset/request", `{"email":"not-an-email"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	resetService.AssertNotCalled(t, "RequestPasswordReset", mock.Anything, mock.Anything)
+}
+
+func TestAuthHandler_RequestPasswordReset_InternalError(t *testing.T) {
+	handler, resetService := newPasswordResetHandler()
+	resetService.On("RequestPasswordReset", mock.Anything, mock.Anything).Return(nil, errors.New("database unavailable"))
+
+	rec := doPasswordResetRequest(handler.RequestPasswordReset, "/api/v1/auth/password/reset/request", `{"email":"test@example.com"}`)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestAuthHandler_ConfirmPasswordReset_Success(t *testing.T) {
+	handler, resetService := newPasswordResetHandler()
+	resetService.On("ResetPassword", mock.Anything, mock.MatchedBy(func(cmd *application.ResetPasswordCommand) bool {
+		return cmd.Token == "valid-token-123" && cmd.NewPassword == "NewPassword456!"
+	})).Return(nil)
+
+	rec := doPasswordResetRequest(handler.ConfirmPasswordReset, "/api/v1/auth/password/reset/confirm",
+		`{"token":"valid-token-123","new_password":"NewPassword456!"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response SuccessResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Contains(t, response.Message, "Password reset successfully")
+	resetService.AssertExpectations(t)
+}
+
+func TestAuthHandler_ConfirmPasswordReset_RejectedTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code string
+	}{
+		{name: "expired token", err: application.NewResetTokenExpiredError(), code: application.ErrorCodeResetTokenExpired},
+		{name: "used token", err: application.NewResetTokenUsedError(), code: application.ErrorCodeResetTokenUsed},
+		{name: "unknown token", err: application.NewResetTokenInvalidError(), code: application.ErrorCodeResetTokenInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, resetService := newPasswordResetHandler()
+			resetService.On("ResetPassword", mock.Anything, mock.Anything).Return(tt.err)
+
+			rec := doPasswordResetRequest(handler.ConfirmPasswordReset, "/api/v1/auth/password/reset/confirm",
+				`{"token":"some-token-123","new_password":"NewPassword456!"}`)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.code, response.Error)
+			assert.Equal(t, "token", response.Field)
+		})
+	}
+}
+
+func TestAuthHandler_ConfirmPasswordReset_MissingFields(t *testing.T) {
+	handler, resetService := newPasswordResetHandler()
+
+	rec := doPasswordResetRequest(handler.ConfirmPasswordReset, "/api/v1/auth/password/reset/confirm", `{"token":""}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	resetService.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything)
+}
+
+func TestRegisterAuthHandlerOnGroup_PasswordResetRoutes(t *testing.T) {
+	findRoute := func(e *echo.Echo, method, path string) bool {
+		for _, route := range e.Routes() {
+			if route.Method == method && route.Path == path {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Without a password reset service the routes are not registered
+	e := echo.New()
+	RegisterAuthHandlerOnGroup(e.Group("/api/v1"), NewAuthHandler(new(mockAuthService)), new(mockAuthService))
+	assert.False(t, findRoute(e, http.MethodPost, "/api/v1/auth/password/reset/request"))
+	assert.False(t, findRoute(e, http.MethodPost, "/api/v1/auth/password/reset/confirm"))
+
+	handler, _ := newPasswordResetHandler()
+	e = echo.New()
+	RegisterAuthHandlerOnGroup(e.Group("/api/v1"), handler, new(mockAuthService))
+	assert.True(t, findRoute(e, http.MethodPost, "/api/v1/auth/password/reset/request"))
+	assert.True(t, findRoute(e, http.MethodPost, "/api/v1/auth/password/reset/confirm"))
+}
@@ -28,6 +28,7 @@ func RegisterAuthRoutes(e *echo.Echo, authService application.AuthService) {
 		auth.POST("/register", authHandler.Register)             // POST /api/v1/auth/register
 		auth.GET("/validate", authHandler.ValidateSession)       // GET /api/v1/auth/validate
 		auth.GET("/csrf-token", csrfMiddleware.CSRFTokenHandler) // GET /api/v1/auth/csrf-token
+		auth.GET("/csrf", csrfMiddleware.CSRFTokenHandler)       // GET /api/v1/auth/csrf
 	}
 
 	// Protected auth routes (authentication required)
@@ -35,10 +36,13 @@ func RegisterAuthRoutes(e *echo.Echo, authService application.AuthService) {
 	authProtected.Use(authMiddleware.RequireAuth)
 	authProtected.Use(csrfMiddleware.Protect) // Apply CSRF protection to protected routes too
 	{
-		authProtected.POST("/logout", authHandler.Logout)          // POST /api/v1/auth/logout
-		authProtected.GET("/me", authHandler.Me)                   // GET /api/v1/auth/me
-		authProtected.POST("/refresh", authHandler.RefreshSession) // POST /api/v1/auth/refresh
-		authProtected.PUT("/password", authHandler.ChangePassword) // PUT /api/v1/auth/password
+		authProtected.POST("/logout", authHandler.Logout)                  // POST /api/v1/auth/logout
+		authProtected.GET("/me", authHandler.Me)                           // GET /api/v1/auth/me
+		authProtected.POST("/refresh", authHandler.RefreshSession)         // POST /api/v1/auth/refresh
+		authProtected.PUT("/password", authHandler.ChangePassword)         // PUT /api/v1/auth/password
+		authProtected.GET("/sessions", authHandler.ListSessions)           // GET /api/v1/auth/sessions
+		authProtected.DELETE("/sessions", authHandler.RevokeOtherSessions) // DELETE /api/v1/auth/sessions
+		authProtected.DELETE("/sessions/:id", authHandler.RevokeSession)   // DELETE /api/v1/auth/sessions/:id
 	}
 }
 
@@ -67,6 +71,7 @@ func RegisterAuthRoutesWithMiddleware(e *echo.Echo, authService application.Auth
 		auth.POST("/register", authHandler.Register)             // POST /api/v1/auth/register
 		auth.GET("/validate", authHandler.ValidateSession)       // GET /api/v1/auth/validate
 		auth.GET("/csrf-token", csrfMiddleware.CSRFTokenHandler) // GET /api/v1/auth/csrf-token
+		auth.GET("/csrf", csrfMiddleware.CSRFTokenHandler)       // GET /api/v1/auth/csrf
 	}
 
 	// Protected auth routes (authentication required)
@@ -74,16 +79,24 @@ func RegisterAuthRoutesWithMiddleware(e *echo.Echo, authService application.Auth
 	authProtected.Use(authMiddleware.RequireAuth)
 	authProtected.Use(csrfMiddleware.Protect) // Apply CSRF protection
 	{
-		authProtected.POST("/logout", authHandler.Logout)          // POST /api/v1/auth/logout
-		authProtected.GET("/me", authHandler.Me)                   // GET /api/v1/auth/me
-		authProtected.POST("/refresh", authHandler.RefreshSession) // POST /api/v1/auth/refresh
-		authProtected.PUT("/password", authHandler.ChangePassword) // PUT /api/v1/auth/password
+		authProtected.POST("/logout", authHandler.Logout)                  // POST /api/v1/auth/logout
+		authProtected.GET("/me", authHandler.Me)                           // GET /api/v1/auth/me
+		authProtected.POST("/refresh", authHandler.RefreshSession)         // POST /api/v1/auth/refresh
+		authProtected.PUT("/password", authHandler.ChangePassword)         // PUT /api/v1/auth/password
+		authProtected.GET("/sessions", authHandler.ListSessions)           // GET /api/v1/auth/sessions
+		authProtected.DELETE("/sessions", authHandler.RevokeOtherSessions) // DELETE /api/v1/auth/sessions
+		authProtected.DELETE("/sessions/:id", authHandler.RevokeSession)   // DELETE /api/v1/auth/sessions/:id
 	}
 }
 
 // RegisterAuthRoutesOnGroup registers auth routes on a provided group (for module system)
 func RegisterAuthRoutesOnGroup(group *echo.Group, authService application.AuthService) {
-	authHandler := NewAuthHandler(authService)
+	RegisterAuthHandlerOnGroup(group, NewAuthHandler(authService), authService)
+}
+
+// RegisterAuthHandlerOnGroup registers the routes of a configured auth handler
+// on a provided group, e.g. one with login protection enabled
+func RegisterAuthHandlerOnGroup(group *echo.Group, authHandler *AuthHandler, authService application.AuthService) {
 	authMiddleware := middleware.NewAuthMiddleware(authService)
 
 	// Create CSRF middleware
@@ -100,17 +113,52 @@ func RegisterAuthRoutesOnGroup(group *echo.Group, authService application.AuthSe
 		auth.POST("/register", authHandler.Register)             // POST /api/v1/auth/register
 		auth.GET("/validate", authHandler.ValidateSession)       // GET /api/v1/auth/validate
 		auth.GET("/csrf-token", csrfMiddleware.CSRFTokenHandler) // GET /api/v1/auth/csrf-token
+		auth.GET("/csrf", csrfMiddleware.CSRFTokenHandler)       // GET /api/v1/auth/csrf
+	}
+
+	// Email verification routes, when the handler has an activation service
+	if authHandler.emailVerificationEnabled() {
+		auth.POST("/verify/request", authHandler.RequestVerification) // POST /api/v1/auth/verify/request
+		auth.GET("/verify/confirm", authHandler.ConfirmVerification)  // GET /api/v1/auth/verify/confirm
+	}
+
+	// Password reset routes, when the handler has a password reset service
+	if authHandler.passwordResetEnabled() {
+		auth.POST("/password/reset/request", authHandler.RequestPasswordReset) // POST /api/v1/auth/password/reset/request
+		auth.POST("/password/reset/confirm", authHandler.ConfirmPasswordReset) // POST /api/v1/auth/password/reset/confirm
+	}
+
+	// Token refresh route, when the handler issues tokens. Refresh tokens are
+	// sent in the body rather than a cookie, so CSRF protection isn't needed
+	// and token clients never hold a CSRF cookie.
+	if authHandler.tokenAuthEnabled() {
+		tokens := group.Group("/auth/token")
+		tokens.POST("/refresh", authHandler.RefreshToken) // POST /api/v1/auth/token/refresh
 	}
 
 	// Protected auth routes (authentication required)
 	authProtected := group.Group("/auth")
 	authProtected.Use(authMiddleware.RequireAuth)
 	authProtected.Use(csrfMiddleware.Protect) // Apply CSRF protection to protected routes too
+	if authHandler.idempotencyEnabled() {
+		authProtected.Use(middleware.IdempotencyMiddleware(authHandler.idempotencyStore, 0))
+	}
 	{
-		authProtected.POST("/logout", authHandler.Logout)          // POST /api/v1/auth/logout
-		authProtected.GET("/me", authHandler.Me)                   // GET /api/v1/auth/me
-		authProtected.POST("/refresh", authHandler.RefreshSession) // POST /api/v1/auth/refresh
-		authProtected.PUT("/password", authHandler.ChangePassword) // PUT /api/v1/auth/password
+		authProtected.POST("/logout", authHandler.Logout)                  // POST /api/v1/auth/logout
+		authProtected.GET("/me", authHandler.Me)                           // GET /api/v1/auth/me
+		authProtected.POST("/refresh", authHandler.RefreshSession)         // POST /api/v1/auth/refresh
+		authProtected.PUT("/password", authHandler.ChangePassword)         // PUT /api/v1/auth/password
+		authProtected.GET("/sessions", authHandler.ListSessions)           // GET /api/v1/auth/sessions
+		authProtected.DELETE("/sessions", authHandler.RevokeOtherSessions) // DELETE /api/v1/auth/sessions
+		authProtected.DELETE("/sessions/:id", authHandler.RevokeSession)   // DELETE /api/v1/auth/sessions/:id
+	}
+
+	// API key management routes, when the handler has an API key service.
+	// They need a session, so API keys can't be used to create more keys.
+	if authHandler.apiKeysEnabled() {
+		authProtected.POST("/api-keys", authHandler.CreateAPIKey)       // POST /api/v1/auth/api-keys
+		authProtected.GET("/api-keys", authHandler.ListAPIKeys)         // GET /api/v1/auth/api-keys
+		authProtected.DELETE("/api-keys/:id", authHandler.RevokeAPIKey) // DELETE /api/v1/auth/api-keys/:id
 	}
 }
 
@@ -160,6 +160,7 @@ func TestRegisterAuthRoutes_WithValidSession(t *testing.T) {
 	mockService.On("ValidateSession", mock.Anything, mock.MatchedBy(func(query *application.ValidateSessionQuery) bool {
 		return query.SessionID == "valid-session-id"
 	})).Return(validationResult, nil)
+	mockService.On("TouchSession", mock.Anything, mock.Anything).Return(nil)
 
 	// Mock logout with proper command matching
 	mockService.On("Logout", mock.Anything, mock.MatchedBy(func(cmd *application.LogoutCommand) bool {
@@ -408,3 +409,73 @@ func TestAuthRoutes_ErrorHandling(t *testing.T) {
 
 	mockService.AssertExpectations(t)
 }
+
+func TestAuthRoutes_CSRFTokenForSPA(t *testing.T) {
+	mockService := new(mockAuthService)
+	mockService.On("Login", mock.Anything, mock.Anything).Return(&application.AuthResult{
+		User:    createTestUser(),
+		Session: createTestSession(),
+	}, nil)
+
+	e := setupEcho()
+	RegisterAuthRoutes(e, mockService)
+
+	// Fetch a token the way a JS front-end would
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/csrf", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var tokenResponse map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tokenResponse))
+	token := tokenResponse["csrf_token"]
+	require.NotEmpty(t, token)
+
+	var csrfCookie *http.Cookie
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == middleware.CSRFCookieName {
+			csrfCookie = cookie
+		}
+	}
+	require.NotNil(t, csrfCookie)
+	assert.Equal(t, token, csrfCookie.Value)
+	assert.False(t, csrfCookie.HttpOnly, "front-end must be able to read the cookie")
+
+	// Later GETs keep the token the front-end holds
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/auth/csrf-token", nil)
+	req.AddCookie(csrfCookie)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tokenResponse))
+	assert.Equal(t, token, tokenResponse["csrf_token"])
+
+	login := func(withHeader bool) *httptest.ResponseRecorder {
+		reqBody, _ := json.Marshal(LoginRequest{
+			Email:    "test@example.com",
+			Password: "Password123",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(reqBody))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.AddCookie(csrfCookie)
+		if withHeader {
+			req.Header.Set(middleware.CSRFHeaderName, token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// POST with the token in the header is accepted
+	rec = login(true)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// POST without the header is rejected
+	rec = login(false)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "CSRF_TOKEN_MISSING", response["error"])
+
+	mockService.AssertNumberOfCalls(t, "Login", 1)
+}
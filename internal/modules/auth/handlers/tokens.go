@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-templ-template/internal/modules/auth/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WithTokenAuth enables stateless authentication: logins also issue an
+// access and refresh token, and the token refresh endpoint is registered.
+// Session cookies are still set, so browser clients are unaffected.
+func (h *AuthHandler) WithTokenAuth(tokenService application.TokenService) *AuthHandler {
+	h.tokenService = tokenService
+	return h
+}
+
+// tokenAuthEnabled reports whether logins issue tokens and the token refresh
+// endpoint should be registered
+func (h *AuthHandler) tokenAuthEnabled() bool {
+	return h != nil && h.tokenService != nil
+}
+
+// RefreshToken handles POST /api/v1/auth/token/refresh
+func (h *AuthHandler) RefreshToken(c echo.Context) error {
+	var req RefreshTokenRequest
+	if err := BindAndValidate(c, &req); err != nil {
+		return h.handleValidationError(c, err)
+	}
+
+	tokens, _, err := h.tokenService.Refresh(c.Request().Context(), req.RefreshToken)
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, ToTokenResponse(tokens))
+}
@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/auth/application"
+	userDomain "go-templ-template/internal/modules/user/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTokenService is a mock TokenService for testing
+type mockTokenService struct {
+	mock.Mock
+}
+
+func (m *mockTokenService) IssueTokens(user *userDomain.User) (*application.TokenPair, error) {
+	args := m.Called(user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.TokenPair), args.Error(1)
+}
+
+func (m *mockTokenService) IssueScopedTokens(user *userDomain.User, scopes []string) (*application.TokenPair, error) {
+	args := m.Called(user, scopes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*application.TokenPair), args.Error(1)
+}
+
+func (m *mockTokenService) Authenticate(ctx context.Context, accessToken string) (*userDomain.User, []string, error) {
+	args := m.Called(ctx, accessToken)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	scopes, _ := args.Get(1).([]string)
+	return args.Get(0).(*userDomain.User), scopes, args.Error(2)
+}
+
+func (m *mockTokenService) Refresh(ctx context.Context, refreshToken string) (*application.TokenPair, *userDomain.User, error) {
+	args := m.Called(ctx, refreshToken)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*application.TokenPair), args.Get(1).(*userDomain.User), args.Error(2)
+}
+
+func createTestTokenPair() *application.TokenPair {
+	now := time.Now().Truncate(time.Second)
+	return &application.TokenPair{
+		AccessToken:      "access.token.signature",
+		RefreshToken:     "refresh.token.signature",
+		AccessExpiresAt:  now.Add(15 * time.Minute),
+		RefreshExpiresAt: now.Add(30 * 24 * time.Hour),
+	}
+}
+
+func TestAuthHandler_Login_IssuesTokens(t *testing.T) {
+	authService := new(mockAuthService)
+	tokenService := new(mockTokenService)
+	handler := NewAuthHandler(authService).WithTokenAuth(tokenService)
+
+	user := createTestUser()
+	authService.On("Login", mock.Anything, mock.Anything).Return(&application.AuthResult{
+		User:    user,
+		Session: createTestSession(),
+	}, nil)
+	pair := createTestTokenPair()
+	tokenService.On("IssueTokens", user).Return(pair, nil)
+
+	rec := doLogin(t, handler, "Password123", "192.168.1.1")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response AuthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.NotNil(t, response.Tokens)
+	assert.Equal(t, "access.token.signature", response.Tokens.AccessToken)
+	assert.Equal(t, "refresh.token.signature", response.Tokens.RefreshToken)
+	assert.Equal(t, "Bearer", response.Tokens.TokenType)
+	assert.True(t, pair.AccessExpiresAt.Equal(response.Tokens.ExpiresAt))
+
+	// Browser clients still get their session cookie
+	assert.NotEmpty(t, rec.Result().Cookies())
+	tokenService.AssertExpectations(t)
+}
+
+func TestAuthHandler_Login_NoTokensByDefault(t *testing.T) {
+	authService := new(mockAuthService)
+	authService.On("Login", mock.Anything, mock.Anything).Return(&application.AuthResult{
+		User:    createTestUser(),
+		Session: createTestSession(),
+	}, nil)
+
+	rec := doLogin(t, NewAuthHandler(authService), "Password123", "192.168.1.1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "tokens")
+}
+
+func TestAuthHandler_RefreshToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(tokenService *mockTokenService)
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name: "valid refresh token",
+			body: `{"refresh_token":"refresh.token.signature"}`,
+			setup: func(tokenService *mockTokenService) {
+				tokenService.On("Refresh", mock.Anything, "refresh.token.signature").
+					Return(createTestTokenPair(), createTestUser(), nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "expired refresh token",
+			body: `{"refresh_token":"expired.token.signature"}`,
+			setup: func(tokenService *mockTokenService) {
+				tokenService.On("Refresh", mock.Anything, "expired.token.signature").
+					Return(nil, nil, application.NewTokenExpiredError())
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantError:  application.ErrorCodeTokenExpired,
+		},
+		{
+			name: "tampered refresh token",
+			body: `{"refresh_token":"tampered.token.signature"}`,
+			setup: func(tokenService *mockTokenService) {
+				tokenService.On("Refresh", mock.Anything, "tampered.token.signature").
+					Return(nil, nil, application.NewTokenInvalidError())
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantError:  application.ErrorCodeTokenInvalid,
+		},
+		{
+			name:       "missing refresh token",
+			body:       `{}`,
+			setup:      func(tokenService *mockTokenService) {},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "FIELD_REQUIRED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenService := new(mockTokenService)
+			tt.setup(tokenService)
+			handler := NewAuthHandler(new(mockAuthService)).WithTokenAuth(tokenService)
+
+			e := setupEcho()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/token/refresh", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			if err := handler.RefreshToken(c); err != nil {
+				e.HTTPErrorHandler(err, c)
+			}
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				assert.Contains(t, rec.Body.String(), tt.wantError)
+				return
+			}
+
+			var response TokenResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, "access.token.signature", response.AccessToken)
+			assert.Equal(t, "refresh.token.signature", response.RefreshToken)
+			tokenService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRegisterAuthHandlerOnGroup_TokenRefreshRoute(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    func() *AuthHandler
+		wantStatus int
+	}{
+		{
+			name: "registered with token auth",
+			handler: func() *AuthHandler {
+				tokenService := new(mockTokenService)
+				tokenService.On("Refresh", mock.Anything, "refresh.token.signature").
+					Return(createTestTokenPair(), createTestUser(), nil)
+				return NewAuthHandler(new(mockAuthService)).WithTokenAuth(tokenService)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			// The request falls through to the session-protected auth routes
+			name:       "not registered by default",
+			handler:    func() *AuthHandler { return NewAuthHandler(new(mockAuthService)) },
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := setupEcho()
+			RegisterAuthHandlerOnGroup(e.Group("/api/v1"), tt.handler(), new(mockAuthService))
+
+			// Token clients hold no CSRF cookie, so none is sent
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/token/refresh",
+				bytes.NewReader([]byte(`{"refresh_token":"refresh.token.signature"}`)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -6,9 +6,30 @@ import (
 	"regexp"
 	"strings"
 
+	appErrors "go-templ-template/internal/shared/errors"
+	"go-templ-template/internal/shared/validation"
+
 	"github.com/labstack/echo/v4"
 )
 
+// requestValidator checks login and registration requests against their
+// validate tags, adding the password complexity and name character rules
+var requestValidator = validation.New().
+	WithRule("password", func(field, value, param string) *appErrors.AppError {
+		if !isValidPassword(value) {
+			return validation.Invalid("WEAK_PASSWORD", field,
+				"password must contain at least one uppercase letter, one lowercase letter, and one digit")
+		}
+		return nil
+	}).
+	WithRule("name", func(field, value, param string) *appErrors.AppError {
+		if !isValidName(value) {
+			return validation.Invalid("INVALID_NAME", field,
+				strings.ReplaceAll(field, "_", " ")+" contains invalid characters")
+		}
+		return nil
+	})
+
 // ValidationError represents a validation error
 type ValidationError struct {
 	Field   string `json:"field"`
@@ -155,19 +176,91 @@ func ValidateChangePasswordRequest(req *ChangePasswordRequest) error {
 	return nil
 }
 
-// BindAndValidate binds the request and validates it
+// ValidateVerificationRequest validates the email verification request
+func ValidateVerificationRequest(req *VerificationRequest) error {
+	var errors []ValidationError
+
+	if req.Email == "" {
+		errors = append(errors, ValidationError{Field: "email", Message: "email is required"})
+	} else {
+		if _, err := mail.ParseAddress(req.Email); err != nil {
+			errors = append(errors, ValidationError{Field: "email", Message: "invalid email format"})
+		}
+		if len(req.Email) > 255 {
+			errors = append(errors, ValidationError{Field: "email", Message: "email cannot exceed 255 characters"})
+		}
+	}
+
+	if len(errors) > 0 {
+		return ValidationErrors{Errors: errors}
+	}
+
+	return nil
+}
+
+// ValidatePasswordResetRequest validates the password reset request
+func ValidatePasswordResetRequest(req *PasswordResetRequest) error {
+	var errors []ValidationError
+
+	if req.Email == "" {
+		errors = append(errors, ValidationError{Field: "email", Message: "email is required"})
+	} else {
+		if _, err := mail.ParseAddress(req.Email); err != nil {
+			errors = append(errors, ValidationError{Field: "email", Message: "invalid email format"})
+		}
+		if len(req.Email) > 255 {
+			errors = append(errors, ValidationError{Field: "email", Message: "email cannot exceed 255 characters"})
+		}
+	}
+
+	if len(errors) > 0 {
+		return ValidationErrors{Errors: errors}
+	}
+
+	return nil
+}
+
+// ValidateResetPasswordRequest validates the reset password request. Password
+// strength is checked by the service against the configured policy.
+func ValidateResetPasswordRequest(req *ResetPasswordRequest) error {
+	var errors []ValidationError
+
+	if req.Token == "" {
+		errors = append(errors, ValidationError{Field: "token", Message: "reset token is required"})
+	}
+
+	if req.NewPassword == "" {
+		errors = append(errors, ValidationError{Field: "new_password", Message: "new password is required"})
+	} else if len(req.NewPassword) > 128 {
+		errors = append(errors, ValidationError{Field: "new_password", Message: "new password cannot exceed 128 characters"})
+	}
+
+	if len(errors) > 0 {
+		return ValidationErrors{Errors: errors}
+	}
+
+	return nil
+}
+
+// BindAndValidate binds the request and validates it. Login, registration,
+// token refresh and API key requests are checked against their validate tags,
+// failing with an *errors.ErrorList holding one error per invalid field.
 func BindAndValidate(c echo.Context, req interface{}) error {
 	if err := c.Bind(req); err != nil {
 		return echo.NewHTTPError(400, "Invalid request format")
 	}
 
 	switch v := req.(type) {
-	case *LoginRequest:
-		return ValidateLoginRequest(v)
-	case *RegisterRequest:
-		return ValidateRegisterRequest(v)
+	case *LoginRequest, *RegisterRequest, *RefreshTokenRequest, *CreateAPIKeyRequest:
+		return requestValidator.Struct(v)
 	case *ChangePasswordRequest:
 		return ValidateChangePasswordRequest(v)
+	case *VerificationRequest:
+		return ValidateVerificationRequest(v)
+	case *PasswordResetRequest:
+		return ValidatePasswordResetRequest(v)
+	case *ResetPasswordRequest:
+		return ValidateResetPasswordRequest(v)
 	}
 
 	return nil
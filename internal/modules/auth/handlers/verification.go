@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+
+	userApplication "go-templ-template/internal/modules/user/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WithEmailVerification enables the email verification endpoints, backed by
+// activationService. Verification tokens are delivered by subscribers of the
+// user.activation_requested event.
+func (h *AuthHandler) WithEmailVerification(activationService userApplication.ActivationService) *AuthHandler {
+	h.activationService = activationService
+	return h
+}
+
+// emailVerificationEnabled reports whether the email verification endpoints
+// should be registered
+func (h *AuthHandler) emailVerificationEnabled() bool {
+	return h != nil && h.activationService != nil
+}
+
+// RequestVerification handles POST /api/v1/auth/verify/request
+func (h *AuthHandler) RequestVerification(c echo.Context) error {
+	var req VerificationRequest
+	if err := BindAndValidate(c, &req); err != nil {
+		return h.handleValidationError(c, err)
+	}
+
+	cmd := &userApplication.RequestActivationCommand{
+		Email:       req.Email,
+		RequestedBy: "self",
+	}
+
+	// Unknown and already verified accounts get the same response, so the
+	// endpoint can't be used to discover which emails are registered
+	_, err := h.activationService.RequestActivation(c.Request().Context(), cmd)
+	if err != nil && !userApplication.IsUserNotFoundError(err) && !userApplication.IsValidationError(err) {
+		return c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "INTERNAL_ERROR",
+			Message: "An unexpected error occurred",
+		})
+	}
+
+	return c.JSON(http.StatusAccepted, SuccessResponse{
+		Message: "If the account exists and is awaiting verification, a verification email has been sent",
+	})
+}
+
+// ConfirmVerification handles GET /api/v1/auth/verify/confirm?token=...
+func (h *AuthHandler) ConfirmVerification(c echo.Context) error {
+	token := c.QueryParam("token")
+	if token == "" {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: "verification token is required",
+			Field:   "token",
+		})
+	}
+
+	cmd := &userApplication.ActivateUserCommand{
+		Token:       token,
+		ActivatedBy: "self",
+	}
+
+	user, err := h.activationService.ActivateUser(c.Request().Context(), cmd)
+	if err != nil {
+		return h.handleVerificationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Email verified successfully",
+		Data:    ToUserResponse(user),
+	})
+}
+
+// handleVerificationError maps activation service errors to responses
+func (h *AuthHandler) handleVerificationError(c echo.Context, err error) error {
+	if appErr, ok := err.(*userApplication.ApplicationError); ok {
+		switch appErr.Code {
+		case userApplication.ErrCodeValidation:
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   appErr.Code,
+				Message: appErr.Message,
+				Field:   appErr.Field,
+			})
+		case userApplication.ErrCodeUserNotFound:
+			// The token outlived its user
+			return c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   userApplication.ErrCodeValidation,
+				Message: "invalid activation token",
+				Field:   "token",
+			})
+		}
+	}
+
+	return c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "INTERNAL_ERROR",
+		Message: "An unexpected error occurred",
+	})
+}
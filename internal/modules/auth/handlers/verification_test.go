@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	userApplication "go-templ-template/internal/modules/user/application"
+	userDomain "go-templ-template/internal/modules/user/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockActivationService is a mock ActivationService for testing
+type mockActivationService struct {
+	mock.Mock
+}
+
+func (m *mockActivationService) RequestActivation(ctx context.Context, cmd *userApplication.RequestActivationCommand) (*userDomain.ActivationToken, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.ActivationToken), args.Error(1)
+}
+
+func (m *mockActivationService) ActivateUser(ctx context.Context, cmd *userApplication.ActivateUserCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *mockActivationService) DeactivateUser(ctx context.Context, cmd *userApplication.DeactivateUserCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *mockActivationService) CleanupExpiredTokens(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *mockActivationService) GetActivationToken(ctx context.Context, token string) (*userDomain.ActivationToken, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.ActivationToken), args.Error(1)
+}
+
+func newVerificationHandler() (*AuthHandler, *mockActivationService) {
+	activationService := new(mockActivationService)
+	handler := NewAuthHandler(new(mockAuthService)).WithEmailVerification(activationService)
+	return handler, activationService
+}
+
+func doRequestVerification(handler *AuthHandler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/verify/request", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	_ = handler.RequestVerification(setupEcho().NewContext(req, rec))
+	return rec
+}
+
+func doConfirmVerification(handler *AuthHandler, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/verify/confirm?token="+token, nil)
+	rec := httptest.NewRecorder()
+	_ = handler.ConfirmVerification(setupEcho().NewContext(req, rec))
+	return rec
+}
+
+func TestAuthHandler_RequestVerification_IssuesToken(t *testing.T) {
+	handler, activationService := newVerificationHandler()
+
+	activationService.On("RequestActivation", mock.Anything, mock.MatchedBy(func(cmd *userApplication.RequestActivationCommand) bool {
+		return cmd.Email == "test@example.com"
+	})).Return(&userDomain.ActivationToken{Token: "verification-token"}, nil)
+
+	rec := doRequestVerification(handler, `{"email":"test@example.com"}`)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	// The token is only delivered by email
+	assert.NotContains(t, rec.Body.String(), "verification-token")
+	activationService.AssertExpectations(t)
+}
+
+func TestAuthHandler_RequestVerification_HidesAccountState(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "unknown email", err: userApplication.NewUserNotFoundError("unknown@example.com")},
+		{name: "already verified", err: userApplication.NewValidationError("activation", "user is already active")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, activationService := newVerificationHandler()
+			activationService.On("RequestActivation", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			rec := doRequestVerification(handler, `{"email":"test@example.com"}`)
+			assert.Equal(t, http.StatusAccepted, rec.Code)
+		})
+	}
+}
+
+func TestAuthHandler_RequestVerification_InvalidEmail(t *testing.T) {
+	handler, activationService := newVerificationHandler()
+
+	rec := doRequestVerification(handler, `{"email":"not-an-email"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	activationService.AssertNotCalled(t, "RequestActivation", mock.Anything, mock.Anything)
+}
+
+func TestAuthHandler_RequestVerification_InternalError(t *testing.T) {
+	handler, activationService := newVerificationHandler()
+	activationService.On("RequestActivation", mock.Anything, mock.Anything).Return(nil, errors.New("database unavailable"))
+
+	rec := doRequestVerification(handler, `{"email":"test@example.com"}`)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestAuthHandler_ConfirmVerification_ActivatesUser(t *testing.T) {
+	handler, activationService := newVerificationHandler()
+
+	user := createTestUser()
+	activationService.On("ActivateUser", mock.Anything, mock.MatchedBy(func(cmd *userApplication.ActivateUserCommand) bool {
+		return cmd.Token == "valid-token-123"
+	})).Return(user, nil)
+
+	rec := doConfirmVerification(handler, "valid-token-123")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "Email verified successfully", response["message"])
+	data := response["data"].(map[string]interface{})
+	assert.Equal(t, "active", data["status"])
+	activationService.AssertExpectations(t)
+}
+
+func TestAuthHandler_ConfirmVerification_RejectedTokens(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		message string
+	}{
+		{
+			name:    "reused token",
+			err:     userApplication.NewValidationError("token", "activation token has already been used"),
+			message: "activation token has already been used",
+		},
+		{
+			name:    "expired token",
+			err:     userApplication.NewValidationError("token", "activation token has expired"),
+			message: "activation token has expired",
+		},
+		{
+			name:    "unknown token",
+			err:     userApplication.NewValidationError("token", "invalid activation token"),
+			message: "invalid activation token",
+		},
+		{
+			name:    "deleted user",
+			err:     userApplication.NewUserNotFoundError("user-123"),
+			message: "invalid activation token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, activationService := newVerificationHandler()
+			activationService.On("ActivateUser", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+			rec := doConfirmVerification(handler, "some-token-123")
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var response ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, "VALIDATION_ERROR", response.Error)
+			assert.Equal(t, tt.message, response.Message)
+			assert.Equal(t, "token", response.Field)
+		})
+	}
+}
+
+func TestAuthHandler_ConfirmVerification_MissingToken(t *testing.T) {
+	handler, activationService := newVerificationHandler()
+
+	rec := doConfirmVerification(handler, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	activationService.AssertNotCalled(t, "ActivateUser", mock.Anything, mock.Anything)
+}
+
+func TestRegisterAuthHandlerOnGroup_VerificationRoutes(t *testing.T) {
+	findRoute := func(e *echo.Echo, method, path string) bool {
+		for _, route := range e.Routes() {
+			if route.Method == method && route.Path == path {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Without an activation service the routes are not registered
+	e := echo.New()
+	RegisterAuthHandlerOnGroup(e.Group("/api/v1"), NewAuthHandler(new(mockAuthService)), new(mockAuthService))
+	assert.False(t, findRoute(e, http.MethodPost, "/api/v1/auth/verify/request"))
+	assert.False(t, findRoute(e, http.MethodGet, "/api/v1/auth/verify/confirm"))
+
+	handler, _ := newVerificationHandler()
+	e = echo.New()
+	RegisterAuthHandlerOnGroup(e.Group("/api/v1"), handler, new(mockAuthService))
+	assert.True(t, findRoute(e, http.MethodPost, "/api/v1/auth/verify/request"))
+	assert.True(t, findRoute(e, http.MethodGet, "/api/v1/auth/verify/confirm"))
+}
@@ -0,0 +1,110 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-templ-template/internal/modules/auth/application"
+	"go-templ-template/internal/modules/auth/domain"
+	"go-templ-template/internal/shared/database"
+)
+
+// apiKeyRepository implements the APIKeyRepository interface
+type apiKeyRepository struct {
+	db *database.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *database.DB) application.APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new API key
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, user_id, name, prefix, key_hash, scopes, expires_at, created_at)
+		VALUES (:id, :user_id, :name, :prefix, :key_hash, :scopes, :expires_at, :created_at)`
+
+	_, err := database.GetExecutor(ctx, r.db).NamedExecContext(ctx, query, key)
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return nil
+}
+
+// GetByKeyHash retrieves an API key by the hash of its value
+func (r *apiKeyRepository) GetByKeyHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `
+		SELECT id, user_id, name, prefix, key_hash, scopes, last_used_at, expires_at, revoked_at, created_at
+		FROM api_keys
+		WHERE key_hash = $1`
+
+	var key domain.APIKey
+	err := database.GetExecutor(ctx, r.db).GetContext(ctx, &key, query, keyHash)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return &key, nil
+}
+
+// ListByUserID retrieves a user's unrevoked API keys, newest first
+func (r *apiKeyRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.APIKey, error) {
+	query := `
+		SELECT id, user_id, name, prefix, key_hash, scopes, last_used_at, expires_at, revoked_at, created_at
+		FROM api_keys
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC`
+
+	var keys []*domain.APIKey
+	err := database.GetExecutor(ctx, r.db).SelectContext(ctx, &keys, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke revokes one of a user's API keys. Revoking a missing, already
+// revoked or another user's key returns database.ErrNotFound.
+func (r *apiKeyRepository) Revoke(ctx context.Context, keyID, userID string, revokedAt time.Time) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = $3
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	result, err := database.GetExecutor(ctx, r.db).ExecContext(ctx, query, keyID, userID, revokedAt)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+// UpdateLastUsed records when an API key was last used
+func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, keyID string, usedAt time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`
+
+	_, err := database.GetExecutor(ctx, r.db).ExecContext(ctx, query, keyID, usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update API key last used time: %w", err)
+	}
+
+	return nil
+}
@@ -66,6 +66,7 @@ func (suite *SessionCleanupTestSuite) createTestTables() {
 			user_id VARCHAR(255) NOT NULL,
 			expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
 			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+			last_activity_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
 			ip_address INET,
 			user_agent TEXT,
 			is_active BOOLEAN NOT NULL DEFAULT true
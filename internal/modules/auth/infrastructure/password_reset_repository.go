@@ -0,0 +1,105 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-templ-template/internal/modules/auth/application"
+	"go-templ-template/internal/modules/auth/domain"
+	"go-templ-template/internal/shared/database"
+)
+
+// passwordResetTokenRepository implements the PasswordResetTokenRepository interface
+type passwordResetTokenRepository struct {
+	db *database.DB
+}
+
+// NewPasswordResetTokenRepository creates a new password reset token repository
+func NewPasswordResetTokenRepository(db *database.DB) application.PasswordResetTokenRepository {
+	return &passwordResetTokenRepository{
+		db: db,
+	}
+}
+
+// Create inserts a new password reset token
+func (r *passwordResetTokenRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	query := `
+		INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at)
+		VALUES (:id, :user_id, :token_hash, :expires_at, :created_at)`
+
+	_, err := database.GetExecutor(ctx, r.db).NamedExecContext(ctx, query, token)
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTokenHash retrieves a password reset token by the hash of its value
+func (r *passwordResetTokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, token_hash, expires_at, used_at, created_at
+		FROM password_reset_tokens
+		WHERE token_hash = $1`
+
+	var token domain.PasswordResetToken
+	err := database.GetExecutor(ctx, r.db).GetContext(ctx, &token, query, tokenHash)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	return &token, nil
+}
+
+// MarkUsed records a password reset token's use. Tokens are single-use, so
+// marking an already used token returns database.ErrNotFound.
+func (r *passwordResetTokenRepository) MarkUsed(ctx context.Context, tokenID string, usedAt time.Time) error {
+	query := `
+		UPDATE password_reset_tokens
+		SET used_at = $2
+		WHERE id = $1 AND used_at IS NULL`
+
+	result, err := database.GetExecutor(ctx, r.db).ExecContext(ctx, query, tokenID, usedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update password reset token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeleteByUserID deletes all password reset tokens for a user
+func (r *passwordResetTokenRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	query := `DELETE FROM password_reset_tokens WHERE user_id = $1`
+
+	_, err := database.GetExecutor(ctx, r.db).ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete password reset tokens by user ID: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes all expired password reset tokens
+func (r *passwordResetTokenRepository) DeleteExpired(ctx context.Context) error {
+	query := `DELETE FROM password_reset_tokens WHERE expires_at < $1`
+
+	_, err := database.GetExecutor(ctx, r.db).ExecContext(ctx, query, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to delete expired password reset tokens: %w", err)
+	}
+
+	return nil
+}
@@ -36,6 +36,10 @@ type SessionRepository interface {
 	// ValidateAndGet retrieves a session and validates it's active and not expired
 	ValidateAndGet(ctx context.Context, sessionID string) (*domain.Session, error)
 
+	// UpdateActivity stores a session's last activity and expiry, leaving a
+	// session revoked in the meantime untouched
+	UpdateActivity(ctx context.Context, session *domain.Session) error
+
 	// ExtendSession extends the expiration time of a session
 	ExtendSession(ctx context.Context, sessionID string, duration time.Duration) error
 
@@ -64,11 +68,17 @@ func NewSessionRepository(db *database.DB) SessionRepository {
 	}
 }
 
+// executor returns the transaction from ctx if one is active so every
+// method participates in a surrounding database.ExecuteInTransaction
+func (r *sessionRepository) executor(ctx context.Context) database.Executor {
+	return database.GetExecutor(ctx, r.db)
+}
+
 // Create inserts a new session
 func (r *sessionRepository) Create(ctx context.Context, session *domain.Session) error {
 	query := `
-		INSERT INTO sessions (id, user_id, expires_at, created_at, ip_address, user_agent, is_active)
-		VALUES (:id, :user_id, :expires_at, :created_at, :ip_address, :user_agent, :is_active)`
+		INSERT INTO sessions (id, user_id, expires_at, created_at, last_activity_at, ip_address, user_agent, is_active)
+		VALUES (:id, :user_id, :expires_at, :created_at, :last_activity_at, :ip_address, :user_agent, :is_active)`
 
 	return r.BaseRepository.Create(ctx, session, query)
 }
@@ -76,7 +86,7 @@ func (r *sessionRepository) Create(ctx context.Context, session *domain.Session)
 // GetByID retrieves a session by its ID
 func (r *sessionRepository) GetByID(ctx context.Context, id string) (*domain.Session, error) {
 	query := `
-		SELECT id, user_id, expires_at, created_at, ip_address, user_agent, is_active
+		SELECT id, user_id, expires_at, created_at, last_activity_at, ip_address, user_agent, is_active
 		FROM sessions 
 		WHERE id = $1`
 
@@ -86,19 +96,13 @@ func (r *sessionRepository) GetByID(ctx context.Context, id string) (*domain.Ses
 // GetByUserID retrieves all active sessions for a user
 func (r *sessionRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.Session, error) {
 	query := `
-		SELECT id, user_id, expires_at, created_at, ip_address, user_agent, is_active
+		SELECT id, user_id, expires_at, created_at, last_activity_at, ip_address, user_agent, is_active
 		FROM sessions 
 		WHERE user_id = $1 AND is_active = true AND expires_at > NOW()
 		ORDER BY created_at DESC`
 
 	var sessions []*domain.Session
-	tx := database.GetTxFromContext(ctx)
-	if tx != nil {
-		err := tx.SelectContext(ctx, &sessions, query, userID)
-		return sessions, err
-	}
-
-	err := r.db.SelectContext(ctx, &sessions, query, userID)
+	err := r.executor(ctx).SelectContext(ctx, &sessions, query, userID)
 	return sessions, err
 }
 
@@ -106,7 +110,7 @@ func (r *sessionRepository) GetByUserID(ctx context.Context, userID string) ([]*
 func (r *sessionRepository) Update(ctx context.Context, session *domain.Session) error {
 	query := `
 		UPDATE sessions 
-		SET expires_at = :expires_at, ip_address = :ip_address, user_agent = :user_agent, is_active = :is_active
+		SET expires_at = :expires_at, last_activity_at = :last_activity_at, ip_address = :ip_address, user_agent = :user_agent, is_active = :is_active
 		WHERE id = :id`
 
 	return r.BaseRepository.Update(ctx, session, query)
@@ -122,13 +126,7 @@ func (r *sessionRepository) Delete(ctx context.Context, id string) error {
 func (r *sessionRepository) DeleteByUserID(ctx context.Context, userID string) error {
 	query := `DELETE FROM sessions WHERE user_id = $1`
 
-	tx := database.GetTxFromContext(ctx)
-	if tx != nil {
-		_, err := tx.ExecContext(ctx, query, userID)
-		return err
-	}
-
-	_, err := r.db.ExecContext(ctx, query, userID)
+	_, err := r.executor(ctx).ExecContext(ctx, query, userID)
 	return err
 }
 
@@ -136,16 +134,7 @@ func (r *sessionRepository) DeleteByUserID(ctx context.Context, userID string) e
 func (r *sessionRepository) CleanupExpired(ctx context.Context) (int64, error) {
 	query := `DELETE FROM sessions WHERE expires_at < NOW() OR is_active = false`
 
-	tx := database.GetTxFromContext(ctx)
-	if tx != nil {
-		result, err := tx.ExecContext(ctx, query)
-		if err != nil {
-			return 0, err
-		}
-		return result.RowsAffected()
-	}
-
-	result, err := r.db.ExecContext(ctx, query)
+	result, err := r.executor(ctx).ExecContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
@@ -155,24 +144,12 @@ func (r *sessionRepository) CleanupExpired(ctx context.Context) (int64, error) {
 // ValidateAndGet retrieves a session and validates it's active and not expired
 func (r *sessionRepository) ValidateAndGet(ctx context.Context, sessionID string) (*domain.Session, error) {
 	query := `
-		SELECT id, user_id, expires_at, created_at, ip_address, user_agent, is_active
+		SELECT id, user_id, expires_at, created_at, last_activity_at, ip_address, user_agent, is_active
 		FROM sessions 
 		WHERE id = $1 AND is_active = true AND expires_at > NOW()`
 
 	var session domain.Session
-	tx := database.GetTxFromContext(ctx)
-	if tx != nil {
-		err := tx.GetContext(ctx, &session, query, sessionID)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				return nil, database.ErrNotFound
-			}
-			return nil, err
-		}
-		return &session, nil
-	}
-
-	err := r.db.GetContext(ctx, &session, query, sessionID)
+	err := r.executor(ctx).GetContext(ctx, &session, query, sessionID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, database.ErrNotFound
@@ -182,6 +159,28 @@ func (r *sessionRepository) ValidateAndGet(ctx context.Context, sessionID string
 	return &session, nil
 }
 
+// UpdateActivity stores a session's last activity and expiry. Only active
+// sessions are updated so a concurrent revoke isn't undone
+func (r *sessionRepository) UpdateActivity(ctx context.Context, session *domain.Session) error {
+	query := `
+		UPDATE sessions 
+		SET last_activity_at = $2, expires_at = $3
+		WHERE id = $1 AND is_active = true`
+
+	result, err := r.executor(ctx).ExecContext(ctx, query, session.ID, session.LastActivityAt, session.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
 // ExtendSession extends the expiration time of a session
 func (r *sessionRepository) ExtendSession(ctx context.Context, sessionID string, duration time.Duration) error {
 	query := `
@@ -191,23 +190,7 @@ func (r *sessionRepository) ExtendSession(ctx context.Context, sessionID string,
 
 	formattedQuery := fmt.Sprintf(query, int(duration.Seconds()))
 
-	tx := database.GetTxFromContext(ctx)
-	if tx != nil {
-		result, err := tx.ExecContext(ctx, formattedQuery, sessionID)
-		if err != nil {
-			return err
-		}
-		rowsAffected, err := result.RowsAffected()
-		if err != nil {
-			return err
-		}
-		if rowsAffected == 0 {
-			return database.ErrNotFound
-		}
-		return nil
-	}
-
-	result, err := r.db.ExecContext(ctx, formattedQuery, sessionID)
+	result, err := r.executor(ctx).ExecContext(ctx, formattedQuery, sessionID)
 	if err != nil {
 		return err
 	}
@@ -225,23 +208,7 @@ func (r *sessionRepository) ExtendSession(ctx context.Context, sessionID string,
 func (r *sessionRepository) InvalidateSession(ctx context.Context, sessionID string) error {
 	query := `UPDATE sessions SET is_active = false WHERE id = $1`
 
-	tx := database.GetTxFromContext(ctx)
-	if tx != nil {
-		result, err := tx.ExecContext(ctx, query, sessionID)
-		if err != nil {
-			return err
-		}
-		rowsAffected, err := result.RowsAffected()
-		if err != nil {
-			return err
-		}
-		if rowsAffected == 0 {
-			return database.ErrNotFound
-		}
-		return nil
-	}
-
-	result, err := r.db.ExecContext(ctx, query, sessionID)
+	result, err := r.executor(ctx).ExecContext(ctx, query, sessionID)
 	if err != nil {
 		return err
 	}
@@ -263,32 +230,20 @@ func (r *sessionRepository) CountActiveSessions(ctx context.Context, userID stri
 		WHERE user_id = $1 AND is_active = true AND expires_at > NOW()`
 
 	var count int64
-	tx := database.GetTxFromContext(ctx)
-	if tx != nil {
-		err := tx.GetContext(ctx, &count, query, userID)
-		return count, err
-	}
-
-	err := r.db.GetContext(ctx, &count, query, userID)
+	err := r.executor(ctx).GetContext(ctx, &count, query, userID)
 	return count, err
 }
 
 // GetOldestSessionsByUser retrieves the oldest sessions for a user (for cleanup)
 func (r *sessionRepository) GetOldestSessionsByUser(ctx context.Context, userID string, limit int) ([]*domain.Session, error) {
 	query := `
-		SELECT id, user_id, expires_at, created_at, ip_address, user_agent, is_active
+		SELECT id, user_id, expires_at, created_at, last_activity_at, ip_address, user_agent, is_active
 		FROM sessions 
 		WHERE user_id = $1 AND is_active = true
 		ORDER BY created_at ASC
 		LIMIT $2`
 
 	var sessions []*domain.Session
-	tx := database.GetTxFromContext(ctx)
-	if tx != nil {
-		err := tx.SelectContext(ctx, &sessions, query, userID, limit)
-		return sessions, err
-	}
-
-	err := r.db.SelectContext(ctx, &sessions, query, userID, limit)
+	err := r.executor(ctx).SelectContext(ctx, &sessions, query, userID, limit)
 	return sessions, err
 }
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"go-templ-template/internal/modules/auth/domain"
+	userdomain "go-templ-template/internal/modules/user/domain"
+	userinfra "go-templ-template/internal/modules/user/infrastructure"
 	"go-templ-template/internal/shared/database"
 
 	"github.com/stretchr/testify/assert"
@@ -64,6 +66,7 @@ func (suite *SessionRepositoryTestSuite) createTestTables() {
 			user_id VARCHAR(255) NOT NULL,
 			expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
 			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+			last_activity_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
 			ip_address INET,
 			user_agent TEXT,
 			is_active BOOLEAN NOT NULL DEFAULT true
@@ -208,6 +211,31 @@ func (suite *SessionRepositoryTestSuite) TestUpdate() {
 	assert.False(suite.T(), retrieved.IsActive)
 }
 
+func (suite *SessionRepositoryTestSuite) TestUpdateActivity() {
+	userID := suite.createTestUser()
+	session := suite.createTestSession(userID)
+
+	err := suite.repo.Create(suite.ctx, session)
+	require.NoError(suite.T(), err)
+
+	session.LastActivityAt = session.CreatedAt.Add(time.Hour)
+	session.ExpiresAt = session.LastActivityAt.Add(24 * time.Hour)
+	err = suite.repo.UpdateActivity(suite.ctx, session)
+	assert.NoError(suite.T(), err)
+
+	retrieved, err := suite.repo.GetByID(suite.ctx, session.ID)
+	require.NoError(suite.T(), err)
+	assert.WithinDuration(suite.T(), session.LastActivityAt, retrieved.LastActivityAt, time.Millisecond)
+	assert.WithinDuration(suite.T(), session.ExpiresAt, retrieved.ExpiresAt, time.Millisecond)
+
+	// A session revoked in the meantime isn't updated
+	err = suite.repo.InvalidateSession(suite.ctx, session.ID)
+	require.NoError(suite.T(), err)
+
+	err = suite.repo.UpdateActivity(suite.ctx, session)
+	assert.True(suite.T(), database.IsNotFoundError(err))
+}
+
 func (suite *SessionRepositoryTestSuite) TestDelete() {
 	userID := suite.createTestUser()
 	session := suite.createTestSession(userID)
@@ -445,6 +473,45 @@ func (suite *SessionRepositoryTestSuite) TestTransactionSupport() {
 	assert.Equal(suite.T(), session.ID, retrieved.ID)
 }
 
+func (suite *SessionRepositoryTestSuite) TestTransactionRollbackWithUser() {
+	userRepo := userinfra.NewUserRepository(suite.db)
+	user, err := userdomain.NewUser("tx-user-id", "tx@example.com", "Password123", "Tx", "User")
+	require.NoError(suite.T(), err)
+	session := suite.createTestSession(user.ID)
+
+	// Create the user and their first session atomically, then force a rollback
+	err = database.ExecuteInTransaction(suite.ctx, suite.db, func(ctx context.Context) error {
+		if err := userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		if err := suite.repo.Create(ctx, session); err != nil {
+			return err
+		}
+
+		// Both rows are visible inside the transaction
+		count, err := suite.repo.CountActiveSessions(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(suite.T(), int64(1), count)
+
+		return assert.AnError
+	})
+	assert.ErrorIs(suite.T(), err, assert.AnError)
+
+	// Neither row persists after rollback
+	exists, err := userRepo.Exists(suite.ctx, user.ID)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), exists)
+
+	_, err = suite.repo.GetByID(suite.ctx, session.ID)
+	assert.Equal(suite.T(), database.ErrNotFound, err)
+
+	count, err := suite.repo.CountActiveSessions(suite.ctx, user.ID)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(0), count)
+}
+
 func TestSessionRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(SessionRepositoryTestSuite))
 }
@@ -41,6 +41,11 @@ func (a *sessionRepositoryAdapter) Update(ctx context.Context, session *domain.S
 	return a.repo.Update(ctx, session)
 }
 
+// UpdateActivity stores a session's last activity and expiry
+func (a *sessionRepositoryAdapter) UpdateActivity(ctx context.Context, session *domain.Session) error {
+	return a.repo.UpdateActivity(ctx, session)
+}
+
 // Delete removes a session by its ID
 func (a *sessionRepositoryAdapter) Delete(ctx context.Context, sessionID string) error {
 	return a.repo.Delete(ctx, sessionID)
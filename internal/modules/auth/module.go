@@ -5,32 +5,40 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
 	"go-templ-template/internal/config"
 	"go-templ-template/internal/modules/auth/application"
+	"go-templ-template/internal/modules/auth/domain"
 	"go-templ-template/internal/modules/auth/handlers"
 	"go-templ-template/internal/modules/auth/infrastructure"
 	"go-templ-template/internal/modules/user"
 	userApplication "go-templ-template/internal/modules/user/application"
+	userDomain "go-templ-template/internal/modules/user/domain"
 	"go-templ-template/internal/shared"
 	"go-templ-template/internal/shared/audit"
 	"go-templ-template/internal/shared/database"
 	"go-templ-template/internal/shared/events"
+	"go-templ-template/internal/shared/middleware"
 
 	"github.com/labstack/echo/v4"
 )
 
 // AuthModule implements the Module interface for authentication functionality
 type AuthModule struct {
-	name        string
-	authService application.AuthService
-	authHandler *handlers.AuthHandler
-	eventBus    events.EventBus
-	db          *database.DB
-	config      *config.Config
-	userService userApplication.UserService
-	auditLogger audit.AuditLogger
-	logger      *slog.Logger
+	name              string
+	authService       application.AuthService
+	authHandler       *handlers.AuthHandler
+	eventBus          events.EventBus
+	db                *database.DB
+	config            *config.Config
+	userService       userApplication.UserService
+	activationService userApplication.ActivationService
+	resetService      application.PasswordResetService
+	tokenService      application.TokenService
+	apiKeyService     application.APIKeyService
+	auditLogger       audit.AuditLogger
+	logger            *slog.Logger
 }
 
 // NewAuthModule creates a new auth module instance
@@ -45,6 +53,11 @@ func (m *AuthModule) Name() string {
 	return m.name
 }
 
+// DependsOn returns the modules initialized before the auth module
+func (m *AuthModule) DependsOn() []string {
+	return []string{"user"}
+}
+
 // Initialize sets up the module with its dependencies
 func (m *AuthModule) Initialize(ctx context.Context, container *shared.ModuleContainer) error {
 	// Type assert dependencies
@@ -83,6 +96,7 @@ func (m *AuthModule) Initialize(ctx context.Context, container *shared.ModuleCon
 	if m.userService == nil {
 		return shared.NewModuleError(m.name, "user service not available from user module")
 	}
+	m.activationService = userMod.GetActivationService()
 
 	// Initialize audit logger
 	m.auditLogger = audit.NewAuditLogger(db)
@@ -97,6 +111,43 @@ func (m *AuthModule) Initialize(ctx context.Context, container *shared.ModuleCon
 	// Initialize session config
 	sessionConfig := application.DefaultSessionConfig()
 
+	// Initialize session cookie attributes, used wherever the cookie is set
+	// or cleared
+	cookieConfig := middleware.DefaultSessionCookieConfig()
+	if config.Auth.SessionCookiePath != "" {
+		cookieConfig.Path = config.Auth.SessionCookiePath
+	}
+	cookieConfig.Domain = config.Auth.SessionCookieDomain
+	if config.Auth.SessionCookieSameSite != "" {
+		sameSite, err := middleware.ParseSameSite(config.Auth.SessionCookieSameSite)
+		if err != nil {
+			return shared.NewModuleErrorWithCause(m.name, "invalid session cookie SameSite", err)
+		}
+		cookieConfig.SameSite = sameSite
+	}
+	cookieConfig.Secure = config.Auth.SessionCookieSecure
+	middleware.SetSessionCookieConfig(cookieConfig)
+
+	// Initialize password policy
+	passwordPolicy := domain.DefaultPasswordPolicy()
+	if config.Auth.PasswordMinLength > 0 {
+		passwordPolicy.MinLength = config.Auth.PasswordMinLength
+	}
+	passwordPolicy.RequireSpecial = config.Auth.PasswordRequireSpecial
+
+	// Count login failures and reset requests per address the same way the
+	// user module stores addresses
+	emailNormalizer := userDomain.NewEmailNormalizer(config.Auth.NormalizeGmailAliases)
+
+	// Initialize account lockout, bounded by the login rate limit so the
+	// threshold can be reached before logins are refused
+	accountLockout := application.AccountLockoutConfig{
+		MaxFailedAttempts: config.Auth.AccountLockMaxFailedAttempts,
+	}
+	if accountLockout.MaxFailedAttempts > rateLimiterConfig.MaxAttempts {
+		accountLockout.MaxFailedAttempts = rateLimiterConfig.MaxAttempts
+	}
+
 	// Initialize service
 	m.authService = application.NewAuthService(
 		sessionRepo,
@@ -105,10 +156,70 @@ func (m *AuthModule) Initialize(ctx context.Context, container *shared.ModuleCon
 		db,
 		rateLimiter,
 		sessionConfig,
+		passwordPolicy,
+		accountLockout,
 	)
 
-	// Initialize handlers
-	m.authHandler = handlers.NewAuthHandler(m.authService)
+	// Initialize password reset service
+	m.resetService = application.NewPasswordResetService(
+		infrastructure.NewPasswordResetTokenRepository(db),
+		sessionRepo,
+		m.userService,
+		m.eventBus,
+		db,
+		rateLimiter,
+		passwordPolicy,
+		emailNormalizer,
+		time.Duration(config.Auth.PasswordResetTokenMinutes)*time.Minute,
+	)
+
+	// Initialize handlers with brute-force protection for logins
+	lockoutWindow := time.Duration(config.Auth.LoginLockoutMinutes) * time.Minute
+	loginLimiter := application.NewInMemoryRateLimiter(application.RateLimiterConfig{
+		MaxAttempts: config.Auth.LoginMaxFailedAttempts,
+		Window:      lockoutWindow,
+		LockoutTime: lockoutWindow,
+	})
+	m.authHandler = handlers.NewAuthHandler(m.authService).
+		WithLoginProtection(loginLimiter, handlers.LoginProtectionConfig{
+			MaxFailedAttempts: config.Auth.LoginMaxFailedAttempts,
+			LockoutWindow:     lockoutWindow,
+			EmailNormalizer:   emailNormalizer,
+		}).
+		WithEmailVerification(m.activationService).
+		WithPasswordReset(m.resetService)
+
+	// Replay the responses to retried writes carrying an Idempotency-Key
+	// header on authenticated routes, so keys are scoped to the user
+	idempotencyStore := middleware.NewMemoryIdempotencyStore()
+	m.authHandler.WithIdempotency(idempotencyStore)
+
+	// Initialize API keys for programmatic access
+	m.apiKeyService = application.NewAPIKeyService(infrastructure.NewAPIKeyRepository(db), m.userService)
+	m.authHandler.WithAPIKeys(m.apiKeyService)
+
+	// Let the user module authenticate the routes acting on the signed-in
+	// user, with a session or an API key
+	requireAuth := handlers.GetAuthMiddleware(m.authService).RequireAuth
+	requireAuth = middleware.NewAPIKeyMiddleware(m.apiKeyService).RequireAuthOr(requireAuth)
+
+	// Issue tokens to clients that don't use session cookies, when enabled
+	if config.Auth.JWTSecret != "" {
+		tokenConfig := application.DefaultTokenConfig([]byte(config.Auth.JWTSecret))
+		tokenConfig.AccessTokenTTL = time.Duration(config.Auth.JWTAccessTokenMinutes) * time.Minute
+		tokenConfig.RefreshTokenTTL = time.Duration(config.Auth.JWTRefreshTokenHours) * time.Hour
+		m.tokenService = application.NewTokenService(tokenConfig, m.userService)
+
+		m.authHandler.WithTokenAuth(m.tokenService)
+		requireAuth = middleware.NewJWTAuthMiddleware(m.tokenService).RequireAuthOr(requireAuth)
+	}
+	userMod.SetAuthMiddleware(middleware.IdempotentAfterAuth(requireAuth, idempotencyStore, 0))
+
+	// Include the user's sessions in their data exports
+	userMod.AddDataExportSource(application.NewSessionsExportSource(sessionRepo))
+
+	// Revoke the user's sessions when they delete their account
+	userMod.SetSessionRevoker(application.NewSessionRevoker(sessionRepo))
 
 	return nil
 }
@@ -116,7 +227,7 @@ func (m *AuthModule) Initialize(ctx context.Context, container *shared.ModuleCon
 // RegisterRoutes registers the module's HTTP routes with the router
 func (m *AuthModule) RegisterRoutes(router *echo.Group) {
 	// Use the existing handler function that works with groups
-	handlers.RegisterAuthRoutesOnGroup(router, m.authService)
+	handlers.RegisterAuthHandlerOnGroup(router, m.authHandler, m.authService)
 }
 
 // RegisterEventHandlers registers the module's event handlers with the event bus
@@ -206,6 +317,17 @@ func (m *AuthModule) GetAuthService() application.AuthService {
 	return m.authService
 }
 
+// GetAPIKeyService returns the API key service for inter-module communication
+func (m *AuthModule) GetAPIKeyService() application.APIKeyService {
+	return m.apiKeyService
+}
+
+// GetTokenService returns the token service, or nil when bearer token
+// authentication is disabled
+func (m *AuthModule) GetTokenService() application.TokenService {
+	return m.tokenService
+}
+
 // GetAuthHandler returns the auth handler for testing purposes
 func (m *AuthModule) GetAuthHandler() *handlers.AuthHandler {
 	return m.authHandler
@@ -89,11 +89,31 @@ func (m *MockUserService) ChangeUserPassword(ctx context.Context, cmd *userAppli
 	return args.Get(0).(*userDomain.User), args.Error(1)
 }
 
+func (m *MockUserService) ResetUserPassword(ctx context.Context, cmd *userApplication.ResetUserPasswordCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
 func (m *MockUserService) ChangeUserStatus(ctx context.Context, cmd *userApplication.ChangeUserStatusCommand) (*userDomain.User, error) {
 	args := m.Called(ctx, cmd)
 	return args.Get(0).(*userDomain.User), args.Error(1)
 }
 
+func (m *MockUserService) LockUser(ctx context.Context, cmd *userApplication.LockUserCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserService) UnlockUser(ctx context.Context, cmd *userApplication.UnlockUserCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+func (m *MockUserService) RecordLogin(ctx context.Context, cmd *userApplication.RecordLoginCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
 func (m *MockUserService) DeleteUser(ctx context.Context, cmd *userApplication.DeleteUserCommand) error {
 	args := m.Called(ctx, cmd)
 	return args.Error(0)
@@ -134,6 +154,11 @@ func (m *MockAuthService) RefreshSession(ctx context.Context, cmd *application.R
 	return args.Get(0).(*domain.Session), args.Error(1)
 }
 
+func (m *MockAuthService) TouchSession(ctx context.Context, session *domain.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
 func (m *MockAuthService) ChangePassword(ctx context.Context, cmd *application.ChangePasswordCommand) error {
 	args := m.Called(ctx, cmd)
 	return args.Error(0)
@@ -149,6 +174,16 @@ func (m *MockAuthService) GetUserSessions(ctx context.Context, userID string) ([
 	return args.Get(0).([]*domain.Session), args.Error(1)
 }
 
+func (m *MockAuthService) RevokeSession(ctx context.Context, cmd *application.RevokeSessionCommand) error {
+	args := m.Called(ctx, cmd)
+	return args.Error(0)
+}
+
+func (m *MockAuthService) RevokeOtherSessions(ctx context.Context, cmd *application.RevokeOtherSessionsCommand) (int, error) {
+	args := m.Called(ctx, cmd)
+	return args.Int(0), args.Error(1)
+}
+
 func TestAuthModule_Name(t *testing.T) {
 	// Arrange
 	module := NewAuthModule()
@@ -160,6 +195,15 @@ func TestAuthModule_Name(t *testing.T) {
 	assert.Equal(t, "auth", name)
 }
 
+func TestAuthModule_DependsOnUser(t *testing.T) {
+	var module shared.Module = NewAuthModule()
+
+	declared, ok := module.(shared.ModuleDependencies)
+
+	assert.True(t, ok)
+	assert.Equal(t, []string{"user"}, declared.DependsOn())
+}
+
 func TestAuthModule_Initialize_Success(t *testing.T) {
 	// Arrange
 	module := NewAuthModule()
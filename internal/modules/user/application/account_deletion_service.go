@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/modules/user/infrastructure"
+	"go-templ-template/internal/shared/audit"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/events"
+
+	"github.com/google/uuid"
+)
+
+// DeleteAccountCommand represents a user's request to delete their own account
+type DeleteAccountCommand struct {
+	UserID    string
+	Password  string
+	Reason    string
+	IPAddress string
+	UserAgent string
+}
+
+// Validate performs validation on the DeleteAccountCommand
+func (c *DeleteAccountCommand) Validate() error {
+	if c.UserID == "" {
+		return NewValidationError("id", "user ID is required")
+	}
+	if c.Password == "" {
+		return NewValidationError("password", "password is required")
+	}
+	return nil
+}
+
+// SessionRevoker ends every session of a user. It is implemented by the auth
+// module, which owns sessions.
+type SessionRevoker interface {
+	// RevokeAllSessions revokes a user's sessions and returns how many were revoked
+	RevokeAllSessions(ctx context.Context, userID string) (int, error)
+}
+
+// AccountDeletionService defines the interface for users deleting their own
+// account
+type AccountDeletionService interface {
+	// DeleteAccount soft-deletes a user after checking their password and
+	// revokes all of their sessions
+	DeleteAccount(ctx context.Context, cmd *DeleteAccountCommand) error
+}
+
+// accountDeletionServiceImpl implements the AccountDeletionService interface
+type accountDeletionServiceImpl struct {
+	userRepo       infrastructure.UserRepository
+	sessionRevoker SessionRevoker
+	auditLogger    audit.AuditLogger
+	eventBus       events.EventBus
+	db             *database.DB
+	passwordHasher domain.PasswordHasher
+}
+
+// NewAccountDeletionService creates a new account deletion service instance
+func NewAccountDeletionService(
+	userRepo infrastructure.UserRepository,
+	sessionRevoker SessionRevoker,
+	auditLogger audit.AuditLogger,
+	eventBus events.EventBus,
+	db *database.DB,
+	passwordHasher domain.PasswordHasher,
+) AccountDeletionService {
+	return &accountDeletionServiceImpl{
+		userRepo:       userRepo,
+		sessionRevoker: sessionRevoker,
+		auditLogger:    auditLogger,
+		eventBus:       eventBus,
+		db:             db,
+		passwordHasher: passwordHasher,
+	}
+}
+
+// DeleteAccount soft-deletes the user and revokes their sessions in one
+// transaction, then records an audit event and publishes user.deleted
+func (s *accountDeletionServiceImpl) DeleteAccount(ctx context.Context, cmd *DeleteAccountCommand) error {
+	if err := cmd.Validate(); err != nil {
+		return err
+	}
+
+	var user *domain.User
+	var revoked int
+	err := database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
+		var err error
+		user, err = s.userRepo.GetByID(txCtx, cmd.UserID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return NewUserNotFoundError(cmd.UserID)
+			}
+			return NewInternalError(fmt.Sprintf("failed to get user: %v", err))
+		}
+
+		if !user.CheckPasswordWith(s.passwordHasher, cmd.Password) {
+			return NewInvalidPasswordError()
+		}
+
+		if err := user.MarkDeleted(); err != nil {
+			return NewBusinessRuleError(err.Error())
+		}
+
+		if err := s.userRepo.Update(txCtx, user); err != nil {
+			if database.IsOptimisticLockError(err) {
+				return NewOptimisticLockError(cmd.UserID)
+			}
+			return NewInternalError(fmt.Sprintf("failed to delete user: %v", err))
+		}
+
+		revoked, err = s.sessionRevoker.RevokeAllSessions(txCtx, user.ID)
+		if err != nil {
+			return NewInternalError(fmt.Sprintf("failed to revoke sessions: %v", err))
+		}
+
+		event := domain.NewUserDeletedEvent(user, user.ID, cmd.Reason)
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish event: %v", err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The audit log is written outside the transaction, so it is only
+	// recorded once the deletion has committed
+	auditEvent := &audit.AuditEvent{
+		EventID:       uuid.New().String(),
+		EventType:     "user.account_deleted",
+		AggregateID:   user.ID,
+		AggregateType: "user",
+		UserID:        user.ID,
+		Action:        "account_deleted",
+		Resource:      "user",
+		ResourceID:    user.ID,
+		Details: map[string]interface{}{
+			"reason":           cmd.Reason,
+			"revoked_sessions": revoked,
+			"ip_address":       cmd.IPAddress,
+			"user_agent":       cmd.UserAgent,
+		},
+		OccurredAt: time.Now().UTC(),
+	}
+	if err := s.auditLogger.LogEvent(ctx, auditEvent); err != nil {
+		log.Printf("Failed to record account deletion audit event for user %s: %v", user.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSessionRevoker counts the sessions of each user and revokes them
+type fakeSessionRevoker struct {
+	sessions map[string]int
+}
+
+func (r *fakeSessionRevoker) RevokeAllSessions(ctx context.Context, userID string) (int, error) {
+	revoked := r.sessions[userID]
+	delete(r.sessions, userID)
+	return revoked, nil
+}
+
+func TestDeleteAccountCommand_Validate(t *testing.T) {
+	assert.NoError(t, (&DeleteAccountCommand{UserID: "user-123", Password: "Password123"}).Validate())
+
+	err := (&DeleteAccountCommand{Password: "Password123"}).Validate()
+	assert.Equal(t, ErrCodeValidation, err.(*ApplicationError).Code)
+
+	err = (&DeleteAccountCommand{UserID: "user-123"}).Validate()
+	assert.Equal(t, ErrCodeValidation, err.(*ApplicationError).Code)
+	assert.Equal(t, "password", err.(*ApplicationError).Field)
+}
+
+// newAccountDeletionTestService builds the account deletion service over
+// fakes and a stub database for the transaction around the deletion
+func newAccountDeletionTestService(t *testing.T) (AccountDeletionService, *MockUserRepositorySimple, *MockEventBusSimple, *fakeSessionRevoker, *stubAuditLogger) {
+	t.Helper()
+
+	repo := &MockUserRepositorySimple{}
+	eventBus := &MockEventBusSimple{}
+	revoker := &fakeSessionRevoker{sessions: map[string]int{"user-123": 2}}
+	auditLogger := &stubAuditLogger{}
+	service := NewAccountDeletionService(repo, revoker, auditLogger, eventBus, database.NewStubDB().DB, domain.DefaultPasswordHasher())
+	return service, repo, eventBus, revoker, auditLogger
+}
+
+func TestAccountDeletionService_DeleteAccount(t *testing.T) {
+	service, repo, eventBus, revoker, auditLogger := newAccountDeletionTestService(t)
+	published := capturePublished(t, eventBus)
+
+	user := newEventTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool { return u.IsDeleted() })).Return(nil)
+
+	err := service.DeleteAccount(context.Background(), &DeleteAccountCommand{
+		UserID:   user.ID,
+		Password: "Password123",
+		Reason:   "no longer needed",
+	})
+	require.NoError(t, err)
+
+	// The user is soft-deleted and their sessions are gone
+	assert.True(t, user.IsDeleted())
+	assert.Empty(t, revoker.sessions)
+
+	// user.deleted is published after commit
+	require.Len(t, *published, 1)
+	assert.Equal(t, "user.deleted", (*published)[0].EventType())
+	assert.Equal(t, user.ID, (*published)[0].AggregateID())
+
+	// The deletion is audited
+	require.Len(t, auditLogger.logged, 1)
+	assert.Equal(t, "user.account_deleted", auditLogger.logged[0].EventType)
+	assert.Equal(t, 2, auditLogger.logged[0].Details["revoked_sessions"])
+	repo.AssertExpectations(t)
+}
+
+func TestAccountDeletionService_DeleteAccount_WrongPassword(t *testing.T) {
+	service, repo, eventBus, revoker, auditLogger := newAccountDeletionTestService(t)
+
+	user := newEventTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	err := service.DeleteAccount(context.Background(), &DeleteAccountCommand{
+		UserID:   user.ID,
+		Password: "WrongPassword1",
+	})
+	require.Error(t, err)
+	assert.Equal(t, ErrCodeInvalidPassword, err.(*ApplicationError).Code)
+
+	// Nothing is deleted, revoked, published or audited
+	assert.False(t, user.IsDeleted())
+	assert.Equal(t, 2, revoker.sessions["user-123"])
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	assert.Empty(t, auditLogger.logged)
+}
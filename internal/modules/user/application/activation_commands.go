@@ -5,16 +5,18 @@ import (
 	"strings"
 )
 
-// RequestActivationCommand represents a command to request user activation
+// RequestActivationCommand represents a command to request user activation.
+// The user is identified by UserID or, if that is empty, by Email.
 type RequestActivationCommand struct {
 	UserID      string `json:"user_id"`
+	Email       string `json:"email,omitempty"`
 	RequestedBy string `json:"requested_by,omitempty"`
 }
 
 // Validate validates the request activation command
 func (c *RequestActivationCommand) Validate() error {
-	if strings.TrimSpace(c.UserID) == "" {
-		return NewValidationError("user_id", "user ID is required")
+	if strings.TrimSpace(c.UserID) == "" && strings.TrimSpace(c.Email) == "" {
+		return NewValidationError("user_id", "user ID or email is required")
 	}
 
 	return nil
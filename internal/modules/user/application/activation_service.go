@@ -71,21 +71,17 @@ func (s *activationServiceImpl) RequestActivation(ctx context.Context, cmd *Requ
 	// Execute in transaction
 	err := database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
 		// Get user
-		user, err := s.userRepo.GetByID(txCtx, cmd.UserID)
+		user, err := s.getRequestingUser(txCtx, cmd)
 		if err != nil {
-			if database.IsNotFoundError(err) {
-				return NewUserNotFoundError(cmd.UserID)
-			}
-			return NewInternalError(fmt.Sprintf("failed to get user: %v", err))
+			return err
 		}
 
-		// Check if user is already active
-		if user.Status == domain.UserStatusActive {
-			return NewValidationError("activation", "user is already active")
+		if err := checkAwaitingActivation(user); err != nil {
+			return err
 		}
 
 		// Delete any existing tokens for this user
-		if err := s.tokenRepo.DeleteByUserID(txCtx, cmd.UserID); err != nil {
+		if err := s.tokenRepo.DeleteByUserID(txCtx, user.ID); err != nil {
 			return NewInternalError(fmt.Sprintf("failed to delete existing tokens: %v", err))
 		}
 
@@ -98,7 +94,7 @@ func (s *activationServiceImpl) RequestActivation(ctx context.Context, cmd *Requ
 		// Create activation token
 		token = &domain.ActivationToken{
 			ID:        generateTokenID(),
-			UserID:    cmd.UserID,
+			UserID:    user.ID,
 			Token:     tokenValue,
 			ExpiresAt: time.Now().UTC().Add(s.tokenDuration),
 			CreatedAt: time.Now().UTC(),
@@ -124,6 +120,29 @@ func (s *activationServiceImpl) RequestActivation(ctx context.Context, cmd *Requ
 	return token, nil
 }
 
+// getRequestingUser loads the user an activation request is for
+func (s *activationServiceImpl) getRequestingUser(ctx context.Context, cmd *RequestActivationCommand) (*domain.User, error) {
+	var (
+		user *domain.User
+		err  error
+	)
+	identifier := cmd.UserID
+	if identifier != "" {
+		user, err = s.userRepo.GetByID(ctx, identifier)
+	} else {
+		identifier = cmd.Email
+		user, err = s.userRepo.GetByEmail(ctx, identifier)
+	}
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, NewUserNotFoundError(identifier)
+		}
+		return nil, NewInternalError(fmt.Sprintf("failed to get user: %v", err))
+	}
+
+	return user, nil
+}
+
 // ActivateUser activates a user using an activation token
 func (s *activationServiceImpl) ActivateUser(ctx context.Context, cmd *ActivateUserCommand) (*domain.User, error) {
 	if err := cmd.Validate(); err != nil {
@@ -166,9 +185,8 @@ func (s *activationServiceImpl) ActivateUser(ctx context.Context, cmd *ActivateU
 			return NewInternalError(fmt.Sprintf("failed to get user: %v", err))
 		}
 
-		// Check if user is already active
-		if user.Status == domain.UserStatusActive {
-			return NewValidationError("activation", "user is already active")
+		if err := checkAwaitingActivation(user); err != nil {
+			return err
 		}
 
 		// Activate user
@@ -181,10 +199,13 @@ func (s *activationServiceImpl) ActivateUser(ctx context.Context, cmd *ActivateU
 			return NewInternalError(fmt.Sprintf("failed to update user: %v", err))
 		}
 
-		// Mark token as used
+		// Mark token as used, which fails if a concurrent request used it first
 		now := time.Now().UTC()
 		token.UsedAt = &now
 		if err := s.tokenRepo.Update(txCtx, token); err != nil {
+			if database.IsNotFoundError(err) {
+				return NewValidationError("token", "activation token has already been used")
+			}
 			return NewInternalError(fmt.Sprintf("failed to update token: %v", err))
 		}
 
@@ -286,6 +307,19 @@ func (s *activationServiceImpl) GetActivationToken(ctx context.Context, token st
 	return activationToken, nil
 }
 
+// checkAwaitingActivation ensures user can be activated with a token. Only
+// inactive users qualify, so a token can't lift a suspension or lockout.
+func checkAwaitingActivation(user *domain.User) error {
+	switch user.Status {
+	case domain.UserStatusInactive:
+		return nil
+	case domain.UserStatusActive:
+		return NewValidationError("activation", "user is already active")
+	default:
+		return NewValidationError("activation", fmt.Sprintf("user with status %s cannot be activated", user.Status))
+	}
+}
+
 // generateActivationToken generates a secure random activation token
 func generateActivationToken() (string, error) {
 	bytes := make([]byte, 32) // 256 bits
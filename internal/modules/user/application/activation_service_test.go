@@ -0,0 +1,201 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryActivationTokenRepository is an in-memory ActivationTokenRepository
+// with the same single-use semantics as the SQL implementation
+type memoryActivationTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[string]*domain.ActivationToken
+}
+
+func newMemoryActivationTokenRepository() *memoryActivationTokenRepository {
+	return &memoryActivationTokenRepository{tokens: make(map[string]*domain.ActivationToken)}
+}
+
+func (r *memoryActivationTokenRepository) Create(ctx context.Context, token *domain.ActivationToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *token
+	r.tokens[token.Token] = &stored
+	return nil
+}
+
+func (r *memoryActivationTokenRepository) GetByToken(ctx context.Context, token string) (*domain.ActivationToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.tokens[token]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	found := *stored
+	return &found, nil
+}
+
+func (r *memoryActivationTokenRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.ActivationToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var tokens []*domain.ActivationToken
+	for _, stored := range r.tokens {
+		if stored.UserID == userID {
+			found := *stored
+			tokens = append(tokens, &found)
+		}
+	}
+	return tokens, nil
+}
+
+func (r *memoryActivationTokenRepository) Update(ctx context.Context, token *domain.ActivationToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.tokens[token.Token]
+	if !ok || stored.UsedAt != nil {
+		return database.ErrNotFound
+	}
+	stored.UsedAt = token.UsedAt
+	return nil
+}
+
+func (r *memoryActivationTokenRepository) Delete(ctx context.Context, tokenID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, stored := range r.tokens {
+		if stored.ID == tokenID {
+			delete(r.tokens, key)
+			return nil
+		}
+	}
+	return database.ErrNotFound
+}
+
+func (r *memoryActivationTokenRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, stored := range r.tokens {
+		if stored.UserID == userID {
+			delete(r.tokens, key)
+		}
+	}
+	return nil
+}
+
+func (r *memoryActivationTokenRepository) DeleteExpired(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, stored := range r.tokens {
+		if stored.IsExpired() {
+			delete(r.tokens, key)
+		}
+	}
+	return nil
+}
+
+// newActivationTestService builds the real activation service over an
+// in-memory token store. A database is still needed because the service
+// opens a transaction around each operation.
+func newActivationTestService(t *testing.T, tokenDuration time.Duration) (ActivationService, *MockUserRepositorySimple, *memoryActivationTokenRepository) {
+	t.Helper()
+
+	database.SkipIfNoDatabase(t)
+	tdb := database.NewTestDatabase(t)
+	t.Cleanup(tdb.Close)
+
+	repo := &MockUserRepositorySimple{}
+	tokenRepo := newMemoryActivationTokenRepository()
+	eventBus := &MockEventBusSimple{}
+	eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+
+	return NewActivationService(repo, tokenRepo, eventBus, tdb.DB, tokenDuration), repo, tokenRepo
+}
+
+func newInactiveTestUser(t *testing.T) *domain.User {
+	t.Helper()
+
+	user, err := domain.NewUser("user-123", "verify@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+	require.NoError(t, user.Deactivate())
+	return user
+}
+
+func TestActivationService_RequestActivation_IssuesToken(t *testing.T) {
+	service, repo, tokenRepo := newActivationTestService(t, time.Hour)
+
+	user := newInactiveTestUser(t)
+	repo.On("GetByEmail", mock.Anything, "verify@example.com").Return(user, nil)
+
+	token, err := service.RequestActivation(context.Background(), &RequestActivationCommand{Email: "verify@example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, user.ID, token.UserID)
+	assert.Len(t, token.Token, 64)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Minute)
+
+	stored, err := tokenRepo.GetByToken(context.Background(), token.Token)
+	require.NoError(t, err)
+	assert.True(t, stored.IsValid())
+}
+
+func TestActivationService_RequestActivation_RejectsLockedUser(t *testing.T) {
+	service, repo, _ := newActivationTestService(t, time.Hour)
+
+	user := newInactiveTestUser(t)
+	require.NoError(t, user.Lock())
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	_, err := service.RequestActivation(context.Background(), &RequestActivationCommand{UserID: user.ID})
+	require.Error(t, err)
+	assert.True(t, IsValidationError(err))
+}
+
+func TestActivationService_ActivateUser_SingleUse(t *testing.T) {
+	service, repo, _ := newActivationTestService(t, time.Hour)
+
+	user := newInactiveTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(nil)
+
+	token, err := service.RequestActivation(context.Background(), &RequestActivationCommand{UserID: user.ID})
+	require.NoError(t, err)
+
+	activated, err := service.ActivateUser(context.Background(), &ActivateUserCommand{Token: token.Token})
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserStatusActive, activated.Status)
+
+	// Re-using the token is rejected even if the user is deactivated again
+	require.NoError(t, user.Deactivate())
+	_, err = service.ActivateUser(context.Background(), &ActivateUserCommand{Token: token.Token})
+	require.Error(t, err)
+	assert.True(t, IsValidationError(err))
+	assert.Contains(t, err.Error(), "already been used")
+	assert.Equal(t, domain.UserStatusInactive, user.Status)
+}
+
+func TestActivationService_ActivateUser_ExpiredToken(t *testing.T) {
+	service, repo, tokenRepo := newActivationTestService(t, time.Hour)
+
+	user := newInactiveTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	token, err := service.RequestActivation(context.Background(), &RequestActivationCommand{UserID: user.ID})
+	require.NoError(t, err)
+	tokenRepo.tokens[token.Token].ExpiresAt = time.Now().UTC().Add(-time.Minute)
+
+	_, err = service.ActivateUser(context.Background(), &ActivateUserCommand{Token: token.Token})
+	require.Error(t, err)
+	assert.True(t, IsValidationError(err))
+	assert.Contains(t, err.Error(), "expired")
+	assert.Equal(t, domain.UserStatusInactive, user.Status)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,175 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/modules/user/infrastructure"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/events"
+	"go-templ-template/internal/shared/storage"
+)
+
+// AvatarContentTypes maps the image types accepted as avatars to the file
+// extension they are stored with
+var AvatarContentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// UploadAvatarCommand represents a command to replace a user's avatar image
+type UploadAvatarCommand struct {
+	UserID      string
+	ContentType string
+	Data        []byte
+}
+
+// Validate performs validation on the UploadAvatarCommand
+func (c *UploadAvatarCommand) Validate() error {
+	if c.UserID == "" {
+		return NewValidationError("id", "user ID is required")
+	}
+	if len(c.Data) == 0 {
+		return NewValidationError("avatar", "avatar image is required")
+	}
+	if _, ok := AvatarContentTypes[c.ContentType]; !ok {
+		return NewValidationError("avatar", "avatar must be a JPEG, PNG, GIF or WebP image")
+	}
+	return nil
+}
+
+// AvatarService defines the interface for managing user avatar images
+type AvatarService interface {
+	// UploadAvatar stores a new avatar image and sets it as the user's avatar
+	UploadAvatar(ctx context.Context, cmd *UploadAvatarCommand) (*domain.User, error)
+}
+
+// avatarServiceImpl implements the AvatarService interface
+type avatarServiceImpl struct {
+	userRepo  infrastructure.UserRepository
+	blobStore storage.BlobStore
+	eventBus  events.EventBus
+	db        *database.DB
+}
+
+// NewAvatarService creates a new avatar service instance
+func NewAvatarService(
+	userRepo infrastructure.UserRepository,
+	blobStore storage.BlobStore,
+	eventBus events.EventBus,
+	db *database.DB,
+) AvatarService {
+	return &avatarServiceImpl{
+		userRepo:  userRepo,
+		blobStore: blobStore,
+		eventBus:  eventBus,
+		db:        db,
+	}
+}
+
+// UploadAvatar stores the image under a new key, so cached copies of the old
+// avatar are never served for the new one, and removes the previous image
+// once the user is updated
+func (s *avatarServiceImpl) UploadAvatar(ctx context.Context, cmd *UploadAvatarCommand) (*domain.User, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	key, err := avatarKey(cmd.UserID, AvatarContentTypes[cmd.ContentType])
+	if err != nil {
+		return nil, NewInternalError(fmt.Sprintf("failed to generate avatar key: %v", err))
+	}
+
+	var user *domain.User
+	var previousURL string
+	err = database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
+		var err error
+		user, err = s.userRepo.GetByID(txCtx, cmd.UserID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return NewUserNotFoundError(cmd.UserID)
+			}
+			return NewInternalError(fmt.Sprintf("failed to get user: %v", err))
+		}
+
+		if err := s.blobStore.Put(txCtx, key, bytes.NewReader(cmd.Data), cmd.ContentType); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to store avatar: %v", err))
+		}
+
+		previousURL = user.AvatarURL
+		if err := user.SetAvatarURL(s.blobStore.URL(key)); err != nil {
+			return NewValidationError("avatar", err.Error())
+		}
+
+		if err := s.userRepo.Update(txCtx, user); err != nil {
+			if database.IsOptimisticLockError(err) {
+				return NewOptimisticLockError(cmd.UserID)
+			}
+			return NewInternalError(fmt.Sprintf("failed to update user: %v", err))
+		}
+
+		changes := map[string]interface{}{
+			"avatar_url": map[string]interface{}{
+				"old": previousURL,
+				"new": user.AvatarURL,
+			},
+		}
+		event := domain.NewUserUpdatedEvent(user, changes)
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish event: %v", err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		// The new image is unreferenced if the user was not updated
+		if deleteErr := s.blobStore.Delete(ctx, key); deleteErr != nil {
+			log.Printf("Failed to delete unused avatar %s: %v", key, deleteErr)
+		}
+		return nil, err
+	}
+
+	if previousKey := s.avatarKeyForURL(cmd.UserID, previousURL); previousKey != "" {
+		if err := s.blobStore.Delete(ctx, previousKey); err != nil {
+			log.Printf("Failed to delete previous avatar %s: %v", previousKey, err)
+		}
+	}
+
+	return user, nil
+}
+
+// avatarKeyForURL returns the key of the user's avatar served from
+// avatarURL, or "" if the URL does not point at an avatar in the store
+func (s *avatarServiceImpl) avatarKeyForURL(userID, avatarURL string) string {
+	index := strings.Index(avatarURL, avatarKeyPrefix(userID))
+	if index < 0 {
+		return ""
+	}
+	key := avatarURL[index:]
+	if s.blobStore.URL(key) != avatarURL {
+		return ""
+	}
+	return key
+}
+
+// avatarKeyPrefix returns the prefix of the keys a user's avatars are stored under
+func avatarKeyPrefix(userID string) string {
+	return "avatars/" + userID + "/"
+}
+
+// avatarKey returns a new, unguessable key for a user's avatar
+func avatarKey(userID, extension string) (string, error) {
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return avatarKeyPrefix(userID) + hex.EncodeToString(suffix) + extension, nil
+}
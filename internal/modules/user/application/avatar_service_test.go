@@ -0,0 +1,41 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvatarService_UploadAvatar_PublishesEvent(t *testing.T) {
+	repo := &MockUserRepositorySimple{}
+	eventBus := &MockEventBusSimple{}
+	published := capturePublished(t, eventBus)
+	store := storage.NewLocalStore(t.TempDir(), "/uploads")
+	service := NewAvatarService(repo, store, eventBus, database.NewStubDB().DB)
+
+	user := newEventTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(nil)
+
+	updated, err := service.UploadAvatar(context.Background(), &UploadAvatarCommand{
+		UserID:      user.ID,
+		ContentType: "image/png",
+		Data:        []byte("\x89PNG\r\n\x1a\n"),
+	})
+	require.NoError(t, err)
+
+	// user.updated is published after commit with the new avatar
+	require.Len(t, *published, 1)
+	event, ok := (*published)[0].(*domain.UserUpdatedEvent)
+	require.True(t, ok)
+	assert.Equal(t, user.ID, event.AggregateID())
+	assert.Contains(t, event.Changes, "avatar_url")
+	assert.NotEmpty(t, updated.AvatarURL)
+}
@@ -1,7 +1,11 @@
 package application
 
 import (
+	"slices"
+	"time"
+
 	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/modules/user/infrastructure"
 )
 
 // CreateUserCommand represents a command to create a new user
@@ -100,6 +104,24 @@ func (c *ChangeUserPasswordCommand) Validate() error {
 	return nil
 }
 
+// ResetUserPasswordCommand represents a command to set a user's password
+// without the old one, e.g. from a verified password reset request
+type ResetUserPasswordCommand struct {
+	ID          string `json:"id" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=8,max=128"`
+}
+
+// Validate performs validation on the ResetUserPasswordCommand
+func (c *ResetUserPasswordCommand) Validate() error {
+	if c.ID == "" {
+		return NewValidationError("id", "user ID is required")
+	}
+	if c.NewPassword == "" {
+		return NewValidationError("new_password", "new password is required")
+	}
+	return nil
+}
+
 // ChangeUserStatusCommand represents a command to change a user's status
 type ChangeUserStatusCommand struct {
 	ID        string            `json:"id" validate:"required"`
@@ -123,6 +145,54 @@ func (c *ChangeUserStatusCommand) Validate() error {
 	return nil
 }
 
+// LockUserCommand represents a command to lock a user's account
+type LockUserCommand struct {
+	ID        string `json:"id" validate:"required"`
+	ChangedBy string `json:"changed_by,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Validate performs validation on the LockUserCommand
+func (c *LockUserCommand) Validate() error {
+	if c.ID == "" {
+		return NewValidationError("id", "user ID is required")
+	}
+	return nil
+}
+
+// UnlockUserCommand represents a command to unlock a locked user's account
+type UnlockUserCommand struct {
+	ID        string `json:"id" validate:"required"`
+	ChangedBy string `json:"changed_by,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// Validate performs validation on the UnlockUserCommand
+func (c *UnlockUserCommand) Validate() error {
+	if c.ID == "" {
+		return NewValidationError("id", "user ID is required")
+	}
+	return nil
+}
+
+// RecordLoginCommand represents a command to record a user's successful login
+type RecordLoginCommand struct {
+	ID        string    `json:"id" validate:"required"`
+	IPAddress string    `json:"ip_address,omitempty"`
+	LoginAt   time.Time `json:"login_at" validate:"required"`
+}
+
+// Validate performs validation on the RecordLoginCommand
+func (c *RecordLoginCommand) Validate() error {
+	if c.ID == "" {
+		return NewValidationError("id", "user ID is required")
+	}
+	if c.LoginAt.IsZero() {
+		return NewValidationError("login_at", "login time is required")
+	}
+	return nil
+}
+
 // DeleteUserCommand represents a command to delete a user
 type DeleteUserCommand struct {
 	ID        string `json:"id" validate:"required"`
@@ -164,6 +234,9 @@ func (q *GetUserByEmailQuery) Validate() error {
 	return nil
 }
 
+// UserSortFields are the fields ListUsersQuery can sort users by
+var UserSortFields = infrastructure.UserSortFields
+
 // ListUsersQuery represents a query to list users with filtering and pagination
 type ListUsersQuery struct {
 	Status        *domain.UserStatus `json:"status,omitempty"`
@@ -172,6 +245,9 @@ type ListUsersQuery struct {
 	LastName      *string            `json:"last_name,omitempty"`
 	CreatedAfter  *string            `json:"created_after,omitempty"`
 	CreatedBefore *string            `json:"created_before,omitempty"`
+	Search        *string            `json:"search,omitempty"`
+	SortBy        string             `json:"sort_by,omitempty"`
+	SortDesc      bool               `json:"sort_desc,omitempty"`
 	Limit         int                `json:"limit" validate:"min=1,max=100"`
 	Offset        int                `json:"offset" validate:"min=0"`
 }
@@ -187,5 +263,8 @@ func (q *ListUsersQuery) Validate() error {
 	if q.Offset < 0 {
 		return NewValidationError("offset", "offset cannot be negative")
 	}
+	if q.SortBy != "" && !slices.Contains(UserSortFields, q.SortBy) {
+		return NewValidationError("sort_by", "unknown sort field")
+	}
 	return nil
 }
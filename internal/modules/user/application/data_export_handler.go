@@ -0,0 +1,273 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"log"
+	"strings"
+	"time"
+
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/modules/user/infrastructure"
+	"go-templ-template/internal/shared/audit"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/email"
+	"go-templ-template/internal/shared/events"
+	"go-templ-template/internal/shared/storage"
+)
+
+// DataExportSource contributes a section of data to a user's export bundle.
+// Other modules add sources for the data they own, e.g. sessions.
+type DataExportSource interface {
+	// Section returns the key the data is stored under in the bundle
+	Section() string
+
+	// Collect returns the user's data, which must marshal to JSON
+	Collect(ctx context.Context, userID string) (interface{}, error)
+}
+
+// dataExportSourceFunc adapts a function to the DataExportSource interface
+type dataExportSourceFunc struct {
+	section string
+	collect func(ctx context.Context, userID string) (interface{}, error)
+}
+
+// NewDataExportSource creates a DataExportSource storing the result of
+// collect under section
+func NewDataExportSource(section string, collect func(ctx context.Context, userID string) (interface{}, error)) DataExportSource {
+	return &dataExportSourceFunc{section: section, collect: collect}
+}
+
+func (s *dataExportSourceFunc) Section() string {
+	return s.section
+}
+
+func (s *dataExportSourceFunc) Collect(ctx context.Context, userID string) (interface{}, error) {
+	return s.collect(ctx, userID)
+}
+
+// exportedAuditEvent is the part of an audit event included in an export.
+// Event details are left out as they can hold secrets such as session IDs.
+type exportedAuditEvent struct {
+	EventType  string    `json:"event_type"`
+	Action     string    `json:"action"`
+	Resource   string    `json:"resource"`
+	ResourceID string    `json:"resource_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// NewAuditEventsExportSource creates a DataExportSource listing the audit
+// events recorded for a user, oldest first
+func NewAuditEventsExportSource(auditLogger audit.AuditLogger) DataExportSource {
+	return NewDataExportSource("audit_events", func(ctx context.Context, userID string) (interface{}, error) {
+		auditEvents, err := auditLogger.GetEvents(ctx, &audit.AuditFilter{UserID: userID, Ascending: true})
+		if err != nil {
+			return nil, err
+		}
+
+		exported := make([]exportedAuditEvent, 0, len(auditEvents))
+		for _, event := range auditEvents {
+			exported = append(exported, exportedAuditEvent{
+				EventType:  event.EventType,
+				Action:     event.Action,
+				Resource:   event.Resource,
+				ResourceID: event.ResourceID,
+				OccurredAt: event.OccurredAt,
+			})
+		}
+		return exported, nil
+	})
+}
+
+// DataExportBundle is the JSON document a data export produces
+type DataExportBundle struct {
+	ExportID    string                 `json:"export_id"`
+	GeneratedAt time.Time              `json:"generated_at"`
+	Profile     *domain.User           `json:"profile"`
+	Sections    map[string]interface{} `json:"sections"`
+}
+
+// DataExportHandler assembles a user's export bundle when an export is
+// requested, stores it in the blob store and emails the user a download
+// link. Redelivered events for a ready export only resend the email, so a
+// failed send can be retried by the event bus.
+type DataExportHandler struct {
+	exportRepo domain.DataExportRepository
+	userRepo   infrastructure.UserRepository
+	blobStore  storage.BlobStore
+	sender     email.EmailSender
+	apiURL     string
+	sources    []DataExportSource
+}
+
+// NewDataExportHandler creates a new data export handler. Links in the email
+// point at apiURL, the root of the versioned API; no email is sent when
+// sender is nil.
+func NewDataExportHandler(
+	exportRepo domain.DataExportRepository,
+	userRepo infrastructure.UserRepository,
+	blobStore storage.BlobStore,
+	sender email.EmailSender,
+	apiURL string,
+	sources ...DataExportSource,
+) *DataExportHandler {
+	return &DataExportHandler{
+		exportRepo: exportRepo,
+		userRepo:   userRepo,
+		blobStore:  blobStore,
+		sender:     sender,
+		apiURL:     strings.TrimSuffix(apiURL, "/"),
+		sources:    sources,
+	}
+}
+
+// AddSource adds a section of data to the bundles the handler assembles
+func (h *DataExportHandler) AddSource(source DataExportSource) {
+	h.sources = append(h.sources, source)
+}
+
+// Handle processes data export requested events
+func (h *DataExportHandler) Handle(ctx context.Context, event events.DomainEvent) error {
+	eventData, ok := event.EventData().(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid event data format")
+	}
+
+	exportID, _ := eventData["export_id"].(string)
+	if exportID == "" {
+		return fmt.Errorf("export requested event %s has no export ID", event.EventID())
+	}
+
+	export, err := h.exportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return h.exportNotFound(ctx, exportID, eventData)
+		}
+		return fmt.Errorf("failed to get data export: %w", err)
+	}
+
+	user, err := h.userRepo.GetByID(ctx, export.UserID)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			log.Printf("Skipping data export %s: user %s not found", exportID, export.UserID)
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if export.IsPending() {
+		if err := h.assemble(ctx, export, user); err != nil {
+			if failErr := export.MarkFailed(err.Error()); failErr == nil {
+				if updateErr := h.exportRepo.Update(ctx, export); updateErr != nil {
+					log.Printf("Failed to record failure of data export %s: %v", exportID, updateErr)
+				}
+			}
+			return fmt.Errorf("failed to assemble data export %s: %w", exportID, err)
+		}
+	}
+
+	if !export.IsReady() {
+		return nil
+	}
+	return h.sendEmail(ctx, user, export)
+}
+
+// exportNotFound handles an event for an export that can't be found. Exports
+// are deleted along with their user, so the event is skipped once the user is
+// gone; otherwise an error is returned so the event bus retries it rather
+// than leave the export pending.
+func (h *DataExportHandler) exportNotFound(ctx context.Context, exportID string, eventData map[string]interface{}) error {
+	userID, _ := eventData["user_id"].(string)
+	if userID != "" {
+		_, err := h.userRepo.GetByID(ctx, userID)
+		if database.IsNotFoundError(err) {
+			log.Printf("Skipping data export %s: user %s not found", exportID, userID)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+	}
+	return fmt.Errorf("data export %s not found", exportID)
+}
+
+// assemble builds the bundle, stores it and marks the export ready
+func (h *DataExportHandler) assemble(ctx context.Context, export *domain.DataExport, user *domain.User) error {
+	bundle := &DataExportBundle{
+		ExportID:    export.ID,
+		GeneratedAt: time.Now().UTC(),
+		Profile:     user,
+		Sections:    make(map[string]interface{}, len(h.sources)),
+	}
+	for _, source := range h.sources {
+		data, err := source.Collect(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("failed to collect %s: %w", source.Section(), err)
+		}
+		bundle.Sections[source.Section()] = data
+	}
+
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle: %w", err)
+	}
+
+	key := dataExportKey(user.ID, export.ID)
+	if err := h.blobStore.Put(ctx, key, bytes.NewReader(data), "application/json"); err != nil {
+		return fmt.Errorf("failed to store bundle: %w", err)
+	}
+
+	if err := export.MarkReady(key); err != nil {
+		return err
+	}
+	if err := h.exportRepo.Update(ctx, export); err != nil {
+		return fmt.Errorf("failed to update data export: %w", err)
+	}
+	return nil
+}
+
+// sendEmail emails the user a link to download their export
+func (h *DataExportHandler) sendEmail(ctx context.Context, user *domain.User, export *domain.DataExport) error {
+	if h.sender == nil {
+		return nil
+	}
+
+	link := h.apiURL + DataExportDownloadPath(user.ID, export.ID)
+
+	msg := email.Message{
+		To:       []string{user.Email},
+		Subject:  "Your data export is ready",
+		TextBody: fmt.Sprintf("Hi %s,\n\nThe copy of your data you requested is ready to download:\n\n%s\n", greetingName(user.FirstName), link),
+		HTMLBody: fmt.Sprintf(`<p>Hi %s,</p><p>The copy of your data you requested is ready to download:</p><p><a href="%s">Download your data</a></p>`,
+			html.EscapeString(greetingName(user.FirstName)), html.EscapeString(link)),
+	}
+
+	if err := h.sender.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send data export email: %w", err)
+	}
+	return nil
+}
+
+// EventType returns the event type this handler processes
+func (h *DataExportHandler) EventType() string {
+	return "user.export_requested"
+}
+
+// HandlerName returns a unique name for this handler
+func (h *DataExportHandler) HandlerName() string {
+	return "user.data_export_handler"
+}
+
+// DataExportDownloadPath returns the path an export's bundle is downloaded
+// from, relative to the API prefix
+func DataExportDownloadPath(userID, exportID string) string {
+	return "/users/" + userID + "/export/" + exportID + "/download"
+}
+
+// dataExportKey returns the key an export's bundle is stored under
+func dataExportKey(userID, exportID string) string {
+	return "exports/" + userID + "/" + exportID + ".json"
+}
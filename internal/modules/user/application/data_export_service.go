@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/modules/user/infrastructure"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/events"
+	"go-templ-template/internal/shared/storage"
+
+	"github.com/google/uuid"
+)
+
+// DataExportService defines the interface for requesting and retrieving
+// copies of a user's data
+type DataExportService interface {
+	// RequestExport creates a pending export; the bundle is assembled by the
+	// handler of the user.export_requested event
+	RequestExport(ctx context.Context, userID string) (*domain.DataExport, error)
+
+	// GetExport retrieves one of a user's exports
+	GetExport(ctx context.Context, userID, exportID string) (*domain.DataExport, error)
+
+	// OpenExport opens the bundle of one of a user's ready exports. The
+	// caller must close it.
+	OpenExport(ctx context.Context, userID, exportID string) (io.ReadCloser, error)
+}
+
+// dataExportServiceImpl implements the DataExportService interface
+type dataExportServiceImpl struct {
+	userRepo   infrastructure.UserRepository
+	exportRepo domain.DataExportRepository
+	blobStore  storage.BlobStore
+	eventBus   events.EventBus
+	db         *database.DB
+}
+
+// NewDataExportService creates a new data export service instance
+func NewDataExportService(
+	userRepo infrastructure.UserRepository,
+	exportRepo domain.DataExportRepository,
+	blobStore storage.BlobStore,
+	eventBus events.EventBus,
+	db *database.DB,
+) DataExportService {
+	return &dataExportServiceImpl{
+		userRepo:   userRepo,
+		exportRepo: exportRepo,
+		blobStore:  blobStore,
+		eventBus:   eventBus,
+		db:         db,
+	}
+}
+
+// RequestExport creates a pending export and publishes the event that
+// assembles it once the export is committed, so a request is never lost and
+// the handler never runs before the export exists
+func (s *dataExportServiceImpl) RequestExport(ctx context.Context, userID string) (*domain.DataExport, error) {
+	if userID == "" {
+		return nil, NewValidationError("id", "user ID is required")
+	}
+
+	var export *domain.DataExport
+	err := database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
+		user, err := s.userRepo.GetByID(txCtx, userID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return NewUserNotFoundError(userID)
+			}
+			return NewInternalError(fmt.Sprintf("failed to get user: %v", err))
+		}
+
+		export = domain.NewDataExport(uuid.New().String(), user.ID)
+		if err := s.exportRepo.Create(txCtx, export); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to create data export: %v", err))
+		}
+
+		event := domain.NewDataExportRequestedEvent(user, export)
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish export requested event: %v", err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// GetExport retrieves an export, reporting exports of other users as not found
+func (s *dataExportServiceImpl) GetExport(ctx context.Context, userID, exportID string) (*domain.DataExport, error) {
+	export, err := s.exportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, NewExportNotFoundError(exportID)
+		}
+		return nil, NewInternalError(fmt.Sprintf("failed to get data export: %v", err))
+	}
+	if export.UserID != userID {
+		return nil, NewExportNotFoundError(exportID)
+	}
+
+	return export, nil
+}
+
+// OpenExport opens the bundle of a ready export
+func (s *dataExportServiceImpl) OpenExport(ctx context.Context, userID, exportID string) (io.ReadCloser, error) {
+	export, err := s.GetExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, err
+	}
+	if !export.IsReady() {
+		return nil, NewExportNotReadyError(exportID)
+	}
+
+	body, err := s.blobStore.Get(ctx, export.BlobKey)
+	if err != nil {
+		return nil, NewInternalError(fmt.Sprintf("failed to open data export: %v", err))
+	}
+
+	return body, nil
+}
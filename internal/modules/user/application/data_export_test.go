@@ -0,0 +1,338 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/audit"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/email"
+	"go-templ-template/internal/shared/events"
+	"go-templ-template/internal/shared/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryDataExportRepository is an in-memory DataExportRepository
+type memoryDataExportRepository struct {
+	mu      sync.Mutex
+	exports map[string]*domain.DataExport
+}
+
+func newMemoryDataExportRepository() *memoryDataExportRepository {
+	return &memoryDataExportRepository{exports: make(map[string]*domain.DataExport)}
+}
+
+func (r *memoryDataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *export
+	r.exports[export.ID] = &stored
+	return nil
+}
+
+func (r *memoryDataExportRepository) GetByID(ctx context.Context, id string) (*domain.DataExport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.exports[id]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	found := *stored
+	return &found, nil
+}
+
+func (r *memoryDataExportRepository) Update(ctx context.Context, export *domain.DataExport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.exports[export.ID]; !ok {
+		return database.ErrNotFound
+	}
+	stored := *export
+	r.exports[export.ID] = &stored
+	return nil
+}
+
+// stubAuditLogger returns fixed audit events and records logged ones
+type stubAuditLogger struct {
+	events []*audit.AuditEvent
+	filter *audit.AuditFilter
+	logged []*audit.AuditEvent
+}
+
+func (l *stubAuditLogger) LogEvent(ctx context.Context, event *audit.AuditEvent) error {
+	l.logged = append(l.logged, event)
+	return nil
+}
+
+func (l *stubAuditLogger) GetEvents(ctx context.Context, filter *audit.AuditFilter) ([]*audit.AuditEvent, error) {
+	l.filter = filter
+	return l.events, nil
+}
+
+// dataExportFixture holds a data export handler over in-memory dependencies
+type dataExportFixture struct {
+	handler    *DataExportHandler
+	repo       *MockUserRepositorySimple
+	exportRepo *memoryDataExportRepository
+	blobStore  storage.BlobStore
+	sender     *email.RecordingSender
+	user       *domain.User
+	export     *domain.DataExport
+}
+
+func newDataExportFixture(t *testing.T, sources ...DataExportSource) *dataExportFixture {
+	t.Helper()
+
+	f := &dataExportFixture{
+		repo:       &MockUserRepositorySimple{},
+		exportRepo: newMemoryDataExportRepository(),
+		blobStore:  storage.NewLocalStore(t.TempDir(), "/uploads"),
+		sender:     email.NewRecordingSender(),
+		user:       newEventTestUser(t),
+	}
+	f.handler = NewDataExportHandler(f.exportRepo, f.repo, f.blobStore, f.sender, "https://app.example.com/api/v1/", sources...)
+
+	f.export = domain.NewDataExport("export-1", f.user.ID)
+	require.NoError(t, f.exportRepo.Create(context.Background(), f.export))
+	f.repo.On("GetByID", mock.Anything, f.user.ID).Return(f.user, nil)
+	return f
+}
+
+func (f *dataExportFixture) event() events.DomainEvent {
+	return domain.NewDataExportRequestedEvent(f.user, f.export)
+}
+
+func TestDataExportHandler_AssemblesBundle(t *testing.T) {
+	auditLogger := &stubAuditLogger{events: []*audit.AuditEvent{{
+		EventType:  "user.updated",
+		Action:     "update",
+		Resource:   "user",
+		ResourceID: "user-123",
+		Details:    map[string]interface{}{"session_id": "secret-session"},
+		OccurredAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}}}
+	sessions := NewDataExportSource("sessions", func(ctx context.Context, userID string) (interface{}, error) {
+		return []map[string]string{{"ip_address": "192.0.2.1"}}, nil
+	})
+	f := newDataExportFixture(t, NewAuditEventsExportSource(auditLogger), sessions)
+
+	require.NoError(t, f.handler.Handle(context.Background(), f.event()))
+
+	// The export moves from pending to ready
+	export, err := f.exportRepo.GetByID(context.Background(), "export-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DataExportStatusReady, export.Status)
+	assert.Equal(t, "exports/user-123/export-1.json", export.BlobKey)
+	assert.NotNil(t, export.CompletedAt)
+
+	// The bundle holds the profile and every source's section
+	body, err := f.blobStore.Get(context.Background(), export.BlobKey)
+	require.NoError(t, err)
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var bundle struct {
+		ExportID string                     `json:"export_id"`
+		Profile  map[string]interface{}     `json:"profile"`
+		Sections map[string]json.RawMessage `json:"sections"`
+	}
+	require.NoError(t, json.Unmarshal(data, &bundle))
+	assert.Equal(t, "export-1", bundle.ExportID)
+	assert.Equal(t, "events@example.com", bundle.Profile["email"])
+	assert.NotContains(t, bundle.Profile, "password")
+	assert.JSONEq(t, `[{"ip_address":"192.0.2.1"}]`, string(bundle.Sections["sessions"]))
+	assert.JSONEq(t, `[{"event_type":"user.updated","action":"update","resource":"user","resource_id":"user-123","occurred_at":"2024-01-02T03:04:05Z"}]`,
+		string(bundle.Sections["audit_events"]))
+	assert.NotContains(t, string(data), "secret-session")
+	assert.Equal(t, "user-123", auditLogger.filter.UserID)
+
+	// The user is emailed a download link
+	messages := f.sender.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"events@example.com"}, messages[0].To)
+	assert.Contains(t, messages[0].TextBody, "https://app.example.com/api/v1/users/user-123/export/export-1/download")
+}
+
+func TestDataExportHandler_SourceFailureMarksFailed(t *testing.T) {
+	failing := NewDataExportSource("sessions", func(ctx context.Context, userID string) (interface{}, error) {
+		return nil, errors.New("sessions unavailable")
+	})
+	f := newDataExportFixture(t, failing)
+
+	assert.Error(t, f.handler.Handle(context.Background(), f.event()))
+
+	export, err := f.exportRepo.GetByID(context.Background(), "export-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DataExportStatusFailed, export.Status)
+	assert.Contains(t, export.Error, "sessions unavailable")
+	assert.Empty(t, f.sender.Messages())
+
+	// Redelivery leaves a failed export alone
+	assert.NoError(t, f.handler.Handle(context.Background(), f.event()))
+	assert.Empty(t, f.sender.Messages())
+}
+
+func TestDataExportHandler_RedeliveryOnlyResendsEmail(t *testing.T) {
+	collected := 0
+	counting := NewDataExportSource("sessions", func(ctx context.Context, userID string) (interface{}, error) {
+		collected++
+		return []string{}, nil
+	})
+	f := newDataExportFixture(t, counting)
+	f.sender.Err = errors.New("smtp unavailable")
+
+	// The bundle is stored even though the email cannot be sent
+	assert.ErrorIs(t, f.handler.Handle(context.Background(), f.event()), f.sender.Err)
+	export, err := f.exportRepo.GetByID(context.Background(), "export-1")
+	require.NoError(t, err)
+	assert.True(t, export.IsReady())
+
+	f.sender.Err = nil
+	require.NoError(t, f.handler.Handle(context.Background(), f.event()))
+	assert.Equal(t, 1, collected)
+	assert.Len(t, f.sender.Messages(), 1)
+}
+
+func TestDataExportHandler_ExportNotFound(t *testing.T) {
+	t.Run("user exists", func(t *testing.T) {
+		f := newDataExportFixture(t)
+
+		// The export may not be visible yet, so the event is retried
+		event := domain.NewDataExportRequestedEvent(f.user, domain.NewDataExport("missing", f.user.ID))
+		assert.Error(t, f.handler.Handle(context.Background(), event))
+		assert.Empty(t, f.sender.Messages())
+	})
+
+	t.Run("user deleted", func(t *testing.T) {
+		f := newDataExportFixture(t)
+		deleted, err := domain.NewUser("deleted-user", "deleted@example.com", "Password123", "Jane", "Doe")
+		require.NoError(t, err)
+		f.repo.On("GetByID", mock.Anything, deleted.ID).Return(nil, database.ErrNotFound)
+
+		// Exports are deleted along with their user, so the event is skipped
+		event := domain.NewDataExportRequestedEvent(deleted, domain.NewDataExport("missing", deleted.ID))
+		assert.NoError(t, f.handler.Handle(context.Background(), event))
+		assert.Empty(t, f.sender.Messages())
+	})
+}
+
+func TestDataExportService_RequestExport_PublishesAfterCommit(t *testing.T) {
+	repo := &MockUserRepositorySimple{}
+	exportRepo := newMemoryDataExportRepository()
+	eventBus := &MockEventBusSimple{}
+	published := capturePublished(t, eventBus)
+	service := NewDataExportService(repo, exportRepo, storage.NewLocalStore(t.TempDir(), "/uploads"), eventBus, database.NewStubDB().DB)
+
+	user := newEventTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	export, err := service.RequestExport(context.Background(), user.ID)
+	require.NoError(t, err)
+	assert.True(t, export.IsPending())
+
+	require.Len(t, *published, 1)
+	event, ok := (*published)[0].(*domain.DataExportRequestedEvent)
+	require.True(t, ok)
+	assert.Equal(t, export.ID, event.ExportID)
+}
+
+func TestDataExportService_RequestExport_PublishFailureKeepsExport(t *testing.T) {
+	repo := &MockUserRepositorySimple{}
+	exportRepo := newMemoryDataExportRepository()
+	eventBus := &MockEventBusSimple{}
+	eventBus.On("Publish", mock.Anything, mock.Anything).Return(errors.New("handler failed"))
+	service := NewDataExportService(repo, exportRepo, storage.NewLocalStore(t.TempDir(), "/uploads"), eventBus, database.NewStubDB().DB)
+
+	user := newEventTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	// The export is committed before the event is handled, so a failing
+	// handler doesn't fail the request
+	export, err := service.RequestExport(context.Background(), user.ID)
+	require.NoError(t, err)
+	eventBus.AssertNumberOfCalls(t, "Publish", 1)
+
+	_, err = exportRepo.GetByID(context.Background(), export.ID)
+	assert.NoError(t, err)
+}
+
+func TestDataExportService_GetAndOpenExport(t *testing.T) {
+	exportRepo := newMemoryDataExportRepository()
+	blobStore := storage.NewLocalStore(t.TempDir(), "/uploads")
+	service := NewDataExportService(&MockUserRepositorySimple{}, exportRepo, blobStore, &MockEventBusSimple{}, nil)
+	ctx := context.Background()
+
+	export := domain.NewDataExport("export-1", "user-123")
+	require.NoError(t, exportRepo.Create(ctx, export))
+
+	// Pending exports can be viewed but not downloaded
+	found, err := service.GetExport(ctx, "user-123", "export-1")
+	require.NoError(t, err)
+	assert.True(t, found.IsPending())
+
+	_, err = service.OpenExport(ctx, "user-123", "export-1")
+	assert.Equal(t, ErrCodeExportNotReady, err.(*ApplicationError).Code)
+
+	// Other users' exports are reported as not found
+	_, err = service.GetExport(ctx, "user-456", "export-1")
+	assert.Equal(t, ErrCodeExportNotFound, err.(*ApplicationError).Code)
+
+	_, err = service.GetExport(ctx, "user-123", "missing")
+	assert.Equal(t, ErrCodeExportNotFound, err.(*ApplicationError).Code)
+
+	// Ready exports open their bundle
+	require.NoError(t, blobStore.Put(ctx, "exports/user-123/export-1.json", strings.NewReader(`{}`), "application/json"))
+	require.NoError(t, export.MarkReady("exports/user-123/export-1.json"))
+	require.NoError(t, exportRepo.Update(ctx, export))
+
+	body, err := service.OpenExport(ctx, "user-123", "export-1")
+	require.NoError(t, err)
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(data))
+}
+
+func TestDataExportService_RequestExport_PublishesEvent(t *testing.T) {
+	database.SkipIfNoDatabase(t)
+	tdb := database.NewTestDatabase(t)
+	t.Cleanup(tdb.Close)
+
+	repo := &MockUserRepositorySimple{}
+	exportRepo := newMemoryDataExportRepository()
+	eventBus := &MockEventBusSimple{}
+	service := NewDataExportService(repo, exportRepo, storage.NewLocalStore(t.TempDir(), "/uploads"), eventBus, tdb.DB)
+
+	user := newEventTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	var published events.DomainEvent
+	eventBus.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		published = args.Get(1).(events.DomainEvent)
+	}).Return(nil)
+
+	export, err := service.RequestExport(context.Background(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DataExportStatusPending, export.Status)
+
+	stored, err := exportRepo.GetByID(context.Background(), export.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.IsPending())
+
+	require.NotNil(t, published)
+	assert.Equal(t, "user.export_requested", published.EventType())
+	data := published.EventData().(map[string]interface{})
+	assert.Equal(t, export.ID, data["export_id"])
+	assert.Equal(t, "events@example.com", data["email"])
+}
@@ -0,0 +1,128 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	"go-templ-template/internal/shared/email"
+	"go-templ-template/internal/shared/events"
+)
+
+// WelcomeEmailHandler sends a welcome email when a user registers. It runs
+// after the user is committed, so a failed send never rolls back the
+// registration; the error is returned so the event bus can retry it.
+type WelcomeEmailHandler struct {
+	sender email.EmailSender
+}
+
+// NewWelcomeEmailHandler creates a new welcome email handler
+func NewWelcomeEmailHandler(sender email.EmailSender) *WelcomeEmailHandler {
+	return &WelcomeEmailHandler{
+		sender: sender,
+	}
+}
+
+// Handle processes user created events
+func (h *WelcomeEmailHandler) Handle(ctx context.Context, event events.DomainEvent) error {
+	eventData, ok := event.EventData().(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid event data format")
+	}
+
+	address, _ := eventData["email"].(string)
+	if address == "" {
+		return fmt.Errorf("user created event %s has no email", event.EventID())
+	}
+	firstName, _ := eventData["first_name"].(string)
+
+	msg := email.Message{
+		To:      []string{address},
+		Subject: "Welcome!",
+		TextBody: fmt.Sprintf("Hi %s,\n\nThanks for signing up. Your account is ready to use.\n",
+			greetingName(firstName)),
+		HTMLBody: fmt.Sprintf("<p>Hi %s,</p><p>Thanks for signing up. Your account is ready to use.</p>",
+			html.EscapeString(greetingName(firstName))),
+	}
+
+	if err := h.sender.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send welcome email: %w", err)
+	}
+	return nil
+}
+
+// EventType returns the event type this handler processes
+func (h *WelcomeEmailHandler) EventType() string {
+	return "user.created"
+}
+
+// HandlerName returns a unique name for this handler
+func (h *WelcomeEmailHandler) HandlerName() string {
+	return "user.welcome_email_handler"
+}
+
+// VerificationEmailHandler emails the verification link when a user requests
+// email verification
+type VerificationEmailHandler struct {
+	sender email.EmailSender
+	apiURL string
+}
+
+// NewVerificationEmailHandler creates a new verification email handler.
+// Links in the email point at apiURL, the root of the versioned API such as
+// https://app.example.com/api/v1.
+func NewVerificationEmailHandler(sender email.EmailSender, apiURL string) *VerificationEmailHandler {
+	return &VerificationEmailHandler{
+		sender: sender,
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+	}
+}
+
+// Handle processes user activation requested events
+func (h *VerificationEmailHandler) Handle(ctx context.Context, event events.DomainEvent) error {
+	eventData, ok := event.EventData().(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid event data format")
+	}
+
+	address, _ := eventData["email"].(string)
+	token, _ := eventData["activation_token"].(string)
+	if address == "" || token == "" {
+		return fmt.Errorf("activation requested event %s has no email or token", event.EventID())
+	}
+
+	link := h.apiURL + "/auth/verify/confirm?token=" + url.QueryEscape(token)
+
+	msg := email.Message{
+		To:       []string{address},
+		Subject:  "Verify your email address",
+		TextBody: fmt.Sprintf("Please verify your email address by opening this link:\n\n%s\n", link),
+		HTMLBody: fmt.Sprintf(`<p>Please verify your email address by opening this link:</p><p><a href="%s">Verify email</a></p>`,
+			html.EscapeString(link)),
+	}
+
+	if err := h.sender.Send(ctx, msg); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	return nil
+}
+
+// EventType returns the event type this handler processes
+func (h *VerificationEmailHandler) EventType() string {
+	return "user.activation_requested"
+}
+
+// HandlerName returns a unique name for this handler
+func (h *VerificationEmailHandler) HandlerName() string {
+	return "user.verification_email_handler"
+}
+
+// greetingName returns the name to greet a user by
+func greetingName(firstName string) string {
+	if firstName == "" {
+		return "there"
+	}
+	return firstName
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/email"
+	"go-templ-template/internal/shared/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWelcomeEmailHandler_SendsWelcomeEmail(t *testing.T) {
+	sender := email.NewRecordingSender()
+	handler := NewWelcomeEmailHandler(sender)
+
+	user := newEventTestUser(t)
+	err := handler.Handle(context.Background(), domain.NewUserCreatedEvent(user))
+	require.NoError(t, err)
+
+	messages := sender.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"events@example.com"}, messages[0].To)
+	assert.Contains(t, messages[0].TextBody, "Hi John")
+	assert.NotEmpty(t, messages[0].HTMLBody)
+}
+
+func TestWelcomeEmailHandler_SendFailure(t *testing.T) {
+	sender := email.NewRecordingSender()
+	sender.Err = errors.New("smtp unavailable")
+	handler := NewWelcomeEmailHandler(sender)
+
+	err := handler.Handle(context.Background(), domain.NewUserCreatedEvent(newEventTestUser(t)))
+	assert.ErrorIs(t, err, sender.Err)
+}
+
+func TestVerificationEmailHandler_SendsLink(t *testing.T) {
+	sender := email.NewRecordingSender()
+	handler := NewVerificationEmailHandler(sender, "https://app.example.com/api/v1/")
+
+	user := newEventTestUser(t)
+	event := domain.NewUserActivationRequestedEvent(user, "token+123", time.Now().Add(time.Hour), "self")
+	require.NoError(t, handler.Handle(context.Background(), event))
+
+	messages := sender.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"events@example.com"}, messages[0].To)
+	assert.Contains(t, messages[0].TextBody, "https://app.example.com/api/v1/auth/verify/confirm?token=token%2B123")
+}
+
+func TestVerificationEmailHandler_MissingToken(t *testing.T) {
+	sender := email.NewRecordingSender()
+	handler := NewVerificationEmailHandler(sender, "https://app.example.com/api/v1")
+
+	event := domain.NewUserActivationRequestedEvent(newEventTestUser(t), "", time.Now().Add(time.Hour), "self")
+	assert.Error(t, handler.Handle(context.Background(), event))
+	assert.Empty(t, sender.Messages())
+}
+
+// newRegistrationEmailTestService builds the real user service publishing to
+// an in-memory event bus with the welcome email handler subscribed
+func newRegistrationEmailTestService(t *testing.T, sender email.EmailSender) (UserService, *MockUserRepositorySimple) {
+	t.Helper()
+
+	database.SkipIfNoDatabase(t)
+	tdb := database.NewTestDatabase(t)
+	t.Cleanup(tdb.Close)
+
+	eventBus := events.NewInMemoryEventBus()
+	require.NoError(t, eventBus.Subscribe("user.created", NewWelcomeEmailHandler(sender)))
+	require.NoError(t, eventBus.Start(context.Background()))
+	t.Cleanup(func() { _ = eventBus.Stop(context.Background()) })
+
+	repo := &MockUserRepositorySimple{}
+	repo.On("ExistsByEmail", mock.Anything, "events@example.com").Return(false, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+
+	return NewUserService(repo, eventBus, tdb.DB), repo
+}
+
+func TestUserService_CreateUser_SendsWelcomeEmail(t *testing.T) {
+	sender := email.NewRecordingSender()
+	service, _ := newRegistrationEmailTestService(t, sender)
+
+	_, err := service.CreateUser(context.Background(), &CreateUserCommand{
+		Email:     "events@example.com",
+		Password:  "Password123",
+		FirstName: "John",
+		LastName:  "Doe",
+	})
+	require.NoError(t, err)
+
+	messages := sender.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, []string{"events@example.com"}, messages[0].To)
+}
+
+func TestUserService_CreateUser_EmailFailureKeepsUser(t *testing.T) {
+	sender := email.NewRecordingSender()
+	sender.Err = errors.New("smtp unavailable")
+	service, repo := newRegistrationEmailTestService(t, sender)
+
+	user, err := service.CreateUser(context.Background(), &CreateUserCommand{
+		Email:     "events@example.com",
+		Password:  "Password123",
+		FirstName: "John",
+		LastName:  "Doe",
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, user)
+
+	repo.AssertCalled(t, "Create", mock.Anything, mock.AnythingOfType("*domain.User"))
+	repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
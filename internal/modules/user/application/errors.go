@@ -28,6 +28,8 @@ const (
 	ErrCodeOptimisticLock    = "OPTIMISTIC_LOCK_ERROR"
 	ErrCodeBusinessRule      = "BUSINESS_RULE_VIOLATION"
 	ErrCodeInternal          = "INTERNAL_ERROR"
+	ErrCodeExportNotFound    = "DATA_EXPORT_NOT_FOUND"
+	ErrCodeExportNotReady    = "DATA_EXPORT_NOT_READY"
 )
 
 // NewValidationError creates a new validation error
@@ -79,6 +81,23 @@ func NewBusinessRuleError(message string) *ApplicationError {
 	}
 }
 
+// NewExportNotFoundError creates a new data export not found error
+func NewExportNotFoundError(id string) *ApplicationError {
+	return &ApplicationError{
+		Code:    ErrCodeExportNotFound,
+		Message: fmt.Sprintf("data export with ID '%s' not found", id),
+	}
+}
+
+// NewExportNotReadyError creates a new error for downloading an export that
+// is not ready
+func NewExportNotReadyError(id string) *ApplicationError {
+	return &ApplicationError{
+		Code:    ErrCodeExportNotReady,
+		Message: fmt.Sprintf("data export with ID '%s' is not ready", id),
+	}
+}
+
 // NewInternalError creates a new internal error
 func NewInternalError(message string) *ApplicationError {
 	return &ApplicationError{
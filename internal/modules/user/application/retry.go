@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+
+	"go-templ-template/internal/shared/database"
+)
+
+// profileUpdateAttempts is how many times a profile update is tried when
+// concurrent writes to the user keep conflicting with it
+const profileUpdateAttempts = 3
+
+// RetryOnConflict runs fn, running it again while it fails with an
+// optimistic lock error, up to maxAttempts times in all. fn must re-read
+// what it updates, so each attempt works on the latest version. It returns
+// nil once fn succeeds, and otherwise fn's last error, or the context's
+// error when ctx is done between attempts.
+func RetryOnConflict(ctx context.Context, maxAttempts int, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < max(maxAttempts, 1); attempt++ {
+		if attempt > 0 {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+		}
+
+		err = fn()
+		if err == nil || !isConflict(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// isConflict reports whether err is an optimistic lock error, from the
+// service or straight from the repository
+func isConflict(err error) bool {
+	return IsOptimisticLockError(err) || database.IsOptimisticLockError(err)
+}
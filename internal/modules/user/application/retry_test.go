@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go-templ-template/internal/shared/database"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryOnConflict_SucceedsOnRetry(t *testing.T) {
+	attempts := 0
+	err := RetryOnConflict(context.Background(), 3, func() error {
+		attempts++
+		if attempts == 1 {
+			return NewOptimisticLockError("user-123")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestRetryOnConflict_ExhaustsAttempts(t *testing.T) {
+	attempts := 0
+	err := RetryOnConflict(context.Background(), 3, func() error {
+		attempts++
+		return fmt.Errorf("attempt %d: %w", attempts, database.ErrOptimisticLock)
+	})
+
+	assert.True(t, database.IsOptimisticLockError(err))
+	assert.EqualError(t, err, "attempt 3: optimistic locking conflict")
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryOnConflict_DoesNotRetryOtherErrors(t *testing.T) {
+	attempts := 0
+	err := RetryOnConflict(context.Background(), 3, func() error {
+		attempts++
+		return NewUserNotFoundError("user-123")
+	})
+
+	assert.True(t, IsUserNotFoundError(err))
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryOnConflict_StopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	err := RetryOnConflict(ctx, 3, func() error {
+		attempts++
+		cancel()
+		return NewOptimisticLockError("user-123")
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryOnConflict_RunsAtLeastOnce(t *testing.T) {
+	attempts := 0
+	err := RetryOnConflict(context.Background(), 0, func() error {
+		attempts++
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+}
@@ -32,9 +32,21 @@ type UserService interface {
 	// ChangeUserPassword changes a user's password
 	ChangeUserPassword(ctx context.Context, cmd *ChangeUserPasswordCommand) (*domain.User, error)
 
+	// ResetUserPassword sets a user's password without checking the old one
+	ResetUserPassword(ctx context.Context, cmd *ResetUserPasswordCommand) (*domain.User, error)
+
 	// ChangeUserStatus changes a user's status
 	ChangeUserStatus(ctx context.Context, cmd *ChangeUserStatusCommand) (*domain.User, error)
 
+	// LockUser locks a user's account
+	LockUser(ctx context.Context, cmd *LockUserCommand) (*domain.User, error)
+
+	// UnlockUser unlocks a locked user's account
+	UnlockUser(ctx context.Context, cmd *UnlockUserCommand) (*domain.User, error)
+
+	// RecordLogin records when and from where a user last logged in
+	RecordLogin(ctx context.Context, cmd *RecordLoginCommand) (*domain.User, error)
+
 	// DeleteUser deletes a user
 	DeleteUser(ctx context.Context, cmd *DeleteUserCommand) error
 
@@ -44,17 +56,56 @@ type UserService interface {
 
 // userServiceImpl implements the UserService interface
 type userServiceImpl struct {
-	userRepo infrastructure.UserRepository
-	eventBus events.EventBus
-	db       *database.DB
+	userRepo        infrastructure.UserRepository
+	eventBus        events.EventBus
+	db              *database.DB
+	passwordHasher  domain.PasswordHasher
+	sessionRevoker  SessionRevoker
+	emailNormalizer domain.EmailNormalizer
 }
 
-// NewUserService creates a new user service instance
+// UserServiceConfig configures a user service
+type UserServiceConfig struct {
+	// PasswordHasher hashes and verifies passwords; the default hasher is
+	// used when nil
+	PasswordHasher domain.PasswordHasher
+
+	// SessionRevoker revokes all of a user's sessions when their password
+	// changes; sessions are left alone when nil
+	SessionRevoker SessionRevoker
+
+	// EmailNormalizer puts emails in the form users are stored by. It must
+	// match the repository's normalizer so lookups find stored users.
+	EmailNormalizer domain.EmailNormalizer
+}
+
+// NewUserService creates a new user service instance using the default
+// password hasher
 func NewUserService(userRepo infrastructure.UserRepository, eventBus events.EventBus, db *database.DB) UserService {
+	return NewUserServiceWithHasher(userRepo, eventBus, db, domain.DefaultPasswordHasher())
+}
+
+// NewUserServiceWithHasher creates a new user service instance that hashes
+// and verifies passwords with passwordHasher
+func NewUserServiceWithHasher(userRepo infrastructure.UserRepository, eventBus events.EventBus, db *database.DB, passwordHasher domain.PasswordHasher) UserService {
+	return NewUserServiceWithConfig(userRepo, eventBus, db, UserServiceConfig{PasswordHasher: passwordHasher})
+}
+
+// NewUserServiceWithConfig creates a new user service instance configured
+// by config
+func NewUserServiceWithConfig(userRepo infrastructure.UserRepository, eventBus events.EventBus, db *database.DB, config UserServiceConfig) UserService {
+	passwordHasher := config.PasswordHasher
+	if passwordHasher == nil {
+		passwordHasher = domain.DefaultPasswordHasher()
+	}
+
 	return &userServiceImpl{
-		userRepo: userRepo,
-		eventBus: eventBus,
-		db:       db,
+		userRepo:        userRepo,
+		eventBus:        eventBus,
+		db:              db,
+		passwordHasher:  passwordHasher,
+		sessionRevoker:  config.SessionRevoker,
+		emailNormalizer: config.EmailNormalizer,
 	}
 }
 
@@ -75,7 +126,7 @@ func (s *userServiceImpl) CreateUser(ctx context.Context, cmd *CreateUserCommand
 
 	// Create new user domain object
 	userID := uuid.New().String()
-	user, err := domain.NewUser(userID, cmd.Email, cmd.Password, cmd.FirstName, cmd.LastName)
+	user, err := domain.NewUserWithHasher(s.passwordHasher, userID, s.emailNormalizer.Normalize(cmd.Email), cmd.Password, cmd.FirstName, cmd.LastName)
 	if err != nil {
 		return nil, NewValidationError("user", fmt.Sprintf("failed to create user: %v", err))
 	}
@@ -92,8 +143,8 @@ func (s *userServiceImpl) CreateUser(ctx context.Context, cmd *CreateUserCommand
 
 		// Publish user created event
 		event := domain.NewUserCreatedEvent(user)
-		if err := s.eventBus.Publish(txCtx, event); err != nil {
-			return NewInternalError(fmt.Sprintf("failed to publish user created event: %v", err))
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish event: %v", err))
 		}
 
 		return nil
@@ -146,57 +197,65 @@ func (s *userServiceImpl) UpdateUser(ctx context.Context, cmd *UpdateUserCommand
 	}
 
 	var user *domain.User
-	// Execute in transaction
-	err := database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
-		// Get existing user
-		var err error
-		user, err = s.userRepo.GetByID(txCtx, cmd.ID)
-		if err != nil {
-			if database.IsNotFoundError(err) {
-				return NewUserNotFoundError(cmd.ID)
+	// Execute in transaction, retrying when a concurrent write to the user
+	// conflicts with the update
+	versionChecked := false
+	err := RetryOnConflict(ctx, profileUpdateAttempts, func() error {
+		return database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
+			// Get existing user
+			var err error
+			user, err = s.userRepo.GetByID(txCtx, cmd.ID)
+			if err != nil {
+				if database.IsNotFoundError(err) {
+					return NewUserNotFoundError(cmd.ID)
+				}
+				return NewInternalError(fmt.Sprintf("failed to get user: %v", err))
 			}
-			return NewInternalError(fmt.Sprintf("failed to get user: %v", err))
-		}
 
-		// Check version for optimistic locking
-		if user.Version != cmd.Version {
-			return NewOptimisticLockError(cmd.ID)
-		}
+			// Check version for optimistic locking. Once the client's version
+			// has matched, retries apply the update to the latest version.
+			if !versionChecked {
+				if user.Version != cmd.Version {
+					return NewOptimisticLockError(cmd.ID)
+				}
+				versionChecked = true
+			}
 
-		// Store previous values for event
-		previousFirstName := user.FirstName
-		previousLastName := user.LastName
+			// Store previous values for event
+			previousFirstName := user.FirstName
+			previousLastName := user.LastName
 
-		// Update user profile
-		if err := user.UpdateProfile(cmd.FirstName, cmd.LastName); err != nil {
-			return NewValidationError("profile", fmt.Sprintf("failed to update profile: %v", err))
-		}
+			// Update user profile
+			if err := user.UpdateProfile(cmd.FirstName, cmd.LastName); err != nil {
+				return NewValidationError("profile", fmt.Sprintf("failed to update profile: %v", err))
+			}
 
-		// Save updated user
-		if err := s.userRepo.Update(txCtx, user); err != nil {
-			if database.IsOptimisticLockError(err) {
-				return NewOptimisticLockError(cmd.ID)
+			// Save updated user
+			if err := s.userRepo.Update(txCtx, user); err != nil {
+				if database.IsOptimisticLockError(err) {
+					return NewOptimisticLockError(cmd.ID)
+				}
+				return NewInternalError(fmt.Sprintf("failed to update user: %v", err))
 			}
-			return NewInternalError(fmt.Sprintf("failed to update user: %v", err))
-		}
 
-		// Publish user updated event
-		changes := map[string]interface{}{
-			"first_name": map[string]interface{}{
-				"old": previousFirstName,
-				"new": user.FirstName,
-			},
-			"last_name": map[string]interface{}{
-				"old": previousLastName,
-				"new": user.LastName,
-			},
-		}
-		event := domain.NewUserUpdatedEvent(user, changes)
-		if err := s.eventBus.Publish(txCtx, event); err != nil {
-			return NewInternalError(fmt.Sprintf("failed to publish user updated event: %v", err))
-		}
+			// Publish user updated event
+			changes := map[string]interface{}{
+				"first_name": map[string]interface{}{
+					"old": previousFirstName,
+					"new": user.FirstName,
+				},
+				"last_name": map[string]interface{}{
+					"old": previousLastName,
+					"new": user.LastName,
+				},
+			}
+			event := domain.NewUserUpdatedEvent(user, changes)
+			if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+				return NewInternalError(fmt.Sprintf("failed to publish event: %v", err))
+			}
 
-		return nil
+			return nil
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -242,7 +301,7 @@ func (s *userServiceImpl) UpdateUserEmail(ctx context.Context, cmd *UpdateUserEm
 		previousEmail := user.Email
 
 		// Update user email
-		if err := user.UpdateEmail(cmd.Email); err != nil {
+		if err := user.UpdateEmail(s.emailNormalizer.Normalize(cmd.Email)); err != nil {
 			return NewValidationError("email", fmt.Sprintf("failed to update email: %v", err))
 		}
 
@@ -259,8 +318,8 @@ func (s *userServiceImpl) UpdateUserEmail(ctx context.Context, cmd *UpdateUserEm
 
 		// Publish user email changed event
 		event := domain.NewUserEmailChangedEvent(user, previousEmail)
-		if err := s.eventBus.Publish(txCtx, event); err != nil {
-			return NewInternalError(fmt.Sprintf("failed to publish user email changed event: %v", err))
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish event: %v", err))
 		}
 
 		return nil
@@ -272,7 +331,8 @@ func (s *userServiceImpl) UpdateUserEmail(ctx context.Context, cmd *UpdateUserEm
 	return user, nil
 }
 
-// ChangeUserPassword changes a user's password
+// ChangeUserPassword changes a user's password and revokes the user's
+// sessions in the same transaction
 func (s *userServiceImpl) ChangeUserPassword(ctx context.Context, cmd *ChangeUserPasswordCommand) (*domain.User, error) {
 	if err := cmd.Validate(); err != nil {
 		return nil, err
@@ -297,12 +357,12 @@ func (s *userServiceImpl) ChangeUserPassword(ctx context.Context, cmd *ChangeUse
 		}
 
 		// Verify old password
-		if !user.CheckPassword(cmd.OldPassword) {
+		if !user.CheckPasswordWith(s.passwordHasher, cmd.OldPassword) {
 			return NewInvalidPasswordError()
 		}
 
 		// Set new password
-		if err := user.SetPassword(cmd.NewPassword); err != nil {
+		if err := user.SetPasswordWith(s.passwordHasher, cmd.NewPassword); err != nil {
 			return NewValidationError("password", fmt.Sprintf("failed to set password: %v", err))
 		}
 
@@ -314,13 +374,75 @@ func (s *userServiceImpl) ChangeUserPassword(ctx context.Context, cmd *ChangeUse
 			return NewInternalError(fmt.Sprintf("failed to update user: %v", err))
 		}
 
+		// Log the user out everywhere
+		if err := s.revokeSessions(txCtx, user.ID); err != nil {
+			return err
+		}
+
 		// Publish user updated event (password change)
 		changes := map[string]interface{}{
 			"password_changed": true,
 		}
 		event := domain.NewUserUpdatedEvent(user, changes)
-		if err := s.eventBus.Publish(txCtx, event); err != nil {
-			return NewInternalError(fmt.Sprintf("failed to publish user updated event: %v", err))
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish event: %v", err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ResetUserPassword sets a user's password without checking the old one and
+// revokes the user's sessions in the same transaction. Callers are
+// responsible for verifying the user, e.g. with a reset token.
+func (s *userServiceImpl) ResetUserPassword(ctx context.Context, cmd *ResetUserPasswordCommand) (*domain.User, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+	// Execute in transaction
+	err := database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
+		// Get existing user
+		var err error
+		user, err = s.userRepo.GetByID(txCtx, cmd.ID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return NewUserNotFoundError(cmd.ID)
+			}
+			return NewInternalError(fmt.Sprintf("failed to get user: %v", err))
+		}
+
+		// Set new password
+		if err := user.SetPasswordWith(s.passwordHasher, cmd.NewPassword); err != nil {
+			return NewValidationError("password", fmt.Sprintf("failed to set password: %v", err))
+		}
+
+		// Save updated user
+		if err := s.userRepo.Update(txCtx, user); err != nil {
+			if database.IsOptimisticLockError(err) {
+				return NewOptimisticLockError(cmd.ID)
+			}
+			return NewInternalError(fmt.Sprintf("failed to update user: %v", err))
+		}
+
+		// Log the user out everywhere
+		if err := s.revokeSessions(txCtx, user.ID); err != nil {
+			return err
+		}
+
+		// Publish user updated event (password reset)
+		changes := map[string]interface{}{
+			"password_changed": true,
+		}
+		event := domain.NewUserUpdatedEvent(user, changes)
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish event: %v", err))
 		}
 
 		return nil
@@ -374,8 +496,115 @@ func (s *userServiceImpl) ChangeUserStatus(ctx context.Context, cmd *ChangeUserS
 
 		// Publish user status changed event
 		event := domain.NewUserStatusChangedEvent(user, previousStatus, cmd.ChangedBy, cmd.Reason)
-		if err := s.eventBus.Publish(txCtx, event); err != nil {
-			return NewInternalError(fmt.Sprintf("failed to publish user status changed event: %v", err))
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish event: %v", err))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// LockUser locks a user's account. Unlike ChangeUserStatus it is not
+// version checked, since it is triggered by the system rather than an edit.
+func (s *userServiceImpl) LockUser(ctx context.Context, cmd *LockUserCommand) (*domain.User, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.transitionStatus(ctx, cmd.ID, cmd.ChangedBy, cmd.Reason, (*domain.User).Lock)
+}
+
+// UnlockUser unlocks a locked user's account
+func (s *userServiceImpl) UnlockUser(ctx context.Context, cmd *UnlockUserCommand) (*domain.User, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.transitionStatus(ctx, cmd.ID, cmd.ChangedBy, cmd.Reason, (*domain.User).Unlock)
+}
+
+// RecordLogin records when and from where a user last logged in. Like
+// LockUser it is not version checked, and it is retried when a concurrent
+// write to the user conflicts with it. It publishes no event; the login
+// itself is published by the auth module.
+func (s *userServiceImpl) RecordLogin(ctx context.Context, cmd *RecordLoginCommand) (*domain.User, error) {
+	if err := cmd.Validate(); err != nil {
+		return nil, err
+	}
+
+	var user *domain.User
+	err := RetryOnConflict(ctx, profileUpdateAttempts, func() error {
+		return database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
+			var err error
+			user, err = s.userRepo.GetByID(txCtx, cmd.ID)
+			if err != nil {
+				if database.IsNotFoundError(err) {
+					return NewUserNotFoundError(cmd.ID)
+				}
+				return NewInternalError(fmt.Sprintf("failed to get user: %v", err))
+			}
+
+			if err := user.RecordLogin(cmd.LoginAt, cmd.IPAddress); err != nil {
+				return NewValidationError("ip_address", fmt.Sprintf("failed to record login: %v", err))
+			}
+
+			if err := s.userRepo.Update(txCtx, user); err != nil {
+				if database.IsOptimisticLockError(err) {
+					return NewOptimisticLockError(cmd.ID)
+				}
+				return NewInternalError(fmt.Sprintf("failed to update user: %v", err))
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// transitionStatus applies transition to a user and publishes a status
+// changed event after commit
+func (s *userServiceImpl) transitionStatus(ctx context.Context, userID, changedBy, reason string, transition func(*domain.User) error) (*domain.User, error) {
+	var user *domain.User
+	// Execute in transaction
+	err := database.ExecuteInTransaction(ctx, s.db, func(txCtx context.Context) error {
+		// Get existing user
+		var err error
+		user, err = s.userRepo.GetByID(txCtx, userID)
+		if err != nil {
+			if database.IsNotFoundError(err) {
+				return NewUserNotFoundError(userID)
+			}
+			return NewInternalError(fmt.Sprintf("failed to get user: %v", err))
+		}
+
+		// Store previous status for event
+		previousStatus := user.Status
+
+		if err := transition(user); err != nil {
+			return NewValidationError("status", fmt.Sprintf("failed to change status: %v", err))
+		}
+
+		// Save updated user
+		if err := s.userRepo.Update(txCtx, user); err != nil {
+			if database.IsOptimisticLockError(err) {
+				return NewOptimisticLockError(userID)
+			}
+			return NewInternalError(fmt.Sprintf("failed to update user: %v", err))
+		}
+
+		// Publish user status changed event
+		event := domain.NewUserStatusChangedEvent(user, previousStatus, changedBy, reason)
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish event: %v", err))
 		}
 
 		return nil
@@ -414,8 +643,8 @@ func (s *userServiceImpl) DeleteUser(ctx context.Context, cmd *DeleteUserCommand
 
 		// Publish user deleted event
 		event := domain.NewUserDeletedEvent(user, cmd.DeletedBy, cmd.Reason)
-		if err := s.eventBus.Publish(txCtx, event); err != nil {
-			return NewInternalError(fmt.Sprintf("failed to publish user deleted event: %v", err))
+		if err := events.PublishAfterCommit(txCtx, s.eventBus, event); err != nil {
+			return NewInternalError(fmt.Sprintf("failed to publish event: %v", err))
 		}
 
 		return nil
@@ -441,6 +670,9 @@ func (s *userServiceImpl) ListUsers(ctx context.Context, query *ListUsersQuery)
 		LastName:      query.LastName,
 		CreatedAfter:  query.CreatedAfter,
 		CreatedBefore: query.CreatedBefore,
+		Search:        query.Search,
+		SortBy:        query.SortBy,
+		SortDesc:      query.SortDesc,
 	}
 
 	// Get users
@@ -457,3 +689,15 @@ func (s *userServiceImpl) ListUsers(ctx context.Context, query *ListUsersQuery)
 
 	return users, total, nil
 }
+
+// revokeSessions revokes all of a user's sessions, if the service has a
+// session revoker
+func (s *userServiceImpl) revokeSessions(ctx context.Context, userID string) error {
+	if s.sessionRevoker == nil {
+		return nil
+	}
+	if _, err := s.sessionRevoker.RevokeAllSessions(ctx, userID); err != nil {
+		return NewInternalError(fmt.Sprintf("failed to revoke sessions: %v", err))
+	}
+	return nil
+}
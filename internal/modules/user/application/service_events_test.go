@@ -0,0 +1,393 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newEventTestService builds the real user service over mocks and a stub
+// database, which the service opens a transaction on around each write
+func newEventTestService(t *testing.T) (UserService, *MockUserRepositorySimple, *MockEventBusSimple) {
+	t.Helper()
+
+	repo := &MockUserRepositorySimple{}
+	eventBus := &MockEventBusSimple{}
+	return NewUserService(repo, eventBus, database.NewStubDB().DB), repo, eventBus
+}
+
+// capturePublished records published events and asserts each one is
+// published outside the transaction, i.e. after commit
+func capturePublished(t *testing.T, eventBus *MockEventBusSimple) *[]events.DomainEvent {
+	t.Helper()
+
+	published := &[]events.DomainEvent{}
+	eventBus.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		assert.Nil(t, database.GetTxFromContext(ctx), "event should be published after commit")
+		*published = append(*published, args.Get(1).(events.DomainEvent))
+	}).Return(nil)
+	return published
+}
+
+func newEventTestUser(t *testing.T) *domain.User {
+	t.Helper()
+
+	user, err := domain.NewUser("user-123", "events@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+	return user
+}
+
+func TestUserService_CreateUser_PublishesEvent(t *testing.T) {
+	service, repo, eventBus := newEventTestService(t)
+	published := capturePublished(t, eventBus)
+
+	repo.On("ExistsByEmail", mock.Anything, "events@example.com").Return(false, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+
+	user, err := service.CreateUser(context.Background(), &CreateUserCommand{
+		Email:     "events@example.com",
+		Password:  "Password123",
+		FirstName: "John",
+		LastName:  "Doe",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *published, 1)
+	event, ok := (*published)[0].(*domain.UserCreatedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "user.created", event.EventType())
+	assert.Equal(t, user.ID, event.UserID)
+	assert.Equal(t, "events@example.com", event.Email)
+	assert.Equal(t, "John", event.FirstName)
+	assert.Equal(t, "Doe", event.LastName)
+}
+
+func TestUserService_EmailNormalizer(t *testing.T) {
+	repo := &MockUserRepositorySimple{}
+	eventBus := &MockEventBusSimple{}
+	capturePublished(t, eventBus)
+	service := NewUserServiceWithConfig(repo, eventBus, database.NewStubDB().DB, UserServiceConfig{
+		EmailNormalizer: domain.NewEmailNormalizer(true),
+	})
+
+	// Users are stored by the folded address
+	repo.On("ExistsByEmail", mock.Anything, "J.Doe+signup@Gmail.com").Return(false, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+
+	user, err := service.CreateUser(context.Background(), &CreateUserCommand{
+		Email:     "J.Doe+signup@Gmail.com",
+		Password:  "Password123",
+		FirstName: "John",
+		LastName:  "Doe",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe@gmail.com", user.Email)
+
+	repo.On("ExistsByEmail", mock.Anything, "John.Doe@googlemail.com").Return(false, nil)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(nil)
+
+	user, err = service.UpdateUserEmail(context.Background(), &UpdateUserEmailCommand{
+		ID:      user.ID,
+		Email:   "John.Doe@googlemail.com",
+		Version: user.Version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "johndoe@gmail.com", user.Email)
+}
+
+func TestUserService_UpdateUser_PublishesEvent(t *testing.T) {
+	service, repo, eventBus := newEventTestService(t)
+	published := capturePublished(t, eventBus)
+
+	user := newEventTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(nil)
+
+	_, err := service.UpdateUser(context.Background(), &UpdateUserCommand{
+		ID:        user.ID,
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Version:   user.Version,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *published, 1)
+	event, ok := (*published)[0].(*domain.UserUpdatedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "user.updated", event.EventType())
+	assert.Equal(t, user.ID, event.UserID)
+	assert.Equal(t, map[string]interface{}{"old": "John", "new": "Jane"}, event.Changes["first_name"])
+}
+
+func TestUserService_ChangeUserStatus_PublishesEvent(t *testing.T) {
+	service, repo, eventBus := newEventTestService(t)
+	published := capturePublished(t, eventBus)
+
+	user := newEventTestUser(t)
+	previousStatus := user.Status
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(nil)
+
+	_, err := service.ChangeUserStatus(context.Background(), &ChangeUserStatusCommand{
+		ID:        user.ID,
+		Status:    domain.UserStatusSuspended,
+		ChangedBy: "admin",
+		Reason:    "abuse",
+		Version:   user.Version,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *published, 1)
+	event, ok := (*published)[0].(*domain.UserStatusChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "user.status_changed", event.EventType())
+	assert.Equal(t, user.ID, event.UserID)
+	assert.Equal(t, string(previousStatus), event.PreviousStatus)
+	assert.Equal(t, string(domain.UserStatusSuspended), event.NewStatus)
+	assert.Equal(t, "admin", event.ChangedBy)
+}
+
+func TestUserService_LockUser_PublishesEvent(t *testing.T) {
+	service, repo, eventBus := newEventTestService(t)
+	published := capturePublished(t, eventBus)
+
+	user := newEventTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(nil)
+
+	locked, err := service.LockUser(context.Background(), &LockUserCommand{
+		ID:        user.ID,
+		ChangedBy: "system",
+		Reason:    "too many failed login attempts",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserStatusLocked, locked.Status)
+
+	require.Len(t, *published, 1)
+	event, ok := (*published)[0].(*domain.UserStatusChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "user.status_changed", event.EventType())
+	assert.Equal(t, string(domain.UserStatusActive), event.PreviousStatus)
+	assert.Equal(t, string(domain.UserStatusLocked), event.NewStatus)
+	assert.Equal(t, "system", event.ChangedBy)
+	assert.Equal(t, "too many failed login attempts", event.Reason)
+}
+
+func TestUserService_UnlockUser_PublishesEvent(t *testing.T) {
+	service, repo, eventBus := newEventTestService(t)
+	published := capturePublished(t, eventBus)
+
+	user := newEventTestUser(t)
+	require.NoError(t, user.Lock())
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(nil)
+
+	unlocked, err := service.UnlockUser(context.Background(), &UnlockUserCommand{
+		ID:        user.ID,
+		ChangedBy: "admin",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.UserStatusActive, unlocked.Status)
+
+	require.Len(t, *published, 1)
+	event, ok := (*published)[0].(*domain.UserStatusChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, string(domain.UserStatusLocked), event.PreviousStatus)
+	assert.Equal(t, string(domain.UserStatusActive), event.NewStatus)
+}
+
+func TestUserService_UnlockUser_NotLocked(t *testing.T) {
+	service, repo, eventBus := newEventTestService(t)
+
+	user := newEventTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	_, err := service.UnlockUser(context.Background(), &UnlockUserCommand{ID: user.ID})
+	require.Error(t, err)
+	assert.True(t, IsValidationError(err))
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestUserService_RecordLogin(t *testing.T) {
+	service, repo, eventBus := newEventTestService(t)
+
+	user := newEventTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(nil)
+
+	loginAt := time.Now().UTC()
+	updated, err := service.RecordLogin(context.Background(), &RecordLoginCommand{
+		ID:        user.ID,
+		IPAddress: "203.0.113.7",
+		LoginAt:   loginAt,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, updated.LastLoginAt)
+	assert.True(t, loginAt.Equal(*updated.LastLoginAt))
+	assert.Equal(t, "203.0.113.7", updated.LastLoginIP)
+	repo.AssertExpectations(t)
+
+	// The auth module publishes the login, so no user event is published
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestUserService_DeleteUser_PublishesEvent(t *testing.T) {
+	service, repo, eventBus := newEventTestService(t)
+	published := capturePublished(t, eventBus)
+
+	user := newEventTestUser(t)
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Delete", mock.Anything, user.ID).Return(nil)
+
+	err := service.DeleteUser(context.Background(), &DeleteUserCommand{
+		ID:        user.ID,
+		DeletedBy: "admin",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, *published, 1)
+	event, ok := (*published)[0].(*domain.UserDeletedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "user.deleted", event.EventType())
+	assert.Equal(t, user.ID, event.UserID)
+	assert.Equal(t, user.Email, event.Email)
+}
+
+func TestUserService_FailedWrite_PublishesNoEvent(t *testing.T) {
+	service, repo, eventBus := newEventTestService(t)
+
+	user := newEventTestUser(t)
+	repo.On("ExistsByEmail", mock.Anything, "events@example.com").Return(false, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(errors.New("insert failed"))
+	repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+	repo.On("Update", mock.Anything, user).Return(errors.New("update failed"))
+	repo.On("Delete", mock.Anything, user.ID).Return(errors.New("delete failed"))
+
+	ctx := context.Background()
+	_, err := service.CreateUser(ctx, &CreateUserCommand{
+		Email:     "events@example.com",
+		Password:  "Password123",
+		FirstName: "John",
+		LastName:  "Doe",
+	})
+	assert.Error(t, err)
+
+	_, err = service.UpdateUser(ctx, &UpdateUserCommand{
+		ID:        user.ID,
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Version:   user.Version,
+	})
+	assert.Error(t, err)
+
+	err = service.DeleteUser(ctx, &DeleteUserCommand{ID: user.ID})
+	assert.Error(t, err)
+
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestUserService_OutboxBus_WritesEventInTransaction(t *testing.T) {
+	db := database.NewStubDB()
+	repo := &MockUserRepositorySimple{}
+	inner := &MockEventBusSimple{}
+	service := NewUserService(repo, events.NewOutboxEventBus(inner, db.DB, events.DefaultOutboxConfig()), db.DB)
+
+	repo.On("ExistsByEmail", mock.Anything, "events@example.com").Return(false, nil)
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil)
+
+	_, err := service.CreateUser(context.Background(), &CreateUserCommand{
+		Email:     "events@example.com",
+		Password:  "Password123",
+		FirstName: "John",
+		LastName:  "Doe",
+	})
+	require.NoError(t, err)
+
+	// The event is written to the outbox in the transaction, for the relay
+	// to publish, rather than published once it has committed
+	statements := db.Statements()
+	require.Len(t, statements, 2)
+	assert.Contains(t, statements[0], "INSERT INTO event_outbox")
+	assert.Equal(t, "COMMIT", statements[1])
+	inner.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+// failingSessionRevoker fails to revoke sessions
+type failingSessionRevoker struct{}
+
+func (failingSessionRevoker) RevokeAllSessions(ctx context.Context, userID string) (int, error) {
+	return 0, errors.New("sessions unavailable")
+}
+
+func TestUserService_PasswordChange_RevokesSessions(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(UserService, *domain.User) error
+	}{
+		{"change", func(service UserService, user *domain.User) error {
+			_, err := service.ChangeUserPassword(context.Background(), &ChangeUserPasswordCommand{
+				ID:          user.ID,
+				OldPassword: "Password123",
+				NewPassword: "NewPassword456",
+				Version:     user.Version,
+			})
+			return err
+		}},
+		{"reset", func(service UserService, user *domain.User) error {
+			_, err := service.ResetUserPassword(context.Background(), &ResetUserPasswordCommand{
+				ID:          user.ID,
+				NewPassword: "NewPassword456",
+			})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockUserRepositorySimple{}
+			eventBus := &MockEventBusSimple{}
+			published := capturePublished(t, eventBus)
+			revoker := &fakeSessionRevoker{sessions: map[string]int{"user-123": 2, "other-user": 1}}
+			service := NewUserServiceWithConfig(repo, eventBus, database.NewStubDB().DB, UserServiceConfig{SessionRevoker: revoker})
+
+			user := newEventTestUser(t)
+			repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+			repo.On("Update", mock.Anything, user).Return(nil)
+
+			require.NoError(t, tt.change(service, user))
+
+			// Only the user's sessions are revoked
+			assert.Equal(t, map[string]int{"other-user": 1}, revoker.sessions)
+			assert.NotNil(t, user.PasswordChangedAt)
+			assert.Len(t, *published, 1)
+		})
+	}
+
+	t.Run("revocation failure", func(t *testing.T) {
+		repo := &MockUserRepositorySimple{}
+		eventBus := &MockEventBusSimple{}
+		service := NewUserServiceWithConfig(repo, eventBus, database.NewStubDB().DB, UserServiceConfig{SessionRevoker: failingSessionRevoker{}})
+
+		user := newEventTestUser(t)
+		repo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+		repo.On("Update", mock.Anything, user).Return(nil)
+
+		// The password change is rolled back and nothing is published
+		err := tests[0].change(service, user)
+		require.Error(t, err)
+		assert.Equal(t, ErrCodeInternal, err.(*ApplicationError).Code)
+		eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+}
@@ -25,6 +25,11 @@ func (m *MockUserRepositorySimple) Create(ctx context.Context, user *domain.User
 	return args.Error(0)
 }
 
+func (m *MockUserRepositorySimple) CreateBatch(ctx context.Context, users []*domain.User) error {
+	args := m.Called(ctx, users)
+	return args.Error(0)
+}
+
 func (m *MockUserRepositorySimple) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -59,6 +64,14 @@ func (m *MockUserRepositorySimple) List(ctx context.Context, filter infrastructu
 	return args.Get(0).([]*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepositorySimple) ListByCursor(ctx context.Context, filter infrastructure.UserFilter, cursor string, limit int) ([]*domain.User, string, error) {
+	args := m.Called(ctx, filter, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).([]*domain.User), args.String(1), args.Error(2)
+}
+
 func (m *MockUserRepositorySimple) Count(ctx context.Context, filter infrastructure.UserFilter) (int64, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).(int64), args.Error(1)
@@ -224,10 +237,26 @@ func (s *testUserService) ChangeUserPassword(ctx context.Context, cmd *ChangeUse
 	return nil, NewInternalError("not implemented in test service")
 }
 
+func (s *testUserService) ResetUserPassword(ctx context.Context, cmd *ResetUserPasswordCommand) (*domain.User, error) {
+	return nil, NewInternalError("not implemented in test service")
+}
+
 func (s *testUserService) ChangeUserStatus(ctx context.Context, cmd *ChangeUserStatusCommand) (*domain.User, error) {
 	return nil, NewInternalError("not implemented in test service")
 }
 
+func (s *testUserService) LockUser(ctx context.Context, cmd *LockUserCommand) (*domain.User, error) {
+	return nil, NewInternalError("not implemented in test service")
+}
+
+func (s *testUserService) UnlockUser(ctx context.Context, cmd *UnlockUserCommand) (*domain.User, error) {
+	return nil, NewInternalError("not implemented in test service")
+}
+
+func (s *testUserService) RecordLogin(ctx context.Context, cmd *RecordLoginCommand) (*domain.User, error) {
+	return nil, NewInternalError("not implemented in test service")
+}
+
 func (s *testUserService) DeleteUser(ctx context.Context, cmd *DeleteUserCommand) error {
 	if err := cmd.Validate(); err != nil {
 		return err
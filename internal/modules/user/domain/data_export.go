@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DataExportStatus represents the progress of a data export
+type DataExportStatus string
+
+const (
+	DataExportStatusPending DataExportStatus = "pending"
+	DataExportStatusReady   DataExportStatus = "ready"
+	DataExportStatusFailed  DataExportStatus = "failed"
+)
+
+// DataExport is a request for a copy of a user's data, assembled in the
+// background into a bundle stored under BlobKey
+type DataExport struct {
+	ID          string           `db:"id" json:"id"`
+	UserID      string           `db:"user_id" json:"user_id"`
+	Status      DataExportStatus `db:"status" json:"status"`
+	BlobKey     string           `db:"blob_key" json:"-"`
+	Error       string           `db:"error" json:"error,omitempty"`
+	RequestedAt time.Time        `db:"requested_at" json:"requested_at"`
+	CompletedAt *time.Time       `db:"completed_at" json:"completed_at,omitempty"`
+}
+
+// NewDataExport creates a pending data export for a user
+func NewDataExport(id, userID string) *DataExport {
+	return &DataExport{
+		ID:          id,
+		UserID:      userID,
+		Status:      DataExportStatusPending,
+		RequestedAt: time.Now().UTC(),
+	}
+}
+
+// IsPending checks if the export is still being assembled
+func (e *DataExport) IsPending() bool {
+	return e.Status == DataExportStatusPending
+}
+
+// IsReady checks if the export bundle can be downloaded
+func (e *DataExport) IsReady() bool {
+	return e.Status == DataExportStatusReady
+}
+
+// MarkReady records that the bundle was stored under blobKey
+func (e *DataExport) MarkReady(blobKey string) error {
+	if !e.IsPending() {
+		return errors.New("only pending data exports can be completed")
+	}
+	if blobKey == "" {
+		return errors.New("blob key cannot be empty")
+	}
+
+	now := time.Now().UTC()
+	e.Status = DataExportStatusReady
+	e.BlobKey = blobKey
+	e.CompletedAt = &now
+	return nil
+}
+
+// MarkFailed records that the bundle could not be assembled
+func (e *DataExport) MarkFailed(reason string) error {
+	if !e.IsPending() {
+		return errors.New("only pending data exports can fail")
+	}
+
+	now := time.Now().UTC()
+	e.Status = DataExportStatusFailed
+	e.Error = reason
+	e.CompletedAt = &now
+	return nil
+}
+
+// DataExportRepository defines the interface for data export data access
+type DataExportRepository interface {
+	Create(ctx context.Context, export *DataExport) error
+	GetByID(ctx context.Context, id string) (*DataExport, error)
+	Update(ctx context.Context, export *DataExport) error
+}
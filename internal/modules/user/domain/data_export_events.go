@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DataExportRequestedEvent represents a request for a copy of a user's data.
+// Handlers assemble the export bundle in the background.
+type DataExportRequestedEvent struct {
+	BaseEvent
+	ExportID    string    `json:"export_id"`
+	UserID      string    `json:"user_id"`
+	Email       string    `json:"email"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// NewDataExportRequestedEvent creates a new DataExportRequestedEvent
+func NewDataExportRequestedEvent(user *User, export *DataExport) *DataExportRequestedEvent {
+	return &DataExportRequestedEvent{
+		BaseEvent: BaseEvent{
+			ID:           generateEventID(),
+			Type:         "user.export_requested",
+			AggregateId:  user.ID,
+			AggregateTyp: "user",
+			OccurredOn:   time.Now().UTC(),
+			EventVersion: 1,
+		},
+		ExportID:    export.ID,
+		UserID:      user.ID,
+		Email:       user.Email,
+		RequestedAt: export.RequestedAt,
+	}
+}
+
+// EventData returns the event data
+func (e *DataExportRequestedEvent) EventData() interface{} {
+	return map[string]interface{}{
+		"export_id":    e.ExportID,
+		"user_id":      e.UserID,
+		"email":        e.Email,
+		"requested_at": e.RequestedAt,
+	}
+}
+
+// ToJSON converts the event to JSON
+func (e *DataExportRequestedEvent) ToJSON() ([]byte, error) {
+	return json.Marshal(e)
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataExport_StatusTransitions(t *testing.T) {
+	t.Run("pending to ready", func(t *testing.T) {
+		export := NewDataExport("export-1", "user-123")
+		assert.True(t, export.IsPending())
+		assert.Nil(t, export.CompletedAt)
+
+		assert.Error(t, export.MarkReady(""), "blob key is required")
+		require.NoError(t, export.MarkReady("exports/user-123/export-1.json"))
+
+		assert.True(t, export.IsReady())
+		assert.Equal(t, "exports/user-123/export-1.json", export.BlobKey)
+		assert.NotNil(t, export.CompletedAt)
+
+		assert.Error(t, export.MarkReady("exports/other.json"), "ready exports cannot be completed again")
+		assert.Error(t, export.MarkFailed("too late"), "ready exports cannot fail")
+	})
+
+	t.Run("pending to failed", func(t *testing.T) {
+		export := NewDataExport("export-1", "user-123")
+		require.NoError(t, export.MarkFailed("storage unavailable"))
+
+		assert.Equal(t, DataExportStatusFailed, export.Status)
+		assert.Equal(t, "storage unavailable", export.Error)
+		assert.NotNil(t, export.CompletedAt)
+		assert.Error(t, export.MarkReady("exports/user-123/export-1.json"), "failed exports cannot be completed")
+	})
+}
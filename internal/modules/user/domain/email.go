@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"strings"
+)
+
+// EmailNormalizer turns email addresses into the canonical form users are
+// stored and looked up by, so two addresses of the same mailbox normalize to
+// the same string and duplicate detection and lookups agree
+type EmailNormalizer struct {
+	foldGmailAliases bool
+}
+
+// NewEmailNormalizer creates an email normalizer. With foldGmailAliases,
+// Gmail aliases are folded, so j.doe+news@gmail.com and jdoe@googlemail.com
+// are the same account as jdoe@gmail.com.
+func NewEmailNormalizer(foldGmailAliases bool) EmailNormalizer {
+	return EmailNormalizer{foldGmailAliases: foldGmailAliases}
+}
+
+// FoldsGmailAliases reports whether the normalizer folds Gmail aliases
+func (n EmailNormalizer) FoldsGmailAliases() bool {
+	return n.foldGmailAliases
+}
+
+// Normalize returns the canonical form of email: trimmed and lowercased,
+// with Gmail aliases folded when enabled
+func (n EmailNormalizer) Normalize(email string) string {
+	email = NormalizeEmail(email)
+	if !n.foldGmailAliases {
+		return email
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], email[at+1:]
+	if domain != "gmail.com" && domain != "googlemail.com" {
+		return email
+	}
+
+	// Gmail ignores dots and anything after a plus in the local part
+	local, _, _ = strings.Cut(local, "+")
+	local = strings.ReplaceAll(local, ".", "")
+	if local == "" {
+		return email
+	}
+	return local + "@gmail.com"
+}
+
+// NormalizeEmail returns email trimmed and lowercased, the canonical form
+// of every address. Use an EmailNormalizer to also fold Gmail aliases.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
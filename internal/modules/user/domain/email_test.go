@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailNormalizer_Normalize(t *testing.T) {
+	tests := []struct {
+		name         string
+		email        string
+		want         string
+		gmailAliases bool
+	}{
+		{"lowercases", "John.Doe@Example.COM", "john.doe@example.com", false},
+		{"trims", "  john@example.com\t", "john@example.com", false},
+		{"keeps gmail aliases by default", "J.Doe+news@Gmail.com", "j.doe+news@gmail.com", false},
+		{"folds gmail dots and plus tags", "J.Doe+news@Gmail.com", "jdoe@gmail.com", true},
+		{"folds googlemail", "jdoe@googlemail.com", "jdoe@gmail.com", true},
+		{"leaves other domains", "j.doe+news@example.com", "j.doe+news@example.com", true},
+		{"leaves addresses without a local part", "+news@gmail.com", "+news@gmail.com", true},
+		{"leaves malformed addresses", " Not-An-Email ", "not-an-email", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalizer := NewEmailNormalizer(tt.gmailAliases)
+
+			assert.Equal(t, tt.gmailAliases, normalizer.FoldsGmailAliases())
+			assert.Equal(t, tt.want, normalizer.Normalize(tt.email))
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "j.doe+news@gmail.com", NormalizeEmail(" J.Doe+news@Gmail.com "))
+	assert.Equal(t, EmailNormalizer{}.Normalize("John@Example.com"), NormalizeEmail("John@Example.com"))
+}
+
+func TestNewUser_NormalizesEmail(t *testing.T) {
+	user, err := NewUser("user-123", " J.Doe+signup@GMAIL.com ", "Password123", "John", "Doe")
+	require.NoError(t, err)
+	assert.Equal(t, "j.doe+signup@gmail.com", user.Email)
+
+	require.NoError(t, user.UpdateEmail("John.Doe@Example.com"))
+	assert.Equal(t, "john.doe@example.com", user.Email)
+}
@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password hashing algorithms
+const (
+	PasswordHashBcrypt   = "bcrypt"
+	PasswordHashArgon2id = "argon2id"
+)
+
+// ErrUnknownPasswordHash indicates a stored hash wasn't produced by a
+// supported algorithm
+var ErrUnknownPasswordHash = errors.New("unrecognized password hash format")
+
+// PasswordHasher hashes passwords and verifies them against stored hashes.
+// Hashes are self-describing: each starts with a prefix naming the algorithm
+// and parameters that produced it.
+type PasswordHasher interface {
+	// Hash returns the hash to store for password
+	Hash(password string) (string, error)
+
+	// Verify reports whether password matches hash. A mismatch is not an
+	// error; errors indicate a malformed or unsupported hash.
+	Verify(password, hash string) (bool, error)
+}
+
+// PasswordHashAlgorithm returns the algorithm that produced hash, or "" if
+// it isn't recognized
+func PasswordHashAlgorithm(hash string) string {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return PasswordHashArgon2id
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return PasswordHashBcrypt
+	default:
+		return ""
+	}
+}
+
+// BcryptHasher hashes passwords with bcrypt
+type BcryptHasher struct {
+	Cost int
+}
+
+// NewBcryptHasher creates a bcrypt hasher with the given cost
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{Cost: cost}
+}
+
+// Hash hashes password with bcrypt
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hashed), nil
+}
+
+// Verify checks password against a bcrypt hash
+func (h *BcryptHasher) Verify(password, hash string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to verify password: %w", err)
+	}
+	return true, nil
+}
+
+// Argon2idHasher hashes passwords with Argon2id. Hashes use the PHC string
+// format, e.g. $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>, and are verified
+// with the parameters they record, so changing the parameters doesn't
+// invalidate existing hashes.
+type Argon2idHasher struct {
+	// Time is the number of passes over memory
+	Time uint32
+	// Memory is the memory used, in KiB
+	Memory uint32
+	// Threads is the degree of parallelism
+	Threads uint8
+	// KeyLength is the length of the derived key, in bytes
+	KeyLength uint32
+	// SaltLength is the length of the random salt, in bytes
+	SaltLength int
+}
+
+// NewArgon2idHasher creates an Argon2id hasher with the parameters
+// recommended by RFC 9106 for memory-constrained environments
+func NewArgon2idHasher() *Argon2idHasher {
+	return &Argon2idHasher{
+		Time:       1,
+		Memory:     64 * 1024,
+		Threads:    4,
+		KeyLength:  32,
+		SaltLength: 16,
+	}
+}
+
+// Hash hashes password with Argon2id and a random salt
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.Time, h.Memory, h.Threads, h.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.Memory, h.Time, h.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify checks password against an Argon2id hash
+func (h *Argon2idHasher) Verify(password, hash string) (bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return false, ErrUnknownPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return false, fmt.Errorf("invalid argon2id hash version: %w", err)
+	}
+	if version != argon2.Version {
+		return false, fmt.Errorf("unsupported argon2id version: %d", version)
+	}
+
+	var memory, iterations uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &iterations, &threads); err != nil {
+		return false, fmt.Errorf("invalid argon2id hash parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, fmt.Errorf("invalid argon2id hash salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, fmt.Errorf("invalid argon2id hash key: %w", err)
+	}
+
+	computed := argon2.IDKey([]byte(password), salt, iterations, memory, threads, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, computed) == 1, nil
+}
+
+// multiAlgorithmHasher hashes new passwords with one algorithm and verifies
+// stored hashes with the algorithm that produced them, so hashes from
+// different algorithms can coexist while passwords are migrated
+type multiAlgorithmHasher struct {
+	algorithm string
+	hashers   map[string]PasswordHasher
+}
+
+// NewPasswordHasher creates a hasher that hashes new passwords with algorithm
+// and verifies both bcrypt and Argon2id hashes
+func NewPasswordHasher(algorithm string) (PasswordHasher, error) {
+	hashers := map[string]PasswordHasher{
+		PasswordHashBcrypt:   NewBcryptHasher(bcrypt.DefaultCost),
+		PasswordHashArgon2id: NewArgon2idHasher(),
+	}
+	if _, ok := hashers[algorithm]; !ok {
+		return nil, fmt.Errorf("unsupported password hash algorithm: %s", algorithm)
+	}
+
+	return &multiAlgorithmHasher{
+		algorithm: algorithm,
+		hashers:   hashers,
+	}, nil
+}
+
+// DefaultPasswordHasher returns the hasher used when none is injected. It
+// hashes with bcrypt and verifies both bcrypt and Argon2id hashes.
+func DefaultPasswordHasher() PasswordHasher {
+	return defaultPasswordHasher
+}
+
+var defaultPasswordHasher, _ = NewPasswordHasher(PasswordHashBcrypt)
+
+// Hash hashes password with the configured algorithm
+func (h *multiAlgorithmHasher) Hash(password string) (string, error) {
+	return h.hashers[h.algorithm].Hash(password)
+}
+
+// Verify checks password using the algorithm named by the hash's prefix
+func (h *multiAlgorithmHasher) Verify(password, hash string) (bool, error) {
+	hasher, ok := h.hashers[PasswordHashAlgorithm(hash)]
+	if !ok {
+		return false, ErrUnknownPasswordHash
+	}
+	return hasher.Verify(password, hash)
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcryptHasher_ExistingHashesStillVerify(t *testing.T) {
+	// Hashes stored before the hasher abstraction were produced directly by bcrypt
+	legacy, err := bcrypt.GenerateFromPassword([]byte("Password123"), bcrypt.DefaultCost)
+	require.NoError(t, err)
+
+	for _, algorithm := range []string{PasswordHashBcrypt, PasswordHashArgon2id} {
+		t.Run(algorithm, func(t *testing.T) {
+			hasher, err := NewPasswordHasher(algorithm)
+			require.NoError(t, err)
+
+			ok, err := hasher.Verify("Password123", string(legacy))
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = hasher.Verify("WrongPassword123", string(legacy))
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestArgon2idHasher_RoundTrip(t *testing.T) {
+	hasher := NewArgon2idHasher()
+
+	hash, err := hasher.Hash("Password123")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=1,p=4$"))
+
+	ok, err := hasher.Verify("Password123", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hasher.Verify("WrongPassword123", hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Salts are random, so the same password hashes differently
+	other, err := hasher.Hash("Password123")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+}
+
+func TestArgon2idHasher_VerifiesWithStoredParameters(t *testing.T) {
+	hasher := NewArgon2idHasher()
+	hasher.Memory = 16 * 1024
+	hasher.Time = 2
+	hash, err := hasher.Hash("Password123")
+	require.NoError(t, err)
+
+	// Changing the parameters doesn't invalidate existing hashes
+	ok, err := NewArgon2idHasher().Verify("Password123", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestArgon2idHasher_MalformedHash(t *testing.T) {
+	hasher := NewArgon2idHasher()
+
+	for _, hash := range []string{
+		"",
+		"$argon2id$v=19$m=65536,t=1,p=4$salt",
+		"$argon2id$v=18$m=65536,t=1,p=4$c2FsdA$a2V5",
+		"$argon2id$v=19$bad$c2FsdA$a2V5",
+		"$argon2id$v=19$m=65536,t=1,p=4$!!!$a2V5",
+	} {
+		ok, err := hasher.Verify("Password123", hash)
+		assert.Error(t, err, hash)
+		assert.False(t, ok)
+	}
+}
+
+func TestPasswordHasher_SelectsAlgorithmByPrefix(t *testing.T) {
+	bcryptHash, err := NewBcryptHasher(bcrypt.MinCost).Hash("Password123")
+	require.NoError(t, err)
+	argonHash, err := NewArgon2idHasher().Hash("Password123")
+	require.NoError(t, err)
+
+	assert.Equal(t, PasswordHashBcrypt, PasswordHashAlgorithm(bcryptHash))
+	assert.Equal(t, PasswordHashArgon2id, PasswordHashAlgorithm(argonHash))
+	assert.Equal(t, "", PasswordHashAlgorithm("plaintext"))
+
+	hasher, err := NewPasswordHasher(PasswordHashArgon2id)
+	require.NoError(t, err)
+
+	// New hashes use the configured algorithm
+	hash, err := hasher.Hash("Password123")
+	require.NoError(t, err)
+	assert.Equal(t, PasswordHashArgon2id, PasswordHashAlgorithm(hash))
+
+	// Stored hashes of either algorithm verify
+	for _, stored := range []string{bcryptHash, argonHash} {
+		ok, err := hasher.Verify("Password123", stored)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	_, err = hasher.Verify("Password123", "plaintext")
+	assert.ErrorIs(t, err, ErrUnknownPasswordHash)
+}
+
+func TestNewPasswordHasher_UnsupportedAlgorithm(t *testing.T) {
+	_, err := NewPasswordHasher("md5")
+	assert.Error(t, err)
+}
+
+func TestUser_PasswordWithHasher(t *testing.T) {
+	hasher, err := NewPasswordHasher(PasswordHashArgon2id)
+	require.NoError(t, err)
+
+	user, err := NewUserWithHasher(hasher, "user-123", "test@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+	assert.Equal(t, PasswordHashArgon2id, PasswordHashAlgorithm(user.Password))
+
+	// The default hasher verifies hashes from any supported algorithm
+	assert.True(t, user.CheckPassword("Password123"))
+	assert.True(t, user.CheckPasswordWith(hasher, "Password123"))
+	assert.False(t, user.CheckPasswordWith(hasher, "WrongPassword123"))
+
+	require.NoError(t, user.SetPasswordWith(NewBcryptHasher(bcrypt.MinCost), "NewPassword456"))
+	assert.Equal(t, PasswordHashBcrypt, PasswordHashAlgorithm(user.Password))
+	assert.True(t, user.CheckPasswordWith(hasher, "NewPassword456"))
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+)
+
+// Actions on users checked by a Policy
+const (
+	ActionUserEdit   = "user.edit"
+	ActionUserDelete = "user.delete"
+)
+
+// Scopes a scoped credential, e.g. an API key, needs to use the user routes
+const (
+	ScopeUsersRead  = "users:read"
+	ScopeUsersWrite = "users:write"
+)
+
+// Policy decides whether a user may perform an action on a resource
+type Policy interface {
+	// Can reports whether subject may perform action on resource. An error
+	// means the decision could not be made, e.g. for an unexpected resource.
+	Can(ctx context.Context, subject *User, action string, resource interface{}) (bool, error)
+}
+
+// defaultPolicy lets users act on their own account and admins act on any
+type defaultPolicy struct{}
+
+// NewDefaultPolicy creates the default policy: admins may perform every
+// action, and other users may edit and delete only themselves
+func NewDefaultPolicy() Policy {
+	return defaultPolicy{}
+}
+
+// Can implements Policy
+func (defaultPolicy) Can(ctx context.Context, subject *User, action string, resource interface{}) (bool, error) {
+	if subject == nil {
+		return false, nil
+	}
+	if subject.IsAdmin() {
+		return true, nil
+	}
+
+	switch action {
+	case ActionUserEdit, ActionUserDelete:
+		target, ok := resource.(*User)
+		if !ok || target == nil {
+			return false, fmt.Errorf("action %s requires a user resource, got %T", action, resource)
+		}
+		return target.ID == subject.ID, nil
+	default:
+		return false, nil
+	}
+}
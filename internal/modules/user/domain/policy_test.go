@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPolicyTestUser(t *testing.T, id string, role UserRole) *User {
+	t.Helper()
+
+	user, err := NewUser(id, id+"@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+	require.NoError(t, user.ChangeRole(role))
+	return user
+}
+
+func TestDefaultPolicy_Can(t *testing.T) {
+	alice := newPolicyTestUser(t, "alice", UserRoleUser)
+	bob := newPolicyTestUser(t, "bob", UserRoleUser)
+	admin := newPolicyTestUser(t, "admin", UserRoleAdmin)
+
+	tests := []struct {
+		name    string
+		subject *User
+		action  string
+		target  *User
+		want    bool
+	}{
+		{"self edit allowed", alice, ActionUserEdit, alice, true},
+		{"self delete allowed", alice, ActionUserDelete, alice, true},
+		{"other user edit denied", alice, ActionUserEdit, bob, false},
+		{"other user delete denied", alice, ActionUserDelete, bob, false},
+		{"admin edits other user", admin, ActionUserEdit, bob, true},
+		{"admin deletes other user", admin, ActionUserDelete, bob, true},
+		{"unknown action denied", alice, "user.promote", alice, false},
+		{"no subject denied", nil, ActionUserEdit, alice, false},
+	}
+
+	policy := NewDefaultPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := policy.Can(context.Background(), tt.subject, tt.action, tt.target)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+}
+
+func TestDefaultPolicy_Can_UnexpectedResource(t *testing.T) {
+	alice := newPolicyTestUser(t, "alice", UserRoleUser)
+
+	allowed, err := NewDefaultPolicy().Can(context.Background(), alice, ActionUserEdit, "alice")
+	assert.Error(t, err)
+	assert.False(t, allowed)
+}
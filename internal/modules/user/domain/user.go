@@ -6,8 +6,6 @@ import (
 	"regexp"
 	"strings"
 	"time"
-
-	"golang.org/x/crypto/bcrypt"
 )
 
 // UserStatus represents the status of a user account
@@ -17,12 +15,13 @@ const (
 	UserStatusActive    UserStatus = "active"
 	UserStatusInactive  UserStatus = "inactive"
 	UserStatusSuspended UserStatus = "suspended"
+	UserStatusLocked    UserStatus = "locked"
 )
 
 // IsValid checks if the UserStatus is valid
 func (s UserStatus) IsValid() bool {
 	switch s {
-	case UserStatusActive, UserStatusInactive, UserStatusSuspended:
+	case UserStatusActive, UserStatusInactive, UserStatusSuspended, UserStatusLocked:
 		return true
 	default:
 		return false
@@ -34,6 +33,29 @@ func (s UserStatus) String() string {
 	return string(s)
 }
 
+// UserRole represents what a user is allowed to do
+type UserRole string
+
+const (
+	UserRoleUser  UserRole = "user"
+	UserRoleAdmin UserRole = "admin"
+)
+
+// IsValid checks if the UserRole is valid
+func (r UserRole) IsValid() bool {
+	switch r {
+	case UserRoleUser, UserRoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of UserRole
+func (r UserRole) String() string {
+	return string(r)
+}
+
 // User represents the user aggregate root
 type User struct {
 	ID        string     `db:"id" json:"id"`
@@ -42,25 +64,41 @@ type User struct {
 	FirstName string     `db:"first_name" json:"first_name"`
 	LastName  string     `db:"last_name" json:"last_name"`
 	Status    UserStatus `db:"status" json:"status"`
+	Role      UserRole   `db:"role" json:"role"`
+	AvatarURL string     `db:"avatar_url" json:"avatar_url,omitempty"`
 	CreatedAt time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt time.Time  `db:"updated_at" json:"updated_at"`
-	Version   int        `db:"version" json:"version"` // Optimistic locking
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"` // Soft delete
+	Version   int        `db:"version" json:"version"`                 // Optimistic locking
+
+	LastLoginAt *time.Time `db:"last_login_at" json:"last_login_at,omitempty"`
+	LastLoginIP string     `db:"last_login_ip" json:"last_login_ip,omitempty"`
+
+	PasswordChangedAt *time.Time `db:"password_changed_at" json:"-"` // Tokens issued earlier are rejected
 }
 
-// NewUser creates a new User aggregate with validation
+// NewUser creates a new User aggregate with validation, hashing password with
+// the default password hasher
 func NewUser(id, email, password, firstName, lastName string) (*User, error) {
+	return NewUserWithHasher(DefaultPasswordHasher(), id, email, password, firstName, lastName)
+}
+
+// NewUserWithHasher creates a new User aggregate with validation, hashing
+// password with hasher
+func NewUserWithHasher(hasher PasswordHasher, id, email, password, firstName, lastName string) (*User, error) {
 	user := &User{
 		ID:        id,
-		Email:     strings.ToLower(strings.TrimSpace(email)),
+		Email:     NormalizeEmail(email),
 		FirstName: strings.TrimSpace(firstName),
 		LastName:  strings.TrimSpace(lastName),
 		Status:    UserStatusActive,
+		Role:      UserRoleUser,
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
 		Version:   1,
 	}
 
-	if err := user.SetPassword(password); err != nil {
+	if err := user.SetPasswordWith(hasher, password); err != nil {
 		return nil, fmt.Errorf("failed to set password: %w", err)
 	}
 
@@ -89,6 +127,10 @@ func (u *User) Validate() error {
 		return fmt.Errorf("invalid user status: %s", u.Status)
 	}
 
+	if !u.Role.IsValid() {
+		return fmt.Errorf("invalid user role: %s", u.Role)
+	}
+
 	return nil
 }
 
@@ -142,26 +184,44 @@ func (u *User) validateName() error {
 	return nil
 }
 
-// SetPassword hashes and sets the user's password
+// SetPassword hashes and sets the user's password with the default password
+// hasher
 func (u *User) SetPassword(password string) error {
+	return u.SetPasswordWith(DefaultPasswordHasher(), password)
+}
+
+// SetPasswordWith hashes and sets the user's password with hasher. Replacing
+// an existing password records when it changed.
+func (u *User) SetPasswordWith(hasher PasswordHasher, password string) error {
 	if err := validatePassword(password); err != nil {
 		return err
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := hasher.Hash(password)
 	if err != nil {
-		return fmt.Errorf("failed to hash password: %w", err)
+		return err
 	}
 
-	u.Password = string(hashedPassword)
-	u.UpdatedAt = time.Now().UTC()
+	now := time.Now().UTC()
+	if u.Password != "" {
+		u.PasswordChangedAt = &now
+	}
+	u.Password = hashedPassword
+	u.UpdatedAt = now
 	return nil
 }
 
 // CheckPassword verifies if the provided password matches the user's password
+// with the default password hasher, which accepts every supported algorithm
 func (u *User) CheckPassword(password string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
-	return err == nil
+	return u.CheckPasswordWith(DefaultPasswordHasher(), password)
+}
+
+// CheckPasswordWith verifies if the provided password matches the user's
+// password with hasher
+func (u *User) CheckPasswordWith(hasher PasswordHasher, password string) bool {
+	ok, err := hasher.Verify(password, u.Password)
+	return err == nil && ok
 }
 
 // UpdateProfile updates the user's profile information
@@ -176,13 +236,26 @@ func (u *User) UpdateProfile(firstName, lastName string) error {
 
 // UpdateEmail updates the user's email address
 func (u *User) UpdateEmail(email string) error {
-	u.Email = strings.ToLower(strings.TrimSpace(email))
+	u.Email = NormalizeEmail(email)
 	u.UpdatedAt = time.Now().UTC()
 	u.Version++
 
 	return u.validateEmail()
 }
 
+// SetAvatarURL sets the URL of the user's avatar image; an empty URL removes it
+func (u *User) SetAvatarURL(avatarURL string) error {
+	if len(avatarURL) > 500 {
+		return errors.New("avatar URL cannot exceed 500 characters")
+	}
+
+	u.AvatarURL = avatarURL
+	u.UpdatedAt = time.Now().UTC()
+	u.Version++
+
+	return nil
+}
+
 // ChangeStatus changes the user's status
 func (u *User) ChangeStatus(status UserStatus) error {
 	if !status.IsValid() {
@@ -196,6 +269,19 @@ func (u *User) ChangeStatus(status UserStatus) error {
 	return nil
 }
 
+// ChangeRole changes the user's role
+func (u *User) ChangeRole(role UserRole) error {
+	if !role.IsValid() {
+		return fmt.Errorf("invalid user role: %s", role)
+	}
+
+	u.Role = role
+	u.UpdatedAt = time.Now().UTC()
+	u.Version++
+
+	return nil
+}
+
 // Activate sets the user status to active
 func (u *User) Activate() error {
 	return u.ChangeStatus(UserStatusActive)
@@ -211,6 +297,63 @@ func (u *User) Suspend() error {
 	return u.ChangeStatus(UserStatusSuspended)
 }
 
+// Lock sets the user status to locked, e.g. after repeated failed logins
+func (u *User) Lock() error {
+	return u.ChangeStatus(UserStatusLocked)
+}
+
+// Unlock restores a locked user to active
+func (u *User) Unlock() error {
+	if u.Status != UserStatusLocked {
+		return fmt.Errorf("cannot unlock user with status: %s", u.Status)
+	}
+	return u.Activate()
+}
+
+// MarkDeleted soft-deletes the user
+func (u *User) MarkDeleted() error {
+	if u.IsDeleted() {
+		return errors.New("user is already deleted")
+	}
+
+	now := time.Now().UTC()
+	u.DeletedAt = &now
+	u.UpdatedAt = now
+	u.Version++
+
+	return nil
+}
+
+// RecordLogin records that the user logged in at loginAt from ipAddress
+func (u *User) RecordLogin(loginAt time.Time, ipAddress string) error {
+	if len(ipAddress) > 45 {
+		return errors.New("login IP address cannot exceed 45 characters")
+	}
+
+	loginAt = loginAt.UTC()
+	u.LastLoginAt = &loginAt
+	u.LastLoginIP = ipAddress
+	u.UpdatedAt = time.Now().UTC()
+	u.Version++
+
+	return nil
+}
+
+// IsDeleted returns true if the user has been soft-deleted
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// IsLocked returns true if the user is locked
+func (u *User) IsLocked() bool {
+	return u.Status == UserStatusLocked
+}
+
+// IsAdmin returns true if the user has the admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == UserRoleAdmin
+}
+
 // IsActive returns true if the user is active
 func (u *User) IsActive() bool {
 	return u.Status == UserStatusActive
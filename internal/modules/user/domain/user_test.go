@@ -18,6 +18,7 @@ func TestUserStatus_IsValid(t *testing.T) {
 		{"Active status is valid", UserStatusActive, true},
 		{"Inactive status is valid", UserStatusInactive, true},
 		{"Suspended status is valid", UserStatusSuspended, true},
+		{"Locked status is valid", UserStatusLocked, true},
 		{"Invalid status", UserStatus("invalid"), false},
 		{"Empty status", UserStatus(""), false},
 	}
@@ -33,6 +34,14 @@ func TestUserStatus_String(t *testing.T) {
 	assert.Equal(t, "active", UserStatusActive.String())
 	assert.Equal(t, "inactive", UserStatusInactive.String())
 	assert.Equal(t, "suspended", UserStatusSuspended.String())
+	assert.Equal(t, "locked", UserStatusLocked.String())
+}
+
+func TestUserRole_IsValid(t *testing.T) {
+	assert.True(t, UserRoleUser.IsValid())
+	assert.True(t, UserRoleAdmin.IsValid())
+	assert.False(t, UserRole("owner").IsValid())
+	assert.False(t, UserRole("").IsValid())
 }
 
 func TestNewUser(t *testing.T) {
@@ -293,6 +302,27 @@ func TestUser_UpdateEmail(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid email format")
 }
 
+func TestUser_SetAvatarURL(t *testing.T) {
+	user, err := NewUser("test-user", "test@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+
+	originalVersion := user.Version
+
+	err = user.SetAvatarURL("/uploads/avatars/test-user/abc.png")
+	assert.NoError(t, err)
+	assert.Equal(t, "/uploads/avatars/test-user/abc.png", user.AvatarURL)
+	assert.Equal(t, originalVersion+1, user.Version)
+
+	// Clearing the avatar
+	err = user.SetAvatarURL("")
+	assert.NoError(t, err)
+	assert.Empty(t, user.AvatarURL)
+
+	// Test overlong URL
+	err = user.SetAvatarURL("/" + strings.Repeat("a", 500))
+	assert.Error(t, err)
+}
+
 func TestUser_ChangeStatus(t *testing.T) {
 	user, err := NewUser("test-user", "test@example.com", "Password123", "John", "Doe")
 	require.NoError(t, err)
@@ -315,6 +345,23 @@ func TestUser_ChangeStatus(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid user status")
 }
 
+func TestUser_ChangeRole(t *testing.T) {
+	user, err := NewUser("test-user", "test@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+	assert.Equal(t, UserRoleUser, user.Role)
+	assert.False(t, user.IsAdmin())
+
+	originalVersion := user.Version
+	require.NoError(t, user.ChangeRole(UserRoleAdmin))
+	assert.True(t, user.IsAdmin())
+	assert.Equal(t, originalVersion+1, user.Version)
+
+	err = user.ChangeRole(UserRole("owner"))
+	assert.Error(t, err)
+	assert.Equal(t, UserRoleAdmin, user.Role)
+	assert.Equal(t, originalVersion+1, user.Version)
+}
+
 func TestUser_StatusMethods(t *testing.T) {
 	user, err := NewUser("test-user", "test@example.com", "Password123", "John", "Doe")
 	require.NoError(t, err)
@@ -338,6 +385,103 @@ func TestUser_StatusMethods(t *testing.T) {
 	assert.False(t, user.IsActive())
 }
 
+func TestUser_LockUnlock(t *testing.T) {
+	user, err := NewUser("test-user", "test@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+
+	originalVersion := user.Version
+
+	// Test Lock
+	err = user.Lock()
+	assert.NoError(t, err)
+	assert.Equal(t, UserStatusLocked, user.Status)
+	assert.True(t, user.IsLocked())
+	assert.False(t, user.IsActive())
+	assert.Equal(t, originalVersion+1, user.Version)
+
+	// Test Unlock
+	err = user.Unlock()
+	assert.NoError(t, err)
+	assert.Equal(t, UserStatusActive, user.Status)
+	assert.False(t, user.IsLocked())
+	assert.True(t, user.IsActive())
+	assert.Equal(t, originalVersion+2, user.Version)
+}
+
+func TestUser_Unlock_RequiresLockedStatus(t *testing.T) {
+	for _, status := range []UserStatus{UserStatusActive, UserStatusInactive, UserStatusSuspended} {
+		t.Run(string(status), func(t *testing.T) {
+			user, err := NewUser("test-user", "test@example.com", "Password123", "John", "Doe")
+			require.NoError(t, err)
+			require.NoError(t, user.ChangeStatus(status))
+
+			version := user.Version
+			err = user.Unlock()
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "cannot unlock user")
+			assert.Equal(t, status, user.Status)
+			assert.Equal(t, version, user.Version)
+		})
+	}
+}
+
+func TestUser_MarkDeleted(t *testing.T) {
+	user, err := NewUser("test-user", "test@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+
+	originalVersion := user.Version
+	assert.False(t, user.IsDeleted())
+
+	require.NoError(t, user.MarkDeleted())
+	assert.True(t, user.IsDeleted())
+	require.NotNil(t, user.DeletedAt)
+	assert.Equal(t, originalVersion+1, user.Version)
+
+	// A deleted user cannot be deleted again
+	err = user.MarkDeleted()
+	assert.Error(t, err)
+	assert.Equal(t, originalVersion+1, user.Version)
+}
+
+func TestUser_RecordLogin(t *testing.T) {
+	user, err := NewUser("test-user", "test@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+
+	originalVersion := user.Version
+	assert.Nil(t, user.LastLoginAt)
+
+	loginAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.FixedZone("WIB", 7*60*60))
+	require.NoError(t, user.RecordLogin(loginAt, "203.0.113.7"))
+	require.NotNil(t, user.LastLoginAt)
+	assert.True(t, loginAt.Equal(*user.LastLoginAt))
+	assert.Equal(t, time.UTC, user.LastLoginAt.Location())
+	assert.Equal(t, "203.0.113.7", user.LastLoginIP)
+	assert.Equal(t, originalVersion+1, user.Version)
+
+	// Test overlong IP address
+	err = user.RecordLogin(loginAt, strings.Repeat("1", 46))
+	assert.Error(t, err)
+}
+
+func TestUser_SetPasswordRecordsChange(t *testing.T) {
+	user, err := NewUser("test-user", "test@example.com", "Password123", "John", "Doe")
+	require.NoError(t, err)
+
+	// Setting the initial password isn't a change
+	assert.Nil(t, user.PasswordChangedAt)
+
+	before := time.Now().UTC()
+	require.NoError(t, user.SetPassword("NewPassword456"))
+	require.NotNil(t, user.PasswordChangedAt)
+	assert.False(t, user.PasswordChangedAt.Before(before))
+	assert.Equal(t, user.UpdatedAt, *user.PasswordChangedAt)
+
+	// Invalid passwords leave it unchanged
+	changedAt := *user.PasswordChangedAt
+	assert.Error(t, user.SetPassword("short"))
+	assert.Equal(t, changedAt, *user.PasswordChangedAt)
+}
+
 func TestUser_FullName(t *testing.T) {
 	user, err := NewUser("test-user", "test@example.com", "Password123", "John", "Doe")
 	require.NoError(t, err)
@@ -360,6 +504,7 @@ func TestUser_Validate(t *testing.T) {
 				FirstName: "John",
 				LastName:  "Doe",
 				Status:    UserStatusActive,
+				Role:      UserRoleUser,
 			},
 			wantErr: false,
 		},
@@ -387,6 +532,19 @@ func TestUser_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid user status",
 		},
+		{
+			name: "Invalid role",
+			user: &User{
+				ID:        "test-user",
+				Email:     "test@example.com",
+				FirstName: "John",
+				LastName:  "Doe",
+				Status:    UserStatusActive,
+				Role:      UserRole("owner"),
+			},
+			wantErr: true,
+			errMsg:  "invalid user role",
+		},
 	}
 
 	for _, tt := range tests {
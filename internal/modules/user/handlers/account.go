@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"go-templ-template/internal/modules/user/application"
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WithAccountDeletion serves users deleting themselves with self-service
+// account deletion, backed by deletionService. Users must have signed in
+// within reauthWindow to delete their account.
+func (h *UserHandler) WithAccountDeletion(deletionService application.AccountDeletionService, reauthWindow time.Duration) *UserHandler {
+	h.deletionService = deletionService
+	h.reauthWindow = reauthWindow
+	return h
+}
+
+// accountDeletionEnabled reports whether users deleting themselves should be
+// served by DeleteAccount
+func (h *UserHandler) accountDeletionEnabled() bool {
+	return h != nil && h.deletionService != nil
+}
+
+// deleteUserOrAccount handles DELETE /api/v1/users/:id. Users deleting
+// themselves go through account deletion when it is enabled; other deletions,
+// e.g. by admins, delete the user directly.
+func (h *UserHandler) deleteUserOrAccount(c echo.Context) error {
+	if h.accountDeletionEnabled() {
+		user, ok := middleware.GetUserFromContext(c).(*domain.User)
+		if !ok || user.ID == c.Param("id") {
+			return h.DeleteAccount(c)
+		}
+	}
+	return h.DeleteUser(c)
+}
+
+// DeleteAccount handles DELETE /api/v1/users/:id for a user deleting their
+// own account
+func (h *UserHandler) DeleteAccount(c echo.Context) error {
+	user, err := requireSelf(c, "You can only delete your own account")
+	if user == nil {
+		return err
+	}
+
+	authenticatedAt, ok := middleware.GetAuthenticatedAt(c)
+	if !ok || time.Since(authenticatedAt) > h.reauthWindow {
+		return c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "REAUTHENTICATION_REQUIRED",
+			Message: "Please sign in again to delete your account",
+		})
+	}
+
+	var req DeleteAccountRequest
+	if err := BindAndValidate(c, &req); err != nil {
+		return h.handleValidationError(c, err)
+	}
+
+	cmd := &application.DeleteAccountCommand{
+		UserID:    user.ID,
+		Password:  req.Password,
+		Reason:    req.Reason,
+		IPAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+	}
+
+	if err := h.deletionService.DeleteAccount(c.Request().Context(), cmd); err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	// The session was revoked with the account, so drop its cookie too
+	middleware.SetSessionCookie(c, "", -1)
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Account deleted successfully",
+	})
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	authDomain "go-templ-template/internal/modules/auth/domain"
+	"go-templ-template/internal/modules/user/application"
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAccountDeletionService is a mock implementation of AccountDeletionService
+type MockAccountDeletionService struct {
+	mock.Mock
+}
+
+func (m *MockAccountDeletionService) DeleteAccount(ctx context.Context, cmd *application.DeleteAccountCommand) error {
+	args := m.Called(ctx, cmd)
+	return args.Error(0)
+}
+
+// newDeleteAccountContext builds a context for a DELETE /api/v1/users/:id
+// request by signedInAs, whose session started at signedInAt
+func newDeleteAccountContext(e *echo.Echo, userID, body string, signedInAs *domain.User, signedInAt time.Time) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/"+userID, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(userID)
+	if signedInAs != nil {
+		c.Set(middleware.UserContextKey, signedInAs)
+		c.Set(middleware.SessionContextKey, &authDomain.Session{
+			ID:        "session-1",
+			UserID:    signedInAs.ID,
+			CreatedAt: signedInAt,
+			ExpiresAt: signedInAt.Add(24 * time.Hour),
+		})
+	}
+	return c, rec
+}
+
+func TestUserHandler_DeleteAccount(t *testing.T) {
+	currentUser := &domain.User{ID: "user-123", Email: "test@example.com", Status: domain.UserStatusActive}
+
+	tests := []struct {
+		name           string
+		userID         string
+		body           string
+		signedInAs     *domain.User
+		signedInAt     time.Time
+		setupMock      func(*MockAccountDeletionService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:       "account deleted",
+			userID:     "user-123",
+			body:       `{"password":"Password123","reason":"no longer needed"}`,
+			signedInAs: currentUser,
+			signedInAt: time.Now().Add(-time.Minute),
+			setupMock: func(service *MockAccountDeletionService) {
+				service.On("DeleteAccount", mock.Anything, mock.MatchedBy(func(cmd *application.DeleteAccountCommand) bool {
+					return cmd.UserID == "user-123" && cmd.Password == "Password123" && cmd.Reason == "no longer needed"
+				})).Return(nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:       "wrong password",
+			userID:     "user-123",
+			body:       `{"password":"WrongPassword1"}`,
+			signedInAs: currentUser,
+			signedInAt: time.Now().Add(-time.Minute),
+			setupMock: func(service *MockAccountDeletionService) {
+				service.On("DeleteAccount", mock.Anything, mock.Anything).Return(application.NewInvalidPasswordError())
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  application.ErrCodeInvalidPassword,
+		},
+		{
+			name:           "another user's account",
+			userID:         "user-456",
+			body:           `{"password":"Password123"}`,
+			signedInAs:     currentUser,
+			signedInAt:     time.Now().Add(-time.Minute),
+			setupMock:      func(service *MockAccountDeletionService) {},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "FORBIDDEN",
+		},
+		{
+			name:           "signed in too long ago",
+			userID:         "user-123",
+			body:           `{"password":"Password123"}`,
+			signedInAs:     currentUser,
+			signedInAt:     time.Now().Add(-time.Hour),
+			setupMock:      func(service *MockAccountDeletionService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "REAUTHENTICATION_REQUIRED",
+		},
+		{
+			name:           "password missing",
+			userID:         "user-123",
+			body:           `{}`,
+			signedInAs:     currentUser,
+			signedInAt:     time.Now().Add(-time.Minute),
+			setupMock:      func(service *MockAccountDeletionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:           "not signed in",
+			userID:         "user-123",
+			body:           `{"password":"Password123"}`,
+			setupMock:      func(service *MockAccountDeletionService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "UNAUTHORIZED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deletionService := &MockAccountDeletionService{}
+			tt.setupMock(deletionService)
+			handler := NewUserHandler(&MockUserService{}).WithAccountDeletion(deletionService, 15*time.Minute)
+
+			c, rec := newDeleteAccountContext(echo.New(), tt.userID, tt.body, tt.signedInAs, tt.signedInAt)
+			require.NoError(t, handler.DeleteAccount(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedError != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Error)
+			} else {
+				// The revoked session's cookie is cleared
+				assert.Contains(t, rec.Header().Get(echo.HeaderSetCookie), middleware.SessionCookieName+"=;")
+			}
+
+			deletionService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRegisterUserHandlerOnGroup_AccountDeletionRoute(t *testing.T) {
+	e := echo.New()
+	handler := NewUserHandler(&MockUserService{}).WithAccountDeletion(&MockAccountDeletionService{}, 15*time.Minute)
+
+	authCalls := 0
+	requireAuth := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authCalls++
+			return c.NoContent(http.StatusUnauthorized)
+		}
+	}
+	RegisterUserHandlerOnGroup(e.Group("/api/v1"), handler, requireAuth)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/users/user-123", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, 1, authCalls)
+}
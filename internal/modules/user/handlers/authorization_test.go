@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authDomain "go-templ-template/internal/modules/auth/domain"
+	"go-templ-template/internal/modules/user/application"
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newAuthorizationTestServer registers the user routes with an auth
+// middleware signing in signedInAs
+func newAuthorizationTestServer(handler *UserHandler, signedInAs *domain.User) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.CustomErrorHandler(middleware.ErrorHandlerConfig{JSONAPIErrors: true})
+
+	authenticate := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(middleware.UserContextKey, signedInAs)
+			return next(c)
+		}
+	}
+	RegisterUserHandlerOnGroup(e.Group("/api/v1"), handler, authenticate)
+	return e
+}
+
+// newAPIKeyTestServer registers the user routes with an auth middleware
+// authenticating signedInAs with an API key granted scopes
+func newAPIKeyTestServer(handler *UserHandler, signedInAs *domain.User, scopes ...string) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.CustomErrorHandler(middleware.ErrorHandlerConfig{JSONAPIErrors: true})
+
+	authenticate := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(middleware.UserContextKey, signedInAs)
+			c.Set(middleware.APIKeyContextKey, &authDomain.APIKey{ID: "key-123", UserID: signedInAs.ID, Scopes: scopes})
+			c.Set(middleware.ScopesContextKey, scopes)
+			return next(c)
+		}
+	}
+	RegisterUserHandlerOnGroup(e.Group("/api/v1"), handler, authenticate)
+	return e
+}
+
+func doAuthorizationRequest(e *echo.Echo, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Accept", echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUserRoutes_EditAuthorization(t *testing.T) {
+	alice := &domain.User{ID: "alice", Email: "alice@example.com", FirstName: "Alice", LastName: "Doe", Status: domain.UserStatusActive, Role: domain.UserRoleUser}
+	bob := &domain.User{ID: "bob", Email: "bob@example.com", FirstName: "Bob", LastName: "Doe", Status: domain.UserStatusActive, Role: domain.UserRoleUser}
+	admin := &domain.User{ID: "admin", Email: "admin@example.com", FirstName: "Ada", LastName: "Min", Status: domain.UserStatusActive, Role: domain.UserRoleAdmin}
+
+	tests := []struct {
+		name           string
+		signedInAs     *domain.User
+		target         *domain.User
+		expectedStatus int
+	}{
+		{"self edit allowed", alice, alice, http.StatusOK},
+		{"other user edit denied", alice, bob, http.StatusForbidden},
+		{"admin edits other user", admin, bob, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockUserService{}
+			mockService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: tt.target.ID}).Return(tt.target, nil)
+			if tt.expectedStatus == http.StatusOK {
+				mockService.On("UpdateUser", mock.Anything, mock.MatchedBy(func(cmd *application.UpdateUserCommand) bool {
+					return cmd.ID == tt.target.ID
+				})).Return(tt.target, nil)
+			}
+
+			e := newAuthorizationTestServer(NewUserHandler(mockService), tt.signedInAs)
+			rec := doAuthorizationRequest(e, http.MethodPut, "/api/v1/users/"+tt.target.ID,
+				`{"first_name":"Jane","last_name":"Doe","version":1}`)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserRoutes_EditUnknownUser(t *testing.T) {
+	alice := &domain.User{ID: "alice", Status: domain.UserStatusActive, Role: domain.UserRoleUser}
+
+	mockService := &MockUserService{}
+	mockService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: "missing"}).
+		Return(nil, application.NewUserNotFoundError("missing"))
+
+	e := newAuthorizationTestServer(NewUserHandler(mockService), alice)
+	rec := doAuthorizationRequest(e, http.MethodPut, "/api/v1/users/missing", `{"first_name":"Jane","last_name":"Doe","version":1}`)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	mockService.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+}
+
+func TestUserRoutes_DeleteAuthorization(t *testing.T) {
+	alice := &domain.User{ID: "alice", Status: domain.UserStatusActive, Role: domain.UserRoleUser}
+	bob := &domain.User{ID: "bob", Status: domain.UserStatusActive, Role: domain.UserRoleUser}
+	admin := &domain.User{ID: "admin", Status: domain.UserStatusActive, Role: domain.UserRoleAdmin}
+
+	t.Run("other user delete denied", func(t *testing.T) {
+		mockService := &MockUserService{}
+		mockService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: "bob"}).Return(bob, nil)
+		deletionService := &MockAccountDeletionService{}
+
+		handler := NewUserHandler(mockService).WithAccountDeletion(deletionService, 0)
+		rec := doAuthorizationRequest(newAuthorizationTestServer(handler, alice), http.MethodDelete, "/api/v1/users/bob", `{"password":"Password123"}`)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		mockService.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything)
+		deletionService.AssertNotCalled(t, "DeleteAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("admin deletes other user", func(t *testing.T) {
+		mockService := &MockUserService{}
+		mockService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: "bob"}).Return(bob, nil)
+		mockService.On("DeleteUser", mock.Anything, &application.DeleteUserCommand{ID: "bob", DeletedBy: "admin"}).Return(nil)
+		deletionService := &MockAccountDeletionService{}
+
+		handler := NewUserHandler(mockService).WithAccountDeletion(deletionService, 0)
+		rec := doAuthorizationRequest(newAuthorizationTestServer(handler, admin), http.MethodDelete, "/api/v1/users/bob", "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockService.AssertExpectations(t)
+		deletionService.AssertNotCalled(t, "DeleteAccount", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserRoutes_APIKeyScopes(t *testing.T) {
+	alice := &domain.User{ID: "alice", Email: "alice@example.com", FirstName: "Alice", LastName: "Doe", Status: domain.UserStatusActive, Role: domain.UserRoleUser}
+	admin := &domain.User{ID: "admin", Email: "admin@example.com", FirstName: "Ada", LastName: "Min", Status: domain.UserStatusActive, Role: domain.UserRoleAdmin}
+
+	t.Run("read-only key cannot edit", func(t *testing.T) {
+		mockService := &MockUserService{}
+		e := newAPIKeyTestServer(NewUserHandler(mockService), alice, domain.ScopeUsersRead)
+
+		for _, path := range []string{"/api/v1/users/alice", "/api/v1/users/alice/email", "/api/v1/users/alice/password"} {
+			rec := doAuthorizationRequest(e, http.MethodPut, path, `{"first_name":"Jane","last_name":"Doe","version":1}`)
+			assert.Equal(t, http.StatusForbidden, rec.Code, path)
+			assert.Contains(t, rec.Body.String(), "INSUFFICIENT_SCOPE", path)
+		}
+		mockService.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything)
+		mockService.AssertNotCalled(t, "UpdateUserEmail", mock.Anything, mock.Anything)
+		mockService.AssertNotCalled(t, "ChangeUserPassword", mock.Anything, mock.Anything)
+	})
+
+	t.Run("read-only key cannot delete", func(t *testing.T) {
+		mockService := &MockUserService{}
+		deletionService := &MockAccountDeletionService{}
+		handler := NewUserHandler(mockService).WithAccountDeletion(deletionService, 0)
+
+		rec := doAuthorizationRequest(newAPIKeyTestServer(handler, alice, domain.ScopeUsersRead), http.MethodDelete, "/api/v1/users/alice", `{"password":"Password123"}`)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		deletionService.AssertNotCalled(t, "DeleteAccount", mock.Anything, mock.Anything)
+	})
+
+	t.Run("read-only key cannot upload an avatar or request an export", func(t *testing.T) {
+		avatarService := &MockAvatarService{}
+		exportService := &MockDataExportService{}
+		handler := NewUserHandler(&MockUserService{}).
+			WithAvatarUploads(avatarService, 1024).
+			WithDataExports(exportService)
+		e := newAPIKeyTestServer(handler, alice, domain.ScopeUsersRead)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, newAvatarRequest(t, "alice", []byte("image")))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+
+		rec = doAuthorizationRequest(e, http.MethodPost, "/api/v1/users/alice/export", "")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+
+		avatarService.AssertNotCalled(t, "UploadAvatar", mock.Anything, mock.Anything)
+		exportService.AssertNotCalled(t, "RequestExport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("read-only key can list users", func(t *testing.T) {
+		mockService := &MockUserService{}
+		mockService.On("ListUsers", mock.Anything, mock.Anything).Return([]*domain.User{}, int64(0), nil)
+
+		rec := doAuthorizationRequest(newAPIKeyTestServer(NewUserHandler(mockService), admin, domain.ScopeUsersRead), http.MethodGet, "/api/v1/users", "")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("write-only key cannot list users", func(t *testing.T) {
+		mockService := &MockUserService{}
+
+		rec := doAuthorizationRequest(newAPIKeyTestServer(NewUserHandler(mockService), admin, domain.ScopeUsersWrite), http.MethodGet, "/api/v1/users", "")
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		mockService.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("write key can edit", func(t *testing.T) {
+		mockService := &MockUserService{}
+		mockService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: "alice"}).Return(alice, nil)
+		mockService.On("UpdateUser", mock.Anything, mock.Anything).Return(alice, nil)
+
+		e := newAPIKeyTestServer(NewUserHandler(mockService), alice, domain.ScopeUsersRead, domain.ScopeUsersWrite)
+		rec := doAuthorizationRequest(e, http.MethodPut, "/api/v1/users/alice", `{"first_name":"Jane","last_name":"Doe","version":1}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestUserRoutes_ChangeStatusRequiresAdmin(t *testing.T) {
+	alice := &domain.User{ID: "alice", Status: domain.UserStatusLocked, Role: domain.UserRoleUser, Version: 1}
+	bob := &domain.User{ID: "bob", Status: domain.UserStatusActive, Role: domain.UserRoleUser}
+	admin := &domain.User{ID: "admin", Status: domain.UserStatusActive, Role: domain.UserRoleAdmin}
+	body := `{"status":"active","changed_by":"someone-else","version":1}`
+
+	t.Run("anonymous", func(t *testing.T) {
+		mockService := &MockUserService{}
+		e := echo.New()
+		e.HTTPErrorHandler = middleware.CustomErrorHandler(middleware.ErrorHandlerConfig{JSONAPIErrors: true})
+		authenticate := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				return c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "UNAUTHORIZED", Message: "Authentication required"})
+			}
+		}
+		RegisterUserHandlerOnGroup(e.Group("/api/v1"), NewUserHandler(mockService), authenticate)
+
+		rec := doAuthorizationRequest(e, http.MethodPut, "/api/v1/users/alice/status", body)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		mockService.AssertNotCalled(t, "ChangeUserStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("without auth middleware", func(t *testing.T) {
+		mockService := &MockUserService{}
+		e := echo.New()
+		e.HTTPErrorHandler = middleware.CustomErrorHandler(middleware.ErrorHandlerConfig{JSONAPIErrors: true})
+		RegisterUserHandlerOnGroup(e.Group("/api/v1"), NewUserHandler(mockService), nil)
+
+		rec := doAuthorizationRequest(e, http.MethodPut, "/api/v1/users/alice/status", body)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		mockService.AssertNotCalled(t, "ChangeUserStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("regular user", func(t *testing.T) {
+		mockService := &MockUserService{}
+
+		rec := doAuthorizationRequest(newAuthorizationTestServer(NewUserHandler(mockService), bob), http.MethodPut, "/api/v1/users/alice/status", body)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		mockService.AssertNotCalled(t, "ChangeUserStatus", mock.Anything, mock.Anything)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		mockService := &MockUserService{}
+		mockService.On("ChangeUserStatus", mock.Anything, mock.MatchedBy(func(cmd *application.ChangeUserStatusCommand) bool {
+			return cmd.ID == "alice" && cmd.ChangedBy == "admin"
+		})).Return(alice, nil)
+
+		rec := doAuthorizationRequest(newAuthorizationTestServer(NewUserHandler(mockService), admin), http.MethodPut, "/api/v1/users/alice/status", body)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"go-templ-template/internal/modules/user/application"
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AvatarFormField is the multipart form field holding an uploaded avatar
+const AvatarFormField = "avatar"
+
+// WithAvatarUploads enables the avatar upload endpoint, backed by
+// avatarService. Images larger than maxBytes are rejected.
+func (h *UserHandler) WithAvatarUploads(avatarService application.AvatarService, maxBytes int64) *UserHandler {
+	h.avatarService = avatarService
+	h.avatarMaxBytes = maxBytes
+	return h
+}
+
+// avatarUploadsEnabled reports whether the avatar upload endpoint should be
+// registered
+func (h *UserHandler) avatarUploadsEnabled() bool {
+	return h != nil && h.avatarService != nil && h.avatarMaxBytes > 0
+}
+
+// UploadAvatar handles POST /api/v1/users/:id/avatar
+func (h *UserHandler) UploadAvatar(c echo.Context) error {
+	// Users may only change their own avatar
+	user, err := requireSelf(c, "You can only change your own avatar")
+	if user == nil {
+		return err
+	}
+
+	fileHeader, err := c.FormFile(AvatarFormField)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: "Avatar image is required",
+			Field:   AvatarFormField,
+		})
+	}
+	if fileHeader.Size > h.avatarMaxBytes {
+		return h.avatarTooLarge(c)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+	defer file.Close()
+
+	// Read one byte past the limit to catch parts larger than they declare
+	data, err := io.ReadAll(io.LimitReader(file, h.avatarMaxBytes+1))
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+	if int64(len(data)) > h.avatarMaxBytes {
+		return h.avatarTooLarge(c)
+	}
+
+	// Trust the file's contents rather than the client's declared type
+	contentType := http.DetectContentType(data)
+	if _, ok := application.AvatarContentTypes[contentType]; !ok {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "VALIDATION_ERROR",
+			Message: "Avatar must be a JPEG, PNG, GIF or WebP image",
+			Field:   AvatarFormField,
+		})
+	}
+
+	cmd := &application.UploadAvatarCommand{
+		UserID:      user.ID,
+		ContentType: contentType,
+		Data:        data,
+	}
+
+	updated, err := h.avatarService.UploadAvatar(c.Request().Context(), cmd)
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Avatar updated successfully",
+		Data:    userResponse(c, updated),
+	})
+}
+
+// requireSelf returns the signed-in user if they are the user named by the
+// :id path parameter. Otherwise it responds 401 or 403 with forbiddenMessage
+// and returns a nil user.
+func requireSelf(c echo.Context, forbiddenMessage string) (*domain.User, error) {
+	user, ok := middleware.GetUserFromContext(c).(*domain.User)
+	if !ok {
+		return nil, c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "UNAUTHORIZED",
+			Message: "Authentication required",
+		})
+	}
+	if user.ID != c.Param("id") {
+		return nil, c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "FORBIDDEN",
+			Message: forbiddenMessage,
+		})
+	}
+	return user, nil
+}
+
+// avatarTooLarge responds to an avatar over the size limit
+func (h *UserHandler) avatarTooLarge(c echo.Context) error {
+	return c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+		Error:   "PAYLOAD_TOO_LARGE",
+		Message: fmt.Sprintf("Avatar image must not exceed %d bytes", h.avatarMaxBytes),
+		Field:   AvatarFormField,
+	})
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/user/application"
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAvatarService is a mock implementation of AvatarService
+type MockAvatarService struct {
+	mock.Mock
+}
+
+func (m *MockAvatarService) UploadAvatar(ctx context.Context, cmd *application.UploadAvatarCommand) (*domain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+// pngImage is the start of a PNG file, enough for content type detection
+var pngImage = append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 64)...)
+
+// newAvatarRequest builds a multipart request uploading data as the avatar
+func newAvatarRequest(t *testing.T, userID string, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(AvatarFormField, "avatar.png")
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+userID+"/avatar", &body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	return req
+}
+
+func TestUserHandler_UploadAvatar(t *testing.T) {
+	currentUser := &domain.User{
+		ID:        "user-123",
+		Email:     "test@example.com",
+		FirstName: "John",
+		LastName:  "Doe",
+		Status:    domain.UserStatusActive,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Version:   1,
+	}
+
+	tests := []struct {
+		name           string
+		userID         string
+		signedInAs     *domain.User
+		data           []byte
+		setupMock      func(*MockAvatarService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:       "successful upload",
+			userID:     "user-123",
+			signedInAs: currentUser,
+			data:       pngImage,
+			setupMock: func(service *MockAvatarService) {
+				updated := *currentUser
+				updated.AvatarURL = "/uploads/avatars/user-123/abc.png"
+				updated.Version = 2
+				service.On("UploadAvatar", mock.Anything, mock.MatchedBy(func(cmd *application.UploadAvatarCommand) bool {
+					return cmd.UserID == "user-123" && cmd.ContentType == "image/png" && bytes.Equal(cmd.Data, pngImage)
+				})).Return(&updated, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "oversized file",
+			userID:         "user-123",
+			signedInAs:     currentUser,
+			data:           append(append([]byte{}, pngImage...), make([]byte, 1024)...),
+			setupMock:      func(service *MockAvatarService) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedError:  "PAYLOAD_TOO_LARGE",
+		},
+		{
+			name:           "non-image file",
+			userID:         "user-123",
+			signedInAs:     currentUser,
+			data:           []byte("just some text, not an image"),
+			setupMock:      func(service *MockAvatarService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
+		{
+			name:           "another user's avatar",
+			userID:         "user-456",
+			signedInAs:     currentUser,
+			data:           pngImage,
+			setupMock:      func(service *MockAvatarService) {},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "FORBIDDEN",
+		},
+		{
+			name:           "not signed in",
+			userID:         "user-123",
+			data:           pngImage,
+			setupMock:      func(service *MockAvatarService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "UNAUTHORIZED",
+		},
+		{
+			name:       "user not found",
+			userID:     "user-123",
+			signedInAs: currentUser,
+			data:       pngImage,
+			setupMock: func(service *MockAvatarService) {
+				service.On("UploadAvatar", mock.Anything, mock.Anything).Return(nil, application.NewUserNotFoundError("user-123"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "USER_NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			avatarService := &MockAvatarService{}
+			tt.setupMock(avatarService)
+
+			handler := NewUserHandler(&MockUserService{}).
+				WithAvatarUploads(avatarService, int64(len(pngImage)+512))
+			e := echo.New()
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(newAvatarRequest(t, tt.userID, tt.data), rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.userID)
+			if tt.signedInAs != nil {
+				c.Set(middleware.UserContextKey, tt.signedInAs)
+			}
+
+			// Execute
+			err := handler.UploadAvatar(c)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedError != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Error)
+			} else {
+				var response struct {
+					Data UserResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, "/uploads/avatars/user-123/abc.png", response.Data.AvatarURL)
+			}
+
+			avatarService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRegisterUserHandlerOnGroup_AvatarRoute(t *testing.T) {
+	t.Run("registered with auth middleware when enabled", func(t *testing.T) {
+		e := echo.New()
+		handler := NewUserHandler(&MockUserService{}).
+			WithAvatarUploads(&MockAvatarService{}, 1024)
+
+		authCalled := false
+		requireAuth := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				authCalled = true
+				return c.NoContent(http.StatusUnauthorized)
+			}
+		}
+		RegisterUserHandlerOnGroup(e.Group("/api/v1"), handler, requireAuth)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, newAvatarRequest(t, "user-123", pngImage))
+
+		assert.True(t, authCalled)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("replays retried uploads after auth", func(t *testing.T) {
+		e := echo.New()
+		avatarService := &MockAvatarService{}
+		handler := NewUserHandler(&MockUserService{}).WithAvatarUploads(avatarService, 1024)
+
+		signedIn := &domain.User{ID: "user-123", Status: domain.UserStatusActive}
+		requireAuth := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				c.Set(middleware.UserContextKey, signedIn)
+				return next(c)
+			}
+		}
+		store := middleware.NewMemoryIdempotencyStore()
+		RegisterUserHandlerOnGroup(e.Group("/api/v1"), handler, middleware.IdempotentAfterAuth(requireAuth, store, 0))
+
+		updated := *signedIn
+		updated.AvatarURL = "/uploads/avatars/user-123/abc.png"
+		avatarService.On("UploadAvatar", mock.Anything, mock.Anything).Return(&updated, nil).Once()
+
+		// A retry resends the same bytes, multipart boundary included
+		upload := newAvatarRequest(t, "user-123", pngImage)
+		body, err := io.ReadAll(upload.Body)
+		require.NoError(t, err)
+		send := func(key string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/user-123/avatar", bytes.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, upload.Header.Get(echo.HeaderContentType))
+			req.Header.Set(middleware.IdempotencyKeyHeader, key)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			return rec
+		}
+
+		first := send("upload-1")
+		require.Equal(t, http.StatusOK, first.Code)
+		retry := send("upload-1")
+		assert.Equal(t, http.StatusOK, retry.Code)
+		assert.Equal(t, first.Body.String(), retry.Body.String())
+		assert.Equal(t, "true", retry.Header().Get(middleware.IdempotentReplayedHeader))
+		avatarService.AssertNumberOfCalls(t, "UploadAvatar", 1)
+
+		avatarService.On("UploadAvatar", mock.Anything, mock.Anything).Return(&updated, nil).Once()
+		assert.Equal(t, http.StatusOK, send("upload-2").Code)
+		avatarService.AssertNumberOfCalls(t, "UploadAvatar", 2)
+	})
+
+	t.Run("not registered without an avatar service", func(t *testing.T) {
+		e := echo.New()
+		RegisterUserHandlerOnGroup(e.Group("/api/v1"), NewUserHandler(&MockUserService{}), nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, newAvatarRequest(t, "user-123", pngImage))
+
+		assert.NotEqual(t, http.StatusOK, rec.Code)
+		assert.NotEqual(t, http.StatusUnauthorized, rec.Code)
+	})
+}
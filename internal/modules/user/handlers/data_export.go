@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"go-templ-template/internal/modules/user/application"
+
+	"github.com/labstack/echo/v4"
+)
+
+// WithDataExports enables the data export endpoints, backed by exportService
+func (h *UserHandler) WithDataExports(exportService application.DataExportService) *UserHandler {
+	h.exportService = exportService
+	return h
+}
+
+// dataExportsEnabled reports whether the data export endpoints should be
+// registered
+func (h *UserHandler) dataExportsEnabled() bool {
+	return h != nil && h.exportService != nil
+}
+
+// RequestDataExport handles POST /api/v1/users/:id/export
+func (h *UserHandler) RequestDataExport(c echo.Context) error {
+	user, err := requireSelf(c, "You can only export your own data")
+	if user == nil {
+		return err
+	}
+
+	export, err := h.exportService.RequestExport(c.Request().Context(), user.ID)
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, SuccessResponse{
+		Message: "Data export requested. We will email you a download link when it is ready.",
+		Data:    ToDataExportResponse(export, h.apiPrefix),
+	})
+}
+
+// GetDataExport handles GET /api/v1/users/:id/export/:jobId
+func (h *UserHandler) GetDataExport(c echo.Context) error {
+	user, err := requireSelf(c, "You can only view your own data exports")
+	if user == nil {
+		return err
+	}
+
+	export, err := h.exportService.GetExport(c.Request().Context(), user.ID, c.Param("jobId"))
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, ToDataExportResponse(export, h.apiPrefix))
+}
+
+// DownloadDataExport handles GET /api/v1/users/:id/export/:jobId/download
+func (h *UserHandler) DownloadDataExport(c echo.Context) error {
+	user, err := requireSelf(c, "You can only download your own data exports")
+	if user == nil {
+		return err
+	}
+
+	exportID := c.Param("jobId")
+	body, err := h.exportService.OpenExport(c.Request().Context(), user.ID, exportID)
+	if err != nil {
+		return h.handleApplicationError(c, err)
+	}
+	defer body.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="data-export-%s.json"`, exportID))
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.Stream(http.StatusOK, echo.MIMEApplicationJSON, body)
+}
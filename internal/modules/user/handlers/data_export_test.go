@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/user/application"
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDataExportService is a mock implementation of DataExportService
+type MockDataExportService struct {
+	mock.Mock
+}
+
+func (m *MockDataExportService) RequestExport(ctx context.Context, userID string) (*domain.DataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportService) GetExport(ctx context.Context, userID, exportID string) (*domain.DataExport, error) {
+	args := m.Called(ctx, userID, exportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DataExport), args.Error(1)
+}
+
+func (m *MockDataExportService) OpenExport(ctx context.Context, userID, exportID string) (io.ReadCloser, error) {
+	args := m.Called(ctx, userID, exportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+// newExportContext builds a context for an export request by signedInAs on
+// the export endpoints of userID
+func newExportContext(e *echo.Echo, method, userID, jobID string, signedInAs *domain.User) (echo.Context, *httptest.ResponseRecorder) {
+	path := "/api/v1/users/" + userID + "/export"
+	names, values := []string{"id"}, []string{userID}
+	if jobID != "" {
+		path += "/" + jobID
+		names, values = append(names, "jobId"), append(values, jobID)
+	}
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(method, path, nil), rec)
+	c.SetParamNames(names...)
+	c.SetParamValues(values...)
+	if signedInAs != nil {
+		c.Set(middleware.UserContextKey, signedInAs)
+	}
+	return c, rec
+}
+
+func TestUserHandler_RequestDataExport(t *testing.T) {
+	currentUser := &domain.User{ID: "user-123", Email: "test@example.com", Status: domain.UserStatusActive}
+
+	tests := []struct {
+		name           string
+		userID         string
+		signedInAs     *domain.User
+		setupMock      func(*MockDataExportService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:       "export requested",
+			userID:     "user-123",
+			signedInAs: currentUser,
+			setupMock: func(service *MockDataExportService) {
+				service.On("RequestExport", mock.Anything, "user-123").
+					Return(domain.NewDataExport("export-1", "user-123"), nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "another user's data",
+			userID:         "user-456",
+			signedInAs:     currentUser,
+			setupMock:      func(service *MockDataExportService) {},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "FORBIDDEN",
+		},
+		{
+			name:           "not signed in",
+			userID:         "user-123",
+			setupMock:      func(service *MockDataExportService) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "UNAUTHORIZED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exportService := &MockDataExportService{}
+			tt.setupMock(exportService)
+			handler := NewUserHandler(&MockUserService{}).WithDataExports(exportService)
+
+			c, rec := newExportContext(echo.New(), http.MethodPost, tt.userID, "", tt.signedInAs)
+			require.NoError(t, handler.RequestDataExport(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedError != "" {
+				var response ErrorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Error)
+			} else {
+				var response struct {
+					Data DataExportResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Equal(t, "export-1", response.Data.ID)
+				assert.Equal(t, domain.DataExportStatusPending, response.Data.Status)
+				assert.Empty(t, response.Data.DownloadURL)
+			}
+
+			exportService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_GetDataExport(t *testing.T) {
+	currentUser := &domain.User{ID: "user-123", Email: "test@example.com", Status: domain.UserStatusActive}
+
+	t.Run("pending export", func(t *testing.T) {
+		exportService := &MockDataExportService{}
+		exportService.On("GetExport", mock.Anything, "user-123", "export-1").
+			Return(domain.NewDataExport("export-1", "user-123"), nil)
+		handler := NewUserHandler(&MockUserService{}).WithDataExports(exportService)
+
+		c, rec := newExportContext(echo.New(), http.MethodGet, "user-123", "export-1", currentUser)
+		require.NoError(t, handler.GetDataExport(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response DataExportResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, domain.DataExportStatusPending, response.Status)
+		assert.Empty(t, response.DownloadURL)
+	})
+
+	t.Run("ready export links to the download", func(t *testing.T) {
+		export := domain.NewDataExport("export-1", "user-123")
+		require.NoError(t, export.MarkReady("exports/user-123/export-1.json"))
+
+		exportService := &MockDataExportService{}
+		exportService.On("GetExport", mock.Anything, "user-123", "export-1").Return(export, nil)
+		handler := NewUserHandler(&MockUserService{}).WithDataExports(exportService)
+
+		c, rec := newExportContext(echo.New(), http.MethodGet, "user-123", "export-1", currentUser)
+		require.NoError(t, handler.GetDataExport(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response DataExportResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, domain.DataExportStatusReady, response.Status)
+		assert.Equal(t, "/api/v1/users/user-123/export/export-1/download", response.DownloadURL)
+		assert.NotContains(t, rec.Body.String(), "exports/user-123", "blob keys are not exposed")
+	})
+
+	t.Run("unknown export", func(t *testing.T) {
+		exportService := &MockDataExportService{}
+		exportService.On("GetExport", mock.Anything, "user-123", "missing").
+			Return(nil, application.NewExportNotFoundError("missing"))
+		handler := NewUserHandler(&MockUserService{}).WithDataExports(exportService)
+
+		c, rec := newExportContext(echo.New(), http.MethodGet, "user-123", "missing", currentUser)
+		require.NoError(t, handler.GetDataExport(c))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestUserHandler_DownloadDataExport(t *testing.T) {
+	currentUser := &domain.User{ID: "user-123", Email: "test@example.com", Status: domain.UserStatusActive}
+
+	t.Run("streams a ready bundle", func(t *testing.T) {
+		exportService := &MockDataExportService{}
+		exportService.On("OpenExport", mock.Anything, "user-123", "export-1").
+			Return(io.NopCloser(strings.NewReader(`{"export_id":"export-1"}`)), nil)
+		handler := NewUserHandler(&MockUserService{}).WithDataExports(exportService)
+
+		c, rec := newExportContext(echo.New(), http.MethodGet, "user-123", "export-1", currentUser)
+		require.NoError(t, handler.DownloadDataExport(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `{"export_id":"export-1"}`, rec.Body.String())
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "attachment")
+	})
+
+	t.Run("pending export", func(t *testing.T) {
+		exportService := &MockDataExportService{}
+		exportService.On("OpenExport", mock.Anything, "user-123", "export-1").
+			Return(nil, application.NewExportNotReadyError("export-1"))
+		handler := NewUserHandler(&MockUserService{}).WithDataExports(exportService)
+
+		c, rec := newExportContext(echo.New(), http.MethodGet, "user-123", "export-1", currentUser)
+		require.NoError(t, handler.DownloadDataExport(c))
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestRegisterUserHandlerOnGroup_DataExportRoutes(t *testing.T) {
+	t.Run("registered with auth middleware when enabled", func(t *testing.T) {
+		e := echo.New()
+		handler := NewUserHandler(&MockUserService{}).WithDataExports(&MockDataExportService{})
+
+		authCalls := 0
+		requireAuth := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				authCalls++
+				return c.NoContent(http.StatusUnauthorized)
+			}
+		}
+		RegisterUserHandlerOnGroup(e.Group("/api/v1"), handler, requireAuth)
+
+		for _, req := range []*http.Request{
+			httptest.NewRequest(http.MethodPost, "/api/v1/users/user-123/export", nil),
+			httptest.NewRequest(http.MethodGet, "/api/v1/users/user-123/export/export-1", nil),
+			httptest.NewRequest(http.MethodGet, "/api/v1/users/user-123/export/export-1/download", nil),
+		} {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code, req.URL.Path)
+		}
+		assert.Equal(t, 3, authCalls)
+	})
+
+	t.Run("not registered without a data export service", func(t *testing.T) {
+		e := echo.New()
+		RegisterUserHandlerOnGroup(e.Group("/api/v1"), NewUserHandler(&MockUserService{}), nil)
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/users/user-123/export", nil))
+
+		assert.NotEqual(t, http.StatusAccepted, rec.Code)
+		assert.NotEqual(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+// Ensure the ready time and download link are reported once an export
+// completes
+func TestToDataExportResponse(t *testing.T) {
+	export := domain.NewDataExport("export-1", "user-123")
+	assert.Nil(t, ToDataExportResponse(export, "/api/v2").CompletedAt)
+
+	require.NoError(t, export.MarkReady("exports/user-123/export-1.json"))
+	response := ToDataExportResponse(export, "/api/v2")
+	assert.Equal(t, "/api/v2/users/user-123/export/export-1/download", response.DownloadURL)
+	require.NotNil(t, response.CompletedAt)
+	assert.WithinDuration(t, time.Now(), *response.CompletedAt, time.Minute)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"time"
 
+	"go-templ-template/internal/modules/user/application"
 	"go-templ-template/internal/modules/user/domain"
 )
 
@@ -34,6 +35,13 @@ type ChangeUserPasswordRequest struct {
 	Version     int    `json:"version" validate:"required,min=1"`
 }
 
+// DeleteAccountRequest represents the request payload for a user deleting
+// their own account
+type DeleteAccountRequest struct {
+	Password string `json:"password" form:"password" validate:"required"`
+	Reason   string `json:"reason,omitempty" form:"reason" validate:"max=500"`
+}
+
 // ChangeUserStatusRequest represents the request payload for changing a user's status
 type ChangeUserStatusRequest struct {
 	Status    domain.UserStatus `json:"status" validate:"required"`
@@ -50,6 +58,9 @@ type ListUsersRequest struct {
 	LastName      *string            `query:"last_name"`
 	CreatedAfter  *string            `query:"created_after"`
 	CreatedBefore *string            `query:"created_before"`
+	Search        *string            `query:"search"`
+	SortBy        string             `query:"sort"`
+	SortDesc      bool               `query:"-"`
 	Limit         int                `query:"limit"`
 	Offset        int                `query:"offset"`
 }
@@ -62,11 +73,22 @@ type UserResponse struct {
 	LastName  string            `json:"last_name"`
 	FullName  string            `json:"full_name"`
 	Status    domain.UserStatus `json:"status"`
+	Role      domain.UserRole   `json:"role"`
+	AvatarURL string            `json:"avatar_url,omitempty"`
 	CreatedAt time.Time         `json:"created_at"`
 	UpdatedAt time.Time         `json:"updated_at"`
 	Version   int               `json:"version"`
 }
 
+// AdminUserResponse is a UserResponse with the user's private details, only
+// returned to the user themselves or to an admin
+type AdminUserResponse struct {
+	*UserResponse
+
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	LastLoginIP string     `json:"last_login_ip,omitempty"`
+}
+
 // ListUsersResponse represents the response payload for listing users
 type ListUsersResponse struct {
 	Users   []*UserResponse `json:"users"`
@@ -76,6 +98,15 @@ type ListUsersResponse struct {
 	HasMore bool            `json:"has_more"`
 }
 
+// DataExportResponse represents the response payload for a data export
+type DataExportResponse struct {
+	ID          string                  `json:"id"`
+	Status      domain.DataExportStatus `json:"status"`
+	RequestedAt time.Time               `json:"requested_at"`
+	CompletedAt *time.Time              `json:"completed_at,omitempty"`
+	DownloadURL string                  `json:"download_url,omitempty"`
+}
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -98,12 +129,23 @@ func ToUserResponse(user *domain.User) *UserResponse {
 		LastName:  user.LastName,
 		FullName:  user.FullName(),
 		Status:    user.Status,
+		Role:      user.Role,
+		AvatarURL: user.AvatarURL,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 		Version:   user.Version,
 	}
 }
 
+// ToAdminUserResponse converts a domain User to AdminUserResponse
+func ToAdminUserResponse(user *domain.User) *AdminUserResponse {
+	return &AdminUserResponse{
+		UserResponse: ToUserResponse(user),
+		LastLoginAt:  user.LastLoginAt,
+		LastLoginIP:  user.LastLoginIP,
+	}
+}
+
 // ToUserResponseList converts a slice of domain Users to UserResponse slice
 func ToUserResponseList(users []*domain.User) []*UserResponse {
 	responses := make([]*UserResponse, len(users))
@@ -112,3 +154,18 @@ func ToUserResponseList(users []*domain.User) []*UserResponse {
 	}
 	return responses
 }
+
+// ToDataExportResponse converts a domain DataExport to DataExportResponse,
+// with the download URL under apiPrefix
+func ToDataExportResponse(export *domain.DataExport, apiPrefix string) *DataExportResponse {
+	response := &DataExportResponse{
+		ID:          export.ID,
+		Status:      export.Status,
+		RequestedAt: export.RequestedAt,
+		CompletedAt: export.CompletedAt,
+	}
+	if export.IsReady() {
+		response.DownloadURL = apiPrefix + application.DataExportDownloadPath(export.UserID, export.ID)
+	}
+	return response
+}
@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"net/http"
-	"strconv"
+	"strings"
+	"time"
 
 	"go-templ-template/internal/modules/user/application"
 	"go-templ-template/internal/modules/user/domain"
+	appErrors "go-templ-template/internal/shared/errors"
+	sharedHandlers "go-templ-template/internal/shared/handlers"
+	"go-templ-template/internal/shared/middleware"
 
 	"github.com/labstack/echo/v4"
 )
@@ -13,13 +17,66 @@ import (
 // UserHandler handles HTTP requests for user operations
 type UserHandler struct {
 	userService application.UserService
+	policy      domain.Policy
+	apiPrefix   string
+
+	avatarService  application.AvatarService
+	avatarMaxBytes int64
+
+	exportService application.DataExportService
+
+	deletionService application.AccountDeletionService
+	reauthWindow    time.Duration
 }
 
 // NewUserHandler creates a new user handler
 func NewUserHandler(userService application.UserService) *UserHandler {
 	return &UserHandler{
 		userService: userService,
+		policy:      domain.NewDefaultPolicy(),
+		apiPrefix:   "/api/v1",
+	}
+}
+
+// WithAPIPrefix sets the prefix the handler's routes are mounted under, used
+// to build links in responses
+func (h *UserHandler) WithAPIPrefix(prefix string) *UserHandler {
+	if prefix != "" {
+		h.apiPrefix = "/" + strings.Trim(prefix, "/")
+	}
+	return h
+}
+
+// WithPolicy replaces the default policy deciding who may edit and delete
+// users
+func (h *UserHandler) WithPolicy(policy domain.Policy) *UserHandler {
+	h.policy = policy
+	return h
+}
+
+// loadPathUser loads the user named by the :id path parameter for
+// authorization
+func (h *UserHandler) loadPathUser(c echo.Context) (interface{}, error) {
+	id := c.Param("id")
+	user, err := h.userService.GetUser(c.Request().Context(), &application.GetUserQuery{ID: id})
+	if err != nil {
+		if appErr, ok := err.(*application.ApplicationError); ok && appErr.Code == application.ErrCodeUserNotFound {
+			return nil, appErrors.NewUserNotFoundError(id)
+		}
+		return nil, appErrors.NewInternalErrorWithCause("USER_LOAD_FAILED", "Failed to load user", err)
+	}
+	return user, nil
+}
+
+// userResponse converts user to the response for the signed-in user, which
+// only includes private details such as the last login IP when they are the
+// user or an admin
+func userResponse(c echo.Context, user *domain.User) interface{} {
+	viewer, ok := middleware.GetUserFromContext(c).(*domain.User)
+	if ok && (viewer.ID == user.ID || viewer.IsAdmin()) {
+		return ToAdminUserResponse(user)
 	}
+	return ToUserResponse(user)
 }
 
 // CreateUser handles POST /api/v1/users
@@ -41,7 +98,7 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 		return h.handleApplicationError(c, err)
 	}
 
-	response := ToUserResponse(user)
+	response := userResponse(c, user)
 	return c.JSON(http.StatusCreated, SuccessResponse{
 		Message: "User created successfully",
 		Data:    response,
@@ -65,7 +122,7 @@ func (h *UserHandler) GetUser(c echo.Context) error {
 		return h.handleApplicationError(c, err)
 	}
 
-	response := ToUserResponse(user)
+	response := userResponse(c, user)
 	return c.JSON(http.StatusOK, response)
 }
 
@@ -86,7 +143,7 @@ func (h *UserHandler) GetUserByEmail(c echo.Context) error {
 		return h.handleApplicationError(c, err)
 	}
 
-	response := ToUserResponse(user)
+	response := userResponse(c, user)
 	return c.JSON(http.StatusOK, response)
 }
 
@@ -118,7 +175,7 @@ func (h *UserHandler) UpdateUser(c echo.Context) error {
 		return h.handleApplicationError(c, err)
 	}
 
-	response := ToUserResponse(user)
+	response := userResponse(c, user)
 	return c.JSON(http.StatusOK, SuccessResponse{
 		Message: "User updated successfully",
 		Data:    response,
@@ -152,7 +209,7 @@ func (h *UserHandler) UpdateUserEmail(c echo.Context) error {
 		return h.handleApplicationError(c, err)
 	}
 
-	response := ToUserResponse(user)
+	response := userResponse(c, user)
 	return c.JSON(http.StatusOK, SuccessResponse{
 		Message: "User email updated successfully",
 		Data:    response,
@@ -187,7 +244,7 @@ func (h *UserHandler) ChangeUserPassword(c echo.Context) error {
 		return h.handleApplicationError(c, err)
 	}
 
-	response := ToUserResponse(user)
+	response := userResponse(c, user)
 	return c.JSON(http.StatusOK, SuccessResponse{
 		Message: "Password changed successfully",
 		Data:    response,
@@ -210,10 +267,16 @@ func (h *UserHandler) ChangeUserStatus(c echo.Context) error {
 		return h.handleValidationError(c, err)
 	}
 
+	// The signed-in user, if any, is recorded as the one changing the status
+	changedBy := req.ChangedBy
+	if user, ok := middleware.GetUserFromContext(c).(*domain.User); ok {
+		changedBy = user.ID
+	}
+
 	cmd := &application.ChangeUserStatusCommand{
 		ID:        id,
 		Status:    req.Status,
-		ChangedBy: req.ChangedBy,
+		ChangedBy: changedBy,
 		Reason:    req.Reason,
 		Version:   req.Version,
 	}
@@ -223,7 +286,7 @@ func (h *UserHandler) ChangeUserStatus(c echo.Context) error {
 		return h.handleApplicationError(c, err)
 	}
 
-	response := ToUserResponse(user)
+	response := userResponse(c, user)
 	return c.JSON(http.StatusOK, SuccessResponse{
 		Message: "User status changed successfully",
 		Data:    response,
@@ -241,8 +304,12 @@ func (h *UserHandler) DeleteUser(c echo.Context) error {
 		})
 	}
 
-	// Get optional query parameters
+	// Get optional query parameters; the signed-in user, if any, is recorded
+	// as the deleter
 	deletedBy := c.QueryParam("deleted_by")
+	if user, ok := middleware.GetUserFromContext(c).(*domain.User); ok {
+		deletedBy = user.ID
+	}
 	reason := c.QueryParam("reason")
 
 	cmd := &application.DeleteUserCommand{
@@ -286,17 +353,18 @@ func (h *UserHandler) ListUsers(c echo.Context) error {
 		req.CreatedBefore = &createdBefore
 	}
 
-	// Parse limit and offset
-	if limitStr := c.QueryParam("limit"); limitStr != "" {
-		if limit, err := strconv.Atoi(limitStr); err == nil {
-			req.Limit = limit
-		}
+	// Parse pagination, search and sort
+	params, err := sharedHandlers.BindListParams(c, application.UserSortFields...)
+	if err != nil {
+		return h.handleValidationError(c, listParamsValidationErrors(err))
 	}
-	if offsetStr := c.QueryParam("offset"); offsetStr != "" {
-		if offset, err := strconv.Atoi(offsetStr); err == nil {
-			req.Offset = offset
-		}
+	req.Limit = params.Limit
+	req.Offset = params.Offset
+	if params.Search != "" {
+		req.Search = &params.Search
 	}
+	req.SortBy = params.Sort
+	req.SortDesc = params.SortDesc
 
 	// Validate request
 	if err := ValidateListUsersRequest(&req); err != nil {
@@ -310,6 +378,9 @@ func (h *UserHandler) ListUsers(c echo.Context) error {
 		LastName:      req.LastName,
 		CreatedAfter:  req.CreatedAfter,
 		CreatedBefore: req.CreatedBefore,
+		Search:        req.Search,
+		SortBy:        req.SortBy,
+		SortDesc:      req.SortDesc,
 		Limit:         req.Limit,
 		Offset:        req.Offset,
 	}
@@ -330,6 +401,22 @@ func (h *UserHandler) ListUsers(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// listParamsValidationErrors converts the errors of BindListParams to the
+// handler's validation errors
+func listParamsValidationErrors(err error) error {
+	problems, ok := appErrors.AsErrorList(err)
+	if !ok {
+		return err
+	}
+
+	var validationErrs ValidationErrors
+	for _, problem := range problems.Errors {
+		field, _ := problem.Details["field"].(string)
+		validationErrs.Errors = append(validationErrs.Errors, ValidationError{Field: field, Message: problem.Message})
+	}
+	return validationErrs
+}
+
 // handleValidationError handles validation errors
 func (h *UserHandler) handleValidationError(c echo.Context, err error) error {
 	if validationErrs, ok := err.(ValidationErrors); ok {
@@ -379,6 +466,10 @@ func (h *UserHandler) getStatusCodeForError(errorCode string) int {
 		return http.StatusConflict
 	case application.ErrCodeBusinessRule:
 		return http.StatusBadRequest
+	case application.ErrCodeExportNotFound:
+		return http.StatusNotFound
+	case application.ErrCodeExportNotReady:
+		return http.StatusConflict
 	case application.ErrCodeInternal:
 		return http.StatusInternalServerError
 	default:
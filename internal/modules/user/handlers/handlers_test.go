@@ -11,6 +11,7 @@ import (
 
 	"go-templ-template/internal/modules/user/application"
 	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/middleware"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -71,6 +72,14 @@ func (m *MockUserService) ChangeUserPassword(ctx context.Context, cmd *applicati
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserService) ResetUserPassword(ctx context.Context, cmd *application.ResetUserPasswordCommand) (*domain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
 func (m *MockUserService) ChangeUserStatus(ctx context.Context, cmd *application.ChangeUserStatusCommand) (*domain.User, error) {
 	args := m.Called(ctx, cmd)
 	if args.Get(0) == nil {
@@ -79,6 +88,30 @@ func (m *MockUserService) ChangeUserStatus(ctx context.Context, cmd *application
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserService) LockUser(ctx context.Context, cmd *application.LockUserCommand) (*domain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserService) UnlockUser(ctx context.Context, cmd *application.UnlockUserCommand) (*domain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserService) RecordLogin(ctx context.Context, cmd *application.RecordLoginCommand) (*domain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
 func (m *MockUserService) DeleteUser(ctx context.Context, cmd *application.DeleteUserCommand) error {
 	args := m.Called(ctx, cmd)
 	return args.Error(0)
@@ -268,6 +301,65 @@ func TestUserHandler_GetUser(t *testing.T) {
 	}
 }
 
+func TestUserRoutes_HideLastLoginFromOthers(t *testing.T) {
+	lastLoginAt := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	alice := &domain.User{ID: "alice", Email: "alice@example.com", FirstName: "Alice", LastName: "Doe", Status: domain.UserStatusActive, Role: domain.UserRoleUser, LastLoginAt: &lastLoginAt, LastLoginIP: "203.0.113.7"}
+	bob := &domain.User{ID: "bob", Status: domain.UserStatusActive, Role: domain.UserRoleUser}
+	admin := &domain.User{ID: "admin", Status: domain.UserStatusActive, Role: domain.UserRoleAdmin}
+
+	// get requests path, signed in as signedInAs unless it is nil
+	get := func(t *testing.T, path string, signedInAs *domain.User) map[string]interface{} {
+		mockService := &MockUserService{}
+		mockService.On("GetUser", mock.Anything, &application.GetUserQuery{ID: "alice"}).Return(alice, nil).Maybe()
+		mockService.On("GetUserByEmail", mock.Anything, &application.GetUserByEmailQuery{Email: "alice@example.com"}).Return(alice, nil).Maybe()
+
+		e := echo.New()
+		if signedInAs != nil {
+			e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set(middleware.UserContextKey, signedInAs)
+					return next(c)
+				}
+			})
+		}
+		RegisterUserHandlerOnGroup(e.Group("/api/v1"), NewUserHandler(mockService), nil)
+
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "alice", body["id"])
+		return body
+	}
+
+	for _, path := range []string{"/api/v1/users/alice", "/api/v1/users/by-email/alice@example.com"} {
+		t.Run("anonymous "+path, func(t *testing.T) {
+			body := get(t, path, nil)
+			assert.NotContains(t, body, "last_login_ip")
+			assert.NotContains(t, body, "last_login_at")
+		})
+
+		t.Run("other user "+path, func(t *testing.T) {
+			body := get(t, path, bob)
+			assert.NotContains(t, body, "last_login_ip")
+		})
+	}
+
+	t.Run("self", func(t *testing.T) {
+		body := get(t, "/api/v1/users/alice", alice)
+		assert.Equal(t, "203.0.113.7", body["last_login_ip"])
+		assert.Contains(t, body, "last_login_at")
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		body := get(t, "/api/v1/users/alice", admin)
+		assert.Equal(t, "203.0.113.7", body["last_login_ip"])
+	})
+}
+
 func TestUserHandler_UpdateUser(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -469,9 +561,38 @@ func TestUserHandler_ListUsers(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:        "limit above the maximum is clamped",
+			queryParams: "?limit=200",
+			setupMock: func(service *MockUserService) {
+				service.On("ListUsers", mock.Anything, mock.MatchedBy(func(query *application.ListUsersQuery) bool {
+					return query.Limit == 100 && query.Offset == 0
+				})).Return([]*domain.User{}, int64(0), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "search, sort and page",
+			queryParams: "?search=jane&sort=-last_name&page=3&limit=5",
+			setupMock: func(service *MockUserService) {
+				service.On("ListUsers", mock.Anything, mock.MatchedBy(func(query *application.ListUsersQuery) bool {
+					return query.Search != nil && *query.Search == "jane" &&
+						query.SortBy == "last_name" && query.SortDesc &&
+						query.Limit == 5 && query.Offset == 10
+				})).Return([]*domain.User{}, int64(0), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown sort field",
+			queryParams:    "?sort=password_hash",
+			setupMock:      func(service *MockUserService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "VALIDATION_ERROR",
+		},
 		{
 			name:           "invalid limit",
-			queryParams:    "?limit=200",
+			queryParams:    "?limit=many",
 			setupMock:      func(service *MockUserService) {},
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  "VALIDATION_ERROR",
@@ -512,3 +633,43 @@ func TestUserHandler_ListUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterUserHandlerOnGroup_ListUsersRequiresAdmin(t *testing.T) {
+	tests := []struct {
+		name           string
+		role           domain.UserRole
+		expectedStatus int
+	}{
+		{"admin", domain.UserRoleAdmin, http.StatusOK},
+		{"regular user", domain.UserRoleUser, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockUserService{}
+			mockService.On("ListUsers", mock.Anything, mock.Anything).Return([]*domain.User{}, int64(0), nil).Maybe()
+
+			e := echo.New()
+			e.HTTPErrorHandler = middleware.CustomErrorHandler(middleware.ErrorHandlerConfig{JSONAPIErrors: true})
+
+			signedInAs := &domain.User{ID: "user-123", Status: domain.UserStatusActive, Role: tt.role}
+			authenticate := func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set(middleware.UserContextKey, signedInAs)
+					return next(c)
+				}
+			}
+			RegisterUserHandlerOnGroup(e.Group("/api/v1"), NewUserHandler(mockService), authenticate)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+			req.Header.Set("Accept", echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusForbidden {
+				mockService.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
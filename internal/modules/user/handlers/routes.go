@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"go-templ-template/internal/modules/user/application"
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/middleware"
 
 	"github.com/labstack/echo/v4"
 )
@@ -9,6 +11,7 @@ import (
 // RegisterUserRoutes registers all user-related routes
 func RegisterUserRoutes(e *echo.Echo, userService application.UserService) {
 	userHandler := NewUserHandler(userService)
+	requireAdmin := middleware.RequireRole(string(domain.UserRoleAdmin))
 
 	// API v1 routes
 	v1 := e.Group("/api/v1")
@@ -16,60 +19,102 @@ func RegisterUserRoutes(e *echo.Echo, userService application.UserService) {
 	// User routes
 	users := v1.Group("/users")
 	{
-		users.POST("", userHandler.CreateUser)                     // POST /api/v1/users
-		users.GET("", userHandler.ListUsers)                       // GET /api/v1/users
-		users.GET("/:id", userHandler.GetUser)                     // GET /api/v1/users/:id
-		users.GET("/by-email/:email", userHandler.GetUserByEmail)  // GET /api/v1/users/by-email/:email
-		users.PUT("/:id", userHandler.UpdateUser)                  // PUT /api/v1/users/:id
-		users.PUT("/:id/email", userHandler.UpdateUserEmail)       // PUT /api/v1/users/:id/email
-		users.PUT("/:id/password", userHandler.ChangeUserPassword) // PUT /api/v1/users/:id/password
-		users.PUT("/:id/status", userHandler.ChangeUserStatus)     // PUT /api/v1/users/:id/status
-		users.DELETE("/:id", userHandler.DeleteUser)               // DELETE /api/v1/users/:id
+		users.POST("", userHandler.CreateUser)                               // POST /api/v1/users
+		users.GET("", userHandler.ListUsers)                                 // GET /api/v1/users
+		users.GET("/:id", userHandler.GetUser)                               // GET /api/v1/users/:id
+		users.GET("/by-email/:email", userHandler.GetUserByEmail)            // GET /api/v1/users/by-email/:email
+		users.PUT("/:id", userHandler.UpdateUser)                            // PUT /api/v1/users/:id
+		users.PUT("/:id/email", userHandler.UpdateUserEmail)                 // PUT /api/v1/users/:id/email
+		users.PUT("/:id/password", userHandler.ChangeUserPassword)           // PUT /api/v1/users/:id/password
+		users.PUT("/:id/status", userHandler.ChangeUserStatus, requireAdmin) // PUT /api/v1/users/:id/status
+		users.DELETE("/:id", userHandler.DeleteUser)                         // DELETE /api/v1/users/:id
 	}
 }
 
 // RegisterUserRoutesWithMiddleware registers user routes with custom middleware
 func RegisterUserRoutesWithMiddleware(e *echo.Echo, userService application.UserService, middlewares ...echo.MiddlewareFunc) {
 	userHandler := NewUserHandler(userService)
+	requireAdmin := middleware.RequireRole(string(domain.UserRoleAdmin))
 
 	// API v1 routes
 	v1 := e.Group("/api/v1")
 
 	// Apply middleware to the group
-	for _, middleware := range middlewares {
-		v1.Use(middleware)
+	for _, m := range middlewares {
+		v1.Use(m)
 	}
 
 	// User routes
 	users := v1.Group("/users")
 	{
-		users.POST("", userHandler.CreateUser)                     // POST /api/v1/users
-		users.GET("", userHandler.ListUsers)                       // GET /api/v1/users
-		users.GET("/:id", userHandler.GetUser)                     // GET /api/v1/users/:id
-		users.GET("/by-email/:email", userHandler.GetUserByEmail)  // GET /api/v1/users/by-email/:email
-		users.PUT("/:id", userHandler.UpdateUser)                  // PUT /api/v1/users/:id
-		users.PUT("/:id/email", userHandler.UpdateUserEmail)       // PUT /api/v1/users/:id/email
-		users.PUT("/:id/password", userHandler.ChangeUserPassword) // PUT /api/v1/users/:id/password
-		users.PUT("/:id/status", userHandler.ChangeUserStatus)     // PUT /api/v1/users/:id/status
-		users.DELETE("/:id", userHandler.DeleteUser)               // DELETE /api/v1/users/:id
+		users.POST("", userHandler.CreateUser)                               // POST /api/v1/users
+		users.GET("", userHandler.ListUsers)                                 // GET /api/v1/users
+		users.GET("/:id", userHandler.GetUser)                               // GET /api/v1/users/:id
+		users.GET("/by-email/:email", userHandler.GetUserByEmail)            // GET /api/v1/users/by-email/:email
+		users.PUT("/:id", userHandler.UpdateUser)                            // PUT /api/v1/users/:id
+		users.PUT("/:id/email", userHandler.UpdateUserEmail)                 // PUT /api/v1/users/:id/email
+		users.PUT("/:id/password", userHandler.ChangeUserPassword)           // PUT /api/v1/users/:id/password
+		users.PUT("/:id/status", userHandler.ChangeUserStatus, requireAdmin) // PUT /api/v1/users/:id/status
+		users.DELETE("/:id", userHandler.DeleteUser)                         // DELETE /api/v1/users/:id
 	}
 }
 
 // RegisterUserRoutesOnGroup registers user routes on a provided group (for module system)
 func RegisterUserRoutesOnGroup(group *echo.Group, userService application.UserService) {
-	userHandler := NewUserHandler(userService)
+	RegisterUserHandlerOnGroup(group, NewUserHandler(userService), nil)
+}
 
+// RegisterUserHandlerOnGroup registers the routes of a configured user handler
+// on a provided group. authMiddleware authenticates the routes that act on
+// the signed-in user, e.g. avatar uploads, data exports and account deletion;
+// without it they respond 401. With it, listing users and changing their
+// status are limited to admins,
+// users are edited and deleted as allowed by the handler's policy, and scoped
+// credentials such as API keys need the users:read scope to read and the
+// users:write scope to write.
+func RegisterUserHandlerOnGroup(group *echo.Group, userHandler *UserHandler, authMiddleware echo.MiddlewareFunc) {
 	// User routes - group is already /api/v1, so we create /users subgroup
 	users := group.Group("/users")
 	{
-		users.POST("", userHandler.CreateUser)                     // POST /api/v1/users
-		users.GET("", userHandler.ListUsers)                       // GET /api/v1/users
-		users.GET("/:id", userHandler.GetUser)                     // GET /api/v1/users/:id
-		users.GET("/by-email/:email", userHandler.GetUserByEmail)  // GET /api/v1/users/by-email/:email
-		users.PUT("/:id", userHandler.UpdateUser)                  // PUT /api/v1/users/:id
-		users.PUT("/:id/email", userHandler.UpdateUserEmail)       // PUT /api/v1/users/:id/email
-		users.PUT("/:id/password", userHandler.ChangeUserPassword) // PUT /api/v1/users/:id/password
-		users.PUT("/:id/status", userHandler.ChangeUserStatus)     // PUT /api/v1/users/:id/status
-		users.DELETE("/:id", userHandler.DeleteUser)               // DELETE /api/v1/users/:id
+		users.POST("", userHandler.CreateUser)                    // POST /api/v1/users
+		users.GET("/:id", userHandler.GetUser)                    // GET /api/v1/users/:id
+		users.GET("/by-email/:email", userHandler.GetUserByEmail) // GET /api/v1/users/by-email/:email
+	}
+
+	// Changing status, e.g. to lock or unlock an account, is limited to
+	// admins; without authMiddleware it always responds 401
+	requireAdmin := middleware.RequireRole(string(domain.UserRoleAdmin))
+	changeStatus := []echo.MiddlewareFunc{requireAdmin}
+
+	var readOwn, writeOwn []echo.MiddlewareFunc
+	var listUsers, editUser, deleteUser []echo.MiddlewareFunc
+	if authMiddleware != nil {
+		readScope := middleware.RequireScope(domain.ScopeUsersRead)
+		writeScope := middleware.RequireScope(domain.ScopeUsersWrite)
+		readOwn = []echo.MiddlewareFunc{authMiddleware, readScope}
+		writeOwn = []echo.MiddlewareFunc{authMiddleware, writeScope}
+		listUsers = []echo.MiddlewareFunc{authMiddleware, readScope, requireAdmin}
+		changeStatus = []echo.MiddlewareFunc{authMiddleware, writeScope, requireAdmin}
+		editUser = []echo.MiddlewareFunc{authMiddleware, writeScope, middleware.Authorize(userHandler.policy, domain.ActionUserEdit, userHandler.loadPathUser)}
+		deleteUser = []echo.MiddlewareFunc{authMiddleware, writeScope, middleware.Authorize(userHandler.policy, domain.ActionUserDelete, userHandler.loadPathUser)}
+	}
+
+	users.GET("", userHandler.ListUsers, listUsers...)                      // GET /api/v1/users
+	users.PUT("/:id", userHandler.UpdateUser, editUser...)                  // PUT /api/v1/users/:id
+	users.PUT("/:id/email", userHandler.UpdateUserEmail, editUser...)       // PUT /api/v1/users/:id/email
+	users.PUT("/:id/password", userHandler.ChangeUserPassword, editUser...) // PUT /api/v1/users/:id/password
+	users.PUT("/:id/status", userHandler.ChangeUserStatus, changeStatus...) // PUT /api/v1/users/:id/status
+	users.DELETE("/:id", userHandler.deleteUserOrAccount, deleteUser...)    // DELETE /api/v1/users/:id
+
+	// Avatar uploads, when the handler has an avatar service
+	if userHandler.avatarUploadsEnabled() {
+		users.POST("/:id/avatar", userHandler.UploadAvatar, writeOwn...) // POST /api/v1/users/:id/avatar
+	}
+
+	// Data exports, when the handler has a data export service
+	if userHandler.dataExportsEnabled() {
+		users.POST("/:id/export", userHandler.RequestDataExport, writeOwn...)                // POST /api/v1/users/:id/export
+		users.GET("/:id/export/:jobId", userHandler.GetDataExport, readOwn...)               // GET /api/v1/users/:id/export/:jobId
+		users.GET("/:id/export/:jobId/download", userHandler.DownloadDataExport, readOwn...) // GET /api/v1/users/:id/export/:jobId/download
 	}
 }
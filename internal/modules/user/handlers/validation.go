@@ -195,6 +195,27 @@ func ValidateChangeUserPasswordRequest(req *ChangeUserPasswordRequest) error {
 	return nil
 }
 
+// ValidateDeleteAccountRequest validates the delete account request
+func ValidateDeleteAccountRequest(req *DeleteAccountRequest) error {
+	var errors []ValidationError
+
+	// Validate password
+	if req.Password == "" {
+		errors = append(errors, ValidationError{Field: "password", Message: "password is required"})
+	}
+
+	// Validate reason
+	if len(req.Reason) > 500 {
+		errors = append(errors, ValidationError{Field: "reason", Message: "reason cannot exceed 500 characters"})
+	}
+
+	if len(errors) > 0 {
+		return ValidationErrors{Errors: errors}
+	}
+
+	return nil
+}
+
 // ValidateChangeUserStatusRequest validates the change user status request
 func ValidateChangeUserStatusRequest(req *ChangeUserStatusRequest) error {
 	var errors []ValidationError
@@ -260,6 +281,8 @@ func BindAndValidate(c echo.Context, req interface{}) error {
 		return ValidateUpdateUserEmailRequest(v)
 	case *ChangeUserPasswordRequest:
 		return ValidateChangeUserPasswordRequest(v)
+	case *DeleteAccountRequest:
+		return ValidateDeleteAccountRequest(v)
 	case *ChangeUserStatusRequest:
 		return ValidateChangeUserStatusRequest(v)
 	case *ListUsersRequest:
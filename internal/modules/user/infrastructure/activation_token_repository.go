@@ -29,7 +29,7 @@ func (r *activationTokenRepositoryImpl) Create(ctx context.Context, token *domai
 		INSERT INTO activation_tokens (id, user_id, token, expires_at, created_at)
 		VALUES (:id, :user_id, :token, :expires_at, :created_at)`
 
-	_, err := r.db.NamedExecContext(ctx, query, token)
+	_, err := database.GetExecutor(ctx, r.db).NamedExecContext(ctx, query, token)
 	if err != nil {
 		return fmt.Errorf("failed to create activation token: %w", err)
 	}
@@ -45,7 +45,7 @@ func (r *activationTokenRepositoryImpl) GetByToken(ctx context.Context, token st
 		WHERE token = $1`
 
 	var activationToken domain.ActivationToken
-	err := r.db.GetContext(ctx, &activationToken, query, token)
+	err := database.GetExecutor(ctx, r.db).GetContext(ctx, &activationToken, query, token)
 	if err != nil {
 		if database.IsNotFoundError(err) {
 			return nil, database.ErrNotFound
@@ -65,7 +65,7 @@ func (r *activationTokenRepositoryImpl) GetByUserID(ctx context.Context, userID
 		ORDER BY created_at DESC`
 
 	var tokens []*domain.ActivationToken
-	err := r.db.SelectContext(ctx, &tokens, query, userID)
+	err := database.GetExecutor(ctx, r.db).SelectContext(ctx, &tokens, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get activation tokens by user ID: %w", err)
 	}
@@ -73,14 +73,15 @@ func (r *activationTokenRepositoryImpl) GetByUserID(ctx context.Context, userID
 	return tokens, nil
 }
 
-// Update updates an activation token
+// Update records an activation token's use. Tokens are single-use, so
+// updating an already used token returns database.ErrNotFound.
 func (r *activationTokenRepositoryImpl) Update(ctx context.Context, token *domain.ActivationToken) error {
 	query := `
 		UPDATE activation_tokens
 		SET used_at = :used_at
-		WHERE id = :id`
+		WHERE id = :id AND used_at IS NULL`
 
-	result, err := r.db.NamedExecContext(ctx, query, token)
+	result, err := database.GetExecutor(ctx, r.db).NamedExecContext(ctx, query, token)
 	if err != nil {
 		return fmt.Errorf("failed to update activation token: %w", err)
 	}
@@ -101,7 +102,7 @@ func (r *activationTokenRepositoryImpl) Update(ctx context.Context, token *domai
 func (r *activationTokenRepositoryImpl) Delete(ctx context.Context, tokenID string) error {
 	query := `DELETE FROM activation_tokens WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, tokenID)
+	result, err := database.GetExecutor(ctx, r.db).ExecContext(ctx, query, tokenID)
 	if err != nil {
 		return fmt.Errorf("failed to delete activation token: %w", err)
 	}
@@ -122,7 +123,7 @@ func (r *activationTokenRepositoryImpl) Delete(ctx context.Context, tokenID stri
 func (r *activationTokenRepositoryImpl) DeleteByUserID(ctx context.Context, userID string) error {
 	query := `DELETE FROM activation_tokens WHERE user_id = $1`
 
-	_, err := r.db.ExecContext(ctx, query, userID)
+	_, err := database.GetExecutor(ctx, r.db).ExecContext(ctx, query, userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete activation tokens by user ID: %w", err)
 	}
@@ -134,7 +135,7 @@ func (r *activationTokenRepositoryImpl) DeleteByUserID(ctx context.Context, user
 func (r *activationTokenRepositoryImpl) DeleteExpired(ctx context.Context) error {
 	query := `DELETE FROM activation_tokens WHERE expires_at < $1`
 
-	result, err := r.db.ExecContext(ctx, query, time.Now().UTC())
+	result, err := database.GetExecutor(ctx, r.db).ExecContext(ctx, query, time.Now().UTC())
 	if err != nil {
 		return fmt.Errorf("failed to delete expired activation tokens: %w", err)
 	}
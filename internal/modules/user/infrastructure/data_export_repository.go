@@ -0,0 +1,78 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+
+	"go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/database"
+)
+
+// dataExportRepositoryImpl implements the DataExportRepository interface
+type dataExportRepositoryImpl struct {
+	db *database.DB
+}
+
+// NewDataExportRepository creates a new data export repository instance
+func NewDataExportRepository(db *database.DB) domain.DataExportRepository {
+	return &dataExportRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create creates a new data export
+func (r *dataExportRepositoryImpl) Create(ctx context.Context, export *domain.DataExport) error {
+	query := `
+		INSERT INTO data_exports (id, user_id, status, blob_key, error, requested_at, completed_at)
+		VALUES (:id, :user_id, :status, :blob_key, :error, :requested_at, :completed_at)`
+
+	_, err := database.GetExecutor(ctx, r.db).NamedExecContext(ctx, query, export)
+	if err != nil {
+		return fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves a data export by ID
+func (r *dataExportRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.DataExport, error) {
+	query := `
+		SELECT id, user_id, status, blob_key, error, requested_at, completed_at
+		FROM data_exports
+		WHERE id = $1`
+
+	var export domain.DataExport
+	err := database.GetExecutor(ctx, r.db).GetContext(ctx, &export, query, id)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+
+	return &export, nil
+}
+
+// Update records the outcome of a data export
+func (r *dataExportRepositoryImpl) Update(ctx context.Context, export *domain.DataExport) error {
+	query := `
+		UPDATE data_exports
+		SET status = :status, blob_key = :blob_key, error = :error, completed_at = :completed_at
+		WHERE id = :id`
+
+	result, err := database.GetExecutor(ctx, r.db).NamedExecContext(ctx, query, export)
+	if err != nil {
+		return fmt.Errorf("failed to update data export: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNotFound
+	}
+
+	return nil
+}
@@ -3,11 +3,15 @@ package infrastructure
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"go-templ-template/internal/modules/user/domain"
 	"go-templ-template/internal/shared/database"
+	appErrors "go-templ-template/internal/shared/errors"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -18,6 +22,9 @@ type UserRepository interface {
 	// Create inserts a new user into the database
 	Create(ctx context.Context, user *domain.User) error
 
+	// CreateBatch inserts multiple users in a single transaction
+	CreateBatch(ctx context.Context, users []*domain.User) error
+
 	// GetByID retrieves a user by their ID
 	GetByID(ctx context.Context, id string) (*domain.User, error)
 
@@ -33,6 +40,10 @@ type UserRepository interface {
 	// List retrieves users with pagination and optional filtering
 	List(ctx context.Context, filter UserFilter, limit, offset int) ([]*domain.User, error)
 
+	// ListByCursor retrieves a page of users after the given cursor and
+	// returns the cursor for the next page, empty when there are no more
+	ListByCursor(ctx context.Context, filter UserFilter, cursor string, limit int) ([]*domain.User, string, error)
+
 	// Count returns the total number of users matching the filter
 	Count(ctx context.Context, filter UserFilter) (int64, error)
 
@@ -51,31 +62,68 @@ type UserFilter struct {
 	LastName      *string
 	CreatedAfter  *string
 	CreatedBefore *string
+
+	// Search matches users whose email or name contains it
+	Search *string
+
+	// SortBy orders List results by one of UserSortFields, newest first
+	// when empty; Count ignores it
+	SortBy   string
+	SortDesc bool
 }
 
+// UserSortFields are the fields users can be listed in order of
+var UserSortFields = []string{"created_at", "email", "first_name", "last_name"}
+
 // userRepositoryImpl implements the UserRepository interface using SQLx
 type userRepositoryImpl struct {
 	*database.BaseRepository[domain.User, string]
+	emailNormalizer domain.EmailNormalizer
 }
 
-// NewUserRepository creates a new user repository instance
+// NewUserRepository creates a new user repository instance that matches
+// emails case-insensitively
 func NewUserRepository(db *database.DB) UserRepository {
+	return NewUserRepositoryWithEmailNormalizer(db, domain.NewEmailNormalizer(false))
+}
+
+// NewUserRepositoryWithEmailNormalizer creates a new user repository instance
+// that matches emails by their emailNormalizer form. When it folds Gmail
+// aliases, stored emails are folded in the query as well, so users saved
+// before folding was enabled are still found by any alias of their address.
+func NewUserRepositoryWithEmailNormalizer(db *database.DB, emailNormalizer domain.EmailNormalizer) UserRepository {
 	baseRepo := database.NewBaseRepository[domain.User, string](db, "users", "id")
 	return &userRepositoryImpl{
-		BaseRepository: baseRepo,
+		BaseRepository:  baseRepo,
+		emailNormalizer: emailNormalizer,
 	}
 }
 
+// emailColumn returns the SQL expression emails are compared by, matching
+// the form the email normalizer produces
+func (r *userRepositoryImpl) emailColumn() string {
+	if r.emailNormalizer.FoldsGmailAliases() {
+		return "fold_gmail_alias(email)"
+	}
+	return "email"
+}
+
 // Create inserts a new user into the database
 func (r *userRepositoryImpl) Create(ctx context.Context, user *domain.User) error {
+	ctx, span := database.StartQuerySpan(ctx, r.GetTableName(), "Create")
+	defer span.End()
+
 	// Generate UUID if not provided
 	if user.ID == "" {
 		user.ID = uuid.New().String()
 	}
+	if user.Role == "" {
+		user.Role = domain.UserRoleUser
+	}
 
 	query := `
-		INSERT INTO users (id, email, password_hash, first_name, last_name, status, created_at, updated_at, version)
-		VALUES (:id, :email, :password, :first_name, :last_name, :status, :created_at, :updated_at, :version)`
+		INSERT INTO users (id, email, password_hash, first_name, last_name, status, role, created_at, updated_at, version)
+		VALUES (:id, :email, :password, :first_name, :last_name, :status, :role, :created_at, :updated_at, :version)`
 
 	err := r.BaseRepository.Create(ctx, user, query)
 	if err != nil {
@@ -85,12 +133,135 @@ func (r *userRepositoryImpl) Create(ctx context.Context, user *domain.User) erro
 	return nil
 }
 
+// createBatchSize is the maximum number of users inserted per statement,
+// keeping well below PostgreSQL's limit on bind parameters
+const createBatchSize = 1000
+
+// CreateBatch inserts users with multi-row statements inside one transaction.
+// The batch is all or nothing: if any email is already taken, or repeated
+// within the batch, no users are inserted and an *errors.ErrorList is returned
+// with one entry per conflicting user, identified by its index in the batch.
+func (r *userRepositoryImpl) CreateBatch(ctx context.Context, users []*domain.User) error {
+	ctx, span := database.StartQuerySpan(ctx, r.GetTableName(), "CreateBatch")
+	defer span.End()
+
+	if len(users) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	emails := make([]string, len(users))
+	for i, user := range users {
+		if user.ID == "" {
+			user.ID = uuid.New().String()
+		}
+		if user.Version < 1 {
+			user.Version = 1
+		}
+		if user.Role == "" {
+			user.Role = domain.UserRoleUser
+		}
+		if user.CreatedAt.IsZero() {
+			user.CreatedAt = now
+		}
+		if user.UpdatedAt.IsZero() {
+			user.UpdatedAt = user.CreatedAt
+		}
+		user.Email = r.emailNormalizer.Normalize(user.Email)
+		emails[i] = user.Email
+	}
+
+	return database.ExecuteInTransaction(ctx, r.GetDB(), func(txCtx context.Context) error {
+		// Queries run in the transaction stored in txCtx
+		db := r.GetDB().Timed()
+
+		var existing []string
+		query := fmt.Sprintf(`SELECT %[1]s FROM users WHERE %[1]s = ANY($1)`, r.emailColumn())
+		if err := db.SelectContext(txCtx, "users.CreateBatch", &existing, query, pq.Array(emails)); err != nil {
+			return r.handleError("CreateBatch", err)
+		}
+
+		if conflicts := batchEmailConflicts(users, existing); conflicts.HasErrors() {
+			return conflicts
+		}
+
+		for start := 0; start < len(users); start += createBatchSize {
+			end := start + createBatchSize
+			if end > len(users) {
+				end = len(users)
+			}
+
+			query, args := buildBatchInsertQuery(users[start:end])
+			if _, err := db.ExecContext(txCtx, "users.CreateBatch", query, args...); err != nil {
+				return r.handleError("CreateBatch", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// batchEmailConflicts reports users whose email already exists or appears
+// earlier in the batch
+func batchEmailConflicts(users []*domain.User, existing []string) *appErrors.ErrorList {
+	taken := make(map[string]bool, len(existing)+len(users))
+	for _, email := range existing {
+		taken[email] = true
+	}
+
+	conflicts := &appErrors.ErrorList{}
+	for i, user := range users {
+		if taken[user.Email] {
+			conflicts.Add(appErrors.NewEmailAlreadyExistsError(user.Email).
+				WithDetails(map[string]interface{}{
+					"index":   i,
+					"user_id": user.ID,
+				}))
+			continue
+		}
+		taken[user.Email] = true
+	}
+
+	return conflicts
+}
+
+// buildBatchInsertQuery constructs a multi-row INSERT for the given users
+func buildBatchInsertQuery(users []*domain.User) (string, []interface{}) {
+	const columns = 10
+
+	values := make([]string, len(users))
+	args := make([]interface{}, 0, len(users)*columns)
+
+	for i, user := range users {
+		base := i * columns
+		placeholders := make([]string, columns)
+		for j := range placeholders {
+			placeholders[j] = fmt.Sprintf("$%d", base+j+1)
+		}
+		values[i] = "(" + strings.Join(placeholders, ", ") + ")"
+
+		args = append(args,
+			user.ID, user.Email, user.Password, user.FirstName, user.LastName,
+			string(user.Status), string(user.Role), user.CreatedAt, user.UpdatedAt, user.Version,
+		)
+	}
+
+	query := `
+		INSERT INTO users (id, email, password_hash, first_name, last_name, status, role, created_at, updated_at, version)
+		VALUES ` + strings.Join(values, ", ")
+
+	return query, args
+}
+
 // GetByID retrieves a user by their ID
 func (r *userRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	ctx, span := database.StartQuerySpan(ctx, r.GetTableName(), "GetByID")
+	defer span.End()
+
 	query := `
-		SELECT id, email, password_hash as password, first_name, last_name, status, created_at, updated_at, version
+		SELECT id, email, password_hash as password, first_name, last_name, status, role, avatar_url, created_at, updated_at, deleted_at, version, last_login_at, last_login_ip, password_changed_at
 		FROM users 
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL`
 
 	user, err := r.BaseRepository.GetByID(ctx, id, query)
 	if err != nil {
@@ -102,21 +273,20 @@ func (r *userRepositoryImpl) GetByID(ctx context.Context, id string) (*domain.Us
 
 // GetByEmail retrieves a user by their email address
 func (r *userRepositoryImpl) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	query := `
-		SELECT id, email, password_hash as password, first_name, last_name, status, created_at, updated_at, version
+	ctx, span := database.StartQuerySpan(ctx, r.GetTableName(), "GetByEmail")
+	defer span.End()
+
+	// Addresses stored before Gmail alias folding was enabled may fold to the
+	// same address; the one matching email exactly wins, then the oldest
+	query := fmt.Sprintf(`
+		SELECT id, email, password_hash as password, first_name, last_name, status, role, avatar_url, created_at, updated_at, deleted_at, version, last_login_at, last_login_ip, password_changed_at
 		FROM users 
-		WHERE email = $1`
+		WHERE %s = $1 AND deleted_at IS NULL
+		ORDER BY (email = $2) DESC, created_at ASC
+		LIMIT 1`, r.emailColumn())
 
 	var user domain.User
-	tx := database.GetTxFromContext(ctx)
-
-	var err error
-	if tx != nil {
-		err = tx.GetContext(ctx, &user, query, strings.ToLower(email))
-	} else {
-		err = r.GetDB().GetContext(ctx, &user, query, strings.ToLower(email))
-	}
-
+	err := r.GetDB().Timed().GetContext(ctx, "users.GetByEmail", &user, query, r.emailNormalizer.Normalize(email), domain.NormalizeEmail(email))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, database.ErrNotFound
@@ -129,6 +299,9 @@ func (r *userRepositoryImpl) GetByEmail(ctx context.Context, email string) (*dom
 
 // Update updates an existing user with optimistic locking
 func (r *userRepositoryImpl) Update(ctx context.Context, user *domain.User) error {
+	ctx, span := database.StartQuerySpan(ctx, r.GetTableName(), "Update")
+	defer span.End()
+
 	query := `
 		UPDATE users 
 		SET email = :email, 
@@ -136,20 +309,17 @@ func (r *userRepositoryImpl) Update(ctx context.Context, user *domain.User) erro
 		    first_name = :first_name, 
 		    last_name = :last_name, 
 		    status = :status, 
+		    role = :role, 
+		    avatar_url = :avatar_url, 
+		    last_login_at = :last_login_at, 
+		    last_login_ip = :last_login_ip, 
+		    password_changed_at = :password_changed_at, 
 		    updated_at = :updated_at, 
+		    deleted_at = :deleted_at, 
 		    version = :version
 		WHERE id = :id AND version = :version - 1`
 
-	tx := database.GetTxFromContext(ctx)
-	var result sql.Result
-	var err error
-
-	if tx != nil {
-		result, err = tx.NamedExecContext(ctx, query, user)
-	} else {
-		result, err = r.GetDB().NamedExecContext(ctx, query, user)
-	}
-
+	result, err := r.GetDB().Timed().NamedExecContext(ctx, "users.Update", query, user)
 	if err != nil {
 		return r.handleError("Update", err)
 	}
@@ -176,6 +346,9 @@ func (r *userRepositoryImpl) Update(ctx context.Context, user *domain.User) erro
 
 // Delete removes a user by their ID
 func (r *userRepositoryImpl) Delete(ctx context.Context, id string) error {
+	ctx, span := database.StartQuerySpan(ctx, r.GetTableName(), "Delete")
+	defer span.End()
+
 	query := `DELETE FROM users WHERE id = $1`
 
 	err := r.BaseRepository.Delete(ctx, id, query)
@@ -188,39 +361,67 @@ func (r *userRepositoryImpl) Delete(ctx context.Context, id string) error {
 
 // List retrieves users with pagination and optional filtering
 func (r *userRepositoryImpl) List(ctx context.Context, filter UserFilter, limit, offset int) ([]*domain.User, error) {
+	ctx, span := database.StartQuerySpan(ctx, r.GetTableName(), "List")
+	defer span.End()
+
 	query, args := r.buildListQuery(filter, limit, offset)
 
 	var users []*domain.User
-	tx := database.GetTxFromContext(ctx)
+	err := r.GetDB().Timed().SelectContext(ctx, "users.List", &users, query, args...)
+	if err != nil {
+		return nil, r.handleError("List", err)
+	}
+
+	return users, nil
+}
 
-	var err error
-	if tx != nil {
-		err = tx.SelectContext(ctx, &users, query, args...)
-	} else {
-		err = r.GetDB().SelectContext(ctx, &users, query, args...)
+// ListByCursor retrieves users in the same order as List, using keyset
+// pagination on (created_at, id) so pages stay stable under concurrent inserts.
+// An empty cursor starts from the beginning.
+func (r *userRepositoryImpl) ListByCursor(ctx context.Context, filter UserFilter, cursor string, limit int) ([]*domain.User, string, error) {
+	ctx, span := database.StartQuerySpan(ctx, r.GetTableName(), "ListByCursor")
+	defer span.End()
+
+	if limit <= 0 {
+		return nil, "", database.NewDatabaseError("ListByCursor", "users", fmt.Errorf("%w: limit must be positive", database.ErrInvalidInput))
+	}
+
+	var after *userCursor
+	if cursor != "" {
+		decoded, err := decodeUserCursor(cursor)
+		if err != nil {
+			return nil, "", database.NewDatabaseError("ListByCursor", "users", err)
+		}
+		after = decoded
 	}
 
+	// Fetch one extra row to find out whether another page exists
+	query, args := r.buildCursorQuery(filter, after, limit+1)
+
+	var users []*domain.User
+	err := r.GetDB().Timed().SelectContext(ctx, "users.ListByCursor", &users, query, args...)
 	if err != nil {
-		return nil, r.handleError("List", err)
+		return nil, "", r.handleError("ListByCursor", err)
 	}
 
-	return users, nil
+	if len(users) <= limit {
+		return users, "", nil
+	}
+
+	users = users[:limit]
+	last := users[len(users)-1]
+	return users, encodeUserCursor(userCursor{CreatedAt: last.CreatedAt, ID: last.ID}), nil
 }
 
 // Count returns the total number of users matching the filter
 func (r *userRepositoryImpl) Count(ctx context.Context, filter UserFilter) (int64, error) {
+	ctx, span := database.StartQuerySpan(ctx, r.GetTableName(), "Count")
+	defer span.End()
+
 	query, args := r.buildCountQuery(filter)
 
 	var count int64
-	tx := database.GetTxFromContext(ctx)
-
-	var err error
-	if tx != nil {
-		err = tx.GetContext(ctx, &count, query, args...)
-	} else {
-		err = r.GetDB().GetContext(ctx, &count, query, args...)
-	}
-
+	err := r.GetDB().Timed().GetContext(ctx, "users.Count", &count, query, args...)
 	if err != nil {
 		return 0, r.handleError("Count", err)
 	}
@@ -230,18 +431,13 @@ func (r *userRepositoryImpl) Count(ctx context.Context, filter UserFilter) (int6
 
 // Exists checks if a user exists by ID
 func (r *userRepositoryImpl) Exists(ctx context.Context, id string) (bool, error) {
+	ctx, span := database.StartQuerySpan(ctx, r.GetTableName(), "Exists")
+	defer span.End()
+
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`
 
 	var exists bool
-	tx := database.GetTxFromContext(ctx)
-
-	var err error
-	if tx != nil {
-		err = tx.GetContext(ctx, &exists, query, id)
-	} else {
-		err = r.GetDB().GetContext(ctx, &exists, query, id)
-	}
-
+	err := r.GetDB().Timed().GetContext(ctx, "users.Exists", &exists, query, id)
 	if err != nil {
 		return false, r.handleError("Exists", err)
 	}
@@ -251,18 +447,13 @@ func (r *userRepositoryImpl) Exists(ctx context.Context, id string) (bool, error
 
 // ExistsByEmail checks if a user exists by email
 func (r *userRepositoryImpl) ExistsByEmail(ctx context.Context, email string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)`
-
-	var exists bool
-	tx := database.GetTxFromContext(ctx)
+	ctx, span := database.StartQuerySpan(ctx, r.GetTableName(), "ExistsByEmail")
+	defer span.End()
 
-	var err error
-	if tx != nil {
-		err = tx.GetContext(ctx, &exists, query, strings.ToLower(email))
-	} else {
-		err = r.GetDB().GetContext(ctx, &exists, query, strings.ToLower(email))
-	}
+	query := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM users WHERE %s = $1)`, r.emailColumn())
 
+	var exists bool
+	err := r.GetDB().Timed().GetContext(ctx, "users.ExistsByEmail", &exists, query, r.emailNormalizer.Normalize(email))
 	if err != nil {
 		return false, r.handleError("ExistsByEmail", err)
 	}
@@ -273,7 +464,7 @@ func (r *userRepositoryImpl) ExistsByEmail(ctx context.Context, email string) (b
 // buildListQuery constructs the SQL query for listing users with filters
 func (r *userRepositoryImpl) buildListQuery(filter UserFilter, limit, offset int) (string, []interface{}) {
 	query := `
-		SELECT id, email, password_hash as password, first_name, last_name, status, created_at, updated_at, version
+		SELECT id, email, password_hash as password, first_name, last_name, status, role, avatar_url, created_at, updated_at, deleted_at, version, last_login_at, last_login_ip, password_changed_at
 		FROM users`
 
 	whereClause, args := r.buildWhereClause(filter)
@@ -281,7 +472,7 @@ func (r *userRepositoryImpl) buildListQuery(filter UserFilter, limit, offset int
 		query += " WHERE " + whereClause
 	}
 
-	query += " ORDER BY created_at DESC"
+	query += " ORDER BY " + userOrderBy(filter)
 
 	// Add pagination
 	argIndex := len(args) + 1
@@ -291,6 +482,53 @@ func (r *userRepositoryImpl) buildListQuery(filter UserFilter, limit, offset int
 	return query, args
 }
 
+// userOrderBy returns the ORDER BY clause for a filter's sort, with id
+// breaking ties so pages are stable
+func userOrderBy(filter UserFilter) string {
+	if !slices.Contains(UserSortFields, filter.SortBy) {
+		return "created_at DESC, id DESC"
+	}
+
+	direction := "ASC"
+	if filter.SortDesc {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("%s %s, id %s", filter.SortBy, direction, direction)
+}
+
+// buildCursorQuery constructs the SQL query for listing users after a cursor
+func (r *userRepositoryImpl) buildCursorQuery(filter UserFilter, after *userCursor, limit int) (string, []interface{}) {
+	query := `
+		SELECT id, email, password_hash as password, first_name, last_name, status, role, avatar_url, created_at, updated_at, deleted_at, version, last_login_at, last_login_ip, password_changed_at
+		FROM users`
+
+	whereClause, args := r.buildWhereClause(filter)
+
+	if after != nil {
+		argIndex := len(args) + 1
+		cursorClause := fmt.Sprintf("(created_at, id) < ($%d, $%d)", argIndex, argIndex+1)
+		args = append(args, after.CreatedAt, after.ID)
+
+		if whereClause != "" {
+			whereClause += " AND " + cursorClause
+		} else {
+			whereClause = cursorClause
+		}
+	}
+
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+
+	// id breaks ties between users created at the same instant
+	query += " ORDER BY created_at DESC, id DESC"
+
+	query += fmt.Sprintf(" LIMIT $%d", len(args)+1)
+	args = append(args, limit)
+
+	return query, args
+}
+
 // buildCountQuery constructs the SQL query for counting users with filters
 func (r *userRepositoryImpl) buildCountQuery(filter UserFilter) (string, []interface{}) {
 	query := "SELECT COUNT(*) FROM users"
@@ -303,9 +541,10 @@ func (r *userRepositoryImpl) buildCountQuery(filter UserFilter) (string, []inter
 	return query, args
 }
 
-// buildWhereClause constructs the WHERE clause for filtering
+// buildWhereClause constructs the WHERE clause for filtering. Deleted users
+// are always excluded.
 func (r *userRepositoryImpl) buildWhereClause(filter UserFilter) (string, []interface{}) {
-	var conditions []string
+	conditions := []string{"deleted_at IS NULL"}
 	var args []interface{}
 	argIndex := 1
 
@@ -345,9 +584,48 @@ func (r *userRepositoryImpl) buildWhereClause(filter UserFilter) (string, []inte
 		argIndex++
 	}
 
+	if filter.Search != nil {
+		conditions = append(conditions, fmt.Sprintf(
+			"(email ILIKE $%[1]d OR first_name ILIKE $%[1]d OR last_name ILIKE $%[1]d OR first_name || ' ' || last_name ILIKE $%[1]d)", argIndex))
+		args = append(args, "%"+*filter.Search+"%")
+		argIndex++
+	}
+
 	return strings.Join(conditions, " AND "), args
 }
 
+// userCursor is the position of the last user on a page
+type userCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// encodeUserCursor encodes a cursor as an opaque URL-safe string
+func encodeUserCursor(cursor userCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeUserCursor decodes a cursor produced by encodeUserCursor
+func decodeUserCursor(cursor string) (*userCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+
+	createdAt, id, found := strings.Cut(string(raw), "|")
+	if !found || id == "" {
+		return nil, fmt.Errorf("%w: malformed cursor", database.ErrInvalidInput)
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor timestamp", database.ErrInvalidInput)
+	}
+
+	return &userCursor{CreatedAt: parsed, ID: id}, nil
+}
+
 // handleError converts database errors to appropriate domain errors
 func (r *userRepositoryImpl) handleError(operation string, err error) error {
 	if err == nil {
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"go-templ-template/internal/modules/user/domain"
 	"go-templ-template/internal/shared/database"
+	appErrors "go-templ-template/internal/shared/errors"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -17,9 +19,10 @@ import (
 // UserRepositoryTestSuite provides integration tests for the user repository
 type UserRepositoryTestSuite struct {
 	suite.Suite
-	db   *database.DB
-	repo UserRepository
-	ctx  context.Context
+	testDB *database.TestDatabase
+	db     *database.DB
+	repo   UserRepository
+	ctx    context.Context
 }
 
 // SetupSuite initializes the test database and repository
@@ -29,6 +32,7 @@ func (suite *UserRepositoryTestSuite) SetupSuite() {
 	// Initialize test database
 	testDB := database.NewTestDatabase(suite.T())
 
+	suite.testDB = testDB
 	suite.db = testDB.DB
 	suite.repo = NewUserRepository(testDB.DB)
 
@@ -49,14 +53,32 @@ func (suite *UserRepositoryTestSuite) createUsersTable() {
 			password_hash VARCHAR(255) NOT NULL,
 			first_name VARCHAR(100) NOT NULL,
 			last_name VARCHAR(100) NOT NULL,
-			status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'inactive', 'suspended')),
+			status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'inactive', 'suspended', 'locked')),
+			role VARCHAR(20) NOT NULL DEFAULT 'user',
+			avatar_url VARCHAR(500) NOT NULL DEFAULT '',
 			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
 			updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
-			version INTEGER DEFAULT 1
+			deleted_at TIMESTAMP WITH TIME ZONE,
+			version INTEGER DEFAULT 1,
+			last_login_at TIMESTAMP WITH TIME ZONE,
+			last_login_ip VARCHAR(45) NOT NULL DEFAULT '',
+			password_changed_at TIMESTAMP WITH TIME ZONE
 		);
 
 		-- Create indexes for better performance
 		CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
+
+		-- Fold Gmail aliases, as in migration 017
+		CREATE OR REPLACE FUNCTION fold_gmail_alias(email TEXT)
+		RETURNS TEXT AS $$
+			SELECT CASE
+				WHEN split_part(lower(email), '@', 2) IN ('gmail.com', 'googlemail.com')
+					AND replace(split_part(split_part(lower(email), '@', 1), '+', 1), '.', '') <> ''
+				THEN replace(split_part(split_part(lower(email), '@', 1), '+', 1), '.', '') || '@gmail.com'
+				ELSE lower(email)
+			END
+		$$ LANGUAGE SQL IMMUTABLE STRICT;
+		CREATE INDEX IF NOT EXISTS idx_users_email_gmail_folded ON users (fold_gmail_alias(email));
 		CREATE INDEX IF NOT EXISTS idx_users_status ON users(status);
 		CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at);
 
@@ -153,21 +175,143 @@ func (suite *UserRepositoryTestSuite) TestCreateDuplicateEmail() {
 	assert.True(suite.T(), database.IsDuplicateKeyError(err))
 }
 
-// TestGetByID tests retrieving users by ID
-func (suite *UserRepositoryTestSuite) TestGetByID() {
-	// Create a user
-	user := suite.createTestUser("getbyid@example.com")
+// TestCreateDuplicateEmailDifferentCase tests that emails differing only in
+// case and surrounding space are detected as duplicates
+func (suite *UserRepositoryTestSuite) TestCreateDuplicateEmailDifferentCase() {
+	user1, err := domain.NewUser(uuid.New().String(), "A@B.com", "Password123", "John", "Doe")
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), suite.repo.Create(suite.ctx, user1))
 
-	// Test successful retrieval
-	retrieved, err := suite.repo.GetByID(suite.ctx, user.ID)
-	assert.NoError(suite.T(), err)
-	assert.Equal(suite.T(), user.ID, retrieved.ID)
-	assert.Equal(suite.T(), user.Email, retrieved.Email)
+	exists, err := suite.repo.ExistsByEmail(suite.ctx, " a@b.COM ")
+	require.NoError(suite.T(), err)
+	assert.True(suite.T(), exists)
 
-	// Test non-existent user
-	_, err = suite.repo.GetByID(suite.ctx, uuid.New().String())
+	user2, err := domain.NewUser(uuid.New().String(), "a@b.com", "Password456", "Jane", "Doe")
+	require.NoError(suite.T(), err)
+
+	err = suite.repo.Create(suite.ctx, user2)
 	assert.Error(suite.T(), err)
-	assert.True(suite.T(), database.IsNotFoundError(err))
+	assert.True(suite.T(), database.IsDuplicateKeyError(err))
+}
+
+// TestCreateBatch tests inserting a clean batch of users
+func (suite *UserRepositoryTestSuite) TestCreateBatch() {
+	users := suite.newTestUsers("batch", 3)
+
+	err := suite.repo.CreateBatch(suite.ctx, users)
+	require.NoError(suite.T(), err)
+
+	count, err := suite.repo.Count(suite.ctx, UserFilter{})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), count)
+
+	for _, user := range users {
+		retrieved, err := suite.repo.GetByID(suite.ctx, user.ID)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), user.Email, retrieved.Email)
+		assert.Equal(suite.T(), 1, retrieved.Version)
+	}
+
+	// Inserted users work with optimistic locking and the updated_at trigger
+	retrieved, err := suite.repo.GetByID(suite.ctx, users[0].ID)
+	require.NoError(suite.T(), err)
+	err = retrieved.UpdateProfile("Updated", "Name")
+	require.NoError(suite.T(), err)
+	err = suite.repo.Update(suite.ctx, retrieved)
+	require.NoError(suite.T(), err)
+
+	updated, err := suite.repo.GetByID(suite.ctx, users[0].ID)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, updated.Version)
+	assert.True(suite.T(), updated.UpdatedAt.After(users[0].CreatedAt))
+}
+
+// TestCreateBatchDuplicateEmail tests that a conflicting email rejects the whole batch
+func (suite *UserRepositoryTestSuite) TestCreateBatchDuplicateEmail() {
+	existing := suite.createTestUser("batch1@example.com")
+
+	users := suite.newTestUsers("batch", 3)
+	err := suite.repo.CreateBatch(suite.ctx, users)
+	require.Error(suite.T(), err)
+
+	var errorList *appErrors.ErrorList
+	require.ErrorAs(suite.T(), err, &errorList)
+	require.Len(suite.T(), errorList.Errors, 1)
+	assert.Equal(suite.T(), 1, errorList.Errors[0].Details["index"])
+	assert.Equal(suite.T(), existing.Email, errorList.Errors[0].Details["value"])
+
+	// Nothing from the batch was inserted
+	count, err := suite.repo.Count(suite.ctx, UserFilter{})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), count)
+
+	// Duplicates within the batch are reported too
+	users = suite.newTestUsers("repeat", 2)
+	users[1].Email = users[0].Email
+	err = suite.repo.CreateBatch(suite.ctx, users)
+	require.ErrorAs(suite.T(), err, &errorList)
+	require.Len(suite.T(), errorList.Errors, 1)
+	assert.Equal(suite.T(), 1, errorList.Errors[0].Details["index"])
+}
+
+// TestCreateBatchEmpty tests that an empty batch is a no-op
+func (suite *UserRepositoryTestSuite) TestCreateBatchEmpty() {
+	err := suite.repo.CreateBatch(suite.ctx, []*domain.User{})
+	assert.NoError(suite.T(), err)
+
+	count, err := suite.repo.Count(suite.ctx, UserFilter{})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(0), count)
+}
+
+// TestGetByID tests retrieving users by ID, rolling back instead of
+// relying on SetupTest's truncation
+func (suite *UserRepositoryTestSuite) TestGetByID() {
+	suite.testDB.RunInRollback(func(ctx context.Context) {
+		// Create a user
+		user := suite.createTestUserInContext(ctx, "getbyid@example.com")
+
+		// Test successful retrieval
+		retrieved, err := suite.repo.GetByID(ctx, user.ID)
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), user.ID, retrieved.ID)
+		assert.Equal(suite.T(), user.Email, retrieved.Email)
+
+		// Test non-existent user
+		_, err = suite.repo.GetByID(ctx, uuid.New().String())
+		assert.Error(suite.T(), err)
+		assert.True(suite.T(), database.IsNotFoundError(err))
+	})
+}
+
+// TestRunInRollbackIsolation tests that users created in one rolled back
+// test are invisible to the next
+func (suite *UserRepositoryTestSuite) TestRunInRollbackIsolation() {
+	var user *domain.User
+	suite.testDB.RunInRollback(func(ctx context.Context) {
+		user = suite.createTestUserInContext(ctx, "rollback@example.com")
+
+		exists, err := suite.repo.ExistsByEmail(ctx, user.Email)
+		assert.NoError(suite.T(), err)
+		assert.True(suite.T(), exists)
+	})
+
+	suite.testDB.RunInRollback(func(ctx context.Context) {
+		exists, err := suite.repo.Exists(ctx, user.ID)
+		assert.NoError(suite.T(), err)
+		assert.False(suite.T(), exists)
+
+		count, err := suite.repo.Count(ctx, UserFilter{})
+		assert.NoError(suite.T(), err)
+		assert.Equal(suite.T(), int64(0), count)
+
+		// The email is free again
+		suite.createTestUserInContext(ctx, "rollback@example.com")
+	})
+
+	exists, err := suite.repo.Exists(suite.ctx, user.ID)
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), exists)
 }
 
 // TestGetByEmail tests retrieving users by email
@@ -192,6 +336,56 @@ func (suite *UserRepositoryTestSuite) TestGetByEmail() {
 	assert.True(suite.T(), database.IsNotFoundError(err))
 }
 
+// TestGetByEmailFoldsGmailAliases tests that users saved before Gmail alias
+// folding was enabled are found by any alias of their address
+func (suite *UserRepositoryTestSuite) TestGetByEmailFoldsGmailAliases() {
+	user := suite.createTestUser("j.doe+signup@gmail.com")
+	repo := NewUserRepositoryWithEmailNormalizer(suite.db, domain.NewEmailNormalizer(true))
+
+	for _, email := range []string{"jdoe@gmail.com", "J.Doe@googlemail.com", "j.doe+signup@gmail.com"} {
+		retrieved, err := repo.GetByEmail(suite.ctx, email)
+		require.NoError(suite.T(), err, email)
+		assert.Equal(suite.T(), user.ID, retrieved.ID)
+	}
+
+	exists, err := repo.ExistsByEmail(suite.ctx, "jdoe+other@gmail.com")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), exists)
+
+	// Aliases saved as separate users are resolved to the exact address,
+	// then to the oldest user
+	exact := suite.createTestUser("jdoe@gmail.com")
+
+	retrieved, err := repo.GetByEmail(suite.ctx, "jdoe@gmail.com")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), exact.ID, retrieved.ID)
+
+	retrieved, err = repo.GetByEmail(suite.ctx, "j.d.o.e@gmail.com")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), user.ID, retrieved.ID)
+
+	// Without folding, aliases are different addresses
+	_, err = suite.repo.GetByEmail(suite.ctx, "j.d.o.e@gmail.com")
+	assert.True(suite.T(), database.IsNotFoundError(err))
+}
+
+// TestCreateBatchFoldsGmailAliases tests that batches are checked against
+// stored aliases of their addresses when folding is enabled
+func (suite *UserRepositoryTestSuite) TestCreateBatchFoldsGmailAliases() {
+	suite.createTestUser("j.doe@gmail.com")
+	repo := NewUserRepositoryWithEmailNormalizer(suite.db, domain.NewEmailNormalizer(true))
+
+	users := suite.newTestUsers("batchfold", 1)
+	users[0].Email = "jdoe+batch@gmail.com"
+
+	err := repo.CreateBatch(suite.ctx, users)
+	require.Error(suite.T(), err)
+
+	var conflicts *appErrors.ErrorList
+	require.ErrorAs(suite.T(), err, &conflicts)
+	assert.Len(suite.T(), conflicts.Errors, 1)
+}
+
 // TestUpdate tests user update functionality
 func (suite *UserRepositoryTestSuite) TestUpdate() {
 	// Create a user
@@ -214,6 +408,27 @@ func (suite *UserRepositoryTestSuite) TestUpdate() {
 	assert.Equal(suite.T(), originalVersion+1, retrieved.Version)
 }
 
+// TestUpdateRecordsLastLogin tests that a user's last login persists
+func (suite *UserRepositoryTestSuite) TestUpdateRecordsLastLogin() {
+	user := suite.createTestUser("last-login@example.com")
+
+	// Users who never logged in have no last login
+	retrieved, err := suite.repo.GetByID(suite.ctx, user.ID)
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), retrieved.LastLoginAt)
+	assert.Empty(suite.T(), retrieved.LastLoginIP)
+
+	loginAt := time.Now().UTC().Truncate(time.Microsecond)
+	require.NoError(suite.T(), retrieved.RecordLogin(loginAt, "203.0.113.7"))
+	require.NoError(suite.T(), suite.repo.Update(suite.ctx, retrieved))
+
+	retrieved, err = suite.repo.GetByEmail(suite.ctx, user.Email)
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), retrieved.LastLoginAt)
+	assert.True(suite.T(), loginAt.Equal(*retrieved.LastLoginAt))
+	assert.Equal(suite.T(), "203.0.113.7", retrieved.LastLoginIP)
+}
+
 // TestUpdateOptimisticLocking tests optimistic locking during updates
 func (suite *UserRepositoryTestSuite) TestUpdateOptimisticLocking() {
 	// Create a user
@@ -357,6 +572,102 @@ func (suite *UserRepositoryTestSuite) TestListWithFilters() {
 	assert.Contains(suite.T(), ids, user3.ID)
 }
 
+// TestListSearchAndSort tests searching across email and name, and sorting
+func (suite *UserRepositoryTestSuite) TestListSearchAndSort() {
+	alice := suite.createTestUserWithDetails("alice@example.com", "Alice", "Johnson")
+	suite.createTestUserWithDetails("bob@example.com", "Bob", "Smith")
+	charlie := suite.createTestUserWithDetails("charlie@example.com", "Charlie", "Johnson")
+
+	search := "johnson"
+	filter := UserFilter{Search: &search, SortBy: "first_name", SortDesc: true}
+	retrieved, err := suite.repo.List(suite.ctx, filter, 10, 0)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), retrieved, 2)
+	assert.Equal(suite.T(), charlie.ID, retrieved[0].ID)
+	assert.Equal(suite.T(), alice.ID, retrieved[1].ID)
+
+	count, err := suite.repo.Count(suite.ctx, filter)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), count)
+
+	search = "Alice Johnson"
+	retrieved, err = suite.repo.List(suite.ctx, UserFilter{Search: &search}, 10, 0)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), retrieved, 1)
+	assert.Equal(suite.T(), alice.ID, retrieved[0].ID)
+
+	retrieved, err = suite.repo.List(suite.ctx, UserFilter{SortBy: "email"}, 10, 0)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), retrieved, 3)
+	assert.Equal(suite.T(), "alice@example.com", retrieved[0].Email)
+	assert.Equal(suite.T(), "charlie@example.com", retrieved[2].Email)
+}
+
+// TestListByCursor tests paging through users with cursors
+func (suite *UserRepositoryTestSuite) TestListByCursor() {
+	users := suite.createMultipleTestUsers(5)
+
+	seen := make(map[string]bool)
+	var pageSizes []int
+	cursor := ""
+
+	for {
+		page, next, err := suite.repo.ListByCursor(suite.ctx, UserFilter{}, cursor, 2)
+		require.NoError(suite.T(), err)
+
+		pageSizes = append(pageSizes, len(page))
+		for _, user := range page {
+			assert.False(suite.T(), seen[user.ID], "User %s returned on more than one page", user.ID)
+			seen[user.ID] = true
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	assert.Equal(suite.T(), []int{2, 2, 1}, pageSizes)
+	assert.Len(suite.T(), seen, len(users))
+	for _, user := range users {
+		assert.True(suite.T(), seen[user.ID], "User %s missing from pages", user.ID)
+	}
+}
+
+// TestListByCursorWithFilter tests cursor pagination combined with filtering
+func (suite *UserRepositoryTestSuite) TestListByCursorWithFilter() {
+	alice := suite.createTestUserWithDetails("alice@example.com", "Alice", "Johnson")
+	suite.createTestUserWithDetails("bob@example.com", "Bob", "Smith")
+	charlie := suite.createTestUserWithDetails("charlie@example.com", "Charlie", "Johnson")
+	suite.createTestUserWithDetails("dave@example.com", "Dave", "Smith")
+	erin := suite.createTestUserWithDetails("erin@example.com", "Erin", "Johnson")
+
+	lastName := "Johnson"
+	filter := UserFilter{LastName: &lastName}
+
+	page, next, err := suite.repo.ListByCursor(suite.ctx, filter, "", 2)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), page, 2)
+	require.NotEmpty(suite.T(), next)
+
+	// Newest first, matching List
+	assert.Equal(suite.T(), erin.ID, page[0].ID)
+	assert.Equal(suite.T(), charlie.ID, page[1].ID)
+
+	page, next, err = suite.repo.ListByCursor(suite.ctx, filter, next, 2)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), page, 1)
+	assert.Equal(suite.T(), alice.ID, page[0].ID)
+	assert.Empty(suite.T(), next)
+}
+
+// TestListByCursorInvalidCursor tests that malformed cursors are rejected
+func (suite *UserRepositoryTestSuite) TestListByCursorInvalidCursor() {
+	_, _, err := suite.repo.ListByCursor(suite.ctx, UserFilter{}, "not-a-cursor!", 2)
+	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, database.ErrInvalidInput)
+}
+
 // TestCount tests user counting functionality
 func (suite *UserRepositoryTestSuite) TestCount() {
 	// Create test users
@@ -468,8 +779,106 @@ func (suite *UserRepositoryTestSuite) TestTransactionSupport() {
 	assert.False(suite.T(), exists2)
 }
 
+// TestContextCancelledMidQuery tests that every method gives up on a query
+// still waiting when its context is done, returning the context's error
+func (suite *UserRepositoryTestSuite) TestContextCancelledMidQuery() {
+	existing := suite.createTestUser("waiting@example.com")
+
+	// Lock the users table so every query against it waits
+	lockTx, err := suite.db.BeginTxx(suite.ctx, nil)
+	require.NoError(suite.T(), err)
+	defer lockTx.Rollback()
+	_, err = lockTx.ExecContext(suite.ctx, "LOCK TABLE users IN ACCESS EXCLUSIVE MODE")
+	require.NoError(suite.T(), err)
+
+	for name, call := range suite.repositoryCalls(existing) {
+		suite.Run(name, func() {
+			ctx, cancel := context.WithTimeout(suite.ctx, 100*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			err := call(ctx)
+			assert.ErrorIs(suite.T(), err, context.DeadlineExceeded)
+			assert.Less(suite.T(), time.Since(start), 5*time.Second, "The query should be abandoned, not waited for")
+		})
+	}
+}
+
+// TestContextCancelledBeforeQuery tests that no method queries the database
+// with a context that is already cancelled
+func (suite *UserRepositoryTestSuite) TestContextCancelledBeforeQuery() {
+	existing := suite.createTestUser("cancelled@example.com")
+
+	ctx, cancel := context.WithCancel(suite.ctx)
+	cancel()
+
+	for name, call := range suite.repositoryCalls(existing) {
+		suite.Run(name, func() {
+			assert.ErrorIs(suite.T(), call(ctx), context.Canceled)
+		})
+	}
+
+	// Nothing was written
+	count, err := suite.repo.Count(suite.ctx, UserFilter{})
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), count)
+
+	retrieved, err := suite.repo.GetByID(suite.ctx, existing.ID)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), existing.FirstName, retrieved.FirstName)
+}
+
 // Helper methods
 
+// repositoryCalls returns a call of every repository method, by name, with
+// existing an already saved user
+func (suite *UserRepositoryTestSuite) repositoryCalls(existing *domain.User) map[string]func(ctx context.Context) error {
+	return map[string]func(ctx context.Context) error{
+		"Create": func(ctx context.Context) error {
+			return suite.repo.Create(ctx, suite.newTestUsers("create", 1)[0])
+		},
+		"CreateBatch": func(ctx context.Context) error {
+			return suite.repo.CreateBatch(ctx, suite.newTestUsers("batch", 2))
+		},
+		"GetByID": func(ctx context.Context) error {
+			_, err := suite.repo.GetByID(ctx, existing.ID)
+			return err
+		},
+		"GetByEmail": func(ctx context.Context) error {
+			_, err := suite.repo.GetByEmail(ctx, existing.Email)
+			return err
+		},
+		"Update": func(ctx context.Context) error {
+			updated := *existing
+			require.NoError(suite.T(), updated.UpdateProfile("Updated", "User"))
+			return suite.repo.Update(ctx, &updated)
+		},
+		"Delete": func(ctx context.Context) error {
+			return suite.repo.Delete(ctx, existing.ID)
+		},
+		"List": func(ctx context.Context) error {
+			_, err := suite.repo.List(ctx, UserFilter{}, 10, 0)
+			return err
+		},
+		"ListByCursor": func(ctx context.Context) error {
+			_, _, err := suite.repo.ListByCursor(ctx, UserFilter{}, "", 10)
+			return err
+		},
+		"Count": func(ctx context.Context) error {
+			_, err := suite.repo.Count(ctx, UserFilter{})
+			return err
+		},
+		"Exists": func(ctx context.Context) error {
+			_, err := suite.repo.Exists(ctx, existing.ID)
+			return err
+		},
+		"ExistsByEmail": func(ctx context.Context) error {
+			_, err := suite.repo.ExistsByEmail(ctx, existing.Email)
+			return err
+		},
+	}
+}
+
 // createTestUser creates a test user with default values
 func (suite *UserRepositoryTestSuite) createTestUser(email string) *domain.User {
 	return suite.createTestUserWithDetails(email, "Test", "User")
@@ -509,6 +918,23 @@ func (suite *UserRepositoryTestSuite) createTestUserInContext(ctx context.Contex
 	return user
 }
 
+// newTestUsers builds users without saving them, with emails prefix0..prefixN
+func (suite *UserRepositoryTestSuite) newTestUsers(prefix string, count int) []*domain.User {
+	users := make([]*domain.User, count)
+	for i := 0; i < count; i++ {
+		user, err := domain.NewUser(
+			uuid.New().String(),
+			fmt.Sprintf("%s%d@example.com", prefix, i),
+			"Password123",
+			"Test",
+			"User",
+		)
+		require.NoError(suite.T(), err)
+		users[i] = user
+	}
+	return users
+}
+
 // createMultipleTestUsers creates multiple test users
 func (suite *UserRepositoryTestSuite) createMultipleTestUsers(count int) []*domain.User {
 	users := make([]*domain.User, count)
@@ -519,6 +945,38 @@ func (suite *UserRepositoryTestSuite) createMultipleTestUsers(count int) []*doma
 	return users
 }
 
+// TestBuildBatchInsertQuery tests placeholder numbering for multi-row inserts
+func TestBuildBatchInsertQuery(t *testing.T) {
+	users := []*domain.User{
+		{ID: "1", Email: "a@example.com", Status: domain.UserStatusActive, Version: 1},
+		{ID: "2", Email: "b@example.com", Status: domain.UserStatusActive, Version: 1},
+	}
+
+	query, args := buildBatchInsertQuery(users)
+
+	assert.Contains(t, query, "($1, $2, $3, $4, $5, $6, $7, $8, $9, $10), ($11, $12, $13, $14, $15, $16, $17, $18, $19, $20)")
+	assert.Len(t, args, 20)
+	assert.Equal(t, "2", args[10])
+}
+
+// TestUserCursorEncoding tests that cursors round trip and reject bad input
+func TestUserCursorEncoding(t *testing.T) {
+	cursor := userCursor{
+		CreatedAt: time.Date(2024, 3, 1, 12, 30, 0, 123456000, time.UTC),
+		ID:        uuid.New().String(),
+	}
+
+	decoded, err := decodeUserCursor(encodeUserCursor(cursor))
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+
+	for _, invalid := range []string{"%%%", "bm8tc2VwYXJhdG9y", "bm90LWEtdGltZXxpZA"} {
+		_, err := decodeUserCursor(invalid)
+		assert.ErrorIs(t, err, database.ErrInvalidInput, "cursor %q", invalid)
+	}
+}
+
 // TestUserRepository runs the user repository test suite
 func TestUserRepository(t *testing.T) {
 	suite.Run(t, new(UserRepositoryTestSuite))
@@ -2,26 +2,61 @@ package user
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"go-templ-template/internal/config"
 	"go-templ-template/internal/modules/user/application"
+	"go-templ-template/internal/modules/user/domain"
 	"go-templ-template/internal/modules/user/handlers"
 	"go-templ-template/internal/modules/user/infrastructure"
 	"go-templ-template/internal/shared"
+	"go-templ-template/internal/shared/audit"
 	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/email"
 	"go-templ-template/internal/shared/events"
+	"go-templ-template/internal/shared/storage"
 
 	"github.com/labstack/echo/v4"
 )
 
 // UserModule implements the Module interface for user management functionality
 type UserModule struct {
-	name        string
-	userService application.UserService
-	userHandler *handlers.UserHandler
-	eventBus    events.EventBus
-	db          *database.DB
-	config      *config.Config
+	name              string
+	userService       application.UserService
+	activationService application.ActivationService
+	avatarService     application.AvatarService
+	exportService     application.DataExportService
+	exportHandler     *application.DataExportHandler
+	deletionDeps      *accountDeletionDeps
+	sessionRevoker    *deferredSessionRevoker
+	emailSender       email.EmailSender
+	userHandler       *handlers.UserHandler
+	authMiddleware    echo.MiddlewareFunc
+	eventBus          events.EventBus
+	db                *database.DB
+	config            *config.Config
+}
+
+// accountDeletionDeps holds what the account deletion service needs besides
+// the session revoker, which is provided later by the auth module
+type accountDeletionDeps struct {
+	userRepo       infrastructure.UserRepository
+	auditLogger    audit.AuditLogger
+	passwordHasher domain.PasswordHasher
+}
+
+// deferredSessionRevoker revokes sessions with the session revoker set later
+// by the auth module. Until it is set there are no sessions to revoke.
+type deferredSessionRevoker struct {
+	revoker application.SessionRevoker
+}
+
+func (r *deferredSessionRevoker) RevokeAllSessions(ctx context.Context, userID string) (int, error) {
+	if r.revoker == nil {
+		return 0, nil
+	}
+	return r.revoker.RevokeAllSessions(ctx, userID)
 }
 
 // NewUserModule creates a new user module instance
@@ -54,32 +89,161 @@ func (m *UserModule) Initialize(ctx context.Context, container *shared.ModuleCon
 	m.db = db
 	m.config = config
 
-	// Initialize repository
-	userRepo := infrastructure.NewUserRepository(db)
+	// Initialize repositories
+	emailNormalizer := domain.NewEmailNormalizer(config.Auth.NormalizeGmailAliases)
+	userRepo := infrastructure.NewUserRepositoryWithEmailNormalizer(db, emailNormalizer)
+	activationTokenRepo := infrastructure.NewActivationTokenRepository(db)
 
-	// Initialize service
-	m.userService = application.NewUserService(userRepo, m.eventBus, db)
+	// Initialize password hasher
+	hashAlgorithm := config.Auth.PasswordHashAlgorithm
+	if hashAlgorithm == "" {
+		hashAlgorithm = domain.PasswordHashBcrypt
+	}
+	passwordHasher, err := domain.NewPasswordHasher(hashAlgorithm)
+	if err != nil {
+		return shared.NewModuleErrorWithCause(m.name, "invalid password hash algorithm", err)
+	}
+
+	// Initialize services. Password changes revoke the user's sessions once
+	// the auth module provides a session revoker with SetSessionRevoker.
+	m.sessionRevoker = &deferredSessionRevoker{}
+	m.userService = application.NewUserServiceWithConfig(userRepo, m.eventBus, db, application.UserServiceConfig{
+		PasswordHasher:  passwordHasher,
+		SessionRevoker:  m.sessionRevoker,
+		EmailNormalizer: emailNormalizer,
+	})
+	m.activationService = application.NewActivationService(
+		userRepo,
+		activationTokenRepo,
+		m.eventBus,
+		db,
+		time.Duration(config.Auth.VerificationTokenHours)*time.Hour,
+	)
+
+	// Initialize blob storage for avatars and data exports
+	blobStore, err := storage.NewBlobStore(config.Storage)
+	if err != nil {
+		return shared.NewModuleErrorWithCause(m.name, "invalid storage configuration", err)
+	}
+	m.avatarService = application.NewAvatarService(userRepo, blobStore, m.eventBus, db)
+
+	// Initialize email sender
+	m.emailSender, err = email.NewSender(config.Email, nil)
+	if err != nil {
+		return shared.NewModuleErrorWithCause(m.name, "invalid email configuration", err)
+	}
+
+	// Initialize data exports. Other modules add the data they own with
+	// AddDataExportSource.
+	exportRepo := infrastructure.NewDataExportRepository(db)
+	m.exportService = application.NewDataExportService(userRepo, exportRepo, blobStore, m.eventBus, db)
+	auditLogger := audit.NewAuditLogger(db)
+	m.exportHandler = application.NewDataExportHandler(exportRepo, userRepo, blobStore, m.emailSender, m.apiURL(),
+		application.NewAuditEventsExportSource(auditLogger),
+	)
+
+	// Account deletion is enabled once the auth module provides a session
+	// revoker with SetSessionRevoker
+	m.deletionDeps = &accountDeletionDeps{
+		userRepo:       userRepo,
+		auditLogger:    auditLogger,
+		passwordHasher: passwordHasher,
+	}
 
 	// Initialize handlers
-	m.userHandler = handlers.NewUserHandler(m.userService)
+	m.userHandler = handlers.NewUserHandler(m.userService).
+		WithAPIPrefix(config.Server.APIPrefix).
+		WithAvatarUploads(m.avatarService, config.Storage.AvatarMaxBytes).
+		WithDataExports(m.exportService)
 
 	return nil
 }
 
+// apiURL returns the absolute URL of the API root that links in emails
+// point at, e.g. https://app.example.com/api/v1
+func (m *UserModule) apiURL() string {
+	prefix := m.config.Server.APIPrefix
+	if prefix == "" {
+		prefix = shared.DefaultAPIPrefix
+	}
+	return strings.TrimSuffix(m.config.Email.BaseURL, "/") + "/" + strings.Trim(prefix, "/")
+}
+
+// SetAuthMiddleware sets the middleware authenticating routes that act on
+// the signed-in user. It is called by the auth module, which is initialized
+// after this one.
+func (m *UserModule) SetAuthMiddleware(authMiddleware echo.MiddlewareFunc) {
+	m.authMiddleware = authMiddleware
+}
+
+// AddDataExportSource adds a section of data to users' export bundles. It is
+// called by modules owning user data, which are initialized after this one.
+func (m *UserModule) AddDataExportSource(source application.DataExportSource) {
+	if m.exportHandler != nil {
+		m.exportHandler.AddSource(source)
+	}
+}
+
+// SetSessionRevoker enables self-service account deletion, revoking the
+// deleted user's sessions with sessionRevoker, which also revokes the
+// sessions of users changing their password. It is called by the auth
+// module, which owns sessions and is initialized after this one.
+func (m *UserModule) SetSessionRevoker(sessionRevoker application.SessionRevoker) {
+	if m.sessionRevoker != nil {
+		m.sessionRevoker.revoker = sessionRevoker
+	}
+	if m.deletionDeps == nil || m.userHandler == nil {
+		return
+	}
+
+	deletionService := application.NewAccountDeletionService(
+		m.deletionDeps.userRepo,
+		sessionRevoker,
+		m.deletionDeps.auditLogger,
+		m.eventBus,
+		m.db,
+		m.deletionDeps.passwordHasher,
+	)
+	m.userHandler.WithAccountDeletion(deletionService, time.Duration(m.config.Auth.ReauthWindowMinutes)*time.Minute)
+}
+
 // RegisterRoutes registers the module's HTTP routes with the router
 func (m *UserModule) RegisterRoutes(router *echo.Group) {
-	// Use the existing handler function that works with groups
-	handlers.RegisterUserRoutesOnGroup(router, m.userService)
+	handlers.RegisterUserHandlerOnGroup(router, m.userHandler, m.authMiddleware)
 }
 
 // RegisterEventHandlers registers the module's event handlers with the event bus
 func (m *UserModule) RegisterEventHandlers(eventBus events.EventBus) error {
-	// User module primarily publishes events, but could subscribe to others
-	// For example, it might listen to auth events to update user last login time
+	if m.exportHandler != nil {
+		if err := eventBus.Subscribe(m.exportHandler.EventType(), m.exportHandler); err != nil {
+			return shared.NewModuleErrorWithCause(m.name, "failed to subscribe to user.export_requested event", err)
+		}
+	}
 
-	// Register user lifecycle event handlers if needed
-	// This is where we would register handlers for events from other modules
-	// For now, the user module is primarily an event publisher
+	// Emails are sent from event handlers so delivery is decoupled from the
+	// writes that trigger them
+	if m.emailSender == nil {
+		return nil
+	}
+
+	var welcomeHandler events.EventHandler = application.NewWelcomeEmailHandler(m.emailSender)
+	var verificationHandler events.EventHandler = application.NewVerificationEmailHandler(m.emailSender, m.apiURL())
+
+	// A redelivered event would send the same email twice, so with a database
+	// available the handlers skip events they already processed
+	if m.db != nil {
+		store := events.NewDBProcessedEventStore(m.db, events.DefaultProcessedEventRetention)
+		welcomeHandler = events.NewIdempotentHandler(welcomeHandler, store)
+		verificationHandler = events.NewIdempotentHandler(verificationHandler, store)
+	}
+
+	if err := eventBus.Subscribe(welcomeHandler.EventType(), welcomeHandler); err != nil {
+		return shared.NewModuleErrorWithCause(m.name, "failed to subscribe to user.created event", err)
+	}
+
+	if err := eventBus.Subscribe(verificationHandler.EventType(), verificationHandler); err != nil {
+		return shared.NewModuleErrorWithCause(m.name, "failed to subscribe to user.activation_requested event", err)
+	}
 
 	return nil
 }
@@ -126,6 +290,11 @@ func (m *UserModule) GetUserService() application.UserService {
 	return m.userService
 }
 
+// GetActivationService returns the activation service for inter-module communication
+func (m *UserModule) GetActivationService() application.ActivationService {
+	return m.activationService
+}
+
 // GetUserHandler returns the user handler for testing purposes
 func (m *UserModule) GetUserHandler() *handlers.UserHandler {
 	return m.userHandler
@@ -9,6 +9,7 @@ import (
 	"go-templ-template/internal/modules/user/domain"
 	"go-templ-template/internal/shared"
 	"go-templ-template/internal/shared/database"
+	"go-templ-template/internal/shared/email"
 	"go-templ-template/internal/shared/events"
 
 	"github.com/labstack/echo/v4"
@@ -87,11 +88,31 @@ func (m *MockUserService) ChangeUserPassword(ctx context.Context, cmd *applicati
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserService) ResetUserPassword(ctx context.Context, cmd *application.ResetUserPasswordCommand) (*domain.User, error) {
+	args := m.Called(ctx, cmd)
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
 func (m *MockUserService) ChangeUserStatus(ctx context.Context, cmd *application.ChangeUserStatusCommand) (*domain.User, error) {
 	args := m.Called(ctx, cmd)
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+func (m *MockUserService) LockUser(ctx context.Context, cmd *application.LockUserCommand) (*domain.User, error) {
+	args := m.Called(ctx, cmd)
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserService) UnlockUser(ctx context.Context, cmd *application.UnlockUserCommand) (*domain.User, error) {
+	args := m.Called(ctx, cmd)
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserService) RecordLogin(ctx context.Context, cmd *application.RecordLoginCommand) (*domain.User, error) {
+	args := m.Called(ctx, cmd)
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
 func (m *MockUserService) DeleteUser(ctx context.Context, cmd *application.DeleteUserCommand) error {
 	args := m.Called(ctx, cmd)
 	return args.Error(0)
@@ -209,10 +230,60 @@ func TestUserModule_RegisterEventHandlers(t *testing.T) {
 
 	// Assert
 	assert.NoError(t, err)
-	// User module doesn't register any event handlers currently
+	// Without an email sender there is nothing to subscribe
 	eventBus.AssertNotCalled(t, "Subscribe")
 }
 
+func TestUserModule_RegisterEventHandlers_EmailHandlers(t *testing.T) {
+	// Arrange
+	module := NewUserModule()
+	module.config = &config.Config{}
+	module.emailSender = email.NewRecordingSender()
+	eventBus := &MockEventBus{}
+	eventBus.On("Subscribe", "user.created", mock.AnythingOfType("*application.WelcomeEmailHandler")).Return(nil)
+	eventBus.On("Subscribe", "user.activation_requested", mock.AnythingOfType("*application.VerificationEmailHandler")).Return(nil)
+
+	// Act
+	err := module.RegisterEventHandlers(eventBus)
+
+	// Assert
+	assert.NoError(t, err)
+	eventBus.AssertExpectations(t)
+}
+
+func TestUserModule_RegisterEventHandlers_IdempotentEmailHandlers(t *testing.T) {
+	// Arrange
+	module := NewUserModule()
+	module.config = &config.Config{}
+	module.db = &database.DB{}
+	module.emailSender = email.NewRecordingSender()
+	eventBus := &MockEventBus{}
+	eventBus.On("Subscribe", "user.created", mock.AnythingOfType("*events.IdempotentHandler")).Return(nil)
+	eventBus.On("Subscribe", "user.activation_requested", mock.AnythingOfType("*events.IdempotentHandler")).Return(nil)
+
+	// Act
+	err := module.RegisterEventHandlers(eventBus)
+
+	// Assert
+	assert.NoError(t, err)
+	eventBus.AssertExpectations(t)
+}
+
+func TestUserModule_RegisterEventHandlers_DataExportHandler(t *testing.T) {
+	// Arrange
+	module := NewUserModule()
+	module.exportHandler = application.NewDataExportHandler(nil, nil, nil, nil, "")
+	eventBus := &MockEventBus{}
+	eventBus.On("Subscribe", "user.export_requested", module.exportHandler).Return(nil)
+
+	// Act
+	err := module.RegisterEventHandlers(eventBus)
+
+	// Assert
+	assert.NoError(t, err)
+	eventBus.AssertExpectations(t)
+}
+
 func TestUserModule_Health_NotInitialized(t *testing.T) {
 	// Arrange
 	module := NewUserModule()
@@ -288,3 +359,33 @@ func TestUserModule_GetUserHandler_NotInitialized(t *testing.T) {
 	// Assert
 	assert.Nil(t, handler)
 }
+
+// countingSessionRevoker counts the users whose sessions it revokes
+type countingSessionRevoker struct {
+	revoked []string
+}
+
+func (r *countingSessionRevoker) RevokeAllSessions(ctx context.Context, userID string) (int, error) {
+	r.revoked = append(r.revoked, userID)
+	return 1, nil
+}
+
+func TestDeferredSessionRevoker(t *testing.T) {
+	// Arrange
+	deferred := &deferredSessionRevoker{}
+	module := NewUserModule()
+	module.sessionRevoker = deferred
+
+	// Act & Assert: nothing is revoked before the auth module sets a revoker
+	revoked, err := deferred.RevokeAllSessions(context.Background(), "user-123")
+	assert.NoError(t, err)
+	assert.Zero(t, revoked)
+
+	revoker := &countingSessionRevoker{}
+	module.SetSessionRevoker(revoker)
+
+	revoked, err = deferred.RevokeAllSessions(context.Background(), "user-123")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, revoked)
+	assert.Equal(t, []string{"user-123"}, revoker.revoked)
+}
@@ -34,7 +34,8 @@ type AuditEvent struct {
 	Metadata      events.EventMetadata   `json:"metadata"`
 }
 
-// AuditFilter defines filters for querying audit events
+// AuditFilter defines filters for querying audit events. Zero-valued fields
+// are ignored; StartTime and EndTime are inclusive bounds on occurred_at.
 type AuditFilter struct {
 	EventID    string    `json:"event_id,omitempty"`
 	UserID     string    `json:"user_id,omitempty"`
@@ -46,6 +47,9 @@ type AuditFilter struct {
 	EndTime    time.Time `json:"end_time,omitempty"`
 	Limit      int       `json:"limit,omitempty"`
 	Offset     int       `json:"offset,omitempty"`
+
+	// Ascending orders results oldest first; the default is newest first
+	Ascending bool `json:"ascending,omitempty"`
 }
 
 // auditLoggerImpl implements the AuditLogger interface using PostgreSQL
@@ -161,6 +165,46 @@ func (a *auditLoggerImpl) LogEvent(ctx context.Context, event *AuditEvent) error
 
 // GetEvents retrieves audit events based on the provided filter
 func (a *auditLoggerImpl) GetEvents(ctx context.Context, filter *AuditFilter) ([]*AuditEvent, error) {
+	if filter == nil {
+		filter = &AuditFilter{}
+	}
+
+	query, args := buildEventsQuery(filter)
+
+	var records []auditEventRecord
+	nstmt, err := a.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare query: %w", err)
+	}
+	defer nstmt.Close()
+
+	err = nstmt.SelectContext(ctx, &records, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit events: %w", err)
+	}
+
+	auditEvents := make([]*AuditEvent, len(records))
+	for i, record := range records {
+		auditEvents[i] = &AuditEvent{
+			EventID:       record.EventID,
+			EventType:     record.EventType,
+			AggregateID:   record.AggregateID,
+			AggregateType: record.AggregateType,
+			UserID:        record.UserID,
+			Action:        record.Action,
+			Resource:      record.Resource,
+			ResourceID:    record.ResourceID,
+			Details:       map[string]interface{}(record.Details),
+			OccurredAt:    record.OccurredAt,
+			Metadata:      events.EventMetadata(record.Metadata),
+		}
+	}
+
+	return auditEvents, nil
+}
+
+// buildEventsQuery builds the named-parameter query and arguments for filter
+func buildEventsQuery(filter *AuditFilter) (string, map[string]interface{}) {
 	query := `
 		SELECT 
 			id, event_id, event_type, aggregate_id, aggregate_type, user_id,
@@ -210,7 +254,13 @@ func (a *auditLoggerImpl) GetEvents(ctx context.Context, filter *AuditFilter) ([
 		args["end_time"] = filter.EndTime
 	}
 
-	query += " ORDER BY occurred_at DESC"
+	// id breaks ties between events recorded at the same instant so that
+	// pages are stable
+	if filter.Ascending {
+		query += " ORDER BY occurred_at ASC, id ASC"
+	} else {
+		query += " ORDER BY occurred_at DESC, id DESC"
+	}
 
 	if filter.Limit > 0 {
 		query += " LIMIT :limit"
@@ -222,36 +272,7 @@ func (a *auditLoggerImpl) GetEvents(ctx context.Context, filter *AuditFilter) ([
 		args["offset"] = filter.Offset
 	}
 
-	var records []auditEventRecord
-	nstmt, err := a.db.PrepareNamedContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to prepare query: %w", err)
-	}
-	defer nstmt.Close()
-
-	err = nstmt.SelectContext(ctx, &records, args)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query audit events: %w", err)
-	}
-
-	auditEvents := make([]*AuditEvent, len(records))
-	for i, record := range records {
-		auditEvents[i] = &AuditEvent{
-			EventID:       record.EventID,
-			EventType:     record.EventType,
-			AggregateID:   record.AggregateID,
-			AggregateType: record.AggregateType,
-			UserID:        record.UserID,
-			Action:        record.Action,
-			Resource:      record.Resource,
-			ResourceID:    record.ResourceID,
-			Details:       map[string]interface{}(record.Details),
-			OccurredAt:    record.OccurredAt,
-			Metadata:      events.EventMetadata(record.Metadata),
-		}
-	}
-
-	return auditEvents, nil
+	return query, args
 }
 
 // CreateAuditEventsTable creates the audit_events table if it doesn't exist
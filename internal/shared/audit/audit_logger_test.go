@@ -0,0 +1,148 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/shared/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const adminUserID = "admin-user-id-1234567890"
+
+// seedAuditEvents logs the five audit events from the database integration
+// seed relative to now and returns that reference time
+func seedAuditEvents(t *testing.T, logger AuditLogger) time.Time {
+	t.Helper()
+
+	now := time.Now().UTC()
+	seed := []*AuditEvent{
+		{EventID: "audit-event-1", EventType: "user.created", AggregateID: adminUserID, AggregateType: "user", UserID: adminUserID, Action: "create", Resource: "user", ResourceID: adminUserID, OccurredAt: now.Add(-60 * time.Minute)},
+		{EventID: "audit-event-2", EventType: "user.created", AggregateID: "user-id-1234567890", AggregateType: "user", UserID: "user-id-1234567890", Action: "create", Resource: "user", ResourceID: "user-id-1234567890", OccurredAt: now.Add(-30 * time.Minute)},
+		{EventID: "audit-event-3", EventType: "user.login", AggregateID: adminUserID, AggregateType: "session", UserID: adminUserID, Action: "login", Resource: "session", ResourceID: "session-admin-123456", OccurredAt: now.Add(-15 * time.Minute)},
+		{EventID: "audit-event-4", EventType: "user.created", AggregateID: "user-id-0987654321", AggregateType: "user", UserID: "user-id-0987654321", Action: "create", Resource: "user", ResourceID: "user-id-0987654321", OccurredAt: now.Add(-25 * time.Minute)},
+		{EventID: "audit-event-5", EventType: "user.created", AggregateID: "user-id-1122334455", AggregateType: "user", UserID: "user-id-1122334455", Action: "create", Resource: "user", ResourceID: "user-id-1122334455", OccurredAt: now.Add(-20 * time.Minute)},
+	}
+
+	for _, event := range seed {
+		require.NoError(t, logger.LogEvent(context.Background(), event))
+	}
+	return now
+}
+
+func eventIDs(auditEvents []*AuditEvent) []string {
+	ids := make([]string, len(auditEvents))
+	for i, event := range auditEvents {
+		ids[i] = event.EventID
+	}
+	return ids
+}
+
+func TestAuditLogger_GetEvents(t *testing.T) {
+	database.SkipIfNoDatabase(t)
+
+	tdb := database.NewTestDatabase(t)
+	defer tdb.Close()
+
+	tdb.DropTable("audit_events")
+	require.NoError(t, CreateAuditEventsTable(context.Background(), tdb.DB.DB))
+	defer tdb.DropTable("audit_events")
+
+	logger := NewAuditLogger(tdb.DB)
+	now := seedAuditEvents(t, logger)
+
+	tests := []struct {
+		name     string
+		filter   *AuditFilter
+		expected []string
+	}{
+		{
+			name:     "no filter returns newest first",
+			filter:   &AuditFilter{},
+			expected: []string{"audit-event-3", "audit-event-5", "audit-event-4", "audit-event-2", "audit-event-1"},
+		},
+		{
+			name:     "by user",
+			filter:   &AuditFilter{UserID: adminUserID},
+			expected: []string{"audit-event-3", "audit-event-1"},
+		},
+		{
+			name:     "by event type",
+			filter:   &AuditFilter{EventType: "user.login"},
+			expected: []string{"audit-event-3"},
+		},
+		{
+			name:     "by action",
+			filter:   &AuditFilter{Action: "create"},
+			expected: []string{"audit-event-5", "audit-event-4", "audit-event-2", "audit-event-1"},
+		},
+		{
+			name:     "by resource",
+			filter:   &AuditFilter{Resource: "session"},
+			expected: []string{"audit-event-3"},
+		},
+		{
+			name:     "by time range",
+			filter:   &AuditFilter{StartTime: now.Add(-35 * time.Minute), EndTime: now.Add(-18 * time.Minute)},
+			expected: []string{"audit-event-5", "audit-event-4", "audit-event-2"},
+		},
+		{
+			name:     "combined filters",
+			filter:   &AuditFilter{UserID: adminUserID, Action: "create"},
+			expected: []string{"audit-event-1"},
+		},
+		{
+			name:     "limit and offset",
+			filter:   &AuditFilter{Limit: 2, Offset: 1},
+			expected: []string{"audit-event-5", "audit-event-4"},
+		},
+		{
+			name:     "ascending order",
+			filter:   &AuditFilter{Action: "create", Ascending: true},
+			expected: []string{"audit-event-1", "audit-event-2", "audit-event-4", "audit-event-5"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := logger.GetEvents(context.Background(), tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, eventIDs(result))
+		})
+	}
+}
+
+func TestBuildEventsQuery(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	query, args := buildEventsQuery(&AuditFilter{
+		UserID:    "user-1",
+		Resource:  "user",
+		StartTime: start,
+		Limit:     10,
+		Offset:    20,
+		Ascending: true,
+	})
+
+	assert.Contains(t, query, "AND user_id = :user_id")
+	assert.Contains(t, query, "AND resource = :resource")
+	assert.Contains(t, query, "AND occurred_at >= :start_time")
+	assert.NotContains(t, query, ":end_time")
+	assert.NotContains(t, query, ":action")
+	assert.Contains(t, query, "ORDER BY occurred_at ASC, id ASC LIMIT :limit OFFSET :offset")
+
+	assert.Equal(t, map[string]interface{}{
+		"user_id":    "user-1",
+		"resource":   "user",
+		"start_time": start,
+		"limit":      10,
+		"offset":     20,
+	}, args)
+
+	query, args = buildEventsQuery(&AuditFilter{})
+	assert.Contains(t, query, "ORDER BY occurred_at DESC, id DESC")
+	assert.NotContains(t, query, "LIMIT")
+	assert.Empty(t, args)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"go-templ-template/internal/config"
+	appErrors "go-templ-template/internal/shared/errors"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq" // PostgreSQL driver
@@ -15,7 +16,9 @@ import (
 // DB wraps sqlx.DB with additional functionality
 type DB struct {
 	*sqlx.DB
-	config *config.DatabaseConfig
+	config  *config.DatabaseConfig
+	options ConnectionOptions
+	logger  appErrors.Logger
 }
 
 // ConnectionOptions holds database connection configuration
@@ -36,6 +39,38 @@ func DefaultConnectionOptions() ConnectionOptions {
 	}
 }
 
+// ConnectionOptionsFromConfig returns connection options populated from the
+// database configuration, using the defaults for unset values
+func ConnectionOptionsFromConfig(cfg *config.DatabaseConfig) ConnectionOptions {
+	opts := ConnectionOptions{
+		MaxOpenConns:    cfg.MaxOpenConns,
+		MaxIdleConns:    cfg.MaxIdleConns,
+		ConnMaxLifetime: time.Duration(cfg.ConnMaxLifetimeSeconds) * time.Second,
+		ConnMaxIdleTime: time.Duration(cfg.ConnMaxIdleTimeSeconds) * time.Second,
+	}
+	return opts.withDefaults()
+}
+
+// withDefaults replaces zero or negative values with the defaults
+func (opts ConnectionOptions) withDefaults() ConnectionOptions {
+	defaults := DefaultConnectionOptions()
+
+	if opts.MaxOpenConns <= 0 {
+		opts.MaxOpenConns = defaults.MaxOpenConns
+	}
+	if opts.MaxIdleConns <= 0 {
+		opts.MaxIdleConns = defaults.MaxIdleConns
+	}
+	if opts.ConnMaxLifetime <= 0 {
+		opts.ConnMaxLifetime = defaults.ConnMaxLifetime
+	}
+	if opts.ConnMaxIdleTime <= 0 {
+		opts.ConnMaxIdleTime = defaults.ConnMaxIdleTime
+	}
+
+	return opts
+}
+
 // NewConnection creates a new database connection with connection pooling
 func NewConnection(cfg *config.DatabaseConfig, opts ConnectionOptions) (*DB, error) {
 	return NewConnectionWithTimeout(cfg, opts, 30*time.Second)
@@ -65,20 +100,26 @@ func NewConnectionWithTimeout(cfg *config.DatabaseConfig, opts ConnectionOptions
 		return nil, fmt.Errorf("failed to connect to database within %v: %w", timeout, err)
 	}
 
-	// Configure connection pool
-	sqlxDB.SetMaxOpenConns(opts.MaxOpenConns)
-	sqlxDB.SetMaxIdleConns(opts.MaxIdleConns)
-	sqlxDB.SetConnMaxLifetime(opts.ConnMaxLifetime)
-	sqlxDB.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+	opts = opts.withDefaults()
+	configurePool(sqlxDB.DB, opts)
 
 	db := &DB{
-		DB:     sqlxDB,
-		config: cfg,
+		DB:      sqlxDB,
+		config:  cfg,
+		options: opts,
 	}
 
 	return db, nil
 }
 
+// configurePool applies the connection pool options to the database handle
+func configurePool(db *sql.DB, opts ConnectionOptions) {
+	db.SetMaxOpenConns(opts.MaxOpenConns)
+	db.SetMaxIdleConns(opts.MaxIdleConns)
+	db.SetConnMaxLifetime(opts.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(opts.ConnMaxIdleTime)
+}
+
 // HealthCheck performs a health check on the database connection
 func (db *DB) HealthCheck(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -99,6 +140,24 @@ func (db *DB) Stats() sql.DBStats {
 	return db.DB.Stats()
 }
 
+// Options returns the connection pool options applied to the connection
+func (db *DB) Options() ConnectionOptions {
+	return db.options
+}
+
+// SetQueryLogger sets the logger slow queries run through Timed are logged
+// to. Call it before the connection is shared.
+func (db *DB) SetQueryLogger(logger appErrors.Logger) {
+	db.logger = logger
+}
+
+// Timed returns a query timer around the connection, logging queries slower
+// than the configured DB_SLOW_QUERY_MILLISECONDS to the query logger
+func (db *DB) Timed() *TimedDB {
+	threshold := time.Duration(db.config.SlowQueryMilliseconds) * time.Millisecond
+	return NewTimedDB(db, db.logger, threshold)
+}
+
 // Close closes the database connection
 func (db *DB) Close() error {
 	return db.DB.Close()
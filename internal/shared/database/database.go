@@ -15,12 +15,13 @@ type Manager struct {
 	HealthChecker   *HealthChecker
 	MigrationRunner *MigrationRunner
 	config          *config.DatabaseConfig
+	migrationsPath  string
 }
 
 // NewManager creates a new database manager with all components
 func NewManager(cfg *config.DatabaseConfig, migrationsPath string) (*Manager, error) {
 	// Create database connection
-	db, err := NewConnection(cfg, DefaultConnectionOptions())
+	db, err := NewConnection(cfg, ConnectionOptionsFromConfig(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create database connection: %w", err)
 	}
@@ -49,9 +50,16 @@ func NewManager(cfg *config.DatabaseConfig, migrationsPath string) (*Manager, er
 		HealthChecker:   healthChecker,
 		MigrationRunner: migrationRunner,
 		config:          cfg,
+		migrationsPath:  migrationsPath,
 	}, nil
 }
 
+// Migrations returns a migration manager sharing the manager's migration
+// runner, which stays owned by the manager
+func (m *Manager) Migrations() *MigrationManager {
+	return &MigrationManager{migrationsPath: m.migrationsPath, runner: m.MigrationRunner}
+}
+
 // Initialize sets up the database with migrations and validation
 func (m *Manager) Initialize(ctx context.Context, runMigrations bool) error {
 	log.Println("Initializing database...")
@@ -64,7 +72,8 @@ func (m *Manager) Initialize(ctx context.Context, runMigrations bool) error {
 	// Run migrations if requested
 	if runMigrations {
 		log.Println("Running database migrations...")
-		if err := m.MigrationRunner.Up(); err != nil {
+		// Migrate through the manager so the applied history is recorded
+		if err := m.Migrations().MigrateUp(); err != nil {
 			return fmt.Errorf("failed to run migrations: %w", err)
 		}
 
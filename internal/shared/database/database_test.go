@@ -2,7 +2,9 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -88,6 +90,71 @@ func TestHealthChecker(t *testing.T) {
 	}
 }
 
+// TestHealthCheckerCheckWithQuery tests custom liveness probe queries
+func TestHealthCheckerCheckWithQuery(t *testing.T) {
+	// Skip if no test database is configured
+	if os.Getenv("TEST_DATABASE_URL") == "" {
+		t.Skip("TEST_DATABASE_URL not set, skipping database tests")
+	}
+
+	cfg := &config.DatabaseConfig{
+		URL: os.Getenv("TEST_DATABASE_URL"),
+	}
+
+	db, err := NewConnection(cfg, DefaultConnectionOptions())
+	if err != nil {
+		t.Fatalf("Failed to create database connection: %v", err)
+	}
+	defer db.Close()
+
+	healthChecker := NewHealthChecker(db)
+
+	t.Run("passing query", func(t *testing.T) {
+		status := healthChecker.CheckWithQuery(context.Background(), "SELECT 1")
+
+		if status.Status != "healthy" {
+			t.Errorf("Expected healthy status, got %s: %s", status.Status, status.Message)
+		}
+		if status.Message == "" {
+			t.Error("Expected message to be populated")
+		}
+		if status.Latency == 0 {
+			t.Error("Expected non-zero latency")
+		}
+		if status.Timestamp.IsZero() {
+			t.Error("Expected timestamp to be populated")
+		}
+	})
+
+	t.Run("nonexistent table", func(t *testing.T) {
+		status := healthChecker.CheckWithQuery(context.Background(), "SELECT 1 FROM health_check_missing_table LIMIT 1")
+
+		if status.Status != "unhealthy" {
+			t.Errorf("Expected unhealthy status, got %s", status.Status)
+		}
+		if !strings.Contains(status.Message, "health_check_missing_table") {
+			t.Errorf("Expected message to name the missing table, got %q", status.Message)
+		}
+	})
+
+	t.Run("context timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		status := healthChecker.CheckWithQuery(ctx, "SELECT pg_sleep(2)")
+
+		if status.Status != "unhealthy" {
+			t.Errorf("Expected unhealthy status, got %s", status.Status)
+		}
+		if status.Message == "" {
+			t.Error("Expected message to describe the timeout")
+		}
+		if status.Latency >= 2*time.Second {
+			t.Errorf("Expected query to be cancelled at the deadline, took %s", status.Latency)
+		}
+	})
+}
+
 // TestConnectionOptions tests different connection configurations
 func TestConnectionOptions(t *testing.T) {
 	opts := DefaultConnectionOptions()
@@ -109,6 +176,99 @@ func TestConnectionOptions(t *testing.T) {
 	}
 }
 
+// TestConnectionOptionsFromConfig tests pool options populated from config
+func TestConnectionOptionsFromConfig(t *testing.T) {
+	cfg := &config.DatabaseConfig{
+		MaxOpenConns:           50,
+		MaxIdleConns:           10,
+		ConnMaxLifetimeSeconds: 600,
+		ConnMaxIdleTimeSeconds: 120,
+	}
+
+	opts := ConnectionOptionsFromConfig(cfg)
+
+	if opts.MaxOpenConns != 50 {
+		t.Errorf("Expected MaxOpenConns 50, got %d", opts.MaxOpenConns)
+	}
+	if opts.MaxIdleConns != 10 {
+		t.Errorf("Expected MaxIdleConns 10, got %d", opts.MaxIdleConns)
+	}
+	if opts.ConnMaxLifetime != 10*time.Minute {
+		t.Errorf("Expected ConnMaxLifetime 10m, got %s", opts.ConnMaxLifetime)
+	}
+	if opts.ConnMaxIdleTime != 2*time.Minute {
+		t.Errorf("Expected ConnMaxIdleTime 2m, got %s", opts.ConnMaxIdleTime)
+	}
+}
+
+// TestConnectionOptionsFromConfig_Defaults tests that unset or invalid values fall back to defaults
+func TestConnectionOptionsFromConfig_Defaults(t *testing.T) {
+	defaults := DefaultConnectionOptions()
+
+	for name, cfg := range map[string]*config.DatabaseConfig{
+		"zero": {},
+		"negative": {
+			MaxOpenConns:           -1,
+			MaxIdleConns:           -5,
+			ConnMaxLifetimeSeconds: -60,
+			ConnMaxIdleTimeSeconds: -1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			opts := ConnectionOptionsFromConfig(cfg)
+			if opts != defaults {
+				t.Errorf("Expected default options %+v, got %+v", defaults, opts)
+			}
+		})
+	}
+}
+
+// TestConfigurePool tests that pool options are applied to the database handle
+func TestConfigurePool(t *testing.T) {
+	// sql.Open does not connect, so no database is needed
+	sqlDB, err := sql.Open("postgres", "host=localhost dbname=unused sslmode=disable")
+	if err != nil {
+		t.Fatalf("Failed to open database handle: %v", err)
+	}
+	defer sqlDB.Close()
+
+	opts := ConnectionOptionsFromConfig(&config.DatabaseConfig{MaxOpenConns: 7, MaxIdleConns: 3})
+	configurePool(sqlDB, opts)
+
+	if stats := sqlDB.Stats(); stats.MaxOpenConnections != 7 {
+		t.Errorf("Expected MaxOpenConnections 7 on handle, got %d", stats.MaxOpenConnections)
+	}
+}
+
+// TestNewConnection_AppliesPoolOptions tests pool settings on a live connection
+func TestNewConnection_AppliesPoolOptions(t *testing.T) {
+	// Skip if no test database is configured
+	if os.Getenv("TEST_DATABASE_URL") == "" {
+		t.Skip("TEST_DATABASE_URL not set, skipping database tests")
+	}
+
+	cfg := &config.DatabaseConfig{
+		URL:          os.Getenv("TEST_DATABASE_URL"),
+		MaxOpenConns: 12,
+		MaxIdleConns: 4,
+	}
+
+	db, err := NewConnection(cfg, ConnectionOptionsFromConfig(cfg))
+	if err != nil {
+		t.Fatalf("Failed to create database connection: %v", err)
+	}
+	defer db.Close()
+
+	if stats := db.Stats(); stats.MaxOpenConnections != 12 {
+		t.Errorf("Expected MaxOpenConnections 12, got %d", stats.MaxOpenConnections)
+	}
+
+	status := NewHealthChecker(db).Check(context.Background())
+	if status.Connections.MaxOpenConns != 12 || status.Connections.MaxIdleConns != 4 {
+		t.Errorf("Expected health stats to report configured maximums, got %+v", status.Connections)
+	}
+}
+
 // BenchmarkHealthCheck benchmarks the health check performance
 func BenchmarkHealthCheck(b *testing.B) {
 	// Skip if no test database is configured
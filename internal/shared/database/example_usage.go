@@ -107,7 +107,7 @@ func ExampleHealthMonitoring() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	db, err := NewConnection(&cfg.Database, DefaultConnectionOptions())
+	db, err := NewConnection(&cfg.Database, ConnectionOptionsFromConfig(&cfg.Database))
 	if err != nil {
 		log.Fatalf("Failed to create database connection: %v", err)
 	}
@@ -26,6 +26,8 @@ type DBStats struct {
 	MaxIdleClosed     int `json:"max_idle_closed"`
 	MaxIdleTimeClosed int `json:"max_idle_time_closed"`
 	MaxLifetimeClosed int `json:"max_lifetime_closed"`
+	MaxOpenConns      int `json:"max_open_connections"`
+	MaxIdleConns      int `json:"max_idle_connections"`
 }
 
 // HealthChecker provides database health checking functionality
@@ -41,24 +43,60 @@ func NewHealthChecker(db *DB) *HealthChecker {
 // Check performs a comprehensive health check on the database
 func (hc *HealthChecker) Check(ctx context.Context) *HealthStatus {
 	start := time.Now()
-	status := &HealthStatus{
-		Timestamp: start,
-	}
 
 	// Perform health check with timeout
 	checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	err := hc.db.HealthCheck(checkCtx)
-	latency := time.Since(start)
-	status.Latency = latency
+
+	return hc.newStatus(start, err, "Database is responding normally")
+}
+
+// CheckWithQuery runs the given statement as a liveness probe, for example
+// to verify that a critical table is reachable. The returned status reports
+// whether the statement succeeded and how long it took.
+func (hc *HealthChecker) CheckWithQuery(ctx context.Context, query string) *HealthStatus {
+	start := time.Now()
+
+	checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := hc.runQuery(checkCtx, query)
+	if err != nil {
+		err = fmt.Errorf("health check query failed: %w", err)
+	}
+
+	return hc.newStatus(start, err, "Query executed successfully")
+}
+
+// runQuery executes the query and reads its result set
+func (hc *HealthChecker) runQuery(ctx context.Context, query string) error {
+	rows, err := hc.db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+	}
+
+	return rows.Err()
+}
+
+// newStatus builds a health status from the outcome of a check started at start
+func (hc *HealthChecker) newStatus(start time.Time, err error, healthyMessage string) *HealthStatus {
+	status := &HealthStatus{
+		Timestamp: start,
+		Latency:   time.Since(start),
+	}
 
 	if err != nil {
 		status.Status = "unhealthy"
 		status.Message = err.Error()
 	} else {
 		status.Status = "healthy"
-		status.Message = "Database is responding normally"
+		status.Message = healthyMessage
 	}
 
 	// Get connection statistics
@@ -72,6 +110,8 @@ func (hc *HealthChecker) Check(ctx context.Context) *HealthStatus {
 		MaxIdleClosed:     int(stats.MaxIdleClosed),
 		MaxIdleTimeClosed: int(stats.MaxIdleTimeClosed),
 		MaxLifetimeClosed: int(stats.MaxLifetimeClosed),
+		MaxOpenConns:      stats.MaxOpenConnections,
+		MaxIdleConns:      hc.db.Options().MaxIdleConns,
 	}
 
 	return status
@@ -1,6 +1,9 @@
 package database
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -18,8 +21,63 @@ type MigrationInfo struct {
 	UpFile      string
 	DownFile    string
 	Description string
+	AppliedAt   *time.Time `json:"applied_at,omitempty"`
 }
 
+// AppliedMigration records when a migration was applied
+type AppliedMigration struct {
+	Version   uint      `json:"version"`
+	Name      string    `json:"name"`
+	AppliedAt time.Time `json:"applied_at"`
+
+	// Checksum is the SHA-256 of the up file when the migration was applied,
+	// or empty if it has yet to be recorded
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// MigrationChecksumError reports an applied migration whose up file has
+// changed since it was applied
+type MigrationChecksumError struct {
+	Version  uint
+	Name     string
+	Expected string
+	Actual   string
+}
+
+// Error implements the error interface
+func (e *MigrationChecksumError) Error() string {
+	return fmt.Sprintf("applied migration %d (%s) has been modified: checksum %s does not match %s recorded when it was applied",
+		e.Version, e.Name, e.Actual, e.Expected)
+}
+
+// Migration directions
+const (
+	MigrationDirectionUp   = "up"
+	MigrationDirectionDown = "down"
+)
+
+// PlannedMigration is a migration file that would run to reach a target version
+type PlannedMigration struct {
+	Version   uint   `json:"version"`
+	Name      string `json:"name"`
+	Direction string `json:"direction"`
+	File      string `json:"file"`
+	SQL       string `json:"sql"`
+}
+
+// migrationHistoryTable tracks when each migration was applied. The
+// schema_migrations table owned by golang-migrate only holds the current
+// version, so per-migration timestamps are kept alongside it.
+const migrationHistoryTable = "schema_migrations_history"
+
+// baselineMigrationName names the migration that squashes every migration
+// up to its version into one file
+const baselineMigrationName = "baseline"
+
+// MigrationArchiveDir is the subdirectory of the migrations directory that
+// baselined migration files are moved to. Migration sources ignore it.
+const MigrationArchiveDir = "archive"
+
 // MigrationManager provides utilities for managing migrations
 type MigrationManager struct {
 	migrationsPath string
@@ -220,11 +278,24 @@ func (mm *MigrationManager) GetStatus() (*MigrationStatus, error) {
 		latestVersion = migrations[len(migrations)-1].Version
 	}
 
+	history, err := mm.GetAppliedMigrations(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	appliedAt := make(map[uint]time.Time, len(history))
+	for _, applied := range history {
+		appliedAt[applied.Version] = applied.AppliedAt
+	}
+
 	appliedMigrations := make([]MigrationInfo, 0)
 	pendingMigrations := make([]MigrationInfo, 0)
 
 	for _, migration := range migrations {
 		if migration.Version <= currentVersion {
+			if at, ok := appliedAt[migration.Version]; ok {
+				migration.AppliedAt = &at
+			}
 			appliedMigrations = append(appliedMigrations, migration)
 		} else {
 			pendingMigrations = append(pendingMigrations, migration)
@@ -243,19 +314,75 @@ func (mm *MigrationManager) GetStatus() (*MigrationStatus, error) {
 	}, nil
 }
 
-// MigrateUp runs migrations up
+// Summary returns the migration state of the database. Unlike GetStatus it
+// doesn't read the applied migration history, so it is cheap enough for
+// readiness probes.
+func (mm *MigrationManager) Summary() (*MigrationSummary, error) {
+	currentVersion, dirty, err := mm.runner.Version()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current version: %w", err)
+	}
+
+	migrations, err := mm.ListMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	summary := &MigrationSummary{CurrentVersion: currentVersion, Dirty: dirty}
+	for _, migration := range migrations {
+		summary.LatestVersion = max(summary.LatestVersion, migration.Version)
+		if migration.Version > currentVersion {
+			summary.PendingCount++
+		}
+	}
+	return summary, nil
+}
+
+// MigrateUp runs migrations up, one at a time so each gets its own
+// applied-at timestamp
 func (mm *MigrationManager) MigrateUp() error {
-	return mm.runner.Up()
+	currentVersion, _, err := mm.runner.Version()
+	if err != nil {
+		return fmt.Errorf("failed to get current version: %w", err)
+	}
+
+	migrations, err := mm.ListMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	pending := 0
+	for _, migration := range migrations {
+		if migration.Version > currentVersion {
+			pending++
+		}
+	}
+
+	if pending == 0 {
+		// Record migrations applied by other means
+		return mm.syncHistory(context.Background())
+	}
+	return mm.stepUp(pending)
 }
 
 // MigrateDown runs migrations down
 func (mm *MigrationManager) MigrateDown() error {
-	return mm.runner.Down()
+	if err := mm.runner.Down(); err != nil {
+		return err
+	}
+	return mm.syncHistory(context.Background())
 }
 
 // MigrateSteps runs n migration steps
 func (mm *MigrationManager) MigrateSteps(n int) error {
-	return mm.runner.Steps(n)
+	if n > 0 {
+		return mm.stepUp(n)
+	}
+
+	if err := mm.runner.Steps(n); err != nil {
+		return err
+	}
+	return mm.syncHistory(context.Background())
 }
 
 // MigrateTo migrates to a specific version
@@ -272,29 +399,307 @@ func (mm *MigrationManager) MigrateTo(targetVersion uint) error {
 	if targetVersion > currentVersion {
 		// Migrate up
 		steps := int(targetVersion - currentVersion)
-		return mm.runner.Steps(steps)
+		return mm.stepUp(steps)
 	} else {
 		// Migrate down
 		steps := -int(currentVersion - targetVersion)
-		return mm.runner.Steps(steps)
+		if err := mm.runner.Steps(steps); err != nil {
+			return err
+		}
+		return mm.syncHistory(context.Background())
+	}
+}
+
+// PlanMigration returns the migration files that MigrateTo would run to reach
+// the target version, in execution order, without touching the database
+func (mm *MigrationManager) PlanMigration(target uint) ([]PlannedMigration, error) {
+	currentVersion, _, err := mm.runner.Version()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current version: %w", err)
+	}
+
+	migrations, err := mm.ListMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	steps, direction, err := planSteps(migrations, currentVersion, target)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := make([]PlannedMigration, 0, len(steps))
+	for _, migration := range steps {
+		file := migration.UpFile
+		if direction == MigrationDirectionDown {
+			file = migration.DownFile
+		}
+
+		content, err := os.ReadFile(filepath.Join(mm.migrationsPath, file))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file %s: %w", file, err)
+		}
+
+		plan = append(plan, PlannedMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Direction: direction,
+			File:      file,
+			SQL:       string(content),
+		})
+	}
+
+	return plan, nil
+}
+
+// PlanSteps returns the migration files that MigrateSteps(n) would run
+func (mm *MigrationManager) PlanSteps(n int) ([]PlannedMigration, error) {
+	currentVersion, _, err := mm.runner.Version()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current version: %w", err)
+	}
+
+	migrations, err := mm.ListMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	target, err := stepsTarget(migrations, currentVersion, n)
+	if err != nil {
+		return nil, err
+	}
+
+	return mm.PlanMigration(target)
+}
+
+// planSteps selects the migrations between the current and target versions
+// in the order they would run, along with the direction
+func planSteps(migrations []MigrationInfo, current, target uint) ([]MigrationInfo, string, error) {
+	if target != 0 && !hasMigrationVersion(migrations, target) {
+		return nil, "", fmt.Errorf("target version %d does not exist", target)
+	}
+
+	steps := make([]MigrationInfo, 0)
+
+	if target >= current {
+		for _, migration := range migrations {
+			if migration.Version > current && migration.Version <= target {
+				steps = append(steps, migration)
+			}
+		}
+		return steps, MigrationDirectionUp, nil
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if migration.Version <= current && migration.Version > target {
+			steps = append(steps, migration)
+		}
+	}
+	return steps, MigrationDirectionDown, nil
+}
+
+// stepsTarget returns the version reached after n steps from the current version
+func stepsTarget(migrations []MigrationInfo, current uint, n int) (uint, error) {
+	// Index of the current version; -1 when nothing is applied
+	index := -1
+	for i, migration := range migrations {
+		if migration.Version <= current {
+			index = i
+		}
+	}
+
+	targetIndex := index + n
+	if targetIndex < -1 || targetIndex >= len(migrations) {
+		return 0, fmt.Errorf("cannot run %d steps from version %d: not enough migrations", n, current)
+	}
+
+	if targetIndex == -1 {
+		return 0, nil
+	}
+	return migrations[targetIndex].Version, nil
+}
+
+// hasMigrationVersion reports whether a migration with the given version exists
+func hasMigrationVersion(migrations []MigrationInfo, version uint) bool {
+	for _, migration := range migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAppliedMigrations returns the migrations recorded in the history as
+// applied, with the time each was applied, ordered by version. It only reads
+// the history, which migrating through the manager keeps up to date;
+// migrations applied by other means are recorded the next time the manager
+// migrates.
+func (mm *MigrationManager) GetAppliedMigrations(ctx context.Context) ([]AppliedMigration, error) {
+	exists, hasChecksum, err := mm.historyTableColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return []AppliedMigration{}, nil
+	}
+
+	// History tables created before checksums were tracked lack the column
+	checksumColumn := "COALESCE(checksum, '')"
+	if !hasChecksum {
+		checksumColumn = "''"
+	}
+
+	query := fmt.Sprintf("SELECT version, name, applied_at, %s FROM %s ORDER BY version", checksumColumn, migrationHistoryTable)
+	rows, err := mm.runner.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query migration history: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make([]AppliedMigration, 0)
+	for rows.Next() {
+		var migration AppliedMigration
+		if err := rows.Scan(&migration.Version, &migration.Name, &migration.AppliedAt, &migration.Checksum); err != nil {
+			return nil, fmt.Errorf("failed to scan migration history: %w", err)
+		}
+		applied = append(applied, migration)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read migration history: %w", err)
+	}
+
+	return applied, nil
+}
+
+// historyTableColumns reports whether the history table exists and whether
+// it has the checksum column, without creating either
+func (mm *MigrationManager) historyTableColumns(ctx context.Context) (bool, bool, error) {
+	query := `
+		SELECT to_regclass($1) IS NOT NULL,
+		       EXISTS (
+		           SELECT 1 FROM pg_attribute
+		           WHERE attrelid = to_regclass($1) AND attname = 'checksum' AND NOT attisdropped
+		       )`
+
+	var exists, hasChecksum bool
+	if err := mm.runner.db.QueryRowContext(ctx, query, migrationHistoryTable).Scan(&exists, &hasChecksum); err != nil {
+		return false, false, fmt.Errorf("failed to inspect migration history table: %w", err)
+	}
+	return exists, hasChecksum, nil
+}
+
+// stepUp applies n migrations one at a time, recording each in the history
+func (mm *MigrationManager) stepUp(n int) error {
+	for i := 0; i < n; i++ {
+		if err := mm.runner.Steps(1); err != nil {
+			return err
+		}
+		if err := mm.syncHistory(context.Background()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncHistory makes the history table match the current migration version,
+// recording newly applied migrations and removing rolled back ones. Only
+// operations that change the migration version call it, so reading the
+// status never writes to the database.
+func (mm *MigrationManager) syncHistory(ctx context.Context) error {
+	createQuery := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			checksum VARCHAR(64)
+		)`, migrationHistoryTable)
+
+	if _, err := mm.runner.db.ExecContext(ctx, createQuery); err != nil {
+		return fmt.Errorf("failed to create migration history table: %w", err)
+	}
+
+	// History tables created before checksums were tracked lack the column
+	alterQuery := fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS checksum VARCHAR(64)", migrationHistoryTable)
+	if _, err := mm.runner.db.ExecContext(ctx, alterQuery); err != nil {
+		return fmt.Errorf("failed to add checksum to migration history table: %w", err)
+	}
+
+	currentVersion, dirty, err := mm.runner.Version()
+	if err != nil {
+		return fmt.Errorf("failed to get current version: %w", err)
+	}
+
+	// A dirty version failed part way through and is not applied
+	appliedVersion := currentVersion
+	if dirty && appliedVersion > 0 {
+		appliedVersion--
+	}
+
+	migrations, err := mm.ListMigrations()
+	if err != nil {
+		return fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	tx, err := mm.runner.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration history transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE version > $1", migrationHistoryTable)
+	if _, err := tx.ExecContext(ctx, deleteQuery, appliedVersion); err != nil {
+		return fmt.Errorf("failed to remove rolled back migrations from history: %w", err)
+	}
+
+	insertQuery := fmt.Sprintf(
+		"INSERT INTO %s (version, name, checksum) VALUES ($1, $2, $3) ON CONFLICT (version) DO NOTHING",
+		migrationHistoryTable,
+	)
+	for _, migration := range migrations {
+		if migration.Version > appliedVersion {
+			break
+		}
+		checksum, err := mm.checksum(migration)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, insertQuery, migration.Version, migration.Name, checksum); err != nil {
+			return fmt.Errorf("failed to record migration %d in history: %w", migration.Version, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration history: %w", err)
 	}
+
+	return nil
 }
 
 // Force forces the migration version
 func (mm *MigrationManager) Force(version int) error {
-	return mm.runner.Force(version)
+	if err := mm.runner.Force(version); err != nil {
+		return err
+	}
+	return mm.syncHistory(context.Background())
 }
 
-// ValidateMigrations validates all migration files
+// ValidateMigrations validates all migration files, and that migrations
+// already applied have not been edited since
 func (mm *MigrationManager) ValidateMigrations() error {
 	migrations, err := mm.ListMigrations()
 	if err != nil {
 		return fmt.Errorf("failed to list migrations: %w", err)
 	}
 
-	// Check for gaps in version numbers
+	// Check for gaps in version numbers, which start after a baseline
+	firstVersion := uint(1)
+	if baseline, ok := baselineOf(migrations); ok {
+		firstVersion = baseline.Version
+	}
 	for i, migration := range migrations {
-		expectedVersion := uint(i + 1)
+		expectedVersion := firstVersion + uint(i)
 		if migration.Version != expectedVersion {
 			return fmt.Errorf("migration version gap detected: expected %d, found %d", expectedVersion, migration.Version)
 		}
@@ -332,9 +737,251 @@ func (mm *MigrationManager) ValidateMigrations() error {
 		}
 	}
 
+	return mm.verifyChecksums(context.Background(), migrations)
+}
+
+// verifyChecksums checks that the up file of every applied migration still
+// has the checksum recorded when it was applied. Applied migrations without
+// a recorded checksum, applied before checksums were tracked, have their
+// current checksum recorded instead.
+func (mm *MigrationManager) verifyChecksums(ctx context.Context, migrations []MigrationInfo) error {
+	applied, err := mm.GetAppliedMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	files := make(map[uint]MigrationInfo, len(migrations))
+	for _, migration := range migrations {
+		files[migration.Version] = migration
+	}
+	baseline, hasBaseline := baselineOf(migrations)
+
+	updateQuery := fmt.Sprintf("UPDATE %s SET checksum = $2 WHERE version = $1 AND checksum IS NULL", migrationHistoryTable)
+	for _, migration := range applied {
+		// A database migrated past the baseline with the files it squashes
+		// takes the baseline as applied
+		if hasBaseline && migration.Version <= baseline.Version && migration.Name != baselineMigrationName {
+			currentVersion, _, err := mm.runner.Version()
+			if err != nil {
+				return fmt.Errorf("failed to get current version: %w", err)
+			}
+			if currentVersion < baseline.Version {
+				return fmt.Errorf("database is at version %d, behind the baseline at version %d: migrate it with the archived migrations first",
+					currentVersion, baseline.Version)
+			}
+
+			checksum, err := mm.checksum(baseline)
+			if err != nil {
+				return err
+			}
+			if err := mm.recordBaseline(ctx, baseline.Version, checksum); err != nil {
+				return err
+			}
+			return mm.verifyChecksums(ctx, migrations)
+		}
+
+		file, ok := files[migration.Version]
+		if !ok {
+			return fmt.Errorf("applied migration %d (%s) has no migration file", migration.Version, migration.Name)
+		}
+
+		checksum, err := mm.checksum(file)
+		if err != nil {
+			return err
+		}
+
+		if migration.Checksum == "" {
+			if _, err := mm.runner.db.ExecContext(ctx, updateQuery, migration.Version, checksum); err != nil {
+				return fmt.Errorf("failed to record checksum of migration %d: %w", migration.Version, err)
+			}
+			continue
+		}
+
+		if checksum != migration.Checksum {
+			return &MigrationChecksumError{
+				Version:  migration.Version,
+				Name:     migration.Name,
+				Expected: migration.Checksum,
+				Actual:   checksum,
+			}
+		}
+	}
+
+	return nil
+}
+
+// Baseline squashes every migration into a single baseline migration at the
+// current version, so fresh databases apply one file instead of the whole
+// history. The up file runs each migration's up SQL in order and the down
+// file each down SQL in reverse. The squashed files are moved to the archive
+// subdirectory and the database records the baseline as applied. The
+// database must be clean and up to date with migrations that validate.
+//
+// Other databases must be migrated to the baseline version before they use
+// the baselined migrations; they take the baseline as applied when they are
+// validated. The manager's migration source still holds the squashed files,
+// so create a new manager to migrate with the baseline.
+func (mm *MigrationManager) Baseline(ctx context.Context) (*MigrationInfo, error) {
+	status, err := mm.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	if status.IsDirty {
+		return nil, fmt.Errorf("cannot baseline: database is dirty at version %d", status.CurrentVersion)
+	}
+	if status.CurrentVersion == 0 {
+		return nil, fmt.Errorf("cannot baseline: no migrations have been applied")
+	}
+	if status.CurrentVersion != status.LatestVersion {
+		return nil, fmt.Errorf("cannot baseline: database is at version %d but the latest migration is %d",
+			status.CurrentVersion, status.LatestVersion)
+	}
+
+	// Record migrations applied by other means before the history is
+	// replaced by the baseline
+	if err := mm.syncHistory(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := mm.ValidateMigrations(); err != nil {
+		return nil, fmt.Errorf("cannot baseline: %w", err)
+	}
+
+	migrations, err := mm.ListMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	if len(migrations) == 1 && migrations[0].Name == baselineMigrationName {
+		return nil, fmt.Errorf("cannot baseline: migrations are already a baseline at version %d", migrations[0].Version)
+	}
+
+	upSQL, downSQL, err := squashMigrations(mm.migrationsPath, migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	// Move the squashed files out of the way first, since the baseline has
+	// the same version as the latest of them
+	archivePath := filepath.Join(mm.migrationsPath, MigrationArchiveDir)
+	if err := os.MkdirAll(archivePath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create migration archive directory: %w", err)
+	}
+	for _, migration := range migrations {
+		for _, file := range []string{migration.UpFile, migration.DownFile} {
+			if err := os.Rename(filepath.Join(mm.migrationsPath, file), filepath.Join(archivePath, file)); err != nil {
+				return nil, fmt.Errorf("failed to archive migration file %s: %w", file, err)
+			}
+		}
+	}
+
+	baseline := &MigrationInfo{
+		Version:     status.CurrentVersion,
+		Name:        baselineMigrationName,
+		UpFile:      fmt.Sprintf("%03d_%s.up.sql", status.CurrentVersion, baselineMigrationName),
+		DownFile:    fmt.Sprintf("%03d_%s.down.sql", status.CurrentVersion, baselineMigrationName),
+		Description: fmt.Sprintf("Baseline of migrations %d to %d", migrations[0].Version, status.CurrentVersion),
+	}
+
+	if err := os.WriteFile(filepath.Join(mm.migrationsPath, baseline.UpFile), upSQL, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write baseline up migration: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(mm.migrationsPath, baseline.DownFile), downSQL, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write baseline down migration: %w", err)
+	}
+
+	checksum, err := mm.checksum(*baseline)
+	if err != nil {
+		return nil, err
+	}
+	if err := mm.recordBaseline(ctx, baseline.Version, checksum); err != nil {
+		return nil, err
+	}
+
+	return baseline, nil
+}
+
+// recordBaseline replaces the history of the migrations a baseline squashes
+// with the baseline itself, keeping when the last of them was applied
+func (mm *MigrationManager) recordBaseline(ctx context.Context, version uint, checksum string) error {
+	tx, err := mm.runner.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration history transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE version < $1", migrationHistoryTable)
+	if _, err := tx.ExecContext(ctx, deleteQuery, version); err != nil {
+		return fmt.Errorf("failed to remove baselined migrations from history: %w", err)
+	}
+
+	upsertQuery := fmt.Sprintf(`
+		INSERT INTO %s (version, name, checksum) VALUES ($1, $2, $3)
+		ON CONFLICT (version) DO UPDATE SET name = EXCLUDED.name, checksum = EXCLUDED.checksum`,
+		migrationHistoryTable)
+	if _, err := tx.ExecContext(ctx, upsertQuery, version, baselineMigrationName, checksum); err != nil {
+		return fmt.Errorf("failed to record baseline migration %d in history: %w", version, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration history: %w", err)
+	}
+
 	return nil
 }
 
+// baselineOf returns the first migration if it is a baseline
+func baselineOf(migrations []MigrationInfo) (MigrationInfo, bool) {
+	if len(migrations) > 0 && migrations[0].Name == baselineMigrationName {
+		return migrations[0], true
+	}
+	return MigrationInfo{}, false
+}
+
+// squashMigrations concatenates the up files of migrations in order and
+// their down files in reverse order, each preceded by a comment naming it
+func squashMigrations(migrationsPath string, migrations []MigrationInfo) ([]byte, []byte, error) {
+	if len(migrations) == 0 {
+		return nil, nil, fmt.Errorf("no migrations to squash")
+	}
+
+	first, last := migrations[0].Version, migrations[len(migrations)-1].Version
+
+	var up, down strings.Builder
+	fmt.Fprintf(&up, "-- Migration: baseline of migrations %d to %d\n", first, last)
+	fmt.Fprintf(&down, "-- Migration rollback: baseline of migrations %d to %d\n", first, last)
+
+	for _, migration := range migrations {
+		content, err := os.ReadFile(filepath.Join(migrationsPath, migration.UpFile))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read up migration file %s: %w", migration.UpFile, err)
+		}
+		fmt.Fprintf(&up, "\n-- %s\n%s\n", migration.UpFile, strings.TrimSpace(string(content)))
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		content, err := os.ReadFile(filepath.Join(migrationsPath, migration.DownFile))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read down migration file %s: %w", migration.DownFile, err)
+		}
+		fmt.Fprintf(&down, "\n-- %s\n%s\n", migration.DownFile, strings.TrimSpace(string(content)))
+	}
+
+	return []byte(up.String()), []byte(down.String()), nil
+}
+
+// checksum returns the hex-encoded SHA-256 of a migration's up file
+func (mm *MigrationManager) checksum(migration MigrationInfo) (string, error) {
+	content, err := os.ReadFile(filepath.Join(mm.migrationsPath, migration.UpFile))
+	if err != nil {
+		return "", fmt.Errorf("failed to read up migration file %s: %w", migration.UpFile, err)
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 // getNextVersion determines the next version number for a new migration
 func (mm *MigrationManager) getNextVersion() (uint, error) {
 	migrations, err := mm.ListMigrations()
@@ -382,6 +1029,36 @@ func (ms *MigrationStatus) IsUpToDate() bool {
 	return ms.CurrentVersion == ms.LatestVersion && !ms.IsDirty
 }
 
+// MigrationSummary is the migration state of the database, without the
+// per-migration details of MigrationStatus
+type MigrationSummary struct {
+	CurrentVersion uint `json:"current_version"`
+	LatestVersion  uint `json:"latest_version"`
+	Dirty          bool `json:"dirty"`
+	PendingCount   int  `json:"pending_count"`
+}
+
+// MigrationSummarizer reports the migration state of a database
+type MigrationSummarizer interface {
+	Summary() (*MigrationSummary, error)
+}
+
+var _ MigrationSummarizer = (*MigrationManager)(nil)
+
+// Err returns why the database isn't ready to serve the application: a
+// migration failed part way, leaving it dirty, or migrations are pending.
+// It returns nil when every migration is applied.
+func (ms *MigrationSummary) Err() error {
+	if ms.Dirty {
+		return fmt.Errorf("database is dirty at migration version %d", ms.CurrentVersion)
+	}
+	if ms.PendingCount > 0 {
+		return fmt.Errorf("%d migrations pending: at version %d of %d",
+			ms.PendingCount, ms.CurrentVersion, ms.LatestVersion)
+	}
+	return nil
+}
+
 // NeedsMigration returns true if there are pending migrations
 func (ms *MigrationStatus) NeedsMigration() bool {
 	return ms.PendingCount > 0 || ms.IsDirty
@@ -1,9 +1,11 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -262,6 +264,395 @@ DROP TABLE IF EXISTS users;`
 	require.Contains(t, err.Error(), "empty or contains no SQL")
 }
 
+// TestGetAppliedMigrations tests that applied migrations are tracked with timestamps
+func TestGetAppliedMigrations(t *testing.T) {
+	// Skip if no test database is configured
+	if os.Getenv("TEST_DATABASE_URL") == "" {
+		t.Skip("TEST_DATABASE_URL not set, skipping applied migration tests")
+	}
+
+	cfg := &config.DatabaseConfig{
+		URL: os.Getenv("TEST_DATABASE_URL"),
+	}
+
+	// Create temporary migrations directory
+	tempDir := t.TempDir()
+	migrationsPath := filepath.Join(tempDir, "migrations")
+	err := os.MkdirAll(migrationsPath, 0755)
+	require.NoError(t, err)
+
+	files := map[string]string{
+		"001_create_history_a.up.sql":   "CREATE TABLE history_test_a (id SERIAL PRIMARY KEY);",
+		"001_create_history_a.down.sql": "DROP TABLE IF EXISTS history_test_a;",
+		"002_create_history_b.up.sql":   "CREATE TABLE history_test_b (id SERIAL PRIMARY KEY);",
+		"002_create_history_b.down.sql": "DROP TABLE IF EXISTS history_test_b;",
+	}
+	for name, content := range files {
+		err = os.WriteFile(filepath.Join(migrationsPath, name), []byte(content), 0644)
+		require.NoError(t, err)
+	}
+
+	manager, err := NewMigrationManager(cfg, migrationsPath)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	// Ensure we start from a clean state
+	_ = manager.MigrateDown()
+	defer manager.MigrateDown()
+
+	ctx := context.Background()
+	applied, err := manager.GetAppliedMigrations(ctx)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	before := time.Now().Add(-time.Minute)
+	err = manager.MigrateUp()
+	require.NoError(t, err, "Failed to migrate up")
+
+	applied, err = manager.GetAppliedMigrations(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+
+	require.Equal(t, uint(1), applied[0].Version)
+	require.Equal(t, "create_history_a", applied[0].Name)
+	require.Equal(t, uint(2), applied[1].Version)
+	require.Equal(t, "create_history_b", applied[1].Name)
+
+	for _, migration := range applied {
+		require.True(t, migration.AppliedAt.After(before), "Applied-at should be populated for version %d", migration.Version)
+	}
+	require.False(t, applied[1].AppliedAt.Before(applied[0].AppliedAt), "Applied-at should follow version order")
+
+	// Status exposes the timestamps per applied migration
+	status, err := manager.GetStatus()
+	require.NoError(t, err)
+	require.Len(t, status.AppliedMigrations, 2)
+	require.NotNil(t, status.AppliedMigrations[0].AppliedAt)
+	require.True(t, status.AppliedMigrations[0].AppliedAt.Equal(applied[0].AppliedAt))
+
+	// Rolling back removes the migration from the history
+	err = manager.MigrateSteps(-1)
+	require.NoError(t, err)
+
+	applied, err = manager.GetAppliedMigrations(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	require.Equal(t, uint(1), applied[0].Version)
+}
+
+// TestMigrationChecksums tests that validation detects edited applied migrations
+func TestMigrationChecksums(t *testing.T) {
+	// Skip if no test database is configured
+	if os.Getenv("TEST_DATABASE_URL") == "" {
+		t.Skip("TEST_DATABASE_URL not set, skipping migration checksum tests")
+	}
+
+	cfg := &config.DatabaseConfig{
+		URL: os.Getenv("TEST_DATABASE_URL"),
+	}
+
+	// Create temporary migrations directory
+	tempDir := t.TempDir()
+	migrationsPath := filepath.Join(tempDir, "migrations")
+	err := os.MkdirAll(migrationsPath, 0755)
+	require.NoError(t, err)
+
+	files := map[string]string{
+		"001_create_checksum_a.up.sql":   "CREATE TABLE checksum_test_a (id SERIAL PRIMARY KEY);",
+		"001_create_checksum_a.down.sql": "DROP TABLE IF EXISTS checksum_test_a;",
+		"002_create_checksum_b.up.sql":   "CREATE TABLE checksum_test_b (id SERIAL PRIMARY KEY);",
+		"002_create_checksum_b.down.sql": "DROP TABLE IF EXISTS checksum_test_b;",
+	}
+	for name, content := range files {
+		err = os.WriteFile(filepath.Join(migrationsPath, name), []byte(content), 0644)
+		require.NoError(t, err)
+	}
+
+	manager, err := NewMigrationManager(cfg, migrationsPath)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	// Ensure we start from a clean state
+	_ = manager.MigrateDown()
+	defer manager.MigrateDown()
+
+	err = manager.MigrateUp()
+	require.NoError(t, err, "Failed to migrate up")
+
+	// Unchanged migrations validate, with a checksum recorded for each
+	err = manager.ValidateMigrations()
+	require.NoError(t, err, "Validation should pass for unchanged migrations")
+
+	ctx := context.Background()
+	applied, err := manager.GetAppliedMigrations(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	for _, migration := range applied {
+		require.Len(t, migration.Checksum, 64, "Checksum should be recorded for version %d", migration.Version)
+	}
+
+	// Migrations applied before checksums were tracked get one recorded
+	_, err = manager.runner.db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET checksum = NULL WHERE version = 2", migrationHistoryTable))
+	require.NoError(t, err)
+
+	err = manager.ValidateMigrations()
+	require.NoError(t, err, "Validation should record missing checksums")
+
+	backfilled, err := manager.GetAppliedMigrations(ctx)
+	require.NoError(t, err)
+	require.Equal(t, applied[1].Checksum, backfilled[1].Checksum)
+
+	// Editing an applied migration fails validation, naming its version
+	err = os.WriteFile(filepath.Join(migrationsPath, "002_create_checksum_b.up.sql"),
+		[]byte("CREATE TABLE checksum_test_b (id SERIAL PRIMARY KEY, name TEXT);"), 0644)
+	require.NoError(t, err)
+
+	err = manager.ValidateMigrations()
+	require.Error(t, err, "Validation should fail for a modified applied migration")
+
+	var checksumErr *MigrationChecksumError
+	require.ErrorAs(t, err, &checksumErr)
+	require.Equal(t, uint(2), checksumErr.Version)
+	require.Equal(t, "create_checksum_b", checksumErr.Name)
+	require.Contains(t, err.Error(), "applied migration 2")
+}
+
+// writeBaselineTestMigrations writes two migrations creating related tables
+func writeBaselineTestMigrations(t *testing.T, migrationsPath string) {
+	t.Helper()
+
+	files := map[string]string{
+		"001_create_baseline_a.up.sql":   "CREATE TABLE baseline_test_a (id SERIAL PRIMARY KEY);",
+		"001_create_baseline_a.down.sql": "DROP TABLE IF EXISTS baseline_test_a;",
+		"002_create_baseline_b.up.sql":   "CREATE TABLE baseline_test_b (id SERIAL PRIMARY KEY, a_id INTEGER REFERENCES baseline_test_a(id));",
+		"002_create_baseline_b.down.sql": "DROP TABLE IF EXISTS baseline_test_b;",
+	}
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(migrationsPath, name), []byte(content), 0644)
+		require.NoError(t, err)
+	}
+}
+
+// TestSquashMigrations tests that a baseline runs ups in order and downs in reverse
+func TestSquashMigrations(t *testing.T) {
+	migrationsPath := t.TempDir()
+	writeBaselineTestMigrations(t, migrationsPath)
+
+	manager := &MigrationManager{migrationsPath: migrationsPath}
+	migrations, err := manager.ListMigrations()
+	require.NoError(t, err)
+
+	up, down, err := squashMigrations(migrationsPath, migrations)
+	require.NoError(t, err)
+
+	upSQL, downSQL := string(up), string(down)
+	require.True(t, strings.HasPrefix(upSQL, "-- Migration: baseline of migrations 1 to 2"))
+	require.Less(t, strings.Index(upSQL, "CREATE TABLE baseline_test_a"), strings.Index(upSQL, "CREATE TABLE baseline_test_b"))
+	require.Less(t, strings.Index(downSQL, "DROP TABLE IF EXISTS baseline_test_b"), strings.Index(downSQL, "DROP TABLE IF EXISTS baseline_test_a"))
+	require.True(t, containsSQL(upSQL))
+	require.True(t, containsSQL(downSQL))
+
+	_, _, err = squashMigrations(migrationsPath, nil)
+	require.Error(t, err)
+}
+
+// TestMigrationBaseline tests that a baseline reproduces the schema it squashes
+func TestMigrationBaseline(t *testing.T) {
+	SkipIfNoDatabase(t)
+
+	migrationsPath := t.TempDir()
+	writeBaselineTestMigrations(t, migrationsPath)
+
+	source := NewTestDatabase(t)
+	manager, err := NewMigrationManager(source.Config.ToConfig(), migrationsPath)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	ctx := context.Background()
+
+	// A database behind the latest migration can't be baselined
+	err = manager.MigrateSteps(1)
+	require.NoError(t, err)
+	_, err = manager.Baseline(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "latest migration is 2")
+
+	// Nor can a dirty one
+	err = manager.MigrateUp()
+	require.NoError(t, err)
+	source.ExecuteSQL("UPDATE schema_migrations SET dirty = true")
+	_, err = manager.Baseline(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dirty")
+	source.ExecuteSQL("UPDATE schema_migrations SET dirty = false")
+
+	baseline, err := manager.Baseline(ctx)
+	require.NoError(t, err, "Failed to baseline an up to date database")
+	require.Equal(t, uint(2), baseline.Version)
+	require.Equal(t, "002_baseline.up.sql", baseline.UpFile)
+
+	// Only the baseline remains, with the squashed files archived
+	migrations, err := manager.ListMigrations()
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+	require.Equal(t, "baseline", migrations[0].Name)
+
+	archived, err := os.ReadDir(filepath.Join(migrationsPath, MigrationArchiveDir))
+	require.NoError(t, err)
+	require.Len(t, archived, 4)
+
+	// The baselined database validates and records the baseline as applied
+	baselined, err := NewMigrationManager(source.Config.ToConfig(), migrationsPath)
+	require.NoError(t, err)
+	defer baselined.Close()
+
+	err = baselined.ValidateMigrations()
+	require.NoError(t, err, "Validation should pass after baselining")
+
+	applied, err := baselined.GetAppliedMigrations(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	require.Equal(t, uint(2), applied[0].Version)
+	require.Equal(t, "baseline", applied[0].Name)
+
+	// Applied to an empty database, the baseline yields the same tables
+	empty := NewTestDatabase(t)
+	fresh, err := NewMigrationManager(empty.Config.ToConfig(), migrationsPath)
+	require.NoError(t, err)
+	defer fresh.Close()
+
+	err = fresh.MigrateUp()
+	require.NoError(t, err, "Failed to apply the baseline to an empty database")
+
+	version, dirty, err := fresh.GetCurrentVersion()
+	require.NoError(t, err)
+	require.Equal(t, uint(2), version)
+	require.False(t, dirty)
+
+	empty.AssertTableExists("baseline_test_a")
+	empty.AssertTableExists("baseline_test_b")
+	empty.AssertColumnExists("baseline_test_b", "a_id")
+	empty.AssertForeignKey("baseline_test_b", "a_id", "baseline_test_a")
+}
+
+// TestPlanMigration tests that planning reports pending files without migrating
+func TestPlanMigration(t *testing.T) {
+	// Skip if no test database is configured
+	if os.Getenv("TEST_DATABASE_URL") == "" {
+		t.Skip("TEST_DATABASE_URL not set, skipping migration plan tests")
+	}
+
+	cfg := &config.DatabaseConfig{
+		URL: os.Getenv("TEST_DATABASE_URL"),
+	}
+
+	// Create temporary migrations directory
+	tempDir := t.TempDir()
+	migrationsPath := filepath.Join(tempDir, "migrations")
+	err := os.MkdirAll(migrationsPath, 0755)
+	require.NoError(t, err)
+
+	files := map[string]string{
+		"001_create_plan_a.up.sql":   "CREATE TABLE plan_test_a (id SERIAL PRIMARY KEY);",
+		"001_create_plan_a.down.sql": "DROP TABLE IF EXISTS plan_test_a;",
+		"002_create_plan_b.up.sql":   "CREATE TABLE plan_test_b (id SERIAL PRIMARY KEY);",
+		"002_create_plan_b.down.sql": "DROP TABLE IF EXISTS plan_test_b;",
+		"003_create_plan_c.up.sql":   "CREATE TABLE plan_test_c (id SERIAL PRIMARY KEY);",
+		"003_create_plan_c.down.sql": "DROP TABLE IF EXISTS plan_test_c;",
+	}
+	for name, content := range files {
+		err = os.WriteFile(filepath.Join(migrationsPath, name), []byte(content), 0644)
+		require.NoError(t, err)
+	}
+
+	manager, err := NewMigrationManager(cfg, migrationsPath)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	// Ensure we start from a clean state
+	_ = manager.MigrateDown()
+	defer manager.MigrateDown()
+
+	err = manager.MigrateSteps(1)
+	require.NoError(t, err)
+
+	// Planning up matches the pending set
+	status, err := manager.GetStatus()
+	require.NoError(t, err)
+
+	plan, err := manager.PlanMigration(3)
+	require.NoError(t, err)
+	require.Len(t, plan, len(status.PendingMigrations))
+	for i, planned := range plan {
+		require.Equal(t, status.PendingMigrations[i].Version, planned.Version)
+		require.Equal(t, status.PendingMigrations[i].UpFile, planned.File)
+		require.Equal(t, MigrationDirectionUp, planned.Direction)
+		require.Equal(t, files[planned.File], planned.SQL)
+	}
+
+	// Planning down runs the applied migrations in reverse
+	plan, err = manager.PlanMigration(0)
+	require.NoError(t, err)
+	require.Len(t, plan, 1)
+	require.Equal(t, "001_create_plan_a.down.sql", plan[0].File)
+	require.Equal(t, MigrationDirectionDown, plan[0].Direction)
+
+	plan, err = manager.PlanSteps(2)
+	require.NoError(t, err)
+	require.Len(t, plan, 2)
+
+	// Planning does not change the version
+	version, dirty, err := manager.GetCurrentVersion()
+	require.NoError(t, err)
+	require.False(t, dirty)
+	require.Equal(t, uint(1), version)
+
+	_, err = manager.PlanMigration(7)
+	require.Error(t, err, "Should fail to plan to an unknown version")
+}
+
+// TestPlanSteps tests migration selection and ordering for a plan
+func TestPlanSteps(t *testing.T) {
+	migrations := []MigrationInfo{
+		{Version: 1, Name: "first"},
+		{Version: 2, Name: "second"},
+		{Version: 3, Name: "third"},
+	}
+
+	steps, direction, err := planSteps(migrations, 1, 3)
+	require.NoError(t, err)
+	require.Equal(t, MigrationDirectionUp, direction)
+	require.Len(t, steps, 2)
+	require.Equal(t, uint(2), steps[0].Version)
+	require.Equal(t, uint(3), steps[1].Version)
+
+	steps, direction, err = planSteps(migrations, 3, 1)
+	require.NoError(t, err)
+	require.Equal(t, MigrationDirectionDown, direction)
+	require.Len(t, steps, 2)
+	require.Equal(t, uint(3), steps[0].Version)
+	require.Equal(t, uint(2), steps[1].Version)
+
+	steps, _, err = planSteps(migrations, 2, 2)
+	require.NoError(t, err)
+	require.Empty(t, steps)
+
+	_, _, err = planSteps(migrations, 0, 4)
+	require.Error(t, err)
+
+	target, err := stepsTarget(migrations, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint(3), target)
+
+	target, err = stepsTarget(migrations, 2, -2)
+	require.NoError(t, err)
+	require.Equal(t, uint(0), target)
+
+	_, err = stepsTarget(migrations, 3, 1)
+	require.Error(t, err)
+}
+
 // TestMigrationStatus tests migration status functionality
 func TestMigrationStatus(t *testing.T) {
 	status := &MigrationStatus{
@@ -406,3 +797,81 @@ func BenchmarkMigrationListing(b *testing.B) {
 		require.NoError(b, err)
 	}
 }
+
+// TestMigrationSummaryErr tests when a migration summary reports the database as not ready
+func TestMigrationSummaryErr(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary MigrationSummary
+		wantErr string
+	}{
+		{
+			name:    "up to date",
+			summary: MigrationSummary{CurrentVersion: 3, LatestVersion: 3},
+		},
+		{
+			name:    "pending",
+			summary: MigrationSummary{CurrentVersion: 1, LatestVersion: 3, PendingCount: 2},
+			wantErr: "2 migrations pending: at version 1 of 3",
+		},
+		{
+			name:    "dirty",
+			summary: MigrationSummary{CurrentVersion: 2, LatestVersion: 3, Dirty: true, PendingCount: 1},
+			wantErr: "database is dirty at migration version 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.summary.Err()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+// TestGetStatusIsReadOnly tests that reading the status never writes the
+// migration history, which only migrating records
+func TestGetStatusIsReadOnly(t *testing.T) {
+	SkipIfNoDatabase(t)
+
+	testDB := NewTestDatabase(t)
+	cfg := testDB.Config.ToConfig()
+
+	migrationsPath := t.TempDir()
+	err := os.WriteFile(filepath.Join(migrationsPath, "001_create_status_items.up.sql"),
+		[]byte("CREATE TABLE status_test_items (id SERIAL PRIMARY KEY);"), 0644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(migrationsPath, "001_create_status_items.down.sql"),
+		[]byte("DROP TABLE IF EXISTS status_test_items;"), 0644)
+	require.NoError(t, err)
+
+	manager, err := NewMigrationManager(cfg, migrationsPath)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	// Migrate without the manager, so nothing records the history
+	require.NoError(t, manager.runner.Up())
+
+	status, err := manager.GetStatus()
+	require.NoError(t, err)
+	require.Equal(t, uint(1), status.CurrentVersion)
+	require.Len(t, status.AppliedMigrations, 1)
+	require.Nil(t, status.AppliedMigrations[0].AppliedAt)
+
+	applied, err := manager.GetAppliedMigrations(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, applied)
+	testDB.AssertTableNotExists(migrationHistoryTable)
+
+	// Migrating through the manager records migrations applied by other means
+	require.NoError(t, manager.MigrateUp())
+
+	applied, err = manager.GetAppliedMigrations(context.Background())
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	require.Equal(t, "create_status_items", applied[0].Name)
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	appErrors "go-templ-template/internal/shared/errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Queryer runs queries; *DB and *sqlx.Tx satisfy it
+type Queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error)
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// TimedDB times the queries it runs and logs those taking at least the slow
+// query threshold, with the label the caller gave the query and how long it
+// took. Queries run in the transaction stored in the context, if any, and
+// fail with an error wrapping the context's error once it is done. Neither
+// the SQL nor its arguments are logged, as they may hold personal data.
+type TimedDB struct {
+	db        Queryer
+	logger    appErrors.Logger
+	threshold time.Duration
+	now       func() time.Time
+}
+
+// NewTimedDB creates a query timer around db that logs queries taking at
+// least threshold to logger. A nil logger or a threshold of zero disables
+// slow query logging.
+func NewTimedDB(db Queryer, logger appErrors.Logger, threshold time.Duration) *TimedDB {
+	return &TimedDB{
+		db:        db,
+		logger:    logger,
+		threshold: threshold,
+		now:       time.Now,
+	}
+}
+
+// ExecContext executes a query without returning rows
+func (t *TimedDB) ExecContext(ctx context.Context, label, query string, args ...interface{}) (sql.Result, error) {
+	defer t.observe(label, t.now())
+	result, err := t.queryer(ctx).ExecContext(ctx, query, args...)
+	return result, contextError(ctx, err)
+}
+
+// NamedExecContext executes a query with named parameters bound from arg
+func (t *TimedDB) NamedExecContext(ctx context.Context, label, query string, arg interface{}) (sql.Result, error) {
+	defer t.observe(label, t.now())
+	result, err := t.queryer(ctx).NamedExecContext(ctx, query, arg)
+	return result, contextError(ctx, err)
+}
+
+// QueryxContext runs a query returning rows. Only the time until the first
+// rows are available is measured.
+func (t *TimedDB) QueryxContext(ctx context.Context, label, query string, args ...interface{}) (*sqlx.Rows, error) {
+	defer t.observe(label, t.now())
+	rows, err := t.queryer(ctx).QueryxContext(ctx, query, args...)
+	return rows, contextError(ctx, err)
+}
+
+// GetContext runs a query and scans its single row into dest
+func (t *TimedDB) GetContext(ctx context.Context, label string, dest interface{}, query string, args ...interface{}) error {
+	defer t.observe(label, t.now())
+	return contextError(ctx, t.queryer(ctx).GetContext(ctx, dest, query, args...))
+}
+
+// SelectContext runs a query and scans its rows into dest
+func (t *TimedDB) SelectContext(ctx context.Context, label string, dest interface{}, query string, args ...interface{}) error {
+	defer t.observe(label, t.now())
+	return contextError(ctx, t.queryer(ctx).SelectContext(ctx, dest, query, args...))
+}
+
+// queryer returns the transaction stored in ctx, or the wrapped database
+func (t *TimedDB) queryer(ctx context.Context) Queryer {
+	if tx := GetTxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return t.db
+}
+
+// contextError makes err, from a query run with ctx, wrap the context's
+// error if ctx is done. The driver reports a query cancelled midway as a
+// PostgreSQL error rather than the context's, so without this callers
+// couldn't tell a cancelled query from a failed one.
+func contextError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil || errors.Is(err, ctx.Err()) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ctx.Err(), err)
+}
+
+// observe logs the query labelled label if it has taken at least the
+// threshold since start
+func (t *TimedDB) observe(label string, start time.Time) {
+	if t.logger == nil || t.threshold <= 0 {
+		return
+	}
+
+	duration := t.now().Sub(start)
+	if duration < t.threshold {
+		return
+	}
+
+	t.logger.WithFields(map[string]interface{}{
+		"query":        label,
+		"duration_ms":  duration.Milliseconds(),
+		"threshold_ms": t.threshold.Milliseconds(),
+	}).Warn("Slow database query")
+}
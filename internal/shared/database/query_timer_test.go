@@ -0,0 +1,208 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	appErrors "go-templ-template/internal/shared/errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a clock that only moves when advanced
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+// fakeQueryer is a database whose queries take latency on the fake clock
+// and fail with err
+type fakeQueryer struct {
+	clock   *fakeClock
+	latency time.Duration
+	err     error
+	queries []string
+}
+
+func (q *fakeQueryer) run(query string) error {
+	q.queries = append(q.queries, query)
+	q.clock.now = q.clock.now.Add(q.latency)
+	return q.err
+}
+
+func (q *fakeQueryer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, q.run(query)
+}
+
+func (q *fakeQueryer) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	return nil, q.run(query)
+}
+
+func (q *fakeQueryer) QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error) {
+	return nil, q.run(query)
+}
+
+func (q *fakeQueryer) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return q.run(query)
+}
+
+func (q *fakeQueryer) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return q.run(query)
+}
+
+// logEntry is a message logged to a recordingLogger
+type logEntry struct {
+	level   string
+	message string
+	fields  map[string]interface{}
+}
+
+// recordingLogger records the messages logged to it
+type recordingLogger struct {
+	fields  map[string]interface{}
+	entries *[]logEntry
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{fields: map[string]interface{}{}, entries: &[]logEntry{}}
+}
+
+func (l *recordingLogger) WithFields(fields map[string]interface{}) appErrors.Logger {
+	merged := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &recordingLogger{fields: merged, entries: l.entries}
+}
+
+func (l *recordingLogger) WithError(err error) appErrors.Logger {
+	return l.WithFields(map[string]interface{}{"error": err.Error()})
+}
+
+func (l *recordingLogger) log(level, msg string) {
+	*l.entries = append(*l.entries, logEntry{level: level, message: msg, fields: l.fields})
+}
+
+func (l *recordingLogger) Error(msg string) { l.log("error", msg) }
+func (l *recordingLogger) Warn(msg string)  { l.log("warn", msg) }
+func (l *recordingLogger) Info(msg string)  { l.log("info", msg) }
+func (l *recordingLogger) Debug(msg string) { l.log("debug", msg) }
+
+// newTestTimedDB creates a timed fake database with a 100ms threshold whose
+// queries take latency
+func newTestTimedDB(latency time.Duration) (*TimedDB, *fakeQueryer, *recordingLogger) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	db := &fakeQueryer{clock: clock, latency: latency}
+	logger := newRecordingLogger()
+
+	timed := NewTimedDB(db, logger, 100*time.Millisecond)
+	timed.now = clock.Now
+	return timed, db, logger
+}
+
+func TestTimedDB_LogsSlowQueries(t *testing.T) {
+	timed, db, logger := newTestTimedDB(250 * time.Millisecond)
+	ctx := context.Background()
+
+	var email string
+	err := timed.GetContext(ctx, "users.GetByEmail", &email, "SELECT email FROM users WHERE email = $1", "secret@example.com")
+	require.NoError(t, err)
+	require.Len(t, db.queries, 1, "The query should run on the wrapped database")
+
+	require.Len(t, *logger.entries, 1)
+	entry := (*logger.entries)[0]
+	assert.Equal(t, "warn", entry.level)
+	assert.Equal(t, "Slow database query", entry.message)
+	assert.Equal(t, "users.GetByEmail", entry.fields["query"])
+	assert.Equal(t, int64(250), entry.fields["duration_ms"])
+	assert.Equal(t, int64(100), entry.fields["threshold_ms"])
+}
+
+func TestTimedDB_SkipsFastQueries(t *testing.T) {
+	timed, db, logger := newTestTimedDB(99 * time.Millisecond)
+	ctx := context.Background()
+
+	_, err := timed.ExecContext(ctx, "users.Delete", "DELETE FROM users WHERE id = $1", "user-1")
+	require.NoError(t, err)
+	var users []string
+	require.NoError(t, timed.SelectContext(ctx, "users.List", &users, "SELECT email FROM users"))
+
+	assert.Len(t, db.queries, 2)
+	assert.Empty(t, *logger.entries, "Queries under the threshold should not be logged")
+}
+
+func TestTimedDB_NeverLogsArguments(t *testing.T) {
+	timed, _, logger := newTestTimedDB(time.Second)
+	ctx := context.Background()
+
+	args := []interface{}{"secret@example.com", "hunter2-password-hash"}
+	_, err := timed.ExecContext(ctx, "users.Update", "UPDATE users SET password_hash = $2 WHERE email = $1", args...)
+	require.NoError(t, err)
+	_, err = timed.NamedExecContext(ctx, "users.Create", "INSERT INTO users (email) VALUES (:email)",
+		map[string]interface{}{"email": "secret@example.com"})
+	require.NoError(t, err)
+
+	require.Len(t, *logger.entries, 2)
+	for _, entry := range *logger.entries {
+		logged := fmt.Sprintf("%s %v", entry.message, entry.fields)
+		assert.NotContains(t, logged, "secret@example.com")
+		assert.NotContains(t, logged, "hunter2-password-hash")
+		assert.NotContains(t, logged, "UPDATE users", "The SQL should not be logged")
+	}
+}
+
+func TestTimedDB_Disabled(t *testing.T) {
+	timed, _, logger := newTestTimedDB(time.Second)
+	timed.threshold = 0
+
+	_, err := timed.ExecContext(context.Background(), "users.Delete", "DELETE FROM users")
+	require.NoError(t, err)
+	assert.Empty(t, *logger.entries, "A zero threshold should disable slow query logging")
+
+	// Without a logger queries still run
+	noLogger := NewTimedDB(&fakeQueryer{clock: &fakeClock{}}, nil, time.Millisecond)
+	_, err = noLogger.ExecContext(context.Background(), "users.Delete", "DELETE FROM users")
+	require.NoError(t, err)
+}
+
+func TestTimedDB_ContextErrors(t *testing.T) {
+	timed, db, _ := newTestTimedDB(time.Millisecond)
+
+	// PostgreSQL reports a query cancelled midway as its own error
+	db.err = &pq.Error{Code: "57014", Message: "canceling statement due to user request"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := timed.ExecContext(ctx, "users.Delete", "DELETE FROM users")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	var pqErr *pq.Error
+	assert.ErrorAs(t, err, &pqErr, "The driver error should still be available")
+
+	var count int
+	err = timed.GetContext(ctx, "users.Count", &count, "SELECT COUNT(*) FROM users")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Errors that are already the context's are not wrapped again
+	db.err = context.Canceled
+	err = timed.SelectContext(ctx, "users.List", &[]string{}, "SELECT email FROM users")
+	assert.Equal(t, context.Canceled, err)
+
+	// Errors from queries whose context is not done are left alone
+	db.err = sql.ErrNoRows
+	err = timed.GetContext(context.Background(), "users.GetByID", &count, "SELECT 1")
+	assert.Equal(t, sql.ErrNoRows, err)
+}
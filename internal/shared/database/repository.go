@@ -48,13 +48,7 @@ func NewBaseRepository[T any, ID comparable](db *DB, tableName, idColumn string)
 
 // Create inserts a new entity using the provided query
 func (r *BaseRepository[T, ID]) Create(ctx context.Context, entity *T, query string, args ...interface{}) error {
-	tx := GetTxFromContext(ctx)
-	if tx != nil {
-		_, err := tx.NamedExecContext(ctx, query, entity)
-		return err
-	}
-
-	_, err := r.db.NamedExecContext(ctx, query, entity)
+	_, err := r.db.Timed().NamedExecContext(ctx, r.queryLabel("Create"), query, entity)
 	return err
 }
 
@@ -62,19 +56,7 @@ func (r *BaseRepository[T, ID]) Create(ctx context.Context, entity *T, query str
 func (r *BaseRepository[T, ID]) GetByID(ctx context.Context, id ID, query string) (*T, error) {
 	var entity T
 
-	tx := GetTxFromContext(ctx)
-	if tx != nil {
-		err := tx.GetContext(ctx, &entity, query, id)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				return nil, ErrNotFound
-			}
-			return nil, err
-		}
-		return &entity, nil
-	}
-
-	err := r.db.GetContext(ctx, &entity, query, id)
+	err := r.db.Timed().GetContext(ctx, r.queryLabel("GetByID"), &entity, query, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrNotFound
@@ -86,16 +68,7 @@ func (r *BaseRepository[T, ID]) GetByID(ctx context.Context, id ID, query string
 
 // Update updates an entity using the provided query
 func (r *BaseRepository[T, ID]) Update(ctx context.Context, entity *T, query string, args ...interface{}) error {
-	tx := GetTxFromContext(ctx)
-	if tx != nil {
-		result, err := tx.NamedExecContext(ctx, query, entity)
-		if err != nil {
-			return err
-		}
-		return r.checkRowsAffected(result)
-	}
-
-	result, err := r.db.NamedExecContext(ctx, query, entity)
+	result, err := r.db.Timed().NamedExecContext(ctx, r.queryLabel("Update"), query, entity)
 	if err != nil {
 		return err
 	}
@@ -104,16 +77,7 @@ func (r *BaseRepository[T, ID]) Update(ctx context.Context, entity *T, query str
 
 // Delete removes an entity by ID using the provided query
 func (r *BaseRepository[T, ID]) Delete(ctx context.Context, id ID, query string) error {
-	tx := GetTxFromContext(ctx)
-	if tx != nil {
-		result, err := tx.ExecContext(ctx, query, id)
-		if err != nil {
-			return err
-		}
-		return r.checkRowsAffected(result)
-	}
-
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.Timed().ExecContext(ctx, r.queryLabel("Delete"), query, id)
 	if err != nil {
 		return err
 	}
@@ -123,42 +87,21 @@ func (r *BaseRepository[T, ID]) Delete(ctx context.Context, id ID, query string)
 // List retrieves entities with pagination using the provided query
 func (r *BaseRepository[T, ID]) List(ctx context.Context, query string, limit, offset int) ([]*T, error) {
 	var entities []*T
-
-	tx := GetTxFromContext(ctx)
-	if tx != nil {
-		err := tx.SelectContext(ctx, &entities, query, limit, offset)
-		return entities, err
-	}
-
-	err := r.db.SelectContext(ctx, &entities, query, limit, offset)
+	err := r.db.Timed().SelectContext(ctx, r.queryLabel("List"), &entities, query, limit, offset)
 	return entities, err
 }
 
 // Count returns the total number of entities using the provided query
 func (r *BaseRepository[T, ID]) Count(ctx context.Context, query string) (int64, error) {
 	var count int64
-
-	tx := GetTxFromContext(ctx)
-	if tx != nil {
-		err := tx.GetContext(ctx, &count, query)
-		return count, err
-	}
-
-	err := r.db.GetContext(ctx, &count, query)
+	err := r.db.Timed().GetContext(ctx, r.queryLabel("Count"), &count, query)
 	return count, err
 }
 
 // Exists checks if an entity exists by ID using the provided query
 func (r *BaseRepository[T, ID]) Exists(ctx context.Context, id ID, query string) (bool, error) {
 	var exists bool
-
-	tx := GetTxFromContext(ctx)
-	if tx != nil {
-		err := tx.GetContext(ctx, &exists, query, id)
-		return exists, err
-	}
-
-	err := r.db.GetContext(ctx, &exists, query, id)
+	err := r.db.Timed().GetContext(ctx, r.queryLabel("Exists"), &exists, query, id)
 	return exists, err
 }
 
@@ -182,6 +125,11 @@ func (r *BaseRepository[T, ID]) GetIDColumn() string {
 	return r.idColumn
 }
 
+// queryLabel labels an operation's queries for slow query logging
+func (r *BaseRepository[T, ID]) queryLabel(operation string) string {
+	return r.tableName + "." + operation
+}
+
 // checkRowsAffected checks if any rows were affected by the operation
 func (r *BaseRepository[T, ID]) checkRowsAffected(result sql.Result) error {
 	rowsAffected, err := result.RowsAffected()
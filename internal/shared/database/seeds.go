@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-templ-template/internal/config"
+)
+
+// seedHistoryTable tracks which seed files have run. It is separate from
+// the schema migration tables, so running seeds never changes the schema
+// version.
+const seedHistoryTable = "schema_seeds"
+
+// SeedFile is a SQL file in a seed set
+type SeedFile struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// AppliedSeed records when a seed file last ran
+type AppliedSeed struct {
+	Set       string    `json:"set"`
+	File      string    `json:"file"`
+	Checksum  string    `json:"checksum"`
+	RunCount  int       `json:"run_count"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// SeedRunner runs seed data. Seeds live in sets, each a subdirectory of the
+// seeds directory holding SQL files run in file name order. Every run runs
+// all of a set's files, so they must be idempotent, e.g. inserting with
+// ON CONFLICT DO NOTHING.
+type SeedRunner struct {
+	seedsPath string
+	db        *sql.DB
+}
+
+// NewSeedRunner creates a new seed runner
+func NewSeedRunner(cfg *config.DatabaseConfig, seedsPath string) (*SeedRunner, error) {
+	var dsn string
+
+	// Use DATABASE_URL if provided, otherwise build from individual components
+	if cfg.URL != "" {
+		dsn = cfg.URL
+	} else {
+		dsn = fmt.Sprintf(
+			"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+			cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode,
+		)
+	}
+
+	// Open database connection for seeds
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database for seeds: %w", err)
+	}
+
+	// Test the connection
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &SeedRunner{
+		seedsPath: seedsPath,
+		db:        db,
+	}, nil
+}
+
+// List returns the files of a seed set in the order they run
+func (sr *SeedRunner) List(set string) ([]SeedFile, error) {
+	return listSeedFiles(sr.seedsPath, set)
+}
+
+// Run runs every file of a seed set in order, in one transaction, and
+// records each run. It returns the files that ran.
+func (sr *SeedRunner) Run(ctx context.Context, set string) ([]SeedFile, error) {
+	files, err := sr.List(set)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sr.ensureHistoryTable(ctx); err != nil {
+		return nil, err
+	}
+
+	tx, err := sr.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin seed transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	recordQuery := fmt.Sprintf(`
+		INSERT INTO %s (set_name, file, checksum, run_count, applied_at)
+		VALUES ($1, $2, $3, 1, NOW())
+		ON CONFLICT (set_name, file) DO UPDATE
+		SET checksum = EXCLUDED.checksum, run_count = %s.run_count + 1, applied_at = NOW()`,
+		seedHistoryTable, seedHistoryTable)
+
+	for _, file := range files {
+		content, err := os.ReadFile(file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read seed file %s: %w", file.Name, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+			return nil, fmt.Errorf("failed to run seed file %s: %w", file.Name, err)
+		}
+
+		sum := sha256.Sum256(content)
+		if _, err := tx.ExecContext(ctx, recordQuery, set, file.Name, hex.EncodeToString(sum[:])); err != nil {
+			return nil, fmt.Errorf("failed to record seed file %s: %w", file.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit seed set %s: %w", set, err)
+	}
+
+	return files, nil
+}
+
+// Applied returns the files of a seed set that have run, in file name order
+func (sr *SeedRunner) Applied(ctx context.Context, set string) ([]AppliedSeed, error) {
+	if err := sr.ensureHistoryTable(ctx); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(
+		"SELECT set_name, file, checksum, run_count, applied_at FROM %s WHERE set_name = $1 ORDER BY file",
+		seedHistoryTable,
+	)
+	rows, err := sr.db.QueryContext(ctx, query, set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query seed history: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make([]AppliedSeed, 0)
+	for rows.Next() {
+		var seed AppliedSeed
+		if err := rows.Scan(&seed.Set, &seed.File, &seed.Checksum, &seed.RunCount, &seed.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan seed history: %w", err)
+		}
+		applied = append(applied, seed)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read seed history: %w", err)
+	}
+
+	return applied, nil
+}
+
+// Close closes the database connection
+func (sr *SeedRunner) Close() error {
+	return sr.db.Close()
+}
+
+// ensureHistoryTable creates the seed history table if it doesn't exist
+func (sr *SeedRunner) ensureHistoryTable(ctx context.Context) error {
+	query := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			set_name VARCHAR(255) NOT NULL,
+			file VARCHAR(255) NOT NULL,
+			checksum VARCHAR(64) NOT NULL,
+			run_count INTEGER NOT NULL DEFAULT 0,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (set_name, file)
+		)`, seedHistoryTable)
+
+	if _, err := sr.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create seed history table: %w", err)
+	}
+	return nil
+}
+
+// listSeedFiles returns the SQL files of a seed set sorted by name
+func listSeedFiles(seedsPath, set string) ([]SeedFile, error) {
+	if set == "" || set != filepath.Base(set) || strings.HasPrefix(set, ".") {
+		return nil, fmt.Errorf("invalid seed set name: %q", set)
+	}
+
+	// ReadDir returns entries sorted by file name
+	dir := filepath.Join(seedsPath, set)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed set %s: %w", set, err)
+	}
+
+	files := make([]SeedFile, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		files = append(files, SeedFile{Name: entry.Name(), Path: filepath.Join(dir, entry.Name())})
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("seed set %s has no SQL files", set)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeSeedTestFiles writes the files of a seed set
+func writeSeedTestFiles(t *testing.T, seedsPath, set string, files map[string]string) {
+	t.Helper()
+
+	dir := filepath.Join(seedsPath, set)
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+}
+
+// TestListSeedFiles tests that seed files are listed in the order they run
+func TestListSeedFiles(t *testing.T) {
+	seedsPath := t.TempDir()
+	writeSeedTestFiles(t, seedsPath, "dev", map[string]string{
+		"002_orders.sql": "SELECT 1;",
+		"001_users.sql":  "SELECT 1;",
+		"README.md":      "Not a seed",
+	})
+	require.NoError(t, os.MkdirAll(filepath.Join(seedsPath, "dev", "nested"), 0755))
+
+	files, err := listSeedFiles(seedsPath, "dev")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, "001_users.sql", files[0].Name)
+	require.Equal(t, "002_orders.sql", files[1].Name)
+	require.Equal(t, filepath.Join(seedsPath, "dev", "001_users.sql"), files[0].Path)
+
+	_, err = listSeedFiles(seedsPath, "missing")
+	require.Error(t, err, "Listing a missing seed set should fail")
+
+	for _, set := range []string{"", "..", "../dev", "dev/nested"} {
+		_, err = listSeedFiles(seedsPath, set)
+		require.Error(t, err, "Seed set %q should be invalid", set)
+	}
+
+	writeSeedTestFiles(t, seedsPath, "empty", map[string]string{"notes.txt": "No SQL"})
+	_, err = listSeedFiles(seedsPath, "empty")
+	require.Error(t, err, "A seed set without SQL files should fail")
+}
+
+// TestSeedRunner tests running seeds idempotently, independently of migrations
+func TestSeedRunner(t *testing.T) {
+	SkipIfNoDatabase(t)
+
+	testDB := NewTestDatabase(t)
+	cfg := testDB.Config.ToConfig()
+
+	migrationsPath := t.TempDir()
+	err := os.WriteFile(filepath.Join(migrationsPath, "001_create_seed_items.up.sql"),
+		[]byte("CREATE TABLE seed_test_items (id INTEGER PRIMARY KEY, name TEXT NOT NULL);"), 0644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(migrationsPath, "001_create_seed_items.down.sql"),
+		[]byte("DROP TABLE IF EXISTS seed_test_items;"), 0644)
+	require.NoError(t, err)
+
+	manager, err := NewMigrationManager(cfg, migrationsPath)
+	require.NoError(t, err)
+	defer manager.Close()
+	require.NoError(t, manager.MigrateUp())
+
+	seedsPath := t.TempDir()
+	writeSeedTestFiles(t, seedsPath, "test", map[string]string{
+		"001_items.sql": "INSERT INTO seed_test_items (id, name) VALUES (1, 'one'), (2, 'two') ON CONFLICT (id) DO NOTHING;",
+		"002_more.sql":  "INSERT INTO seed_test_items (id, name) VALUES (3, 'three') ON CONFLICT (id) DO NOTHING;",
+	})
+
+	runner, err := NewSeedRunner(cfg, seedsPath)
+	require.NoError(t, err, "Failed to create seed runner")
+	defer runner.Close()
+
+	ctx := context.Background()
+
+	// Running a seed set inserts its rows
+	files, err := runner.Run(ctx, "test")
+	require.NoError(t, err, "Failed to run seeds")
+	require.Len(t, files, 2)
+	testDB.AssertRowCount("seed_test_items", 3)
+
+	// Running it again is idempotent
+	_, err = runner.Run(ctx, "test")
+	require.NoError(t, err, "Failed to re-run seeds")
+	testDB.AssertRowCount("seed_test_items", 3)
+
+	applied, err := runner.Applied(ctx, "test")
+	require.NoError(t, err)
+	require.Len(t, applied, 2)
+	require.Equal(t, "001_items.sql", applied[0].File)
+	require.Equal(t, "002_more.sql", applied[1].File)
+	for _, seed := range applied {
+		require.Equal(t, "test", seed.Set)
+		require.Equal(t, 2, seed.RunCount)
+		require.Len(t, seed.Checksum, 64)
+	}
+
+	// Seeds are tracked apart from the schema version and migration history
+	version, dirty, err := manager.GetCurrentVersion()
+	require.NoError(t, err)
+	require.Equal(t, uint(1), version)
+	require.False(t, dirty)
+
+	migrations, err := manager.GetAppliedMigrations(ctx)
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+	require.Equal(t, "create_seed_items", migrations[0].Name)
+
+	other, err := runner.Applied(ctx, "other")
+	require.NoError(t, err)
+	require.Empty(t, other)
+
+	// A failing seed set rolls back entirely
+	writeSeedTestFiles(t, seedsPath, "broken", map[string]string{
+		"001_items.sql": "INSERT INTO seed_test_items (id, name) VALUES (4, 'four');",
+		"002_fail.sql":  "INSERT INTO seed_test_items (id, name) VALUES (5, NULL);",
+	})
+	_, err = runner.Run(ctx, "broken")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "002_fail.sql")
+	testDB.AssertRowCount("seed_test_items", 3)
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+
+	"go-templ-template/internal/config"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// StubDB is a DB whose connection accepts every statement without running
+// it, so code that opens transactions can be tested without PostgreSQL.
+// Statements succeed affecting one row, queries return no rows, and each
+// executed statement, commit and rollback is recorded.
+type StubDB struct {
+	*DB
+	mu         sync.Mutex
+	statements []string
+}
+
+// NewStubDB creates a DB backed by a stub connection
+func NewStubDB() *StubDB {
+	stub := &StubDB{}
+	sqlxDB := sqlx.NewDb(sql.OpenDB(stubConnector{stub: stub}), "postgres")
+	stub.DB = &DB{
+		DB:      sqlxDB,
+		config:  &config.DatabaseConfig{},
+		options: DefaultConnectionOptions(),
+	}
+	return stub
+}
+
+// Statements returns the statements executed so far, with "COMMIT" and
+// "ROLLBACK" marking where transactions ended
+func (s *StubDB) Statements() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.statements...)
+}
+
+func (s *StubDB) record(statement string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statements = append(s.statements, statement)
+}
+
+// stubConnector opens stub connections recording to a StubDB
+type stubConnector struct {
+	stub *StubDB
+}
+
+func (c stubConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &stubConn{stub: c.stub}, nil
+}
+
+func (c stubConnector) Driver() driver.Driver {
+	return stubDriver{}
+}
+
+// stubDriver only exists to satisfy driver.Connector
+type stubDriver struct{}
+
+func (stubDriver) Open(name string) (driver.Conn, error) {
+	return &stubConn{stub: &StubDB{}}, nil
+}
+
+// stubConn is a connection that records statements instead of running them
+type stubConn struct {
+	stub *StubDB
+}
+
+func (c *stubConn) Prepare(query string) (driver.Stmt, error) {
+	return &stubStmt{conn: c, query: query}, nil
+}
+
+func (c *stubConn) Close() error {
+	return nil
+}
+
+func (c *stubConn) Begin() (driver.Tx, error) {
+	return &stubTx{stub: c.stub}, nil
+}
+
+func (c *stubConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.stub.record(query)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *stubConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.stub.record(query)
+	return stubRows{}, nil
+}
+
+// stubStmt is a prepared statement on a stubConn
+type stubStmt struct {
+	conn  *stubConn
+	query string
+}
+
+func (s *stubStmt) Close() error {
+	return nil
+}
+
+func (s *stubStmt) NumInput() int {
+	return -1
+}
+
+func (s *stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.stub.record(s.query)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.conn.stub.record(s.query)
+	return stubRows{}, nil
+}
+
+// stubTx is a transaction on a stubConn
+type stubTx struct {
+	stub *StubDB
+}
+
+func (t *stubTx) Commit() error {
+	t.stub.record("COMMIT")
+	return nil
+}
+
+func (t *stubTx) Rollback() error {
+	t.stub.record("ROLLBACK")
+	return nil
+}
+
+// stubRows is an empty result set
+type stubRows struct{}
+
+func (stubRows) Columns() []string {
+	return nil
+}
+
+func (stubRows) Close() error {
+	return nil
+}
+
+func (stubRows) Next(dest []driver.Value) error {
+	return io.EOF
+}
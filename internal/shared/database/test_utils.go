@@ -4,12 +4,17 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"go-templ-template/internal/config"
 
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,6 +27,10 @@ type TestDatabaseConfig struct {
 	Name           string
 	SSLMode        string
 	ConnectTimeout time.Duration
+
+	// Schema, when set, is put first on the search_path of every connection,
+	// so unqualified tables are created and found there before public
+	Schema string
 }
 
 // DefaultTestDatabaseConfig returns default test database configuration
@@ -43,9 +52,11 @@ func DefaultTestDatabaseConfig() *TestDatabaseConfig {
 	}
 }
 
-// ToConfig converts TestDatabaseConfig to config.DatabaseConfig
+// ToConfig converts TestDatabaseConfig to config.DatabaseConfig. With a
+// Schema the connection string sets the search_path, so connections opened
+// from the config, including the migration runner's, use the schema.
 func (cfg *TestDatabaseConfig) ToConfig() *config.DatabaseConfig {
-	return &config.DatabaseConfig{
+	dbConfig := &config.DatabaseConfig{
 		Host:     cfg.Host,
 		Port:     cfg.Port,
 		User:     cfg.User,
@@ -53,31 +64,70 @@ func (cfg *TestDatabaseConfig) ToConfig() *config.DatabaseConfig {
 		Name:     cfg.Name,
 		SSLMode:  cfg.SSLMode,
 	}
+
+	if cfg.Schema != "" {
+		dbConfig.URL = fmt.Sprintf(
+			"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s search_path=%s,public",
+			cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode, cfg.Schema,
+		)
+	}
+
+	return dbConfig
+}
+
+// testSchemaInvalidChars matches characters not allowed in test schema names
+var testSchemaInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// testSchemaName returns a schema name unique to the test, readable enough
+// to tell which test left it behind
+func testSchemaName(t *testing.T) string {
+	name := testSchemaInvalidChars.ReplaceAllString(strings.ToLower(t.Name()), "_")
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	suffix := strings.ReplaceAll(uuid.New().String(), "-", "")[:12]
+	return fmt.Sprintf("test_%s_%s", name, suffix)
 }
 
 // TestDatabase represents a test database instance
 type TestDatabase struct {
 	DB     *DB
 	Config *TestDatabaseConfig
-	t      *testing.T
+	t      require.TestingT
+	closed bool
 }
 
-// NewTestDatabase creates a new test database instance
+// NewTestDatabase creates a new test database instance working in a schema
+// of its own, so tests using it can run with t.Parallel(). The schema is
+// dropped when the database is closed or the test finishes.
 func NewTestDatabase(t *testing.T) *TestDatabase {
 	cfg := DefaultTestDatabaseConfig()
+	cfg.Schema = testSchemaName(t)
 	return NewTestDatabaseWithConfig(t, cfg)
 }
 
-// NewTestDatabaseWithConfig creates a new test database instance with custom config
+// NewTestDatabaseWithConfig creates a new test database instance with custom
+// config, creating cfg.Schema if set
 func NewTestDatabaseWithConfig(t *testing.T, cfg *TestDatabaseConfig) *TestDatabase {
 	db, err := NewConnectionWithTimeout(cfg.ToConfig(), DefaultConnectionOptions(), cfg.ConnectTimeout)
 	require.NoError(t, err, "Failed to connect to test database")
 
-	return &TestDatabase{
+	tdb := &TestDatabase{
 		DB:     db,
 		Config: cfg,
 		t:      t,
 	}
+
+	if cfg.Schema != "" {
+		_, err := db.Exec(fmt.Sprintf("CREATE SCHEMA %s", pq.QuoteIdentifier(cfg.Schema)))
+		if err != nil {
+			db.Close()
+		}
+		require.NoError(t, err, "Failed to create test schema")
+		t.Cleanup(tdb.Close)
+	}
+
+	return tdb
 }
 
 // CreateTable creates a table using the provided SQL
@@ -112,12 +162,21 @@ func (tdb *TestDatabase) SeedData(sql string, args ...interface{}) {
 	require.NoError(tdb.t, err, "Failed to seed test data")
 }
 
-// Close closes the test database connection
+// Close drops the test schema, if any, and closes the test database
+// connection. Closing more than once is a no-op.
 func (tdb *TestDatabase) Close() {
-	if tdb.DB != nil {
-		err := tdb.DB.Close()
-		require.NoError(tdb.t, err, "Failed to close test database")
+	if tdb.DB == nil || tdb.closed {
+		return
+	}
+	tdb.closed = true
+
+	if tdb.Config != nil && tdb.Config.Schema != "" {
+		_, err := tdb.DB.Exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", pq.QuoteIdentifier(tdb.Config.Schema)))
+		require.NoError(tdb.t, err, "Failed to drop test schema")
 	}
+
+	err := tdb.DB.Close()
+	require.NoError(tdb.t, err, "Failed to close test database")
 }
 
 // Cleanup performs cleanup operations (truncate tables, etc.)
@@ -133,13 +192,30 @@ func (tdb *TestDatabase) WithTransaction(fn func(ctx context.Context) error) err
 	return ExecuteInTransaction(ctx, tdb.DB, fn)
 }
 
+// RunInRollback runs fn with a context carrying a transaction that is rolled
+// back once fn returns, isolating tests without truncating tables. Only
+// writes made through the context's transaction, as repositories using
+// GetTxFromContext or GetExecutor do, are rolled back. ExecuteInTransaction
+// calls inside fn join the transaction, so tests of their own rollback
+// behaviour still need a real transaction.
+func (tdb *TestDatabase) RunInRollback(fn func(ctx context.Context)) {
+	tx, err := tdb.DB.BeginTxx(context.Background(), nil)
+	require.NoError(tdb.t, err, "Failed to begin test transaction")
+
+	defer func() {
+		require.NoError(tdb.t, tx.Rollback(), "Failed to roll back test transaction")
+	}()
+
+	fn(WithTransaction(context.Background(), tx))
+}
+
 // AssertTableExists asserts that a table exists
 func (tdb *TestDatabase) AssertTableExists(tableName string) {
 	var exists bool
 	query := `
 		SELECT EXISTS (
 			SELECT FROM information_schema.tables 
-			WHERE table_schema = 'public' 
+			WHERE table_schema = current_schema()
 			AND table_name = $1
 		)`
 
@@ -154,7 +230,7 @@ func (tdb *TestDatabase) AssertTableNotExists(tableName string) {
 	query := `
 		SELECT EXISTS (
 			SELECT FROM information_schema.tables 
-			WHERE table_schema = 'public' 
+			WHERE table_schema = current_schema()
 			AND table_name = $1
 		)`
 
@@ -173,6 +249,75 @@ func (tdb *TestDatabase) AssertRowCount(tableName string, expectedCount int) {
 	require.Equal(tdb.t, expectedCount, count, "Row count mismatch for table %s", tableName)
 }
 
+// AssertRowExists asserts that a row in the table has the given column
+// values; a nil value matches NULL
+func (tdb *TestDatabase) AssertRowExists(tableName string, where map[string]any) {
+	columns := make([]string, 0, len(where))
+	for column := range where {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	conditions := []string{"TRUE"}
+	args := make([]interface{}, 0, len(where))
+	for _, column := range columns {
+		if where[column] == nil {
+			conditions = append(conditions, fmt.Sprintf("%s IS NULL", pq.QuoteIdentifier(column)))
+			continue
+		}
+		args = append(args, where[column])
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", pq.QuoteIdentifier(column), len(args)))
+	}
+
+	var exists bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE %s)", tableName, strings.Join(conditions, " AND "))
+
+	err := tdb.DB.Get(&exists, query, args...)
+	require.NoError(tdb.t, err)
+	require.True(tdb.t, exists, "Table %s should have a row matching %v", tableName, where)
+}
+
+// AssertColumnExists asserts that a table has a column
+func (tdb *TestDatabase) AssertColumnExists(tableName, columnName string) {
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT FROM information_schema.columns
+			WHERE table_schema = current_schema()
+			AND table_name = $1
+			AND column_name = $2
+		)`
+
+	err := tdb.DB.Get(&exists, query, tableName, columnName)
+	require.NoError(tdb.t, err)
+	require.True(tdb.t, exists, "Column %s.%s should exist", tableName, columnName)
+}
+
+// AssertForeignKey asserts that a column of a table has a foreign key
+// constraint referencing refTableName
+func (tdb *TestDatabase) AssertForeignKey(tableName, columnName, refTableName string) {
+	var exists bool
+	query := `
+		SELECT EXISTS (
+			SELECT FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+				ON kcu.constraint_schema = tc.constraint_schema
+				AND kcu.constraint_name = tc.constraint_name
+			JOIN information_schema.constraint_column_usage ccu
+				ON ccu.constraint_schema = tc.constraint_schema
+				AND ccu.constraint_name = tc.constraint_name
+			WHERE tc.constraint_type = 'FOREIGN KEY'
+			AND tc.table_schema = current_schema()
+			AND tc.table_name = $1
+			AND kcu.column_name = $2
+			AND ccu.table_name = $3
+		)`
+
+	err := tdb.DB.Get(&exists, query, tableName, columnName, refTableName)
+	require.NoError(tdb.t, err)
+	require.True(tdb.t, exists, "Column %s.%s should have a foreign key referencing %s", tableName, columnName, refTableName)
+}
+
 // WaitForConnection waits for the database to be available
 func (tdb *TestDatabase) WaitForConnection(timeout time.Duration) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
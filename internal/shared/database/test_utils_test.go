@@ -0,0 +1,123 @@
+package database
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingT records assertion failures instead of failing the test. FailNow
+// exits the goroutine like testing.T does, so run assertions with failed.
+type recordingT struct {
+	messages []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.messages = append(r.messages, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) FailNow() {
+	runtime.Goexit()
+}
+
+// failed runs assertion against testDB with a recordingT, returning the
+// recorded failure messages
+func failed(testDB *TestDatabase, assertion func(tdb *TestDatabase)) []string {
+	recorder := &recordingT{}
+	tdb := &TestDatabase{DB: testDB.DB, Config: testDB.Config, t: recorder}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assertion(tdb)
+	}()
+	<-done
+
+	return recorder.messages
+}
+
+// newSeededTestDatabase creates a test database with an authors table and a
+// books table referencing it
+func newSeededTestDatabase(t *testing.T) *TestDatabase {
+	SkipIfNoDatabase(t)
+
+	testDB := NewTestDatabase(t)
+	testDB.CreateTable(`
+		CREATE TABLE authors (
+			id SERIAL PRIMARY KEY,
+			name VARCHAR(255) NOT NULL
+		)`)
+	testDB.CreateTable(`
+		CREATE TABLE books (
+			id SERIAL PRIMARY KEY,
+			author_id INTEGER NOT NULL REFERENCES authors(id),
+			title VARCHAR(255) NOT NULL,
+			subtitle VARCHAR(255)
+		)`)
+	testDB.SeedData(`INSERT INTO authors (name) VALUES ('Ursula'), ('Iain')`)
+	testDB.SeedData(`INSERT INTO books (author_id, title, subtitle) VALUES (1, 'The Dispossessed', NULL), (2, 'Excession', 'A Culture Novel')`)
+
+	return testDB
+}
+
+func TestTestDatabase_AssertRowExists(t *testing.T) {
+	testDB := newSeededTestDatabase(t)
+
+	testDB.AssertRowExists("authors", map[string]any{"name": "Ursula"})
+	testDB.AssertRowExists("books", map[string]any{"author_id": 2, "title": "Excession"})
+	testDB.AssertRowExists("books", map[string]any{"title": "The Dispossessed", "subtitle": nil})
+	testDB.AssertRowExists("books", map[string]any{})
+
+	messages := failed(testDB, func(tdb *TestDatabase) {
+		tdb.AssertRowExists("books", map[string]any{"author_id": 1, "title": "Excession"})
+	})
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "Table books should have a row matching map[author_id:1 title:Excession]")
+
+	messages = failed(testDB, func(tdb *TestDatabase) {
+		tdb.AssertRowExists("books", map[string]any{"missing": 1})
+	})
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], `column "missing" does not exist`)
+}
+
+func TestTestDatabase_AssertColumnExists(t *testing.T) {
+	testDB := newSeededTestDatabase(t)
+
+	testDB.AssertColumnExists("books", "subtitle")
+
+	messages := failed(testDB, func(tdb *TestDatabase) {
+		tdb.AssertColumnExists("books", "isbn")
+	})
+	require.Len(t, messages, 1)
+	assert.Contains(t, messages[0], "Column books.isbn should exist")
+}
+
+func TestTestDatabase_AssertForeignKey(t *testing.T) {
+	testDB := newSeededTestDatabase(t)
+
+	testDB.AssertForeignKey("books", "author_id", "authors")
+
+	tests := []struct {
+		name     string
+		column   string
+		refTable string
+		message  string
+	}{
+		{"column without foreign key", "title", "authors", "Column books.title should have a foreign key referencing authors"},
+		{"other referenced table", "author_id", "books", "Column books.author_id should have a foreign key referencing books"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := failed(testDB, func(tdb *TestDatabase) {
+				tdb.AssertForeignKey("books", tt.column, tt.refTable)
+			})
+			require.Len(t, messages, 1)
+			assert.Contains(t, messages[0], tt.message)
+		})
+	}
+}
@@ -98,3 +98,86 @@ func TestNewTestSuiteWithTimeout(t *testing.T) {
 	// Setup should work with the configured timeout
 	suite.Setup()
 }
+
+func TestTestDatabase_RunInRollback(t *testing.T) {
+	// Skip if no database available
+	SkipIfNoDatabase(t)
+
+	testDB := NewTestDatabase(t)
+	defer testDB.Close()
+
+	testDB.CreateTable(`CREATE TABLE IF NOT EXISTS rollback_entities (id SERIAL PRIMARY KEY, name VARCHAR(255) NOT NULL)`)
+	defer testDB.DropTable("rollback_entities")
+
+	// Data written in one rollback is visible inside it...
+	testDB.RunInRollback(func(ctx context.Context) {
+		_, err := GetExecutor(ctx, testDB.DB).ExecContext(ctx, `INSERT INTO rollback_entities (name) VALUES ('first')`)
+		require.NoError(t, err)
+
+		var count int
+		require.NoError(t, GetExecutor(ctx, testDB.DB).GetContext(ctx, &count, `SELECT COUNT(*) FROM rollback_entities`))
+		assert.Equal(t, 1, count)
+	})
+
+	// ...but not to the next one, nor outside
+	testDB.RunInRollback(func(ctx context.Context) {
+		var count int
+		require.NoError(t, GetExecutor(ctx, testDB.DB).GetContext(ctx, &count, `SELECT COUNT(*) FROM rollback_entities`))
+		assert.Equal(t, 0, count)
+	})
+	testDB.AssertRowCount("rollback_entities", 0)
+}
+
+func TestNewTestDatabase_SchemaPerTest(t *testing.T) {
+	// Skip if no database available
+	SkipIfNoDatabase(t)
+
+	schemas := make(chan string, 2)
+	t.Run("group", func(t *testing.T) {
+		for _, name := range []string{"first", "second"} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				testDB := NewTestDatabase(t)
+				schemas <- testDB.Config.Schema
+
+				// Both tests create the same table, each in its own schema
+				testDB.CreateTable(`CREATE TABLE isolation_entities (id SERIAL PRIMARY KEY, name VARCHAR(255) NOT NULL)`)
+				testDB.AssertTableExists("isolation_entities")
+				testDB.SeedData(`INSERT INTO isolation_entities (name) VALUES ($1)`, name)
+
+				var names []string
+				require.NoError(t, testDB.DB.Select(&names, `SELECT name FROM isolation_entities`))
+				assert.Equal(t, []string{name}, names)
+			})
+		}
+	})
+	close(schemas)
+
+	// The schemas were dropped when the tests finished
+	checkDB := NewTestDatabaseWithConfig(t, DefaultTestDatabaseConfig())
+	defer checkDB.Close()
+	for schema := range schemas {
+		var exists bool
+		err := checkDB.DB.Get(&exists, `SELECT EXISTS (SELECT FROM information_schema.schemata WHERE schema_name = $1)`, schema)
+		require.NoError(t, err)
+		assert.False(t, exists, "Schema %s should have been dropped", schema)
+	}
+}
+
+func TestTestDatabaseConfig_ToConfigWithSchema(t *testing.T) {
+	cfg := DefaultTestDatabaseConfig()
+	assert.Empty(t, cfg.ToConfig().URL)
+
+	cfg.Schema = "test_schema"
+	assert.Contains(t, cfg.ToConfig().URL, "search_path=test_schema,public")
+}
+
+func TestTestSchemaName(t *testing.T) {
+	first := testSchemaName(t)
+	second := testSchemaName(t)
+
+	assert.NotEqual(t, first, second)
+	assert.Regexp(t, `^test_testtestschemaname_[0-9a-f]{12}$`, first)
+	assert.LessOrEqual(t, len(first), 63, "Postgres truncates identifiers past 63 bytes")
+}
@@ -0,0 +1,17 @@
+package database
+
+import (
+	"context"
+
+	"go-templ-template/internal/shared/tracing"
+)
+
+// StartQuerySpan starts the span covering a repository operation on table.
+// Queries run with the returned context join the caller's trace.
+func StartQuerySpan(ctx context.Context, table, operation string) (context.Context, tracing.Span) {
+	ctx, span := tracing.Start(ctx, "db "+table+"."+operation)
+	span.SetAttribute("db.system", "postgresql")
+	span.SetAttribute("db.sql.table", table)
+	span.SetAttribute("db.operation", operation)
+	return ctx, span
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"go-templ-template/internal/shared/tracing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartQuerySpan(t *testing.T) {
+	recorder := tracing.NewRecorder()
+	tracing.SetTracer(recorder)
+	t.Cleanup(func() { tracing.SetTracer(nil) })
+
+	ctx, parent := tracing.Start(context.Background(), "request")
+	_, span := StartQuerySpan(ctx, "users", "GetByID")
+	span.End()
+	parent.End()
+
+	query := recorder.Find("db users.GetByID")
+	require.NotNil(t, query)
+	assert.True(t, query.IsChildOf(recorder.Find("request")))
+	assert.Equal(t, "postgresql", query.Attributes["db.system"])
+	assert.Equal(t, "users", query.Attributes["db.sql.table"])
+	assert.Equal(t, "GetByID", query.Attributes["db.operation"])
+}
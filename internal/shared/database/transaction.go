@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -42,8 +43,10 @@ func ExecuteInTransaction(ctx context.Context, db *DB, fn func(ctx context.Conte
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	// Add transaction to context
+	// Add transaction and its after-commit hooks to context
+	hooks := &afterCommitHooks{}
 	txCtx := context.WithValue(ctx, TxKey{}, tx)
+	txCtx = context.WithValue(txCtx, afterCommitKey{}, hooks)
 
 	// Set up defer for rollback in case of panic
 	defer func() {
@@ -70,6 +73,7 @@ func ExecuteInTransaction(ctx context.Context, db *DB, fn func(ctx context.Conte
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	hooks.run()
 	return nil
 }
 
@@ -87,8 +91,10 @@ func ExecuteInTransactionWithOptions(ctx context.Context, db *DB, opts *sql.TxOp
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	// Add transaction to context
+	// Add transaction and its after-commit hooks to context
+	hooks := &afterCommitHooks{}
 	txCtx := context.WithValue(ctx, TxKey{}, tx)
+	txCtx = context.WithValue(txCtx, afterCommitKey{}, hooks)
 
 	// Set up defer for rollback in case of panic
 	defer func() {
@@ -115,9 +121,48 @@ func ExecuteInTransactionWithOptions(ctx context.Context, db *DB, opts *sql.TxOp
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	hooks.run()
 	return nil
 }
 
+// afterCommitKey is the context key for callbacks deferred until commit
+type afterCommitKey struct{}
+
+// afterCommitHooks collects callbacks registered during a transaction
+type afterCommitHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+func (h *afterCommitHooks) add(fn func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fns = append(h.fns, fn)
+}
+
+func (h *afterCommitHooks) run() {
+	h.mu.Lock()
+	fns := h.fns
+	h.fns = nil
+	h.mu.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// AfterCommit registers fn to run once the transaction started by
+// ExecuteInTransaction commits. Callbacks registered in nested calls wait for
+// the outermost commit and are discarded on rollback. Outside such a
+// transaction fn runs immediately.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks); ok && hooks != nil {
+		hooks.add(fn)
+		return
+	}
+	fn()
+}
+
 // GetTxFromContext retrieves the transaction from the context
 func GetTxFromContext(ctx context.Context) *sqlx.Tx {
 	if tx, ok := ctx.Value(TxKey{}).(*sqlx.Tx); ok {
@@ -126,11 +171,36 @@ func GetTxFromContext(ctx context.Context) *sqlx.Tx {
 	return nil
 }
 
+// Executor is the query API shared by *sqlx.Tx and *DB
+type Executor interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+}
+
+// GetExecutor returns the transaction from the context if there is one,
+// otherwise db, so repositories join any surrounding ExecuteInTransaction
+func GetExecutor(ctx context.Context, db *DB) Executor {
+	if tx := GetTxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return db
+}
+
 // WithTransaction adds a transaction to the context
 func WithTransaction(ctx context.Context, tx *sqlx.Tx) context.Context {
 	return context.WithValue(ctx, TxKey{}, tx)
 }
 
+// WithoutTransaction returns a copy of ctx without its transaction, for work
+// that must not join it, such as publishing once it has committed. Queries
+// and AfterCommit callbacks using the copy run outside any transaction.
+func WithoutTransaction(ctx context.Context) context.Context {
+	ctx = context.WithValue(ctx, TxKey{}, (*sqlx.Tx)(nil))
+	return context.WithValue(ctx, afterCommitKey{}, (*afterCommitHooks)(nil))
+}
+
 // TransactionOptions provides common transaction option presets
 type TransactionOptions struct{}
 
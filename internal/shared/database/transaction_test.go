@@ -343,3 +343,74 @@ func TestWithTransaction(t *testing.T) {
 	retrievedTx := GetTxFromContext(txCtx)
 	assert.Equal(t, tx, retrievedTx)
 }
+
+func TestAfterCommit_WithoutTransaction(t *testing.T) {
+	called := false
+	AfterCommit(context.Background(), func() { called = true })
+
+	assert.True(t, called, "callback should run immediately outside a transaction")
+}
+
+func TestAfterCommit_RunsAfterCommit(t *testing.T) {
+	SkipIfNoDatabase(t)
+	db, cleanup := setupTestDatabase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	called := false
+
+	err := ExecuteInTransaction(ctx, db, func(txCtx context.Context) error {
+		AfterCommit(txCtx, func() { called = true })
+
+		// Nested calls join the outer transaction and must not flush hooks
+		err := ExecuteInTransaction(txCtx, db, func(nestedCtx context.Context) error {
+			return nil
+		})
+		assert.False(t, called, "callback should not run before commit")
+		return err
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, called, "callback should run after commit")
+}
+
+func TestAfterCommit_DiscardedOnRollback(t *testing.T) {
+	SkipIfNoDatabase(t)
+	db, cleanup := setupTestDatabase(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	called := false
+	rollbackErr := errors.New("rollback")
+
+	err := ExecuteInTransaction(ctx, db, func(txCtx context.Context) error {
+		AfterCommit(txCtx, func() { called = true })
+		return rollbackErr
+	})
+
+	assert.ErrorIs(t, err, rollbackErr)
+	assert.False(t, called, "callback should not run after rollback")
+}
+
+func TestWithoutTransaction(t *testing.T) {
+	db := NewStubDB()
+	ctx := context.Background()
+	called := false
+
+	err := ExecuteInTransaction(ctx, db.DB, func(txCtx context.Context) error {
+		detached := WithoutTransaction(txCtx)
+		assert.Nil(t, GetTxFromContext(detached))
+
+		AfterCommit(detached, func() { called = true })
+		assert.True(t, called, "callback should run immediately outside the transaction")
+
+		// Transactions started from it are independent of the outer one
+		return ExecuteInTransaction(detached, db.DB, func(innerCtx context.Context) error {
+			assert.NotEqual(t, GetTxFromContext(txCtx), GetTxFromContext(innerCtx))
+			return nil
+		})
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"COMMIT", "COMMIT"}, db.Statements())
+}
@@ -0,0 +1,135 @@
+// Package email provides the port modules use to send email, with
+// implementations that log messages in development and deliver them over SMTP.
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"go-templ-template/internal/config"
+)
+
+// ErrInvalidMessage indicates a message is missing recipients or content
+var ErrInvalidMessage = errors.New("invalid email message")
+
+// Message is an email to send. At least one of TextBody and HTMLBody must be
+// set; when both are, clients pick the format they prefer.
+type Message struct {
+	To       []string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+// Validate checks that the message can be sent
+func (m Message) Validate() error {
+	if len(m.To) == 0 {
+		return fmt.Errorf("%w: no recipients", ErrInvalidMessage)
+	}
+	for _, to := range m.To {
+		// Reject header injection through recipient addresses
+		if strings.TrimSpace(to) == "" || strings.ContainsAny(to, "\r\n") {
+			return fmt.Errorf("%w: invalid recipient %q", ErrInvalidMessage, to)
+		}
+	}
+	if strings.ContainsAny(m.Subject, "\r\n") {
+		return fmt.Errorf("%w: subject contains a line break", ErrInvalidMessage)
+	}
+	if m.TextBody == "" && m.HTMLBody == "" {
+		return fmt.Errorf("%w: no body", ErrInvalidMessage)
+	}
+	return nil
+}
+
+// EmailSender sends email messages
+type EmailSender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewSender creates the email sender selected by the EMAIL_DRIVER setting
+func NewSender(cfg config.EmailConfig, logger *slog.Logger) (EmailSender, error) {
+	switch cfg.Driver {
+	case "", "log":
+		return NewLogSender(logger), nil
+	case "smtp":
+		return NewSMTPSender(SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUser,
+			Password: cfg.SMTPPassword,
+			From:     cfg.From,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown email driver: %s", cfg.Driver)
+	}
+}
+
+// LogSender writes messages to a logger instead of sending them. It is the
+// default in development, where no mail server is available.
+type LogSender struct {
+	logger *slog.Logger
+}
+
+// NewLogSender creates a log sender, using slog's default logger if logger is nil
+func NewLogSender(logger *slog.Logger) *LogSender {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &LogSender{logger: logger}
+}
+
+// Send logs msg
+func (s *LogSender) Send(ctx context.Context, msg Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "Email not sent (log driver)",
+		"to", msg.To,
+		"subject", msg.Subject,
+		"text_body", msg.TextBody,
+	)
+	return nil
+}
+
+// RecordingSender keeps sent messages in memory, for tests
+type RecordingSender struct {
+	mu       sync.Mutex
+	messages []Message
+
+	// Err, when set, is returned by Send instead of recording the message
+	Err error
+}
+
+// NewRecordingSender creates a recording sender
+func NewRecordingSender() *RecordingSender {
+	return &RecordingSender{}
+}
+
+// Send records msg, or returns Err if it is set
+func (s *RecordingSender) Send(ctx context.Context, msg Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Err != nil {
+		return s.Err
+	}
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far
+func (s *RecordingSender) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.messages...)
+}
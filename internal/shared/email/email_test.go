@@ -0,0 +1,131 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_Validate(t *testing.T) {
+	valid := Message{To: []string{"user@example.com"}, Subject: "Hello", TextBody: "Hi"}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name string
+		msg  Message
+	}{
+		{name: "no recipients", msg: Message{Subject: "Hello", TextBody: "Hi"}},
+		{name: "blank recipient", msg: Message{To: []string{" "}, TextBody: "Hi"}},
+		{name: "header injection in recipient", msg: Message{To: []string{"user@example.com\r\nBcc: x@example.com"}, TextBody: "Hi"}},
+		{name: "header injection in subject", msg: Message{To: []string{"user@example.com"}, Subject: "Hi\r\nBcc: x@example.com", TextBody: "Hi"}},
+		{name: "no body", msg: Message{To: []string{"user@example.com"}, Subject: "Hello"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.msg.Validate(), ErrInvalidMessage)
+		})
+	}
+}
+
+func TestNewSender(t *testing.T) {
+	sender, err := NewSender(config.EmailConfig{Driver: "log"}, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &LogSender{}, sender)
+
+	sender, err = NewSender(config.EmailConfig{Driver: "smtp", SMTPHost: "smtp.example.com", SMTPPort: "587"}, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &SMTPSender{}, sender)
+
+	_, err = NewSender(config.EmailConfig{Driver: "carrier-pigeon"}, nil)
+	assert.Error(t, err)
+}
+
+func TestLogSender_Send(t *testing.T) {
+	var buf bytes.Buffer
+	sender := NewLogSender(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	err := sender.Send(context.Background(), Message{To: []string{"user@example.com"}, Subject: "Hello", TextBody: "Hi"})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "user@example.com")
+	assert.Contains(t, buf.String(), "Hello")
+
+	err = sender.Send(context.Background(), Message{})
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+}
+
+func TestRecordingSender(t *testing.T) {
+	sender := NewRecordingSender()
+	msg := Message{To: []string{"user@example.com"}, Subject: "Hello", TextBody: "Hi"}
+
+	require.NoError(t, sender.Send(context.Background(), msg))
+	assert.Equal(t, []Message{msg}, sender.Messages())
+
+	sender.Err = errors.New("smtp unavailable")
+	assert.Error(t, sender.Send(context.Background(), msg))
+	assert.Len(t, sender.Messages(), 1)
+}
+
+func TestBuildMessage_SingleBody(t *testing.T) {
+	msg := Message{To: []string{"a@example.com", "b@example.com"}, Subject: "Héllo", TextBody: "Hi there"}
+
+	raw, err := buildMessage("no-reply@example.com", msg, time.Now())
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+	assert.Equal(t, "no-reply@example.com", parsed.Header.Get("From"))
+	assert.Equal(t, "a@example.com, b@example.com", parsed.Header.Get("To"))
+	assert.Equal(t, "text/plain; charset=utf-8", parsed.Header.Get("Content-Type"))
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Héllo", subject)
+
+	body, err := io.ReadAll(parsed.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "Hi there", string(body))
+}
+
+func TestBuildMessage_Alternative(t *testing.T) {
+	msg := Message{To: []string{"a@example.com"}, Subject: "Hello", TextBody: "Hi there", HTMLBody: "<p>Hi there</p>"}
+
+	raw, err := buildMessage("no-reply@example.com", msg, time.Now())
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(raw))
+	require.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var types, bodies []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		types = append(types, strings.Split(part.Header.Get("Content-Type"), ";")[0])
+		bodies = append(bodies, string(body))
+	}
+
+	assert.Equal(t, []string{"text/plain", "text/html"}, types)
+	assert.Equal(t, []string{"Hi there", "<p>Hi there</p>"}, bodies)
+}
@@ -8,9 +8,10 @@ import (
 	"github.com/google/uuid"
 )
 
-// NewAppError creates a new AppError with the given parameters
+// NewAppError creates a new AppError with the given parameters. Codes
+// registered in the default catalog get its user message.
 func NewAppError(errorType ErrorType, code, message string, httpStatus int) *AppError {
-	return &AppError{
+	return applyCatalog(&AppError{
 		ID:         generateErrorID(),
 		Code:       code,
 		Type:       errorType,
@@ -19,12 +20,12 @@ func NewAppError(errorType ErrorType, code, message string, httpStatus int) *App
 		Timestamp:  time.Now().UTC(),
 		HTTPStatus: httpStatus,
 		Retryable:  isRetryableByType(errorType),
-	}
+	})
 }
 
 // NewAppErrorWithCause creates a new AppError with an underlying cause
 func NewAppErrorWithCause(errorType ErrorType, code, message string, httpStatus int, cause error) *AppError {
-	return &AppError{
+	return applyCatalog(&AppError{
 		ID:         generateErrorID(),
 		Code:       code,
 		Type:       errorType,
@@ -34,7 +35,7 @@ func NewAppErrorWithCause(errorType ErrorType, code, message string, httpStatus
 		HTTPStatus: httpStatus,
 		Cause:      cause,
 		Retryable:  isRetryableByType(errorType),
-	}
+	})
 }
 
 // Validation error builders
@@ -46,6 +47,15 @@ func NewValidationErrorWithDetails(code, message string, details map[string]inte
 	return NewAppError(ErrorTypeValidation, code, message, http.StatusBadRequest).WithDetails(details)
 }
 
+func NewPayloadTooLargeError(limit int64) *AppError {
+	message := fmt.Sprintf("Request body exceeds the limit of %d bytes", limit)
+	return NewAppError(ErrorTypeValidation, "PAYLOAD_TOO_LARGE", message, http.StatusRequestEntityTooLarge).
+		WithDetails(map[string]interface{}{
+			"limit_bytes": limit,
+		}).
+		WithUserMessage("The request is too large.")
+}
+
 // Authentication error builders
 func NewAuthenticationError(code, message string) *AppError {
 	return NewAppError(ErrorTypeAuthentication, code, message, http.StatusUnauthorized)
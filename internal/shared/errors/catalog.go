@@ -0,0 +1,179 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// ErrorCodeInfo describes a registered error code
+type ErrorCodeInfo struct {
+	// Code is the machine-readable error code
+	Code string `json:"code"`
+
+	// Type is the error category for the code
+	Type ErrorType `json:"type"`
+
+	// HTTPStatus is the default HTTP status for the code
+	HTTPStatus int `json:"http_status"`
+
+	// Retryable indicates if operations failing with the code can be retried
+	Retryable bool `json:"retryable"`
+
+	// UserMessage is the default user-facing message for the code
+	UserMessage string `json:"user_message,omitempty"`
+}
+
+// ErrorCatalog is a registry of known error codes and their metadata
+type ErrorCatalog struct {
+	codes map[string]ErrorCodeInfo
+	mu    sync.RWMutex
+}
+
+// NewErrorCatalog creates an empty error catalog
+func NewErrorCatalog() *ErrorCatalog {
+	return &ErrorCatalog{
+		codes: make(map[string]ErrorCodeInfo),
+	}
+}
+
+// Register adds an error code to the catalog. Each code may only be
+// registered once. A zero HTTPStatus defaults to the status for the type.
+func (c *ErrorCatalog) Register(info ErrorCodeInfo) error {
+	if info.Code == "" {
+		return fmt.Errorf("error code is required")
+	}
+	if info.Type == "" {
+		return fmt.Errorf("error type is required for code %s", info.Code)
+	}
+	if info.HTTPStatus == 0 {
+		info.HTTPStatus = getHTTPStatusForType(info.Type)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.codes[info.Code]; exists {
+		return fmt.Errorf("error code %s is already registered", info.Code)
+	}
+
+	c.codes[info.Code] = info
+	return nil
+}
+
+// Lookup returns the metadata registered for code
+func (c *ErrorCatalog) Lookup(code string) (ErrorCodeInfo, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	info, exists := c.codes[code]
+	return info, exists
+}
+
+// Dump returns every registered code sorted by code, for generating
+// documentation and tests
+func (c *ErrorCatalog) Dump() []ErrorCodeInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	infos := make([]ErrorCodeInfo, 0, len(c.codes))
+	for _, info := range c.codes {
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Code < infos[j].Code
+	})
+
+	return infos
+}
+
+// DefaultErrorCatalog holds the codes used by the error builders and any
+// registered by application modules
+var DefaultErrorCatalog = newDefaultErrorCatalog()
+
+// RegisterErrorCode registers a code in the default catalog
+func RegisterErrorCode(info ErrorCodeInfo) error {
+	return DefaultErrorCatalog.Register(info)
+}
+
+// LookupErrorCode returns the metadata for a code in the default catalog
+func LookupErrorCode(code string) (ErrorCodeInfo, bool) {
+	return DefaultErrorCatalog.Lookup(code)
+}
+
+// NewErrorFromCode creates an AppError whose type, HTTP status, retryability
+// and user message come from the default catalog. Unregistered codes produce
+// an internal error.
+func NewErrorFromCode(code, message string) *AppError {
+	info, exists := LookupErrorCode(code)
+	if !exists {
+		return NewInternalError(code, message).
+			WithDetails(map[string]interface{}{
+				"unregistered_code": true,
+			})
+	}
+
+	appErr := NewAppError(info.Type, code, message, info.HTTPStatus)
+	appErr.Retryable = info.Retryable
+	return appErr
+}
+
+// applyCatalog fills in the user message of err from the default catalog
+// when its code is registered and no message has been set
+func applyCatalog(err *AppError) *AppError {
+	if err.UserMessage != "" {
+		return err
+	}
+
+	if info, exists := LookupErrorCode(err.Code); exists {
+		err.UserMessage = info.UserMessage
+	}
+	return err
+}
+
+// builtinErrorCodes are the codes produced by the helpers in builders.go
+var builtinErrorCodes = []ErrorCodeInfo{
+	{Code: "PAYLOAD_TOO_LARGE", Type: ErrorTypeValidation, HTTPStatus: http.StatusRequestEntityTooLarge,
+		UserMessage: "The request is too large."},
+	{Code: "INVALID_CREDENTIALS", Type: ErrorTypeAuthentication, HTTPStatus: http.StatusUnauthorized,
+		UserMessage: "The username or password you entered is incorrect. Please try again."},
+	{Code: "SESSION_EXPIRED", Type: ErrorTypeAuthentication, HTTPStatus: http.StatusUnauthorized,
+		UserMessage: "Your session has expired. Please log in again."},
+	{Code: "SESSION_INVALID", Type: ErrorTypeAuthentication, HTTPStatus: http.StatusUnauthorized,
+		UserMessage: "Your session is invalid. Please log in again."},
+	{Code: "INSUFFICIENT_PERMISSIONS", Type: ErrorTypeAuthorization, HTTPStatus: http.StatusForbidden,
+		UserMessage: "You don't have permission to perform this action."},
+	{Code: "ACCOUNT_SUSPENDED", Type: ErrorTypeAuthorization, HTTPStatus: http.StatusForbidden,
+		UserMessage: "Your account has been suspended. Please contact support for assistance."},
+	{Code: "RESOURCE_NOT_FOUND", Type: ErrorTypeNotFound, HTTPStatus: http.StatusNotFound,
+		UserMessage: "The requested resource could not be found."},
+	{Code: "DUPLICATE_RESOURCE", Type: ErrorTypeConflict, HTTPStatus: http.StatusConflict,
+		UserMessage: "This resource already exists."},
+	{Code: "OPTIMISTIC_LOCK_CONFLICT", Type: ErrorTypeConflict, HTTPStatus: http.StatusConflict,
+		UserMessage: "The resource has been modified by another user. Please refresh and try again."},
+	{Code: "RATE_LIMIT_EXCEEDED", Type: ErrorTypeRateLimit, HTTPStatus: http.StatusTooManyRequests, Retryable: true,
+		UserMessage: "You have made too many requests. Please wait a moment and try again."},
+	{Code: "DATABASE_ERROR", Type: ErrorTypeInternal, HTTPStatus: http.StatusInternalServerError,
+		UserMessage: "An internal error occurred. Please try again later."},
+	{Code: "EVENT_BUS_ERROR", Type: ErrorTypeInternal, HTTPStatus: http.StatusInternalServerError,
+		UserMessage: "An internal error occurred. Please try again later."},
+	{Code: "EXTERNAL_SERVICE_ERROR", Type: ErrorTypeExternal, HTTPStatus: http.StatusBadGateway, Retryable: true,
+		UserMessage: "An external service is currently unavailable. Please try again later."},
+	{Code: "OPERATION_TIMEOUT", Type: ErrorTypeTimeout, HTTPStatus: http.StatusRequestTimeout, Retryable: true,
+		UserMessage: "The operation took too long to complete. Please try again."},
+	{Code: "SERVICE_UNAVAILABLE", Type: ErrorTypeUnavailable, HTTPStatus: http.StatusServiceUnavailable, Retryable: true,
+		UserMessage: "The service is currently unavailable. Please try again later."},
+}
+
+// newDefaultErrorCatalog creates a catalog holding the builtin codes
+func newDefaultErrorCatalog() *ErrorCatalog {
+	catalog := NewErrorCatalog()
+	for _, info := range builtinErrorCodes {
+		if err := catalog.Register(info); err != nil {
+			panic(err)
+		}
+	}
+	return catalog
+}
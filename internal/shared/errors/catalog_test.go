@@ -0,0 +1,135 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTestCatalog swaps in a fresh default catalog for the duration of a test
+func useTestCatalog(t *testing.T) {
+	t.Helper()
+
+	original := DefaultErrorCatalog
+	DefaultErrorCatalog = newDefaultErrorCatalog()
+	t.Cleanup(func() { DefaultErrorCatalog = original })
+}
+
+func TestErrorCatalog_Register(t *testing.T) {
+	catalog := NewErrorCatalog()
+
+	err := catalog.Register(ErrorCodeInfo{
+		Code:        "PAYMENT_DECLINED",
+		Type:        ErrorTypeValidation,
+		UserMessage: "Your payment was declined.",
+	})
+	require.NoError(t, err)
+
+	info, exists := catalog.Lookup("PAYMENT_DECLINED")
+	require.True(t, exists)
+	assert.Equal(t, ErrorTypeValidation, info.Type)
+	assert.Equal(t, http.StatusBadRequest, info.HTTPStatus, "status should default from type")
+	assert.Equal(t, "Your payment was declined.", info.UserMessage)
+
+	_, exists = catalog.Lookup("UNKNOWN_CODE")
+	assert.False(t, exists)
+}
+
+func TestErrorCatalog_RegisterInvalid(t *testing.T) {
+	catalog := NewErrorCatalog()
+
+	assert.Error(t, catalog.Register(ErrorCodeInfo{Type: ErrorTypeInternal}))
+	assert.Error(t, catalog.Register(ErrorCodeInfo{Code: "NO_TYPE"}))
+
+	require.NoError(t, catalog.Register(ErrorCodeInfo{Code: "ONCE", Type: ErrorTypeInternal}))
+	assert.Error(t, catalog.Register(ErrorCodeInfo{Code: "ONCE", Type: ErrorTypeConflict}))
+}
+
+func TestErrorCatalog_Dump(t *testing.T) {
+	catalog := NewErrorCatalog()
+	require.NoError(t, catalog.Register(ErrorCodeInfo{Code: "B_CODE", Type: ErrorTypeConflict}))
+	require.NoError(t, catalog.Register(ErrorCodeInfo{Code: "A_CODE", Type: ErrorTypeNotFound}))
+
+	dump := catalog.Dump()
+	require.Len(t, dump, 2)
+	assert.Equal(t, "A_CODE", dump[0].Code)
+	assert.Equal(t, "B_CODE", dump[1].Code)
+
+	codes := make([]string, 0)
+	for _, info := range DefaultErrorCatalog.Dump() {
+		codes = append(codes, info.Code)
+	}
+	assert.True(t, sort.StringsAreSorted(codes))
+	assert.Len(t, codes, len(builtinErrorCodes))
+}
+
+func TestNewErrorFromCode(t *testing.T) {
+	useTestCatalog(t)
+
+	require.NoError(t, RegisterErrorCode(ErrorCodeInfo{
+		Code:        "QUOTA_EXHAUSTED",
+		Type:        ErrorTypeRateLimit,
+		HTTPStatus:  http.StatusPaymentRequired,
+		Retryable:   false,
+		UserMessage: "You have used up your quota.",
+	}))
+
+	appErr := NewErrorFromCode("QUOTA_EXHAUSTED", "Monthly quota exhausted")
+	info, exists := LookupErrorCode(appErr.Code)
+	require.True(t, exists)
+
+	assert.Equal(t, info.Type, appErr.Type)
+	assert.Equal(t, info.HTTPStatus, appErr.HTTPStatus)
+	assert.Equal(t, info.Retryable, appErr.Retryable)
+	assert.Equal(t, info.UserMessage, appErr.UserMessage)
+	assert.Equal(t, "Monthly quota exhausted", appErr.Message)
+
+	// Builders pick up the catalog user message for registered codes
+	builtErr := NewAppError(ErrorTypeRateLimit, "QUOTA_EXHAUSTED", "Quota exhausted", http.StatusPaymentRequired)
+	assert.Equal(t, info.UserMessage, builtErr.UserMessage)
+}
+
+func TestNewErrorFromCode_Unregistered(t *testing.T) {
+	useTestCatalog(t)
+
+	appErr := NewErrorFromCode("NOT_IN_CATALOG", "Something failed")
+	assert.Equal(t, ErrorTypeInternal, appErr.Type)
+	assert.Equal(t, http.StatusInternalServerError, appErr.HTTPStatus)
+	assert.Equal(t, true, appErr.Details["unregistered_code"])
+}
+
+func TestBuiltinErrorCodes_MatchBuilders(t *testing.T) {
+	cause := errors.New("cause")
+	built := []*AppError{
+		NewPayloadTooLargeError(1024),
+		NewInvalidCredentialsError(),
+		NewSessionExpiredError(),
+		NewSessionInvalidError(),
+		NewInsufficientPermissionsError(),
+		NewAccountSuspendedError(),
+		NewUserNotFoundError("user-1"),
+		NewEmailAlreadyExistsError("user@example.com"),
+		NewOptimisticLockError("user"),
+		NewRateLimitError(10, "minute"),
+		NewDatabaseError("insert", cause),
+		NewEventBusError("publish", cause),
+		NewExternalServiceError("payments", "charge", cause),
+		NewTimeoutError("export", time.Second),
+		NewServiceUnavailableError("search"),
+	}
+
+	for _, appErr := range built {
+		t.Run(appErr.Code, func(t *testing.T) {
+			info, exists := LookupErrorCode(appErr.Code)
+			require.True(t, exists, "builder code should be registered")
+			assert.Equal(t, info.Type, appErr.Type)
+			assert.Equal(t, info.HTTPStatus, appErr.HTTPStatus)
+			assert.Equal(t, info.Retryable, appErr.Retryable)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 )
 
@@ -42,7 +43,7 @@ var (
 
 // IsType checks if an error is of a specific type
 func IsType(err error, errorType ErrorType) bool {
-	if appErr, ok := err.(*AppError); ok {
+	if appErr, ok := AsAppError(err); ok {
 		return appErr.Type == errorType
 	}
 	return false
@@ -100,7 +101,7 @@ func IsUnavailableError(err error) bool {
 
 // IsRetryable checks if an error is retryable
 func IsRetryable(err error) bool {
-	if appErr, ok := err.(*AppError); ok {
+	if appErr, ok := AsAppError(err); ok {
 		return appErr.Retryable
 	}
 	return false
@@ -108,7 +109,7 @@ func IsRetryable(err error) bool {
 
 // GetErrorCode extracts the error code from an error
 func GetErrorCode(err error) string {
-	if appErr, ok := err.(*AppError); ok {
+	if appErr, ok := AsAppError(err); ok {
 		return appErr.Code
 	}
 	return "UNKNOWN_ERROR"
@@ -116,7 +117,7 @@ func GetErrorCode(err error) string {
 
 // GetErrorType extracts the error type from an error
 func GetErrorType(err error) ErrorType {
-	if appErr, ok := err.(*AppError); ok {
+	if appErr, ok := AsAppError(err); ok {
 		return appErr.Type
 	}
 	return ErrorTypeInternal
@@ -124,7 +125,7 @@ func GetErrorType(err error) ErrorType {
 
 // GetHTTPStatus extracts the HTTP status code from an error
 func GetHTTPStatus(err error) int {
-	if appErr, ok := err.(*AppError); ok {
+	if appErr, ok := AsAppError(err); ok {
 		return appErr.HTTPStatus
 	}
 	return 500
@@ -186,13 +187,11 @@ func Ignore(err error) {
 	_ = err
 }
 
-// AsAppError converts an error to AppError if possible
+// AsAppError returns the first AppError in err's chain, if any, so errors
+// wrapping an AppError with fmt.Errorf("...: %w", err) are recognised
 func AsAppError(err error) (*AppError, bool) {
-	if err == nil {
-		return nil, false
-	}
-
-	if appErr, ok := err.(*AppError); ok {
+	var appErr *AppError
+	if stderrors.As(err, &appErr) {
 		return appErr, true
 	}
 
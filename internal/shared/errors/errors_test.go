@@ -334,6 +334,12 @@ func TestAsAppError(t *testing.T) {
 			expectOk:  true,
 			expectNil: false,
 		},
+		{
+			name:      "wrapped AppError",
+			err:       fmt.Errorf("context: %w", NewValidationError("TEST", "test")),
+			expectOk:  true,
+			expectNil: false,
+		},
 		{
 			name:      "standard error",
 			err:       fmt.Errorf("standard error"),
@@ -0,0 +1,134 @@
+package errors
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// LocaleContextKey is the Echo context key middleware can set to override the
+// locale taken from the Accept-Language header
+const LocaleContextKey = "locale"
+
+// MessageBundle maps a locale to the user messages for each error code
+type MessageBundle map[string]map[string]string
+
+// ErrorTranslator localizes user-facing error messages by error code
+type ErrorTranslator struct {
+	defaultLocale string
+	bundle        MessageBundle
+	mu            sync.RWMutex
+}
+
+// NewErrorTranslator creates a translator that falls back to defaultLocale
+func NewErrorTranslator(defaultLocale string) *ErrorTranslator {
+	return &ErrorTranslator{
+		defaultLocale: normalizeLocale(defaultLocale),
+		bundle:        make(MessageBundle),
+	}
+}
+
+// AddMessages registers user messages for a locale, replacing any existing
+// message for the same code
+func (t *ErrorTranslator) AddMessages(locale string, messages map[string]string) {
+	locale = normalizeLocale(locale)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.bundle[locale] == nil {
+		t.bundle[locale] = make(map[string]string)
+	}
+	for code, message := range messages {
+		t.bundle[locale][code] = message
+	}
+}
+
+// Translate returns the message for code in the first of locales that has
+// one, trying each locale's base language too (pt-BR falls back to pt). It
+// then tries the default locale and finally returns fallback.
+func (t *ErrorTranslator) Translate(locales []string, code, fallback string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, locale := range locales {
+		if message, ok := t.lookup(normalizeLocale(locale), code); ok {
+			return message
+		}
+	}
+
+	if message, ok := t.lookup(t.defaultLocale, code); ok {
+		return message
+	}
+
+	return fallback
+}
+
+// TranslateAcceptLanguage translates code for the locales in an
+// Accept-Language header value
+func (t *ErrorTranslator) TranslateAcceptLanguage(header, code, fallback string) string {
+	return t.Translate(ParseAcceptLanguage(header), code, fallback)
+}
+
+// lookup finds code in locale or its base language. Callers hold t.mu.
+func (t *ErrorTranslator) lookup(locale, code string) (string, bool) {
+	if message, ok := t.bundle[locale][code]; ok {
+		return message, true
+	}
+
+	if base, _, found := strings.Cut(locale, "-"); found {
+		if message, ok := t.bundle[base][code]; ok {
+			return message, true
+		}
+	}
+
+	return "", false
+}
+
+// ParseAcceptLanguage returns the locales in an Accept-Language header
+// ordered by preference. Wildcards and locales with q=0 are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weightedLocale struct {
+		locale  string
+		quality float64
+	}
+
+	var weighted []weightedLocale
+	for _, part := range strings.Split(header, ",") {
+		locale, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		locale = strings.TrimSpace(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		weighted = append(weighted, weightedLocale{locale: locale, quality: quality})
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].quality > weighted[j].quality
+	})
+
+	locales := make([]string, len(weighted))
+	for i, w := range weighted {
+		locales[i] = w.locale
+	}
+	return locales
+}
+
+// normalizeLocale lowercases a locale and uses "-" as the region separator
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -0,0 +1,113 @@
+package errors
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTranslator() *ErrorTranslator {
+	translator := NewErrorTranslator("en")
+	translator.AddMessages("en", map[string]string{
+		"SESSION_EXPIRED": "Your session has expired. Please log in again.",
+	})
+	translator.AddMessages("id", map[string]string{
+		"SESSION_EXPIRED": "Sesi Anda telah berakhir. Silakan masuk kembali.",
+	})
+	return translator
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected []string
+	}{
+		{"", []string{}},
+		{"id", []string{"id"}},
+		{"en-US,en;q=0.9,id;q=0.8", []string{"en-US", "en", "id"}},
+		{"fr;q=0.5, id-ID;q=0.9, *;q=0.1", []string{"id-ID", "fr"}},
+		{"de;q=0, es", []string{"es"}},
+		{"nl;q=abc, pt", []string{"pt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestErrorTranslator_Translate(t *testing.T) {
+	translator := newTestTranslator()
+
+	tests := []struct {
+		name     string
+		locales  []string
+		code     string
+		expected string
+	}{
+		{"english", []string{"en"}, "SESSION_EXPIRED", "Your session has expired. Please log in again."},
+		{"second locale", []string{"id"}, "SESSION_EXPIRED", "Sesi Anda telah berakhir. Silakan masuk kembali."},
+		{"region falls back to base language", []string{"id-ID"}, "SESSION_EXPIRED", "Sesi Anda telah berakhir. Silakan masuk kembali."},
+		{"missing locale uses default locale", []string{"fr"}, "SESSION_EXPIRED", "Your session has expired. Please log in again."},
+		{"preference order", []string{"fr", "id", "en"}, "SESSION_EXPIRED", "Sesi Anda telah berakhir. Silakan masuk kembali."},
+		{"unknown code uses fallback", []string{"id"}, "UNKNOWN", "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, translator.Translate(tt.locales, tt.code, "fallback"))
+		})
+	}
+}
+
+func TestErrorMiddleware_LocalizesUserMessage(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		contextLocale  string
+		expected       string
+	}{
+		{"english", "en-US,en;q=0.9", "", "Your session has expired. Please log in again."},
+		{"second locale", "id-ID,id;q=0.9", "", "Sesi Anda telah berakhir. Silakan masuk kembali."},
+		{"missing locale falls back", "fr-FR", "", "Your session has expired. Please log in again."},
+		{"context locale wins over header", "en", "id", "Sesi Anda telah berakhir. Silakan masuk kembali."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ErrorMiddlewareConfig{
+				LogAllErrors: true,
+				Translator:   newTestTranslator(),
+			}
+			middleware := NewErrorMiddleware(NewLogrusLogger(logrus.New()), config)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if tt.contextLocale != "" {
+				c.Set(LocaleContextKey, tt.contextLocale)
+			}
+
+			handler := middleware.Handler()(func(c echo.Context) error {
+				return NewSessionExpiredError()
+			})
+			require.NoError(t, handler(c))
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+			errorData := response["error"].(map[string]interface{})
+			assert.Equal(t, "SESSION_EXPIRED", errorData["code"])
+			assert.Equal(t, tt.expected, errorData["user_message"])
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -39,6 +40,14 @@ type LoggingConfig struct {
 
 	// Environment is the deployment environment
 	Environment string `json:"environment" yaml:"environment"`
+
+	// AlertOnHighSeverity sends high severity errors to the alert sink in
+	// addition to critical ones
+	AlertOnHighSeverity bool `json:"alert_on_high_severity" yaml:"alert_on_high_severity"`
+
+	// Sampling limits how many low and medium severity errors with the same
+	// code the ErrorLogger logs; the zero value logs every error
+	Sampling SamplingConfig `json:"sampling" yaml:"sampling"`
 }
 
 // DefaultLoggingConfig returns default logging configuration
@@ -122,6 +131,23 @@ func NewStructuredLogger(config LoggingConfig) (*StructuredLogger, error) {
 	}, nil
 }
 
+// SetLevel changes the minimum log level at runtime, e.g. to "debug" while
+// investigating an issue. It applies to every subsequent log entry,
+// including those from loggers already derived from l.
+func (l *StructuredLogger) SetLevel(level string) error {
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log level '%s': %w", level, err)
+	}
+	l.logger.SetLevel(parsed)
+	return nil
+}
+
+// Level returns the current minimum log level
+func (l *StructuredLogger) Level() string {
+	return l.logger.GetLevel().String()
+}
+
 // WithContext creates a logger with context information
 func (l *StructuredLogger) WithContext(ctx context.Context) *ContextLogger {
 	return &ContextLogger{
@@ -313,22 +339,58 @@ func (cl *ContextLogger) addContextFields(fields map[string]interface{}) {
 	}
 }
 
+// AlertSink forwards errors to an external alerting system such as
+// PagerDuty or Slack
+type AlertSink interface {
+	Alert(ctx context.Context, err *AppError) error
+}
+
 // ErrorLogger provides specialized error logging
 type ErrorLogger struct {
-	logger Logger
-	config LoggingConfig
+	logger    Logger
+	config    LoggingConfig
+	alertSink AlertSink
+	alerts    sync.WaitGroup
+	sampler   *errorSampler
 }
 
 // NewErrorLogger creates a new error logger
 func NewErrorLogger(logger Logger, config LoggingConfig) *ErrorLogger {
 	return &ErrorLogger{
-		logger: logger,
-		config: config,
+		logger:  logger,
+		config:  config,
+		sampler: newErrorSampler(config.Sampling),
 	}
 }
 
+// SetAlertSink sets the sink notified of critical errors, and of high
+// severity errors when AlertOnHighSeverity is enabled
+func (el *ErrorLogger) SetAlertSink(sink AlertSink) {
+	el.alertSink = sink
+}
+
+// FlushAlerts waits for in-flight alerts to finish, e.g. before shutdown
+func (el *ErrorLogger) FlushAlerts() {
+	el.alerts.Wait()
+}
+
 // LogError logs an AppError with full context
 func (el *ErrorLogger) LogError(err *AppError) {
+	el.LogErrorContext(context.Background(), err)
+}
+
+// LogErrorContext logs an AppError with full context and alerts on it if its
+// severity warrants. The alert is sent in the background so a slow or failing
+// sink never delays or replaces the log entry.
+func (el *ErrorLogger) LogErrorContext(ctx context.Context, err *AppError) {
+	if el.sampler != nil && isSampledSeverity(err.Severity) {
+		log, report := el.sampler.sample(err.Code)
+		el.logSampled(report)
+		if !log {
+			return
+		}
+	}
+
 	fields := map[string]interface{}{
 		"error_id":    err.ID,
 		"error_code":  err.Code,
@@ -392,6 +454,72 @@ func (el *ErrorLogger) LogError(err *AppError) {
 	default:
 		logger.Error(message)
 	}
+
+	if el.shouldAlert(err) {
+		el.sendAlert(ctx, err)
+	}
+}
+
+// FlushSampled logs how many errors were sampled out since the last report,
+// e.g. before shutdown. Reports are otherwise logged once per sampling
+// interval, when the next low or medium severity error arrives.
+func (el *ErrorLogger) FlushSampled() {
+	if el.sampler != nil {
+		el.logSampled(el.sampler.flush())
+	}
+}
+
+// logSampled logs one entry per error code sampled out
+func (el *ErrorLogger) logSampled(report []sampledCount) {
+	for _, count := range report {
+		el.logger.WithFields(map[string]interface{}{
+			"error_code":    count.code,
+			"sampled_count": count.dropped,
+			"interval":      el.sampler.config.Interval.String(),
+		}).Warn("Errors sampled out of the log")
+	}
+}
+
+// shouldAlert reports whether err is severe enough to alert on
+func (el *ErrorLogger) shouldAlert(err *AppError) bool {
+	if el.alertSink == nil {
+		return false
+	}
+
+	switch err.Severity {
+	case SeverityCritical:
+		return true
+	case SeverityHigh:
+		return el.config.AlertOnHighSeverity
+	default:
+		return false
+	}
+}
+
+// sendAlert delivers err to the alert sink on a separate goroutine, logging
+// any failure instead of returning it
+func (el *ErrorLogger) sendAlert(ctx context.Context, err *AppError) {
+	sink := el.alertSink
+	ctx = context.WithoutCancel(ctx)
+
+	el.alerts.Add(1)
+	go func() {
+		defer el.alerts.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				el.logger.WithFields(map[string]interface{}{
+					"error_id": err.ID,
+					"panic":    r,
+				}).Warn("Alert sink panicked")
+			}
+		}()
+
+		if alertErr := sink.Alert(ctx, err); alertErr != nil {
+			el.logger.WithFields(map[string]interface{}{
+				"error_id": err.ID,
+			}).WithError(alertErr).Warn("Failed to send error alert")
+		}
+	}()
 }
 
 // Helper functions for context extraction
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -572,6 +573,40 @@ func TestStructuredLogger_LogLevels(t *testing.T) {
 	}
 }
 
+func TestStructuredLogger_SetLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := DefaultLoggingConfig()
+	config.Level = "info"
+	logger, err := NewStructuredLogger(config)
+	require.NoError(t, err)
+	logger.logger.SetOutput(&buf)
+
+	// Loggers derived before the change follow it too
+	derived := logger.WithFields(map[string]interface{}{"component": "test"})
+
+	logger.Debug("suppressed debug message")
+	derived.Debug("suppressed derived debug message")
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, logger.SetLevel("debug"))
+	assert.Equal(t, "debug", logger.Level())
+
+	logger.Debug("visible debug message")
+	derived.Debug("visible derived debug message")
+	assert.Contains(t, buf.String(), "visible debug message")
+	assert.Contains(t, buf.String(), "visible derived debug message")
+	assert.NotContains(t, buf.String(), "suppressed")
+}
+
+func TestStructuredLogger_SetLevelInvalid(t *testing.T) {
+	logger, err := NewStructuredLogger(DefaultLoggingConfig())
+	require.NoError(t, err)
+
+	assert.Error(t, logger.SetLevel("verbose"))
+	assert.Equal(t, "info", logger.Level())
+}
+
 func TestStructuredLogger_Integration(t *testing.T) {
 	// Create temporary log file
 	tempDir := t.TempDir()
@@ -653,3 +688,241 @@ func TestStructuredLogger_Integration(t *testing.T) {
 		}
 	}
 }
+
+// recordingAlertSink records alerted errors and returns err from Alert
+type recordingAlertSink struct {
+	mu     sync.Mutex
+	alerts []*AppError
+	err    error
+}
+
+func (s *recordingAlertSink) Alert(ctx context.Context, err *AppError) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alerts = append(s.alerts, err)
+	return s.err
+}
+
+func (s *recordingAlertSink) Alerts() []*AppError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*AppError(nil), s.alerts...)
+}
+
+func newAlertingErrorLogger(t *testing.T, config LoggingConfig, sink AlertSink) (*ErrorLogger, *bytes.Buffer) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	config.Format = "json"
+
+	structuredLogger, err := NewStructuredLogger(config)
+	require.NoError(t, err)
+	structuredLogger.logger.SetOutput(&buf)
+
+	errorLogger := NewErrorLogger(structuredLogger.WithFields(nil), config)
+	errorLogger.SetAlertSink(sink)
+	return errorLogger, &buf
+}
+
+func TestErrorLogger_AlertSink(t *testing.T) {
+	tests := []struct {
+		name        string
+		severity    ErrorSeverity
+		alertOnHigh bool
+		expectAlert bool
+	}{
+		{"critical alerts", SeverityCritical, false, true},
+		{"high does not alert by default", SeverityHigh, false, false},
+		{"high alerts when enabled", SeverityHigh, true, true},
+		{"medium never alerts", SeverityMedium, true, false},
+		{"low never alerts", SeverityLow, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultLoggingConfig()
+			config.AlertOnHighSeverity = tt.alertOnHigh
+
+			sink := &recordingAlertSink{}
+			errorLogger, _ := newAlertingErrorLogger(t, config, sink)
+
+			appErr := NewInternalError("TEST_ERROR", "Test error")
+			appErr.Severity = tt.severity
+
+			errorLogger.LogError(appErr)
+			errorLogger.FlushAlerts()
+
+			alerts := sink.Alerts()
+			if tt.expectAlert {
+				require.Len(t, alerts, 1)
+				assert.Equal(t, appErr.ID, alerts[0].ID)
+			} else {
+				assert.Empty(t, alerts)
+			}
+		})
+	}
+}
+
+func TestErrorLogger_AlertSinkErrorDoesNotBreakLogging(t *testing.T) {
+	sink := &recordingAlertSink{err: fmt.Errorf("pager unavailable")}
+	errorLogger, buf := newAlertingErrorLogger(t, DefaultLoggingConfig(), sink)
+
+	appErr := NewInternalError("DATA_CORRUPTION", "Data corruption detected")
+	appErr.Severity = SeverityCritical
+
+	errorLogger.LogError(appErr)
+	errorLogger.FlushAlerts()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var logEntry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &logEntry))
+	assert.Equal(t, appErr.ID, logEntry["error_id"])
+	assert.Equal(t, "Error occurred: Data corruption detected", logEntry["message"])
+
+	var alertEntry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &alertEntry))
+	assert.Equal(t, "Failed to send error alert", alertEntry["message"])
+	assert.Equal(t, "pager unavailable", alertEntry["error"])
+	assert.Len(t, sink.Alerts(), 1)
+}
+
+// newSamplingErrorLogger returns an error logger sampling with config, whose
+// clock is controlled by the returned function, and the buffer it writes to
+func newSamplingErrorLogger(t *testing.T, sampling SamplingConfig) (*ErrorLogger, *bytes.Buffer, func(time.Duration)) {
+	t.Helper()
+
+	config := DefaultLoggingConfig()
+	config.Sampling = sampling
+	errorLogger, buf := newAlertingErrorLogger(t, config, nil)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	errorLogger.sampler.now = func() time.Time { return now }
+	advance := func(d time.Duration) { now = now.Add(d) }
+	return errorLogger, buf, advance
+}
+
+// logEntries parses the JSON log lines in buf
+func logEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// countMessages counts the log entries with message
+func countMessages(entries []map[string]interface{}, message string) int {
+	count := 0
+	for _, entry := range entries {
+		if entry["message"] == message {
+			count++
+		}
+	}
+	return count
+}
+
+func TestErrorLogger_SamplesLowSeverityErrors(t *testing.T) {
+	errorLogger, buf, _ := newSamplingErrorLogger(t, SamplingConfig{Initial: 3, Thereafter: 10, Interval: time.Minute})
+
+	for i := 0; i < 100; i++ {
+		appErr := NewValidationError("INVALID_INPUT", "Invalid input provided")
+		appErr.Severity = SeverityLow
+		errorLogger.LogError(appErr)
+	}
+
+	// The first 3, then every 10th of the remaining 97
+	entries := logEntries(t, buf)
+	assert.Equal(t, 3+9, countMessages(entries, "Error occurred: Invalid input provided"))
+}
+
+func TestErrorLogger_SamplingCountsCodesSeparately(t *testing.T) {
+	errorLogger, buf, _ := newSamplingErrorLogger(t, SamplingConfig{Initial: 2, Interval: time.Minute})
+
+	for i := 0; i < 5; i++ {
+		for _, code := range []string{"FIRST", "SECOND"} {
+			appErr := NewValidationError(code, "Invalid "+code)
+			appErr.Severity = SeverityMedium
+			errorLogger.LogError(appErr)
+		}
+	}
+
+	entries := logEntries(t, buf)
+	assert.Equal(t, 2, countMessages(entries, "Error occurred: Invalid FIRST"))
+	assert.Equal(t, 2, countMessages(entries, "Error occurred: Invalid SECOND"))
+}
+
+func TestErrorLogger_NeverSamplesSevereErrors(t *testing.T) {
+	errorLogger, buf, _ := newSamplingErrorLogger(t, SamplingConfig{Initial: 1, Interval: time.Minute})
+
+	for i := 0; i < 20; i++ {
+		critical := NewInternalError("DATA_CORRUPTION", "Data corruption detected")
+		critical.Severity = SeverityCritical
+		errorLogger.LogError(critical)
+
+		high := NewInternalError("DATABASE_DOWN", "Database unavailable")
+		high.Severity = SeverityHigh
+		errorLogger.LogError(high)
+	}
+
+	entries := logEntries(t, buf)
+	assert.Equal(t, 20, countMessages(entries, "Error occurred: Data corruption detected"))
+	assert.Equal(t, 20, countMessages(entries, "Error occurred: Database unavailable"))
+}
+
+func TestErrorLogger_ReportsSampledCountEachInterval(t *testing.T) {
+	errorLogger, buf, advance := newSamplingErrorLogger(t, SamplingConfig{Initial: 1, Interval: time.Minute})
+
+	logLow := func() {
+		appErr := NewValidationError("INVALID_INPUT", "Invalid input provided")
+		appErr.Severity = SeverityLow
+		errorLogger.LogError(appErr)
+	}
+
+	for i := 0; i < 5; i++ {
+		logLow()
+	}
+	assert.Zero(t, countMessages(logEntries(t, buf), "Errors sampled out of the log"))
+
+	// The next error after the interval reports the previous one and is
+	// logged, as counting starts again
+	advance(time.Minute)
+	logLow()
+
+	entries := logEntries(t, buf)
+	require.Equal(t, 1, countMessages(entries, "Errors sampled out of the log"))
+	for _, entry := range entries {
+		if entry["message"] == "Errors sampled out of the log" {
+			assert.Equal(t, "INVALID_INPUT", entry["error_code"])
+			assert.Equal(t, float64(4), entry["sampled_count"])
+			assert.Equal(t, "1m0s", entry["interval"])
+		}
+	}
+	assert.Equal(t, 2, countMessages(entries, "Error occurred: Invalid input provided"))
+
+	// Flushing reports what was sampled out since
+	logLow()
+	errorLogger.FlushSampled()
+	assert.Equal(t, 2, countMessages(logEntries(t, buf), "Errors sampled out of the log"))
+}
+
+func TestErrorLogger_SamplingDisabledByDefault(t *testing.T) {
+	errorLogger, buf := newAlertingErrorLogger(t, DefaultLoggingConfig(), nil)
+
+	for i := 0; i < 50; i++ {
+		appErr := NewValidationError("INVALID_INPUT", "Invalid input provided")
+		appErr.Severity = SeverityLow
+		errorLogger.LogError(appErr)
+	}
+	errorLogger.FlushSampled()
+
+	assert.Equal(t, 50, countMessages(logEntries(t, buf), "Error occurred: Invalid input provided"))
+}
@@ -33,6 +33,9 @@ type ErrorMiddlewareConfig struct {
 
 	// CustomErrorHandler allows custom error handling logic
 	CustomErrorHandler func(c echo.Context, err error) error
+
+	// Translator localizes user messages using the request locale (optional)
+	Translator *ErrorTranslator
 }
 
 // DefaultErrorMiddlewareConfig returns default configuration
@@ -169,7 +172,7 @@ func (m *ErrorMiddleware) createErrorContext(c echo.Context, requestID string) E
 // convertToAppError converts any error to AppError
 func (m *ErrorMiddleware) convertToAppError(err error, ctx ErrorContext) *AppError {
 	// If it's already an AppError, add context and return
-	if appErr, ok := err.(*AppError); ok {
+	if appErr, ok := AsAppError(err); ok {
 		return appErr.WithContext(ctx)
 	}
 
@@ -341,6 +344,16 @@ func (m *ErrorMiddleware) sendErrorResponse(c echo.Context, appErr *AppError) er
 		response["request_id"] = appErr.Context.RequestID
 	}
 
+	// Localize the user message for the request locale
+	if m.config.Translator != nil {
+		if errorMap, ok := response["error"].(map[string]interface{}); ok {
+			userMsg := m.config.Translator.Translate(getLocalesFromContext(c), appErr.Code, appErr.UserMessage)
+			if userMsg != "" {
+				errorMap["user_message"] = userMsg
+			}
+		}
+	}
+
 	// Hide internal error details in production
 	if m.config.HideInternalErrors && appErr.Severity >= SeverityHigh {
 		if errorMap, ok := response["error"].(map[string]interface{}); ok {
@@ -413,6 +426,16 @@ func getSessionIDFromContext(c echo.Context) string {
 	return ""
 }
 
+// getLocalesFromContext returns the locale set on the Echo context, if any,
+// followed by the locales from the Accept-Language header
+func getLocalesFromContext(c echo.Context) []string {
+	locales := ParseAcceptLanguage(c.Request().Header.Get("Accept-Language"))
+	if locale, ok := c.Get(LocaleContextKey).(string); ok && locale != "" {
+		locales = append([]string{locale}, locales...)
+	}
+	return locales
+}
+
 // Recovery middleware that converts panics to AppErrors
 func (m *ErrorMiddleware) RecoveryHandler() echo.MiddlewareFunc {
 	return middleware.RecoverWithConfig(middleware.RecoverConfig{
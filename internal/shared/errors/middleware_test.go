@@ -39,6 +39,21 @@ func TestErrorMiddleware_Handler(t *testing.T) {
 			expectedCode:   "INVALID_INPUT",
 			expectedType:   "validation",
 		},
+		{
+			name: "handles wrapped AppError",
+			handler: func(c echo.Context) error {
+				return fmt.Errorf("updating profile: %w", NewValidationError("INVALID_INPUT", "Invalid input provided"))
+			},
+			config: ErrorMiddlewareConfig{
+				IncludeStackTrace:  false,
+				LogAllErrors:       true,
+				LogRequestDetails:  true,
+				HideInternalErrors: false, // Don't hide internal errors for testing
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_INPUT",
+			expectedType:   "validation",
+		},
 		{
 			name: "handles Echo HTTP error",
 			handler: func(c echo.Context) error {
@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// SamplingConfig limits how many low and medium severity errors with the
+// same code are logged. Critical and high severity errors are always logged.
+type SamplingConfig struct {
+	// Initial is how many errors with the same code are logged per interval
+	// before sampling starts; zero disables sampling
+	Initial int `json:"initial" yaml:"initial"`
+
+	// Thereafter logs one in every Thereafter errors with the same code once
+	// Initial is reached; zero logs none of them
+	Thereafter int `json:"thereafter" yaml:"thereafter"`
+
+	// Interval is how long counts last before starting again, and how often
+	// the number of errors sampled out is logged. Defaults to one minute.
+	Interval time.Duration `json:"interval" yaml:"interval"`
+}
+
+// Enabled reports whether errors are sampled
+func (c SamplingConfig) Enabled() bool {
+	return c.Initial > 0
+}
+
+// sampledCount is the number of errors with a code that were not logged
+type sampledCount struct {
+	code    string
+	dropped int
+}
+
+// errorSampler decides which errors to log, counting per error code within
+// fixed intervals
+type errorSampler struct {
+	config SamplingConfig
+	now    func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	seen        map[string]int
+	dropped     map[string]int
+}
+
+// newErrorSampler creates a sampler for config, or nil if sampling is disabled
+func newErrorSampler(config SamplingConfig) *errorSampler {
+	if !config.Enabled() {
+		return nil
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+
+	return &errorSampler{
+		config:  config,
+		now:     time.Now,
+		seen:    make(map[string]int),
+		dropped: make(map[string]int),
+	}
+}
+
+// sample reports whether an error with code should be logged. Once an
+// interval has passed it also returns the counts sampled out during it, for
+// the caller to log.
+func (s *errorSampler) sample(code string) (bool, []sampledCount) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var report []sampledCount
+	now := s.now()
+	if now.Sub(s.windowStart) >= s.config.Interval {
+		report = s.resetLocked(now)
+	}
+
+	s.seen[code]++
+	n := s.seen[code]
+	if n <= s.config.Initial {
+		return true, report
+	}
+	if s.config.Thereafter > 0 && (n-s.config.Initial)%s.config.Thereafter == 0 {
+		return true, report
+	}
+
+	s.dropped[code]++
+	return false, report
+}
+
+// flush returns the counts sampled out so far and starts a new interval
+func (s *errorSampler) flush() []sampledCount {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.resetLocked(s.now())
+}
+
+// resetLocked starts a new interval at now, returning the counts sampled out
+// during the previous one in code order
+func (s *errorSampler) resetLocked(now time.Time) []sampledCount {
+	var report []sampledCount
+	for code, dropped := range s.dropped {
+		report = append(report, sampledCount{code: code, dropped: dropped})
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].code < report[j].code })
+
+	s.windowStart = now
+	s.seen = make(map[string]int)
+	s.dropped = make(map[string]int)
+	return report
+}
+
+// isSampledSeverity reports whether errors of severity may be sampled out
+func isSampledSeverity(severity ErrorSeverity) bool {
+	return severity == SeverityLow || severity == SeverityMedium
+}
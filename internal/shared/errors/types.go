@@ -132,18 +132,26 @@ func (e *AppError) Error() string {
 	return fmt.Sprintf("[%s] %s: %s", e.Code, e.Type, e.Message)
 }
 
-// Unwrap returns the underlying error
+// Unwrap returns the underlying error, so errors.Is and errors.As see
+// through an AppError to its cause
 func (e *AppError) Unwrap() error {
 	return e.Cause
 }
 
-// Is checks if the error matches the target error
+// Is reports whether the error matches target, for errors.Is. An AppError
+// target matches by the fields it sets: errors with its code, and of its
+// type if it has one, when it has a code, or otherwise errors of its type.
+// Sentinels such as ErrNotFound therefore match every error of their type.
 func (e *AppError) Is(target error) bool {
-	if appErr, ok := target.(*AppError); ok {
-		// Errors match if they have the same type, or if they have the same code and type
-		return e.Type == appErr.Type
+	appErr, ok := target.(*AppError)
+	if !ok {
+		return false
 	}
-	return false
+
+	if appErr.Code != "" {
+		return e.Code == appErr.Code && (appErr.Type == "" || e.Type == appErr.Type)
+	}
+	return appErr.Type != "" && e.Type == appErr.Type
 }
 
 // WithContext adds context to the error
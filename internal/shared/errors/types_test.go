@@ -2,6 +2,7 @@ package errors
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -77,19 +78,69 @@ func TestAppError_Is(t *testing.T) {
 		Type: ErrorTypeInternal,
 	}
 
-	// Same code should match
+	// Same code and type should match
 	assert.True(t, appError1.Is(appError2))
 
-	// Same type should match
-	assert.True(t, appError1.Is(appError3))
+	// Different code should not match
+	assert.False(t, appError1.Is(appError3))
 
 	// Different type should not match
 	assert.False(t, appError1.Is(appError4))
 
+	// A target without a type matches by code
+	assert.True(t, appError1.Is(&AppError{Code: "TEST_ERROR"}))
+
+	// A target without a code matches by type
+	assert.True(t, appError1.Is(&AppError{Type: ErrorTypeValidation}))
+	assert.True(t, appError1.Is(ErrValidation))
+	assert.False(t, appError1.Is(ErrInternal))
+
+	// An empty target matches nothing
+	assert.False(t, appError1.Is(&AppError{}))
+
 	// Non-AppError should not match
 	assert.False(t, appError1.Is(assert.AnError))
 }
 
+func TestAppError_StandardErrors(t *testing.T) {
+	cause := errors.New("connection refused")
+	appError := NewDatabaseError("GetUser", cause)
+	wrapped := fmt.Errorf("loading profile: %w", appError)
+
+	// errors.As finds the AppError through the wrapping
+	var target *AppError
+	require.True(t, errors.As(wrapped, &target))
+	assert.Same(t, appError, target)
+
+	found, ok := AsAppError(wrapped)
+	require.True(t, ok)
+	assert.Same(t, appError, found)
+
+	// errors.Is matches by code, by type and by the AppError's cause
+	assert.True(t, errors.Is(wrapped, &AppError{Code: appError.Code}))
+	assert.True(t, errors.Is(wrapped, ErrInternal))
+	assert.True(t, errors.Is(wrapped, cause))
+	assert.False(t, errors.Is(wrapped, &AppError{Code: "RESOURCE_NOT_FOUND"}))
+	assert.False(t, errors.Is(wrapped, ErrNotFound))
+
+	// Helpers look through wrapping too
+	assert.Equal(t, appError.Code, GetErrorCode(wrapped))
+	assert.Equal(t, ErrorTypeInternal, GetErrorType(wrapped))
+	assert.Equal(t, http.StatusInternalServerError, GetHTTPStatus(wrapped))
+	assert.True(t, IsInternalError(wrapped))
+
+	// Sentinel AppErrors match errors created with the same code
+	errInvalidEmail := &AppError{Code: "INVALID_EMAIL"}
+	invalid := fmt.Errorf("handler: %w", NewValidationError("INVALID_EMAIL", "Email is invalid"))
+	assert.True(t, errors.Is(invalid, errInvalidEmail))
+	assert.True(t, errors.Is(invalid, ErrValidation))
+	assert.False(t, errors.Is(invalid, &AppError{Code: "INVALID_PASSWORD"}))
+
+	// Errors without an AppError in their chain are not AppErrors
+	_, ok = AsAppError(fmt.Errorf("plain: %w", cause))
+	assert.False(t, ok)
+}
+
 func TestAppError_WithContext(t *testing.T) {
 	appError := &AppError{
 		Code: "TEST_ERROR",
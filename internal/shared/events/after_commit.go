@@ -0,0 +1,36 @@
+package events
+
+import (
+	"context"
+	"log"
+
+	"go-templ-template/internal/shared/database"
+)
+
+// PublishAfterCommit publishes event, raised by a write made in the
+// transaction in ctx, so that it is only delivered if the transaction
+// commits. A TransactionalPublisher, such as an OutboxEventBus, writes it in
+// the transaction right away and its error is returned so the transaction is
+// rolled back. Any other bus publishes it once the outermost transaction
+// commits, with a context no longer holding it, and a failure is logged
+// because the write has already persisted. Outside a transaction the event
+// is published immediately and a failure is logged.
+func PublishAfterCommit(ctx context.Context, bus EventBus, event DomainEvent) error {
+	if publishesInTransaction(bus) && database.GetTxFromContext(ctx) != nil {
+		return bus.Publish(ctx, event)
+	}
+
+	database.AfterCommit(ctx, func() {
+		if err := bus.Publish(database.WithoutTransaction(ctx), event); err != nil {
+			log.Printf("Failed to publish %s event for %s: %v", event.EventType(), event.AggregateID(), err)
+		}
+	})
+	return nil
+}
+
+// publishesInTransaction reports whether bus writes events published inside
+// a transaction in it
+func publishesInTransaction(bus EventBus) bool {
+	publisher, ok := bus.(TransactionalPublisher)
+	return ok && publisher.PublishesInTransaction()
+}
@@ -0,0 +1,157 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/shared/database"
+)
+
+// txRecordingBus is an in-memory bus that records whether each event was
+// published with a transaction in its context
+type txRecordingBus struct {
+	*InMemoryEventBus
+	inTransaction []bool
+}
+
+func newTxRecordingBus() *txRecordingBus {
+	bus := &txRecordingBus{InMemoryEventBus: NewInMemoryEventBus()}
+	bus.Start(context.Background())
+	return bus
+}
+
+func (b *txRecordingBus) Publish(ctx context.Context, event DomainEvent) error {
+	b.inTransaction = append(b.inTransaction, database.GetTxFromContext(ctx) != nil)
+	return b.InMemoryEventBus.Publish(ctx, event)
+}
+
+// outboxInserts returns the positions of the outbox inserts and the commit
+// among statements
+func outboxInserts(statements []string) (inserts []int, commit int) {
+	commit = -1
+	for i, statement := range statements {
+		switch {
+		case strings.Contains(statement, "INSERT INTO event_outbox"):
+			inserts = append(inserts, i)
+		case statement == "COMMIT":
+			commit = i
+		}
+	}
+	return inserts, commit
+}
+
+func TestPublishAfterCommit_DefersUntilCommit(t *testing.T) {
+	db := database.NewStubDB()
+	bus := newTxRecordingBus()
+
+	err := database.ExecuteInTransaction(context.Background(), db.DB, func(txCtx context.Context) error {
+		if err := PublishAfterCommit(txCtx, bus, NewTestEvent("test-id", "test-data")); err != nil {
+			return err
+		}
+		if len(bus.inTransaction) != 0 {
+			t.Error("Expected event not to be published before commit")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(bus.inTransaction) != 1 {
+		t.Fatalf("Expected event to be published after commit, got %d publishes", len(bus.inTransaction))
+	}
+	if bus.inTransaction[0] {
+		t.Error("Expected event to be published without the committed transaction")
+	}
+}
+
+func TestPublishAfterCommit_DiscardedOnRollback(t *testing.T) {
+	db := database.NewStubDB()
+	bus := newTxRecordingBus()
+	rollback := errors.New("rollback")
+
+	err := database.ExecuteInTransaction(context.Background(), db.DB, func(txCtx context.Context) error {
+		if err := PublishAfterCommit(txCtx, bus, NewTestEvent("test-id", "test-data")); err != nil {
+			return err
+		}
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Fatalf("Expected rollback error, got %v", err)
+	}
+
+	if len(bus.inTransaction) != 0 {
+		t.Errorf("Expected no event to be published after rollback, got %d", len(bus.inTransaction))
+	}
+}
+
+func TestPublishAfterCommit_WithoutTransaction(t *testing.T) {
+	bus := newTxRecordingBus()
+
+	if err := PublishAfterCommit(context.Background(), bus, NewTestEvent("test-id", "test-data")); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(bus.inTransaction) != 1 {
+		t.Errorf("Expected event to be published immediately, got %d publishes", len(bus.inTransaction))
+	}
+}
+
+func TestPublishAfterCommit_WritesToOutboxInTransaction(t *testing.T) {
+	db := database.NewStubDB()
+	inner := newTxRecordingBus()
+	bus := NewOutboxEventBus(inner, db.DB, DefaultOutboxConfig())
+
+	err := database.ExecuteInTransaction(context.Background(), db.DB, func(txCtx context.Context) error {
+		// Nested transactions join the outer one, so the event must be
+		// written before it commits
+		return database.ExecuteInTransaction(txCtx, db.DB, func(nestedCtx context.Context) error {
+			return PublishAfterCommit(nestedCtx, bus, NewTestEvent("test-id", "test-data"))
+		})
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	inserts, commit := outboxInserts(db.Statements())
+	if len(inserts) != 1 {
+		t.Fatalf("Expected event to be written to the outbox, got statements %v", db.Statements())
+	}
+	if commit < inserts[0] {
+		t.Errorf("Expected outbox insert before commit, got statements %v", db.Statements())
+	}
+	if len(inner.inTransaction) != 0 {
+		t.Errorf("Expected event to be left to the relay, got %d direct publishes", len(inner.inTransaction))
+	}
+}
+
+func TestPublishAfterCommit_DegradedOutboxBuffers(t *testing.T) {
+	db := database.NewStubDB()
+	observer := &recordingObserver{}
+	outbox := NewOutboxEventBus(newUnavailableBus(1<<30), db.DB, DefaultOutboxConfig())
+	bus := NewDegradedEventBus(outbox, DegradedConfig{RetryDelay: time.Hour}, observer)
+
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected degraded start to succeed, got %v", err)
+	}
+	defer bus.Stop(context.Background())
+
+	err := database.ExecuteInTransaction(context.Background(), db.DB, func(txCtx context.Context) error {
+		return PublishAfterCommit(txCtx, bus, NewTestEvent("test-id", "test-data"))
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if inserts, _ := outboxInserts(db.Statements()); len(inserts) != 1 {
+		t.Errorf("Expected event to be buffered in the outbox, got statements %v", db.Statements())
+	}
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if len(observer.publishes) != 0 {
+		t.Errorf("Expected no dropped events, got %+v", observer.publishes)
+	}
+}
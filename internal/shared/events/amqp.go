@@ -0,0 +1,84 @@
+package events
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// amqpConnection is the subset of *amqp.Connection used by the bus,
+// allowing tests to substitute a fake broker
+type amqpConnection interface {
+	Channel() (amqpChannel, error)
+	NotifyClose(receiver chan *amqp.Error) chan *amqp.Error
+	IsClosed() bool
+	Close() error
+}
+
+// amqpChannel is the subset of *amqp.Channel used by the bus
+type amqpChannel interface {
+	ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+	QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
+	QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+	Confirm(noWait bool) error
+	PublishWithDeferredConfirmWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) (amqpConfirmation, error)
+	NotifyReturn(receiver chan amqp.Return) chan amqp.Return
+	NotifyClose(receiver chan *amqp.Error) chan *amqp.Error
+	IsClosed() bool
+	Close() error
+}
+
+// amqpConfirmation is the broker's pending confirmation of a published
+// message, satisfied by *amqp.DeferredConfirmation
+type amqpConfirmation interface {
+	// Done is closed once the broker has confirmed the message
+	Done() <-chan struct{}
+
+	// Acked reports whether the broker acked the message, once Done is closed
+	Acked() bool
+}
+
+// amqpDialer opens a connection to the broker at the given URL
+type amqpDialer func(url string) (amqpConnection, error)
+
+// connectionAdapter adapts *amqp.Connection to amqpConnection
+type connectionAdapter struct {
+	*amqp.Connection
+}
+
+// Channel opens a new channel on the connection
+func (c connectionAdapter) Channel() (amqpChannel, error) {
+	ch, err := c.Connection.Channel()
+	if err != nil {
+		return nil, err
+	}
+	return channelAdapter{ch}, nil
+}
+
+// channelAdapter adapts *amqp.Channel to amqpChannel
+type channelAdapter struct {
+	*amqp.Channel
+}
+
+var _ amqpChannel = channelAdapter{}
+
+// PublishWithDeferredConfirmWithContext publishes a message, returning its
+// pending confirmation, or nil if the channel is not in confirm mode
+func (c channelAdapter) PublishWithDeferredConfirmWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) (amqpConfirmation, error) {
+	confirmation, err := c.Channel.PublishWithDeferredConfirmWithContext(ctx, exchange, key, mandatory, immediate, msg)
+	if err != nil || confirmation == nil {
+		return nil, err
+	}
+	return confirmation, nil
+}
+
+// dialAMQP connects to a real RabbitMQ broker
+func dialAMQP(url string) (amqpConnection, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+	return connectionAdapter{conn}, nil
+}
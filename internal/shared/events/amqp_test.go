@@ -0,0 +1,773 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// fakeConnection implements amqpConnection without a broker
+type fakeConnection struct {
+	mu       sync.Mutex
+	channels []*fakeChannel
+	notify   []chan *amqp.Error
+	closed   bool
+}
+
+func (c *fakeConnection) Channel() (amqpChannel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, amqp.ErrClosed
+	}
+	ch := &fakeChannel{queues: make(map[string]chan amqp.Delivery)}
+	c.channels = append(c.channels, ch)
+	return ch, nil
+}
+
+func (c *fakeConnection) NotifyClose(receiver chan *amqp.Error) chan *amqp.Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.notify = append(c.notify, receiver)
+	return receiver
+}
+
+func (c *fakeConnection) IsClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *fakeConnection) Close() error {
+	c.shutdown(nil)
+	return nil
+}
+
+// drop simulates the broker closing the connection
+func (c *fakeConnection) drop(reason *amqp.Error) {
+	c.shutdown(reason)
+}
+
+func (c *fakeConnection) shutdown(reason *amqp.Error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	notify := c.notify
+	channels := c.channels
+	c.mu.Unlock()
+
+	for _, ch := range channels {
+		ch.shutdown(reason)
+	}
+	for _, receiver := range notify {
+		if reason != nil {
+			receiver <- reason
+		}
+		close(receiver)
+	}
+}
+
+// consumerChannel returns the channel consuming from the named queue
+func (c *fakeConnection) consumerChannel(queue string) *fakeChannel {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, ch := range c.channels {
+		if ch.consuming(queue) {
+			return ch
+		}
+	}
+	return nil
+}
+
+// publishChannel returns the first channel, which the bus uses for publishing
+func (c *fakeConnection) publishChannel() *fakeChannel {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.channels[0]
+}
+
+// fakeChannel implements amqpChannel and records published messages. The
+// broker acks every publish unless nack is set, and returns mandatory
+// publishes when unroutable is set.
+type fakeChannel struct {
+	mu         sync.Mutex
+	published  []amqp.Publishing
+	publishErr error
+	confirming bool
+	nack       bool
+	unroutable bool
+	queues     map[string]chan amqp.Delivery
+	prefetch   int
+	notify     []chan *amqp.Error
+	returns    []chan amqp.Return
+	closed     bool
+}
+
+// fakeConfirmation is an already settled amqpConfirmation
+type fakeConfirmation struct {
+	done  chan struct{}
+	acked bool
+}
+
+func (c *fakeConfirmation) Done() <-chan struct{} { return c.done }
+func (c *fakeConfirmation) Acked() bool           { return c.acked }
+
+func (c *fakeChannel) ExchangeDeclare(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	return nil
+}
+
+func (c *fakeChannel) QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error) {
+	return amqp.Queue{Name: name}, nil
+}
+
+func (c *fakeChannel) QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error {
+	return nil
+}
+
+func (c *fakeChannel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prefetch = prefetchCount
+	return nil
+}
+
+func (c *fakeChannel) Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.queues == nil {
+		c.queues = make(map[string]chan amqp.Delivery)
+	}
+	deliveries := make(chan amqp.Delivery)
+	c.queues[queue] = deliveries
+	return deliveries, nil
+}
+
+func (c *fakeChannel) Confirm(noWait bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.confirming = true
+	return nil
+}
+
+func (c *fakeChannel) PublishWithDeferredConfirmWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) (amqpConfirmation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, amqp.ErrClosed
+	}
+	if c.publishErr != nil {
+		return nil, c.publishErr
+	}
+	c.published = append(c.published, msg)
+
+	// Like the broker, return an unroutable message before confirming it
+	if mandatory && c.unroutable {
+		for _, receiver := range c.returns {
+			receiver <- amqp.Return{
+				ReplyCode:  amqp.NoRoute,
+				ReplyText:  "NO_ROUTE",
+				Exchange:   exchange,
+				RoutingKey: key,
+				MessageId:  msg.MessageId,
+			}
+		}
+	}
+	if !c.confirming {
+		return nil, nil
+	}
+	confirmation := &fakeConfirmation{done: make(chan struct{}), acked: !c.nack}
+	close(confirmation.done)
+	return confirmation, nil
+}
+
+func (c *fakeChannel) NotifyReturn(receiver chan amqp.Return) chan amqp.Return {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.returns = append(c.returns, receiver)
+	return receiver
+}
+
+func (c *fakeChannel) NotifyClose(receiver chan *amqp.Error) chan *amqp.Error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.notify = append(c.notify, receiver)
+	return receiver
+}
+
+func (c *fakeChannel) IsClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func (c *fakeChannel) Close() error {
+	c.shutdown(nil)
+	return nil
+}
+
+func (c *fakeChannel) shutdown(reason *amqp.Error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return
+	}
+	c.closed = true
+	queues := c.queues
+	notify := c.notify
+	returns := c.returns
+	c.mu.Unlock()
+
+	for _, deliveries := range queues {
+		close(deliveries)
+	}
+	for _, receiver := range returns {
+		close(receiver)
+	}
+	for _, receiver := range notify {
+		if reason != nil {
+			receiver <- reason
+		}
+		close(receiver)
+	}
+}
+
+func (c *fakeChannel) consuming(queue string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.queues[queue]
+	return ok
+}
+
+func (c *fakeChannel) prefetchCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.prefetch
+}
+
+func (c *fakeChannel) publishedCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.published)
+}
+
+// deliver pushes a message to the consumer of the named queue
+func (c *fakeChannel) deliver(queue string, msg amqp.Delivery) {
+	c.mu.Lock()
+	deliveries := c.queues[queue]
+	c.mu.Unlock()
+	deliveries <- msg
+}
+
+// waitFor polls cond until it returns true or the timeout elapses
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("Timeout waiting for condition")
+}
+
+func TestRabbitMQEventBus_PublishConfirms(t *testing.T) {
+	tests := []struct {
+		name    string
+		broker  func(ch *fakeChannel)
+		wantErr error
+		wantMsg string
+	}{
+		{"acked", func(ch *fakeChannel) {}, nil, ""},
+		{"nacked", func(ch *fakeChannel) { ch.nack = true }, ErrEventNacked, "test.event was nacked by the broker"},
+		{"unroutable", func(ch *fakeChannel) { ch.unroutable = true }, ErrEventUnroutable, "test.event on exchange events: 312 NO_ROUTE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &fakeConnection{}
+			bus := NewRabbitMQEventBus(DefaultRabbitMQConfig())
+			bus.dial = func(url string) (amqpConnection, error) { return conn, nil }
+			if err := bus.Start(context.Background()); err != nil {
+				t.Fatalf("Expected no error starting bus, got %v", err)
+			}
+			defer bus.Stop(context.Background())
+
+			ch := conn.publishChannel()
+			ch.mu.Lock()
+			if !ch.confirming {
+				t.Error("Expected publisher confirms to be enabled")
+			}
+			tt.broker(ch)
+			ch.mu.Unlock()
+
+			err := bus.Publish(context.Background(), NewTestEvent("test-id", "test-data"))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Expected no error publishing, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Expected error to mention %q, got %q", tt.wantMsg, err.Error())
+			}
+		})
+	}
+}
+
+func TestRabbitMQEventBus_PublishRecordsOtherReturns(t *testing.T) {
+	conn := &fakeConnection{}
+	bus := NewRabbitMQEventBus(DefaultRabbitMQConfig())
+	bus.dial = func(url string) (amqpConnection, error) { return conn, nil }
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+	defer bus.Stop(context.Background())
+
+	// A return for another publisher's event arrives first
+	bus.returns <- amqp.Return{MessageId: "other-event", ReplyCode: amqp.NoRoute}
+
+	if err := bus.Publish(context.Background(), NewTestEvent("test-id", "test-data")); err != nil {
+		t.Fatalf("Expected no error publishing a routed event, got %v", err)
+	}
+	if _, ok := bus.takeReturn("other-event"); !ok {
+		t.Error("Expected the other event's return to be kept for its publisher")
+	}
+}
+
+func TestRabbitMQEventBus_ReconnectRestoresSubscriptions(t *testing.T) {
+	observer := &recordingObserver{}
+	config := DefaultRabbitMQConfig()
+	config.Observer = observer
+	config.ReconnectDelay = 5 * time.Millisecond
+	config.MaxReconnectDelay = 20 * time.Millisecond
+	bus := NewRabbitMQEventBus(config)
+
+	var (
+		connsMu  sync.Mutex
+		conns    []*fakeConnection
+		failDial atomic.Bool
+	)
+	bus.dial = func(url string) (amqpConnection, error) {
+		if failDial.Load() {
+			return nil, errors.New("connection refused")
+		}
+		conn := &fakeConnection{}
+		connsMu.Lock()
+		conns = append(conns, conn)
+		connsMu.Unlock()
+		return conn, nil
+	}
+	connCount := func() int {
+		connsMu.Lock()
+		defer connsMu.Unlock()
+		return len(conns)
+	}
+
+	handler := NewMockEventHandler("test-handler", "test.event")
+	if err := bus.Subscribe("test.event", handler); err != nil {
+		t.Fatalf("Expected no error subscribing, got %v", err)
+	}
+
+	ctx := context.Background()
+	if err := bus.Start(ctx); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+	defer bus.Stop(ctx)
+
+	queue := "go-templ-template.test.event"
+	if conns[0].consumerChannel(queue) == nil {
+		t.Fatal("Expected consumer on initial connection")
+	}
+
+	// Drop the connection and keep the broker unreachable for a while
+	failDial.Store(true)
+	conns[0].drop(&amqp.Error{Code: amqp.ConnectionForced, Reason: "CONNECTION_FORCED"})
+
+	// Health reports unhealthy for the whole reconnect window
+	waitFor(t, time.Second, func() bool {
+		err := bus.Health()
+		return err != nil && err.Error() == "RabbitMQ connection is reconnecting"
+	})
+
+	// Let the broker come back
+	failDial.Store(false)
+	waitFor(t, time.Second, func() bool { return connCount() == 2 && bus.Health() == nil })
+
+	connsMu.Lock()
+	restored := conns[1]
+	connsMu.Unlock()
+
+	consumer := restored.consumerChannel(queue)
+	if consumer == nil {
+		t.Fatal("Expected subscription to be re-registered on new connection")
+	}
+
+	waitFor(t, time.Second, func() bool {
+		observer.mu.Lock()
+		defer observer.mu.Unlock()
+		return observer.reconnects == 1
+	})
+
+	// Publishing resumes on the new connection
+	if err := bus.Publish(ctx, NewTestEvent("test-id", "test-data")); err != nil {
+		t.Fatalf("Expected no error publishing after reconnect, got %v", err)
+	}
+	if restored.publishChannel().publishedCount() != 1 {
+		t.Errorf("Expected publish on restored channel, got %d", restored.publishChannel().publishedCount())
+	}
+
+	// Consuming resumes on the new connection
+	ack := &recordingAcknowledger{}
+	consumer.deliver(queue, newTestDelivery(t, ack, NewTestEvent("test-id", "test-data")))
+	waitFor(t, time.Second, func() bool { return len(handler.GetHandledEvents()) == 1 })
+}
+
+func TestRabbitMQEventBus_StopDoesNotReconnect(t *testing.T) {
+	config := DefaultRabbitMQConfig()
+	config.ReconnectDelay = time.Millisecond
+	bus := NewRabbitMQEventBus(config)
+
+	var dials atomic.Int32
+	bus.dial = func(url string) (amqpConnection, error) {
+		dials.Add(1)
+		return &fakeConnection{}, nil
+	}
+
+	ctx := context.Background()
+	if err := bus.Start(ctx); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+
+	if err := bus.Stop(ctx); err != nil {
+		t.Fatalf("Expected no error stopping bus, got %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if dials.Load() != 1 {
+		t.Errorf("Expected no reconnect after stop, got %d dials", dials.Load())
+	}
+}
+
+// blockingHandler signals when it starts handling and finishes once released
+type blockingHandler struct {
+	started  chan struct{}
+	release  chan struct{}
+	finished atomic.Bool
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{started: make(chan struct{}, 1), release: make(chan struct{})}
+}
+
+func (h *blockingHandler) Handle(ctx context.Context, event DomainEvent) error {
+	h.started <- struct{}{}
+	<-h.release
+	h.finished.Store(true)
+	return nil
+}
+
+func (h *blockingHandler) EventType() string   { return "test.event" }
+func (h *blockingHandler) HandlerName() string { return "blocking-handler" }
+
+// startWithInFlightHandler starts a bus whose handler is busy with a delivery
+func startWithInFlightHandler(t *testing.T, ack amqp.Acknowledger) (*RabbitMQEventBus, *blockingHandler) {
+	t.Helper()
+
+	conn := &fakeConnection{}
+	bus := NewRabbitMQEventBus(DefaultRabbitMQConfig())
+	bus.dial = func(url string) (amqpConnection, error) { return conn, nil }
+
+	handler := newBlockingHandler()
+	if err := bus.Subscribe("test.event", handler); err != nil {
+		t.Fatalf("Expected no error subscribing, got %v", err)
+	}
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+
+	queue := "go-templ-template.test.event"
+	conn.consumerChannel(queue).deliver(queue, newTestDelivery(t, ack, NewTestEvent("test-id", "test-data")))
+
+	select {
+	case <-handler.started:
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for handler to start")
+	}
+	return bus, handler
+}
+
+func TestRabbitMQEventBus_StopWaitsForInFlightHandlers(t *testing.T) {
+	ack := &recordingAcknowledger{}
+	bus, handler := startWithInFlightHandler(t, ack)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		close(handler.release)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := bus.Stop(ctx); err != nil {
+		t.Fatalf("Expected no error stopping bus, got %v", err)
+	}
+
+	if !handler.finished.Load() {
+		t.Error("Expected in-flight handler to finish before Stop returned")
+	}
+	if ack.acks != 1 {
+		t.Errorf("Expected in-flight message to be acked, got %d acks", ack.acks)
+	}
+}
+
+func TestRabbitMQEventBus_StopTimesOutWithHandlersRunning(t *testing.T) {
+	bus, handler := startWithInFlightHandler(t, &recordingAcknowledger{})
+	defer close(handler.release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := bus.Stop(ctx)
+	if !errors.Is(err, ErrShutdownTimeout) {
+		t.Fatalf("Expected ErrShutdownTimeout, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected error to wrap the context deadline, got %v", err)
+	}
+	if handler.finished.Load() {
+		t.Error("Expected handler to still be running")
+	}
+
+	// Stopping again is a no-op
+	if err := bus.Stop(context.Background()); err != nil {
+		t.Errorf("Expected no error stopping again, got %v", err)
+	}
+}
+
+// concurrentHandler blocks every delivery until released, recording how many
+// run at once
+type concurrentHandler struct {
+	release     chan struct{}
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (h *concurrentHandler) Handle(ctx context.Context, event DomainEvent) error {
+	n := h.inFlight.Add(1)
+	for {
+		max := h.maxInFlight.Load()
+		if n <= max || h.maxInFlight.CompareAndSwap(max, n) {
+			break
+		}
+	}
+	<-h.release
+	h.inFlight.Add(-1)
+	return nil
+}
+
+func (h *concurrentHandler) EventType() string   { return "test.event" }
+func (h *concurrentHandler) HandlerName() string { return "concurrent-handler" }
+
+// countingAcknowledger counts acks from concurrent workers
+type countingAcknowledger struct {
+	acks atomic.Int32
+}
+
+func (a *countingAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acks.Add(1)
+	return nil
+}
+
+func (a *countingAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	return nil
+}
+
+func (a *countingAcknowledger) Reject(tag uint64, requeue bool) error {
+	return nil
+}
+
+func TestRabbitMQEventBus_ConsumerConcurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  func(*RabbitMQConfig)
+		workers int
+	}{
+		{"defaults to one", func(c *RabbitMQConfig) {}, 1},
+		{"bus default", func(c *RabbitMQConfig) { c.ConsumerConcurrency = 2 }, 2},
+		{"per event type", func(c *RabbitMQConfig) {
+			c.ConsumerConcurrency = 2
+			c.Concurrency = map[string]int{"test.event": 3, "other.event": 1}
+		}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultRabbitMQConfig()
+			tt.config(&config)
+			conn := &fakeConnection{}
+			bus := NewRabbitMQEventBus(config)
+			bus.dial = func(url string) (amqpConnection, error) { return conn, nil }
+
+			handler := &concurrentHandler{release: make(chan struct{})}
+			if err := bus.Subscribe("test.event", handler); err != nil {
+				t.Fatalf("Expected no error subscribing, got %v", err)
+			}
+			if err := bus.Start(context.Background()); err != nil {
+				t.Fatalf("Expected no error starting bus, got %v", err)
+			}
+
+			queue := "go-templ-template.test.event"
+			ch := conn.consumerChannel(queue)
+			if ch.prefetchCount() != tt.workers {
+				t.Errorf("Expected prefetch of %d, got %d", tt.workers, ch.prefetchCount())
+			}
+
+			// Deliver one more message than there are workers; the extra one
+			// can only be received once a worker is free
+			ack := &countingAcknowledger{}
+			var deliveries []amqp.Delivery
+			for i := 0; i <= tt.workers; i++ {
+				deliveries = append(deliveries, newTestDelivery(t, ack, NewTestEvent(fmt.Sprintf("test-%d", i), "test-data")))
+			}
+			delivered := make(chan struct{})
+			go func() {
+				defer close(delivered)
+				for _, delivery := range deliveries {
+					ch.deliver(queue, delivery)
+				}
+			}()
+
+			waitFor(t, time.Second, func() bool { return handler.inFlight.Load() == int32(tt.workers) })
+			select {
+			case <-delivered:
+				t.Fatal("Expected the extra delivery to wait for a free worker")
+			case <-time.After(20 * time.Millisecond):
+			}
+
+			close(handler.release)
+			<-delivered
+			waitFor(t, time.Second, func() bool { return ack.acks.Load() == int32(tt.workers+1) })
+
+			if max := handler.maxInFlight.Load(); max != int32(tt.workers) {
+				t.Errorf("Expected at most %d concurrent handler invocations, got %d", tt.workers, max)
+			}
+			if err := bus.Stop(context.Background()); err != nil {
+				t.Fatalf("Expected no error stopping bus, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRabbitMQConfig_ConcurrencyFor(t *testing.T) {
+	config := RabbitMQConfig{
+		ConsumerConcurrency: 4,
+		Concurrency:         map[string]int{"user.created": 1, "user.export_requested": 8, "user.deleted": 0},
+	}
+
+	tests := map[string]int{
+		"user.created":          1,
+		"user.export_requested": 8,
+		"user.deleted":          4,
+		"user.updated":          4,
+	}
+	for eventType, want := range tests {
+		if got := config.ConcurrencyFor(eventType); got != want {
+			t.Errorf("Expected concurrency %d for %s, got %d", want, eventType, got)
+		}
+	}
+
+	if got := (RabbitMQConfig{}).ConcurrencyFor("user.created"); got != 1 {
+		t.Errorf("Expected default concurrency of 1, got %d", got)
+	}
+}
+
+func TestRabbitMQEventBus_DedupKeyRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		event func() *BaseEvent
+		want  func(event *BaseEvent) string
+	}{
+		{
+			"custom key",
+			func() *BaseEvent {
+				return NewBaseEvent("test.event", "order-42", "Order", nil).WithDedupKey("order-42-paid")
+			},
+			func(event *BaseEvent) string { return "order-42-paid" },
+		},
+		{
+			"defaults to event ID",
+			func() *BaseEvent { return NewBaseEvent("test.event", "order-42", "Order", nil) },
+			func(event *BaseEvent) string { return event.EventID() },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := &fakeConnection{}
+			bus := NewRabbitMQEventBus(DefaultRabbitMQConfig())
+			bus.dial = func(url string) (amqpConnection, error) { return conn, nil }
+
+			handler := NewMockEventHandler("dedup-handler", "test.event")
+			if err := bus.Subscribe("test.event", handler); err != nil {
+				t.Fatalf("Expected no error subscribing, got %v", err)
+			}
+			if err := bus.Start(context.Background()); err != nil {
+				t.Fatalf("Expected no error starting bus, got %v", err)
+			}
+			defer bus.Stop(context.Background())
+
+			event := tt.event()
+			want := tt.want(event)
+			if err := bus.Publish(context.Background(), event); err != nil {
+				t.Fatalf("Expected no error publishing, got %v", err)
+			}
+
+			publishing := conn.publishChannel().published[0]
+			if publishing.Headers["dedup_key"] != want {
+				t.Errorf("Expected dedup_key header %q, got %v", want, publishing.Headers["dedup_key"])
+			}
+			var envelope SerializableEventEnvelope
+			if err := json.Unmarshal(publishing.Body, &envelope); err != nil {
+				t.Fatalf("Expected no error decoding envelope, got %v", err)
+			}
+			if envelope.DedupKey != want {
+				t.Errorf("Expected envelope dedup key %q, got %q", want, envelope.DedupKey)
+			}
+
+			// Consume the published message
+			queue := "go-templ-template.test.event"
+			conn.consumerChannel(queue).deliver(queue, amqp.Delivery{
+				Acknowledger: &recordingAcknowledger{},
+				MessageId:    publishing.MessageId,
+				Body:         publishing.Body,
+			})
+			waitFor(t, time.Second, func() bool { return len(handler.GetHandledEvents()) == 1 })
+
+			consumed := handler.GetHandledEvents()[0]
+			if got := DedupKey(consumed); got != want {
+				t.Errorf("Expected consumed dedup key %q, got %q", want, got)
+			}
+			if consumed.EventID() != event.EventID() {
+				t.Errorf("Expected consumed event ID %s, got %s", event.EventID(), consumed.EventID())
+			}
+		})
+	}
+}
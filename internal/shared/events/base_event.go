@@ -31,6 +31,7 @@ func NewBaseEvent(eventType, aggregateID, aggregateType string, data interface{}
 		metadata: EventMetadata{
 			CorrelationID: uuid.New().String(),
 			Source:        "go-templ-template",
+			SchemaVersion: 1,
 			Custom:        make(map[string]interface{}),
 		},
 	}
@@ -91,6 +92,23 @@ func (e *BaseEvent) SetCausationID(causationID string) {
 	e.metadata.CausationID = causationID
 }
 
+// CausedBy links the event to parent, the event whose handling raised it:
+// the parent's correlation ID is copied, so every event in a workflow shares
+// one, and the causation ID is set to the parent's event ID. It returns e so
+// the event can be published directly.
+func (e *BaseEvent) CausedBy(parent DomainEvent) DomainEvent {
+	if parent == nil {
+		return e
+	}
+
+	parentMetadata := parent.Metadata()
+	if parentMetadata.CorrelationID != "" {
+		e.metadata.CorrelationID = parentMetadata.CorrelationID
+	}
+	e.metadata.CausationID = parent.EventID()
+	return e
+}
+
 // SetUserID sets the user ID who triggered this event
 func (e *BaseEvent) SetUserID(userID string) {
 	e.metadata.UserID = userID
@@ -101,6 +119,27 @@ func (e *BaseEvent) SetTraceID(traceID string) {
 	e.metadata.TraceID = traceID
 }
 
+// SetSchemaVersion sets the version of the event payload shape
+func (e *BaseEvent) SetSchemaVersion(version int) {
+	e.metadata.SchemaVersion = version
+}
+
+// WithDedupKey sets the key consumers deduplicate the event by, such as an
+// order number, instead of its event ID
+func (e *BaseEvent) WithDedupKey(key string) *BaseEvent {
+	e.metadata.DedupKey = key
+	return e
+}
+
+// DedupKey returns the key event is deduplicated by: the key set with
+// WithDedupKey, or its event ID
+func DedupKey(event DomainEvent) string {
+	if key := event.Metadata().DedupKey; key != "" {
+		return key
+	}
+	return event.EventID()
+}
+
 // AddCustomMetadata adds custom metadata to the event
 func (e *BaseEvent) AddCustomMetadata(key string, value interface{}) {
 	if e.metadata.Custom == nil {
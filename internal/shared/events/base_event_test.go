@@ -0,0 +1,73 @@
+package events
+
+import "testing"
+
+func TestBaseEvent_CausedBy(t *testing.T) {
+	registered := NewBaseEvent("user.registered", "user-1", "User", nil)
+	registered.SetCorrelationID("corr-123")
+
+	activated := NewBaseEvent("user.activated", "user-1", "User", nil)
+	if got := activated.CausedBy(registered); got != activated {
+		t.Fatalf("Expected CausedBy to return the event itself")
+	}
+
+	welcomed := NewBaseEvent("notification.welcome_sent", "user-1", "User", nil)
+	welcomed.CausedBy(activated)
+
+	for _, event := range []DomainEvent{activated, welcomed} {
+		if got := event.Metadata().CorrelationID; got != "corr-123" {
+			t.Errorf("Expected %s to keep correlation ID corr-123, got %q", event.EventType(), got)
+		}
+	}
+
+	if got := activated.Metadata().CausationID; got != registered.EventID() {
+		t.Errorf("Expected activated to be caused by %s, got %q", registered.EventID(), got)
+	}
+	if got := welcomed.Metadata().CausationID; got != activated.EventID() {
+		t.Errorf("Expected welcomed to be caused by its immediate parent %s, got %q", activated.EventID(), got)
+	}
+}
+
+func TestBaseEvent_CausedByRequestScopedEvent(t *testing.T) {
+	parent := WithRequestScope(newRequestContext(), NewBaseEvent("user.registered", "user-1", "User", nil))
+
+	child := NewBaseEvent("user.activated", "user-1", "User", nil)
+	child.CausedBy(parent)
+
+	metadata := child.Metadata()
+	if metadata.CorrelationID != "req-123" {
+		t.Errorf("Expected correlation ID from the request, got %q", metadata.CorrelationID)
+	}
+	if metadata.CausationID != parent.EventID() {
+		t.Errorf("Expected causation ID %s, got %q", parent.EventID(), metadata.CausationID)
+	}
+}
+
+func TestBaseEvent_CausedByNil(t *testing.T) {
+	event := NewBaseEvent("user.activated", "user-1", "User", nil)
+	before := event.Metadata()
+
+	event.CausedBy(nil)
+
+	after := event.Metadata()
+	if after.CorrelationID != before.CorrelationID || after.CausationID != "" {
+		t.Errorf("Expected metadata unchanged, got %+v", after)
+	}
+}
+
+func TestDedupKey(t *testing.T) {
+	event := NewBaseEvent("order.paid", "order-42", "Order", nil)
+	if got := DedupKey(event); got != event.EventID() {
+		t.Errorf("Expected dedup key to default to the event ID %s, got %s", event.EventID(), got)
+	}
+
+	if returned := event.WithDedupKey("order-42-paid"); returned != event {
+		t.Error("Expected WithDedupKey to return the event")
+	}
+	if got := DedupKey(event); got != "order-42-paid" {
+		t.Errorf("Expected dedup key order-42-paid, got %s", got)
+	}
+	if got := event.Metadata().DedupKey; got != "order-42-paid" {
+		t.Errorf("Expected dedup key in metadata, got %q", got)
+	}
+}
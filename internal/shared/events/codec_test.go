@@ -0,0 +1,146 @@
+package events
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestCodecs_RoundTrip(t *testing.T) {
+	event := NewBaseEvent("test.event", "order-42", "Order", map[string]interface{}{
+		"total": 12.5,
+		"items": []interface{}{"book", map[string]interface{}{"sku": "pen", "qty": 2.0}},
+		"paid":  true,
+		"note":  nil,
+	})
+	event.metadata.Custom = map[string]interface{}{"channel": "web"}
+
+	envelope, err := NewSerializableEventEnvelope(event)
+	if err != nil {
+		t.Fatalf("Expected no error creating envelope, got %v", err)
+	}
+
+	for _, codec := range []Codec{JSONCodec{}, GobCodec{}} {
+		t.Run(codec.ContentType(), func(t *testing.T) {
+			data, err := codec.Encode(envelope)
+			if err != nil {
+				t.Fatalf("Expected no error encoding, got %v", err)
+			}
+
+			var decoded SerializableEventEnvelope
+			if err := codec.Decode(data, &decoded); err != nil {
+				t.Fatalf("Expected no error decoding, got %v", err)
+			}
+
+			if decoded.Event.EventID() != event.EventID() {
+				t.Errorf("Expected event ID %s, got %s", event.EventID(), decoded.Event.EventID())
+			}
+			if !decoded.Event.OccurredAt().Equal(event.OccurredAt()) {
+				t.Errorf("Expected occurred at %v, got %v", event.OccurredAt(), decoded.Event.OccurredAt())
+			}
+			if !reflect.DeepEqual(decoded.Event.Data, envelope.Event.Data) {
+				t.Errorf("Expected data %v, got %v", envelope.Event.Data, decoded.Event.Data)
+			}
+			if !reflect.DeepEqual(decoded.Event.Meta.Custom, envelope.Event.Meta.Custom) {
+				t.Errorf("Expected custom metadata %v, got %v", envelope.Event.Meta.Custom, decoded.Event.Meta.Custom)
+			}
+			if decoded.DedupKey != envelope.DedupKey || decoded.MaxRetry != envelope.MaxRetry {
+				t.Errorf("Expected envelope %+v, got %+v", envelope, decoded)
+			}
+		})
+	}
+}
+
+func TestCodecForContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        Codec
+	}{
+		{"", JSONCodec{}},
+		{"application/json", JSONCodec{}},
+		{"application/json; charset=utf-8", JSONCodec{}},
+		{"application/x-gob", GobCodec{}},
+	}
+
+	for _, tt := range tests {
+		codec, err := CodecForContentType(tt.contentType)
+		if err != nil {
+			t.Errorf("Expected no error for %q, got %v", tt.contentType, err)
+			continue
+		}
+		if codec != tt.want {
+			t.Errorf("Expected %T for %q, got %T", tt.want, tt.contentType, codec)
+		}
+	}
+
+	if _, err := CodecForContentType("application/msgpack"); err == nil {
+		t.Error("Expected an error for an unsupported content type")
+	}
+}
+
+func TestRabbitMQEventBus_Codec(t *testing.T) {
+	conn := &fakeConnection{}
+	config := DefaultRabbitMQConfig()
+	config.Codec = GobCodec{}
+	bus := NewRabbitMQEventBus(config)
+	bus.dial = func(url string) (amqpConnection, error) { return conn, nil }
+
+	handler := NewMockEventHandler("codec-handler", "test.event")
+	if err := bus.Subscribe("test.event", handler); err != nil {
+		t.Fatalf("Expected no error subscribing, got %v", err)
+	}
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+	defer bus.Stop(context.Background())
+
+	gobEvent := NewTestEvent("aggregate-1", "gob")
+	if err := bus.Publish(context.Background(), gobEvent); err != nil {
+		t.Fatalf("Expected no error publishing, got %v", err)
+	}
+	publishing := conn.publishChannel().published[0]
+	if publishing.ContentType != GobContentType {
+		t.Errorf("Expected content type %s, got %s", GobContentType, publishing.ContentType)
+	}
+
+	// A JSON message from a publisher still on the default codec
+	jsonEvent := NewTestEvent("aggregate-2", "json")
+	envelope, err := NewSerializableEventEnvelope(jsonEvent)
+	if err != nil {
+		t.Fatalf("Expected no error creating envelope, got %v", err)
+	}
+	jsonBody, err := JSONCodec{}.Encode(envelope)
+	if err != nil {
+		t.Fatalf("Expected no error encoding, got %v", err)
+	}
+
+	// The consumer decodes each message by its content type
+	queue := "go-templ-template.test.event"
+	conn.consumerChannel(queue).deliver(queue, amqp.Delivery{
+		Acknowledger: &recordingAcknowledger{},
+		ContentType:  publishing.ContentType,
+		MessageId:    publishing.MessageId,
+		Body:         publishing.Body,
+	})
+	conn.consumerChannel(queue).deliver(queue, amqp.Delivery{
+		Acknowledger: &recordingAcknowledger{},
+		ContentType:  JSONContentType,
+		MessageId:    jsonEvent.EventID(),
+		Body:         jsonBody,
+	})
+	waitFor(t, time.Second, func() bool { return len(handler.GetHandledEvents()) == 2 })
+
+	for i, want := range []*TestEvent{gobEvent, jsonEvent} {
+		consumed := handler.GetHandledEvents()[i]
+		if consumed.EventID() != want.EventID() {
+			t.Errorf("Expected consumed event ID %s, got %s", want.EventID(), consumed.EventID())
+		}
+		data, _ := consumed.EventData().(map[string]interface{})
+		if data["test_data"] != want.TestData {
+			t.Errorf("Expected test data %q, got %v", want.TestData, data["test_data"])
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package events
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GzipContentEncoding is the content encoding of gzip-compressed messages
+const GzipContentEncoding = "gzip"
+
+// DefaultCompressionThreshold is the encoded size in bytes above which
+// payloads are compressed when compression is enabled without a threshold
+const DefaultCompressionThreshold = 64 * 1024
+
+// compress gzips body
+func compress(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress reverses the content encoding of a message body; bodies
+// without an encoding are returned as they are
+func decompress(body []byte, contentEncoding string) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(contentEncoding)) {
+	case "", "identity":
+		return body, nil
+	case GzipContentEncoding:
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", contentEncoding)
+	}
+}
@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestRabbitMQEventBus_Compression(t *testing.T) {
+	conn := &fakeConnection{}
+	config := DefaultRabbitMQConfig()
+	config.Compression = true
+	config.CompressionThreshold = 1024
+	bus := NewRabbitMQEventBus(config)
+	bus.dial = func(url string) (amqpConnection, error) { return conn, nil }
+
+	handler := NewMockEventHandler("compression-handler", "test.event")
+	if err := bus.Subscribe("test.event", handler); err != nil {
+		t.Fatalf("Expected no error subscribing, got %v", err)
+	}
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+	defer bus.Stop(context.Background())
+
+	large := NewTestEvent("manifest-1", strings.Repeat("export-file.csv;", 1000))
+	small := NewTestEvent("manifest-2", "small")
+	for _, event := range []*TestEvent{large, small} {
+		if err := bus.Publish(context.Background(), event); err != nil {
+			t.Fatalf("Expected no error publishing, got %v", err)
+		}
+	}
+
+	published := conn.publishChannel().published
+	if published[0].ContentEncoding != GzipContentEncoding {
+		t.Errorf("Expected large payload encoding %q, got %q", GzipContentEncoding, published[0].ContentEncoding)
+	}
+	if len(published[0].Body) >= len(large.TestData) {
+		t.Errorf("Expected large payload compressed below %d bytes, got %d", len(large.TestData), len(published[0].Body))
+	}
+	if published[1].ContentEncoding != "" {
+		t.Errorf("Expected small payload without encoding, got %q", published[1].ContentEncoding)
+	}
+	var envelope SerializableEventEnvelope
+	if err := (JSONCodec{}).Decode(published[1].Body, &envelope); err != nil {
+		t.Errorf("Expected small payload to be plain JSON, got %v", err)
+	}
+
+	// The consumer decompresses by the content-encoding header
+	queue := "go-templ-template.test.event"
+	for _, publishing := range published {
+		conn.consumerChannel(queue).deliver(queue, amqp.Delivery{
+			Acknowledger:    &recordingAcknowledger{},
+			ContentType:     publishing.ContentType,
+			ContentEncoding: publishing.ContentEncoding,
+			MessageId:       publishing.MessageId,
+			Body:            publishing.Body,
+		})
+	}
+	waitFor(t, time.Second, func() bool { return len(handler.GetHandledEvents()) == 2 })
+
+	for i, want := range []*TestEvent{large, small} {
+		consumed := handler.GetHandledEvents()[i]
+		data, _ := consumed.EventData().(map[string]interface{})
+		if data["test_data"] != want.TestData {
+			t.Errorf("Expected event %s to round-trip its payload", want.EventID())
+		}
+	}
+}
+
+func TestDecompress(t *testing.T) {
+	body := []byte(`{"event":null}`)
+	compressed, err := compress(body)
+	if err != nil {
+		t.Fatalf("Expected no error compressing, got %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		body     []byte
+		encoding string
+		wantErr  bool
+	}{
+		{"no encoding", body, "", false},
+		{"gzip", compressed, "gzip", false},
+		{"gzip in capitals", compressed, "GZIP", false},
+		{"unsupported encoding", body, "br", true},
+		{"corrupt gzip", body, "gzip", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decompress(tt.body, tt.encoding)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if string(got) != string(body) {
+				t.Errorf("Expected %s, got %s", body, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go-templ-template/internal/shared/database"
+)
+
+// DegradedConfig holds configuration for a DegradedEventBus
+type DegradedConfig struct {
+	// RetryDelay is the initial wait before retrying to start the bus
+	RetryDelay time.Duration
+
+	// MaxRetryDelay caps the exponential backoff between start attempts
+	MaxRetryDelay time.Duration
+}
+
+// DefaultDegradedConfig returns a default configuration for degraded mode
+func DefaultDegradedConfig() DegradedConfig {
+	return DegradedConfig{
+		RetryDelay:    time.Second,
+		MaxRetryDelay: 30 * time.Second,
+	}
+}
+
+// DegradedEventBus wraps an EventBus so the application can run while the
+// broker is unavailable. If the wrapped bus fails to start, Start logs the
+// failure and succeeds, and the bus is retried in the background until it
+// starts. Until then the bus is degraded: Health returns an error wrapping
+// ErrEventBusDegraded, events published inside a transaction are buffered
+// when the wrapped bus is an OutboxEventBus, and other events are dropped,
+// logged and reported to the observer as failed publishes.
+type DegradedEventBus struct {
+	bus      EventBus
+	config   DegradedConfig
+	observer EventBusObserver
+	done     chan struct{}
+	wg       sync.WaitGroup
+	mu       sync.RWMutex
+	startErr error
+	stopped  bool
+}
+
+// NewDegradedEventBus creates a degraded mode wrapper around the given bus,
+// reporting dropped events to observer if it is not nil
+func NewDegradedEventBus(bus EventBus, config DegradedConfig, observer EventBusObserver) *DegradedEventBus {
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = DefaultDegradedConfig().RetryDelay
+	}
+	if config.MaxRetryDelay <= 0 {
+		config.MaxRetryDelay = DefaultDegradedConfig().MaxRetryDelay
+	}
+	if observer == nil {
+		observer = noopObserver{}
+	}
+
+	return &DegradedEventBus{
+		bus:      bus,
+		config:   config,
+		observer: observer,
+		done:     make(chan struct{}),
+	}
+}
+
+// Start starts the wrapped bus. If that fails the bus becomes degraded and
+// is retried in the background; Start itself only fails if ctx is done.
+func (d *DegradedEventBus) Start(ctx context.Context) error {
+	err := d.bus.Start(ctx)
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return err
+	}
+
+	log.Printf("Event bus unavailable, running degraded: %v", err)
+
+	d.mu.Lock()
+	d.startErr = err
+	d.mu.Unlock()
+
+	d.wg.Add(1)
+	go d.retry()
+	return nil
+}
+
+// retry starts the wrapped bus with exponential backoff until it starts or
+// the bus is stopped
+func (d *DegradedEventBus) retry() {
+	defer d.wg.Done()
+
+	backoff := RetryPolicy{
+		InitialDelay: d.config.RetryDelay,
+		MaxDelay:     d.config.MaxRetryDelay,
+		Multiplier:   2,
+	}
+
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-d.done:
+			return
+		case <-time.After(backoff.Delay(attempt)):
+		}
+
+		// Hold the lock so Stop can't stop the wrapped bus mid-start
+		d.mu.Lock()
+		if d.stopped {
+			d.mu.Unlock()
+			return
+		}
+		err := d.bus.Start(context.Background())
+		if err != nil {
+			d.startErr = err
+			d.mu.Unlock()
+			log.Printf("Event bus start attempt %d failed: %v", attempt, err)
+			continue
+		}
+		d.startErr = nil
+		d.mu.Unlock()
+
+		log.Printf("Event bus started after %d attempt(s), leaving degraded mode", attempt)
+		d.observer.OnReconnect()
+		return
+	}
+}
+
+// Degraded reports whether the wrapped bus has yet to start
+func (d *DegradedEventBus) Degraded() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.startErr != nil
+}
+
+// Publish publishes through the wrapped bus, or while degraded buffers the
+// event in the outbox if possible and otherwise drops it
+func (d *DegradedEventBus) Publish(ctx context.Context, event DomainEvent) error {
+	if !d.Degraded() || d.buffers(ctx) {
+		return d.bus.Publish(ctx, event)
+	}
+
+	log.Printf("Event bus degraded, dropping event %s [%s]", event.EventType(), event.EventID())
+	d.observer.OnPublish(event.EventType(), ErrEventBusDegraded)
+	return nil
+}
+
+// buffers reports whether the wrapped bus stores an event published with
+// ctx for later delivery instead of sending it to the broker
+func (d *DegradedEventBus) buffers(ctx context.Context) bool {
+	return d.PublishesInTransaction() && database.GetTxFromContext(ctx) != nil
+}
+
+// PublishesInTransaction reports whether the wrapped bus writes events
+// published inside a transaction in it, which it keeps doing while degraded
+func (d *DegradedEventBus) PublishesInTransaction() bool {
+	publisher, ok := d.bus.(TransactionalPublisher)
+	return ok && publisher.PublishesInTransaction()
+}
+
+// Subscribe registers an event handler on the wrapped bus
+func (d *DegradedEventBus) Subscribe(eventType string, handler EventHandler) error {
+	return d.bus.Subscribe(eventType, handler)
+}
+
+// Unsubscribe removes an event handler from the wrapped bus
+func (d *DegradedEventBus) Unsubscribe(eventType string, handler EventHandler) error {
+	return d.bus.Unsubscribe(eventType, handler)
+}
+
+// Subscriptions returns the subscriptions of the wrapped bus, or nil if it
+// cannot report them
+func (d *DegradedEventBus) Subscriptions() map[string][]string {
+	if lister, ok := d.bus.(SubscriptionLister); ok {
+		return lister.Subscriptions()
+	}
+	return nil
+}
+
+// Stop stops retrying and stops the wrapped bus
+func (d *DegradedEventBus) Stop(ctx context.Context) error {
+	d.mu.Lock()
+	if d.stopped {
+		d.mu.Unlock()
+		return nil
+	}
+	d.stopped = true
+	close(d.done)
+	d.mu.Unlock()
+
+	d.wg.Wait()
+	return d.bus.Stop(ctx)
+}
+
+// Health returns an error wrapping ErrEventBusDegraded while degraded, and
+// otherwise the health of the wrapped bus
+func (d *DegradedEventBus) Health() error {
+	d.mu.RLock()
+	startErr := d.startErr
+	d.mu.RUnlock()
+
+	if startErr != nil {
+		return fmt.Errorf("%w: %v", ErrEventBusDegraded, startErr)
+	}
+	return d.bus.Health()
+}
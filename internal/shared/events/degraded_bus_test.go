@@ -0,0 +1,154 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// unavailableBus is an in-memory bus whose Start fails until it has been
+// attempted more than failures times, simulating a broker that is down
+type unavailableBus struct {
+	*InMemoryEventBus
+	mu       sync.Mutex
+	failures int
+	attempts int
+}
+
+func newUnavailableBus(failures int) *unavailableBus {
+	return &unavailableBus{InMemoryEventBus: NewInMemoryEventBus(), failures: failures}
+}
+
+func (b *unavailableBus) Start(ctx context.Context) error {
+	b.mu.Lock()
+	b.attempts++
+	fail := b.attempts <= b.failures
+	b.mu.Unlock()
+
+	if fail {
+		return errors.New("connection refused")
+	}
+	return b.InMemoryEventBus.Start(ctx)
+}
+
+func (b *unavailableBus) Attempts() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.attempts
+}
+
+var fastRetry = DegradedConfig{RetryDelay: time.Millisecond, MaxRetryDelay: 5 * time.Millisecond}
+
+func TestDegradedEventBus_StartsWhenBusAvailable(t *testing.T) {
+	inner := newUnavailableBus(0)
+	bus := NewDegradedEventBus(inner, fastRetry, nil)
+
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+	defer bus.Stop(context.Background())
+
+	if bus.Degraded() {
+		t.Error("Expected bus not to be degraded")
+	}
+	if err := bus.Health(); err != nil {
+		t.Errorf("Expected healthy bus, got %v", err)
+	}
+}
+
+func TestDegradedEventBus_DropsEventsWhileUnavailable(t *testing.T) {
+	inner := newUnavailableBus(1 << 30)
+	handler := NewMockEventHandler("degraded-handler", "test.event")
+	inner.Subscribe("test.event", handler)
+	observer := &recordingObserver{}
+
+	bus := NewDegradedEventBus(inner, DegradedConfig{RetryDelay: time.Hour}, observer)
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected degraded start to succeed, got %v", err)
+	}
+	defer bus.Stop(context.Background())
+
+	if !bus.Degraded() {
+		t.Fatal("Expected bus to be degraded")
+	}
+	if err := bus.Health(); !errors.Is(err, ErrEventBusDegraded) {
+		t.Errorf("Expected ErrEventBusDegraded from Health, got %v", err)
+	}
+
+	if err := bus.Publish(context.Background(), NewTestEvent("test-id", "test-data")); err != nil {
+		t.Fatalf("Expected dropped publish to succeed, got %v", err)
+	}
+	if len(handler.GetHandledEvents()) != 0 {
+		t.Errorf("Expected event to be dropped, got %d handled", len(handler.GetHandledEvents()))
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if len(observer.publishes) != 1 || !errors.Is(observer.publishes[0].err, ErrEventBusDegraded) {
+		t.Errorf("Expected one degraded publish to be observed, got %+v", observer.publishes)
+	}
+}
+
+func TestDegradedEventBus_RecoversInBackground(t *testing.T) {
+	inner := newUnavailableBus(2)
+	handler := NewMockEventHandler("recovered-handler", "test.event")
+	observer := &recordingObserver{}
+
+	bus := NewDegradedEventBus(inner, fastRetry, observer)
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected degraded start to succeed, got %v", err)
+	}
+	defer bus.Stop(context.Background())
+
+	// Subscriptions made while degraded take effect once the bus starts
+	if err := bus.Subscribe("test.event", handler); err != nil {
+		t.Fatalf("Expected no error subscribing, got %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for bus.Degraded() {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected bus to leave degraded mode")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if inner.Attempts() != 3 {
+		t.Errorf("Expected 3 start attempts, got %d", inner.Attempts())
+	}
+	if err := bus.Health(); err != nil {
+		t.Errorf("Expected healthy bus after recovery, got %v", err)
+	}
+	if err := bus.Publish(context.Background(), NewTestEvent("test-id", "test-data")); err != nil {
+		t.Fatalf("Expected no error publishing, got %v", err)
+	}
+	if len(handler.GetHandledEvents()) != 1 {
+		t.Errorf("Expected event to be delivered after recovery, got %d", len(handler.GetHandledEvents()))
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if observer.reconnects != 1 {
+		t.Errorf("Expected one reconnect to be observed, got %d", observer.reconnects)
+	}
+}
+
+func TestDegradedEventBus_StopEndsRetries(t *testing.T) {
+	inner := newUnavailableBus(1 << 30)
+	bus := NewDegradedEventBus(inner, fastRetry, nil)
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected degraded start to succeed, got %v", err)
+	}
+
+	if err := bus.Stop(context.Background()); err != nil {
+		t.Fatalf("Expected no error stopping bus, got %v", err)
+	}
+
+	attempts := inner.Attempts()
+	time.Sleep(20 * time.Millisecond)
+	if inner.Attempts() != attempts {
+		t.Errorf("Expected no start attempts after Stop, got %d more", inner.Attempts()-attempts)
+	}
+}
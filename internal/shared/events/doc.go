@@ -11,6 +11,8 @@
 // - EventHandler: Interface for processing domain events
 // - BaseEvent: Common implementation for domain events
 // - BaseEventHandler: Common implementation for event handlers
+// - RetryingHandler: Decorator that retries retryable handler errors with backoff
+// - OutboxEventBus: Transactional outbox that publishes events after commit
 //
 // Usage:
 //
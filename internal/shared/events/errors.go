@@ -14,6 +14,10 @@ var (
 	ErrInvalidEvent           = errors.New("invalid event")
 	ErrEventPublishFailed     = errors.New("failed to publish event")
 	ErrEventHandlingFailed    = errors.New("failed to handle event")
+	ErrShutdownTimeout        = errors.New("event bus stopped with handlers still running")
+	ErrEventNacked            = errors.New("broker did not accept the event")
+	ErrEventUnroutable        = errors.New("event was not routed to any queue")
+	ErrEventBusDegraded       = errors.New("event bus is degraded")
 )
 
 // EventError represents an error that occurred during event processing
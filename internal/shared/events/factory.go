@@ -3,6 +3,7 @@ package events
 import (
 	"fmt"
 	"os"
+	"strconv"
 )
 
 // EventBusFactory creates event bus instances based on configuration
@@ -24,6 +25,11 @@ func (f *EventBusFactory) CreateRabbitMQEventBusFromEnv() EventBus {
 	return NewRabbitMQEventBus(config)
 }
 
+// CreateInMemoryEventBus creates an in-memory event bus that dispatches events synchronously
+func (f *EventBusFactory) CreateInMemoryEventBus() EventBus {
+	return NewInMemoryEventBus()
+}
+
 // RabbitMQConfigFromEnv creates RabbitMQ configuration from environment variables
 func RabbitMQConfigFromEnv() RabbitMQConfig {
 	config := DefaultRabbitMQConfig()
@@ -61,6 +67,32 @@ func RabbitMQConfigFromEnv() RabbitMQConfig {
 		config.NoWait = noWait == "true"
 	}
 
+	if dlx := os.Getenv("RABBITMQ_DEAD_LETTER_EXCHANGE"); dlx != "" {
+		config.DeadLetterExchange = dlx
+	}
+
+	if attempts := os.Getenv("RABBITMQ_MAX_DELIVERY_ATTEMPTS"); attempts != "" {
+		if parsed, err := strconv.Atoi(attempts); err == nil {
+			config.MaxDeliveryAttempts = parsed
+		}
+	}
+
+	if compression := os.Getenv("RABBITMQ_COMPRESSION"); compression != "" {
+		config.Compression = compression == "true"
+	}
+
+	if threshold := os.Getenv("RABBITMQ_COMPRESSION_THRESHOLD"); threshold != "" {
+		if parsed, err := strconv.Atoi(threshold); err == nil {
+			config.CompressionThreshold = parsed
+		}
+	}
+
+	if name := os.Getenv("RABBITMQ_CODEC"); name != "" {
+		if codec, err := CodecByName(name); err == nil {
+			config.Codec = codec
+		}
+	}
+
 	return config
 }
 
@@ -94,5 +126,13 @@ func ValidateRabbitMQConfig(config RabbitMQConfig) error {
 		return fmt.Errorf("invalid exchange type: %s. Must be one of: direct, fanout, topic, headers", config.ExchangeType)
 	}
 
+	if config.MaxDeliveryAttempts < 0 {
+		return fmt.Errorf("RabbitMQ max delivery attempts must not be negative")
+	}
+
+	if config.DeadLetterExchange != "" && config.DeadLetterExchange == config.Exchange {
+		return fmt.Errorf("RabbitMQ dead letter exchange must differ from the main exchange")
+	}
+
 	return nil
 }
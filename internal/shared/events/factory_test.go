@@ -170,3 +170,19 @@ func TestValidateRabbitMQConfig_ValidExchangeTypes(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRabbitMQConfig_DeadLetterExchange(t *testing.T) {
+	config := DefaultRabbitMQConfig()
+	config.DeadLetterExchange = config.Exchange
+
+	if err := ValidateRabbitMQConfig(config); err == nil {
+		t.Error("Expected error when dead letter exchange equals main exchange")
+	}
+
+	config.DeadLetterExchange = "events.dlx"
+	config.MaxDeliveryAttempts = -1
+
+	if err := ValidateRabbitMQConfig(config); err == nil {
+		t.Error("Expected error for negative max delivery attempts")
+	}
+}
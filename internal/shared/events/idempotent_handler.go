@@ -0,0 +1,135 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultProcessedEventRetention is how long processed events are remembered
+// unless a store is configured otherwise. Redeliveries happen within minutes,
+// so a day leaves plenty of margin.
+const DefaultProcessedEventRetention = 24 * time.Hour
+
+// ProcessedEventStore records which events each handler has processed, so
+// redelivered events can be recognised. Events are identified by their
+// DedupKey.
+type ProcessedEventStore interface {
+	// IsProcessed reports whether the handler processed the event with key
+	// within the store's retention window
+	IsProcessed(ctx context.Context, handlerName, key string) (bool, error)
+
+	// MarkProcessed records that the handler processed the event with key
+	MarkProcessed(ctx context.Context, handlerName, key string) error
+}
+
+// IdempotentHandler wraps an EventHandler and skips events it has already
+// processed, so at-least-once delivery doesn't write duplicate audit rows or
+// send duplicate emails. Events are recorded by DedupKey per handler name
+// once the wrapped handler succeeds; a redelivery racing the first delivery
+// may still run twice. It keeps the wrapped handler's name and event type so
+// it can replace it transparently.
+type IdempotentHandler struct {
+	handler EventHandler
+	store   ProcessedEventStore
+}
+
+// NewIdempotentHandler creates a handler recording processed events in store
+func NewIdempotentHandler(handler EventHandler, store ProcessedEventStore) *IdempotentHandler {
+	return &IdempotentHandler{
+		handler: handler,
+		store:   store,
+	}
+}
+
+// Handle invokes the wrapped handler unless it already processed the event
+func (h *IdempotentHandler) Handle(ctx context.Context, event DomainEvent) error {
+	key := DedupKey(event)
+	processed, err := h.store.IsProcessed(ctx, h.handler.HandlerName(), key)
+	if err != nil {
+		return fmt.Errorf("failed to check whether event %s was processed: %w", key, err)
+	}
+	if processed {
+		log.Printf("Handler %s already processed event %s, skipping", h.handler.HandlerName(), key)
+		return nil
+	}
+
+	if err := h.handler.Handle(ctx, event); err != nil {
+		return err
+	}
+
+	// The event was handled; failing now would only cause a redelivery that
+	// repeats it, so a failure to record it is logged instead
+	if err := h.store.MarkProcessed(ctx, h.handler.HandlerName(), key); err != nil {
+		log.Printf("Failed to record event %s as processed by %s: %v", key, h.handler.HandlerName(), err)
+	}
+	return nil
+}
+
+// EventType returns the type of event this handler processes
+func (h *IdempotentHandler) EventType() string {
+	return h.handler.EventType()
+}
+
+// HandlerName returns the name of the wrapped handler
+func (h *IdempotentHandler) HandlerName() string {
+	return h.handler.HandlerName()
+}
+
+// MemoryProcessedEventStore is a ProcessedEventStore kept in memory, for
+// tests and single-process deployments using the in-memory event bus
+type MemoryProcessedEventStore struct {
+	retention time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	processed map[processedEventKey]time.Time
+}
+
+// processedEventKey identifies an event processed by a handler
+type processedEventKey struct {
+	handlerName string
+	key         string
+}
+
+// NewMemoryProcessedEventStore creates an in-memory store remembering
+// processed events for retention; zero uses DefaultProcessedEventRetention
+func NewMemoryProcessedEventStore(retention time.Duration) *MemoryProcessedEventStore {
+	if retention <= 0 {
+		retention = DefaultProcessedEventRetention
+	}
+
+	return &MemoryProcessedEventStore{
+		retention: retention,
+		now:       time.Now,
+		processed: make(map[processedEventKey]time.Time),
+	}
+}
+
+// IsProcessed reports whether the handler processed the event within the
+// retention window
+func (s *MemoryProcessedEventStore) IsProcessed(ctx context.Context, handlerName, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	processedAt, ok := s.processed[processedEventKey{handlerName, key}]
+	return ok && s.now().Sub(processedAt) < s.retention, nil
+}
+
+// MarkProcessed records that the handler processed the event, forgetting
+// events older than the retention window
+func (s *MemoryProcessedEventStore) MarkProcessed(ctx context.Context, handlerName, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for stored, processedAt := range s.processed {
+		if now.Sub(processedAt) >= s.retention {
+			delete(s.processed, stored)
+		}
+	}
+	s.processed[processedEventKey{handlerName, key}] = now
+	return nil
+}
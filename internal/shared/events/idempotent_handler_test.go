@@ -0,0 +1,174 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// failingProcessedEventStore fails every lookup or every write
+type failingProcessedEventStore struct {
+	isProcessedErr   error
+	markProcessedErr error
+}
+
+func (s failingProcessedEventStore) IsProcessed(ctx context.Context, handlerName, key string) (bool, error) {
+	return false, s.isProcessedErr
+}
+
+func (s failingProcessedEventStore) MarkProcessed(ctx context.Context, handlerName, key string) error {
+	return s.markProcessedErr
+}
+
+func TestIdempotentHandler_SkipsRedeliveredEvent(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryProcessedEventStore(time.Hour)
+	inner := newScriptedHandler()
+	handler := NewIdempotentHandler(inner, store)
+	event := NewBaseEvent("test.event", "agg-1", "Test", nil)
+
+	if err := handler.Handle(ctx, event); err != nil {
+		t.Fatalf("first delivery: unexpected error: %v", err)
+	}
+	if inner.calls != 1 {
+		t.Fatalf("expected first delivery to run the handler, got %d calls", inner.calls)
+	}
+	processed, err := store.IsProcessed(ctx, "scripted-handler", event.EventID())
+	if err != nil || !processed {
+		t.Fatalf("expected event to be recorded as processed, got %v, %v", processed, err)
+	}
+
+	if err := handler.Handle(ctx, event); err != nil {
+		t.Fatalf("redelivery: unexpected error: %v", err)
+	}
+	if inner.calls != 1 {
+		t.Errorf("expected redelivery to be skipped, got %d calls", inner.calls)
+	}
+
+	other := NewBaseEvent("test.event", "agg-1", "Test", nil)
+	if err := handler.Handle(ctx, other); err != nil {
+		t.Fatalf("other event: unexpected error: %v", err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("expected a different event to run the handler, got %d calls", inner.calls)
+	}
+}
+
+func TestIdempotentHandler_KeysOnDedupKey(t *testing.T) {
+	ctx := context.Background()
+	inner := newScriptedHandler()
+	handler := NewIdempotentHandler(inner, NewMemoryProcessedEventStore(time.Hour))
+
+	// The same business fact published twice under different event IDs
+	first := NewBaseEvent("test.event", "order-42", "Order", nil).WithDedupKey("order-42-paid")
+	second := NewBaseEvent("test.event", "order-42", "Order", nil).WithDedupKey("order-42-paid")
+
+	for _, event := range []DomainEvent{first, second} {
+		if err := handler.Handle(ctx, event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if inner.calls != 1 {
+		t.Errorf("expected events sharing a dedup key to run the handler once, got %d calls", inner.calls)
+	}
+}
+
+func TestIdempotentHandler_FailedEventIsNotRecorded(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryProcessedEventStore(time.Hour)
+	inner := newScriptedHandler(errors.New("boom"))
+	handler := NewIdempotentHandler(inner, store)
+	event := NewBaseEvent("test.event", "agg-1", "Test", nil)
+
+	if err := handler.Handle(ctx, event); err == nil {
+		t.Fatal("expected the handler's error")
+	}
+	if err := handler.Handle(ctx, event); err != nil {
+		t.Fatalf("retry: unexpected error: %v", err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("expected the failed event to run again, got %d calls", inner.calls)
+	}
+}
+
+func TestIdempotentHandler_StoreErrors(t *testing.T) {
+	ctx := context.Background()
+	event := NewBaseEvent("test.event", "agg-1", "Test", nil)
+
+	inner := newScriptedHandler()
+	handler := NewIdempotentHandler(inner, failingProcessedEventStore{isProcessedErr: errors.New("db down")})
+	if err := handler.Handle(ctx, event); err == nil {
+		t.Error("expected an error when the store cannot be queried")
+	}
+	if inner.calls != 0 {
+		t.Errorf("expected the handler not to run, got %d calls", inner.calls)
+	}
+
+	inner = newScriptedHandler()
+	handler = NewIdempotentHandler(inner, failingProcessedEventStore{markProcessedErr: errors.New("db down")})
+	if err := handler.Handle(ctx, event); err != nil {
+		t.Errorf("expected a failure to record the event to be ignored, got %v", err)
+	}
+	if inner.calls != 1 {
+		t.Errorf("expected the handler to run once, got %d calls", inner.calls)
+	}
+}
+
+func TestIdempotentHandler_KeepsHandlerIdentity(t *testing.T) {
+	handler := NewIdempotentHandler(newScriptedHandler(), NewMemoryProcessedEventStore(0))
+
+	if handler.EventType() != "test.event" {
+		t.Errorf("expected event type test.event, got %s", handler.EventType())
+	}
+	if handler.HandlerName() != "scripted-handler" {
+		t.Errorf("expected handler name scripted-handler, got %s", handler.HandlerName())
+	}
+}
+
+func TestMemoryProcessedEventStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryProcessedEventStore(time.Hour)
+	store.now = func() time.Time { return now }
+
+	if err := store.MarkProcessed(ctx, "handler-a", "event-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		handlerName string
+		key         string
+		elapsed     time.Duration
+		want        bool
+	}{
+		{"same handler and event", "handler-a", "event-1", 0, true},
+		{"other handler", "handler-b", "event-1", 0, false},
+		{"other event", "handler-a", "event-2", 0, false},
+		{"within retention", "handler-a", "event-1", 59 * time.Minute, true},
+		{"past retention", "handler-a", "event-1", time.Hour, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store.now = func() time.Time { return now.Add(tt.elapsed) }
+
+			got, err := store.IsProcessed(ctx, tt.handlerName, tt.key)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	store.now = func() time.Time { return now.Add(2 * time.Hour) }
+	if err := store.MarkProcessed(ctx, "handler-a", "event-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.processed) != 1 {
+		t.Errorf("expected expired records to be pruned, got %d records", len(store.processed))
+	}
+}
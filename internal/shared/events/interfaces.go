@@ -26,6 +26,23 @@ type EventBus interface {
 	Health() error
 }
 
+// SubscriptionLister is implemented by event buses that can report which
+// handlers are subscribed, for operational introspection
+type SubscriptionLister interface {
+	// Subscriptions returns the names of the handlers subscribed to each
+	// event type or topic pattern, in subscription order
+	Subscriptions() map[string][]string
+}
+
+// TransactionalPublisher is implemented by event buses that can write an
+// event published inside a database transaction into that transaction, so
+// it is only delivered if the transaction commits
+type TransactionalPublisher interface {
+	// PublishesInTransaction reports whether events published with a
+	// transaction in their context are written in that transaction
+	PublishesInTransaction() bool
+}
+
 // DomainEvent represents a domain event that occurred in the system
 type DomainEvent interface {
 	// EventType returns the type identifier for this event
@@ -82,6 +99,15 @@ type EventMetadata struct {
 	// TraceID for distributed tracing
 	TraceID string `json:"trace_id,omitempty"`
 
+	// SchemaVersion is the version of the event payload shape; consumers
+	// upcast older payloads with an EventUpgraderRegistry
+	SchemaVersion int `json:"schema_version,omitempty"`
+
+	// DedupKey identifies the event for deduplication when the same
+	// business fact may be published more than once under different event
+	// IDs. Empty means the event ID; read it with DedupKey.
+	DedupKey string `json:"dedup_key,omitempty"`
+
 	// Additional custom metadata
 	Custom map[string]interface{} `json:"custom,omitempty"`
 }
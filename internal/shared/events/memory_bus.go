@@ -0,0 +1,241 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// InMemoryEventBus implements EventBus by dispatching events synchronously to
+// subscribed handlers in the publishing goroutine. It is intended for tests
+// and single-process deployments that do not need a message broker.
+type InMemoryEventBus struct {
+	handlers    map[string][]EventHandler
+	patterns    []string
+	handlersMux sync.RWMutex
+	started     bool
+	startedMux  sync.RWMutex
+	observer    EventBusObserver
+}
+
+// NewInMemoryEventBus creates a new in-memory event bus
+func NewInMemoryEventBus() *InMemoryEventBus {
+	return &InMemoryEventBus{
+		handlers: make(map[string][]EventHandler),
+		observer: noopObserver{},
+	}
+}
+
+// WithObserver notifies observer of publishes and handler executions
+func (b *InMemoryEventBus) WithObserver(observer EventBusObserver) *InMemoryEventBus {
+	if observer == nil {
+		observer = noopObserver{}
+	}
+	b.observer = observer
+	return b
+}
+
+// Start marks the event bus as ready to publish events
+func (b *InMemoryEventBus) Start(ctx context.Context) error {
+	b.startedMux.Lock()
+	defer b.startedMux.Unlock()
+
+	if b.started {
+		return ErrEventBusAlreadyStarted
+	}
+	b.started = true
+
+	log.Println("In-memory EventBus started")
+	return nil
+}
+
+// Stop marks the event bus as stopped
+func (b *InMemoryEventBus) Stop(ctx context.Context) error {
+	b.startedMux.Lock()
+	defer b.startedMux.Unlock()
+
+	if !b.started {
+		return nil // Already stopped
+	}
+	b.started = false
+
+	log.Println("In-memory EventBus stopped")
+	return nil
+}
+
+// Publish dispatches an event to every handler whose subscription matches the
+// event type. All handlers are invoked even if some fail; their errors are
+// joined into the returned error.
+func (b *InMemoryEventBus) Publish(ctx context.Context, event DomainEvent) error {
+	if event == nil {
+		return ErrInvalidEvent
+	}
+
+	err := b.publish(ctx, event)
+	b.observer.OnPublish(event.EventType(), err)
+	return err
+}
+
+// publish dispatches an event to its handlers
+func (b *InMemoryEventBus) publish(ctx context.Context, event DomainEvent) error {
+	b.startedMux.RLock()
+	started := b.started
+	b.startedMux.RUnlock()
+	if !started {
+		return ErrEventBusNotStarted
+	}
+
+	ctx, span := startPublishSpan(ctx, event)
+	defer span.End()
+	event = WithRequestScope(ctx, event)
+
+	var errs []error
+	for _, handler := range b.matchingHandlers(event.EventType()) {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		start := time.Now()
+		err := handleWithSpan(ctx, handler, event)
+		b.observer.OnHandle(event.EventType(), handler.HandlerName(), time.Since(start), err)
+		if err != nil {
+			log.Printf("Handler %s failed to process event %s: %v",
+				handler.HandlerName(), event.EventType(), err)
+			errs = append(errs, NewEventError(event.EventID(), event.EventType(), handler.HandlerName(), err))
+		}
+	}
+
+	if len(errs) > 0 {
+		err := NewPublishError(event, errors.Join(errs...))
+		span.RecordError(err)
+		return err
+	}
+	return nil
+}
+
+// Subscribe registers an event handler for an event type or topic pattern.
+// Patterns follow topic exchange rules: "*" matches exactly one word and "#"
+// matches zero or more words, so "user.*" matches "user.created".
+func (b *InMemoryEventBus) Subscribe(eventType string, handler EventHandler) error {
+	b.handlersMux.Lock()
+	defer b.handlersMux.Unlock()
+
+	for _, h := range b.handlers[eventType] {
+		if h.HandlerName() == handler.HandlerName() {
+			return ErrHandlerAlreadyExists
+		}
+	}
+
+	if _, exists := b.handlers[eventType]; !exists {
+		b.patterns = append(b.patterns, eventType)
+	}
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+	return nil
+}
+
+// Unsubscribe removes an event handler for a specific event type or topic pattern
+func (b *InMemoryEventBus) Unsubscribe(eventType string, handler EventHandler) error {
+	b.handlersMux.Lock()
+	defer b.handlersMux.Unlock()
+
+	handlers, exists := b.handlers[eventType]
+	if !exists {
+		return nil // Nothing to unsubscribe
+	}
+
+	for i, h := range handlers {
+		if h.HandlerName() == handler.HandlerName() {
+			b.handlers[eventType] = append(handlers[:i:i], handlers[i+1:]...)
+			break
+		}
+	}
+
+	if len(b.handlers[eventType]) == 0 {
+		delete(b.handlers, eventType)
+		for i, pattern := range b.patterns {
+			if pattern == eventType {
+				b.patterns = append(b.patterns[:i:i], b.patterns[i+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// Health reports whether the event bus has been started
+func (b *InMemoryEventBus) Health() error {
+	b.startedMux.RLock()
+	defer b.startedMux.RUnlock()
+
+	if !b.started {
+		return ErrEventBusNotStarted
+	}
+	return nil
+}
+
+// Subscriptions returns the names of the handlers subscribed to each event
+// type or topic pattern
+func (b *InMemoryEventBus) Subscriptions() map[string][]string {
+	b.handlersMux.RLock()
+	defer b.handlersMux.RUnlock()
+
+	return handlerNames(b.handlers)
+}
+
+// matchingHandlers returns a copy of the handlers subscribed to patterns that
+// match the event type, in subscription order
+func (b *InMemoryEventBus) matchingHandlers(eventType string) []EventHandler {
+	b.handlersMux.RLock()
+	defer b.handlersMux.RUnlock()
+
+	var matched []EventHandler
+	for _, pattern := range b.patterns {
+		if MatchTopic(pattern, eventType) {
+			matched = append(matched, b.handlers[pattern]...)
+		}
+	}
+	return matched
+}
+
+// handlerNames returns the names of the handlers in each list, keyed like
+// handlers
+func handlerNames(handlers map[string][]EventHandler) map[string][]string {
+	names := make(map[string][]string, len(handlers))
+	for eventType, subscribed := range handlers {
+		for _, handler := range subscribed {
+			names[eventType] = append(names[eventType], handler.HandlerName())
+		}
+	}
+	return names
+}
+
+// MatchTopic reports whether an event type matches a topic pattern using the
+// same rules as a RabbitMQ topic exchange binding key
+func MatchTopic(pattern, eventType string) bool {
+	return matchWords(strings.Split(pattern, "."), strings.Split(eventType, "."))
+}
+
+// matchWords matches dot-separated pattern words against event type words
+func matchWords(pattern, words []string) bool {
+	if len(pattern) == 0 {
+		return len(words) == 0
+	}
+
+	switch pattern[0] {
+	case "#":
+		// "#" consumes zero or more words
+		for i := 0; i <= len(words); i++ {
+			if matchWords(pattern[1:], words[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(words) > 0 && matchWords(pattern[1:], words[1:])
+	default:
+		return len(words) > 0 && pattern[0] == words[0] && matchWords(pattern[1:], words[1:])
+	}
+}
@@ -0,0 +1,237 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newStartedInMemoryEventBus(t *testing.T) *InMemoryEventBus {
+	t.Helper()
+
+	bus := NewInMemoryEventBus()
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+	return bus
+}
+
+func TestInMemoryEventBus_ImplementsEventBus(t *testing.T) {
+	var _ EventBus = NewInMemoryEventBus()
+}
+
+func TestInMemoryEventBus_Lifecycle(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	ctx := context.Background()
+
+	if err := bus.Health(); !errors.Is(err, ErrEventBusNotStarted) {
+		t.Errorf("Expected not started health error, got %v", err)
+	}
+
+	if err := bus.Publish(ctx, NewTestEvent("test-id", "test-data")); !errors.Is(err, ErrEventBusNotStarted) {
+		t.Errorf("Expected not started publish error, got %v", err)
+	}
+
+	if err := bus.Start(ctx); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+
+	if err := bus.Start(ctx); !errors.Is(err, ErrEventBusAlreadyStarted) {
+		t.Errorf("Expected already started error, got %v", err)
+	}
+
+	if err := bus.Health(); err != nil {
+		t.Errorf("Expected healthy bus, got %v", err)
+	}
+
+	if err := bus.Stop(ctx); err != nil {
+		t.Errorf("Expected no error stopping bus, got %v", err)
+	}
+
+	if err := bus.Stop(ctx); err != nil {
+		t.Errorf("Expected stop to be idempotent, got %v", err)
+	}
+
+	if err := bus.Health(); err == nil {
+		t.Error("Expected unhealthy bus after stop")
+	}
+}
+
+func TestInMemoryEventBus_MultipleHandlersPerType(t *testing.T) {
+	bus := newStartedInMemoryEventBus(t)
+
+	handler1 := NewMockEventHandler("handler-1", "test.event")
+	handler2 := NewMockEventHandler("handler-2", "test.event")
+	other := NewMockEventHandler("other-handler", "other.event")
+
+	bus.Subscribe("test.event", handler1)
+	bus.Subscribe("test.event", handler2)
+	bus.Subscribe("other.event", other)
+
+	event := NewTestEvent("test-id", "test-data")
+	if err := bus.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Expected no error publishing, got %v", err)
+	}
+
+	for _, handler := range []*MockEventHandler{handler1, handler2} {
+		handled := handler.GetHandledEvents()
+		if len(handled) != 1 {
+			t.Fatalf("Expected %s to handle 1 event, got %d", handler.HandlerName(), len(handled))
+		}
+		if handled[0].EventID() != event.EventID() {
+			t.Errorf("Expected event ID %s, got %s", event.EventID(), handled[0].EventID())
+		}
+	}
+
+	if len(other.GetHandledEvents()) != 0 {
+		t.Errorf("Expected other handler to receive no events, got %d", len(other.GetHandledEvents()))
+	}
+}
+
+func TestInMemoryEventBus_DuplicateSubscription(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	handler := NewMockEventHandler("handler", "test.event")
+
+	if err := bus.Subscribe("test.event", handler); err != nil {
+		t.Fatalf("Expected no error subscribing, got %v", err)
+	}
+
+	if err := bus.Subscribe("test.event", handler); !errors.Is(err, ErrHandlerAlreadyExists) {
+		t.Errorf("Expected handler already exists error, got %v", err)
+	}
+}
+
+func TestInMemoryEventBus_Unsubscribe(t *testing.T) {
+	bus := newStartedInMemoryEventBus(t)
+	handler := NewMockEventHandler("handler", "test.event")
+
+	bus.Subscribe("test.event", handler)
+	if err := bus.Unsubscribe("test.event", handler); err != nil {
+		t.Fatalf("Expected no error unsubscribing, got %v", err)
+	}
+
+	if err := bus.Publish(context.Background(), NewTestEvent("test-id", "test-data")); err != nil {
+		t.Fatalf("Expected no error publishing, got %v", err)
+	}
+
+	if len(handler.GetHandledEvents()) != 0 {
+		t.Errorf("Expected unsubscribed handler to receive no events, got %d", len(handler.GetHandledEvents()))
+	}
+}
+
+func TestInMemoryEventBus_Subscriptions(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	bus.Subscribe("user.created", NewBaseEventHandler("user.created", "welcome-email"))
+	bus.Subscribe("user.deleted", NewBaseEventHandler("user.deleted", "session-cleanup"))
+	bus.Subscribe("user.created", NewBaseEventHandler("user.created", "audit"))
+	bus.Subscribe("user.*", NewBaseEventHandler("user.*", "metrics"))
+
+	want := map[string][]string{
+		"user.created": {"welcome-email", "audit"},
+		"user.deleted": {"session-cleanup"},
+		"user.*":       {"metrics"},
+	}
+	if got := bus.Subscriptions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected subscriptions %v, got %v", want, got)
+	}
+
+	bus.Unsubscribe("user.deleted", NewBaseEventHandler("user.deleted", "session-cleanup"))
+	if _, exists := bus.Subscriptions()["user.deleted"]; exists {
+		t.Error("Expected event type without handlers to be omitted")
+	}
+}
+
+func TestInMemoryEventBus_HandlerErrorAggregation(t *testing.T) {
+	bus := newStartedInMemoryEventBus(t)
+
+	failing1 := NewMockEventHandler("failing-1", "test.event")
+	failing1.SetShouldError(true)
+	succeeding := NewMockEventHandler("succeeding", "test.event")
+	failing2 := NewMockEventHandler("failing-2", "test.event")
+	failing2.SetShouldError(true)
+
+	bus.Subscribe("test.event", failing1)
+	bus.Subscribe("test.event", succeeding)
+	bus.Subscribe("test.event", failing2)
+
+	err := bus.Publish(context.Background(), NewTestEvent("test-id", "test-data"))
+	if err == nil {
+		t.Fatal("Expected error when handlers fail")
+	}
+
+	var publishErr *PublishError
+	if !errors.As(err, &publishErr) {
+		t.Fatalf("Expected PublishError, got %T", err)
+	}
+
+	joined, ok := publishErr.Err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Expected joined handler errors, got %T", publishErr.Err)
+	}
+
+	handlerErrs := joined.Unwrap()
+	if len(handlerErrs) != 2 {
+		t.Fatalf("Expected 2 handler errors, got %d", len(handlerErrs))
+	}
+
+	var eventErr *EventError
+	if !errors.As(handlerErrs[0], &eventErr) || eventErr.Handler != "failing-1" {
+		t.Errorf("Expected first error from failing-1, got %v", handlerErrs[0])
+	}
+	if !errors.As(handlerErrs[1], &eventErr) || eventErr.Handler != "failing-2" {
+		t.Errorf("Expected second error from failing-2, got %v", handlerErrs[1])
+	}
+
+	if len(succeeding.GetHandledEvents()) != 1 {
+		t.Error("Expected succeeding handler to run despite other failures")
+	}
+}
+
+func TestInMemoryEventBus_WildcardSubscription(t *testing.T) {
+	bus := newStartedInMemoryEventBus(t)
+
+	userHandler := NewMockEventHandler("user-handler", "user.*")
+	allHandler := NewMockEventHandler("all-handler", "#")
+	bus.Subscribe("user.*", userHandler)
+	bus.Subscribe("#", allHandler)
+
+	ctx := context.Background()
+	bus.Publish(ctx, NewBaseEvent(UserCreatedEventType, "user-1", UserAggregateType, nil))
+	bus.Publish(ctx, NewBaseEvent(UserDeletedEventType, "user-1", UserAggregateType, nil))
+	bus.Publish(ctx, NewBaseEvent(UserLoggedInEventType, "user-1", UserAggregateType, nil))
+
+	if len(userHandler.GetHandledEvents()) != 2 {
+		t.Errorf("Expected user.* handler to receive 2 events, got %d", len(userHandler.GetHandledEvents()))
+	}
+
+	if len(allHandler.GetHandledEvents()) != 3 {
+		t.Errorf("Expected # handler to receive 3 events, got %d", len(allHandler.GetHandledEvents()))
+	}
+}
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		eventType string
+		expected  bool
+	}{
+		{"user.created", "user.created", true},
+		{"user.created", "user.deleted", false},
+		{"user.*", "user.created", true},
+		{"user.*", "user", false},
+		{"user.*", "user.profile.updated", false},
+		{"*.created", "user.created", true},
+		{"user.#", "user", true},
+		{"user.#", "user.profile.updated", true},
+		{"#.updated", "user.profile.updated", true},
+		{"#", "auth.user_logged_in", true},
+		{"auth.*", "user.created", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchTopic(tt.pattern, tt.eventType); got != tt.expected {
+			t.Errorf("MatchTopic(%q, %q) = %v, expected %v", tt.pattern, tt.eventType, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package events
+
+import "time"
+
+// EventBusObserver receives callbacks about event bus activity so that
+// metrics collectors can be plugged in without changing the bus itself.
+// Implementations must be safe for concurrent use and should return quickly,
+// since callbacks run inline with publishing and message handling.
+type EventBusObserver interface {
+	// OnPublish is called after an event is published, with the publish error if any
+	OnPublish(eventType string, err error)
+
+	// OnHandle is called after a handler processes an event
+	OnHandle(eventType string, handlerName string, duration time.Duration, err error)
+
+	// OnReconnect is called after the bus re-establishes its broker connection
+	OnReconnect()
+}
+
+// noopObserver is used when no observer is configured
+type noopObserver struct{}
+
+func (noopObserver) OnPublish(eventType string, err error) {}
+
+func (noopObserver) OnHandle(eventType string, handlerName string, duration time.Duration, err error) {
+}
+
+func (noopObserver) OnReconnect() {}
@@ -0,0 +1,196 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type publishCall struct {
+	eventType string
+	err       error
+}
+
+type handleCall struct {
+	eventType   string
+	handlerName string
+	duration    time.Duration
+	err         error
+}
+
+// recordingObserver implements EventBusObserver and records every callback
+type recordingObserver struct {
+	mu         sync.Mutex
+	publishes  []publishCall
+	handles    []handleCall
+	reconnects int
+}
+
+func (o *recordingObserver) OnPublish(eventType string, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.publishes = append(o.publishes, publishCall{eventType, err})
+}
+
+func (o *recordingObserver) OnHandle(eventType string, handlerName string, duration time.Duration, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.handles = append(o.handles, handleCall{eventType, handlerName, duration, err})
+}
+
+func (o *recordingObserver) OnReconnect() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.reconnects++
+}
+
+func TestRabbitMQEventBus_Observer_SuccessfulPublish(t *testing.T) {
+	observer := &recordingObserver{}
+	config := DefaultRabbitMQConfig()
+	config.Observer = observer
+	bus := NewRabbitMQEventBus(config)
+
+	channel := &fakeChannel{}
+	bus.channel = channel
+
+	event := NewTestEvent("test-id", "test-data")
+	if err := bus.Publish(context.Background(), event); err != nil {
+		t.Fatalf("Expected no error publishing, got %v", err)
+	}
+
+	if len(channel.published) != 1 {
+		t.Fatalf("Expected 1 published message, got %d", len(channel.published))
+	}
+
+	if len(observer.publishes) != 1 {
+		t.Fatalf("Expected 1 OnPublish callback, got %d", len(observer.publishes))
+	}
+
+	call := observer.publishes[0]
+	if call.eventType != "test.event" {
+		t.Errorf("Expected event type test.event, got %s", call.eventType)
+	}
+	if call.err != nil {
+		t.Errorf("Expected nil error for successful publish, got %v", call.err)
+	}
+}
+
+func TestRabbitMQEventBus_Observer_FailedPublish(t *testing.T) {
+	observer := &recordingObserver{}
+	config := DefaultRabbitMQConfig()
+	config.Observer = observer
+	bus := NewRabbitMQEventBus(config)
+
+	publishErr := errors.New("channel closed")
+	bus.channel = &fakeChannel{publishErr: publishErr}
+
+	err := bus.Publish(context.Background(), NewTestEvent("test-id", "test-data"))
+	if !errors.Is(err, publishErr) {
+		t.Fatalf("Expected publish error, got %v", err)
+	}
+
+	if len(observer.publishes) != 1 {
+		t.Fatalf("Expected 1 OnPublish callback, got %d", len(observer.publishes))
+	}
+
+	if !errors.Is(observer.publishes[0].err, publishErr) {
+		t.Errorf("Expected observer to receive publish error, got %v", observer.publishes[0].err)
+	}
+}
+
+func TestRabbitMQEventBus_Observer_PublishNotStarted(t *testing.T) {
+	observer := &recordingObserver{}
+	config := DefaultRabbitMQConfig()
+	config.Observer = observer
+	bus := NewRabbitMQEventBus(config)
+
+	if err := bus.Publish(context.Background(), NewTestEvent("test-id", "test-data")); err == nil {
+		t.Fatal("Expected error publishing before start")
+	}
+
+	if len(observer.publishes) != 1 || observer.publishes[0].err == nil {
+		t.Errorf("Expected failed OnPublish callback, got %+v", observer.publishes)
+	}
+}
+
+func TestRabbitMQEventBus_Observer_Handle(t *testing.T) {
+	observer := &recordingObserver{}
+	config := DefaultRabbitMQConfig()
+	config.Observer = observer
+	bus := NewRabbitMQEventBus(config)
+
+	succeeding := NewMockEventHandler("succeeding-handler", "test.event")
+	failing := NewMockEventHandler("failing-handler", "test.event")
+	failing.SetShouldError(true)
+	bus.Subscribe("test.event", succeeding)
+	bus.Subscribe("test.event", failing)
+
+	ack := &recordingAcknowledger{}
+	bus.processDelivery("test.event", newTestDelivery(t, ack, NewTestEvent("test-id", "test-data")))
+
+	if len(observer.handles) != 2 {
+		t.Fatalf("Expected 2 OnHandle callbacks, got %d", len(observer.handles))
+	}
+
+	if observer.handles[0].handlerName != "succeeding-handler" || observer.handles[0].err != nil {
+		t.Errorf("Expected successful handle for succeeding-handler, got %+v", observer.handles[0])
+	}
+
+	if observer.handles[1].handlerName != "failing-handler" || observer.handles[1].err == nil {
+		t.Errorf("Expected failed handle for failing-handler, got %+v", observer.handles[1])
+	}
+
+	for _, call := range observer.handles {
+		if call.eventType != "test.event" {
+			t.Errorf("Expected event type test.event, got %s", call.eventType)
+		}
+		if call.duration < 0 {
+			t.Errorf("Expected non-negative duration, got %s", call.duration)
+		}
+	}
+}
+
+func TestRabbitMQEventBus_Observer_DefaultsToNoop(t *testing.T) {
+	bus := NewRabbitMQEventBus(DefaultRabbitMQConfig())
+	bus.channel = &fakeChannel{}
+
+	if err := bus.Publish(context.Background(), NewTestEvent("test-id", "test-data")); err != nil {
+		t.Errorf("Expected no error publishing without observer, got %v", err)
+	}
+}
+
+func TestInMemoryEventBus_Observer(t *testing.T) {
+	observer := &recordingObserver{}
+	bus := NewInMemoryEventBus().WithObserver(observer)
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+
+	ok := NewMockEventHandler("ok-handler", "test.event")
+	failing := NewMockEventHandler("failing-handler", "test.event")
+	failing.SetShouldError(true)
+	for _, handler := range []EventHandler{ok, failing} {
+		if err := bus.Subscribe("test.event", handler); err != nil {
+			t.Fatalf("Expected no error subscribing, got %v", err)
+		}
+	}
+
+	if err := bus.Publish(context.Background(), NewTestEvent("test-id", "test-data")); err == nil {
+		t.Fatal("Expected error from failing handler")
+	}
+
+	if len(observer.publishes) != 1 || observer.publishes[0].err == nil {
+		t.Fatalf("Expected one failed publish, got %+v", observer.publishes)
+	}
+	if len(observer.handles) != 2 {
+		t.Fatalf("Expected 2 handler calls, got %d", len(observer.handles))
+	}
+	if observer.handles[0].handlerName != "ok-handler" || observer.handles[0].err != nil {
+		t.Errorf("Expected ok-handler to succeed, got %+v", observer.handles[0])
+	}
+	if observer.handles[1].handlerName != "failing-handler" || observer.handles[1].err == nil {
+		t.Errorf("Expected failing-handler to fail, got %+v", observer.handles[1])
+	}
+}
@@ -0,0 +1,255 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go-templ-template/internal/shared/database"
+)
+
+// OutboxConfig holds configuration for the outbox relay
+type OutboxConfig struct {
+	// PollInterval is how often the relay checks for unsent events
+	PollInterval time.Duration
+
+	// BatchSize is the maximum number of events relayed per poll
+	BatchSize int
+}
+
+// DefaultOutboxConfig returns a default configuration for the outbox relay
+func DefaultOutboxConfig() OutboxConfig {
+	return OutboxConfig{
+		PollInterval: time.Second,
+		BatchSize:    100,
+	}
+}
+
+// OutboxEventBus wraps an EventBus with the transactional outbox pattern.
+// Events published inside a database.ExecuteInTransaction context are written
+// to the event_outbox table in that transaction, so they are only published
+// if it commits. A background relay publishes committed rows through the
+// wrapped bus and marks them sent. Delivery is at-least-once: an event may
+// be published again if marking it sent fails.
+type OutboxEventBus struct {
+	bus    EventBus
+	db     *database.DB
+	config OutboxConfig
+	done   chan struct{}
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+	active bool
+}
+
+// outboxRow is a pending event in the event_outbox table
+type outboxRow struct {
+	ID      int64  `db:"id"`
+	EventID string `db:"event_id"`
+	Payload []byte `db:"payload"`
+}
+
+// NewOutboxEventBus creates a new outbox event bus wrapping the given bus
+func NewOutboxEventBus(bus EventBus, db *database.DB, config OutboxConfig) *OutboxEventBus {
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultOutboxConfig().PollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultOutboxConfig().BatchSize
+	}
+
+	return &OutboxEventBus{
+		bus:    bus,
+		db:     db,
+		config: config,
+	}
+}
+
+// Publish writes the event to the outbox when called inside a transaction,
+// otherwise it publishes directly through the wrapped bus
+func (o *OutboxEventBus) Publish(ctx context.Context, event DomainEvent) error {
+	tx := database.GetTxFromContext(ctx)
+	if tx == nil {
+		return o.bus.Publish(ctx, event)
+	}
+
+	ctx, span := startPublishSpan(ctx, event)
+	defer span.End()
+
+	envelope, err := NewSerializableEventEnvelope(WithRequestScope(ctx, event))
+	if err != nil {
+		return fmt.Errorf("failed to create serializable envelope: %w", err)
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to serialize event: %w", err)
+	}
+
+	query := `
+		INSERT INTO event_outbox (event_id, event_type, aggregate_id, payload)
+		VALUES ($1, $2, $3, $4)`
+
+	if _, err := tx.ExecContext(ctx, query, event.EventID(), event.EventType(), event.AggregateID(), payload); err != nil {
+		span.RecordError(err)
+		return NewPublishError(event, fmt.Errorf("failed to write event to outbox: %w", err))
+	}
+
+	return nil
+}
+
+// PublishesInTransaction reports that events published inside a transaction
+// are written to the outbox in it
+func (o *OutboxEventBus) PublishesInTransaction() bool {
+	return true
+}
+
+// Subscribe registers an event handler on the wrapped bus
+func (o *OutboxEventBus) Subscribe(eventType string, handler EventHandler) error {
+	return o.bus.Subscribe(eventType, handler)
+}
+
+// Unsubscribe removes an event handler from the wrapped bus
+func (o *OutboxEventBus) Unsubscribe(eventType string, handler EventHandler) error {
+	return o.bus.Unsubscribe(eventType, handler)
+}
+
+// Subscriptions returns the subscriptions of the wrapped bus, or nil if it
+// cannot report them
+func (o *OutboxEventBus) Subscriptions() map[string][]string {
+	if lister, ok := o.bus.(SubscriptionLister); ok {
+		return lister.Subscriptions()
+	}
+	return nil
+}
+
+// Start starts the wrapped bus and the outbox relay
+func (o *OutboxEventBus) Start(ctx context.Context) error {
+	if err := o.bus.Start(ctx); err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.active {
+		return ErrEventBusAlreadyStarted
+	}
+	o.active = true
+	o.done = make(chan struct{})
+
+	o.wg.Add(1)
+	go o.relay()
+
+	log.Printf("Outbox relay started with poll interval: %s", o.config.PollInterval)
+	return nil
+}
+
+// Stop waits for the relay to finish its current batch and stops the wrapped bus
+func (o *OutboxEventBus) Stop(ctx context.Context) error {
+	o.mu.Lock()
+	if o.active {
+		close(o.done)
+		o.active = false
+	}
+	o.mu.Unlock()
+
+	stopped := make(chan struct{})
+	go func() {
+		o.wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		log.Println("Outbox relay stopped")
+	case <-ctx.Done():
+		log.Printf("Outbox relay did not stop before deadline: %v", ctx.Err())
+	}
+
+	return o.bus.Stop(ctx)
+}
+
+// Health returns the health of the wrapped bus
+func (o *OutboxEventBus) Health() error {
+	return o.bus.Health()
+}
+
+// relay polls the outbox and publishes committed events until stopped
+func (o *OutboxEventBus) relay() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(o.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-o.done:
+			return
+		case <-ticker.C:
+			if _, err := o.RelayPending(context.Background()); err != nil {
+				log.Printf("Outbox relay error: %v", err)
+			}
+		}
+	}
+}
+
+// RelayPending publishes up to one batch of unsent events in insertion order
+// and returns how many were published. Rows are locked so that multiple
+// relays can run concurrently without publishing the same event twice.
+func (o *OutboxEventBus) RelayPending(ctx context.Context) (int, error) {
+	published := 0
+
+	err := database.ExecuteInTransaction(ctx, o.db, func(txCtx context.Context) error {
+		tx := database.GetTxFromContext(txCtx)
+
+		var rows []outboxRow
+		query := `
+			SELECT id, event_id, payload
+			FROM event_outbox
+			WHERE sent_at IS NULL
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED`
+
+		if err := tx.SelectContext(txCtx, &rows, query, o.config.BatchSize); err != nil {
+			return fmt.Errorf("failed to load outbox events: %w", err)
+		}
+
+		for _, row := range rows {
+			var envelope SerializableEventEnvelope
+			if err := json.Unmarshal(row.Payload, &envelope); err != nil {
+				return fmt.Errorf("failed to deserialize outbox event %s: %w", row.EventID, err)
+			}
+
+			// Publish outside the transaction context so the wrapped bus
+			// does not see it
+			if err := o.bus.Publish(ctx, envelope.Event); err != nil {
+				// Stop at the first failure to preserve ordering
+				if _, markErr := tx.ExecContext(txCtx,
+					`UPDATE event_outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1`,
+					row.ID, err.Error()); markErr != nil {
+					return fmt.Errorf("failed to record outbox failure for %s: %w", row.EventID, markErr)
+				}
+				log.Printf("Failed to relay outbox event %s: %v", row.EventID, err)
+				return nil
+			}
+
+			if _, err := tx.ExecContext(txCtx,
+				`UPDATE event_outbox SET sent_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = $1`,
+				row.ID); err != nil {
+				return fmt.Errorf("failed to mark outbox event %s sent: %w", row.EventID, err)
+			}
+			published++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return published, nil
+}
@@ -0,0 +1,177 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/shared/database"
+)
+
+const outboxTableSQL = `
+	CREATE TABLE IF NOT EXISTS event_outbox (
+		id BIGSERIAL PRIMARY KEY,
+		event_id VARCHAR(255) NOT NULL UNIQUE,
+		event_type VARCHAR(255) NOT NULL,
+		aggregate_id VARCHAR(255) NOT NULL,
+		payload JSONB NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		last_error TEXT,
+		created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+		sent_at TIMESTAMP WITH TIME ZONE
+	)`
+
+func newOutboxTestDatabase(t *testing.T) *database.TestDatabase {
+	t.Helper()
+
+	database.SkipIfNoDatabaseWithTimeout(t, 2*time.Second)
+
+	tdb := database.NewTestDatabaseWithTimeout(t, 2*time.Second)
+	tdb.DropTable("event_outbox")
+	tdb.CreateTable(outboxTableSQL)
+	t.Cleanup(func() {
+		tdb.DropTable("event_outbox")
+		tdb.Close()
+	})
+	return tdb
+}
+
+func newStartedOutboxBus(t *testing.T, db *database.DB) (*OutboxEventBus, *MockEventHandler) {
+	t.Helper()
+
+	inner := NewInMemoryEventBus()
+	handler := NewMockEventHandler("outbox-handler", "test.event")
+	inner.Subscribe("test.event", handler)
+
+	// A long poll interval keeps the background relay out of the way;
+	// tests drive it with RelayPending
+	bus := NewOutboxEventBus(inner, db, OutboxConfig{PollInterval: time.Hour})
+	if err := bus.Start(context.Background()); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+	t.Cleanup(func() { bus.Stop(context.Background()) })
+
+	return bus, handler
+}
+
+func TestOutboxEventBus_PublishWithoutTransaction(t *testing.T) {
+	inner := NewInMemoryEventBus()
+	inner.Start(context.Background())
+	handler := NewMockEventHandler("direct-handler", "test.event")
+	inner.Subscribe("test.event", handler)
+
+	bus := NewOutboxEventBus(inner, nil, DefaultOutboxConfig())
+
+	if err := bus.Publish(context.Background(), NewTestEvent("test-id", "test-data")); err != nil {
+		t.Fatalf("Expected no error publishing, got %v", err)
+	}
+
+	if len(handler.GetHandledEvents()) != 1 {
+		t.Errorf("Expected event to be published directly, got %d", len(handler.GetHandledEvents()))
+	}
+}
+
+func TestOutboxEventBus_RollbackDiscardsEvents(t *testing.T) {
+	tdb := newOutboxTestDatabase(t)
+	bus, handler := newStartedOutboxBus(t, tdb.DB)
+
+	rollback := errors.New("rollback")
+	err := tdb.WithTransaction(func(ctx context.Context) error {
+		if err := bus.Publish(ctx, NewTestEvent("test-id", "test-data")); err != nil {
+			return err
+		}
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Fatalf("Expected rollback error, got %v", err)
+	}
+
+	tdb.AssertRowCount("event_outbox", 0)
+
+	published, err := bus.RelayPending(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error relaying, got %v", err)
+	}
+
+	if published != 0 || len(handler.GetHandledEvents()) != 0 {
+		t.Errorf("Expected no events published after rollback, got %d", len(handler.GetHandledEvents()))
+	}
+}
+
+func TestOutboxEventBus_CommitPublishesViaRelay(t *testing.T) {
+	tdb := newOutboxTestDatabase(t)
+	bus, handler := newStartedOutboxBus(t, tdb.DB)
+
+	event := NewTestEvent("test-id", "test-data")
+	err := tdb.WithTransaction(func(ctx context.Context) error {
+		return bus.Publish(ctx, event)
+	})
+	if err != nil {
+		t.Fatalf("Expected no error committing, got %v", err)
+	}
+
+	if len(handler.GetHandledEvents()) != 0 {
+		t.Fatal("Expected event to wait in the outbox until relayed")
+	}
+	tdb.AssertRowCount("event_outbox", 1)
+
+	published, err := bus.RelayPending(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error relaying, got %v", err)
+	}
+
+	if published != 1 {
+		t.Errorf("Expected 1 relayed event, got %d", published)
+	}
+
+	handled := handler.GetHandledEvents()
+	if len(handled) != 1 || handled[0].EventID() != event.EventID() {
+		t.Fatalf("Expected handler to receive event %s, got %v", event.EventID(), handled)
+	}
+
+	var sent int
+	if err := tdb.DB.Get(&sent, "SELECT COUNT(*) FROM event_outbox WHERE sent_at IS NOT NULL"); err != nil {
+		t.Fatalf("Expected no error counting sent events, got %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("Expected event to be marked sent, got %d sent rows", sent)
+	}
+
+	published, err = bus.RelayPending(context.Background())
+	if err != nil || published != 0 {
+		t.Errorf("Expected nothing left to relay, got %d (err %v)", published, err)
+	}
+}
+
+func TestOutboxEventBus_FailedRelayIsRetried(t *testing.T) {
+	tdb := newOutboxTestDatabase(t)
+	bus, handler := newStartedOutboxBus(t, tdb.DB)
+
+	err := tdb.WithTransaction(func(ctx context.Context) error {
+		return bus.Publish(ctx, NewTestEvent("test-id", "test-data"))
+	})
+	if err != nil {
+		t.Fatalf("Expected no error committing, got %v", err)
+	}
+
+	handler.SetShouldError(true)
+	published, err := bus.RelayPending(context.Background())
+	if err != nil || published != 0 {
+		t.Fatalf("Expected failed relay to publish nothing, got %d (err %v)", published, err)
+	}
+
+	var lastError string
+	if err := tdb.DB.Get(&lastError, "SELECT COALESCE(last_error, '') FROM event_outbox"); err != nil {
+		t.Fatalf("Expected no error reading outbox, got %v", err)
+	}
+	if lastError == "" {
+		t.Error("Expected relay failure to be recorded")
+	}
+
+	handler.SetShouldError(false)
+	published, err = bus.RelayPending(context.Background())
+	if err != nil || published != 1 {
+		t.Errorf("Expected retry to publish the event, got %d (err %v)", published, err)
+	}
+}
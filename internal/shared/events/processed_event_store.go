@@ -0,0 +1,74 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-templ-template/internal/shared/database"
+)
+
+// DBProcessedEventStore is a ProcessedEventStore backed by the
+// processed_events table, shared by every instance of the application. The
+// table's event_id column holds the events' dedup keys.
+type DBProcessedEventStore struct {
+	db        *database.DB
+	retention time.Duration
+}
+
+// NewDBProcessedEventStore creates a store remembering processed events for
+// retention; zero uses DefaultProcessedEventRetention
+func NewDBProcessedEventStore(db *database.DB, retention time.Duration) *DBProcessedEventStore {
+	if retention <= 0 {
+		retention = DefaultProcessedEventRetention
+	}
+
+	return &DBProcessedEventStore{
+		db:        db,
+		retention: retention,
+	}
+}
+
+// IsProcessed reports whether the handler processed the event within the
+// retention window
+func (s *DBProcessedEventStore) IsProcessed(ctx context.Context, handlerName, key string) (bool, error) {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM processed_events
+			WHERE handler_name = $1 AND event_id = $2 AND processed_at > $3
+		)`
+
+	var exists bool
+	since := time.Now().Add(-s.retention)
+	if err := database.GetExecutor(ctx, s.db).GetContext(ctx, &exists, query, handlerName, key, since); err != nil {
+		return false, fmt.Errorf("failed to query processed events: %w", err)
+	}
+	return exists, nil
+}
+
+// MarkProcessed records that the handler processed the event. Inside a
+// database.ExecuteInTransaction context the record is written in that
+// transaction, so it commits together with the handler's own writes.
+func (s *DBProcessedEventStore) MarkProcessed(ctx context.Context, handlerName, key string) error {
+	query := `
+		INSERT INTO processed_events (handler_name, event_id, processed_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (handler_name, event_id) DO UPDATE SET processed_at = NOW()`
+
+	if _, err := database.GetExecutor(ctx, s.db).ExecContext(ctx, query, handlerName, key); err != nil {
+		return fmt.Errorf("failed to record processed event: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes records older than the retention window, returning
+// how many were removed
+func (s *DBProcessedEventStore) DeleteExpired(ctx context.Context) (int64, error) {
+	query := `DELETE FROM processed_events WHERE processed_at <= $1`
+
+	result, err := s.db.ExecContext(ctx, query, time.Now().Add(-s.retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired processed events: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -2,7 +2,6 @@ package events
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"sync"
@@ -11,15 +10,29 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// returnsBuffer is how many unroutable messages the publishing channel
+// buffers until a publisher collects them
+const returnsBuffer = 64
+
 // RabbitMQEventBus implements EventBus using RabbitMQ
 type RabbitMQEventBus struct {
-	connection   *amqp.Connection
-	channel      *amqp.Channel
+	connection   amqpConnection
+	channel      amqpChannel
+	returns      chan amqp.Return
+	reconnecting bool
+	connMux      sync.RWMutex
+	dial         amqpDialer
+	observer     EventBusObserver
 	config       RabbitMQConfig
 	handlers     map[string][]EventHandler
 	handlersMux  sync.RWMutex
-	consumers    map[string]*amqp.Channel
+	consumers    map[string]amqpChannel
 	consumersMux sync.RWMutex
+	deadLetters  map[string][]EventHandler
+	attempts     map[string]int
+	attemptsMux  sync.Mutex
+	returned     map[string]amqp.Return
+	returnedMux  sync.Mutex
 	done         chan bool
 	wg           sync.WaitGroup
 	stopped      bool
@@ -36,36 +49,130 @@ type RabbitMQConfig struct {
 	AutoDelete   bool
 	Exclusive    bool
 	NoWait       bool
+
+	// DeadLetterExchange receives events that exhausted MaxDeliveryAttempts.
+	// Leave empty to disable dead-lettering.
+	DeadLetterExchange string
+
+	// MaxDeliveryAttempts is the number of failed deliveries after which an
+	// event is rejected without requeue. Zero keeps the envelope retry logic.
+	MaxDeliveryAttempts int
+
+	// Observer is notified of publishes, handler executions and reconnects.
+	// Optional; nil disables observation.
+	Observer EventBusObserver
+
+	// Upgraders migrate older event payloads before they reach handlers.
+	// Optional; nil delivers payloads as received.
+	Upgraders *EventUpgraderRegistry
+
+	// ReconnectDelay is the initial wait before reconnecting after the
+	// connection drops; it doubles on each failed attempt up to MaxReconnectDelay
+	ReconnectDelay    time.Duration
+	MaxReconnectDelay time.Duration
+
+	// ConsumerConcurrency is the number of deliveries of an event type
+	// handled at once, used as the queue's prefetch count and its number of
+	// worker goroutines. Zero or negative handles one at a time, in order.
+	ConsumerConcurrency int
+
+	// Concurrency overrides ConsumerConcurrency for the event types it
+	// names, so heavy handlers can get more parallelism and handlers that
+	// rely on ordering exactly one worker
+	Concurrency map[string]int
+
+	// Codec serializes published events; nil uses JSONCodec. Consumers
+	// decode each message with the codec named by its content type, so
+	// services can switch codecs one at a time.
+	Codec Codec
+
+	// Compression gzips encoded events larger than CompressionThreshold
+	// bytes, marking them with a gzip content encoding. Consumers
+	// decompress marked messages whether or not it is enabled.
+	Compression          bool
+	CompressionThreshold int
+}
+
+// ConcurrencyFor returns the number of deliveries of eventType handled at once
+func (c RabbitMQConfig) ConcurrencyFor(eventType string) int {
+	if n, ok := c.Concurrency[eventType]; ok && n > 0 {
+		return n
+	}
+	if c.ConsumerConcurrency > 0 {
+		return c.ConsumerConcurrency
+	}
+	return 1
 }
 
 // NewRabbitMQEventBus creates a new RabbitMQ event bus
 func NewRabbitMQEventBus(config RabbitMQConfig) *RabbitMQEventBus {
+	var observer EventBusObserver = noopObserver{}
+	if config.Observer != nil {
+		observer = config.Observer
+	}
+
+	if config.ReconnectDelay <= 0 {
+		config.ReconnectDelay = time.Second
+	}
+	if config.MaxReconnectDelay <= 0 {
+		config.MaxReconnectDelay = 30 * time.Second
+	}
+	if config.Codec == nil {
+		config.Codec = JSONCodec{}
+	}
+	if config.CompressionThreshold <= 0 {
+		config.CompressionThreshold = DefaultCompressionThreshold
+	}
+
 	return &RabbitMQEventBus{
-		config:    config,
-		handlers:  make(map[string][]EventHandler),
-		consumers: make(map[string]*amqp.Channel),
-		done:      make(chan bool),
+		config:      config,
+		dial:        dialAMQP,
+		observer:    observer,
+		handlers:    make(map[string][]EventHandler),
+		consumers:   make(map[string]amqpChannel),
+		deadLetters: make(map[string][]EventHandler),
+		attempts:    make(map[string]int),
+		returned:    make(map[string]amqp.Return),
+		done:        make(chan bool),
 	}
 }
 
 // Start initializes the RabbitMQ connection and sets up the exchange
 func (r *RabbitMQEventBus) Start(ctx context.Context) error {
-	var err error
+	if err := r.connect(); err != nil {
+		return err
+	}
+
+	log.Printf("RabbitMQ EventBus started with exchange: %s", r.config.Exchange)
+	return nil
+}
 
+// connect dials the broker, declares exchanges, starts consumers for every
+// registered subscription and begins watching the connection for failures
+func (r *RabbitMQEventBus) connect() error {
 	// Establish connection
-	r.connection, err = amqp.Dial(r.config.URL)
+	conn, err := r.dial(r.config.URL)
 	if err != nil {
 		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
 
 	// Create channel for publishing
-	r.channel, err = r.connection.Channel()
+	channel, err := conn.Channel()
 	if err != nil {
+		conn.Close()
 		return fmt.Errorf("failed to open channel: %w", err)
 	}
 
+	// Have the broker confirm every publish, and return events published
+	// as mandatory that no queue is bound for
+	if err := channel.Confirm(false); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+	returns := channel.NotifyReturn(make(chan amqp.Return, returnsBuffer))
+
 	// Declare exchange
-	err = r.channel.ExchangeDeclare(
+	err = channel.ExchangeDeclare(
 		r.config.Exchange,     // name
 		r.config.ExchangeType, // type
 		r.config.Durable,      // durable
@@ -75,24 +182,129 @@ func (r *RabbitMQEventBus) Start(ctx context.Context) error {
 		nil,                   // arguments
 	)
 	if err != nil {
+		conn.Close()
 		return fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	// Start consumers for existing handlers
+	// Declare dead letter exchange
+	if r.config.DeadLetterExchange != "" {
+		err = channel.ExchangeDeclare(
+			r.config.DeadLetterExchange, // name
+			r.config.ExchangeType,       // type
+			r.config.Durable,            // durable
+			r.config.AutoDelete,         // auto-deleted
+			false,                       // internal
+			r.config.NoWait,             // no-wait
+			nil,                         // arguments
+		)
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to declare dead letter exchange: %w", err)
+		}
+	}
+
+	// Hold the handlers lock until the new connection is published so that
+	// concurrent subscriptions either get restored here or see the new connection
 	r.handlersMux.RLock()
+	defer r.handlersMux.RUnlock()
+
+	// Start consumers for existing handlers
 	for eventType := range r.handlers {
-		if err := r.startConsumer(eventType); err != nil {
-			r.handlersMux.RUnlock()
+		if err := r.startConsumer(conn, eventType); err != nil {
+			conn.Close()
 			return fmt.Errorf("failed to start consumer for %s: %w", eventType, err)
 		}
 	}
-	r.handlersMux.RUnlock()
+	for eventType := range r.deadLetters {
+		if err := r.startDeadLetterConsumer(conn, eventType); err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to start dead letter consumer for %s: %w", eventType, err)
+		}
+	}
+
+	r.connMux.Lock()
+	r.connection = conn
+	r.channel = channel
+	r.returns = returns
+	r.reconnecting = false
+	r.connMux.Unlock()
+
+	// Watch for connection or channel failures
+	connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+	channelClosed := channel.NotifyClose(make(chan *amqp.Error, 1))
+	r.wg.Add(1)
+	go r.watchConnection(connClosed, channelClosed)
 
-	log.Printf("RabbitMQ EventBus started with exchange: %s", r.config.Exchange)
 	return nil
 }
 
-// Stop gracefully shuts down the event bus
+// watchConnection waits for the connection or publishing channel to close
+// and reconnects unless the bus is shutting down
+func (r *RabbitMQEventBus) watchConnection(connClosed, channelClosed <-chan *amqp.Error) {
+	defer r.wg.Done()
+
+	var reason *amqp.Error
+	select {
+	case <-r.done:
+		return
+	case reason = <-connClosed:
+	case reason = <-channelClosed:
+	}
+
+	// A graceful close during shutdown also notifies; don't reconnect then
+	select {
+	case <-r.done:
+		return
+	default:
+	}
+
+	r.reconnect(reason)
+}
+
+// reconnect re-establishes the connection with exponential backoff and
+// restores all subscriptions
+func (r *RabbitMQEventBus) reconnect(reason *amqp.Error) {
+	log.Printf("RabbitMQ connection lost: %v, reconnecting", reason)
+
+	r.connMux.Lock()
+	r.reconnecting = true
+	old := r.connection
+	r.connMux.Unlock()
+
+	// Make sure consumers on the old connection stop
+	if old != nil && !old.IsClosed() {
+		old.Close()
+	}
+
+	backoff := RetryPolicy{
+		InitialDelay: r.config.ReconnectDelay,
+		MaxDelay:     r.config.MaxReconnectDelay,
+		Multiplier:   2,
+	}
+
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-r.done:
+			return
+		case <-time.After(backoff.Delay(attempt)):
+		}
+
+		if err := r.connect(); err != nil {
+			log.Printf("RabbitMQ reconnect attempt %d failed: %v", attempt, err)
+			continue
+		}
+
+		log.Printf("RabbitMQ EventBus reconnected after %d attempt(s)", attempt)
+		r.observer.OnReconnect()
+		return
+	}
+}
+
+// Stop gracefully shuts down the event bus. Consumers stop taking new
+// messages and Stop waits for handlers already running to finish, for as
+// long as ctx allows. If ctx ends first the bus is shut down anyway and an
+// error wrapping ErrShutdownTimeout is returned; messages whose handlers did
+// not finish are redelivered by the broker.
 func (r *RabbitMQEventBus) Stop(ctx context.Context) error {
 	r.stopMux.Lock()
 	defer r.stopMux.Unlock()
@@ -102,8 +314,8 @@ func (r *RabbitMQEventBus) Stop(ctx context.Context) error {
 	}
 
 	close(r.done)
-	r.wg.Wait()
 	r.stopped = true
+	drainErr := r.drain(ctx)
 
 	// Close consumer channels
 	r.consumersMux.Lock()
@@ -112,9 +324,12 @@ func (r *RabbitMQEventBus) Stop(ctx context.Context) error {
 			log.Printf("Error closing consumer channel: %v", err)
 		}
 	}
-	r.consumers = make(map[string]*amqp.Channel)
+	r.consumers = make(map[string]amqpChannel)
 	r.consumersMux.Unlock()
 
+	r.connMux.Lock()
+	defer r.connMux.Unlock()
+
 	// Close main channel
 	if r.channel != nil {
 		if err := r.channel.Close(); err != nil {
@@ -129,57 +344,189 @@ func (r *RabbitMQEventBus) Stop(ctx context.Context) error {
 		}
 	}
 
+	if drainErr != nil {
+		log.Printf("RabbitMQ EventBus stopped: %v", drainErr)
+		return drainErr
+	}
+
 	log.Println("RabbitMQ EventBus stopped")
 	return nil
 }
 
+// drain waits for consumers to finish the messages they are handling
+func (r *RabbitMQEventBus) drain(ctx context.Context) error {
+	drained := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", ErrShutdownTimeout, ctx.Err())
+	}
+}
+
+// stopping reports whether Stop has been called
+func (r *RabbitMQEventBus) stopping() bool {
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
 // Publish sends an event to the exchange
 func (r *RabbitMQEventBus) Publish(ctx context.Context, event DomainEvent) error {
-	if r.channel == nil {
+	ctx, span := startPublishSpan(ctx, event)
+	defer span.End()
+
+	err := r.publish(ctx, event)
+	if err != nil {
+		span.RecordError(err)
+	}
+	r.observer.OnPublish(event.EventType(), err)
+	return err
+}
+
+// publish serializes and sends an event to the exchange
+func (r *RabbitMQEventBus) publish(ctx context.Context, event DomainEvent) error {
+	r.connMux.RLock()
+	channel, returns := r.channel, r.returns
+	r.connMux.RUnlock()
+
+	if channel == nil {
 		return fmt.Errorf("event bus not started")
 	}
 
-	// Create serializable event envelope
-	envelope, err := NewSerializableEventEnvelope(event)
+	// Create serializable event envelope, carrying the request it was
+	// published from
+	envelope, err := NewSerializableEventEnvelope(WithRequestScope(ctx, event))
 	if err != nil {
 		return fmt.Errorf("failed to create serializable envelope: %w", err)
 	}
 
 	// Serialize event
-	body, err := json.Marshal(envelope)
+	body, err := r.config.Codec.Encode(envelope)
 	if err != nil {
 		return fmt.Errorf("failed to serialize event: %w", err)
 	}
 
-	// Publish message
-	err = r.channel.PublishWithContext(
+	// Compress large payloads
+	contentEncoding := ""
+	if r.config.Compression && len(body) > r.config.CompressionThreshold {
+		if body, err = compress(body); err != nil {
+			return fmt.Errorf("failed to compress event: %w", err)
+		}
+		contentEncoding = GzipContentEncoding
+	}
+
+	// Publish message as mandatory, so the broker returns it if no queue
+	// is bound for its event type
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(
 		ctx,
 		r.config.Exchange, // exchange
 		event.EventType(), // routing key
-		false,             // mandatory
+		true,              // mandatory
 		false,             // immediate
 		amqp.Publishing{
-			ContentType:  "application/json",
-			Body:         body,
-			DeliveryMode: amqp.Persistent, // Make message persistent
-			Timestamp:    time.Now(),
-			MessageId:    event.EventID(),
+			ContentType:     r.config.Codec.ContentType(),
+			ContentEncoding: contentEncoding,
+			Body:            body,
+			DeliveryMode:    amqp.Persistent, // Make message persistent
+			Timestamp:       time.Now(),
+			MessageId:       event.EventID(),
 			Headers: amqp.Table{
 				"event_type":     event.EventType(),
 				"aggregate_id":   event.AggregateID(),
 				"aggregate_type": event.AggregateType(),
 				"correlation_id": event.Metadata().CorrelationID,
+				"dedup_key":      envelope.DedupKey,
 			},
 		},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to publish event: %w", err)
 	}
+	if err := r.awaitConfirmation(ctx, event, confirmation, returns); err != nil {
+		return err
+	}
 
 	log.Printf("Published event: %s for aggregate: %s", event.EventType(), event.AggregateID())
 	return nil
 }
 
+// awaitConfirmation waits for the broker to confirm a published event,
+// failing if it nacked the event or returned it as unroutable. Returns for
+// other events received meanwhile are recorded for their publishers.
+func (r *RabbitMQEventBus) awaitConfirmation(ctx context.Context, event DomainEvent, confirmation amqpConfirmation, returns <-chan amqp.Return) error {
+	if confirmation == nil {
+		return nil // The channel is not in confirm mode
+	}
+
+	for confirmed := false; !confirmed; {
+		select {
+		case <-confirmation.Done():
+			confirmed = true
+		case returned, ok := <-returns:
+			if !ok {
+				returns = nil
+				continue
+			}
+			r.recordReturn(returned)
+		case <-ctx.Done():
+			return fmt.Errorf("failed to confirm event %s: %w", event.EventType(), ctx.Err())
+		}
+	}
+
+	// The broker returns an unroutable message before confirming it, so
+	// its return is buffered by the time the confirmation is done
+	r.drainReturns(returns)
+	if returned, ok := r.takeReturn(event.EventID()); ok {
+		return fmt.Errorf("%w: %s on exchange %s: %d %s", ErrEventUnroutable,
+			event.EventType(), returned.Exchange, returned.ReplyCode, returned.ReplyText)
+	}
+	if !confirmation.Acked() {
+		return fmt.Errorf("%w: %s was nacked by the broker", ErrEventNacked, event.EventType())
+	}
+	return nil
+}
+
+// drainReturns records the returns buffered in returns
+func (r *RabbitMQEventBus) drainReturns(returns <-chan amqp.Return) {
+	for {
+		select {
+		case returned, ok := <-returns:
+			if !ok {
+				return
+			}
+			r.recordReturn(returned)
+		default:
+			return
+		}
+	}
+}
+
+// recordReturn records a message the broker returned as unroutable
+func (r *RabbitMQEventBus) recordReturn(returned amqp.Return) {
+	r.returnedMux.Lock()
+	defer r.returnedMux.Unlock()
+	r.returned[returned.MessageId] = returned
+}
+
+// takeReturn removes and returns the return recorded for a message
+func (r *RabbitMQEventBus) takeReturn(messageID string) (amqp.Return, bool) {
+	r.returnedMux.Lock()
+	defer r.returnedMux.Unlock()
+
+	returned, ok := r.returned[messageID]
+	delete(r.returned, messageID)
+	return returned, ok
+}
+
 // Subscribe registers an event handler for a specific event type
 func (r *RabbitMQEventBus) Subscribe(eventType string, handler EventHandler) error {
 	r.handlersMux.Lock()
@@ -189,8 +536,8 @@ func (r *RabbitMQEventBus) Subscribe(eventType string, handler EventHandler) err
 	r.handlers[eventType] = append(r.handlers[eventType], handler)
 
 	// If the bus is already started, start a consumer for this event type
-	if r.connection != nil && !r.connection.IsClosed() {
-		if err := r.startConsumer(eventType); err != nil {
+	if conn := r.currentConnection(); conn != nil && !conn.IsClosed() {
+		if err := r.startConsumer(conn, eventType); err != nil {
 			return fmt.Errorf("failed to start consumer for %s: %w", eventType, err)
 		}
 	}
@@ -199,6 +546,29 @@ func (r *RabbitMQEventBus) Subscribe(eventType string, handler EventHandler) err
 	return nil
 }
 
+// SubscribeDeadLetter registers a handler for events of the given type that
+// were routed to the dead letter exchange after exhausting their deliveries
+func (r *RabbitMQEventBus) SubscribeDeadLetter(eventType string, handler EventHandler) error {
+	if r.config.DeadLetterExchange == "" {
+		return fmt.Errorf("dead letter exchange is not configured")
+	}
+
+	r.handlersMux.Lock()
+	defer r.handlersMux.Unlock()
+
+	r.deadLetters[eventType] = append(r.deadLetters[eventType], handler)
+
+	// If the bus is already started, start a dead letter consumer for this event type
+	if conn := r.currentConnection(); conn != nil && !conn.IsClosed() && len(r.deadLetters[eventType]) == 1 {
+		if err := r.startDeadLetterConsumer(conn, eventType); err != nil {
+			return fmt.Errorf("failed to start dead letter consumer for %s: %w", eventType, err)
+		}
+	}
+
+	log.Printf("Subscribed dead letter handler %s to event type: %s", handler.HandlerName(), eventType)
+	return nil
+}
+
 // Unsubscribe removes an event handler for a specific event type
 func (r *RabbitMQEventBus) Unsubscribe(eventType string, handler EventHandler) error {
 	r.handlersMux.Lock()
@@ -224,8 +594,23 @@ func (r *RabbitMQEventBus) Unsubscribe(eventType string, handler EventHandler) e
 	return nil
 }
 
+// Subscriptions returns the names of the handlers subscribed to each event
+// type. Dead letter handlers are not included.
+func (r *RabbitMQEventBus) Subscriptions() map[string][]string {
+	r.handlersMux.RLock()
+	defer r.handlersMux.RUnlock()
+
+	return handlerNames(r.handlers)
+}
+
 // Health checks the health of the RabbitMQ connection
 func (r *RabbitMQEventBus) Health() error {
+	r.connMux.RLock()
+	defer r.connMux.RUnlock()
+
+	if r.reconnecting {
+		return fmt.Errorf("RabbitMQ connection is reconnecting")
+	}
 	if r.connection == nil || r.connection.IsClosed() {
 		return fmt.Errorf("RabbitMQ connection is closed")
 	}
@@ -235,47 +620,98 @@ func (r *RabbitMQEventBus) Health() error {
 	return nil
 }
 
+// currentConnection returns the active broker connection, if any
+func (r *RabbitMQEventBus) currentConnection() amqpConnection {
+	r.connMux.RLock()
+	defer r.connMux.RUnlock()
+	return r.connection
+}
+
 // startConsumer creates a consumer for a specific event type
-func (r *RabbitMQEventBus) startConsumer(eventType string) error {
+func (r *RabbitMQEventBus) startConsumer(conn amqpConnection, eventType string) error {
+	queueName := fmt.Sprintf("%s.%s", r.config.QueuePrefix, eventType)
+	workers := r.config.ConcurrencyFor(eventType)
+	msgs, ch, err := r.declareConsumer(conn, queueName, eventType, r.config.Exchange, r.queueArguments(), workers)
+	if err != nil {
+		return err
+	}
+
+	// Store consumer channel
+	r.consumersMux.Lock()
+	r.consumers[eventType] = ch
+	r.consumersMux.Unlock()
+
+	// Start goroutine to process messages
+	r.wg.Add(1)
+	go r.processMessages(eventType, msgs, ch, workers)
+
+	log.Printf("Started consumer for event type: %s on queue: %s with %d worker(s)", eventType, queueName, workers)
+	return nil
+}
+
+// startDeadLetterConsumer creates a consumer for dead-lettered events of a specific type
+func (r *RabbitMQEventBus) startDeadLetterConsumer(conn amqpConnection, eventType string) error {
+	queueName := r.deadLetterQueueName(eventType)
+	msgs, ch, err := r.declareConsumer(conn, queueName, eventType, r.config.DeadLetterExchange, nil, 1)
+	if err != nil {
+		return err
+	}
+
+	// Store consumer channel
+	r.consumersMux.Lock()
+	r.consumers[queueName] = ch
+	r.consumersMux.Unlock()
+
+	// Start goroutine to process dead letters
+	r.wg.Add(1)
+	go r.processDeadLetters(eventType, msgs, ch)
+
+	log.Printf("Started dead letter consumer for event type: %s on queue: %s", eventType, queueName)
+	return nil
+}
+
+// declareConsumer opens a channel, declares and binds a queue, and starts
+// consuming from it with up to prefetch unacknowledged deliveries
+func (r *RabbitMQEventBus) declareConsumer(conn amqpConnection, queueName, routingKey, exchange string, args amqp.Table, prefetch int) (<-chan amqp.Delivery, amqpChannel, error) {
 	// Create a new channel for this consumer
-	ch, err := r.connection.Channel()
+	ch, err := conn.Channel()
 	if err != nil {
-		return fmt.Errorf("failed to open consumer channel: %w", err)
+		return nil, nil, fmt.Errorf("failed to open consumer channel: %w", err)
 	}
 
-	// Set QoS to process one message at a time
-	err = ch.Qos(1, 0, false)
+	// Limit unacknowledged deliveries to the messages that can be handled at
+	// once, so the broker doesn't hand this consumer work it can't start
+	err = ch.Qos(prefetch, 0, false)
 	if err != nil {
 		ch.Close()
-		return fmt.Errorf("failed to set QoS: %w", err)
+		return nil, nil, fmt.Errorf("failed to set QoS: %w", err)
 	}
 
-	// Declare queue for this event type
-	queueName := fmt.Sprintf("%s.%s", r.config.QueuePrefix, eventType)
+	// Declare queue
 	queue, err := ch.QueueDeclare(
 		queueName,           // name
 		r.config.Durable,    // durable
 		r.config.AutoDelete, // delete when unused
 		r.config.Exclusive,  // exclusive
 		r.config.NoWait,     // no-wait
-		nil,                 // arguments
+		args,                // arguments
 	)
 	if err != nil {
 		ch.Close()
-		return fmt.Errorf("failed to declare queue: %w", err)
+		return nil, nil, fmt.Errorf("failed to declare queue: %w", err)
 	}
 
 	// Bind queue to exchange
 	err = ch.QueueBind(
-		queue.Name,        // queue name
-		eventType,         // routing key
-		r.config.Exchange, // exchange
-		r.config.NoWait,   // no-wait
-		nil,               // arguments
+		queue.Name,      // queue name
+		routingKey,      // routing key
+		exchange,        // exchange
+		r.config.NoWait, // no-wait
+		nil,             // arguments
 	)
 	if err != nil {
 		ch.Close()
-		return fmt.Errorf("failed to bind queue: %w", err)
+		return nil, nil, fmt.Errorf("failed to bind queue: %w", err)
 	}
 
 	// Start consuming messages
@@ -290,27 +726,49 @@ func (r *RabbitMQEventBus) startConsumer(eventType string) error {
 	)
 	if err != nil {
 		ch.Close()
-		return fmt.Errorf("failed to register consumer: %w", err)
+		return nil, nil, fmt.Errorf("failed to register consumer: %w", err)
 	}
 
-	// Store consumer channel
-	r.consumersMux.Lock()
-	r.consumers[eventType] = ch
-	r.consumersMux.Unlock()
+	return msgs, ch, nil
+}
 
-	// Start goroutine to process messages
-	r.wg.Add(1)
-	go r.processMessages(eventType, msgs, ch)
+// queueArguments returns the declaration arguments for event type queues.
+// Changing these for an existing queue requires deleting it first, since
+// RabbitMQ rejects redeclaration with different arguments.
+func (r *RabbitMQEventBus) queueArguments() amqp.Table {
+	if r.config.DeadLetterExchange == "" {
+		return nil
+	}
+	return amqp.Table{
+		"x-dead-letter-exchange": r.config.DeadLetterExchange,
+	}
+}
 
-	log.Printf("Started consumer for event type: %s on queue: %s", eventType, queueName)
-	return nil
+// deadLetterQueueName returns the queue that collects dead-lettered events of a type
+func (r *RabbitMQEventBus) deadLetterQueueName(eventType string) string {
+	return fmt.Sprintf("%s.dlq.%s", r.config.QueuePrefix, eventType)
 }
 
-// processMessages processes incoming messages for a specific event type
-func (r *RabbitMQEventBus) processMessages(eventType string, msgs <-chan amqp.Delivery, ch *amqp.Channel) {
+// processMessages processes incoming messages for a specific event type with
+// the given number of workers, closing the channel once they have all stopped
+func (r *RabbitMQEventBus) processMessages(eventType string, msgs <-chan amqp.Delivery, ch amqpChannel, workers int) {
 	defer r.wg.Done()
 	defer ch.Close()
 
+	var workersWG sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		workersWG.Add(1)
+		go func() {
+			defer workersWG.Done()
+			r.consumeMessages(eventType, msgs)
+		}()
+	}
+	workersWG.Wait()
+}
+
+// consumeMessages handles deliveries one at a time until the bus stops or
+// the delivery channel closes
+func (r *RabbitMQEventBus) consumeMessages(eventType string, msgs <-chan amqp.Delivery) {
 	for {
 		select {
 		case <-r.done:
@@ -322,38 +780,186 @@ func (r *RabbitMQEventBus) processMessages(eventType string, msgs <-chan amqp.De
 				return
 			}
 
-			// Process the message
-			if err := r.handleMessage(eventType, msg); err != nil {
-				log.Printf("Error handling message for event type %s: %v", eventType, err)
-
-				// Check if we should retry
-				envelope := &SerializableEventEnvelope{}
-				if json.Unmarshal(msg.Body, envelope) == nil && envelope.ShouldRetry() {
-					// Reject and requeue for retry
-					msg.Nack(false, true)
-				} else {
-					// Max retries exceeded or unmarshal error, reject without requeue
-					msg.Nack(false, false)
-				}
+			// Leave messages received while stopping for the next consumer
+			if r.stopping() {
+				msg.Nack(false, true)
+				return
+			}
+
+			r.processDelivery(eventType, msg)
+		}
+	}
+}
+
+// processDelivery handles a single delivery and acknowledges or rejects it
+func (r *RabbitMQEventBus) processDelivery(eventType string, msg amqp.Delivery) {
+	if err := r.handleMessage(eventType, msg); err != nil {
+		log.Printf("Error handling message for event type %s: %v", eventType, err)
+		r.rejectDelivery(eventType, msg)
+		return
+	}
+
+	// Acknowledge successful processing
+	r.clearDeliveryAttempts(msg)
+	msg.Ack(false)
+}
+
+// rejectDelivery nacks a failed message, requeueing it until the configured
+// delivery attempts are exhausted
+func (r *RabbitMQEventBus) rejectDelivery(eventType string, msg amqp.Delivery) {
+	if r.config.MaxDeliveryAttempts <= 0 {
+		// Check if we should retry
+		envelope := &SerializableEventEnvelope{}
+		if r.decode(msg, envelope) == nil && envelope.ShouldRetry() {
+			// Reject and requeue for retry
+			msg.Nack(false, true)
+		} else {
+			// Max retries exceeded or unmarshal error, reject without requeue
+			msg.Nack(false, false)
+		}
+		return
+	}
+
+	attempts := r.recordDeliveryAttempt(msg)
+	if attempts < r.config.MaxDeliveryAttempts {
+		msg.Nack(false, true)
+		return
+	}
+
+	r.clearDeliveryAttempts(msg)
+	if r.config.DeadLetterExchange != "" {
+		log.Printf("Event %s of type %s failed %d deliveries, routing to dead letter exchange %s",
+			msg.MessageId, eventType, attempts, r.config.DeadLetterExchange)
+	} else {
+		log.Printf("Event %s of type %s failed %d deliveries, discarding",
+			msg.MessageId, eventType, attempts)
+	}
+
+	// Without requeue RabbitMQ routes the message to the queue's dead letter exchange
+	msg.Nack(false, false)
+}
+
+// recordDeliveryAttempt increments and returns the number of failed deliveries for a message
+func (r *RabbitMQEventBus) recordDeliveryAttempt(msg amqp.Delivery) int {
+	r.attemptsMux.Lock()
+	defer r.attemptsMux.Unlock()
+
+	key := deliveryKey(msg)
+	r.attempts[key]++
+	return r.attempts[key] + deathCount(msg.Headers)
+}
+
+// clearDeliveryAttempts forgets the failed delivery count for a message
+func (r *RabbitMQEventBus) clearDeliveryAttempts(msg amqp.Delivery) {
+	r.attemptsMux.Lock()
+	defer r.attemptsMux.Unlock()
+
+	delete(r.attempts, deliveryKey(msg))
+}
+
+// deliveryKey identifies a message across redeliveries
+func deliveryKey(msg amqp.Delivery) string {
+	if msg.MessageId != "" {
+		return msg.MessageId
+	}
+	return string(msg.Body)
+}
+
+// deathCount returns how many times a message was previously dead-lettered,
+// as recorded by RabbitMQ in the x-death header
+func deathCount(headers amqp.Table) int {
+	deaths, ok := headers["x-death"].([]interface{})
+	if !ok {
+		return 0
+	}
+
+	total := 0
+	for _, death := range deaths {
+		table, ok := death.(amqp.Table)
+		if !ok {
+			continue
+		}
+		if count, ok := table["count"].(int64); ok {
+			total += int(count)
+		}
+	}
+	return total
+}
+
+// processDeadLetters processes dead-lettered messages for a specific event type
+func (r *RabbitMQEventBus) processDeadLetters(eventType string, msgs <-chan amqp.Delivery, ch amqpChannel) {
+	defer r.wg.Done()
+	defer ch.Close()
+
+	for {
+		select {
+		case <-r.done:
+			log.Printf("Stopping dead letter consumer for event type: %s", eventType)
+			return
+		case msg, ok := <-msgs:
+			if !ok {
+				log.Printf("Dead letter consumer channel closed for event type: %s", eventType)
+				return
+			}
+
+			if r.stopping() {
+				msg.Nack(false, true)
+				return
+			}
+
+			if err := r.dispatch(eventType, msg, r.deadLetters); err != nil {
+				log.Printf("Error handling dead letter for event type %s: %v", eventType, err)
+				// Requeueing would loop forever on the dead letter queue
+				msg.Nack(false, false)
 			} else {
-				// Acknowledge successful processing
 				msg.Ack(false)
 			}
 		}
 	}
 }
 
+// decode decompresses msg as its content encoding says and deserializes it
+// into envelope with the codec named by its content type, preferring the
+// configured codec when it matches
+func (r *RabbitMQEventBus) decode(msg amqp.Delivery, envelope *SerializableEventEnvelope) error {
+	body, err := decompress(msg.Body, msg.ContentEncoding)
+	if err != nil {
+		return err
+	}
+
+	codec := r.config.Codec
+	if codec == nil || msg.ContentType != codec.ContentType() {
+		if codec, err = CodecForContentType(msg.ContentType); err != nil {
+			return err
+		}
+	}
+	return codec.Decode(body, envelope)
+}
+
 // handleMessage processes a single message
 func (r *RabbitMQEventBus) handleMessage(eventType string, msg amqp.Delivery) error {
+	return r.dispatch(eventType, msg, r.handlers)
+}
+
+// dispatch deserializes a message and passes it to the handlers registered
+// for its event type in the given registry
+func (r *RabbitMQEventBus) dispatch(eventType string, msg amqp.Delivery, registry map[string][]EventHandler) error {
 	// Deserialize event envelope
 	var envelope SerializableEventEnvelope
-	if err := json.Unmarshal(msg.Body, &envelope); err != nil {
+	if err := r.decode(msg, &envelope); err != nil {
 		return fmt.Errorf("failed to deserialize event envelope: %w", err)
 	}
 
+	// Upcast payloads from older schema versions
+	if r.config.Upgraders != nil && envelope.Event != nil {
+		if err := r.config.Upgraders.Upgrade(envelope.Event); err != nil {
+			return err
+		}
+	}
+
 	// Get handlers for this event type
 	r.handlersMux.RLock()
-	handlers, exists := r.handlers[eventType]
+	handlers, exists := registry[eventType]
 	if !exists {
 		r.handlersMux.RUnlock()
 		log.Printf("No handlers registered for event type: %s", eventType)
@@ -368,7 +974,10 @@ func (r *RabbitMQEventBus) handleMessage(eventType string, msg amqp.Delivery) er
 	// Process with each handler
 	ctx := context.Background()
 	for _, handler := range handlersCopy {
-		if err := handler.Handle(ctx, envelope.Event); err != nil {
+		start := time.Now()
+		err := handleWithSpan(ctx, handler, envelope.Event)
+		r.observer.OnHandle(eventType, handler.HandlerName(), time.Since(start), err)
+		if err != nil {
 			log.Printf("Handler %s failed to process event %s: %v",
 				handler.HandlerName(), eventType, err)
 			return err
@@ -4,11 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sync"
 	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 // MockEventHandler implements EventHandler for testing
 type MockEventHandler struct {
+	mu            sync.Mutex
 	name          string
 	eventType     string
 	handledEvents []DomainEvent
@@ -24,6 +29,9 @@ func NewMockEventHandler(name, eventType string) *MockEventHandler {
 }
 
 func (m *MockEventHandler) Handle(ctx context.Context, event DomainEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if m.shouldError {
 		return fmt.Errorf("mock error")
 	}
@@ -40,11 +48,15 @@ func (m *MockEventHandler) HandlerName() string {
 }
 
 func (m *MockEventHandler) SetShouldError(shouldError bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.shouldError = shouldError
 }
 
 func (m *MockEventHandler) GetHandledEvents() []DomainEvent {
-	return m.handledEvents
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]DomainEvent(nil), m.handledEvents...)
 }
 
 // TestEvent implements DomainEvent for testing
@@ -132,6 +144,21 @@ func TestRabbitMQEventBus_Subscribe(t *testing.T) {
 	}
 }
 
+func TestRabbitMQEventBus_Subscriptions(t *testing.T) {
+	bus := NewRabbitMQEventBus(DefaultRabbitMQConfig())
+	bus.Subscribe("user.created", NewBaseEventHandler("user.created", "welcome-email"))
+	bus.Subscribe("user.deleted", NewBaseEventHandler("user.deleted", "session-cleanup"))
+	bus.Subscribe("user.created", NewBaseEventHandler("user.created", "audit"))
+
+	want := map[string][]string{
+		"user.created": {"welcome-email", "audit"},
+		"user.deleted": {"session-cleanup"},
+	}
+	if got := bus.Subscriptions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected subscriptions %v, got %v", want, got)
+	}
+}
+
 func TestRabbitMQEventBus_Unsubscribe(t *testing.T) {
 	config := DefaultRabbitMQConfig()
 	bus := NewRabbitMQEventBus(config)
@@ -182,6 +209,193 @@ func TestRabbitMQEventBus_Health_NotStarted(t *testing.T) {
 	}
 }
 
+// recordingAcknowledger implements amqp.Acknowledger and records outcomes
+type recordingAcknowledger struct {
+	acks     int
+	requeued int
+	rejected int
+}
+
+func (a *recordingAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acks++
+	return nil
+}
+
+func (a *recordingAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	if requeue {
+		a.requeued++
+	} else {
+		a.rejected++
+	}
+	return nil
+}
+
+func (a *recordingAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+func newTestDelivery(t *testing.T, ack amqp.Acknowledger, event DomainEvent) amqp.Delivery {
+	t.Helper()
+
+	envelope, err := NewSerializableEventEnvelope(event)
+	if err != nil {
+		t.Fatalf("Expected no error creating envelope, got %v", err)
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Expected no error marshaling envelope, got %v", err)
+	}
+
+	return amqp.Delivery{
+		Acknowledger: ack,
+		MessageId:    event.EventID(),
+		Body:         body,
+	}
+}
+
+func TestRabbitMQEventBus_QueueArguments_DeadLetterExchange(t *testing.T) {
+	config := DefaultRabbitMQConfig()
+	bus := NewRabbitMQEventBus(config)
+
+	if args := bus.queueArguments(); args != nil {
+		t.Errorf("Expected no queue arguments without dead letter exchange, got %v", args)
+	}
+
+	config.DeadLetterExchange = "events.dlx"
+	bus = NewRabbitMQEventBus(config)
+
+	args := bus.queueArguments()
+	if args["x-dead-letter-exchange"] != "events.dlx" {
+		t.Errorf("Expected queue to dead-letter into events.dlx, got %v", args["x-dead-letter-exchange"])
+	}
+
+	if name := bus.deadLetterQueueName("test.event"); name != "go-templ-template.dlq.test.event" {
+		t.Errorf("Expected dead letter queue name, got %s", name)
+	}
+}
+
+func TestRabbitMQEventBus_ProcessDelivery_DeadLettersAfterMaxAttempts(t *testing.T) {
+	config := DefaultRabbitMQConfig()
+	config.DeadLetterExchange = "events.dlx"
+	config.MaxDeliveryAttempts = 3
+	bus := NewRabbitMQEventBus(config)
+
+	handler := NewMockEventHandler("failing-handler", "test.event")
+	handler.SetShouldError(true)
+	bus.Subscribe("test.event", handler)
+
+	ack := &recordingAcknowledger{}
+	msg := newTestDelivery(t, ack, NewTestEvent("test-id", "test-data"))
+
+	// Simulate the broker redelivering the same message after each nack
+	for i := 0; i < config.MaxDeliveryAttempts; i++ {
+		bus.processDelivery("test.event", msg)
+	}
+
+	if ack.requeued != 2 {
+		t.Errorf("Expected 2 requeues before dead-lettering, got %d", ack.requeued)
+	}
+
+	if ack.rejected != 1 {
+		t.Errorf("Expected message to be rejected to the dead letter exchange once, got %d", ack.rejected)
+	}
+
+	if ack.acks != 0 {
+		t.Errorf("Expected no acks for a failing handler, got %d", ack.acks)
+	}
+
+	if len(bus.attempts) != 0 {
+		t.Errorf("Expected delivery attempts to be cleared after dead-lettering, got %v", bus.attempts)
+	}
+}
+
+func TestRabbitMQEventBus_ProcessDelivery_SuccessClearsAttempts(t *testing.T) {
+	config := DefaultRabbitMQConfig()
+	config.DeadLetterExchange = "events.dlx"
+	config.MaxDeliveryAttempts = 3
+	bus := NewRabbitMQEventBus(config)
+
+	handler := NewMockEventHandler("flaky-handler", "test.event")
+	handler.SetShouldError(true)
+	bus.Subscribe("test.event", handler)
+
+	ack := &recordingAcknowledger{}
+	msg := newTestDelivery(t, ack, NewTestEvent("test-id", "test-data"))
+
+	bus.processDelivery("test.event", msg)
+	handler.SetShouldError(false)
+	bus.processDelivery("test.event", msg)
+
+	if ack.requeued != 1 || ack.acks != 1 || ack.rejected != 0 {
+		t.Errorf("Expected one requeue then ack, got requeued=%d acks=%d rejected=%d",
+			ack.requeued, ack.acks, ack.rejected)
+	}
+
+	if len(bus.attempts) != 0 {
+		t.Errorf("Expected delivery attempts to be cleared after success, got %v", bus.attempts)
+	}
+}
+
+func TestRabbitMQEventBus_ProcessDelivery_CountsXDeath(t *testing.T) {
+	config := DefaultRabbitMQConfig()
+	config.DeadLetterExchange = "events.dlx"
+	config.MaxDeliveryAttempts = 3
+	bus := NewRabbitMQEventBus(config)
+
+	handler := NewMockEventHandler("failing-handler", "test.event")
+	handler.SetShouldError(true)
+	bus.Subscribe("test.event", handler)
+
+	ack := &recordingAcknowledger{}
+	msg := newTestDelivery(t, ack, NewTestEvent("test-id", "test-data"))
+	msg.Headers = amqp.Table{
+		"x-death": []interface{}{
+			amqp.Table{"count": int64(2), "reason": "rejected"},
+		},
+	}
+
+	bus.processDelivery("test.event", msg)
+
+	if ack.rejected != 1 {
+		t.Errorf("Expected previously dead-lettered message to be rejected immediately, got %d", ack.rejected)
+	}
+}
+
+func TestRabbitMQEventBus_SubscribeDeadLetter(t *testing.T) {
+	bus := NewRabbitMQEventBus(DefaultRabbitMQConfig())
+	handler := NewMockEventHandler("dlq-handler", "test.event")
+
+	if err := bus.SubscribeDeadLetter("test.event", handler); err == nil {
+		t.Error("Expected error subscribing to dead letters without a dead letter exchange")
+	}
+
+	config := DefaultRabbitMQConfig()
+	config.DeadLetterExchange = "events.dlx"
+	bus = NewRabbitMQEventBus(config)
+
+	if err := bus.SubscribeDeadLetter("test.event", handler); err != nil {
+		t.Fatalf("Expected no error subscribing to dead letters, got %v", err)
+	}
+
+	ack := &recordingAcknowledger{}
+	msg := newTestDelivery(t, ack, NewTestEvent("test-id", "test-data"))
+
+	if err := bus.dispatch("test.event", msg, bus.deadLetters); err != nil {
+		t.Fatalf("Expected no error dispatching dead letter, got %v", err)
+	}
+
+	if len(handler.GetHandledEvents()) != 1 {
+		t.Errorf("Expected dead letter handler to receive 1 event, got %d", len(handler.GetHandledEvents()))
+	}
+
+	bus.handlersMux.RLock()
+	_, subscribed := bus.handlers["test.event"]
+	bus.handlersMux.RUnlock()
+	if subscribed {
+		t.Error("Expected dead letter handler not to be registered as a regular handler")
+	}
+}
+
 func TestSerializableEvent_Creation(t *testing.T) {
 	testEvent := NewTestEvent("test-id", "test-data")
 
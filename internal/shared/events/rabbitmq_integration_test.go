@@ -116,3 +116,64 @@ func TestRabbitMQEventBus_Integration_PublishSubscribe(t *testing.T) {
 		}
 	}
 }
+
+func TestRabbitMQEventBus_Integration_DeadLetter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	t.Skip("Skipping integration test - requires RabbitMQ")
+
+	config := DefaultRabbitMQConfig()
+	config.QueuePrefix = fmt.Sprintf("test-%d", time.Now().UnixNano())
+	config.DeadLetterExchange = config.QueuePrefix + ".dlx"
+	config.MaxDeliveryAttempts = 2
+	bus := NewRabbitMQEventBus(config)
+
+	ctx := context.Background()
+
+	err := bus.Start(ctx)
+	if err != nil {
+		t.Skipf("Skipping integration test - RabbitMQ not available: %v", err)
+	}
+	defer bus.Stop(ctx)
+
+	failing := NewMockEventHandler("failing-handler", "integration.dlq")
+	failing.SetShouldError(true)
+	if err := bus.Subscribe("integration.dlq", failing); err != nil {
+		t.Fatalf("Expected no error subscribing, got: %v", err)
+	}
+
+	deadLetters := NewMockEventHandler("dead-letter-handler", "integration.dlq")
+	if err := bus.SubscribeDeadLetter("integration.dlq", deadLetters); err != nil {
+		t.Fatalf("Expected no error subscribing to dead letters, got: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	testEvent := NewTestEvent("integration-dlq-id", "integration-dlq-data")
+	testEvent.eventType = "integration.dlq"
+	if err := bus.Publish(ctx, testEvent); err != nil {
+		t.Fatalf("Expected no error publishing, got: %v", err)
+	}
+
+	timeout := time.After(5 * time.Second)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-timeout:
+			t.Fatal("Timeout waiting for event to reach the dead letter exchange")
+		case <-ticker.C:
+			handledEvents := deadLetters.GetHandledEvents()
+			if len(handledEvents) > 0 {
+				if handledEvents[0].EventID() != testEvent.EventID() {
+					t.Errorf("Expected event ID %s, got %s",
+						testEvent.EventID(), handledEvents[0].EventID())
+				}
+				return // Test passed
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package events
+
+import (
+	"context"
+
+	"go-templ-template/internal/shared/tracing"
+)
+
+// requestMetadataKey is the context key for RequestMetadata
+type requestMetadataKey struct{}
+
+// RequestMetadata identifies the HTTP request events are published from, so
+// downstream event processing can be tied back to it in logs
+type RequestMetadata struct {
+	// RequestID is the request's X-Request-ID
+	RequestID string
+
+	// TraceID is the distributed trace the request belongs to
+	TraceID string
+
+	// UserID identifies the authenticated user making the request
+	UserID string
+}
+
+// WithRequestMetadata returns a copy of ctx carrying metadata. Events
+// published with the returned context carry the metadata.
+func WithRequestMetadata(ctx context.Context, metadata RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, metadata)
+}
+
+// RequestMetadataFromContext returns the request metadata carried by ctx
+func RequestMetadataFromContext(ctx context.Context) (RequestMetadata, bool) {
+	metadata, ok := ctx.Value(requestMetadataKey{}).(RequestMetadata)
+	return metadata, ok
+}
+
+// WithRequestUserID returns a copy of ctx whose request metadata names
+// userID as the user making the request
+func WithRequestUserID(ctx context.Context, userID string) context.Context {
+	metadata, _ := RequestMetadataFromContext(ctx)
+	metadata.UserID = userID
+	return WithRequestMetadata(ctx, metadata)
+}
+
+// requestScopedEvent is an event published during a request, whose metadata
+// includes the request's metadata
+type requestScopedEvent struct {
+	DomainEvent
+	metadata EventMetadata
+}
+
+// Metadata returns the event's metadata with the request's metadata applied
+func (e *requestScopedEvent) Metadata() EventMetadata {
+	return e.metadata
+}
+
+// WithRequestScope returns event with the request metadata carried by ctx
+// copied into its EventMetadata. The request ID becomes the correlation ID,
+// and the cause unless the event already names one; the trace and user IDs
+// fill in the event's when it has none, the trace ID falling back to the
+// current span's. Events published outside a request and trace are returned
+// unchanged.
+func WithRequestScope(ctx context.Context, event DomainEvent) DomainEvent {
+	if event == nil {
+		return event
+	}
+
+	request, _ := RequestMetadataFromContext(ctx)
+	if request.TraceID == "" {
+		request.TraceID = tracing.SpanFromContext(ctx).SpanContext().TraceID
+	}
+	if request == (RequestMetadata{}) {
+		return event
+	}
+
+	metadata := event.Metadata()
+	if request.RequestID != "" {
+		metadata.CorrelationID = request.RequestID
+		if metadata.CausationID == "" {
+			metadata.CausationID = request.RequestID
+		}
+	}
+	if metadata.TraceID == "" {
+		metadata.TraceID = request.TraceID
+	}
+	if metadata.UserID == "" {
+		metadata.UserID = request.UserID
+	}
+
+	return &requestScopedEvent{DomainEvent: event, metadata: metadata}
+}
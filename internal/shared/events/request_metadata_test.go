@@ -0,0 +1,131 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func newRequestContext() context.Context {
+	return WithRequestMetadata(context.Background(), RequestMetadata{
+		RequestID: "req-123",
+		TraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+		UserID:    "user-123",
+	})
+}
+
+func assertRequestMetadata(t *testing.T, metadata EventMetadata) {
+	t.Helper()
+
+	if metadata.CorrelationID != "req-123" {
+		t.Errorf("Expected correlation ID req-123, got %q", metadata.CorrelationID)
+	}
+	if metadata.CausationID != "req-123" {
+		t.Errorf("Expected causation ID req-123, got %q", metadata.CausationID)
+	}
+	if metadata.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected trace ID from request, got %q", metadata.TraceID)
+	}
+	if metadata.UserID != "user-123" {
+		t.Errorf("Expected user ID user-123, got %q", metadata.UserID)
+	}
+}
+
+func TestWithRequestScope(t *testing.T) {
+	event := NewTestEvent("test-id", "test-data")
+
+	scoped := WithRequestScope(newRequestContext(), event)
+
+	assertRequestMetadata(t, scoped.Metadata())
+	if scoped.Metadata().Source != event.Metadata().Source {
+		t.Errorf("Expected source to be kept, got %q", scoped.Metadata().Source)
+	}
+	if scoped.EventID() != event.EventID() || scoped.EventType() != event.EventType() {
+		t.Error("Expected scoped event to keep the event's identity")
+	}
+}
+
+func TestWithRequestScope_KeepsEventCauseAndUser(t *testing.T) {
+	event := NewTestEvent("test-id", "test-data")
+	event.SetCausationID("event-456")
+	event.SetUserID("admin-1")
+
+	metadata := WithRequestScope(newRequestContext(), event).Metadata()
+
+	if metadata.CorrelationID != "req-123" {
+		t.Errorf("Expected correlation ID req-123, got %q", metadata.CorrelationID)
+	}
+	if metadata.CausationID != "event-456" {
+		t.Errorf("Expected causation ID to be kept, got %q", metadata.CausationID)
+	}
+	if metadata.UserID != "admin-1" {
+		t.Errorf("Expected user ID to be kept, got %q", metadata.UserID)
+	}
+}
+
+func TestWithRequestScope_OutsideRequest(t *testing.T) {
+	event := NewTestEvent("test-id", "test-data")
+
+	if scoped := WithRequestScope(context.Background(), event); scoped != DomainEvent(event) {
+		t.Error("Expected event to be returned unchanged outside a request")
+	}
+}
+
+func TestWithRequestUserID(t *testing.T) {
+	ctx := WithRequestUserID(WithRequestMetadata(context.Background(), RequestMetadata{RequestID: "req-123"}), "user-123")
+
+	metadata, ok := RequestMetadataFromContext(ctx)
+	if !ok {
+		t.Fatal("Expected request metadata in context")
+	}
+	if metadata.RequestID != "req-123" || metadata.UserID != "user-123" {
+		t.Errorf("Expected request and user IDs, got %+v", metadata)
+	}
+}
+
+func TestInMemoryEventBus_PublishCarriesRequestMetadata(t *testing.T) {
+	bus := newStartedInMemoryEventBus(t)
+	handler := NewMockEventHandler("test-handler", "test.event")
+	if err := bus.Subscribe("test.event", handler); err != nil {
+		t.Fatalf("Expected no error subscribing, got %v", err)
+	}
+
+	if err := bus.Publish(newRequestContext(), NewTestEvent("test-id", "test-data")); err != nil {
+		t.Fatalf("Expected no error publishing, got %v", err)
+	}
+
+	handled := handler.GetHandledEvents()
+	if len(handled) != 1 {
+		t.Fatalf("Expected 1 handled event, got %d", len(handled))
+	}
+	assertRequestMetadata(t, handled[0].Metadata())
+}
+
+func TestRabbitMQEventBus_PublishCarriesRequestMetadata(t *testing.T) {
+	bus := NewRabbitMQEventBus(DefaultRabbitMQConfig())
+	conn := &fakeConnection{}
+	bus.dial = func(url string) (amqpConnection, error) {
+		return conn, nil
+	}
+
+	ctx := context.Background()
+	if err := bus.Start(ctx); err != nil {
+		t.Fatalf("Expected no error starting bus, got %v", err)
+	}
+	defer bus.Stop(ctx)
+
+	if err := bus.Publish(newRequestContext(), NewTestEvent("test-id", "test-data")); err != nil {
+		t.Fatalf("Expected no error publishing, got %v", err)
+	}
+
+	channel := conn.publishChannel()
+	if channel.publishedCount() != 1 {
+		t.Fatalf("Expected 1 published message, got %d", channel.publishedCount())
+	}
+
+	var envelope SerializableEventEnvelope
+	if err := json.Unmarshal(channel.published[0].Body, &envelope); err != nil {
+		t.Fatalf("Expected published envelope, got %v", err)
+	}
+	assertRequestMetadata(t, envelope.Event.Meta)
+}
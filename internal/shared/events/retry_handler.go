@@ -0,0 +1,125 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	appErrors "go-templ-template/internal/shared/errors"
+)
+
+// Clock abstracts waiting so retry backoff can be controlled in tests
+type Clock interface {
+	// After waits for the duration to elapse and then sends the current time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock implements Clock using the time package
+type realClock struct{}
+
+// After waits for the duration using time.After
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// RetryPolicy controls how a RetryingHandler retries failed events
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+
+	// InitialDelay is the wait before the first retry
+	InitialDelay time.Duration
+
+	// MaxDelay caps the exponential backoff; zero means no cap
+	MaxDelay time.Duration
+
+	// Multiplier grows the delay after each retry; values below 1 default to 2
+	Multiplier float64
+}
+
+// DefaultRetryPolicy returns a retry policy with sensible defaults
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:   3,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     5 * time.Second,
+		Multiplier:   2,
+	}
+}
+
+// Delay returns the backoff before the given retry, starting at 1
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(p.InitialDelay)
+	for i := 1; i < retry; i++ {
+		delay *= multiplier
+		if p.MaxDelay > 0 && delay >= float64(p.MaxDelay) {
+			return p.MaxDelay
+		}
+	}
+	return time.Duration(delay)
+}
+
+// RetryingHandler wraps an EventHandler and retries Handle with exponential
+// backoff when it fails with a retryable error. It keeps the wrapped
+// handler's name and event type so it can replace it transparently.
+type RetryingHandler struct {
+	handler EventHandler
+	policy  RetryPolicy
+	clock   Clock
+}
+
+// NewRetryingHandler creates a new retrying handler with the given policy
+func NewRetryingHandler(handler EventHandler, policy RetryPolicy) *RetryingHandler {
+	return &RetryingHandler{
+		handler: handler,
+		policy:  policy,
+		clock:   realClock{},
+	}
+}
+
+// WithClock replaces the clock used to wait between attempts
+func (h *RetryingHandler) WithClock(clock Clock) *RetryingHandler {
+	h.clock = clock
+	return h
+}
+
+// Handle invokes the wrapped handler, retrying retryable errors until the
+// policy is exhausted or the context is cancelled
+func (h *RetryingHandler) Handle(ctx context.Context, event DomainEvent) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = h.handler.Handle(ctx, event)
+		if err == nil {
+			return nil
+		}
+
+		if !appErrors.IsRetryable(err) || attempt >= h.policy.MaxRetries {
+			return err
+		}
+
+		delay := h.policy.Delay(attempt + 1)
+		log.Printf("Handler %s failed to process event %s, retrying in %s (attempt %d/%d): %v",
+			h.handler.HandlerName(), event.EventType(), delay, attempt+1, h.policy.MaxRetries, err)
+
+		select {
+		case <-h.clock.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// EventType returns the type of event this handler processes
+func (h *RetryingHandler) EventType() string {
+	return h.handler.EventType()
+}
+
+// HandlerName returns the name of the wrapped handler
+func (h *RetryingHandler) HandlerName() string {
+	return h.handler.HandlerName()
+}
@@ -0,0 +1,196 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appErrors "go-templ-template/internal/shared/errors"
+)
+
+// fakeClock records requested delays and fires immediately
+type fakeClock struct {
+	delays []time.Duration
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+// blockingClock never fires so tests can exercise cancellation
+type blockingClock struct{}
+
+func (blockingClock) After(d time.Duration) <-chan time.Time {
+	return make(chan time.Time)
+}
+
+// scriptedHandler returns the queued errors in order, then nil
+type scriptedHandler struct {
+	*BaseEventHandler
+	errs  []error
+	calls int
+}
+
+func newScriptedHandler(errs ...error) *scriptedHandler {
+	return &scriptedHandler{
+		BaseEventHandler: NewBaseEventHandler("test.event", "scripted-handler"),
+		errs:             errs,
+	}
+}
+
+func (h *scriptedHandler) Handle(ctx context.Context, event DomainEvent) error {
+	h.calls++
+	if h.calls <= len(h.errs) {
+		return h.errs[h.calls-1]
+	}
+	return nil
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries:   5,
+		InitialDelay: 100 * time.Millisecond,
+		MaxDelay:     500 * time.Millisecond,
+		Multiplier:   2,
+	}
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		500 * time.Millisecond,
+		500 * time.Millisecond,
+	}
+
+	for i, want := range expected {
+		if got := policy.Delay(i + 1); got != want {
+			t.Errorf("Expected delay %s for retry %d, got %s", want, i+1, got)
+		}
+	}
+}
+
+func TestRetryingHandler_RetriesRetryableErrors(t *testing.T) {
+	retryable := appErrors.NewServiceUnavailableError("database")
+	inner := newScriptedHandler(retryable, retryable)
+	clock := &fakeClock{}
+
+	handler := NewRetryingHandler(inner, RetryPolicy{
+		MaxRetries:   3,
+		InitialDelay: 10 * time.Millisecond,
+		Multiplier:   2,
+	}).WithClock(clock)
+
+	err := handler.Handle(context.Background(), NewTestEvent("test-id", "test-data"))
+	if err != nil {
+		t.Fatalf("Expected success after retries, got %v", err)
+	}
+
+	if inner.calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", inner.calls)
+	}
+
+	expectedDelays := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond}
+	if len(clock.delays) != len(expectedDelays) {
+		t.Fatalf("Expected %d backoff waits, got %d", len(expectedDelays), len(clock.delays))
+	}
+	for i, want := range expectedDelays {
+		if clock.delays[i] != want {
+			t.Errorf("Expected backoff %s before retry %d, got %s", want, i+1, clock.delays[i])
+		}
+	}
+}
+
+func TestRetryingHandler_GivesUpAfterMaxRetries(t *testing.T) {
+	retryable := appErrors.NewTimeoutError("query", time.Second)
+	inner := newScriptedHandler(retryable, retryable, retryable, retryable)
+	clock := &fakeClock{}
+
+	handler := NewRetryingHandler(inner, RetryPolicy{
+		MaxRetries:   2,
+		InitialDelay: time.Millisecond,
+	}).WithClock(clock)
+
+	err := handler.Handle(context.Background(), NewTestEvent("test-id", "test-data"))
+	if err != retryable {
+		t.Errorf("Expected last retryable error, got %v", err)
+	}
+
+	if inner.calls != 3 {
+		t.Errorf("Expected 3 attempts (1 + 2 retries), got %d", inner.calls)
+	}
+
+	if len(clock.delays) != 2 {
+		t.Errorf("Expected 2 backoff waits, got %d", len(clock.delays))
+	}
+}
+
+func TestRetryingHandler_DoesNotRetryValidationErrors(t *testing.T) {
+	validationErr := appErrors.NewValidationError("INVALID_EVENT", "event payload is invalid")
+	inner := newScriptedHandler(validationErr)
+	clock := &fakeClock{}
+
+	handler := NewRetryingHandler(inner, DefaultRetryPolicy()).WithClock(clock)
+
+	err := handler.Handle(context.Background(), NewTestEvent("test-id", "test-data"))
+	if err != validationErr {
+		t.Errorf("Expected validation error, got %v", err)
+	}
+
+	if inner.calls != 1 {
+		t.Errorf("Expected validation error to fail fast after 1 attempt, got %d", inner.calls)
+	}
+
+	if len(clock.delays) != 0 {
+		t.Errorf("Expected no backoff waits, got %d", len(clock.delays))
+	}
+}
+
+func TestRetryingHandler_DoesNotRetryPlainErrors(t *testing.T) {
+	inner := newScriptedHandler(errors.New("boom"))
+
+	handler := NewRetryingHandler(inner, DefaultRetryPolicy()).WithClock(&fakeClock{})
+
+	if err := handler.Handle(context.Background(), NewTestEvent("test-id", "test-data")); err == nil {
+		t.Error("Expected error from handler")
+	}
+
+	if inner.calls != 1 {
+		t.Errorf("Expected 1 attempt, got %d", inner.calls)
+	}
+}
+
+func TestRetryingHandler_RespectsContextCancellation(t *testing.T) {
+	retryable := appErrors.NewServiceUnavailableError("database")
+	inner := newScriptedHandler(retryable, retryable)
+
+	handler := NewRetryingHandler(inner, DefaultRetryPolicy()).WithClock(blockingClock{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := handler.Handle(ctx, NewTestEvent("test-id", "test-data"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context cancellation error, got %v", err)
+	}
+
+	if inner.calls != 1 {
+		t.Errorf("Expected no retries after cancellation, got %d attempts", inner.calls)
+	}
+}
+
+func TestRetryingHandler_DelegatesIdentity(t *testing.T) {
+	inner := newScriptedHandler()
+	handler := NewRetryingHandler(inner, DefaultRetryPolicy())
+
+	if handler.HandlerName() != inner.HandlerName() {
+		t.Errorf("Expected handler name %s, got %s", inner.HandlerName(), handler.HandlerName())
+	}
+
+	if handler.EventType() != inner.EventType() {
+		t.Errorf("Expected event type %s, got %s", inner.EventType(), handler.EventType())
+	}
+}
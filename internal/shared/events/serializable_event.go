@@ -85,6 +85,10 @@ type SerializableEventEnvelope struct {
 	Timestamp time.Time          `json:"timestamp"`
 	Retry     int                `json:"retry"`
 	MaxRetry  int                `json:"max_retry"`
+
+	// DedupKey is the event's deduplication key, for consumers that read
+	// the envelope without decoding the event
+	DedupKey string `json:"dedup_key,omitempty"`
 }
 
 // NewSerializableEventEnvelope creates a new serializable event envelope
@@ -99,6 +103,7 @@ func NewSerializableEventEnvelope(event DomainEvent) (*SerializableEventEnvelope
 		Timestamp: time.Now().UTC(),
 		Retry:     0,
 		MaxRetry:  3,
+		DedupKey:  DedupKey(event),
 	}, nil
 }
 
@@ -0,0 +1,38 @@
+package events
+
+import (
+	"context"
+
+	"go-templ-template/internal/shared/tracing"
+)
+
+// startPublishSpan starts the span covering publishing event
+func startPublishSpan(ctx context.Context, event DomainEvent) (context.Context, tracing.Span) {
+	ctx, span := tracing.Start(ctx, "events.publish "+event.EventType())
+	span.SetAttribute("event.id", event.EventID())
+	span.SetAttribute("event.type", event.EventType())
+	span.SetAttribute("event.aggregate_id", event.AggregateID())
+	return ctx, span
+}
+
+// handleWithSpan passes event to handler within a span. Events consumed
+// outside the publishing process continue the trace named by their metadata.
+func handleWithSpan(ctx context.Context, handler EventHandler, event DomainEvent) error {
+	if _, ok := tracing.ParentFromContext(ctx); !ok && event != nil {
+		ctx = tracing.ContextWithRemoteParent(ctx, tracing.SpanContext{TraceID: event.Metadata().TraceID})
+	}
+
+	ctx, span := tracing.Start(ctx, "events.handle "+handler.HandlerName())
+	defer span.End()
+	span.SetAttribute("event.handler", handler.HandlerName())
+	if event != nil {
+		span.SetAttribute("event.id", event.EventID())
+		span.SetAttribute("event.type", event.EventType())
+	}
+
+	err := handler.Handle(ctx, event)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
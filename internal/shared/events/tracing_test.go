@@ -0,0 +1,93 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"go-templ-template/internal/shared/tracing"
+)
+
+func useSpanRecorder(t *testing.T) *tracing.Recorder {
+	t.Helper()
+
+	recorder := tracing.NewRecorder()
+	tracing.SetTracer(recorder)
+	t.Cleanup(func() { tracing.SetTracer(nil) })
+	return recorder
+}
+
+func TestInMemoryEventBus_PublishCreatesSpans(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	bus := newStartedInMemoryEventBus(t)
+	handler := NewMockEventHandler("traced-handler", "test.event")
+	if err := bus.Subscribe("test.event", handler); err != nil {
+		t.Fatalf("Expected no error subscribing, got %v", err)
+	}
+
+	ctx, request := tracing.Start(context.Background(), "request")
+	if err := bus.Publish(ctx, NewTestEvent("agg-1", "data")); err != nil {
+		t.Fatalf("Expected no error publishing, got %v", err)
+	}
+	request.End()
+
+	publish := recorder.Find("events.publish test.event")
+	handle := recorder.Find("events.handle traced-handler")
+	if publish == nil || handle == nil {
+		t.Fatalf("Expected publish and handle spans, got %d spans", len(recorder.Ended()))
+	}
+	if !publish.IsChildOf(recorder.Find("request")) {
+		t.Error("Expected publish span to be a child of the request span")
+	}
+	if !handle.IsChildOf(publish) {
+		t.Error("Expected handle span to be a child of the publish span")
+	}
+
+	handled := handler.GetHandledEvents()
+	if len(handled) != 1 {
+		t.Fatalf("Expected 1 handled event, got %d", len(handled))
+	}
+	if traceID := handled[0].Metadata().TraceID; traceID != publish.Context.TraceID {
+		t.Errorf("Expected event trace ID %s, got %q", publish.Context.TraceID, traceID)
+	}
+}
+
+func TestInMemoryEventBus_PublishWithoutTracer(t *testing.T) {
+	bus := newStartedInMemoryEventBus(t)
+	handler := NewMockEventHandler("untraced-handler", "test.event")
+	if err := bus.Subscribe("test.event", handler); err != nil {
+		t.Fatalf("Expected no error subscribing, got %v", err)
+	}
+
+	if err := bus.Publish(context.Background(), NewTestEvent("agg-1", "data")); err != nil {
+		t.Fatalf("Expected no error publishing, got %v", err)
+	}
+
+	handled := handler.GetHandledEvents()
+	if len(handled) != 1 {
+		t.Fatalf("Expected 1 handled event, got %d", len(handled))
+	}
+	if traceID := handled[0].Metadata().TraceID; traceID != "" {
+		t.Errorf("Expected no trace ID without a tracer, got %q", traceID)
+	}
+}
+
+func TestHandleWithSpan_ContinuesEventTrace(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	event := WithRequestScope(
+		WithRequestMetadata(context.Background(), RequestMetadata{TraceID: traceID}),
+		NewTestEvent("agg-1", "data"),
+	)
+
+	if err := handleWithSpan(context.Background(), NewMockEventHandler("consumer", "test.event"), event); err != nil {
+		t.Fatalf("Expected no error handling, got %v", err)
+	}
+
+	handle := recorder.Find("events.handle consumer")
+	if handle == nil {
+		t.Fatal("Expected a handle span")
+	}
+	if handle.Context.TraceID != traceID {
+		t.Errorf("Expected handle span in trace %s, got %s", traceID, handle.Context.TraceID)
+	}
+}
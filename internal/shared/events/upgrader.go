@@ -0,0 +1,82 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// EventUpgrader upcasts a payload serialized at the given schema version to
+// the current shape for its event type
+type EventUpgrader func(version int, raw json.RawMessage) (json.RawMessage, error)
+
+// registeredUpgrader pairs an upgrader with the schema version it produces
+type registeredUpgrader struct {
+	currentVersion int
+	upgrade        EventUpgrader
+}
+
+// EventUpgraderRegistry holds payload upgraders per event type
+type EventUpgraderRegistry struct {
+	upgraders map[string]registeredUpgrader
+	mu        sync.RWMutex
+}
+
+// NewEventUpgraderRegistry creates an empty upgrader registry
+func NewEventUpgraderRegistry() *EventUpgraderRegistry {
+	return &EventUpgraderRegistry{
+		upgraders: make(map[string]registeredUpgrader),
+	}
+}
+
+// Register sets the upgrader for an event type. Payloads with a schema
+// version below currentVersion are passed through it on consume.
+func (r *EventUpgraderRegistry) Register(eventType string, currentVersion int, upgrader EventUpgrader) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.upgraders[eventType] = registeredUpgrader{
+		currentVersion: currentVersion,
+		upgrade:        upgrader,
+	}
+}
+
+// Upgrade migrates the event's payload in place if an upgrader is registered
+// for its type and its schema version is older than the current one. Events
+// without a schema version are treated as version 1.
+func (r *EventUpgraderRegistry) Upgrade(event *SerializableEvent) error {
+	r.mu.RLock()
+	upgrader, exists := r.upgraders[event.Type]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil
+	}
+
+	version := event.Meta.SchemaVersion
+	if version == 0 {
+		version = 1
+	}
+	if version >= upgrader.currentVersion {
+		return nil
+	}
+
+	raw, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to serialize %s payload: %w", event.Type, err)
+	}
+
+	upgraded, err := upgrader.upgrade(version, raw)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade %s payload from schema version %d: %w", event.Type, version, err)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(upgraded, &data); err != nil {
+		return fmt.Errorf("failed to deserialize upgraded %s payload: %w", event.Type, err)
+	}
+
+	event.Data = data
+	event.Meta.SchemaVersion = upgrader.currentVersion
+	return nil
+}
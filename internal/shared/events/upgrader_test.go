@@ -0,0 +1,159 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// renameTestDataUpgrader moves test_data to payload, the v2 shape of test.event
+func renameTestDataUpgrader(version int, raw json.RawMessage) (json.RawMessage, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+
+	if version < 2 {
+		data["payload"] = data["test_data"]
+		delete(data, "test_data")
+	}
+
+	return json.Marshal(data)
+}
+
+func TestBaseEvent_SchemaVersion(t *testing.T) {
+	event := NewTestEvent("test-id", "test-data")
+
+	if event.Metadata().SchemaVersion != 1 {
+		t.Errorf("Expected default schema version 1, got %d", event.Metadata().SchemaVersion)
+	}
+
+	event.SetSchemaVersion(3)
+	envelope, err := NewSerializableEventEnvelope(event)
+	if err != nil {
+		t.Fatalf("Expected no error creating envelope, got %v", err)
+	}
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatalf("Expected no error marshaling envelope, got %v", err)
+	}
+
+	var decoded SerializableEventEnvelope
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Expected no error unmarshaling envelope, got %v", err)
+	}
+	if decoded.Event.Meta.SchemaVersion != 3 {
+		t.Errorf("Expected schema version 3 in envelope, got %d", decoded.Event.Meta.SchemaVersion)
+	}
+}
+
+func TestEventUpgraderRegistry_Upgrade(t *testing.T) {
+	registry := NewEventUpgraderRegistry()
+	registry.Register("test.event", 2, renameTestDataUpgrader)
+
+	event, err := NewSerializableEvent(NewTestEvent("test-id", "test-data"))
+	if err != nil {
+		t.Fatalf("Expected no error creating event, got %v", err)
+	}
+
+	if err := registry.Upgrade(event); err != nil {
+		t.Fatalf("Expected no error upgrading, got %v", err)
+	}
+
+	if event.Data["payload"] != "test-data" {
+		t.Errorf("Expected payload to be migrated, got %v", event.Data)
+	}
+	if _, exists := event.Data["test_data"]; exists {
+		t.Error("Expected test_data to be removed")
+	}
+	if event.Meta.SchemaVersion != 2 {
+		t.Errorf("Expected schema version 2 after upgrade, got %d", event.Meta.SchemaVersion)
+	}
+}
+
+func TestEventUpgraderRegistry_SkipsCurrentVersion(t *testing.T) {
+	registry := NewEventUpgraderRegistry()
+	called := false
+	registry.Register("test.event", 2, func(version int, raw json.RawMessage) (json.RawMessage, error) {
+		called = true
+		return raw, nil
+	})
+
+	source := NewTestEvent("test-id", "test-data")
+	source.SetSchemaVersion(2)
+	event, _ := NewSerializableEvent(source)
+
+	if err := registry.Upgrade(event); err != nil {
+		t.Fatalf("Expected no error upgrading, got %v", err)
+	}
+	if called {
+		t.Error("Expected upgrader not to run for current schema version")
+	}
+}
+
+func TestEventUpgraderRegistry_LegacyEventsTreatedAsV1(t *testing.T) {
+	registry := NewEventUpgraderRegistry()
+	var seen int
+	registry.Register("test.event", 2, func(version int, raw json.RawMessage) (json.RawMessage, error) {
+		seen = version
+		return raw, nil
+	})
+
+	event, _ := NewSerializableEvent(NewTestEvent("test-id", "test-data"))
+	event.Meta.SchemaVersion = 0
+
+	if err := registry.Upgrade(event); err != nil {
+		t.Fatalf("Expected no error upgrading, got %v", err)
+	}
+	if seen != 1 {
+		t.Errorf("Expected upgrader to see version 1, got %d", seen)
+	}
+}
+
+func TestEventUpgraderRegistry_UpgraderError(t *testing.T) {
+	registry := NewEventUpgraderRegistry()
+	upgradeErr := errors.New("unsupported version")
+	registry.Register("test.event", 2, func(version int, raw json.RawMessage) (json.RawMessage, error) {
+		return nil, upgradeErr
+	})
+
+	event, _ := NewSerializableEvent(NewTestEvent("test-id", "test-data"))
+
+	if err := registry.Upgrade(event); !errors.Is(err, upgradeErr) {
+		t.Errorf("Expected upgrader error, got %v", err)
+	}
+}
+
+func TestRabbitMQEventBus_UpgradesPayloadBeforeHandler(t *testing.T) {
+	registry := NewEventUpgraderRegistry()
+	registry.Register("test.event", 2, renameTestDataUpgrader)
+
+	config := DefaultRabbitMQConfig()
+	config.Upgraders = registry
+	bus := NewRabbitMQEventBus(config)
+
+	handler := NewMockEventHandler("test-handler", "test.event")
+	bus.Subscribe("test.event", handler)
+
+	ack := &recordingAcknowledger{}
+	bus.processDelivery("test.event", newTestDelivery(t, ack, NewTestEvent("test-id", "test-data")))
+
+	handled := handler.GetHandledEvents()
+	if len(handled) != 1 {
+		t.Fatalf("Expected 1 handled event, got %d", len(handled))
+	}
+
+	data, ok := handled[0].EventData().(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected map payload, got %T", handled[0].EventData())
+	}
+	if data["payload"] != "test-data" {
+		t.Errorf("Expected handler to receive migrated payload, got %v", data)
+	}
+	if _, exists := data["test_data"]; exists {
+		t.Error("Expected handler not to receive v1 field")
+	}
+	if handled[0].Metadata().SchemaVersion != 2 {
+		t.Errorf("Expected handler to see schema version 2, got %d", handled[0].Metadata().SchemaVersion)
+	}
+}
@@ -0,0 +1,197 @@
+// Package flash stores one-time messages, such as "Profile saved", in a
+// cookie between a POST and the page the client is redirected to. The cookie
+// is signed, so clients can't forge messages that appear to come from the
+// application.
+package flash
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// CookieName is the name of the cookie holding pending flash messages
+	CookieName = "flash"
+
+	// ContextKey is the Echo context key the middleware stores messages under
+	ContextKey = "flash_messages"
+
+	// cookieMaxAge bounds how long an unread flash survives, in seconds
+	cookieMaxAge = 300
+
+	// pendingKey holds messages added during the current request, so several
+	// calls to Add end up in the same cookie
+	pendingKey = "flash_pending"
+)
+
+// signingKey signs flash cookies once SetSigningSecret is called
+var signingKey atomic.Pointer[[]byte]
+
+// fallbackSigningKey signs flash cookies until SetSigningSecret is called. It
+// is random per process, so only that process can read the flashes it sets;
+// it suits tests and single development servers.
+var fallbackSigningKey = newSigningKey()
+
+func newSigningKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic("flash: failed to generate signing key: " + err.Error())
+	}
+	return key
+}
+
+// SetSigningSecret derives the key flash cookies are signed with from the
+// application secret. Every instance behind a load balancer must use the same
+// secret, so a flash set by one instance can be read by another and survives
+// restarts.
+func SetSigningSecret(secret []byte) {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("flash cookie signing key"))
+	key := mac.Sum(nil)
+	signingKey.Store(&key)
+}
+
+// currentSigningKey returns the key flash cookies are signed with
+func currentSigningKey() []byte {
+	if key := signingKey.Load(); key != nil {
+		return *key
+	}
+	return fallbackSigningKey
+}
+
+// Message types understood by the Toast component
+const (
+	TypeSuccess = "success"
+	TypeError   = "error"
+	TypeWarning = "warning"
+	TypeInfo    = "info"
+)
+
+// Message is a flash message shown once on the next page
+type Message struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// Add queues a message to be shown on the next page the client loads
+func Add(c echo.Context, messageType, message string) {
+	pending, _ := c.Get(pendingKey).([]Message)
+	pending = append(pending, Message{Type: messageType, Message: message})
+	c.Set(pendingKey, pending)
+
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return
+	}
+	setCookie(c, encode(data), cookieMaxAge)
+}
+
+// Success queues a success message
+func Success(c echo.Context, message string) {
+	Add(c, TypeSuccess, message)
+}
+
+// Error queues an error message
+func Error(c echo.Context, message string) {
+	Add(c, TypeError, message)
+}
+
+// Info queues an informational message
+func Info(c echo.Context, message string) {
+	Add(c, TypeInfo, message)
+}
+
+// Get returns the messages stored by the previous request and clears them,
+// so each message is read only once. A malformed cookie, or one whose
+// signature doesn't match, is discarded.
+func Get(c echo.Context) []Message {
+	cookie, err := c.Cookie(CookieName)
+	if err != nil || cookie.Value == "" {
+		return nil
+	}
+	setCookie(c, "", -1)
+
+	data, ok := decode(cookie.Value)
+	if !ok {
+		return nil
+	}
+	var messages []Message
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil
+	}
+	return messages
+}
+
+// Middleware reads and clears the flash messages on GET requests and stores
+// them in the Echo context for templates. Other methods leave the cookie in
+// place so messages set before a redirect survive until the next page.
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if c.Request().Method == http.MethodGet {
+			c.Set(ContextKey, Get(c))
+		}
+		return next(c)
+	}
+}
+
+// FromContext returns the messages loaded by the middleware
+func FromContext(c echo.Context) []Message {
+	messages, _ := c.Get(ContextKey).([]Message)
+	return messages
+}
+
+// encode returns data and its signature as a cookie value
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data) + "." +
+		base64.RawURLEncoding.EncodeToString(sign(data))
+}
+
+// decode returns the data in a cookie value written by encode, reporting
+// whether it is well-formed and correctly signed
+func decode(value string) ([]byte, bool) {
+	encodedData, encodedSignature, found := strings.Cut(value, ".")
+	if !found {
+		return nil, false
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encodedData)
+	if err != nil {
+		return nil, false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return nil, false
+	}
+	if !hmac.Equal(signature, sign(data)) {
+		return nil, false
+	}
+	return data, true
+}
+
+// sign returns the HMAC-SHA256 of data
+func sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, currentSigningKey())
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// setCookie writes the flash cookie
+func setCookie(c echo.Context, value string, maxAge int) {
+	cookie := &http.Cookie{
+		Name:     CookieName,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   c.Request().TLS != nil, // Only secure in HTTPS
+		SameSite: http.SameSiteLaxMode,
+	}
+	c.SetCookie(cookie)
+}
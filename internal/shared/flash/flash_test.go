@@ -0,0 +1,175 @@
+package flash
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFlashTestServer(seen *[]Message) *echo.Echo {
+	e := echo.New()
+	e.Use(Middleware)
+
+	e.POST("/profile", func(c echo.Context) error {
+		Success(c, "Profile saved")
+		Info(c, "Your email is unchanged")
+		return c.Redirect(http.StatusSeeOther, "/profile")
+	})
+	e.GET("/profile", func(c echo.Context) error {
+		*seen = FromContext(c)
+		return c.NoContent(http.StatusOK)
+	})
+
+	return e
+}
+
+// flashCookie returns the flash cookie set by rec, if any
+func flashCookie(rec *httptest.ResponseRecorder) *http.Cookie {
+	var found *http.Cookie
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == CookieName {
+			found = cookie
+		}
+	}
+	return found
+}
+
+func TestFlash_RoundTrip(t *testing.T) {
+	var seen []Message
+	e := newFlashTestServer(&seen)
+
+	// Set on POST
+	req := httptest.NewRequest(http.MethodPost, "/profile", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusSeeOther, rec.Code)
+
+	cookie := flashCookie(rec)
+	require.NotNil(t, cookie)
+	assert.True(t, cookie.HttpOnly)
+	assert.Positive(t, cookie.MaxAge)
+
+	// Read on the redirected GET
+	req = httptest.NewRequest(http.MethodGet, "/profile", nil)
+	req.AddCookie(&http.Cookie{Name: CookieName, Value: cookie.Value})
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, []Message{
+		{Type: TypeSuccess, Message: "Profile saved"},
+		{Type: TypeInfo, Message: "Your email is unchanged"},
+	}, seen)
+
+	cleared := flashCookie(rec)
+	require.NotNil(t, cleared)
+	assert.Empty(t, cleared.Value)
+	assert.Negative(t, cleared.MaxAge)
+
+	// The next GET, without the cleared cookie, has no messages
+	seen = nil
+	req = httptest.NewRequest(http.MethodGet, "/profile", nil)
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Empty(t, seen)
+	assert.Nil(t, flashCookie(rec))
+}
+
+func TestFlash_MalformedCookie(t *testing.T) {
+	var seen []Message
+	e := newFlashTestServer(&seen)
+
+	for _, value := range []string{"not base64!", "bm90IGpzb24", encode([]byte("not json"))} {
+		req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+		req.AddCookie(&http.Cookie{Name: CookieName, Value: value})
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, seen)
+
+		// The bad cookie is still cleared
+		cleared := flashCookie(rec)
+		require.NotNil(t, cleared)
+		assert.Negative(t, cleared.MaxAge)
+	}
+}
+
+func TestFlash_RejectsForgedCookie(t *testing.T) {
+	var seen []Message
+	e := newFlashTestServer(&seen)
+
+	forged := []byte(`[{"type":"success","message":"Your account has been verified"}]`)
+	signed := encode([]byte(`[{"type":"info","message":"Profile saved"}]`))
+	_, signature, _ := strings.Cut(signed, ".")
+
+	for name, value := range map[string]string{
+		"unsigned":       base64.RawURLEncoding.EncodeToString(forged),
+		"bad signature":  base64.RawURLEncoding.EncodeToString(forged) + ".c2lnbmF0dXJl",
+		"tampered value": base64.RawURLEncoding.EncodeToString(forged) + "." + signature,
+	} {
+		t.Run(name, func(t *testing.T) {
+			seen = nil
+			req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+			req.AddCookie(&http.Cookie{Name: CookieName, Value: value})
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Empty(t, seen)
+
+			// The forged cookie is still cleared
+			cleared := flashCookie(rec)
+			require.NotNil(t, cleared)
+			assert.Negative(t, cleared.MaxAge)
+		})
+	}
+}
+
+func TestSetSigningSecret(t *testing.T) {
+	t.Cleanup(func() { signingKey.Store(nil) })
+
+	// A flash set by one instance is read by another sharing the secret
+	SetSigningSecret([]byte("0123456789abcdef0123456789abcdef"))
+	value := encode([]byte(`[{"type":"success","message":"Profile saved"}]`))
+	signingKey.Store(nil)
+
+	_, ok := decode(value)
+	assert.False(t, ok, "the per-process key should not verify the cookie")
+
+	SetSigningSecret([]byte("0123456789abcdef0123456789abcdef"))
+	data, ok := decode(value)
+	require.True(t, ok)
+	assert.JSONEq(t, `[{"type":"success","message":"Profile saved"}]`, string(data))
+
+	// Another secret doesn't verify it
+	SetSigningSecret([]byte("fedcba9876543210fedcba9876543210"))
+	_, ok = decode(value)
+	assert.False(t, ok)
+}
+
+func TestMiddleware_LeavesFlashOnNonGET(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware)
+
+	var seen []Message
+	e.PUT("/profile", func(c echo.Context) error {
+		seen = FromContext(c)
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/profile", nil)
+	req.AddCookie(&http.Cookie{Name: CookieName, Value: "W10"})
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Nil(t, seen)
+	assert.Nil(t, flashCookie(rec))
+}
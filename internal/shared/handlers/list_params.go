@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// DefaultListLimit is the page size of list endpoints when the request
+	// doesn't set one
+	DefaultListLimit = 20
+
+	// MaxListLimit is the largest page size list endpoints return; larger
+	// limits are reduced to it
+	MaxListLimit = 100
+
+	// MaxListSearchLength is the longest search term list endpoints accept
+	MaxListSearchLength = 100
+)
+
+// ListParams are the pagination, search and sort query parameters of a
+// list endpoint
+type ListParams struct {
+	// Page is the 1-based page requested
+	Page int
+
+	// Limit is the page size, between 1 and MaxListLimit
+	Limit int
+
+	// Offset is the number of items before the page
+	Offset int
+
+	// Search is the trimmed search term, empty for none
+	Search string
+
+	// Sort is the field to sort by, empty for the endpoint's default order
+	Sort string
+
+	// SortDesc sorts in descending order
+	SortDesc bool
+}
+
+// BindListParams reads the page, limit, offset, search and sort query
+// parameters shared by list endpoints. Page defaults to 1 and limit to
+// DefaultListLimit, and limits above MaxListLimit are reduced to it; an
+// offset, if given, replaces the page. Sort names one of sortFields,
+// prefixed with - to sort in descending order, e.g. sort=-created_at.
+// Invalid parameters are returned together as an *errors.ErrorList of
+// validation errors whose details name the parameter.
+func BindListParams(c echo.Context, sortFields ...string) (ListParams, error) {
+	params := ListParams{Page: 1, Limit: DefaultListLimit}
+	problems := &errors.ErrorList{}
+
+	invalid := func(param, message string) {
+		problems.Add(errors.NewValidationErrorWithDetails("INVALID_LIST_PARAMETER", message,
+			map[string]interface{}{"field": param}))
+	}
+
+	if value := c.QueryParam("page"); value != "" {
+		page, err := strconv.Atoi(value)
+		if err != nil || page < 1 {
+			invalid("page", "page must be a positive integer")
+		} else {
+			params.Page = page
+		}
+	}
+
+	if value := c.QueryParam("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			invalid("limit", "limit must be a positive integer")
+		} else {
+			params.Limit = min(limit, MaxListLimit)
+		}
+	}
+
+	params.Offset = (params.Page - 1) * params.Limit
+	if value := c.QueryParam("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			invalid("offset", "offset cannot be negative")
+		} else {
+			params.Offset = offset
+			params.Page = offset/params.Limit + 1
+		}
+	}
+
+	params.Search = strings.TrimSpace(c.QueryParam("search"))
+	if len(params.Search) > MaxListSearchLength {
+		invalid("search", fmt.Sprintf("search cannot be longer than %d characters", MaxListSearchLength))
+	}
+
+	if value := c.QueryParam("sort"); value != "" {
+		field, desc := strings.CutPrefix(value, "-")
+		if slices.Contains(sortFields, field) {
+			params.Sort, params.SortDesc = field, desc
+		} else if len(sortFields) == 0 {
+			invalid("sort", "sorting is not supported")
+		} else {
+			invalid("sort", "sort must be one of "+strings.Join(sortFields, ", "))
+		}
+	}
+
+	if problems.HasErrors() {
+		return ListParams{}, problems
+	}
+	return params, nil
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bindListParams(query string, sortFields ...string) (ListParams, error) {
+	req := httptest.NewRequest(http.MethodGet, "/items"+query, nil)
+	c := echo.New().NewContext(req, httptest.NewRecorder())
+	return BindListParams(c, sortFields...)
+}
+
+func TestBindListParams_Defaults(t *testing.T) {
+	params, err := bindListParams("", "created_at")
+
+	require.NoError(t, err)
+	assert.Equal(t, ListParams{Page: 1, Limit: DefaultListLimit, Offset: 0}, params)
+}
+
+func TestBindListParams_ClampsLimit(t *testing.T) {
+	params, err := bindListParams("?limit=500&page=3")
+
+	require.NoError(t, err)
+	assert.Equal(t, MaxListLimit, params.Limit)
+	assert.Equal(t, 2*MaxListLimit, params.Offset)
+}
+
+func TestBindListParams_FullSet(t *testing.T) {
+	params, err := bindListParams("?page=2&limit=10&search=+jane+&sort=-email", "created_at", "email")
+
+	require.NoError(t, err)
+	assert.Equal(t, ListParams{
+		Page:     2,
+		Limit:    10,
+		Offset:   10,
+		Search:   "jane",
+		Sort:     "email",
+		SortDesc: true,
+	}, params)
+
+	params, err = bindListParams("?limit=10&offset=25&sort=created_at", "created_at", "email")
+	require.NoError(t, err)
+	assert.Equal(t, 25, params.Offset)
+	assert.Equal(t, 3, params.Page)
+	assert.Equal(t, "created_at", params.Sort)
+	assert.False(t, params.SortDesc)
+}
+
+func TestBindListParams_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		sortFields []string
+		fields     []string
+	}{
+		{"unknown sort field", "?sort=password_hash", []string{"created_at", "email"}, []string{"sort"}},
+		{"sort without sort fields", "?sort=created_at", nil, []string{"sort"}},
+		{"non-numeric page", "?page=first", nil, []string{"page"}},
+		{"zero limit", "?limit=0", nil, []string{"limit"}},
+		{"negative offset", "?offset=-5", nil, []string{"offset"}},
+		{"every problem at once", "?page=0&limit=-1&sort=-name", []string{"email"}, []string{"page", "limit", "sort"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bindListParams(tt.query, tt.sortFields...)
+
+			problems, ok := errors.AsErrorList(err)
+			require.True(t, ok, "expected an error list, got %v", err)
+			var fields []string
+			for _, problem := range problems.Errors {
+				assert.Equal(t, errors.ErrorTypeValidation, problem.Type)
+				fields = append(fields, problem.Details["field"].(string))
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Health statuses reported by a HealthAggregator
+const (
+	HealthStatusHealthy   = "healthy"
+	HealthStatusDegraded  = "degraded"
+	HealthStatusUnhealthy = "unhealthy"
+)
+
+// ErrHealthDegraded is wrapped by check errors for components that are
+// impaired without stopping the application from serving requests
+var ErrHealthDegraded = errors.New("degraded")
+
+// HealthCheckTimeoutReason is the reason reported for checks that did not
+// finish within their timeout
+const HealthCheckTimeoutReason = "timeout"
+
+// HealthCheckFunc checks a component, returning an error if it is unhealthy,
+// or one wrapping ErrHealthDegraded if it is degraded. It should give up
+// when ctx is done.
+type HealthCheckFunc func(ctx context.Context) error
+
+// HealthCheckResult is the outcome of one health check
+type HealthCheckResult struct {
+	Status   string        `json:"status"`
+	Reason   string        `json:"reason,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// HealthReport is the combined outcome of every registered health check
+type HealthReport struct {
+	// Status is healthy only if every check is healthy, and degraded if
+	// some checks are degraded but none are unhealthy
+	Status string                       `json:"status"`
+	Checks map[string]HealthCheckResult `json:"checks"`
+}
+
+// Healthy reports whether every check is healthy
+func (r *HealthReport) Healthy() bool {
+	return r.Status == HealthStatusHealthy
+}
+
+// Serving reports whether the application can serve requests: no check is
+// unhealthy, though some may be degraded
+func (r *HealthReport) Serving() bool {
+	return r.Status != HealthStatusUnhealthy
+}
+
+// healthCheck is a named check registered with a HealthAggregator
+type healthCheck struct {
+	name    string
+	timeout time.Duration
+	check   HealthCheckFunc
+}
+
+// HealthAggregator runs named health checks concurrently, each bounded by
+// its own timeout, so one slow component cannot hang a health probe
+type HealthAggregator struct {
+	mu             sync.RWMutex
+	checks         []healthCheck
+	defaultTimeout time.Duration
+	cacheTTL       time.Duration
+	now            func() time.Time
+
+	// cacheMu serializes cached checks so concurrent probes share one run
+	cacheMu  sync.Mutex
+	cached   *HealthReport
+	cachedAt time.Time
+}
+
+// NewHealthAggregator creates an aggregator whose checks time out after
+// defaultTimeout unless registered with their own timeout
+func NewHealthAggregator(defaultTimeout time.Duration) *HealthAggregator {
+	return &HealthAggregator{defaultTimeout: defaultTimeout, now: time.Now}
+}
+
+// SetCacheTTL makes Check reuse a report for ttl after it was produced, so
+// frequent probes don't each hit every dependency. A failing dependency is
+// noticed, and its recovery seen, within ttl. Zero disables caching.
+func (a *HealthAggregator) SetCacheTTL(ttl time.Duration) {
+	a.mu.Lock()
+	a.cacheTTL = ttl
+	a.mu.Unlock()
+
+	a.cacheMu.Lock()
+	a.cached = nil
+	a.cacheMu.Unlock()
+}
+
+// Register adds a named check. A timeout of zero uses the default timeout.
+func (a *HealthAggregator) Register(name string, timeout time.Duration, check HealthCheckFunc) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, existing := range a.checks {
+		if existing.name == name {
+			return fmt.Errorf("health check %s is already registered", name)
+		}
+	}
+
+	if timeout <= 0 {
+		timeout = a.defaultTimeout
+	}
+	a.checks = append(a.checks, healthCheck{name: name, timeout: timeout, check: check})
+
+	a.cacheMu.Lock()
+	a.cached = nil
+	a.cacheMu.Unlock()
+	return nil
+}
+
+// Check runs every registered check concurrently and combines the results.
+// A check still running when its timeout elapses is reported unhealthy with
+// the timeout reason, whether or not it honours its context.
+//
+// With a cache TTL set, a report younger than the TTL is returned instead of
+// running the checks again; it is shared, so callers must not modify it. A
+// report cut short by ctx being cancelled is not cached.
+func (a *HealthAggregator) Check(ctx context.Context) *HealthReport {
+	a.mu.RLock()
+	ttl := a.cacheTTL
+	a.mu.RUnlock()
+	if ttl <= 0 {
+		return a.check(ctx)
+	}
+
+	a.cacheMu.Lock()
+	defer a.cacheMu.Unlock()
+
+	if a.cached != nil && a.now().Sub(a.cachedAt) < ttl {
+		return a.cached
+	}
+
+	report := a.check(ctx)
+	if ctx.Err() == nil {
+		a.cached = report
+		a.cachedAt = a.now()
+	}
+	return report
+}
+
+// check runs every registered check concurrently and combines the results
+func (a *HealthAggregator) check(ctx context.Context) *HealthReport {
+	a.mu.RLock()
+	checks := make([]healthCheck, len(a.checks))
+	copy(checks, a.checks)
+	a.mu.RUnlock()
+
+	report := &HealthReport{
+		Status: HealthStatusHealthy,
+		Checks: make(map[string]HealthCheckResult, len(checks)),
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check healthCheck) {
+			defer wg.Done()
+
+			result := runHealthCheck(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.name] = result
+			switch result.Status {
+			case HealthStatusUnhealthy:
+				report.Status = HealthStatusUnhealthy
+			case HealthStatusDegraded:
+				if report.Status == HealthStatusHealthy {
+					report.Status = HealthStatusDegraded
+				}
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// runHealthCheck runs a check, giving up once its timeout elapses
+func runHealthCheck(ctx context.Context, check healthCheck) HealthCheckResult {
+	checkCtx, cancel := context.WithTimeout(ctx, check.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.check(checkCtx)
+	}()
+
+	select {
+	case err := <-done:
+		if errors.Is(err, ErrHealthDegraded) {
+			return HealthCheckResult{Status: HealthStatusDegraded, Error: err.Error(), Duration: time.Since(start)}
+		}
+		if err != nil {
+			return HealthCheckResult{Status: HealthStatusUnhealthy, Error: err.Error(), Duration: time.Since(start)}
+		}
+		return HealthCheckResult{Status: HealthStatusHealthy, Duration: time.Since(start)}
+	case <-checkCtx.Done():
+		return HealthCheckResult{
+			Status:   HealthStatusUnhealthy,
+			Reason:   HealthCheckTimeoutReason,
+			Error:    fmt.Sprintf("health check did not finish within %s", check.timeout),
+			Duration: time.Since(start),
+		}
+	}
+}
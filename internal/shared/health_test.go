@@ -0,0 +1,256 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthAggregator_Check(t *testing.T) {
+	aggregator := NewHealthAggregator(time.Second)
+
+	require.NoError(t, aggregator.Register("fast", 0, func(ctx context.Context) error {
+		return nil
+	}))
+	require.NoError(t, aggregator.Register("slow", 20*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	require.NoError(t, aggregator.Register("failing", 0, func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}))
+
+	report := aggregator.Check(context.Background())
+
+	assert.Equal(t, HealthStatusUnhealthy, report.Status)
+	assert.False(t, report.Healthy())
+	require.Len(t, report.Checks, 3)
+
+	fast := report.Checks["fast"]
+	assert.Equal(t, HealthStatusHealthy, fast.Status)
+	assert.Empty(t, fast.Reason)
+	assert.Empty(t, fast.Error)
+
+	slow := report.Checks["slow"]
+	assert.Equal(t, HealthStatusUnhealthy, slow.Status)
+	assert.Equal(t, HealthCheckTimeoutReason, slow.Reason)
+	assert.GreaterOrEqual(t, slow.Duration, 20*time.Millisecond)
+
+	failing := report.Checks["failing"]
+	assert.Equal(t, HealthStatusUnhealthy, failing.Status)
+	assert.Empty(t, failing.Reason)
+	assert.Equal(t, "connection refused", failing.Error)
+}
+
+func TestHealthAggregator_AllHealthy(t *testing.T) {
+	aggregator := NewHealthAggregator(time.Second)
+	for _, name := range []string{"database", "eventbus"} {
+		require.NoError(t, aggregator.Register(name, 0, func(ctx context.Context) error { return nil }))
+	}
+
+	report := aggregator.Check(context.Background())
+
+	assert.True(t, report.Healthy())
+	assert.Len(t, report.Checks, 2)
+}
+
+func TestHealthAggregator_Degraded(t *testing.T) {
+	aggregator := NewHealthAggregator(time.Second)
+	require.NoError(t, aggregator.Register("database", 0, func(ctx context.Context) error { return nil }))
+	require.NoError(t, aggregator.Register("eventbus", 0, func(ctx context.Context) error {
+		return fmt.Errorf("%w: broker unavailable", ErrHealthDegraded)
+	}))
+
+	report := aggregator.Check(context.Background())
+
+	assert.Equal(t, HealthStatusDegraded, report.Status)
+	assert.False(t, report.Healthy())
+	assert.True(t, report.Serving())
+	assert.Equal(t, HealthStatusDegraded, report.Checks["eventbus"].Status)
+	assert.Equal(t, "degraded: broker unavailable", report.Checks["eventbus"].Error)
+
+	// An unhealthy check outweighs a degraded one
+	require.NoError(t, aggregator.Register("storage", 0, func(ctx context.Context) error {
+		return errors.New("disk full")
+	}))
+
+	report = aggregator.Check(context.Background())
+
+	assert.Equal(t, HealthStatusUnhealthy, report.Status)
+	assert.False(t, report.Serving())
+}
+
+func TestHealthAggregator_TimesOutChecksIgnoringContext(t *testing.T) {
+	aggregator := NewHealthAggregator(20 * time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	require.NoError(t, aggregator.Register("stuck", 0, func(ctx context.Context) error {
+		<-release
+		return nil
+	}))
+
+	start := time.Now()
+	report := aggregator.Check(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, HealthCheckTimeoutReason, report.Checks["stuck"].Reason)
+}
+
+func TestHealthAggregator_ChecksRunConcurrently(t *testing.T) {
+	aggregator := NewHealthAggregator(time.Second)
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, aggregator.Register(name, 0, func(ctx context.Context) error {
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		}))
+	}
+
+	start := time.Now()
+	report := aggregator.Check(context.Background())
+
+	assert.True(t, report.Healthy())
+	assert.Less(t, time.Since(start), 140*time.Millisecond)
+}
+
+func TestHealthAggregator_Register_Duplicate(t *testing.T) {
+	aggregator := NewHealthAggregator(time.Second)
+	check := func(ctx context.Context) error { return nil }
+
+	require.NoError(t, aggregator.Register("database", 0, check))
+	assert.Error(t, aggregator.Register("database", 0, check))
+}
+
+// countingCheck is a health check counting its runs, failing while err is set
+type countingCheck struct {
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (c *countingCheck) check(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	return c.err
+}
+
+func (c *countingCheck) runs() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls
+}
+
+func (c *countingCheck) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+func TestHealthAggregator_CacheTTL(t *testing.T) {
+	aggregator := NewHealthAggregator(time.Second)
+	now := time.Now()
+	aggregator.now = func() time.Time { return now }
+	aggregator.SetCacheTTL(2 * time.Second)
+
+	database := &countingCheck{}
+	require.NoError(t, aggregator.Register("database", 0, database.check))
+
+	// Rapid probes within the TTL share one run
+	first := aggregator.Check(context.Background())
+	now = now.Add(time.Second)
+	second := aggregator.Check(context.Background())
+	assert.True(t, first.Healthy())
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, database.runs())
+
+	// Once the TTL expires a fresh check runs and notices the failure
+	database.fail(errors.New("connection refused"))
+	now = now.Add(time.Second)
+	report := aggregator.Check(context.Background())
+	assert.False(t, report.Healthy())
+	assert.Equal(t, 2, database.runs())
+
+	// Recovery is seen as soon as the failed report expires
+	database.fail(nil)
+	now = now.Add(2 * time.Second)
+	assert.True(t, aggregator.Check(context.Background()).Healthy())
+	assert.Equal(t, 3, database.runs())
+}
+
+func TestHealthAggregator_CacheSharedByConcurrentProbes(t *testing.T) {
+	aggregator := NewHealthAggregator(time.Second)
+	aggregator.SetCacheTTL(time.Minute)
+
+	release := make(chan struct{})
+	database := &countingCheck{}
+	require.NoError(t, aggregator.Register("database", 0, func(ctx context.Context) error {
+		<-release
+		return database.check(ctx)
+	}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.True(t, aggregator.Check(context.Background()).Healthy())
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, 1, database.runs())
+}
+
+func TestHealthAggregator_CacheDisabled(t *testing.T) {
+	aggregator := NewHealthAggregator(time.Second)
+	database := &countingCheck{}
+	require.NoError(t, aggregator.Register("database", 0, database.check))
+
+	aggregator.Check(context.Background())
+	aggregator.Check(context.Background())
+	assert.Equal(t, 2, database.runs())
+}
+
+func TestHealthAggregator_CacheSkipsCancelledChecks(t *testing.T) {
+	aggregator := NewHealthAggregator(time.Second)
+	aggregator.SetCacheTTL(time.Minute)
+	database := &countingCheck{}
+	require.NoError(t, aggregator.Register("database", 0, database.check))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cancelled := aggregator.Check(ctx)
+
+	// The next probe runs the checks again rather than reusing the report
+	fresh := aggregator.Check(context.Background())
+	assert.NotSame(t, cancelled, fresh)
+	assert.True(t, fresh.Healthy())
+	assert.Same(t, fresh, aggregator.Check(context.Background()))
+}
+
+func TestModuleRegistry_RegisterHealthChecks(t *testing.T) {
+	registry := NewModuleRegistry(&MockEventBus{}, nil, nil, echo.New())
+	healthy := NewMockModule("healthy")
+	unhealthy := NewMockModule("unhealthy")
+	healthy.On("Health", mock.Anything).Return(nil)
+	unhealthy.On("Health", mock.Anything).Return(assert.AnError)
+	require.NoError(t, registry.Register(healthy))
+	require.NoError(t, registry.Register(unhealthy))
+
+	aggregator := NewHealthAggregator(time.Second)
+	require.NoError(t, registry.RegisterHealthChecks(aggregator, 0))
+	report := aggregator.Check(context.Background())
+
+	assert.Equal(t, HealthStatusHealthy, report.Checks["module.healthy"].Status)
+	assert.Equal(t, HealthStatusUnhealthy, report.Checks["module.unhealthy"].Status)
+	assert.Equal(t, assert.AnError.Error(), report.Checks["module.unhealthy"].Error)
+}
@@ -0,0 +1,180 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+)
+
+// DefaultBuckets are histogram buckets suited to request latencies in seconds
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// CounterVec is a counter partitioned by labels
+type CounterVec struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	values map[string]float64
+	label  map[string][]string
+}
+
+// NewCounterVec creates a counter with the given label names
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{
+		name:   name,
+		help:   help,
+		labels: labels,
+		values: make(map[string]float64),
+		label:  make(map[string][]string),
+	}
+}
+
+// Inc adds one to the counter for the label values
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds delta to the counter for the label values
+func (c *CounterVec) Add(delta float64, labelValues ...string) {
+	mustMatchLabels(c.name, c.labels, labelValues)
+	key := labelKey(labelValues)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.label[key]; !ok {
+		c.label[key] = append([]string(nil), labelValues...)
+	}
+	c.values[key] += delta
+}
+
+// Value returns the counter for the label values
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.values[labelKey(labelValues)]
+}
+
+// Collect implements Collector
+func (c *CounterVec) Collect(w *bytes.Buffer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+	for _, key := range sortedKeys(c.values) {
+		writeSample(w, c.name, c.labels, c.label[key], c.values[key])
+	}
+}
+
+// HistogramVec is a histogram partitioned by labels
+type HistogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*histogramSeries
+}
+
+// histogramSeries is the histogram for one combination of label values
+type histogramSeries struct {
+	labelValues []string
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// NewHistogramVec creates a histogram with the given upper bounds, in
+// increasing order, and label names
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*histogramSeries),
+	}
+}
+
+// Observe records value in the histogram for the label values
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	mustMatchLabels(h.name, h.labels, labelValues)
+	key := labelKey(labelValues)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	series, ok := h.series[key]
+	if !ok {
+		series = &histogramSeries{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = series
+	}
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			series.counts[i]++
+		}
+	}
+	series.count++
+	series.sum += value
+}
+
+// Count returns how many values were observed for the label values
+func (h *HistogramVec) Count(labelValues ...string) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if series, ok := h.series[labelKey(labelValues)]; ok {
+		return series.count
+	}
+	return 0
+}
+
+// Collect implements Collector
+func (h *HistogramVec) Collect(w *bytes.Buffer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	writeHeader(w, h.name, h.help, "histogram")
+	bucketLabels := append(append([]string(nil), h.labels...), "le")
+	for _, key := range sortedKeys(h.series) {
+		series := h.series[key]
+		for i, bound := range h.buckets {
+			writeSample(w, h.name+"_bucket", bucketLabels, append(series.labelValues[:len(series.labelValues):len(series.labelValues)], formatValue(bound)), float64(series.counts[i]))
+		}
+		writeSample(w, h.name+"_bucket", bucketLabels, append(series.labelValues[:len(series.labelValues):len(series.labelValues)], "+Inf"), float64(series.count))
+		writeSample(w, h.name+"_sum", h.labels, series.labelValues, series.sum)
+		writeSample(w, h.name+"_count", h.labels, series.labelValues, float64(series.count))
+	}
+}
+
+// GaugeFunc is a gauge whose value is read when metrics are collected
+type GaugeFunc struct {
+	name  string
+	help  string
+	value func() float64
+}
+
+// NewGaugeFunc creates a gauge reporting value
+func NewGaugeFunc(name, help string, value func() float64) *GaugeFunc {
+	return &GaugeFunc{name: name, help: help, value: value}
+}
+
+// Collect implements Collector
+func (g *GaugeFunc) Collect(w *bytes.Buffer) {
+	writeHeader(w, g.name, g.help, "gauge")
+	writeSample(w, g.name, nil, nil, g.value())
+}
+
+// mustMatchLabels panics when a metric is used with the wrong number of
+// label values, a programming error
+func mustMatchLabels(name string, labels, values []string) {
+	if len(labels) != len(values) {
+		panic(fmt.Sprintf("metric %s expects %d label values, got %d", name, len(labels), len(values)))
+	}
+}
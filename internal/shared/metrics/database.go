@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"bytes"
+	"database/sql"
+)
+
+// DBStatsCollector reports database connection pool statistics
+type DBStatsCollector struct {
+	stats func() sql.DBStats
+}
+
+// NewDBStatsCollector creates a collector reporting the pool statistics
+// returned by stats, e.g. database.DB.Stats
+func NewDBStatsCollector(stats func() sql.DBStats) *DBStatsCollector {
+	return &DBStatsCollector{stats: stats}
+}
+
+// Collect implements Collector
+func (d *DBStatsCollector) Collect(w *bytes.Buffer) {
+	stats := d.stats()
+
+	gauges := []struct {
+		name  string
+		help  string
+		value int
+	}{
+		{"db_max_open_connections", "Maximum number of open database connections", stats.MaxOpenConnections},
+		{"db_open_connections", "Number of open database connections", stats.OpenConnections},
+		{"db_in_use_connections", "Number of database connections in use", stats.InUse},
+		{"db_idle_connections", "Number of idle database connections", stats.Idle},
+	}
+	for _, gauge := range gauges {
+		writeHeader(w, gauge.name, gauge.help, "gauge")
+		writeSample(w, gauge.name, nil, nil, float64(gauge.value))
+	}
+
+	writeHeader(w, "db_wait_count_total", "Total number of waits for a database connection", "counter")
+	writeSample(w, "db_wait_count_total", nil, nil, float64(stats.WaitCount))
+	writeHeader(w, "db_wait_duration_seconds_total", "Total time spent waiting for a database connection", "counter")
+	writeSample(w, "db_wait_duration_seconds_total", nil, nil, stats.WaitDuration.Seconds())
+}
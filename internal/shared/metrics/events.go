@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"time"
+
+	"go-templ-template/internal/shared/events"
+)
+
+// EventMetrics counts published and handled events. It is an
+// events.EventBusObserver.
+type EventMetrics struct {
+	published  *CounterVec
+	handled    *CounterVec
+	duration   *HistogramVec
+	reconnects *CounterVec
+}
+
+var _ events.EventBusObserver = (*EventMetrics)(nil)
+
+// NewEventMetrics creates the event bus metrics and registers them with
+// registry
+func NewEventMetrics(registry *Registry) *EventMetrics {
+	m := &EventMetrics{
+		published: NewCounterVec("events_published_total",
+			"Total number of events published", "event_type", "status"),
+		handled: NewCounterVec("events_handled_total",
+			"Total number of events processed by handlers", "event_type", "handler", "status"),
+		duration: NewHistogramVec("events_handle_duration_seconds",
+			"Event handler latency in seconds", DefaultBuckets, "event_type", "handler"),
+		reconnects: NewCounterVec("eventbus_reconnects_total",
+			"Total number of event bus broker reconnections"),
+	}
+	registry.Register(m.published, m.handled, m.duration, m.reconnects)
+	return m
+}
+
+// OnPublish implements events.EventBusObserver
+func (m *EventMetrics) OnPublish(eventType string, err error) {
+	m.published.Inc(eventType, outcome(err))
+}
+
+// OnHandle implements events.EventBusObserver
+func (m *EventMetrics) OnHandle(eventType string, handlerName string, duration time.Duration, err error) {
+	m.handled.Inc(eventType, handlerName, outcome(err))
+	m.duration.Observe(duration.Seconds(), eventType, handlerName)
+}
+
+// OnReconnect implements events.EventBusObserver
+func (m *EventMetrics) OnReconnect() {
+	m.reconnects.Inc()
+}
+
+// outcome labels whether an operation succeeded
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HTTPMetrics counts and times HTTP requests
+type HTTPMetrics struct {
+	requests *CounterVec
+	duration *HistogramVec
+}
+
+// NewHTTPMetrics creates the HTTP request metrics and registers them with
+// registry
+func NewHTTPMetrics(registry *Registry) *HTTPMetrics {
+	m := &HTTPMetrics{
+		requests: NewCounterVec("http_requests_total",
+			"Total number of HTTP requests", "method", "route", "status"),
+		duration: NewHistogramVec("http_request_duration_seconds",
+			"HTTP request latency in seconds", DefaultBuckets, "method", "route"),
+	}
+	registry.Register(m.requests, m.duration)
+	return m
+}
+
+// Middleware records the count and latency of each request by method and
+// route. Register it before the error handling middleware so failed requests
+// are recorded with the status they are answered with.
+func (m *HTTPMetrics) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := next(c)
+
+		route := c.Path()
+		if route == "" {
+			route = "unmatched"
+		}
+		method := c.Request().Method
+
+		m.requests.Inc(method, route, strconv.Itoa(responseStatus(c, err)))
+		m.duration.Observe(time.Since(start).Seconds(), method, route)
+		return err
+	}
+}
+
+// Requests returns the number of requests recorded for method, route and status
+func (m *HTTPMetrics) Requests(method, route string, status int) float64 {
+	return m.requests.Value(method, route, strconv.Itoa(status))
+}
+
+// responseStatus returns the status a request is answered with, including
+// requests whose error has not been rendered yet
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	if appErr, ok := errors.AsAppError(err); ok {
+		return appErr.HTTPStatus
+	}
+	if httpErr, ok := err.(*echo.HTTPError); ok {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
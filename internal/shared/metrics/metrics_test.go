@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/shared/errors"
+	"go-templ-template/internal/shared/events"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newInstrumentedEcho returns a router exposing registry at /metrics with
+// HTTP metrics recorded for every route
+func newInstrumentedEcho(registry *Registry) (*echo.Echo, *HTTPMetrics) {
+	e := echo.New()
+	httpMetrics := NewHTTPMetrics(registry)
+	e.Use(httpMetrics.Middleware)
+	e.GET("/metrics", registry.Handler())
+	return e, httpMetrics
+}
+
+func scrape(t *testing.T, e *echo.Echo) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get(echo.HeaderContentType))
+	return rec.Body.String()
+}
+
+func TestHTTPMetrics_ScrapeCountsRequests(t *testing.T) {
+	registry := NewRegistry()
+	e, httpMetrics := newInstrumentedEcho(registry)
+	e.GET("/users/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	before := scrape(t, e)
+	assert.NotContains(t, before, `http_requests_total{method="GET",route="/users/:id",status="200"}`)
+
+	for _, id := range []string{"1", "2"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/"+id, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	after := scrape(t, e)
+	assert.Contains(t, after, "# TYPE http_requests_total counter")
+	assert.Contains(t, after, `http_requests_total{method="GET",route="/users/:id",status="200"} 2`)
+	assert.Contains(t, after, `http_request_duration_seconds_count{method="GET",route="/users/:id"} 2`)
+	assert.Contains(t, after, `http_request_duration_seconds_bucket{method="GET",route="/users/:id",le="+Inf"} 2`)
+	assert.Equal(t, float64(2), httpMetrics.Requests(http.MethodGet, "/users/:id", http.StatusOK))
+}
+
+func TestHTTPMetrics_RecordsErrorStatus(t *testing.T) {
+	registry := NewRegistry()
+	e, httpMetrics := newInstrumentedEcho(registry)
+	e.GET("/forbidden", func(c echo.Context) error {
+		return errors.NewInsufficientPermissionsError()
+	})
+	e.GET("/teapot", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusTeapot)
+	})
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/forbidden", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/teapot", nil))
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	assert.Equal(t, float64(1), httpMetrics.Requests(http.MethodGet, "/forbidden", http.StatusForbidden))
+	assert.Equal(t, float64(1), httpMetrics.Requests(http.MethodGet, "/teapot", http.StatusTeapot))
+	assert.Equal(t, float64(1), httpMetrics.Requests(http.MethodGet, "unmatched", http.StatusNotFound))
+}
+
+func TestDBStatsCollector(t *testing.T) {
+	collector := NewDBStatsCollector(func() sql.DBStats {
+		return sql.DBStats{
+			MaxOpenConnections: 25,
+			OpenConnections:    5,
+			InUse:              2,
+			Idle:               3,
+			WaitCount:          7,
+			WaitDuration:       1500 * time.Millisecond,
+		}
+	})
+
+	var buf bytes.Buffer
+	collector.Collect(&buf)
+	output := buf.String()
+
+	assert.Contains(t, output, "# TYPE db_open_connections gauge")
+	assert.Contains(t, output, "db_max_open_connections 25\n")
+	assert.Contains(t, output, "db_open_connections 5\n")
+	assert.Contains(t, output, "db_in_use_connections 2\n")
+	assert.Contains(t, output, "db_idle_connections 3\n")
+	assert.Contains(t, output, "db_wait_count_total 7\n")
+	assert.Contains(t, output, "db_wait_duration_seconds_total 1.5\n")
+}
+
+// countingHandler is an event handler that accepts every event
+type countingHandler struct{}
+
+func (countingHandler) Handle(ctx context.Context, event events.DomainEvent) error { return nil }
+func (countingHandler) EventType() string                                          { return "test.counted" }
+func (countingHandler) HandlerName() string                                        { return "counting-handler" }
+
+func TestEventMetrics_ObservesEventBus(t *testing.T) {
+	registry := NewRegistry()
+	bus := events.NewInMemoryEventBus().WithObserver(NewEventMetrics(registry))
+	require.NoError(t, bus.Start(context.Background()))
+
+	handler := &countingHandler{}
+	require.NoError(t, bus.Subscribe("test.counted", handler))
+	require.NoError(t, bus.Publish(context.Background(), events.NewBaseEvent("test.counted", "agg-1", "Test", nil)))
+
+	output := string(registry.Gather())
+	assert.Contains(t, output, `events_published_total{event_type="test.counted",status="success"} 1`)
+	assert.Contains(t, output, `events_handled_total{event_type="test.counted",handler="counting-handler",status="success"} 1`)
+	assert.Contains(t, output, `events_handle_duration_seconds_count{event_type="test.counted",handler="counting-handler"} 1`)
+}
+
+func TestCounterVec_EscapesLabelValues(t *testing.T) {
+	counter := NewCounterVec("escaped_total", "Help with \\ backslash", "value")
+	counter.Inc("a \"quoted\"\nvalue")
+
+	var buf bytes.Buffer
+	counter.Collect(&buf)
+
+	assert.Equal(t, "# HELP escaped_total Help with \\\\ backslash\n"+
+		"# TYPE escaped_total counter\n"+
+		`escaped_total{value="a \"quoted\"\nvalue"} 1`+"\n", buf.String())
+}
+
+func TestCounterVec_PanicsOnWrongLabelCount(t *testing.T) {
+	counter := NewCounterVec("labelled_total", "Labelled", "a", "b")
+	assert.Panics(t, func() { counter.Inc("only-one") })
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AccessLogConfig configures the AccessLog middleware
+type AccessLogConfig struct {
+	// SkipPaths are request paths that are never logged, such as the health
+	// endpoints polled by load balancers
+	SkipPaths []string
+
+	// SuccessSampleEvery logs one in every SuccessSampleEvery 2xx responses;
+	// zero or one logs them all. Other responses are always logged.
+	SuccessSampleEvery int
+}
+
+// DefaultAccessLogConfig returns a configuration that skips the health
+// endpoints and logs every other request
+func DefaultAccessLogConfig() AccessLogConfig {
+	return AccessLogConfig{
+		SkipPaths: []string{"/health", "/health/detailed", "/ready", "/live"},
+	}
+}
+
+// AccessLog middleware writes one structured entry per request through
+// logger, with the request fields of LoggerFromEchoContext plus the response
+// status, latency in milliseconds and bytes written. 5xx responses are
+// logged as errors and 4xx responses as warnings.
+func AccessLog(logger *errors.StructuredLogger, config AccessLogConfig) echo.MiddlewareFunc {
+	skip := make(map[string]bool, len(config.SkipPaths))
+	for _, path := range config.SkipPaths {
+		skip[path] = true
+	}
+	var successes atomic.Uint64
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skip[c.Request().URL.Path] {
+				return next(c)
+			}
+
+			start := time.Now()
+			err := next(c)
+			latency := time.Since(start)
+
+			status := accessLogStatus(c, err)
+			if status >= 200 && status < 300 && config.SuccessSampleEvery > 1 &&
+				(successes.Add(1)-1)%uint64(config.SuccessSampleEvery) != 0 {
+				return err
+			}
+
+			entry := requestLogger(c, logger).WithFields(map[string]interface{}{
+				"status":     status,
+				"latency_ms": float64(latency.Microseconds()) / 1000,
+				"bytes":      c.Response().Size,
+			})
+
+			switch {
+			case status >= http.StatusInternalServerError:
+				entry.Error("HTTP request")
+			case status >= http.StatusBadRequest:
+				entry.Warn("HTTP request")
+			default:
+				entry.Info("HTTP request")
+			}
+			return err
+		}
+	}
+}
+
+// accessLogStatus returns the status a request is answered with, including
+// requests whose error has not been rendered yet
+func accessLogStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	if appErr, ok := errors.AsAppError(err); ok {
+		return appErr.HTTPStatus
+	}
+	if httpErr, ok := err.(*echo.HTTPError); ok {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
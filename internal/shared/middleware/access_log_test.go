@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLog_LogsRequestFields(t *testing.T) {
+	logger, readEntries := newFileLogger(t)
+
+	e := echo.New()
+	e.Use(RequestMetadata, AccessLog(logger, DefaultAccessLogConfig()))
+	e.POST("/users/:id", func(c echo.Context) error {
+		c.Set(UserContextKey, &userDomain.User{ID: "user-123"})
+		return c.String(http.StatusCreated, "created")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users/user-123", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-789")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	entries := readEntries()
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "HTTP request", entry["message"])
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, http.MethodPost, entry["method"])
+	assert.Equal(t, "/users/user-123", entry["path"])
+	assert.Equal(t, "/users/:id", entry["route"])
+	assert.Equal(t, float64(http.StatusCreated), entry["status"])
+	assert.Equal(t, float64(len("created")), entry["bytes"])
+	assert.Contains(t, entry, "latency_ms")
+	assert.Equal(t, "req-789", entry["request_id"])
+	assert.Equal(t, "user-123", entry["user_id"])
+}
+
+func TestAccessLog_SkipsHealthEndpoints(t *testing.T) {
+	logger, readEntries := newFileLogger(t)
+
+	e := echo.New()
+	e.Use(AccessLog(logger, DefaultAccessLogConfig()))
+	e.GET("/health", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/users", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, path := range []string{"/health", "/users"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	entries := readEntries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "/users", entries[0]["path"])
+}
+
+func TestAccessLog_SamplesSuccessfulResponses(t *testing.T) {
+	logger, readEntries := newFileLogger(t)
+
+	e := echo.New()
+	e.Use(AccessLog(logger, AccessLogConfig{SuccessSampleEvery: 3}))
+	e.GET("/ok", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/fail", func(c echo.Context) error {
+		return errors.NewAppError(errors.ErrorTypeInternal, "BOOM", "Boom", http.StatusInternalServerError)
+	})
+
+	for i := 0; i < 6; i++ {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	}
+	for i := 0; i < 2; i++ {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	}
+
+	var successes, failures int
+	for _, entry := range readEntries() {
+		switch entry["path"] {
+		case "/ok":
+			successes++
+		case "/fail":
+			failures++
+			assert.Equal(t, "error", entry["level"])
+			assert.Equal(t, float64(http.StatusInternalServerError), entry["status"])
+		}
+	}
+	assert.Equal(t, 2, successes, "one in every three 2xx responses is logged")
+	assert.Equal(t, 2, failures, "errors are never sampled")
+}
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-templ-template/internal/modules/auth/application"
+	authDomain "go-templ-template/internal/modules/auth/domain"
+	userDomain "go-templ-template/internal/modules/user/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPIKeyService authenticates the keys it holds that are still valid
+type fakeAPIKeyService struct {
+	user *userDomain.User
+	keys map[string]*authDomain.APIKey
+}
+
+func (s *fakeAPIKeyService) CreateAPIKey(ctx context.Context, cmd *application.CreateAPIKeyCommand) (*authDomain.APIKey, error) {
+	return nil, application.NewInternalError("not implemented")
+}
+
+func (s *fakeAPIKeyService) ListAPIKeys(ctx context.Context, userID string) ([]*authDomain.APIKey, error) {
+	return nil, application.NewInternalError("not implemented")
+}
+
+func (s *fakeAPIKeyService) RevokeAPIKey(ctx context.Context, cmd *application.RevokeAPIKeyCommand) error {
+	return application.NewInternalError("not implemented")
+}
+
+func (s *fakeAPIKeyService) Authenticate(ctx context.Context, key string) (*userDomain.User, *authDomain.APIKey, error) {
+	apiKey, ok := s.keys[key]
+	if !ok || !apiKey.IsValid() {
+		return nil, nil, application.NewAPIKeyInvalidError()
+	}
+	return s.user, apiKey, nil
+}
+
+func newFakeAPIKeyService(t *testing.T) (*fakeAPIKeyService, *authDomain.APIKey, *authDomain.APIKey) {
+	t.Helper()
+
+	user, err := userDomain.NewUser("user-123", "test@example.com", "Password123!", "John", "Doe")
+	require.NoError(t, err)
+
+	valid, err := authDomain.NewAPIKey(user.ID, "CI", []string{"users:read"}, nil)
+	require.NoError(t, err)
+	revoked, err := authDomain.NewAPIKey(user.ID, "Old", nil, nil)
+	require.NoError(t, err)
+	revokedAt := revoked.CreatedAt
+	revoked.RevokedAt = &revokedAt
+
+	return &fakeAPIKeyService{
+		user: user,
+		keys: map[string]*authDomain.APIKey{valid.Key: valid, revoked.Key: revoked},
+	}, valid, revoked
+}
+
+func TestAPIKeyMiddleware_RequireAuth(t *testing.T) {
+	service, valid, revoked := newFakeAPIKeyService(t)
+	apiKeyMiddleware := NewAPIKeyMiddleware(service)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantError     string
+	}{
+		{"valid key", "Bearer " + valid.Key, http.StatusOK, ""},
+		{"missing header", "", http.StatusUnauthorized, "UNAUTHORIZED"},
+		{"revoked key", "Bearer " + revoked.Key, http.StatusUnauthorized, application.ErrorCodeAPIKeyInvalid},
+		{"unknown key", "Bearer " + authDomain.APIKeyPrefix + "unknown", http.StatusUnauthorized, application.ErrorCodeAPIKeyInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(req, rec)
+
+			handler := apiKeyMiddleware.RequireAuth(func(c echo.Context) error {
+				return c.String(http.StatusOK, "success")
+			})
+
+			require.NoError(t, handler(c))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				assert.Contains(t, rec.Body.String(), `"error":"`+tt.wantError+`"`)
+				assert.Nil(t, GetUserFromContext(c))
+				return
+			}
+			assert.Equal(t, service.user, GetUserFromContext(c))
+			assert.Equal(t, valid, GetAPIKeyFromContext(c))
+			scopes, scoped := GetScopesFromContext(c)
+			assert.True(t, scoped)
+			assert.Equal(t, []string{"users:read"}, scopes)
+		})
+	}
+}
+
+func TestAPIKeyMiddleware_RequireAuthOr(t *testing.T) {
+	service, valid, _ := newFakeAPIKeyService(t)
+
+	var fallbackCalls int
+	fallback := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			fallbackCalls++
+			return next(c)
+		}
+	}
+	handler := NewAPIKeyMiddleware(service).RequireAuthOr(fallback)(func(c echo.Context) error {
+		return c.String(http.StatusOK, "success")
+	})
+
+	for _, authorization := range []string{"Bearer " + valid.Key, "Bearer 3f2a9c0d1e"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", authorization)
+		require.NoError(t, handler(echo.New().NewContext(req, httptest.NewRecorder())))
+	}
+
+	// Only the session ID was passed on to the fallback
+	assert.Equal(t, 1, fallbackCalls)
+}
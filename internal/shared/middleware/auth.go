@@ -1,11 +1,16 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"go-templ-template/internal/modules/auth/application"
+	authDomain "go-templ-template/internal/modules/auth/domain"
 	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/errors"
 
 	"github.com/labstack/echo/v4"
 )
@@ -71,6 +76,10 @@ func (m *AuthMiddleware) RequireAuth(next echo.HandlerFunc) echo.HandlerFunc {
 		// Store user and session in context
 		c.Set(UserContextKey, result.User)
 		c.Set(SessionContextKey, result.Session)
+		if result.User != nil {
+			setRequestUser(c, result.User.ID)
+		}
+		m.touchSession(c, result.Session)
 
 		return next(c)
 	}
@@ -93,6 +102,10 @@ func (m *AuthMiddleware) OptionalAuth(next echo.HandlerFunc) echo.HandlerFunc {
 				// Store user and session in context
 				c.Set(UserContextKey, result.User)
 				c.Set(SessionContextKey, result.Session)
+				if result.User != nil {
+					setRequestUser(c, result.User.ID)
+				}
+				m.touchSession(c, result.Session)
 			} else {
 				// Clear invalid session cookie
 				m.clearSessionCookie(c)
@@ -103,8 +116,9 @@ func (m *AuthMiddleware) OptionalAuth(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
-// RequireRole middleware that requires specific user role/status
-func (m *AuthMiddleware) RequireRole(allowedStatuses ...string) echo.MiddlewareFunc {
+// RequireStatus middleware that requires the user to have one of the allowed
+// account statuses
+func (m *AuthMiddleware) RequireStatus(allowedStatuses ...string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			userData := GetUserFromContext(c)
@@ -140,6 +154,28 @@ func (m *AuthMiddleware) RequireRole(allowedStatuses ...string) echo.MiddlewareF
 	}
 }
 
+// RequireRole middleware that requires the authenticated user to have one of
+// roles. It runs after RequireAuth, which stores the user in context.
+func RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := GetUserFromContext(c).(*userDomain.User)
+			if !ok || user == nil {
+				return errors.NewAuthenticationError("UNAUTHORIZED", "Authentication required")
+			}
+
+			for _, role := range roles {
+				if string(user.Role) == role {
+					return next(c)
+				}
+			}
+
+			return errors.NewAuthorizationError("INSUFFICIENT_ROLE", "User role is not allowed to access this resource").
+				WithUserMessage("You don't have permission to perform this action.")
+		}
+	}
+}
+
 // CSRF middleware for CSRF protection (deprecated - use CSRFEnhanced instead)
 func (m *AuthMiddleware) CSRF(next echo.HandlerFunc) echo.HandlerFunc {
 	config := DefaultCSRFConfig()
@@ -169,31 +205,104 @@ func (m *AuthMiddleware) getSessionID(c echo.Context) string {
 	return ""
 }
 
+// touchSession records activity on the session so its expiry slides while the
+// user is active. Failures are logged but don't fail the request, which is
+// already authenticated
+func (m *AuthMiddleware) touchSession(c echo.Context, session *authDomain.Session) {
+	if session == nil {
+		return
+	}
+	if err := m.authService.TouchSession(c.Request().Context(), session); err != nil {
+		c.Logger().Warnf("failed to record session activity: %v", err)
+	}
+}
+
 // clearSessionCookie clears the session cookie
 func (m *AuthMiddleware) clearSessionCookie(c echo.Context) {
-	cookie := &http.Cookie{
-		Name:     SessionCookieName,
-		Value:    "",
+	SetSessionCookie(c, "", -1)
+}
+
+// SessionCookieConfig holds the attributes of the session cookie
+type SessionCookieConfig struct {
+	// Path and Domain scope the cookie; an empty Domain limits it to the
+	// host that set it
+	Path   string
+	Domain string
+
+	// SameSite controls whether browsers send the cookie with cross-site
+	// requests
+	SameSite http.SameSite
+
+	// Secure always marks the cookie Secure. Otherwise it is Secure on
+	// requests served over TLS only.
+	Secure bool
+}
+
+// DefaultSessionCookieConfig returns the session cookie attributes used
+// until SetSessionCookieConfig is called
+func DefaultSessionCookieConfig() SessionCookieConfig {
+	return SessionCookieConfig{
 		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-		Secure:   c.Request().TLS != nil, // Only secure in HTTPS
 		SameSite: http.SameSiteStrictMode,
 	}
-	c.SetCookie(cookie)
 }
 
-// SetSessionCookie sets the session cookie
-func SetSessionCookie(c echo.Context, sessionID string, maxAge int) {
-	cookie := &http.Cookie{
+// sessionCookieConfig holds the attributes every session cookie is set and
+// cleared with
+var sessionCookieConfig atomic.Pointer[SessionCookieConfig]
+
+// SetSessionCookieConfig sets the attributes of session cookies. Cookies are
+// cleared with the same Path and Domain they were set with, otherwise
+// browsers keep them.
+func SetSessionCookieConfig(config SessionCookieConfig) {
+	sessionCookieConfig.Store(&config)
+}
+
+// ParseSameSite parses a SameSite attribute: "strict", "lax" or "none"
+func ParseSameSite(value string) (http.SameSite, error) {
+	switch strings.ToLower(value) {
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "lax":
+		return http.SameSiteLaxMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
+	default:
+		return http.SameSiteDefaultMode, fmt.Errorf("invalid SameSite value %q", value)
+	}
+}
+
+// newSessionCookie returns a session cookie with the configured attributes
+func newSessionCookie(c echo.Context, sessionID string) *http.Cookie {
+	config := DefaultSessionCookieConfig()
+	if stored := sessionCookieConfig.Load(); stored != nil {
+		config = *stored
+	}
+
+	return &http.Cookie{
 		Name:     SessionCookieName,
 		Value:    sessionID,
-		Path:     "/",
-		MaxAge:   maxAge,
+		Path:     config.Path,
+		Domain:   config.Domain,
 		HttpOnly: true,
-		Secure:   c.Request().TLS != nil, // Only secure in HTTPS
-		SameSite: http.SameSiteStrictMode,
+		Secure:   config.Secure || c.Request().TLS != nil,
+		SameSite: config.SameSite,
 	}
+}
+
+// SetSessionCookie sets the session cookie; a negative maxAge clears it
+func SetSessionCookie(c echo.Context, sessionID string, maxAge int) {
+	cookie := newSessionCookie(c, sessionID)
+	cookie.MaxAge = maxAge
+	c.SetCookie(cookie)
+}
+
+// SetPersistentSessionCookie sets a session cookie that survives browser
+// restarts until expiresAt
+func SetPersistentSessionCookie(c echo.Context, sessionID string, expiresAt time.Time) {
+	cookie := newSessionCookie(c, sessionID)
+	cookie.MaxAge = int(time.Until(expiresAt).Seconds())
+	cookie.Expires = expiresAt
 	c.SetCookie(cookie)
 }
 
@@ -206,3 +315,13 @@ func GetUserFromContext(c echo.Context) interface{} {
 func GetSessionFromContext(c echo.Context) interface{} {
 	return c.Get(SessionContextKey)
 }
+
+// GetAuthenticatedAt returns when the user signed in to the session in
+// context, for actions that require recent authentication
+func GetAuthenticatedAt(c echo.Context) (time.Time, bool) {
+	session, ok := GetSessionFromContext(c).(*authDomain.Session)
+	if !ok || session == nil {
+		return time.Time{}, false
+	}
+	return session.CreatedAt, true
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -59,6 +60,11 @@ func (m *mockAuthService) RefreshSession(ctx context.Context, cmd *application.R
 	return args.Get(0).(*domain.Session), args.Error(1)
 }
 
+func (m *mockAuthService) TouchSession(ctx context.Context, session *domain.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
 func (m *mockAuthService) ChangePassword(ctx context.Context, cmd *application.ChangePasswordCommand) error {
 	args := m.Called(ctx, cmd)
 	return args.Error(0)
@@ -77,6 +83,16 @@ func (m *mockAuthService) GetUserSessions(ctx context.Context, userID string) ([
 	return args.Get(0).([]*domain.Session), args.Error(1)
 }
 
+func (m *mockAuthService) RevokeSession(ctx context.Context, cmd *application.RevokeSessionCommand) error {
+	args := m.Called(ctx, cmd)
+	return args.Error(0)
+}
+
+func (m *mockAuthService) RevokeOtherSessions(ctx context.Context, cmd *application.RevokeOtherSessionsCommand) (int, error) {
+	args := m.Called(ctx, cmd)
+	return args.Int(0), args.Error(1)
+}
+
 // Test helper functions
 func createTestUser() *userDomain.User {
 	user, _ := userDomain.NewUser("user-123", "test@example.com", "Password123", "John", "Doe")
@@ -113,6 +129,7 @@ func TestAuthMiddleware_RequireAuth_Success(t *testing.T) {
 	mockService.On("ValidateSession", mock.Anything, mock.MatchedBy(func(query *application.ValidateSessionQuery) bool {
 		return query.SessionID == "valid-session-id"
 	})).Return(validationResult, nil)
+	mockService.On("TouchSession", mock.Anything, mock.Anything).Return(nil)
 
 	// Create test handler
 	testHandler := func(c echo.Context) error {
@@ -142,6 +159,34 @@ func TestAuthMiddleware_RequireAuth_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestAuthMiddleware_RequireAuth_TouchesSession(t *testing.T) {
+	mockService := new(mockAuthService)
+	middleware := NewAuthMiddleware(mockService)
+	e := setupEcho()
+
+	session := createTestSession()
+	mockService.On("ValidateSession", mock.Anything, mock.Anything).Return(&application.SessionValidationResult{
+		User:    createTestUser(),
+		Session: session,
+		Valid:   true,
+	}, nil)
+	// Failing to record activity doesn't fail an authenticated request
+	mockService.On("TouchSession", mock.Anything, session).Return(errors.New("database unavailable"))
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: session.ID})
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := middleware.RequireAuth(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	require.NoError(t, handler(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	mockService.AssertExpectations(t)
+}
+
 func TestAuthMiddleware_RequireAuth_NoSession(t *testing.T) {
 	// Setup
 	mockService := new(mockAuthService)
@@ -218,6 +263,7 @@ func TestAuthMiddleware_RequireAuth_AuthorizationHeader(t *testing.T) {
 	mockService.On("ValidateSession", mock.Anything, mock.MatchedBy(func(query *application.ValidateSessionQuery) bool {
 		return query.SessionID == "bearer-session-id"
 	})).Return(validationResult, nil)
+	mockService.On("TouchSession", mock.Anything, mock.Anything).Return(nil)
 
 	// Create test handler
 	testHandler := func(c echo.Context) error {
@@ -253,6 +299,7 @@ func TestAuthMiddleware_OptionalAuth_WithValidSession(t *testing.T) {
 		Valid:   true,
 	}
 	mockService.On("ValidateSession", mock.Anything, mock.Anything).Return(validationResult, nil)
+	mockService.On("TouchSession", mock.Anything, mock.Anything).Return(nil)
 
 	// Create test handler
 	testHandler := func(c echo.Context) error {
@@ -311,7 +358,7 @@ func TestAuthMiddleware_OptionalAuth_WithoutSession(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
-func TestAuthMiddleware_RequireRole_Success(t *testing.T) {
+func TestAuthMiddleware_RequireStatus_Success(t *testing.T) {
 	// Setup
 	mockService := new(mockAuthService)
 	middleware := NewAuthMiddleware(mockService)
@@ -332,7 +379,7 @@ func TestAuthMiddleware_RequireRole_Success(t *testing.T) {
 	c.Set(UserContextKey, user)
 
 	// Execute middleware
-	handler := middleware.RequireRole("active")(testHandler)
+	handler := middleware.RequireStatus("active")(testHandler)
 	err := handler(c)
 
 	// Assert
@@ -340,7 +387,7 @@ func TestAuthMiddleware_RequireRole_Success(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
-func TestAuthMiddleware_RequireRole_InsufficientPermissions(t *testing.T) {
+func TestAuthMiddleware_RequireStatus_InsufficientPermissions(t *testing.T) {
 	// Setup
 	mockService := new(mockAuthService)
 	middleware := NewAuthMiddleware(mockService)
@@ -362,7 +409,7 @@ func TestAuthMiddleware_RequireRole_InsufficientPermissions(t *testing.T) {
 	c.Set(UserContextKey, user)
 
 	// Execute middleware
-	handler := middleware.RequireRole("active")(testHandler)
+	handler := middleware.RequireStatus("active")(testHandler)
 	err := handler(c)
 
 	// Assert
@@ -370,7 +417,7 @@ func TestAuthMiddleware_RequireRole_InsufficientPermissions(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, rec.Code)
 }
 
-func TestAuthMiddleware_RequireRole_NoUser(t *testing.T) {
+func TestAuthMiddleware_RequireStatus_NoUser(t *testing.T) {
 	// Setup
 	mockService := new(mockAuthService)
 	middleware := NewAuthMiddleware(mockService)
@@ -387,7 +434,7 @@ func TestAuthMiddleware_RequireRole_NoUser(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	// Execute middleware
-	handler := middleware.RequireRole("active")(testHandler)
+	handler := middleware.RequireStatus("active")(testHandler)
 	err := handler(c)
 
 	// Assert
@@ -503,6 +550,34 @@ func TestSetSessionCookie(t *testing.T) {
 	assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
 }
 
+func TestSetSessionCookie_SecureOverTLS(t *testing.T) {
+	e := setupEcho()
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/", nil)
+	rec := httptest.NewRecorder()
+
+	SetSessionCookie(e.NewContext(req, rec), "test-session-id", 3600)
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.True(t, cookies[0].Secure)
+}
+
+func TestParseSameSite(t *testing.T) {
+	tests := map[string]http.SameSite{
+		"strict": http.SameSiteStrictMode,
+		"Lax":    http.SameSiteLaxMode,
+		"none":   http.SameSiteNoneMode,
+	}
+	for value, want := range tests {
+		got, err := ParseSameSite(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	_, err := ParseSameSite("relaxed")
+	assert.Error(t, err)
+}
+
 func TestGetUserFromContext(t *testing.T) {
 	// Setup
 	e := setupEcho()
@@ -538,3 +613,75 @@ func TestGetSessionFromContext(t *testing.T) {
 	session = GetSessionFromContext(c)
 	assert.Equal(t, testSession, session)
 }
+
+func TestGetAuthenticatedAt(t *testing.T) {
+	// Setup
+	e := setupEcho()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Test with no session in context
+	_, ok := GetAuthenticatedAt(c)
+	assert.False(t, ok)
+
+	// Test with session in context
+	testSession := createTestSession()
+	c.Set(SessionContextKey, testSession)
+	authenticatedAt, ok := GetAuthenticatedAt(c)
+	assert.True(t, ok)
+	assert.Equal(t, testSession.CreatedAt, authenticatedAt)
+}
+
+// newRequireRoleTestServer serves /admin to users with the admin role, with
+// signedInAs stored in context as RequireAuth would
+func newRequireRoleTestServer(signedInAs *userDomain.User) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = CustomErrorHandler(ErrorHandlerConfig{JSONAPIErrors: true})
+
+	setUser := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if signedInAs != nil {
+				c.Set(UserContextKey, signedInAs)
+			}
+			return next(c)
+		}
+	}
+	e.GET("/admin", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"message": "authorized"})
+	}, setUser, RequireRole(string(userDomain.UserRoleAdmin)))
+
+	return e
+}
+
+func doRequireRoleRequest(e *echo.Echo) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.Header.Set("Accept", echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRequireRole_AllowedRole(t *testing.T) {
+	user := createTestUser()
+	require.NoError(t, user.ChangeRole(userDomain.UserRoleAdmin))
+
+	rec := doRequireRoleRequest(newRequireRoleTestServer(user))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "authorized")
+}
+
+func TestRequireRole_DisallowedRole(t *testing.T) {
+	rec := doRequireRoleRequest(newRequireRoleTestServer(createTestUser()))
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INSUFFICIENT_ROLE")
+}
+
+func TestRequireRole_NoUser(t *testing.T) {
+	rec := doRequireRoleRequest(newRequireRoleTestServer(nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "UNAUTHORIZED")
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ResourceContextKey is the key used to store the authorized resource in context
+const ResourceContextKey = "resource"
+
+// ResourceLoader loads the resource a request acts on, e.g. the user named
+// by a path parameter. Errors should be *errors.AppError so they are
+// rendered with the right status.
+type ResourceLoader func(c echo.Context) (interface{}, error)
+
+// Authorize middleware that requires policy to allow the authenticated user
+// to perform action on the resource returned by loadResource. The resource
+// is stored in context for the handler. It runs after RequireAuth, which
+// stores the user in context.
+func Authorize(policy userDomain.Policy, action string, loadResource ResourceLoader) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			user, ok := GetUserFromContext(c).(*userDomain.User)
+			if !ok || user == nil {
+				return errors.NewAuthenticationError("UNAUTHORIZED", "Authentication required")
+			}
+
+			resource, err := loadResource(c)
+			if err != nil {
+				return err
+			}
+
+			allowed, err := policy.Can(c.Request().Context(), user, action, resource)
+			if err != nil {
+				return errors.NewInternalErrorWithCause("AUTHORIZATION_FAILED", "Failed to evaluate authorization policy", err)
+			}
+			if !allowed {
+				return errors.NewInsufficientPermissionsError()
+			}
+
+			c.Set(ResourceContextKey, resource)
+			return next(c)
+		}
+	}
+}
+
+// GetResourceFromContext retrieves the resource stored by Authorize
+func GetResourceFromContext(c echo.Context) interface{} {
+	return c.Get(ResourceContextKey)
+}
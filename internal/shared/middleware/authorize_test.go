@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ownerPolicy allows users to act only on resources naming them
+type ownerPolicy struct {
+	err error
+}
+
+func (p *ownerPolicy) Can(ctx context.Context, subject *userDomain.User, action string, resource interface{}) (bool, error) {
+	if p.err != nil {
+		return false, p.err
+	}
+	return resource == subject.ID, nil
+}
+
+// newAuthorizeTestServer serves /things/:id to the owner of the thing, with
+// signedInAs stored in context as RequireAuth would
+func newAuthorizeTestServer(policy userDomain.Policy, signedInAs *userDomain.User) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = CustomErrorHandler(ErrorHandlerConfig{JSONAPIErrors: true})
+
+	setUser := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if signedInAs != nil {
+				c.Set(UserContextKey, signedInAs)
+			}
+			return next(c)
+		}
+	}
+	loadThing := func(c echo.Context) (interface{}, error) {
+		if c.Param("id") == "missing" {
+			return nil, errors.NewNotFoundError("thing", "missing")
+		}
+		return c.Param("id"), nil
+	}
+	e.GET("/things/:id", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]interface{}{"thing": GetResourceFromContext(c)})
+	}, setUser, Authorize(policy, "thing.view", loadThing))
+
+	return e
+}
+
+func doAuthorizeRequest(e *echo.Echo, id string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/things/"+id, nil)
+	req.Header.Set("Accept", echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthorize_Allowed(t *testing.T) {
+	rec := doAuthorizeRequest(newAuthorizeTestServer(&ownerPolicy{}, createTestUser()), "user-123")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"thing":"user-123"}`, rec.Body.String())
+}
+
+func TestAuthorize_Denied(t *testing.T) {
+	rec := doAuthorizeRequest(newAuthorizeTestServer(&ownerPolicy{}, createTestUser()), "user-456")
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INSUFFICIENT_PERMISSIONS")
+}
+
+func TestAuthorize_NoUser(t *testing.T) {
+	rec := doAuthorizeRequest(newAuthorizeTestServer(&ownerPolicy{}, nil), "user-123")
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthorize_LoaderError(t *testing.T) {
+	rec := doAuthorizeRequest(newAuthorizeTestServer(&ownerPolicy{}, createTestUser()), "missing")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAuthorize_PolicyError(t *testing.T) {
+	policy := &ownerPolicy{err: stderrors.New("policy unavailable")}
+	rec := doAuthorizeRequest(newAuthorizeTestServer(policy, createTestUser()), "user-123")
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// BodyLimitMiddleware rejects request bodies larger than maxBytes with a
+// PAYLOAD_TOO_LARGE error (413). Requests that declare a larger
+// Content-Length are rejected up front; otherwise the body is wrapped with
+// http.MaxBytesReader and the failure surfaces when a handler reads past the
+// limit, e.g. in c.Bind. A non-positive maxBytes disables the limit.
+func BodyLimitMiddleware(maxBytes int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if maxBytes <= 0 {
+				return next(c)
+			}
+
+			req := c.Request()
+			if req.ContentLength > maxBytes {
+				return errors.NewPayloadTooLargeError(maxBytes)
+			}
+
+			req.Body = http.MaxBytesReader(c.Response(), req.Body, maxBytes)
+
+			err := next(c)
+			if err != nil {
+				// c.Bind reports read failures as a 400 wrapping the reader error
+				var maxBytesErr *http.MaxBytesError
+				if stderrors.As(err, &maxBytesErr) {
+					return errors.NewPayloadTooLargeError(maxBytes)
+				}
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBodyLimitTestServer(maxBytes int64) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = CustomErrorHandler(ErrorHandlerConfig{JSONAPIErrors: true})
+	e.Use(BodyLimitMiddleware(maxBytes))
+
+	e.POST("/api/echo", func(c echo.Context) error {
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := c.Bind(&body); err != nil {
+			return err
+		}
+		return c.JSON(http.StatusOK, body)
+	})
+
+	return e
+}
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		chunked        bool
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "body under limit",
+			body:           `{"name":"ok"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "declared length over limit",
+			body:           `{"name":"` + strings.Repeat("a", 128) + `"}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   "PAYLOAD_TOO_LARGE",
+		},
+		{
+			name:           "chunked body over limit",
+			body:           `{"name":"` + strings.Repeat("a", 128) + `"}`,
+			chunked:        true,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   "PAYLOAD_TOO_LARGE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newBodyLimitTestServer(64)
+
+			var body io.Reader = strings.NewReader(tt.body)
+			if tt.chunked {
+				// Hide the length so the limit is enforced while reading
+				body = io.MultiReader(body)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/echo", body)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedCode == "" {
+				return
+			}
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			errorData := response["error"].(map[string]interface{})
+			assert.Equal(t, tt.expectedCode, errorData["code"])
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CompressionConfig holds configuration for response compression
+type CompressionConfig struct {
+	// Level is the gzip and deflate compression level, from
+	// gzip.BestSpeed to gzip.BestCompression
+	Level int
+
+	// MinLength is the smallest response body compressed, in bytes; smaller
+	// responses are sent as they are because compression would barely
+	// shrink them
+	MinLength int
+
+	// ContentTypes are the media types that are compressed
+	ContentTypes []string
+}
+
+// DefaultCompressionConfig returns a default compression configuration
+func DefaultCompressionConfig() CompressionConfig {
+	return CompressionConfig{
+		Level:     gzip.DefaultCompression,
+		MinLength: 1024,
+		ContentTypes: []string{
+			"text/html",
+			"text/plain",
+			"text/css",
+			"text/javascript",
+			"application/javascript",
+			"application/json",
+			"image/svg+xml",
+		},
+	}
+}
+
+// CompressionMiddleware compresses responses with gzip or deflate, whichever
+// the client prefers in Accept-Encoding. Only responses of at least
+// MinLength bytes with an allowed content type are compressed; responses
+// that already have a Content-Encoding, such as pre-compressed assets, and
+// partial content are sent as they are.
+func CompressionMiddleware(config CompressionConfig) echo.MiddlewareFunc {
+	allowed := make(map[string]bool, len(config.ContentTypes))
+	for _, contentType := range config.ContentTypes {
+		allowed[contentType] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Method == http.MethodHead {
+				return next(c)
+			}
+
+			res := c.Response()
+			res.Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+
+			encoding := negotiateEncoding(req.Header.Get(echo.HeaderAcceptEncoding))
+			if encoding == "" {
+				return next(c)
+			}
+
+			writer := &compressWriter{
+				ResponseWriter: res.Writer,
+				encoding:       encoding,
+				config:         config,
+				allowed:        allowed,
+			}
+			res.Writer = writer
+			defer func() {
+				res.Writer = writer.ResponseWriter
+			}()
+
+			err := next(c)
+			if closeErr := writer.Close(); err == nil {
+				err = closeErr
+			}
+			return err
+		}
+	}
+}
+
+// negotiateEncoding returns the encoding to compress with for an
+// Accept-Encoding header: "gzip", "deflate" or "" for none. Gzip is
+// preferred when the client accepts both equally.
+func negotiateEncoding(acceptEncoding string) string {
+	best, bestQuality := "", 0.0
+	wildcard := -1.0
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		switch name {
+		case "gzip", "deflate":
+			seen[name] = true
+			if quality > bestQuality || (quality == bestQuality && quality > 0 && name == "gzip") {
+				best, bestQuality = name, quality
+			}
+		case "*":
+			wildcard = quality
+		}
+	}
+
+	// The wildcard covers encodings the header does not name
+	if wildcard > bestQuality {
+		for _, name := range []string{"gzip", "deflate"} {
+			if !seen[name] {
+				return name
+			}
+		}
+	}
+	return best
+}
+
+// compressWriter buffers the start of a response until it knows whether to
+// compress it, then either compresses the body or passes it through
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	config   CompressionConfig
+	allowed  map[string]bool
+
+	status     int
+	buffer     bytes.Buffer
+	decided    bool
+	compressor io.WriteCloser
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.decided || w.status != 0 {
+		return
+	}
+	if status < http.StatusOK {
+		// Informational responses precede the final one
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+
+	// Bodiless and partial responses are never compressed
+	if status == http.StatusNoContent || status == http.StatusNotModified ||
+		status == http.StatusPartialContent {
+		w.passThrough()
+	}
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.decided {
+		return w.write(p)
+	}
+
+	w.buffer.Write(p)
+	if w.Header().Get(echo.HeaderContentEncoding) != "" || !w.compressible() {
+		return len(p), w.passThrough()
+	}
+	if w.buffer.Len() >= w.config.MinLength {
+		return len(p), w.startCompression()
+	}
+	return len(p), nil
+}
+
+// Flush sends what has been written so far, compressing it if the content
+// type allows regardless of its length, as streamed responses rarely reach
+// MinLength in one write
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		if w.Header().Get(echo.HeaderContentEncoding) == "" && w.compressible() {
+			w.startCompression()
+		} else {
+			w.passThrough()
+		}
+	}
+
+	if flusher, ok := w.compressor.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Close sends a response that never reached MinLength as it is, or
+// finishes the compressed body
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		if w.status == 0 {
+			// Nothing was written; let the error handler write the response
+			return nil
+		}
+		return w.passThrough()
+	}
+	if w.compressor != nil {
+		return w.compressor.Close()
+	}
+	return nil
+}
+
+// compressible reports whether the response's content type is allowed,
+// sniffing it from the buffered body when the handler did not set one
+func (w *compressWriter) compressible() bool {
+	contentType := w.Header().Get(echo.HeaderContentType)
+	if contentType == "" {
+		contentType = http.DetectContentType(w.buffer.Bytes())
+		w.Header().Set(echo.HeaderContentType, contentType)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && w.allowed[mediaType]
+}
+
+// passThrough sends the response uncompressed
+func (w *compressWriter) passThrough() error {
+	w.decided = true
+	w.ResponseWriter.WriteHeader(w.status)
+	return w.flushBuffer()
+}
+
+// startCompression sends the response headers for a compressed body and
+// compresses what has been buffered
+func (w *compressWriter) startCompression() error {
+	w.decided = true
+
+	var err error
+	switch w.encoding {
+	case "gzip":
+		w.compressor, err = gzip.NewWriterLevel(w.ResponseWriter, w.config.Level)
+	default:
+		w.compressor, err = flate.NewWriter(w.ResponseWriter, w.config.Level)
+	}
+	if err != nil {
+		w.compressor = nil
+		return w.passThrough()
+	}
+
+	header := w.Header()
+	header.Set(echo.HeaderContentEncoding, w.encoding)
+	header.Del(echo.HeaderContentLength)
+	w.ResponseWriter.WriteHeader(w.status)
+	return w.flushBuffer()
+}
+
+// flushBuffer writes the buffered start of the body
+func (w *compressWriter) flushBuffer() error {
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+	_, err := w.write(w.buffer.Bytes())
+	w.buffer.Reset()
+	return err
+}
+
+func (w *compressWriter) write(p []byte) (int, error) {
+	if w.compressor != nil {
+		return w.compressor.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
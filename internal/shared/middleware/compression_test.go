@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var largeHTML = "<!DOCTYPE html><html><body>" + strings.Repeat("<p>Hello, world!</p>", 200) + "</body></html>"
+
+func newCompressionServer() *echo.Echo {
+	e := echo.New()
+	e.Use(CompressionMiddleware(DefaultCompressionConfig()))
+	e.GET("/page", func(c echo.Context) error {
+		return c.HTML(http.StatusOK, largeHTML)
+	})
+	e.GET("/small", func(c echo.Context) error {
+		return c.HTML(http.StatusOK, "<p>Hi</p>")
+	})
+	e.GET("/image", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, "image/png", bytes.Repeat([]byte{0x89, 'P', 'N', 'G'}, 1024))
+	})
+	e.GET("/precompressed", func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderContentEncoding, "br")
+		return c.Blob(http.StatusOK, "text/css", bytes.Repeat([]byte{0x1b}, 2048))
+	})
+	return e
+}
+
+func serveCompression(e *echo.Echo, path, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCompressionMiddleware_GzipsLargeHTML(t *testing.T) {
+	rec := serveCompression(newCompressionServer(), "/page", "gzip, deflate, br")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, "gzip", rec.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, echo.HeaderAcceptEncoding, rec.Header().Get(echo.HeaderVary))
+	assert.Equal(t, echo.MIMETextHTMLCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+	assert.Less(t, rec.Body.Len(), len(largeHTML))
+
+	reader, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, largeHTML, string(body))
+}
+
+func TestCompressionMiddleware_Deflate(t *testing.T) {
+	rec := serveCompression(newCompressionServer(), "/page", "gzip;q=0.5, deflate")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "deflate", rec.Header().Get(echo.HeaderContentEncoding))
+
+	body, err := io.ReadAll(flate.NewReader(rec.Body))
+	require.NoError(t, err)
+	assert.Equal(t, largeHTML, string(body))
+}
+
+func TestCompressionMiddleware_Uncompressed(t *testing.T) {
+	e := newCompressionServer()
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		encoding       string
+	}{
+		{"client without compression", "/page", "", ""},
+		{"client refusing gzip", "/page", "gzip;q=0", ""},
+		{"small response", "/small", "gzip", ""},
+		{"image", "/image", "gzip", ""},
+		{"already compressed", "/precompressed", "gzip", "br"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveCompression(e, tt.path, tt.acceptEncoding)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			assert.Equal(t, tt.encoding, rec.Header().Get(echo.HeaderContentEncoding))
+			assert.Equal(t, echo.HeaderAcceptEncoding, rec.Header().Get(echo.HeaderVary))
+			if tt.path == "/page" {
+				assert.Equal(t, largeHTML, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"deflate", "deflate"},
+		{"deflate, gzip", "gzip"},
+		{"gzip;q=0.2, deflate;q=0.8", "deflate"},
+		{"br", ""},
+		{"*", "gzip"},
+		{"gzip;q=0, *", "deflate"},
+		{"identity, *;q=0", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, negotiateEncoding(tt.acceptEncoding), "Accept-Encoding: %q", tt.acceptEncoding)
+	}
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCORSServer(config CORSConfig) *echo.Echo {
+	e := echo.New()
+	e.Use(CORSMiddleware(config))
+	e.GET("/api/users", func(c echo.Context) error {
+		return c.String(http.StatusOK, "users")
+	})
+	return e
+}
+
+func serveCORS(e *echo.Echo, method, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/users", nil)
+	req.Header.Set(echo.HeaderOrigin, origin)
+	if method == http.MethodOptions {
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodGet)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCORSMiddleware_AllowedOrigins(t *testing.T) {
+	config := DefaultCORSConfig()
+	config.AllowedOrigins = []string{"https://app.example.com"}
+	e := newCORSServer(config)
+
+	tests := []struct {
+		name   string
+		method string
+		origin string
+		allow  string
+	}{
+		{"allowed origin", http.MethodGet, "https://app.example.com", "https://app.example.com"},
+		{"disallowed origin", http.MethodGet, "https://evil.example.com", ""},
+		{"allowed preflight", http.MethodOptions, "https://app.example.com", "https://app.example.com"},
+		{"disallowed preflight", http.MethodOptions, "https://evil.example.com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveCORS(e, tt.method, tt.origin)
+
+			assert.Equal(t, tt.allow, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+			if tt.method == http.MethodOptions {
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+				if tt.allow != "" {
+					assert.Equal(t, "GET,HEAD,POST,PUT,PATCH,DELETE", rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+					assert.Equal(t, "600", rec.Header().Get(echo.HeaderAccessControlMaxAge))
+				} else {
+					assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods))
+				}
+			}
+		})
+	}
+}
+
+func TestCORSMiddleware_DefaultAllowsNoOrigin(t *testing.T) {
+	e := newCORSServer(DefaultCORSConfig())
+
+	for _, method := range []string{http.MethodGet, http.MethodOptions} {
+		rec := serveCORS(e, method, "https://app.example.com")
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin), method)
+	}
+}
+
+func TestCORSMiddleware_Wildcard(t *testing.T) {
+	config := DefaultCORSConfig()
+	config.AllowedOrigins = []string{"*"}
+
+	rec := serveCORS(newCORSServer(config), http.MethodGet, "https://anywhere.example.com")
+	assert.Equal(t, "https://anywhere.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowCredentials))
+
+	// Credentials are never shared with any origin
+	config.AllowedOrigins = []string{"*", "https://app.example.com"}
+	config.AllowCredentials = true
+	e := newCORSServer(config)
+
+	for _, method := range []string{http.MethodGet, http.MethodOptions} {
+		rec = serveCORS(e, method, "https://anywhere.example.com")
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin), method)
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowCredentials), method)
+
+		rec = serveCORS(e, method, "https://app.example.com")
+		assert.Equal(t, "https://app.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin), method)
+		assert.Equal(t, "true", rec.Header().Get(echo.HeaderAccessControlAllowCredentials), method)
+	}
+}
@@ -1,12 +1,15 @@
 package middleware
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
 	"net/http"
 
+	"go-templ-template/web/templates/components"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -21,7 +24,10 @@ const (
 	CSRFHeaderName = "X-CSRF-Token"
 
 	// CSRFFormFieldName is the name of the CSRF form field
-	CSRFFormFieldName = "_csrf_token"
+	CSRFFormFieldName = components.CSRFFieldName
+
+	// CSRFContextKey is the Echo context key holding the request's CSRF token
+	CSRFContextKey = "csrf_token"
 )
 
 // CSRFConfig holds configuration for CSRF protection
@@ -90,8 +96,9 @@ func (m *CSRFMiddleware) Protect(next echo.HandlerFunc) echo.HandlerFunc {
 		// Skip CSRF check for safe methods
 		method := c.Request().Method
 		if method == "GET" || method == "HEAD" || method == "OPTIONS" {
-			// Generate and set CSRF token for safe methods
-			token, err := m.generateToken()
+			// Issue a CSRF token for safe methods, keeping the client's
+			// current token so tokens held by SPAs stay valid
+			token, err := m.requestToken(c)
 			if err != nil {
 				return c.JSON(http.StatusInternalServerError, map[string]interface{}{
 					"error":   "INTERNAL_ERROR",
@@ -100,7 +107,7 @@ func (m *CSRFMiddleware) Protect(next echo.HandlerFunc) echo.HandlerFunc {
 			}
 
 			m.setCSRFCookie(c, token)
-			c.Set("csrf_token", token)
+			c.Set(CSRFContextKey, token)
 			return next(c)
 		}
 
@@ -129,10 +136,18 @@ func (m *CSRFMiddleware) Protect(next echo.HandlerFunc) echo.HandlerFunc {
 			})
 		}
 
+		// Keep the token available for forms re-rendered by this request
+		c.Set(CSRFContextKey, cookieToken)
 		return next(c)
 	}
 }
 
+// TemplateContext returns the request context with the CSRF token attached,
+// for rendering templates whose forms embed components.CSRFField
+func TemplateContext(c echo.Context) context.Context {
+	return components.WithCSRFToken(c.Request().Context(), GetCSRFToken(c))
+}
+
 // GenerateToken generates a new CSRF token and sets it in the response
 func (m *CSRFMiddleware) GenerateToken(c echo.Context) (string, error) {
 	token, err := m.generateToken()
@@ -144,6 +159,16 @@ func (m *CSRFMiddleware) GenerateToken(c echo.Context) (string, error) {
 	return token, nil
 }
 
+// requestToken returns the valid token from the request's CSRF cookie, or a
+// newly generated one when the cookie is missing or malformed
+func (m *CSRFMiddleware) requestToken(c echo.Context) (string, error) {
+	token := m.getTokenFromCookie(c)
+	if decoded, err := base64.URLEncoding.DecodeString(token); err == nil && len(decoded) == m.config.TokenLength {
+		return token, nil
+	}
+	return m.generateToken()
+}
+
 // generateToken generates a new random CSRF token
 func (m *CSRFMiddleware) generateToken() (string, error) {
 	bytes := make([]byte, m.config.TokenLength)
@@ -219,20 +244,28 @@ func (m *CSRFMiddleware) setCSRFCookie(c echo.Context, token string) {
 
 // GetCSRFToken returns the CSRF token from context or generates a new one
 func GetCSRFToken(c echo.Context) string {
-	if token, ok := c.Get("csrf_token").(string); ok {
+	if token, ok := c.Get(CSRFContextKey).(string); ok {
 		return token
 	}
 	return ""
 }
 
-// CSRFTokenHandler returns a handler that provides CSRF token to clients
+// CSRFTokenHandler returns a handler that provides CSRF token to clients,
+// e.g. JavaScript front-ends that send it back in the X-CSRF-Token header.
+// The token is also set as the CSRF cookie for double-submit validation.
 func (m *CSRFMiddleware) CSRFTokenHandler(c echo.Context) error {
-	token, err := m.GenerateToken(c)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"error":   "INTERNAL_ERROR",
-			"message": "Failed to generate CSRF token",
-		})
+	// Reuse the token already issued for this request by Protect
+	token := GetCSRFToken(c)
+	if token == "" {
+		var err error
+		token, err = m.requestToken(c)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+				"error":   "INTERNAL_ERROR",
+				"message": "Failed to generate CSRF token",
+			})
+		}
+		m.setCSRFCookie(c, token)
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -4,12 +4,15 @@
 package middleware
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"go-templ-template/internal/shared/errors"
+	"go-templ-template/internal/shared/events"
 	"go-templ-template/web/templates/pages"
 
 	"github.com/labstack/echo/v4"
@@ -28,8 +31,36 @@ type ErrorHandlerConfig struct {
 
 	// JSONAPIErrors determines if API errors should be returned as JSON
 	JSONAPIErrors bool
+
+	// Format selects how API errors are rendered. Clients can ask for the
+	// JSON:API or Problem Details format per request with an Accept header
+	// of application/vnd.api+json or application/problem+json whatever it
+	// is set to.
+	Format ErrorFormat
 }
 
+// ErrorFormat is a format API error responses are rendered in
+type ErrorFormat string
+
+const (
+	// ErrorFormatDefault renders errors as {"error": {...}}
+	ErrorFormatDefault ErrorFormat = ""
+
+	// ErrorFormatJSONAPI renders errors as a JSON:API errors array
+	ErrorFormatJSONAPI ErrorFormat = "jsonapi"
+
+	// ErrorFormatProblem renders errors as RFC 7807 Problem Details
+	ErrorFormatProblem ErrorFormat = "problem"
+)
+
+const (
+	// JSONAPIMediaType is the media type of JSON:API documents
+	JSONAPIMediaType = "application/vnd.api+json"
+
+	// ProblemJSONMediaType is the media type of RFC 7807 Problem Details
+	ProblemJSONMediaType = "application/problem+json"
+)
+
 // DefaultErrorHandlerConfig returns the default error handler configuration
 func DefaultErrorHandlerConfig() ErrorHandlerConfig {
 	return ErrorHandlerConfig{
@@ -71,6 +102,21 @@ func handleError(c echo.Context, err error, config ErrorHandlerConfig) error {
 		logError(c, err)
 	}
 
+	// Validation errors may come as a list, which API clients get in full
+	errorList, isList := errors.AsErrorList(err)
+	if isList && errorList.HasErrors() {
+		if isAPIRequest(c) && config.JSONAPIErrors {
+			switch errorFormat(c, config) {
+			case ErrorFormatJSONAPI:
+				return sendJSONAPIErrors(c, errorList.GetHTTPStatus(), errorList.Errors)
+			case ErrorFormatProblem:
+				return sendProblem(c, errorList.GetHTTPStatus(), errorList.Errors)
+			}
+			return c.JSON(errorList.GetHTTPStatus(), errorList.ToHTTPResponse())
+		}
+		err = errorList.Errors[0]
+	}
+
 	// Convert to AppError if possible
 	var appErr *errors.AppError
 	if appError, ok := errors.AsAppError(err); ok {
@@ -86,6 +132,12 @@ func handleError(c echo.Context, err error, config ErrorHandlerConfig) error {
 
 	// Determine response format based on request
 	if isAPIRequest(c) && config.JSONAPIErrors {
+		switch errorFormat(c, config) {
+		case ErrorFormatJSONAPI:
+			return sendJSONAPIErrors(c, appErr.HTTPStatus, []*errors.AppError{appErr})
+		case ErrorFormatProblem:
+			return sendProblem(c, appErr.HTTPStatus, []*errors.AppError{appErr})
+		}
 		return sendJSONError(c, appErr, config)
 	} else if config.CustomErrorPages {
 		return sendHTMLError(c, appErr)
@@ -130,7 +182,8 @@ func isAPIRequest(c echo.Context) bool {
 	}
 
 	// Check Accept header for JSON
-	if strings.Contains(accept, "application/json") {
+	if strings.Contains(accept, "application/json") || strings.Contains(accept, JSONAPIMediaType) ||
+		strings.Contains(accept, ProblemJSONMediaType) {
 		return true
 	}
 
@@ -159,6 +212,128 @@ func sendJSONError(c echo.Context, appErr *errors.AppError, config ErrorHandlerC
 	return c.JSON(appErr.HTTPStatus, response)
 }
 
+// errorFormat returns the format to render API errors in: the one the
+// client's Accept header asks for, or the configured one
+func errorFormat(c echo.Context, config ErrorHandlerConfig) ErrorFormat {
+	accept := c.Request().Header.Get(echo.HeaderAccept)
+	switch {
+	case strings.Contains(accept, ProblemJSONMediaType):
+		return ErrorFormatProblem
+	case strings.Contains(accept, JSONAPIMediaType):
+		return ErrorFormatJSONAPI
+	}
+	return config.Format
+}
+
+// jsonAPIError is an error object of a JSON:API errors document
+type jsonAPIError struct {
+	ID     string              `json:"id,omitempty"`
+	Status string              `json:"status"`
+	Code   string              `json:"code"`
+	Title  string              `json:"title"`
+	Detail string              `json:"detail"`
+	Source *jsonAPIErrorSource `json:"source,omitempty"`
+}
+
+// jsonAPIErrorSource points at the part of the request that caused an error
+type jsonAPIErrorSource struct {
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// sendJSONAPIErrors sends errors as a JSON:API errors document. An error's
+// "field" detail becomes its source: a pointer into the request body, or
+// the query parameter for requests without one.
+func sendJSONAPIErrors(c echo.Context, status int, appErrs []*errors.AppError) error {
+	hasBody := c.Request().ContentLength > 0
+	documents := make([]jsonAPIError, 0, len(appErrs))
+	for _, appErr := range appErrs {
+		document := jsonAPIError{
+			ID:     appErr.ID,
+			Status: strconv.Itoa(appErr.HTTPStatus),
+			Code:   appErr.Code,
+			Title:  getErrorTitle(appErr.HTTPStatus),
+			Detail: errorDetail(appErr),
+		}
+
+		if field, ok := appErr.Details["field"].(string); ok && field != "" {
+			if hasBody {
+				document.Source = &jsonAPIErrorSource{Pointer: "/" + field}
+			} else {
+				document.Source = &jsonAPIErrorSource{Parameter: field}
+			}
+		}
+		documents = append(documents, document)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, JSONAPIMediaType)
+	c.Response().WriteHeader(status)
+	return json.NewEncoder(c.Response()).Encode(map[string]interface{}{"errors": documents})
+}
+
+// problemDetails is an RFC 7807 Problem Details object, with the error code
+// and ID as extension members
+type problemDetails struct {
+	Type     string           `json:"type"`
+	Title    string           `json:"title"`
+	Status   int              `json:"status"`
+	Detail   string           `json:"detail"`
+	Instance string           `json:"instance,omitempty"`
+	Code     string           `json:"code,omitempty"`
+	ErrorID  string           `json:"error_id,omitempty"`
+	Errors   []problemInvalid `json:"errors,omitempty"`
+}
+
+// problemInvalid is one of several errors reported by a problem
+type problemInvalid struct {
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+	Field  string `json:"field,omitempty"`
+}
+
+// sendProblem sends errors as RFC 7807 Problem Details. The problem type is
+// about:blank, so the status code and the code member identify the
+// problem; the instance is the request ID, so clients can quote it. Several
+// errors are sent as one problem listing them in an errors member.
+func sendProblem(c echo.Context, status int, appErrs []*errors.AppError) error {
+	problem := problemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if metadata, ok := events.RequestMetadataFromContext(c.Request().Context()); ok && metadata.RequestID != "" {
+		problem.Instance = "urn:request:" + metadata.RequestID
+	}
+
+	if len(appErrs) == 1 {
+		problem.Code = appErrs[0].Code
+		problem.ErrorID = appErrs[0].ID
+		problem.Detail = errorDetail(appErrs[0])
+	} else {
+		problem.Detail = fmt.Sprintf("The request has %d errors", len(appErrs))
+		for _, appErr := range appErrs {
+			field, _ := appErr.Details["field"].(string)
+			problem.Errors = append(problem.Errors, problemInvalid{
+				Code:   appErr.Code,
+				Detail: errorDetail(appErr),
+				Field:  field,
+			})
+		}
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, ProblemJSONMediaType)
+	c.Response().WriteHeader(status)
+	return json.NewEncoder(c.Response()).Encode(problem)
+}
+
+// errorDetail returns the message of appErr shown to clients
+func errorDetail(appErr *errors.AppError) string {
+	if appErr.UserMessage != "" {
+		return appErr.UserMessage
+	}
+	return appErr.Message
+}
+
 // sendHTMLError sends an HTML error page response
 func sendHTMLError(c echo.Context, appErr *errors.AppError) error {
 	// Set the appropriate HTTP status
@@ -255,6 +430,8 @@ func getErrorTitle(statusCode int) string {
 		return "Method Not Allowed"
 	case http.StatusConflict:
 		return "Conflict"
+	case http.StatusRequestEntityTooLarge:
+		return "Payload Too Large"
 	case http.StatusUnprocessableEntity:
 		return "Validation Error"
 	case http.StatusTooManyRequests:
@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"go-templ-template/internal/shared/errors"
@@ -547,6 +549,187 @@ func TestErrorHandlerIntegration(t *testing.T) {
 	}
 }
 
+// TestErrorHandler_JSONAPIFormat tests JSON:API errors documents
+func TestErrorHandler_JSONAPIFormat(t *testing.T) {
+	config := ErrorHandlerConfig{JSONAPIErrors: true, Format: ErrorFormatJSONAPI}
+
+	run := func(t *testing.T, config ErrorHandlerConfig, req *http.Request, err error) (*httptest.ResponseRecorder, map[string]interface{}) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+		handler := ErrorHandler(config)(func(c echo.Context) error { return err })
+		assert.NoError(t, handler(c))
+
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return rec, body
+	}
+
+	t.Run("single validation error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/users", strings.NewReader(`{"email":"x"}`))
+		err := errors.NewValidationErrorWithDetails("INVALID_EMAIL", "Email is invalid",
+			map[string]interface{}{"field": "email"})
+
+		rec, body := run(t, config, req, err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, JSONAPIMediaType, rec.Header().Get(echo.HeaderContentType))
+		assert.NotContains(t, body, "error")
+		assert.Equal(t, []interface{}{map[string]interface{}{
+			"id":     err.ID,
+			"status": "400",
+			"code":   "INVALID_EMAIL",
+			"title":  "Bad Request",
+			"detail": "Email is invalid",
+			"source": map[string]interface{}{"pointer": "/email"},
+		}}, body["errors"])
+	})
+
+	t.Run("error list", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/users?page=0&sort=age", nil)
+		list := &errors.ErrorList{}
+		list.Add(errors.NewValidationErrorWithDetails("INVALID_LIST_PARAMETER", "page must be a positive integer",
+			map[string]interface{}{"field": "page"}))
+		list.Add(errors.NewValidationErrorWithDetails("INVALID_LIST_PARAMETER", "sort must be one of email",
+			map[string]interface{}{"field": "sort"}))
+
+		rec, body := run(t, config, req, list)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		documents := body["errors"].([]interface{})
+		if assert.Len(t, documents, 2) {
+			for i, param := range []string{"page", "sort"} {
+				document := documents[i].(map[string]interface{})
+				assert.Equal(t, "400", document["status"])
+				assert.Equal(t, "INVALID_LIST_PARAMETER", document["code"])
+				assert.Equal(t, list.Errors[i].Message, document["detail"])
+				assert.Equal(t, map[string]interface{}{"parameter": param}, document["source"])
+			}
+		}
+	})
+
+	t.Run("requested with Accept header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/users/1", nil)
+		req.Header.Set(echo.HeaderAccept, JSONAPIMediaType)
+
+		rec, body := run(t, ErrorHandlerConfig{JSONAPIErrors: true}, req,
+			errors.NewAppError(errors.ErrorTypeNotFound, "USER_NOT_FOUND", "User not found", http.StatusNotFound))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, JSONAPIMediaType, rec.Header().Get(echo.HeaderContentType))
+		document := body["errors"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "404", document["status"])
+		assert.Equal(t, "USER_NOT_FOUND", document["code"])
+		assert.NotContains(t, document, "source")
+	})
+
+	t.Run("default format unchanged", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/users/1", nil)
+		err := errors.NewAppError(errors.ErrorTypeNotFound, "USER_NOT_FOUND", "User not found", http.StatusNotFound)
+
+		rec, body := run(t, ErrorHandlerConfig{JSONAPIErrors: true}, req, err)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+		assert.NotContains(t, body, "errors")
+		appErr := body["error"].(map[string]interface{})
+		assert.Equal(t, err.ID, appErr["id"])
+		assert.Equal(t, "USER_NOT_FOUND", appErr["code"])
+		assert.Equal(t, "User not found", appErr["message"])
+	})
+
+	t.Run("default format renders error lists", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/users", nil)
+		list := &errors.ErrorList{}
+		list.Add(errors.NewValidationError("FIRST", "first"))
+		list.Add(errors.NewValidationError("SECOND", "second"))
+
+		rec, body := run(t, ErrorHandlerConfig{JSONAPIErrors: true}, req, list)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Len(t, body["errors"], 2)
+	})
+}
+
+// TestErrorHandler_ProblemDetails tests RFC 7807 Problem Details responses
+func TestErrorHandler_ProblemDetails(t *testing.T) {
+	config := ErrorHandlerConfig{JSONAPIErrors: true}
+
+	run := func(t *testing.T, path string, err error) (*httptest.ResponseRecorder, map[string]interface{}) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(echo.HeaderAccept, ProblemJSONMediaType)
+		req.Header.Set(echo.HeaderXRequestID, "req-123")
+		rec := httptest.NewRecorder()
+		c := echo.New().NewContext(req, rec)
+
+		handler := RequestMetadata(ErrorHandler(config)(func(c echo.Context) error { return err }))
+		assert.NoError(t, handler(c))
+
+		assert.Equal(t, ProblemJSONMediaType, rec.Header().Get(echo.HeaderContentType))
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return rec, body
+	}
+
+	t.Run("validation error", func(t *testing.T) {
+		err := errors.NewValidationError("INVALID_EMAIL", "Email is invalid")
+
+		rec, body := run(t, "/api/users", err)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, map[string]interface{}{
+			"type":     "about:blank",
+			"title":    "Bad Request",
+			"status":   float64(http.StatusBadRequest),
+			"detail":   "Email is invalid",
+			"instance": "urn:request:req-123",
+			"code":     "INVALID_EMAIL",
+			"error_id": err.ID,
+		}, body)
+	})
+
+	t.Run("not found error", func(t *testing.T) {
+		rec, body := run(t, "/api/users/1",
+			errors.NewAppError(errors.ErrorTypeNotFound, "USER_NOT_FOUND", "User not found", http.StatusNotFound))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "Not Found", body["title"])
+		assert.Equal(t, float64(http.StatusNotFound), body["status"])
+		assert.Equal(t, "USER_NOT_FOUND", body["code"])
+		assert.Equal(t, "User not found", body["detail"])
+		assert.Equal(t, "urn:request:req-123", body["instance"])
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		rec, body := run(t, "/api/users", fmt.Errorf("connection refused"))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "Internal Server Error", body["title"])
+		assert.Equal(t, "INTERNAL_ERROR", body["code"])
+		assert.Equal(t, "An internal error occurred. Please try again later.", body["detail"])
+		assert.NotContains(t, rec.Body.String(), "connection refused")
+		assert.Equal(t, "urn:request:req-123", body["instance"])
+	})
+
+	t.Run("error list", func(t *testing.T) {
+		list := &errors.ErrorList{}
+		list.Add(errors.NewValidationErrorWithDetails("INVALID_LIST_PARAMETER", "page must be a positive integer",
+			map[string]interface{}{"field": "page"}))
+		list.Add(errors.NewValidationErrorWithDetails("INVALID_LIST_PARAMETER", "limit must be a positive integer",
+			map[string]interface{}{"field": "limit"}))
+
+		rec, body := run(t, "/api/users?page=0&limit=0", list)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.NotContains(t, body, "code")
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"code": "INVALID_LIST_PARAMETER", "detail": "page must be a positive integer", "field": "page"},
+			map[string]interface{}{"code": "INVALID_LIST_PARAMETER", "detail": "limit must be a positive integer", "field": "limit"},
+		}, body["errors"])
+	})
+}
+
 // BenchmarkErrorHandler benchmarks the error handler middleware
 func BenchmarkErrorHandler(b *testing.B) {
 	e := echo.New()
@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// ETagHeader is the response header carrying the ETag
+	ETagHeader = "ETag"
+
+	// IfNoneMatchHeader is the request header carrying the ETags a client
+	// has cached
+	IfNoneMatchHeader = "If-None-Match"
+
+	// DefaultETagMaxBytes is the largest response body ETagMiddleware
+	// buffers to compute its ETag
+	DefaultETagMaxBytes = 4 << 20
+)
+
+// ETagMiddleware adds a strong ETag, computed from the body, to successful
+// GET responses of at most maxBytes, and answers 304 Not Modified when the
+// request's If-None-Match matches it. An ETag the handler set is used
+// instead. Responses are buffered to compute the ETag, so larger and
+// streamed responses are sent as they are; a non-positive maxBytes buffers
+// every response. Register it after CompressionMiddleware so the ETag is
+// computed over the uncompressed body.
+func ETagMiddleware(maxBytes int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Method != http.MethodGet {
+				return next(c)
+			}
+
+			res := c.Response()
+			writer := &etagWriter{ResponseWriter: res.Writer, maxBytes: maxBytes}
+			res.Writer = writer
+			defer func() {
+				res.Writer = writer.ResponseWriter
+			}()
+
+			err := next(c)
+			if finishErr := writer.finish(req.Header.Get(IfNoneMatchHeader)); err == nil {
+				err = finishErr
+			}
+			if writer.notModified {
+				// Report what was sent, e.g. to the access log
+				res.Status = http.StatusNotModified
+				res.Size = 0
+			}
+			return err
+		}
+	}
+}
+
+// etagWriter buffers a 200 response to compute its ETag
+type etagWriter struct {
+	http.ResponseWriter
+	maxBytes int64
+
+	status      int
+	buffer      bytes.Buffer
+	passThrough bool
+	notModified bool
+}
+
+func (w *etagWriter) WriteHeader(status int) {
+	if w.passThrough || w.status != 0 {
+		return
+	}
+	if status < http.StatusOK {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+
+	// Only successful responses are tagged
+	if status != http.StatusOK {
+		w.sendBuffered()
+	}
+}
+
+func (w *etagWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.passThrough {
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buffer.Write(p)
+	if w.maxBytes > 0 && int64(w.buffer.Len()) > w.maxBytes {
+		return len(p), w.sendBuffered()
+	}
+	return len(p), nil
+}
+
+// Flush sends the response untagged, as streamed responses can't be
+// buffered
+func (w *etagWriter) Flush() {
+	if !w.passThrough {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		w.sendBuffered()
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (w *etagWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish tags the buffered response and sends it, or 304 Not Modified when
+// ifNoneMatch matches the ETag
+func (w *etagWriter) finish(ifNoneMatch string) error {
+	if w.passThrough || w.status == 0 {
+		// Streamed, or nothing was written and the error handler responds
+		return nil
+	}
+
+	header := w.Header()
+	etag := header.Get(ETagHeader)
+	if etag == "" {
+		sum := sha256.Sum256(w.buffer.Bytes())
+		etag = `"` + hex.EncodeToString(sum[:16]) + `"`
+		header.Set(ETagHeader, etag)
+	}
+
+	if etagMatches(ifNoneMatch, etag) {
+		header.Del(echo.HeaderContentLength)
+		w.passThrough = true
+		w.notModified = true
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	return w.sendBuffered()
+}
+
+// sendBuffered writes the response status and the buffered body, and
+// passes the rest of the response through
+func (w *etagWriter) sendBuffered() error {
+	w.passThrough = true
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+	return err
+}
+
+// etagMatches reports whether an If-None-Match header matches etag, using
+// the weak comparison RFC 9110 requires for If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
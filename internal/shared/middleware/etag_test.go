@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETagMiddleware_ConditionalGet(t *testing.T) {
+	body := "<p>Version 1</p>"
+
+	e := echo.New()
+	e.Use(ETagMiddleware(DefaultETagMaxBytes))
+	e.GET("/page", func(c echo.Context) error {
+		return c.HTML(http.StatusOK, body)
+	})
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/page", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set(IfNoneMatchHeader, ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := get("")
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, body, first.Body.String())
+	etag := first.Header().Get(ETagHeader)
+	require.NotEmpty(t, etag)
+	assert.True(t, strings.HasPrefix(etag, `"`) && strings.HasSuffix(etag, `"`), "ETag %s should be a quoted strong ETag", etag)
+
+	notModified := get(etag)
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+	assert.Empty(t, notModified.Body.String())
+	assert.Equal(t, etag, notModified.Header().Get(ETagHeader))
+
+	assert.Equal(t, http.StatusNotModified, get(`"stale", W/`+etag).Code, "weak and listed ETags match")
+	assert.Equal(t, http.StatusOK, get(`"stale"`).Code)
+
+	body = "<p>Version 2</p>"
+	changed := get(etag)
+	require.Equal(t, http.StatusOK, changed.Code)
+	assert.Equal(t, body, changed.Body.String())
+	assert.NotEqual(t, etag, changed.Header().Get(ETagHeader))
+}
+
+func TestETagMiddleware_SkipsUntaggedResponses(t *testing.T) {
+	e := echo.New()
+	e.Use(ETagMiddleware(8))
+	e.GET("/large", func(c echo.Context) error {
+		return c.String(http.StatusOK, "more than eight bytes")
+	})
+	e.GET("/missing", func(c echo.Context) error {
+		return c.String(http.StatusNotFound, "not found")
+	})
+	e.POST("/form", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		code   int
+		body   string
+	}{
+		{"larger than maxBytes", http.MethodGet, "/large", http.StatusOK, "more than eight bytes"},
+		{"unsuccessful", http.MethodGet, "/missing", http.StatusNotFound, "not found"},
+		{"not a GET", http.MethodPost, "/form", http.StatusOK, "ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.code, rec.Code)
+			assert.Equal(t, tt.body, rec.Body.String())
+			assert.Empty(t, rec.Header().Get(ETagHeader))
+		})
+	}
+}
+
+func TestETagMiddleware_WithCompression(t *testing.T) {
+	e := echo.New()
+	e.Use(CompressionMiddleware(DefaultCompressionConfig()), ETagMiddleware(DefaultETagMaxBytes))
+	e.GET("/page", func(c echo.Context) error {
+		return c.HTML(http.StatusOK, largeHTML)
+	})
+
+	get := func(acceptEncoding, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/page", nil)
+		req.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+		req.Header.Set(IfNoneMatchHeader, ifNoneMatch)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	plain := get("", "")
+	compressed := get("gzip", "")
+	require.Equal(t, "gzip", compressed.Header().Get(echo.HeaderContentEncoding))
+	assert.Equal(t, plain.Header().Get(ETagHeader), compressed.Header().Get(ETagHeader),
+		"the ETag is computed over the uncompressed body")
+
+	reader, err := gzip.NewReader(compressed.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, largeHTML, string(body))
+
+	notModified := get("gzip", plain.Header().Get(ETagHeader))
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+	assert.Empty(t, notModified.Body.Bytes())
+	assert.Empty(t, notModified.Header().Get(echo.HeaderContentEncoding))
+}
@@ -0,0 +1,248 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the client's
+	// idempotency key
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on responses replayed for a repeated
+	// idempotency key
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// DefaultIdempotencyTTL is how long responses are replayed by default
+	DefaultIdempotencyTTL = 24 * time.Hour
+)
+
+// IdempotentResponse is a response stored for an idempotency key
+type IdempotentResponse struct {
+	// Fingerprint identifies the request that produced the response, so a
+	// key reused for a different request is rejected rather than replayed
+	Fingerprint string
+	Status      int
+	Header      http.Header
+	Body        []byte
+}
+
+// IdempotencyStore stores the responses replayed for repeated idempotency
+// keys
+type IdempotencyStore interface {
+	// Get returns the response stored under key, or nil if there is none
+	// or it has expired
+	Get(ctx context.Context, key string) (*IdempotentResponse, error)
+
+	// Save stores response under key for ttl
+	Save(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error
+}
+
+// IdempotencyMiddleware makes POST, PUT, PATCH and DELETE requests carrying
+// an Idempotency-Key header safe to retry: the first response for a key,
+// route and signed-in user is stored for ttl and replayed for repeats of
+// the request, with an Idempotent-Replayed header, without running the
+// handler again. Reusing a key for a different request body fails with
+// IDEMPOTENCY_KEY_REUSED (422). 5xx responses and errors are not stored, so
+// those requests can be retried. Register it after the auth middleware so
+// keys are scoped per user; a zero ttl uses DefaultIdempotencyTTL.
+//
+// Concurrent requests with the same key may both run the handler; the
+// store only dedupes requests that arrive after the first has completed.
+func IdempotencyMiddleware(store IdempotencyStore, ttl time.Duration) echo.MiddlewareFunc {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(IdempotencyKeyHeader)
+			if key == "" || !isMutatingMethod(req.Method) {
+				return next(c)
+			}
+
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := req.Context()
+			storeKey := idempotencyStoreKey(c, key)
+			fingerprint := requestFingerprint(body)
+
+			stored, err := store.Get(ctx, storeKey)
+			if err != nil {
+				return errors.WrapError(err, errors.ErrorTypeInternal, "IDEMPOTENCY_STORE_ERROR",
+					"Failed to look up idempotency key")
+			}
+			if stored != nil {
+				if stored.Fingerprint != fingerprint {
+					return errors.NewAppError(errors.ErrorTypeValidation, "IDEMPOTENCY_KEY_REUSED",
+						"Idempotency key was already used for a different request", http.StatusUnprocessableEntity)
+				}
+				return replayResponse(c, stored)
+			}
+
+			res := c.Response()
+			recorder := &responseRecorder{ResponseWriter: res.Writer}
+			res.Writer = recorder
+			defer func() {
+				res.Writer = recorder.ResponseWriter
+			}()
+
+			if err := next(c); err != nil {
+				return err
+			}
+			if !res.Committed || res.Status >= http.StatusInternalServerError {
+				return nil
+			}
+
+			// Replays keep the request ID of the request being answered
+			header := res.Header().Clone()
+			header.Del(echo.HeaderXRequestID)
+
+			response := &IdempotentResponse{
+				Fingerprint: fingerprint,
+				Status:      res.Status,
+				Header:      header,
+				Body:        recorder.body.Bytes(),
+			}
+			if err := store.Save(ctx, storeKey, response, ttl); err != nil {
+				// The response was sent; a retry simply runs the handler again
+				LoggerFromEchoContext(c).WithError(err).Warn("Failed to store idempotent response")
+			}
+			return nil
+		}
+	}
+}
+
+// IdempotentAfterAuth returns requireAuth followed by IdempotencyMiddleware,
+// so routes it authenticates replay retried writes with keys scoped to the
+// signed-in user. Requests requireAuth rejects never reach the store.
+func IdempotentAfterAuth(requireAuth echo.MiddlewareFunc, store IdempotencyStore, ttl time.Duration) echo.MiddlewareFunc {
+	idempotency := IdempotencyMiddleware(store, ttl)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return requireAuth(idempotency(next))
+	}
+}
+
+// isMutatingMethod reports whether requests with method change state
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// idempotencyStoreKey scopes a client's idempotency key to the route and
+// the signed-in user, if any
+func idempotencyStoreKey(c echo.Context, key string) string {
+	userID := ""
+	if user, ok := GetUserFromContext(c).(*userDomain.User); ok && user != nil {
+		userID = user.ID
+	}
+	return strings.Join([]string{userID, c.Request().Method, c.Path(), key}, "\x00")
+}
+
+// requestFingerprint identifies a request by its body
+func requestFingerprint(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// replayResponse writes a stored response
+func replayResponse(c echo.Context, stored *IdempotentResponse) error {
+	header := c.Response().Header()
+	for name, values := range stored.Header {
+		header[name] = append([]string(nil), values...)
+	}
+	header.Set(IdempotentReplayedHeader, "true")
+
+	c.Response().WriteHeader(stored.Status)
+	_, err := c.Response().Write(stored.Body)
+	return err
+}
+
+// responseRecorder copies the response body as it is written
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.body.Write(p)
+	return r.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// MemoryIdempotencyStore is an in-memory IdempotencyStore for a single
+// server instance
+type MemoryIdempotencyStore struct {
+	now func() time.Time
+
+	mu        sync.Mutex
+	responses map[string]memoryIdempotentResponse
+}
+
+var _ IdempotencyStore = (*MemoryIdempotencyStore)(nil)
+
+// memoryIdempotentResponse is a stored response and when it expires
+type memoryIdempotentResponse struct {
+	response  *IdempotentResponse
+	expiresAt time.Time
+}
+
+// NewMemoryIdempotencyStore creates an empty in-memory idempotency store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		now:       time.Now,
+		responses: make(map[string]memoryIdempotentResponse),
+	}
+}
+
+// Get returns the unexpired response stored under key
+func (s *MemoryIdempotencyStore) Get(ctx context.Context, key string) (*IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.responses[key]
+	if !ok || !s.now().Before(stored.expiresAt) {
+		return nil, nil
+	}
+	return stored.response, nil
+}
+
+// Save stores response under key for ttl, forgetting expired responses
+func (s *MemoryIdempotencyStore) Save(ctx context.Context, key string, response *IdempotentResponse, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for stored, entry := range s.responses {
+		if !now.Before(entry.expiresAt) {
+			delete(s.responses, stored)
+		}
+	}
+	s.responses[key] = memoryIdempotentResponse{response: response, expiresAt: now.Add(ttl)}
+	return nil
+}
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	userDomain "go-templ-template/internal/modules/user/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIdempotencyServer returns a server whose POST /users handler counts its
+// calls; the X-User header signs in as that user
+func newIdempotencyServer(store IdempotencyStore) (*echo.Echo, *int) {
+	calls := 0
+
+	e := echo.New()
+	e.Use(RequestMetadata, ErrorHandler(DefaultErrorHandlerConfig()))
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if userID := c.Request().Header.Get("X-User"); userID != "" {
+				c.Set(UserContextKey, &userDomain.User{ID: userID})
+			}
+			return next(c)
+		}
+	})
+	e.Use(IdempotencyMiddleware(store, time.Hour))
+	e.POST("/users", func(c echo.Context) error {
+		calls++
+		c.Response().Header().Set("X-Call", fmt.Sprint(calls))
+		return c.JSON(http.StatusCreated, map[string]int{"id": calls})
+	})
+	return e, &calls
+}
+
+func postIdempotent(e *echo.Echo, key, userID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAccept, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	if userID != "" {
+		req.Header.Set("X-User", userID)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyMiddleware_ReplaysRepeatedKey(t *testing.T) {
+	e, calls := newIdempotencyServer(NewMemoryIdempotencyStore())
+
+	first := postIdempotent(e, "key-1", "", `{"email":"a@example.com"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+
+	second := postIdempotent(e, "key-1", "", `{"email":"a@example.com"}`)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "1", second.Header().Get("X-Call"))
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+	assert.NotEqual(t, first.Header().Get(echo.HeaderXRequestID), second.Header().Get(echo.HeaderXRequestID))
+	assert.Equal(t, 1, *calls, "the handler should run once")
+
+	third := postIdempotent(e, "key-2", "", `{"email":"a@example.com"}`)
+	assert.Equal(t, http.StatusCreated, third.Code)
+	assert.JSONEq(t, `{"id":2}`, third.Body.String())
+	assert.Equal(t, 2, *calls, "a different key runs the handler again")
+}
+
+func TestIdempotencyMiddleware_Scopes(t *testing.T) {
+	e, calls := newIdempotencyServer(NewMemoryIdempotencyStore())
+
+	postIdempotent(e, "key-1", "user-1", `{}`)
+	postIdempotent(e, "key-1", "user-2", `{}`)
+	assert.Equal(t, 2, *calls, "keys are scoped per user")
+
+	postIdempotent(e, "", "", `{}`)
+	postIdempotent(e, "", "", `{}`)
+	assert.Equal(t, 4, *calls, "requests without a key always run")
+}
+
+func TestIdempotencyMiddleware_KeyReusedForDifferentRequest(t *testing.T) {
+	e, calls := newIdempotencyServer(NewMemoryIdempotencyStore())
+
+	postIdempotent(e, "key-1", "", `{"email":"a@example.com"}`)
+	rec := postIdempotent(e, "key-1", "", `{"email":"b@example.com"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), "IDEMPOTENCY_KEY_REUSED")
+	assert.Equal(t, 1, *calls)
+}
+
+func TestMemoryIdempotencyStore_Expiry(t *testing.T) {
+	store := NewMemoryIdempotencyStore()
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	response := &IdempotentResponse{Status: http.StatusCreated}
+	require.NoError(t, store.Save(t.Context(), "key", response, time.Minute))
+
+	stored, err := store.Get(t.Context(), "key")
+	require.NoError(t, err)
+	assert.Same(t, response, stored)
+
+	now = now.Add(time.Minute)
+	stored, err = store.Get(t.Context(), "key")
+	require.NoError(t, err)
+	assert.Nil(t, stored)
+}
+
+func TestIdempotentAfterAuth(t *testing.T) {
+	calls := 0
+	requireAuth := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID := c.Request().Header.Get("X-User")
+			if userID == "" {
+				return c.NoContent(http.StatusUnauthorized)
+			}
+			c.Set(UserContextKey, &userDomain.User{ID: userID})
+			return next(c)
+		}
+	}
+
+	e := echo.New()
+	e.POST("/users", func(c echo.Context) error {
+		calls++
+		return c.JSON(http.StatusCreated, map[string]int{"id": calls})
+	}, IdempotentAfterAuth(requireAuth, NewMemoryIdempotencyStore(), time.Hour))
+
+	assert.Equal(t, http.StatusUnauthorized, postIdempotent(e, "key-1", "", `{}`).Code)
+
+	postIdempotent(e, "key-1", "user-1", `{}`)
+	replayed := postIdempotent(e, "key-1", "user-1", `{}`)
+	assert.Equal(t, "true", replayed.Header().Get(IdempotentReplayedHeader))
+	assert.Equal(t, 1, calls, "retries by the signed-in user are replayed")
+
+	postIdempotent(e, "key-1", "user-2", `{}`)
+	assert.Equal(t, 2, calls, "keys are scoped to the user signed in by requireAuth")
+}
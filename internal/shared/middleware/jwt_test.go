@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-templ-template/internal/modules/auth/application"
+	userDomain "go-templ-template/internal/modules/user/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTokenService authenticates the tokens it has been given users for
+type fakeTokenService struct {
+	users  map[string]*userDomain.User
+	scopes map[string][]string
+	errors map[string]error
+}
+
+func (s *fakeTokenService) IssueTokens(user *userDomain.User) (*application.TokenPair, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTokenService) IssueScopedTokens(user *userDomain.User, scopes []string) (*application.TokenPair, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *fakeTokenService) Authenticate(ctx context.Context, accessToken string) (*userDomain.User, []string, error) {
+	if err, ok := s.errors[accessToken]; ok {
+		return nil, nil, err
+	}
+	if user, ok := s.users[accessToken]; ok {
+		return user, s.scopes[accessToken], nil
+	}
+	return nil, nil, application.NewTokenInvalidError()
+}
+
+func (s *fakeTokenService) Refresh(ctx context.Context, refreshToken string) (*application.TokenPair, *userDomain.User, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+func TestJWTAuthMiddleware_RequireAuth(t *testing.T) {
+	user, err := userDomain.NewUser("user-123", "test@example.com", "Password123!", "John", "Doe")
+	require.NoError(t, err)
+
+	tokens := &fakeTokenService{
+		users: map[string]*userDomain.User{"header.valid.signature": user},
+		errors: map[string]error{
+			"header.expired.signature": application.NewTokenExpiredError(),
+			"header.broken.signature":  application.NewInternalError("Failed to load token user"),
+		},
+	}
+	jwtMiddleware := NewJWTAuthMiddleware(tokens)
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantError     string
+	}{
+		{"valid token", "Bearer header.valid.signature", http.StatusOK, ""},
+		{"missing header", "", http.StatusUnauthorized, "UNAUTHORIZED"},
+		{"not a bearer token", "Basic dXNlcjpwYXNz", http.StatusUnauthorized, "UNAUTHORIZED"},
+		{"expired token", "Bearer header.expired.signature", http.StatusUnauthorized, application.ErrorCodeTokenExpired},
+		{"tampered token", "Bearer header.tampered.signature", http.StatusUnauthorized, application.ErrorCodeTokenInvalid},
+		{"user lookup failure", "Bearer header.broken.signature", http.StatusInternalServerError, "INTERNAL_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var contextUser interface{}
+			handler := jwtMiddleware.RequireAuth(func(c echo.Context) error {
+				contextUser = GetUserFromContext(c)
+				return c.String(http.StatusOK, "success")
+			})
+
+			require.NoError(t, handler(c))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantError != "" {
+				assert.Contains(t, rec.Body.String(), `"error":"`+tt.wantError+`"`)
+				assert.Nil(t, contextUser)
+				return
+			}
+			assert.Equal(t, user, contextUser)
+			assert.Nil(t, GetSessionFromContext(c))
+
+			// Tokens without scopes act with the user's full access
+			_, scoped := GetScopesFromContext(c)
+			assert.False(t, scoped)
+		})
+	}
+}
+
+func TestJWTAuthMiddleware_RequireAuthOr(t *testing.T) {
+	user, err := userDomain.NewUser("user-123", "test@example.com", "Password123!", "John", "Doe")
+	require.NoError(t, err)
+
+	jwtMiddleware := NewJWTAuthMiddleware(&fakeTokenService{
+		users: map[string]*userDomain.User{"header.valid.signature": user},
+	})
+
+	var fallbackCalls int
+	fallback := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			fallbackCalls++
+			return c.NoContent(http.StatusUnauthorized)
+		}
+	}
+	handler := jwtMiddleware.RequireAuthOr(fallback)(func(c echo.Context) error {
+		return c.String(http.StatusOK, "success")
+	})
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantFallback  int
+	}{
+		{"JWT is checked by the token middleware", "Bearer header.valid.signature", http.StatusOK, 0},
+		{"session ID falls back", "Bearer 3f2a9c0d1e", http.StatusUnauthorized, 1},
+		{"no header falls back", "", http.StatusUnauthorized, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fallbackCalls = 0
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, handler(echo.New().NewContext(req, rec)))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantFallback, fallbackCalls)
+		})
+	}
+}
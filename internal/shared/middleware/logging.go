@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"sync"
+
+	authDomain "go-templ-template/internal/modules/auth/domain"
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/errors"
+	"go-templ-template/internal/shared/events"
+
+	"github.com/labstack/echo/v4"
+)
+
+// LoggerContextKey is the key used to store the request logger in context
+const LoggerContextKey = "logger"
+
+var (
+	defaultLoggerOnce sync.Once
+	defaultLogger     *errors.StructuredLogger
+)
+
+// Logging middleware makes logger available to handlers through
+// LoggerFromEchoContext
+func Logging(logger *errors.StructuredLogger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(LoggerContextKey, logger)
+			return next(c)
+		}
+	}
+}
+
+// LoggerFromEchoContext returns a logger whose entries carry the request's
+// method, path, request and trace IDs, and the signed-in user and session,
+// so handlers log with consistent fields. It uses the logger set by the
+// Logging middleware, or a default logger if there is none.
+func LoggerFromEchoContext(c echo.Context) errors.Logger {
+	logger, ok := c.Get(LoggerContextKey).(*errors.StructuredLogger)
+	if !ok || logger == nil {
+		logger = getDefaultLogger()
+	}
+	return requestLogger(c, logger)
+}
+
+// requestLogger returns logger with the request fields of c
+func requestLogger(c echo.Context, logger *errors.StructuredLogger) errors.Logger {
+	req := c.Request()
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"path":   req.URL.Path,
+	}
+	if route := c.Path(); route != "" {
+		fields["route"] = route
+	}
+
+	if metadata, ok := events.RequestMetadataFromContext(req.Context()); ok {
+		if metadata.RequestID != "" {
+			fields["request_id"] = metadata.RequestID
+		}
+		if metadata.TraceID != "" {
+			fields["trace_id"] = metadata.TraceID
+		}
+	}
+	if user, ok := GetUserFromContext(c).(*userDomain.User); ok && user != nil {
+		fields["user_id"] = user.ID
+	}
+	if session, ok := GetSessionFromContext(c).(*authDomain.Session); ok && session != nil {
+		fields["session_id"] = session.ID
+	}
+
+	return logger.WithContext(req.Context()).WithFields(fields)
+}
+
+// getDefaultLogger returns the logger used when no Logging middleware ran
+func getDefaultLogger() *errors.StructuredLogger {
+	defaultLoggerOnce.Do(func() {
+		// The default configuration is always valid
+		defaultLogger, _ = errors.NewStructuredLogger(errors.DefaultLoggingConfig())
+	})
+	return defaultLogger
+}
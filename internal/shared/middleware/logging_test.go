@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	authDomain "go-templ-template/internal/modules/auth/domain"
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFileLogger returns a JSON logger writing to a file, and a function
+// reading the entries written so far
+func newFileLogger(t *testing.T) (*errors.StructuredLogger, func() []map[string]interface{}) {
+	t.Helper()
+
+	config := errors.DefaultLoggingConfig()
+	config.Output = filepath.Join(t.TempDir(), "app.log")
+	logger, err := errors.NewStructuredLogger(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { logger.Close() })
+
+	return logger, func() []map[string]interface{} {
+		data, err := os.ReadFile(config.Output)
+		require.NoError(t, err)
+
+		var entries []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+}
+
+func TestLoggerFromEchoContext_AddsRequestFields(t *testing.T) {
+	logger, readEntries := newFileLogger(t)
+
+	e := echo.New()
+	e.Use(RequestMetadata, Logging(logger))
+	e.GET("/users/:id", func(c echo.Context) error {
+		c.Set(UserContextKey, &userDomain.User{ID: "user-123"})
+		c.Set(SessionContextKey, &authDomain.Session{ID: "session-456"})
+
+		LoggerFromEchoContext(c).Info("loading user")
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/user-123", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-789")
+	req.Header.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	entries := readEntries()
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "loading user", entry["message"])
+	assert.Equal(t, "user-123", entry["user_id"])
+	assert.Equal(t, "session-456", entry["session_id"])
+	assert.Equal(t, http.MethodGet, entry["method"])
+	assert.Equal(t, "/users/user-123", entry["path"])
+	assert.Equal(t, "/users/:id", entry["route"])
+	assert.Equal(t, "req-789", entry["request_id"])
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entry["trace_id"])
+	assert.Equal(t, "go-templ-template", entry["service"])
+}
+
+func TestLoggerFromEchoContext_Anonymous(t *testing.T) {
+	logger, readEntries := newFileLogger(t)
+
+	e := echo.New()
+	e.Use(Logging(logger))
+	e.GET("/", func(c echo.Context) error {
+		LoggerFromEchoContext(c).Warn("anonymous request")
+		return c.NoContent(http.StatusOK)
+	})
+
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	entries := readEntries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "anonymous request", entries[0]["message"])
+	assert.NotContains(t, entries[0], "user_id")
+	assert.NotContains(t, entries[0], "session_id")
+}
+
+func TestLoggerFromEchoContext_WithoutMiddleware(t *testing.T) {
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	assert.NotNil(t, LoggerFromEchoContext(c))
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"go-templ-template/internal/modules/auth/application"
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RateLimitKeyFunc extracts the key a request is rate limited by
+type RateLimitKeyFunc func(c echo.Context) string
+
+// ClientIPKey rate limits requests by client IP address
+func ClientIPKey(c echo.Context) string {
+	return c.RealIP()
+}
+
+// RateLimitMiddleware consults limiter for every request and rejects blocked
+// requests with a RATE_LIMIT_EXCEEDED error (429). A nil keyFunc limits by
+// client IP. When the limiter implements application.QuotaReporter the
+// X-RateLimit-* headers are set on every response and Retry-After on
+// rejected ones.
+func RateLimitMiddleware(limiter application.RateLimiter, keyFunc RateLimitKeyFunc) echo.MiddlewareFunc {
+	if keyFunc == nil {
+		keyFunc = ClientIPKey
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			key := keyFunc(c)
+
+			allowed, err := limiter.Allow(ctx, key)
+			if err != nil && !application.IsRateLimitError(err) {
+				return errors.WrapError(err, errors.ErrorTypeInternal, "RATE_LIMITER_ERROR",
+					"Failed to check rate limit")
+			}
+
+			var quota *application.RateLimitQuota
+			if reporter, ok := limiter.(application.QuotaReporter); ok {
+				if q, err := reporter.Quota(ctx, key); err == nil {
+					quota = &q
+					setRateLimitHeaders(c, q)
+				}
+			}
+
+			if allowed {
+				return next(c)
+			}
+
+			if quota == nil {
+				return errors.NewAppError(errors.ErrorTypeRateLimit, "RATE_LIMIT_EXCEEDED",
+					"Rate limit exceeded", http.StatusTooManyRequests)
+			}
+
+			retryAfter := int(math.Ceil(quota.RetryAfter.Seconds()))
+			c.Response().Header().Set("Retry-After", strconv.Itoa(max(retryAfter, 1)))
+
+			return errors.NewRateLimitError(quota.Limit, quota.Window.String())
+		}
+	}
+}
+
+// setRateLimitHeaders writes the X-RateLimit-* headers for quota
+func setRateLimitHeaders(c echo.Context, quota application.RateLimitQuota) {
+	header := c.Response().Header()
+	header.Set("X-RateLimit-Limit", strconv.Itoa(quota.Limit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(quota.Remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(quota.ResetAt.Unix(), 10))
+}
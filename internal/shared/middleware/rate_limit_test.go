@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/modules/auth/application"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// plainRateLimiter is a RateLimiter that does not report quotas
+type plainRateLimiter struct {
+	allowed bool
+}
+
+func (m *plainRateLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	if !m.allowed {
+		return false, application.NewRateLimitExceededError("blocked")
+	}
+	return true, nil
+}
+
+func (m *plainRateLimiter) Reset(ctx context.Context, key string) error {
+	return nil
+}
+
+func (m *plainRateLimiter) GetAttempts(ctx context.Context, key string) (int, error) {
+	return 0, nil
+}
+
+func newRateLimitTestServer(limiter application.RateLimiter, keyFunc RateLimitKeyFunc) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = CustomErrorHandler(ErrorHandlerConfig{JSONAPIErrors: true})
+	e.Use(RateLimitMiddleware(limiter, keyFunc))
+
+	e.GET("/api/ping", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	return e
+}
+
+func doRateLimitRequest(e *echo.Echo, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/ping", nil)
+	req.Header.Set("Accept", echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderXRealIP, ip)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitMiddleware_AllowsAndBlocks(t *testing.T) {
+	limiter := application.NewTokenBucketRateLimiter(application.TokenBucketConfig{
+		Capacity:       2,
+		RefillInterval: time.Minute,
+	})
+	e := newRateLimitTestServer(limiter, nil)
+
+	for i := 1; i <= 2; i++ {
+		rec := doRateLimitRequest(e, "10.0.0.1")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, strconv.Itoa(2-i), rec.Header().Get("X-RateLimit-Remaining"))
+		assert.NotEmpty(t, rec.Header().Get("X-RateLimit-Reset"))
+		assert.Empty(t, rec.Header().Get("Retry-After"))
+	}
+
+	rec := doRateLimitRequest(e, "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("X-RateLimit-Remaining"))
+
+	retryAfter, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	require.NoError(t, err)
+	assert.Equal(t, 60, retryAfter)
+
+	reset, err := strconv.ParseInt(rec.Header().Get("X-RateLimit-Reset"), 10, 64)
+	require.NoError(t, err)
+	assert.Greater(t, reset, time.Now().Unix())
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	errorData := response["error"].(map[string]interface{})
+	assert.Equal(t, "RATE_LIMIT_EXCEEDED", errorData["code"])
+	assert.Equal(t, "rate_limit", errorData["type"])
+
+	// Other clients are limited independently
+	rec = doRateLimitRequest(e, "10.0.0.2")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRateLimitMiddleware_CustomKeyFunc(t *testing.T) {
+	limiter := application.NewTokenBucketRateLimiter(application.TokenBucketConfig{
+		Capacity:       1,
+		RefillInterval: time.Minute,
+	})
+	e := newRateLimitTestServer(limiter, func(c echo.Context) string {
+		return "global"
+	})
+
+	assert.Equal(t, http.StatusOK, doRateLimitRequest(e, "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, doRateLimitRequest(e, "10.0.0.2").Code)
+}
+
+func TestRateLimitMiddleware_WithoutQuota(t *testing.T) {
+	tests := []struct {
+		allowed        bool
+		expectedStatus int
+	}{
+		{allowed: true, expectedStatus: http.StatusOK},
+		{allowed: false, expectedStatus: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("allowed=%v", tt.allowed), func(t *testing.T) {
+			e := newRateLimitTestServer(&plainRateLimiter{allowed: tt.allowed}, nil)
+
+			rec := doRateLimitRequest(e, "10.0.0.1")
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Empty(t, rec.Header().Get("X-RateLimit-Limit"))
+		})
+	}
+}
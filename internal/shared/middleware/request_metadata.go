@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"go-templ-template/internal/shared/events"
+	"go-templ-template/internal/shared/tracing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// TraceParentHeader is the W3C Trace Context header carrying the trace ID
+const TraceParentHeader = "traceparent"
+
+// RequestMetadata middleware that identifies each request for the events it
+// publishes. The request ID is taken from the X-Request-ID request header,
+// or generated, and echoed in the response; the trace ID is taken from the
+// traceparent header. Auth middleware adds the signed-in user.
+func RequestMetadata(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+
+		requestID := req.Header.Get(echo.HeaderXRequestID)
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+		c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+		ctx := events.WithRequestMetadata(req.Context(), events.RequestMetadata{
+			RequestID: requestID,
+			TraceID:   tracing.ParseTraceParent(req.Header.Get(TraceParentHeader)).TraceID,
+		})
+		c.SetRequest(req.WithContext(ctx))
+
+		return next(c)
+	}
+}
+
+// setRequestUser records the signed-in user in the request metadata of c's
+// request context
+func setRequestUser(c echo.Context, userID string) {
+	req := c.Request()
+	c.SetRequest(req.WithContext(events.WithRequestUserID(req.Context(), userID)))
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-templ-template/internal/modules/auth/application"
+	"go-templ-template/internal/shared/events"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// captureRequestMetadata returns a handler recording the request metadata
+// carried by the request context
+func captureRequestMetadata(captured *events.RequestMetadata) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		*captured, _ = events.RequestMetadataFromContext(c.Request().Context())
+		return c.NoContent(http.StatusOK)
+	}
+}
+
+func TestRequestMetadata_UsesRequestHeaders(t *testing.T) {
+	e := setupEcho()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-123")
+	req.Header.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+
+	var captured events.RequestMetadata
+	require.NoError(t, RequestMetadata(captureRequestMetadata(&captured))(e.NewContext(req, rec)))
+
+	assert.Equal(t, "req-123", captured.RequestID)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", captured.TraceID)
+	assert.Equal(t, "req-123", rec.Header().Get(echo.HeaderXRequestID))
+}
+
+func TestRequestMetadata_GeneratesRequestID(t *testing.T) {
+	e := setupEcho()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(TraceParentHeader, "not-a-traceparent")
+	rec := httptest.NewRecorder()
+
+	var captured events.RequestMetadata
+	require.NoError(t, RequestMetadata(captureRequestMetadata(&captured))(e.NewContext(req, rec)))
+
+	assert.NotEmpty(t, captured.RequestID)
+	assert.Empty(t, captured.TraceID)
+	assert.Equal(t, captured.RequestID, rec.Header().Get(echo.HeaderXRequestID))
+}
+
+func TestRequestMetadata_RequireAuthAddsUser(t *testing.T) {
+	mockService := new(mockAuthService)
+	mockService.On("ValidateSession", mock.Anything, mock.Anything).Return(&application.SessionValidationResult{
+		User:    createTestUser(),
+		Session: createTestSession(),
+		Valid:   true,
+	}, nil)
+	mockService.On("TouchSession", mock.Anything, mock.Anything).Return(nil)
+
+	e := setupEcho()
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-123")
+	req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "valid-session-id"})
+	rec := httptest.NewRecorder()
+
+	var captured events.RequestMetadata
+	handler := RequestMetadata(NewAuthMiddleware(mockService).RequireAuth(captureRequestMetadata(&captured)))
+	require.NoError(t, handler(e.NewContext(req, rec)))
+
+	assert.Equal(t, "req-123", captured.RequestID)
+	assert.Equal(t, "user-123", captured.UserID)
+}
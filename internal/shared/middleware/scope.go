@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ScopesContextKey is the key used to store the scopes granted to the
+// credential a request was authenticated with, e.g. an API key or a scoped
+// token, in context
+const ScopesContextKey = "scopes"
+
+// ScopeConfig holds configuration for scope enforcement
+type ScopeConfig struct {
+	// AllowUnscoped lets requests authenticated without scopes, e.g. with a
+	// session or an unscoped token, act with the user's full access. When
+	// false they are refused like a credential missing the scopes. It never
+	// applies to requests authenticated with an API key, which only act
+	// with the scopes they were granted.
+	AllowUnscoped bool
+}
+
+// DefaultScopeConfig returns a default scope configuration, in which only
+// scoped credentials and API keys are restricted
+func DefaultScopeConfig() ScopeConfig {
+	return ScopeConfig{
+		AllowUnscoped: true,
+	}
+}
+
+// ScopeMiddleware restricts routes to credentials granted the scopes they
+// need, regardless of the user's own role
+type ScopeMiddleware struct {
+	config ScopeConfig
+}
+
+// NewScopeMiddleware creates a new scope middleware instance
+func NewScopeMiddleware(config ScopeConfig) *ScopeMiddleware {
+	return &ScopeMiddleware{
+		config: config,
+	}
+}
+
+// RequireScope middleware that requires the request's credential to have
+// been granted all of scopes, and refuses it with 403 otherwise. It runs
+// after the middleware authenticating the request; unscoped requests are
+// handled as configured by AllowUnscoped, except for API keys, which are
+// refused.
+func (m *ScopeMiddleware) RequireScope(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			granted, scoped := GetScopesFromContext(c)
+			if !scoped {
+				if m.config.AllowUnscoped && GetAPIKeyFromContext(c) == nil {
+					return next(c)
+				}
+				return insufficientScope(c, scopes)
+			}
+
+			for _, scope := range scopes {
+				if !hasScope(granted, scope) {
+					return insufficientScope(c, scopes)
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireScope middleware that requires requests authenticated with a
+// scoped credential to have been granted all of scopes, using the default
+// scope configuration
+func RequireScope(scopes ...string) echo.MiddlewareFunc {
+	return NewScopeMiddleware(DefaultScopeConfig()).RequireScope(scopes...)
+}
+
+// GetScopesFromContext returns the scopes granted to the credential a
+// request was authenticated with, and whether it was a scoped credential
+func GetScopesFromContext(c echo.Context) ([]string, bool) {
+	scopes, ok := c.Get(ScopesContextKey).([]string)
+	return scopes, ok
+}
+
+// setScopes stores the scopes granted to the request's credential. A
+// credential granted no scopes is still scoped, so it passes no checks.
+func setScopes(c echo.Context, scopes []string) {
+	if scopes == nil {
+		scopes = []string{}
+	}
+	c.Set(ScopesContextKey, scopes)
+}
+
+// hasScope reports whether scope is among granted
+func hasScope(granted []string, scope string) bool {
+	for _, g := range granted {
+		if g == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// insufficientScope refuses a request missing any of scopes
+func insufficientScope(c echo.Context, scopes []string) error {
+	return c.JSON(http.StatusForbidden, map[string]interface{}{
+		"error":   "INSUFFICIENT_SCOPE",
+		"message": "Missing required scope: " + strings.Join(scopes, " "),
+	})
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authDomain "go-templ-template/internal/modules/auth/domain"
+	userDomain "go-templ-template/internal/modules/user/domain"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopeMiddleware_RequireScope(t *testing.T) {
+	service, readOnly, _ := newFakeAPIKeyService(t)
+	unscopedKey, err := authDomain.NewAPIKey(service.user.ID, "No scopes", nil, nil)
+	require.NoError(t, err)
+	service.keys[unscopedKey.Key] = unscopedKey
+
+	tokens := &fakeTokenService{
+		users:  map[string]*userDomain.User{"header.scoped.signature": service.user, "header.full.signature": service.user},
+		scopes: map[string][]string{"header.scoped.signature": {"users:read", "users:write"}},
+	}
+
+	// Requests are authenticated with an API key, a token or, for any other
+	// bearer value, a session as the session middleware would
+	sessionAuth := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(UserContextKey, service.user)
+			return next(c)
+		}
+	}
+	authenticate := NewAPIKeyMiddleware(service).RequireAuthOr(NewJWTAuthMiddleware(tokens).RequireAuthOr(sessionAuth))
+
+	tests := []struct {
+		name          string
+		authorization string
+		scopes        []string
+		allowUnscoped bool
+		wantStatus    int
+	}{
+		{"key with scope", readOnly.Key, []string{"users:read"}, true, http.StatusOK},
+		{"key without scope", readOnly.Key, []string{"users:write"}, true, http.StatusForbidden},
+		{"key with only some scopes", readOnly.Key, []string{"users:read", "users:write"}, true, http.StatusForbidden},
+		{"key granted no scopes", unscopedKey.Key, []string{"users:read"}, true, http.StatusForbidden},
+		{"scoped token with scopes", "header.scoped.signature", []string{"users:read", "users:write"}, true, http.StatusOK},
+		{"scoped token without scope", "header.scoped.signature", []string{"admin"}, true, http.StatusForbidden},
+		{"unscoped token allowed by default", "header.full.signature", []string{"admin"}, true, http.StatusOK},
+		{"unscoped token refused", "header.full.signature", []string{"admin"}, false, http.StatusForbidden},
+		{"session allowed by default", "session-123", []string{"users:write"}, true, http.StatusOK},
+		{"session refused", "session-123", []string{"users:write"}, false, http.StatusForbidden},
+		{"scoped key unaffected by default", readOnly.Key, []string{"users:read"}, false, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requireScope := NewScopeMiddleware(ScopeConfig{AllowUnscoped: tt.allowUnscoped}).RequireScope(tt.scopes...)
+			handler := authenticate(requireScope(func(c echo.Context) error {
+				return c.String(http.StatusOK, "success")
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.authorization)
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, handler(echo.New().NewContext(req, rec)))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusForbidden {
+				assert.Contains(t, rec.Body.String(), `"error":"INSUFFICIENT_SCOPE"`)
+			}
+		})
+	}
+}
+
+func TestRequireScope_AllowsUnscopedByDefault(t *testing.T) {
+	assert.True(t, DefaultScopeConfig().AllowUnscoped)
+
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	c.Set(UserContextKey, &userDomain.User{ID: "user-123"})
+
+	handler := RequireScope("users:write")(func(c echo.Context) error {
+		return c.String(http.StatusOK, "success")
+	})
+
+	require.NoError(t, handler(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequireScope_RefusesUnscopedAPIKeys(t *testing.T) {
+	// A key stored in context without its scopes is still refused, though
+	// unscoped sessions are allowed by default
+	rec := httptest.NewRecorder()
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	c.Set(UserContextKey, &userDomain.User{ID: "user-123"})
+	c.Set(APIKeyContextKey, &authDomain.APIKey{ID: "key-123", UserID: "user-123"})
+
+	handler := RequireScope("users:read")(func(c echo.Context) error {
+		return c.String(http.StatusOK, "success")
+	})
+
+	require.NoError(t, handler(c))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSecurityHeadersServer(config SecurityHeadersConfig) *echo.Echo {
+	e := echo.New()
+	e.Use(SecurityHeadersMiddleware(config))
+	e.GET("/", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+	e.GET("/embed/:id", func(c echo.Context) error {
+		return c.String(http.StatusOK, "embedded")
+	})
+	e.GET("/custom", func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderXFrameOptions, "SAMEORIGIN")
+		return c.String(http.StatusOK, "custom")
+	})
+	return e
+}
+
+func TestSecurityHeadersMiddleware_Defaults(t *testing.T) {
+	e := newSecurityHeadersServer(DefaultSecurityHeadersConfig())
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+	assert.Equal(t, "DENY", rec.Header().Get(echo.HeaderXFrameOptions))
+	assert.Equal(t, "strict-origin-when-cross-origin", rec.Header().Get(echo.HeaderReferrerPolicy))
+	assert.Equal(t, DefaultContentSecurityPolicy, rec.Header().Get(echo.HeaderContentSecurityPolicy))
+	assert.Empty(t, rec.Header().Get(echo.HeaderStrictTransportSecurity), "HSTS is only sent over HTTPS")
+}
+
+func TestSecurityHeadersMiddleware_HSTSOnlyOverHTTPS(t *testing.T) {
+	e := newSecurityHeadersServer(DefaultSecurityHeadersConfig())
+
+	tests := []struct {
+		name  string
+		setup func(req *http.Request)
+		want  string
+	}{
+		{"plain HTTP", func(req *http.Request) {}, ""},
+		{"forwarded HTTPS", func(req *http.Request) { req.Header.Set(echo.HeaderXForwardedProto, "https") }, "max-age=31536000; includeSubDomains"},
+		{"forwarded HTTP", func(req *http.Request) { req.Header.Set(echo.HeaderXForwardedProto, "http") }, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			tt.setup(req)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.want, rec.Header().Get(echo.HeaderStrictTransportSecurity))
+		})
+	}
+
+	t.Run("TLS connection", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "https://example.com/", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, "max-age=31536000; includeSubDomains", rec.Header().Get(echo.HeaderStrictTransportSecurity))
+	})
+}
+
+func TestSecurityHeadersMiddleware_ConfiguredPolicy(t *testing.T) {
+	config := DefaultSecurityHeadersConfig()
+	config.ContentSecurityPolicy = "default-src 'none'"
+	config.ReferrerPolicy = ""
+	embed := config
+	embed.FrameOptions = ""
+	embed.ContentSecurityPolicy = "frame-ancestors https://partner.example.com"
+	config.Routes = map[string]SecurityHeadersConfig{"/embed/:id": embed}
+	e := newSecurityHeadersServer(config)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "default-src 'none'", rec.Header().Get(echo.HeaderContentSecurityPolicy))
+	assert.Empty(t, rec.Header().Get(echo.HeaderReferrerPolicy), "empty values leave the header out")
+
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/embed/42", nil))
+	assert.Equal(t, "frame-ancestors https://partner.example.com", rec.Header().Get(echo.HeaderContentSecurityPolicy))
+	assert.Empty(t, rec.Header().Get(echo.HeaderXFrameOptions))
+	assert.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+}
+
+func TestSecurityHeadersMiddleware_KeepsHandlerHeaders(t *testing.T) {
+	e := newSecurityHeadersServer(DefaultSecurityHeadersConfig())
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/custom", nil))
+
+	assert.Equal(t, "SAMEORIGIN", rec.Header().Get(echo.HeaderXFrameOptions))
+	assert.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+}
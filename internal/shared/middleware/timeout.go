@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	stderrors "errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TimeoutMiddleware gives each request a context that expires after
+// timeout, so handlers and the queries they run with the request context
+// abort once it passes. When the deadline passes before a response is
+// written the request fails with an OPERATION_TIMEOUT error (408). Handlers
+// that ignore the context still run to completion. A non-positive timeout
+// disables the limit. WebSocket upgrades and event streams, which last as
+// long as the client stays connected, are not limited.
+func TimeoutMiddleware(timeout time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if timeout <= 0 || isStreamingRequest(c.Request()) {
+				return next(c)
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), timeout)
+			defer cancel()
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			err := next(c)
+			if stderrors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Response().Committed {
+				operation := c.Request().Method + " " + c.Path()
+				return errors.NewTimeoutError(operation, timeout)
+			}
+			return err
+		}
+	}
+}
+
+// isStreamingRequest reports whether req asks to upgrade to a WebSocket
+// connection or for a Server-Sent Events stream
+func isStreamingRequest(req *http.Request) bool {
+	return strings.EqualFold(req.Header.Get(echo.HeaderUpgrade), "websocket") ||
+		strings.Contains(req.Header.Get(echo.HeaderAccept), "text/event-stream")
+}
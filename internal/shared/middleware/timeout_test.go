@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(ErrorHandler(DefaultErrorHandlerConfig()), TimeoutMiddleware(20*time.Millisecond))
+	e.GET("/slow", func(c echo.Context) error {
+		select {
+		case <-c.Request().Context().Done():
+			return c.Request().Context().Err()
+		case <-time.After(time.Second):
+			return c.String(http.StatusOK, "too late")
+		}
+	})
+	e.GET("/fast", func(c echo.Context) error {
+		return c.String(http.StatusOK, "done")
+	})
+
+	t.Run("handler past the timeout", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+		req.Header.Set(echo.HeaderAccept, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		start := time.Now()
+		e.ServeHTTP(rec, req)
+
+		assert.Less(t, time.Since(start), time.Second, "the handler should abort at the deadline")
+		require.Equal(t, http.StatusRequestTimeout, rec.Code)
+		assert.Contains(t, rec.Body.String(), "OPERATION_TIMEOUT")
+		assert.Contains(t, rec.Body.String(), string(errors.ErrorTypeTimeout))
+	})
+
+	t.Run("handler within the timeout", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fast", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "done", rec.Body.String())
+	})
+}
+
+func TestTimeoutMiddleware_Disabled(t *testing.T) {
+	e := echo.New()
+	e.Use(TimeoutMiddleware(0))
+	e.GET("/", func(c echo.Context) error {
+		_, hasDeadline := c.Request().Context().Deadline()
+		assert.False(t, hasDeadline)
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestTimeoutMiddleware_StreamingRequests(t *testing.T) {
+	e := echo.New()
+	e.Use(TimeoutMiddleware(time.Second))
+	e.GET("/stream", func(c echo.Context) error {
+		_, hasDeadline := c.Request().Context().Deadline()
+		assert.False(t, hasDeadline, "streams outlive the request timeout")
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	tests := map[string]map[string]string{
+		"WebSocket upgrade": {echo.HeaderConnection: "Upgrade", echo.HeaderUpgrade: "websocket"},
+		"event stream":      {echo.HeaderAccept: "text/event-stream"},
+	}
+	for name, headers := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+			for header, value := range headers {
+				req.Header.Set(header, value)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+		})
+	}
+}
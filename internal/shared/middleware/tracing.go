@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"go-templ-template/internal/shared/tracing"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Tracing middleware that starts a span for each request, continuing the
+// trace named by the traceparent header. Handlers, the events they publish
+// and the queries they run start child spans from the request context.
+func Tracing(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+
+		ctx := tracing.ContextWithRemoteParent(req.Context(), tracing.ParseTraceParent(req.Header.Get(TraceParentHeader)))
+		ctx, span := tracing.Start(ctx, req.Method+" "+c.Path())
+		defer span.End()
+
+		span.SetAttribute("http.method", req.Method)
+		span.SetAttribute("http.route", c.Path())
+		span.SetAttribute("http.target", req.URL.Path)
+		c.SetRequest(req.WithContext(ctx))
+
+		err := next(c)
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.SetAttribute("http.status_code", c.Response().Status)
+		return err
+	}
+}
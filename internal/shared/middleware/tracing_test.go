@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-templ-template/internal/shared/events"
+	"go-templ-template/internal/shared/tracing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useSpanRecorder(t *testing.T) *tracing.Recorder {
+	t.Helper()
+
+	recorder := tracing.NewRecorder()
+	tracing.SetTracer(recorder)
+	t.Cleanup(func() { tracing.SetTracer(nil) })
+	return recorder
+}
+
+// recordingHandler records the events it handles
+type recordingHandler struct {
+	handled []events.DomainEvent
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, event events.DomainEvent) error {
+	h.handled = append(h.handled, event)
+	return nil
+}
+
+func (h *recordingHandler) EventType() string   { return "test.traced" }
+func (h *recordingHandler) HandlerName() string { return "recording-handler" }
+
+func TestTracing_CreatesRequestSpan(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	e := echo.New()
+	e.Use(Tracing)
+	e.GET("/users/:id", func(c echo.Context) error {
+		_, span := tracing.Start(c.Request().Context(), "handler")
+		span.End()
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/123", nil)
+	req.Header.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	request := recorder.Find("GET /users/:id")
+	require.NotNil(t, request)
+	assert.Equal(t, tracing.SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"}, request.Parent)
+	assert.Equal(t, "/users/:id", request.Attributes["http.route"])
+	assert.Equal(t, http.StatusNoContent, request.Attributes["http.status_code"])
+
+	handler := recorder.Find("handler")
+	require.NotNil(t, handler)
+	assert.True(t, handler.IsChildOf(request))
+}
+
+func TestTracing_PublishedEventsJoinRequestTrace(t *testing.T) {
+	recorder := useSpanRecorder(t)
+	bus := events.NewInMemoryEventBus()
+	require.NoError(t, bus.Start(context.Background()))
+	eventHandler := &recordingHandler{}
+	require.NoError(t, bus.Subscribe("test.traced", eventHandler))
+
+	e := echo.New()
+	e.Use(RequestMetadata, Tracing)
+	e.POST("/things", func(c echo.Context) error {
+		event := events.NewBaseEvent("test.traced", "thing-1", "Thing", nil)
+		if err := bus.Publish(c.Request().Context(), event); err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusCreated)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/things", nil))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	request := recorder.Find("POST /things")
+	publish := recorder.Find("events.publish test.traced")
+	handle := recorder.Find("events.handle recording-handler")
+	require.NotNil(t, request)
+	require.NotNil(t, publish)
+	require.NotNil(t, handle)
+	assert.True(t, publish.IsChildOf(request))
+	assert.True(t, handle.IsChildOf(publish))
+
+	require.Len(t, eventHandler.handled, 1)
+	metadata := eventHandler.handled[0].Metadata()
+	assert.Equal(t, request.Context.TraceID, metadata.TraceID)
+	assert.Equal(t, rec.Header().Get(echo.HeaderXRequestID), metadata.CorrelationID)
+}
+
+func TestTracing_NoTracerConfigured(t *testing.T) {
+	e := echo.New()
+	e.Use(Tracing)
+	e.GET("/", func(c echo.Context) error {
+		assert.False(t, tracing.SpanFromContext(c.Request().Context()).SpanContext().IsValid())
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -29,6 +29,20 @@ type Module interface {
 	Shutdown(ctx context.Context) error
 }
 
+// ModuleDependencies is implemented by modules that need other modules to
+// be initialized first
+type ModuleDependencies interface {
+	// DependsOn returns the names of the modules this module depends on
+	DependsOn() []string
+}
+
+// ModuleRoutePrefix is implemented by modules whose routes are mounted under
+// their own path below the API prefix, e.g. /api/v1/billing
+type ModuleRoutePrefix interface {
+	// RoutePrefix returns the path the module's routes are mounted under
+	RoutePrefix() string
+}
+
 // ModuleContainer provides dependency injection for modules
 type ModuleContainer struct {
 	// Core dependencies
@@ -0,0 +1,184 @@
+// Package notifications pushes domain events to the signed-in users they
+// concern over WebSocket connections, for live activity feeds.
+package notifications
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-templ-template/internal/shared/events"
+)
+
+// Notification is an event pushed to the connections of a user it
+// concerns. Event data is left out, as payloads can carry secrets such as
+// password reset tokens.
+type Notification struct {
+	ID            string    `json:"id"`
+	Type          string    `json:"type"`
+	AggregateID   string    `json:"aggregate_id"`
+	AggregateType string    `json:"aggregate_type"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// Hub fans the events it handles out to the subscriptions of the users
+// they concern: the user who triggered an event and, for user events, the
+// user it is about. Subscribe it to every event type with
+// bus.Subscribe(hub.EventType(), hub). Each server instance delivers the
+// events its bus consumes, so with a shared RabbitMQ queue a user's
+// connections on other instances may miss some.
+type Hub struct {
+	mu            sync.RWMutex
+	subscriptions map[string]map[*Subscription]struct{}
+	closed        bool
+}
+
+var _ events.EventHandler = (*Hub)(nil)
+
+// NewHub creates a hub without subscriptions
+func NewHub() *Hub {
+	return &Hub{subscriptions: make(map[string]map[*Subscription]struct{})}
+}
+
+// Subscribe returns a subscription receiving the notifications for userID,
+// buffering up to buffer of them for a slow reader. The subscription is
+// already closed if the hub is.
+func (h *Hub) Subscribe(userID string, buffer int) *Subscription {
+	subscription := &Subscription{
+		hub:           h,
+		userID:        userID,
+		notifications: make(chan Notification, max(buffer, 1)),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		subscription.closed = true
+		close(subscription.notifications)
+		return subscription
+	}
+	if h.subscriptions[userID] == nil {
+		h.subscriptions[userID] = make(map[*Subscription]struct{})
+	}
+	h.subscriptions[userID][subscription] = struct{}{}
+	return subscription
+}
+
+// Subscribers returns the number of open subscriptions for userID
+func (h *Hub) Subscribers(userID string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.subscriptions[userID])
+}
+
+// Close closes every subscription, ending their connections, and makes
+// later subscriptions start closed
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for userID, subscriptions := range h.subscriptions {
+		for subscription := range subscriptions {
+			subscription.closed = true
+			close(subscription.notifications)
+		}
+		delete(h.subscriptions, userID)
+	}
+}
+
+// Handle delivers event to the subscriptions of the users it concerns. A
+// subscription whose buffer is full misses the notification rather than
+// holding up the bus.
+func (h *Hub) Handle(ctx context.Context, event events.DomainEvent) error {
+	notification := Notification{
+		ID:            event.EventID(),
+		Type:          event.EventType(),
+		AggregateID:   event.AggregateID(),
+		AggregateType: event.AggregateType(),
+		OccurredAt:    event.OccurredAt(),
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, userID := range concernedUsers(event) {
+		for subscription := range h.subscriptions[userID] {
+			select {
+			case subscription.notifications <- notification:
+			default:
+				subscription.dropped.Add(1)
+			}
+		}
+	}
+	return nil
+}
+
+// EventType returns the topic pattern matching every event type
+func (h *Hub) EventType() string {
+	return "#"
+}
+
+// HandlerName returns the hub's handler name
+func (h *Hub) HandlerName() string {
+	return "notifications.hub"
+}
+
+// concernedUsers returns the IDs of the users event is pushed to
+func concernedUsers(event events.DomainEvent) []string {
+	var userIDs []string
+	if userID := event.Metadata().UserID; userID != "" {
+		userIDs = append(userIDs, userID)
+	}
+	if strings.EqualFold(event.AggregateType(), "user") && event.AggregateID() != "" &&
+		event.AggregateID() != event.Metadata().UserID {
+		userIDs = append(userIDs, event.AggregateID())
+	}
+	return userIDs
+}
+
+// Subscription receives the notifications for one user, usually for one
+// connection
+type Subscription struct {
+	hub           *Hub
+	userID        string
+	notifications chan Notification
+	dropped       atomic.Int64
+
+	// closed is guarded by the hub's lock
+	closed bool
+}
+
+// Notifications returns the channel notifications are delivered on; it is
+// closed when the subscription is
+func (s *Subscription) Notifications() <-chan Notification {
+	return s.notifications
+}
+
+// Dropped returns how many notifications were missed because the buffer
+// was full
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close stops delivery to the subscription. It is safe to call more than
+// once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.notifications)
+
+	delete(s.hub.subscriptions[s.userID], s)
+	if len(s.hub.subscriptions[s.userID]) == 0 {
+		delete(s.hub.subscriptions, s.userID)
+	}
+}
@@ -0,0 +1,77 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+
+	"go-templ-template/internal/shared/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userEvent returns an event about subject triggered by actor
+func userEvent(eventType, subject, actor string) *events.BaseEvent {
+	event := events.NewBaseEvent(eventType, subject, "user", nil)
+	event.SetUserID(actor)
+	return event
+}
+
+func TestHub_DeliversToConcernedUsers(t *testing.T) {
+	hub := NewHub()
+	admin := hub.Subscribe("admin", 4)
+	subject := hub.Subscribe("user-1", 4)
+	other := hub.Subscribe("user-2", 4)
+
+	event := userEvent("user.updated", "user-1", "admin")
+	require.NoError(t, hub.Handle(context.Background(), event))
+
+	for _, subscription := range []*Subscription{admin, subject} {
+		select {
+		case notification := <-subscription.Notifications():
+			assert.Equal(t, event.EventID(), notification.ID)
+			assert.Equal(t, "user.updated", notification.Type)
+			assert.Equal(t, "user-1", notification.AggregateID)
+			assert.Equal(t, "user", notification.AggregateType)
+			assert.Equal(t, event.OccurredAt(), notification.OccurredAt)
+		default:
+			t.Fatalf("notification not delivered to %s", subscription.userID)
+		}
+	}
+	assert.Empty(t, other.Notifications())
+}
+
+func TestHub_DropsWhenBufferFull(t *testing.T) {
+	hub := NewHub()
+	subscription := hub.Subscribe("user-1", 2)
+
+	for range 5 {
+		require.NoError(t, hub.Handle(context.Background(), userEvent("user.updated", "user-1", "user-1")))
+	}
+
+	assert.Len(t, subscription.Notifications(), 2)
+	assert.Equal(t, int64(3), subscription.Dropped())
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub()
+	first := hub.Subscribe("user-1", 1)
+	second := hub.Subscribe("user-1", 1)
+	assert.Equal(t, 2, hub.Subscribers("user-1"))
+
+	first.Close()
+	first.Close()
+	assert.Equal(t, 1, hub.Subscribers("user-1"))
+	_, open := <-first.Notifications()
+	assert.False(t, open)
+
+	hub.Close()
+	assert.Equal(t, 0, hub.Subscribers("user-1"))
+	_, open = <-second.Notifications()
+	assert.False(t, open)
+	second.Close()
+
+	late := hub.Subscribe("user-1", 1)
+	_, open = <-late.Notifications()
+	assert.False(t, open, "subscriptions to a closed hub start closed")
+}
@@ -0,0 +1,96 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/errors"
+	"go-templ-template/internal/shared/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+// EventStreamMediaType is the media type of Server-Sent Events streams
+const EventStreamMediaType = "text/event-stream"
+
+// SSEHandler streams the signed-in user's notifications from hub as
+// Server-Sent Events, for clients that can't open WebSocket connections.
+// Each notification is an event with the notification's ID and its JSON as
+// data; comments are sent every PingInterval so proxies don't close the
+// stream as idle. Register it behind the auth middleware's RequireAuth.
+func SSEHandler(hub *Hub, config Config) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		user, ok := middleware.GetUserFromContext(c).(*userDomain.User)
+		if !ok || user == nil {
+			return errors.NewAuthenticationError("AUTHENTICATION_REQUIRED", "Authentication required")
+		}
+
+		subscription := hub.Subscribe(user.ID, config.BufferSize)
+		defer subscription.Close()
+
+		res := c.Response()
+		header := res.Header()
+		header.Set(echo.HeaderContentType, EventStreamMediaType)
+		header.Set(echo.HeaderCacheControl, "no-cache")
+		header.Set(echo.HeaderConnection, "keep-alive")
+		// Tell nginx not to buffer the stream
+		header.Set("X-Accel-Buffering", "no")
+		res.WriteHeader(http.StatusOK)
+
+		controller := http.NewResponseController(res)
+		send := func(frame string) error {
+			// The HTTP server's write timeout would end the stream, so each
+			// frame gets its own deadline instead
+			deadline := time.Time{}
+			if config.WriteTimeout > 0 {
+				deadline = time.Now().Add(config.WriteTimeout)
+			}
+			controller.SetWriteDeadline(deadline)
+
+			if _, err := fmt.Fprint(res, frame); err != nil {
+				return err
+			}
+			return controller.Flush()
+		}
+
+		// Open the stream, so clients know they are subscribed
+		if err := send(": connected\n\n"); err != nil {
+			return nil
+		}
+
+		var pings <-chan time.Time
+		if config.PingInterval > 0 {
+			ticker := time.NewTicker(config.PingInterval)
+			defer ticker.Stop()
+			pings = ticker.C
+		}
+
+		ctx := c.Request().Context()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+
+			case notification, ok := <-subscription.Notifications():
+				if !ok {
+					return nil
+				}
+				data, err := json.Marshal(notification)
+				if err != nil {
+					return err
+				}
+				if err := send(fmt.Sprintf("id: %s\ndata: %s\n\n", notification.ID, data)); err != nil {
+					return nil
+				}
+
+			case <-pings:
+				if err := send(": keepalive\n\n"); err != nil {
+					return nil
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package notifications
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go-templ-template/internal/shared/events"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSEHandler_StreamsUserEvents(t *testing.T) {
+	bus := events.NewInMemoryEventBus()
+	require.NoError(t, bus.Start(context.Background()))
+	hub := NewHub()
+	require.NoError(t, bus.Subscribe(hub.EventType(), hub))
+	server := newNotificationServer(t, hub, "user-1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/sse/notifications", nil)
+	require.NoError(t, err)
+	req.Header.Set(echo.HeaderAccept, EventStreamMediaType)
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, EventStreamMediaType, res.Header.Get(echo.HeaderContentType))
+	assert.Equal(t, "no-cache", res.Header.Get(echo.HeaderCacheControl))
+	require.Eventually(t, func() bool { return hub.Subscribers("user-1") == 1 }, time.Second, 5*time.Millisecond)
+
+	other := userEvent("user.updated", "user-2", "user-2")
+	require.NoError(t, bus.Publish(context.Background(), other))
+	own := userEvent("user.profile_updated", "user-1", "user-1")
+	require.NoError(t, bus.Publish(context.Background(), own))
+
+	// The first event in the stream is the user's own
+	scanner := bufio.NewScanner(res.Body)
+	var id, data string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, "id: "); ok {
+			id = value
+		}
+		if value, ok := strings.CutPrefix(line, "data: "); ok {
+			data = value
+			break
+		}
+	}
+	require.NotEmpty(t, data, "no data line received: %v", scanner.Err())
+
+	assert.Equal(t, own.EventID(), id)
+	var notification Notification
+	require.NoError(t, json.Unmarshal([]byte(data), &notification))
+	assert.Equal(t, own.EventID(), notification.ID)
+	assert.Equal(t, "user.profile_updated", notification.Type)
+	assert.Equal(t, "user-1", notification.AggregateID)
+}
+
+func TestSSEHandler_CleansUpOnDisconnect(t *testing.T) {
+	hub := NewHub()
+	server := newNotificationServer(t, hub, "user-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/sse/notifications", nil)
+	require.NoError(t, err)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Eventually(t, func() bool { return hub.Subscribers("user-1") == 1 }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	assert.Eventually(t, func() bool { return hub.Subscribers("user-1") == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestSSEHandler_KeepAlive(t *testing.T) {
+	config := DefaultConfig()
+	config.PingInterval = 10 * time.Millisecond
+	server := newNotificationServerWithConfig(t, NewHub(), "user-1", config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/sse/notifications", nil)
+	require.NoError(t, err)
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		if scanner.Text() == ": keepalive" {
+			return
+		}
+	}
+	t.Fatalf("no keepalive comment received: %v", scanner.Err())
+}
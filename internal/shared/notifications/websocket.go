@@ -0,0 +1,137 @@
+package notifications
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/errors"
+	"go-templ-template/internal/shared/middleware"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+)
+
+// maxClientMessageBytes is the largest message accepted from clients,
+// which have nothing to send but control frames
+const maxClientMessageBytes = 4096
+
+// Config holds configuration for notification connections
+type Config struct {
+	// PingInterval is how often connections are pinged, so proxies don't
+	// close them as idle; zero disables pings
+	PingInterval time.Duration
+
+	// WriteTimeout is how long a frame may take to send before the client
+	// is considered gone and the connection is closed
+	WriteTimeout time.Duration
+
+	// BufferSize is how many notifications are buffered for a client that
+	// is slow to receive them; further notifications are dropped
+	BufferSize int
+}
+
+// DefaultConfig returns the default notification connection configuration
+func DefaultConfig() Config {
+	return Config{
+		PingInterval: 30 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		BufferSize:   32,
+	}
+}
+
+// Handler upgrades requests to WebSocket connections that receive the
+// signed-in user's notifications from hub as JSON text frames. Register it
+// behind the auth middleware's RequireAuth. Only same-origin handshakes
+// are accepted, as browsers send the session cookie with cross-site
+// WebSocket handshakes too.
+func Handler(hub *Hub, config Config) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		user, ok := middleware.GetUserFromContext(c).(*userDomain.User)
+		if !ok || user == nil {
+			return errors.NewAuthenticationError("AUTHENTICATION_REQUIRED", "Authentication required")
+		}
+
+		server := websocket.Server{
+			Handshake: sameOrigin,
+			Handler: func(conn *websocket.Conn) {
+				serve(conn, hub.Subscribe(user.ID, config.BufferSize), config)
+			},
+		}
+		server.ServeHTTP(c.Response(), c.Request())
+		return nil
+	}
+}
+
+// sameOrigin accepts handshakes whose Origin is the requested host
+func sameOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+	if origin == nil || origin.Host != req.Host {
+		return fmt.Errorf("origin %q not allowed", req.Header.Get("Origin"))
+	}
+	config.Origin = origin
+	return nil
+}
+
+// serve sends subscription's notifications on conn until the client
+// disconnects, a send fails or the subscription is closed
+func serve(conn *websocket.Conn, subscription *Subscription, config Config) {
+	defer conn.Close()
+	defer subscription.Close()
+
+	// The HTTP server's deadlines still apply to the hijacked connection
+	conn.SetDeadline(time.Time{})
+	conn.MaxPayloadBytes = maxClientMessageBytes
+
+	// Reading answers the client's pings and notices it disconnecting
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		var message []byte
+		for websocket.Message.Receive(conn, &message) == nil {
+		}
+	}()
+
+	var pings <-chan time.Time
+	if config.PingInterval > 0 {
+		ticker := time.NewTicker(config.PingInterval)
+		defer ticker.Stop()
+		pings = ticker.C
+	}
+
+	for {
+		select {
+		case <-disconnected:
+			return
+
+		case notification, ok := <-subscription.Notifications():
+			if !ok {
+				return
+			}
+			setWriteDeadline(conn, config.WriteTimeout)
+			if err := websocket.JSON.Send(conn, notification); err != nil {
+				return
+			}
+
+		case <-pings:
+			setWriteDeadline(conn, config.WriteTimeout)
+			conn.PayloadType = websocket.PingFrame
+			_, err := conn.Write(nil)
+			conn.PayloadType = websocket.TextFrame
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// setWriteDeadline limits how long the next write on conn may take
+func setWriteDeadline(conn *websocket.Conn, timeout time.Duration) {
+	if timeout > 0 {
+		conn.SetWriteDeadline(time.Now().Add(timeout))
+	}
+}
@@ -0,0 +1,115 @@
+package notifications
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	userDomain "go-templ-template/internal/modules/user/domain"
+	"go-templ-template/internal/shared/events"
+	"go-templ-template/internal/shared/middleware"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// newNotificationServer serves Handler and SSEHandler for hub with every
+// request signed in as userID, standing in for the auth middleware
+func newNotificationServer(t *testing.T, hub *Hub, userID string) *httptest.Server {
+	t.Helper()
+	return newNotificationServerWithConfig(t, hub, userID, DefaultConfig())
+}
+
+// newNotificationServerWithConfig is newNotificationServer with config
+func newNotificationServerWithConfig(t *testing.T, hub *Hub, userID string, config Config) *httptest.Server {
+	t.Helper()
+	signIn := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(middleware.UserContextKey, &userDomain.User{ID: userID})
+			return next(c)
+		}
+	}
+
+	e := echo.New()
+	e.GET("/ws/notifications", Handler(hub, config), signIn)
+	e.GET("/sse/notifications", SSEHandler(hub, config), signIn)
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// dial connects to the notification endpoint of server from origin
+func dial(server *httptest.Server, origin string) (*websocket.Conn, error) {
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/notifications"
+	return websocket.Dial(url, "", origin)
+}
+
+func TestHandler_PushesUserEvents(t *testing.T) {
+	bus := events.NewInMemoryEventBus()
+	require.NoError(t, bus.Start(context.Background()))
+	hub := NewHub()
+	require.NoError(t, bus.Subscribe(hub.EventType(), hub))
+
+	server := newNotificationServer(t, hub, "user-1")
+	conn, err := dial(server, server.URL)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.Eventually(t, func() bool { return hub.Subscribers("user-1") == 1 }, time.Second, 5*time.Millisecond)
+
+	// Events for other users are published first, so they would arrive
+	// before the user's own event if they were delivered
+	other := userEvent("user.updated", "user-2", "user-2")
+	require.NoError(t, bus.Publish(context.Background(), other))
+	own := userEvent("user.profile_updated", "user-1", "user-1")
+	require.NoError(t, bus.Publish(context.Background(), own))
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	var notification Notification
+	require.NoError(t, websocket.JSON.Receive(conn, &notification))
+	assert.Equal(t, own.EventID(), notification.ID)
+	assert.Equal(t, "user.profile_updated", notification.Type)
+	assert.Equal(t, "user-1", notification.AggregateID)
+}
+
+func TestHandler_CleansUpOnDisconnect(t *testing.T) {
+	hub := NewHub()
+	server := newNotificationServer(t, hub, "user-1")
+
+	conn, err := dial(server, server.URL)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return hub.Subscribers("user-1") == 1 }, time.Second, 5*time.Millisecond)
+
+	conn.Close()
+	assert.Eventually(t, func() bool { return hub.Subscribers("user-1") == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestHandler_ClosesWithHub(t *testing.T) {
+	hub := NewHub()
+	server := newNotificationServer(t, hub, "user-1")
+
+	conn, err := dial(server, server.URL)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool { return hub.Subscribers("user-1") == 1 }, time.Second, 5*time.Millisecond)
+
+	hub.Close()
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	var message []byte
+	assert.Error(t, websocket.Message.Receive(conn, &message), "the server should close the connection")
+}
+
+func TestHandler_RejectsCrossOriginHandshake(t *testing.T) {
+	hub := NewHub()
+	server := newNotificationServer(t, hub, "user-1")
+
+	_, err := dial(server, "https://evil.example.com")
+	assert.Error(t, err)
+	assert.Equal(t, 0, hub.Subscribers("user-1"))
+}
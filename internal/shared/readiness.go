@@ -0,0 +1,26 @@
+package shared
+
+import "sync/atomic"
+
+// ReadinessState records whether the application should receive new
+// traffic. It starts ready; Drain marks it as draining on shutdown, so
+// readiness probes fail and load balancers stop routing requests to the
+// instance before its server stops accepting them.
+type ReadinessState struct {
+	draining atomic.Bool
+}
+
+// NewReadinessState creates a ready state
+func NewReadinessState() *ReadinessState {
+	return &ReadinessState{}
+}
+
+// Drain marks the application as draining; it does not become ready again
+func (s *ReadinessState) Drain() {
+	s.draining.Store(true)
+}
+
+// Draining reports whether Drain has been called
+func (s *ReadinessState) Draining() bool {
+	return s.draining.Load()
+}
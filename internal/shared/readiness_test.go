@@ -0,0 +1,18 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadinessState(t *testing.T) {
+	state := NewReadinessState()
+	assert.False(t, state.Draining())
+
+	state.Drain()
+	assert.True(t, state.Draining())
+
+	state.Drain()
+	assert.True(t, state.Draining(), "draining is permanent")
+}
@@ -3,18 +3,24 @@ package shared
 import (
 	"context"
 	"fmt"
-	"sort"
+	"strings"
+	"time"
 
 	"go-templ-template/internal/shared/events"
 
 	"github.com/labstack/echo/v4"
 )
 
+// DefaultAPIPrefix is the path module routes are registered under unless
+// configured otherwise
+const DefaultAPIPrefix = "/api/v1"
+
 // ModuleRegistry manages the registration and lifecycle of all application modules
 type ModuleRegistry struct {
 	container *ModuleContainer
 	modules   []Module
 	router    *echo.Echo
+	apiPrefix string
 }
 
 // NewModuleRegistry creates a new module registry
@@ -23,7 +29,22 @@ func NewModuleRegistry(eventBus events.EventBus, db interface{}, config interfac
 		container: NewModuleContainer(eventBus, db, config),
 		modules:   make([]Module, 0),
 		router:    router,
+		apiPrefix: DefaultAPIPrefix,
+	}
+}
+
+// WithAPIPrefix registers module routes under prefix, e.g. /api/v2. An empty
+// prefix keeps DefaultAPIPrefix.
+func (r *ModuleRegistry) WithAPIPrefix(prefix string) *ModuleRegistry {
+	if prefix != "" {
+		r.apiPrefix = "/" + strings.Trim(prefix, "/")
 	}
+	return r
+}
+
+// APIPrefix returns the path module routes are registered under
+func (r *ModuleRegistry) APIPrefix() string {
+	return r.apiPrefix
 }
 
 // Register registers a module with the registry
@@ -46,12 +67,16 @@ func (r *ModuleRegistry) Register(module Module) error {
 	return nil
 }
 
-// Initialize initializes all registered modules in dependency order
+// Initialize initializes all registered modules in dependency order, so
+// modules declaring ModuleDependencies are initialized after the modules they
+// depend on. The registry keeps that order for routes, event handlers and,
+// reversed, shutdown.
 func (r *ModuleRegistry) Initialize(ctx context.Context) error {
-	// Sort modules by dependency order
-	// For now, we'll use a simple approach where user module comes before auth module
-	// In a more complex system, you might want to implement a proper dependency graph
-	sortedModules := r.sortModulesByDependency()
+	sortedModules, err := r.sortModulesByDependency()
+	if err != nil {
+		return err
+	}
+	r.modules = sortedModules
 
 	// Initialize each module
 	for _, module := range sortedModules {
@@ -63,14 +88,18 @@ func (r *ModuleRegistry) Initialize(ctx context.Context) error {
 	return nil
 }
 
-// RegisterRoutes registers all module routes with the router
+// RegisterRoutes registers all module routes with the router under the API
+// prefix, and below that under the module's own prefix if it declares one
 func (r *ModuleRegistry) RegisterRoutes() error {
-	// Create API v1 group
-	v1 := r.router.Group("/api/v1")
+	api := r.router.Group(r.apiPrefix)
 
 	// Register routes for each module
 	for _, module := range r.modules {
-		module.RegisterRoutes(v1)
+		group := api
+		if prefixed, ok := module.(ModuleRoutePrefix); ok && prefixed.RoutePrefix() != "" {
+			group = api.Group("/" + strings.Trim(prefixed.RoutePrefix(), "/"))
+		}
+		module.RegisterRoutes(group)
 	}
 
 	return nil
@@ -118,6 +147,17 @@ func (r *ModuleRegistry) Health(ctx context.Context) map[string]error {
 	return healthStatus
 }
 
+// RegisterHealthChecks registers a check named "module.<name>" for each
+// module with aggregator, each timing out after timeout
+func (r *ModuleRegistry) RegisterHealthChecks(aggregator *HealthAggregator, timeout time.Duration) error {
+	for _, module := range r.modules {
+		if err := aggregator.Register("module."+module.Name(), timeout, module.Health); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Shutdown gracefully shuts down all modules
 func (r *ModuleRegistry) Shutdown(ctx context.Context) error {
 	// Shutdown modules in reverse order
@@ -150,37 +190,70 @@ func (r *ModuleRegistry) GetContainer() *ModuleContainer {
 	return r.container
 }
 
-// sortModulesByDependency sorts modules by their dependency order
-// This is a simple implementation - in a more complex system you might want
-// to implement a proper topological sort based on declared dependencies
-func (r *ModuleRegistry) sortModulesByDependency() []Module {
-	sorted := make([]Module, len(r.modules))
-	copy(sorted, r.modules)
-
-	// Simple dependency order: user module first, then auth module, then others
-	sort.Slice(sorted, func(i, j int) bool {
-		nameI := sorted[i].Name()
-		nameJ := sorted[j].Name()
+// sortModulesByDependency orders modules so each comes after the modules it
+// depends on, keeping registration order otherwise. It fails if a module
+// depends on an unregistered module or dependencies form a cycle.
+func (r *ModuleRegistry) sortModulesByDependency() ([]Module, error) {
+	byName := make(map[string]Module, len(r.modules))
+	for _, module := range r.modules {
+		byName[module.Name()] = module
+	}
 
-		// User module comes first
-		if nameI == "user" {
-			return true
-		}
-		if nameJ == "user" {
-			return false
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(r.modules))
+	sorted := make([]Module, 0, len(r.modules))
+	var path []string
+
+	var visit func(module Module) error
+	visit = func(module Module) error {
+		name := module.Name()
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(path[indexOf(path, name):], name)
+			return fmt.Errorf("module dependency cycle: %s", strings.Join(cycle, " -> "))
 		}
 
-		// Auth module comes after user but before others
-		if nameI == "auth" {
-			return true
+		state[name] = visiting
+		path = append(path, name)
+
+		if declared, ok := module.(ModuleDependencies); ok {
+			for _, dependency := range declared.DependsOn() {
+				dependencyModule, exists := byName[dependency]
+				if !exists {
+					return NewModuleError(name, fmt.Sprintf("depends on unregistered module %s", dependency))
+				}
+				if err := visit(dependencyModule); err != nil {
+					return err
+				}
+			}
 		}
-		if nameJ == "auth" {
-			return false
+
+		path = path[:len(path)-1]
+		state[name] = visited
+		sorted = append(sorted, module)
+		return nil
+	}
+
+	for _, module := range r.modules {
+		if err := visit(module); err != nil {
+			return nil, err
 		}
+	}
 
-		// For other modules, maintain alphabetical order
-		return nameI < nameJ
-	})
+	return sorted, nil
+}
 
-	return sorted
+// indexOf returns the index of name in names, or -1
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
 }
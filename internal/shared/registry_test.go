@@ -2,11 +2,14 @@ package shared
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestModuleRegistry_Register(t *testing.T) {
@@ -219,33 +222,176 @@ func TestModuleRegistry_GetAllModules(t *testing.T) {
 	assert.NotEqual(t, "modified", originalModules[0].Name())
 }
 
+// dependentModule is a MockModule declaring the modules it depends on
+type dependentModule struct {
+	*MockModule
+	dependsOn []string
+}
+
+func newDependentModule(name string, dependsOn ...string) *dependentModule {
+	return &dependentModule{MockModule: NewMockModule(name), dependsOn: dependsOn}
+}
+
+func (m *dependentModule) DependsOn() []string {
+	return m.dependsOn
+}
+
 func TestModuleRegistry_SortModulesByDependency(t *testing.T) {
 	// Arrange
 	eventBus := &MockEventBus{}
 	router := echo.New()
 	registry := NewModuleRegistry(eventBus, nil, nil, router)
 
-	// Register modules in random order
-	authModule := NewMockModule("auth")
-	userModule := NewMockModule("user")
-	otherModule := NewMockModule("other")
+	// Register modules before the modules they depend on
+	require.NoError(t, registry.Register(newDependentModule("auth", "user")))
+	require.NoError(t, registry.Register(NewMockModule("other")))
+	require.NoError(t, registry.Register(newDependentModule("billing", "auth", "user")))
+	require.NoError(t, registry.Register(NewMockModule("user")))
+
+	// Act
+	sorted, err := registry.sortModulesByDependency()
+
+	// Assert
+	require.NoError(t, err)
+	names := make([]string, len(sorted))
+	for i, module := range sorted {
+		names[i] = module.Name()
+	}
+	assert.Equal(t, []string{"user", "auth", "other", "billing"}, names)
+}
+
+func TestModuleRegistry_Start_InitializesDependenciesFirst(t *testing.T) {
+	// Arrange
+	eventBus := &MockEventBus{}
+	router := echo.New()
+	registry := NewModuleRegistry(eventBus, nil, nil, router)
+
+	var started, stopped []string
+	auth := newDependentModule("auth", "user")
+	user := NewMockModule("user")
+	for _, module := range []*MockModule{auth.MockModule, user} {
+		name := module.Name()
+		module.On("Initialize", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+			started = append(started, name)
+		}).Return(nil)
+		module.On("RegisterRoutes", mock.Anything).Return()
+		module.On("RegisterEventHandlers", eventBus).Return(nil)
+		module.On("Shutdown", mock.Anything).Run(func(mock.Arguments) {
+			stopped = append(stopped, name)
+		}).Return(nil)
+	}
+	require.NoError(t, registry.Register(auth))
+	require.NoError(t, registry.Register(user))
+
+	// Act
+	err := registry.Start(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user", "auth"}, started)
+
+	// Modules shut down in reverse dependency order
+	require.NoError(t, registry.Shutdown(context.Background()))
+	assert.Equal(t, []string{"auth", "user"}, stopped)
+}
+
+func TestModuleRegistry_Start_DependencyCycle(t *testing.T) {
+	// Arrange
+	eventBus := &MockEventBus{}
+	router := echo.New()
+	registry := NewModuleRegistry(eventBus, nil, nil, router)
+
+	a := newDependentModule("a", "b")
+	b := newDependentModule("b", "c")
+	c := newDependentModule("c", "a")
+	require.NoError(t, registry.Register(a))
+	require.NoError(t, registry.Register(b))
+	require.NoError(t, registry.Register(c))
+
+	// Act
+	err := registry.Start(context.Background())
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "module dependency cycle: a -> b -> c -> a")
+	a.AssertNotCalled(t, "Initialize", mock.Anything, mock.Anything)
+}
 
-	registry.Register(authModule)
-	registry.Register(otherModule)
-	registry.Register(userModule)
+func TestModuleRegistry_Start_MissingDependency(t *testing.T) {
+	// Arrange
+	eventBus := &MockEventBus{}
+	router := echo.New()
+	registry := NewModuleRegistry(eventBus, nil, nil, router)
+	require.NoError(t, registry.Register(newDependentModule("auth", "user")))
 
 	// Act
-	sorted := registry.sortModulesByDependency()
+	err := registry.Start(context.Background())
 
 	// Assert
-	assert.Len(t, sorted, 3)
+	require.Error(t, err)
+	var moduleErr *ModuleError
+	require.ErrorAs(t, err, &moduleErr)
+	assert.Equal(t, "auth", moduleErr.ModuleName)
+	assert.Contains(t, err.Error(), "depends on unregistered module user")
+}
+
+// routeModule is a MockModule serving GET /ping, optionally under its own
+// route prefix
+type routeModule struct {
+	*MockModule
+	routePrefix string
+}
+
+func newRouteModule(name, routePrefix string) *routeModule {
+	return &routeModule{MockModule: NewMockModule(name), routePrefix: routePrefix}
+}
+
+func (m *routeModule) RegisterRoutes(router *echo.Group) {
+	router.GET("/ping", func(c echo.Context) error {
+		return c.String(http.StatusOK, m.Name())
+	})
+}
 
-	// User module should come first
-	assert.Equal(t, "user", sorted[0].Name())
+func (m *routeModule) RoutePrefix() string {
+	return m.routePrefix
+}
+
+func serveStatus(router *echo.Echo, path string) int {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec.Code
+}
+
+func TestModuleRegistry_RegisterRoutes_DefaultPrefix(t *testing.T) {
+	router := echo.New()
+	registry := NewModuleRegistry(&MockEventBus{}, nil, nil, router)
+	require.NoError(t, registry.Register(newRouteModule("fake", "")))
+
+	require.NoError(t, registry.RegisterRoutes())
+
+	assert.Equal(t, DefaultAPIPrefix, registry.APIPrefix())
+	assert.Equal(t, http.StatusOK, serveStatus(router, "/api/v1/ping"))
+}
+
+func TestModuleRegistry_RegisterRoutes_ConfiguredPrefix(t *testing.T) {
+	router := echo.New()
+	registry := NewModuleRegistry(&MockEventBus{}, nil, nil, router).WithAPIPrefix("api/v2/")
+	require.NoError(t, registry.Register(newRouteModule("fake", "")))
+
+	require.NoError(t, registry.RegisterRoutes())
+
+	assert.Equal(t, "/api/v2", registry.APIPrefix())
+	assert.Equal(t, http.StatusOK, serveStatus(router, "/api/v2/ping"))
+	assert.Equal(t, http.StatusNotFound, serveStatus(router, "/api/v1/ping"))
+}
+
+func TestModuleRegistry_RegisterRoutes_ModuleRoutePrefix(t *testing.T) {
+	router := echo.New()
+	registry := NewModuleRegistry(&MockEventBus{}, nil, nil, router).WithAPIPrefix("/api/v2")
+	require.NoError(t, registry.Register(newRouteModule("billing", "/billing")))
 
-	// Auth module should come second
-	assert.Equal(t, "auth", sorted[1].Name())
+	require.NoError(t, registry.RegisterRoutes())
 
-	// Other modules should come last
-	assert.Equal(t, "other", sorted[2].Name())
+	assert.Equal(t, http.StatusOK, serveStatus(router, "/api/v2/billing/ping"))
+	assert.Equal(t, http.StatusNotFound, serveStatus(router, "/api/v2/ping"))
 }
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBlobStoreContract checks the behaviour every BlobStore must share
+func testBlobStoreContract(t *testing.T, store BlobStore) {
+	ctx := context.Background()
+
+	read := func(t *testing.T, key string) string {
+		t.Helper()
+		body, err := store.Get(ctx, key)
+		require.NoError(t, err)
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	t.Run("put and get round-trip", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "contract/round-trip.txt", strings.NewReader("hello"), "text/plain"))
+		assert.Equal(t, "hello", read(t, "contract/round-trip.txt"))
+	})
+
+	t.Run("put replaces an existing blob", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "contract/replace.txt", strings.NewReader("first"), "text/plain"))
+		require.NoError(t, store.Put(ctx, "contract/replace.txt", strings.NewReader("second"), "text/plain"))
+		assert.Equal(t, "second", read(t, "contract/replace.txt"))
+	})
+
+	t.Run("delete removes a blob", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "contract/delete.txt", strings.NewReader("bye"), "text/plain"))
+		require.NoError(t, store.Delete(ctx, "contract/delete.txt"))
+
+		_, err := store.Get(ctx, "contract/delete.txt")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("delete of a missing key succeeds", func(t *testing.T) {
+		assert.NoError(t, store.Delete(ctx, "contract/never-stored.txt"))
+	})
+
+	t.Run("get of a missing key returns ErrNotFound", func(t *testing.T) {
+		_, err := store.Get(ctx, "contract/missing.txt")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+
+	t.Run("invalid keys are rejected", func(t *testing.T) {
+		for _, key := range []string{"", "/absolute", "trailing/", "a/../b", "../escape", "a//b", `a\b`} {
+			assert.True(t, errors.Is(store.Put(ctx, key, strings.NewReader("x"), "text/plain"), ErrInvalidKey), key)
+			_, err := store.Get(ctx, key)
+			assert.True(t, errors.Is(err, ErrInvalidKey), key)
+			assert.True(t, errors.Is(store.Delete(ctx, key), ErrInvalidKey), key)
+		}
+	})
+
+	t.Run("URL ends with the key", func(t *testing.T) {
+		assert.True(t, strings.HasSuffix(store.URL("contract/a.png"), "/contract/a.png"))
+	})
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-templ-template/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore_Contract(t *testing.T) {
+	testBlobStoreContract(t, NewLocalStore(t.TempDir(), "/uploads"))
+}
+
+func TestLocalStore_WritesFilesUnderDir(t *testing.T) {
+	dir := t.TempDir()
+	store := NewLocalStore(dir, "/uploads")
+
+	err := store.Put(context.Background(), "avatars/user-1/a.png", strings.NewReader("png"), "image/png")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "avatars", "user-1", "a.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "png", string(data))
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(filepath.Join(dir, "avatars", "user-1"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLocalStore_URL(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		key      string
+		expected string
+	}{
+		{"path prefix", "/uploads", "avatars/a.png", "/uploads/avatars/a.png"},
+		{"trailing slash", "/uploads/", "avatars/a.png", "/uploads/avatars/a.png"},
+		{"absolute URL", "https://cdn.example.com/files", "exports/data.zip", "https://cdn.example.com/files/exports/data.zip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NewLocalStore(t.TempDir(), tt.baseURL).URL(tt.key))
+		})
+	}
+}
+
+func TestNewBlobStore(t *testing.T) {
+	store, err := NewBlobStore(config.StorageConfig{Driver: "local", LocalDir: t.TempDir(), LocalURL: "/uploads"})
+	require.NoError(t, err)
+	assert.IsType(t, &LocalStore{}, store)
+
+	store, err = NewBlobStore(config.StorageConfig{LocalDir: t.TempDir()})
+	require.NoError(t, err)
+	assert.IsType(t, &LocalStore{}, store)
+
+	store, err = NewBlobStore(config.StorageConfig{
+		Driver:            "s3",
+		S3Bucket:          "bucket",
+		S3AccessKeyID:     "key",
+		S3SecretAccessKey: "secret",
+	})
+	require.NoError(t, err)
+	assert.IsType(t, &S3Store{}, store)
+
+	_, err = NewBlobStore(config.StorageConfig{Driver: "s3"})
+	assert.Error(t, err)
+
+	_, err = NewBlobStore(config.StorageConfig{Driver: "ftp"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+// Package storage provides the BlobStore port modules use to keep uploaded
+// files, such as avatars and data exports, with implementations backed by the
+// local filesystem and by S3-compatible object storage.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go-templ-template/internal/config"
+)
+
+var (
+	// ErrNotFound indicates no blob is stored under a key
+	ErrNotFound = errors.New("blob not found")
+
+	// ErrInvalidKey indicates a key that is empty or not a clean relative path
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobStore stores blobs under slash-separated keys such as
+// "avatars/<user id>/<name>.png"
+type BlobStore interface {
+	// Put stores body under key with the given content type, replacing any
+	// existing blob
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+
+	// Get opens the blob stored under key, returning ErrNotFound if there is
+	// none. The caller must close the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the blob stored under key; deleting a missing key is
+	// not an error
+	Delete(ctx context.Context, key string) error
+
+	// URL returns the URL the blob stored under key is served from
+	URL(key string) string
+}
+
+// NewBlobStore creates the blob store selected by the STORAGE_DRIVER setting
+func NewBlobStore(cfg config.StorageConfig) (BlobStore, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStore(cfg.LocalDir, cfg.LocalURL), nil
+	case "s3":
+		return NewS3Store(S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			PublicURL:       cfg.S3PublicURL,
+			UsePathStyle:    cfg.S3UsePathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", cfg.Driver)
+	}
+}
+
+// validateKey checks that key is a clean, relative, slash-separated path, so
+// it can't escape a directory or be read differently by different stores
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") ||
+		strings.ContainsAny(key, "\\\x00\r\n") {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authApp "go-templ-template/internal/modules/auth/application"
+	"go-templ-template/internal/modules/auth/domain"
+	"go-templ-template/internal/shared/database"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SessionRepositoryFactory creates the empty repository a contract test runs
+// against, and newUserID, which returns the ID of a new user sessions can
+// belong to
+type SessionRepositoryFactory func(t *testing.T) (repo authApp.SessionRepository, newUserID func() string)
+
+// RunSessionRepositoryContract checks the behaviour every SessionRepository
+// must have, so mocks can't drift from the real repository. Run it against
+// each implementation, with newRepo creating a fresh repository per subtest.
+func RunSessionRepositoryContract(t *testing.T, newRepo SessionRepositoryFactory) {
+	ctx := context.Background()
+
+	t.Run("Create and GetByID", func(t *testing.T) {
+		repo, newUserID := newRepo(t)
+		session := NewSessionFixture(WithUserID(newUserID()), WithClient("10.0.0.1", "contract-agent"))
+
+		require.NoError(t, repo.Create(ctx, session))
+
+		stored, err := repo.GetByID(ctx, session.ID)
+		require.NoError(t, err)
+		assertSameSession(t, session, stored)
+	})
+
+	t.Run("Create rejects duplicate ID", func(t *testing.T) {
+		repo, newUserID := newRepo(t)
+		session := NewSessionFixture(WithUserID(newUserID()))
+
+		require.NoError(t, repo.Create(ctx, session))
+		assert.Error(t, repo.Create(ctx, session))
+	})
+
+	t.Run("GetByID of missing session", func(t *testing.T) {
+		repo, _ := newRepo(t)
+
+		_, err := repo.GetByID(ctx, NewSessionFixture().ID)
+		assert.True(t, database.IsNotFoundError(err), "expected not found, got %v", err)
+	})
+
+	t.Run("GetByUserID returns only the user's valid sessions", func(t *testing.T) {
+		repo, newUserID := newRepo(t)
+		userID := newUserID()
+
+		valid := NewSessionFixture(WithUserID(userID))
+		sessions := []*domain.Session{
+			valid,
+			NewSessionFixture(WithUserID(userID), ExpiredAt(time.Now().Add(-time.Hour))),
+			NewSessionFixture(WithUserID(userID), Inactive()),
+			NewSessionFixture(WithUserID(newUserID())),
+		}
+		for _, session := range sessions {
+			require.NoError(t, repo.Create(ctx, session))
+		}
+
+		found, err := repo.GetByUserID(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, valid.ID, found[0].ID)
+
+		found, err = repo.GetByUserID(ctx, newUserID())
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo, newUserID := newRepo(t)
+		session := NewSessionFixture(WithUserID(newUserID()))
+		require.NoError(t, repo.Create(ctx, session))
+
+		updated := *session
+		updated.ExpiresAt = session.ExpiresAt.Add(time.Hour)
+		updated.UserAgent = "updated-agent"
+		updated.IsActive = false
+		require.NoError(t, repo.Update(ctx, &updated))
+
+		stored, err := repo.GetByID(ctx, session.ID)
+		require.NoError(t, err)
+		assertSameSession(t, &updated, stored)
+	})
+
+	t.Run("Update of missing session", func(t *testing.T) {
+		repo, newUserID := newRepo(t)
+
+		err := repo.Update(ctx, NewSessionFixture(WithUserID(newUserID())))
+		assert.True(t, database.IsNotFoundError(err), "expected not found, got %v", err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo, newUserID := newRepo(t)
+		session := NewSessionFixture(WithUserID(newUserID()))
+		require.NoError(t, repo.Create(ctx, session))
+
+		require.NoError(t, repo.Delete(ctx, session.ID))
+
+		exists, err := repo.ExistsByID(ctx, session.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		err = repo.Delete(ctx, session.ID)
+		assert.True(t, database.IsNotFoundError(err), "expected not found deleting twice, got %v", err)
+	})
+
+	t.Run("DeleteByUserID", func(t *testing.T) {
+		repo, newUserID := newRepo(t)
+		userID := newUserID()
+		first := NewSessionFixture(WithUserID(userID))
+		second := NewSessionFixture(WithUserID(userID), Inactive())
+		other := NewSessionFixture(WithUserID(newUserID()))
+		for _, session := range []*domain.Session{first, second, other} {
+			require.NoError(t, repo.Create(ctx, session))
+		}
+
+		require.NoError(t, repo.DeleteByUserID(ctx, userID))
+
+		for _, session := range []*domain.Session{first, second} {
+			exists, err := repo.ExistsByID(ctx, session.ID)
+			require.NoError(t, err)
+			assert.False(t, exists, "session %s should be deleted", session.ID)
+		}
+		exists, err := repo.ExistsByID(ctx, other.ID)
+		require.NoError(t, err)
+		assert.True(t, exists, "other users' sessions should be kept")
+
+		assert.NoError(t, repo.DeleteByUserID(ctx, newUserID()), "users without sessions are not an error")
+	})
+
+	t.Run("DeleteExpired removes expired and inactive sessions", func(t *testing.T) {
+		repo, newUserID := newRepo(t)
+		userID := newUserID()
+		valid := NewSessionFixture(WithUserID(userID))
+		expired := NewSessionFixture(WithUserID(userID), ExpiredAt(time.Now().Add(-time.Minute)))
+		inactive := NewSessionFixture(WithUserID(userID), Inactive())
+		for _, session := range []*domain.Session{valid, expired, inactive} {
+			require.NoError(t, repo.Create(ctx, session))
+		}
+
+		require.NoError(t, repo.DeleteExpired(ctx))
+
+		for session, want := range map[*domain.Session]bool{valid: true, expired: false, inactive: false} {
+			exists, err := repo.ExistsByID(ctx, session.ID)
+			require.NoError(t, err)
+			assert.Equal(t, want, exists, "session expiring at %s, active %v", session.ExpiresAt, session.IsActive)
+		}
+	})
+
+	t.Run("ExistsByID", func(t *testing.T) {
+		repo, newUserID := newRepo(t)
+		session := NewSessionFixture(WithUserID(newUserID()))
+
+		exists, err := repo.ExistsByID(ctx, session.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		require.NoError(t, repo.Create(ctx, session))
+
+		exists, err = repo.ExistsByID(ctx, session.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
+
+// assertSameSession asserts that a stored session matches the one saved,
+// allowing for the database's timestamp precision
+func assertSameSession(t *testing.T, want, got *domain.Session) {
+	t.Helper()
+
+	assert.Equal(t, want.ID, got.ID)
+	assert.Equal(t, want.UserID, got.UserID)
+	assert.WithinDuration(t, want.ExpiresAt, got.ExpiresAt, time.Millisecond)
+	assert.WithinDuration(t, want.CreatedAt, got.CreatedAt, time.Millisecond)
+	assert.Equal(t, want.IPAddress, got.IPAddress)
+	assert.Equal(t, want.UserAgent, got.UserAgent)
+	assert.Equal(t, want.IsActive, got.IsActive)
+}
@@ -0,0 +1,107 @@
+package testing
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"go-templ-template/internal/config"
+	authApp "go-templ-template/internal/modules/auth/application"
+	authInfra "go-templ-template/internal/modules/auth/infrastructure"
+	"go-templ-template/internal/shared/database"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockSessionRepository_Contract(t *testing.T) {
+	RunSessionRepositoryContract(t, func(t *testing.T) (authApp.SessionRepository, func() string) {
+		repo := NewMockSessionRepository()
+
+		// Fall back to the in-memory storage for every method
+		repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		repo.On("GetByID", mock.Anything, mock.Anything).Return(nil, nil)
+		repo.On("GetByUserID", mock.Anything, mock.Anything).Return(nil, nil)
+		repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		repo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		repo.On("DeleteByUserID", mock.Anything, mock.Anything).Return(nil)
+		repo.On("DeleteExpired", mock.Anything).Return(nil)
+		repo.On("ExistsByID", mock.Anything, mock.Anything).Return(false, nil)
+
+		return repo, func() string { return uuid.New().String() }
+	})
+}
+
+func TestDBSessionRepository_Contract(t *testing.T) {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL not set, skipping session repository contract against the database")
+	}
+
+	RunSessionRepositoryContract(t, func(t *testing.T) (authApp.SessionRepository, func() string) {
+		db := newContractDatabase(t, databaseURL)
+
+		newUserID := func() string {
+			userID := uuid.New().String()
+			_, err := db.Exec(`INSERT INTO users (id) VALUES ($1)`, userID)
+			require.NoError(t, err)
+			return userID
+		}
+
+		return authInfra.NewSessionRepositoryAdapter(db), newUserID
+	})
+}
+
+// newContractDatabase connects to databaseURL with a schema of its own,
+// dropped when the test finishes, holding the tables sessions need. Like the
+// session repository's own tests it stores session IDs as text, as they are
+// not UUIDs.
+func newContractDatabase(t *testing.T, databaseURL string) *database.DB {
+	schema := "contract_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+
+	db, err := database.NewConnection(&config.DatabaseConfig{URL: withSearchPath(t, databaseURL, schema)}, database.DefaultConnectionOptions())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = db.Exec(fmt.Sprintf("DROP SCHEMA IF EXISTS %s CASCADE", pq.QuoteIdentifier(schema)))
+		db.Close()
+	})
+
+	for _, query := range []string{
+		fmt.Sprintf("CREATE SCHEMA %s", pq.QuoteIdentifier(schema)),
+		`CREATE TABLE users (id VARCHAR(255) PRIMARY KEY)`,
+		`CREATE TABLE sessions (
+			id VARCHAR(255) PRIMARY KEY,
+			user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+			last_activity_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+			ip_address INET,
+			user_agent TEXT,
+			is_active BOOLEAN NOT NULL DEFAULT true
+		)`,
+	} {
+		_, err := db.Exec(query)
+		require.NoError(t, err)
+	}
+
+	return db
+}
+
+// withSearchPath adds search_path to a URL or key=value connection string
+func withSearchPath(t *testing.T, databaseURL, schema string) string {
+	if !strings.Contains(databaseURL, "://") {
+		return databaseURL + " search_path=" + schema
+	}
+
+	parsed, err := url.Parse(databaseURL)
+	require.NoError(t, err)
+
+	query := parsed.Query()
+	query.Set("search_path", schema)
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
@@ -2,6 +2,7 @@ package testing
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"go-templ-template/internal/modules/auth/application"
@@ -10,6 +11,7 @@ import (
 	userDomain "go-templ-template/internal/modules/user/domain"
 	"go-templ-template/internal/shared/database"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -80,6 +82,8 @@ type MockUserService struct {
 	users map[string]*userDomain.User
 }
 
+var _ userApp.UserService = (*MockUserService)(nil)
+
 // NewMockUserService creates a new mock user service
 func NewMockUserService() *MockUserService {
 	return &MockUserService{
@@ -147,6 +151,15 @@ func (m *MockUserService) ChangeUserPassword(ctx context.Context, cmd *userApp.C
 	return args.Get(0).(*userDomain.User), nil
 }
 
+// ResetUserPassword mocks password reset
+func (m *MockUserService) ResetUserPassword(ctx context.Context, cmd *userApp.ResetUserPasswordCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), nil
+}
+
 // ChangeUserStatus mocks status change
 func (m *MockUserService) ChangeUserStatus(ctx context.Context, cmd *userApp.ChangeUserStatusCommand) (*userDomain.User, error) {
 	args := m.Called(ctx, cmd)
@@ -156,6 +169,33 @@ func (m *MockUserService) ChangeUserStatus(ctx context.Context, cmd *userApp.Cha
 	return args.Get(0).(*userDomain.User), nil
 }
 
+// LockUser mocks user locking
+func (m *MockUserService) LockUser(ctx context.Context, cmd *userApp.LockUserCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), nil
+}
+
+// UnlockUser mocks user unlocking
+func (m *MockUserService) UnlockUser(ctx context.Context, cmd *userApp.UnlockUserCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), nil
+}
+
+// RecordLogin mocks recording a login
+func (m *MockUserService) RecordLogin(ctx context.Context, cmd *userApp.RecordLoginCommand) (*userDomain.User, error) {
+	args := m.Called(ctx, cmd)
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), nil
+}
+
 // ListUsers mocks user listing
 func (m *MockUserService) ListUsers(ctx context.Context, query *userApp.ListUsersQuery) ([]*userDomain.User, int64, error) {
 	args := m.Called(ctx, query)
@@ -167,36 +207,121 @@ func (m *MockUserService) ListUsers(ctx context.Context, query *userApp.ListUser
 
 // Test Data Factories
 
-// CreateTestUser creates a test user with default values
-func CreateTestUser(id, email string) *userDomain.User {
-	user, _ := userDomain.NewUser(id, email, "Password123", "Test", "User")
+// UserOption customizes a user built by NewUserFixture
+type UserOption func(user *userDomain.User)
+
+// WithID sets the user's ID
+func WithID(id string) UserOption {
+	return func(user *userDomain.User) {
+		user.ID = id
+	}
+}
+
+// WithEmail sets the user's email, normalized as NewUser does
+func WithEmail(email string) UserOption {
+	return func(user *userDomain.User) {
+		user.Email = strings.ToLower(strings.TrimSpace(email))
+	}
+}
+
+// WithName sets the user's first and last name
+func WithName(firstName, lastName string) UserOption {
+	return func(user *userDomain.User) {
+		user.FirstName = firstName
+		user.LastName = lastName
+	}
+}
+
+// WithStatus sets the user's status
+func WithStatus(status userDomain.UserStatus) UserOption {
+	return func(user *userDomain.User) {
+		user.Status = status
+	}
+}
+
+// WithRole sets the user's role
+func WithRole(role userDomain.UserRole) UserOption {
+	return func(user *userDomain.User) {
+		user.Role = role
+	}
+}
+
+// NewUserFixture creates an active test user with a random ID, the email
+// test@example.com and the password Password123, customized by opts
+func NewUserFixture(opts ...UserOption) *userDomain.User {
+	user, _ := userDomain.NewUser(uuid.New().String(), "test@example.com", "Password123", "Test", "User")
+	for _, opt := range opts {
+		opt(user)
+	}
 	return user
 }
 
-// CreateTestSession creates a test session with default values
-func CreateTestSession(userID string) *domain.Session {
+// SessionOption customizes a session built by NewSessionFixture
+type SessionOption func(session *domain.Session)
+
+// WithUserID sets the ID of the session's user
+func WithUserID(userID string) SessionOption {
+	return func(session *domain.Session) {
+		session.UserID = userID
+	}
+}
+
+// WithClient sets the IP address and user agent the session was created from
+func WithClient(ipAddress, userAgent string) SessionOption {
+	return func(session *domain.Session) {
+		session.IPAddress = ipAddress
+		session.UserAgent = userAgent
+	}
+}
+
+// ExpiredAt sets when the session expires; a time in the past makes it expired
+func ExpiredAt(expiresAt time.Time) SessionOption {
+	return func(session *domain.Session) {
+		session.ExpiresAt = expiresAt
+	}
+}
+
+// Inactive marks the session as inactive, as after logout
+func Inactive() SessionOption {
+	return func(session *domain.Session) {
+		session.IsActive = false
+	}
+}
+
+// NewSessionFixture creates an active test session lasting 24 hours for a
+// random user, customized by opts
+func NewSessionFixture(opts ...SessionOption) *domain.Session {
 	config := domain.SessionConfig{
 		DefaultDuration: time.Hour * 24,
 		MaxDuration:     time.Hour * 24 * 7,
 		CleanupInterval: time.Hour,
 	}
 
-	session, _ := domain.NewSession(userID, "127.0.0.1", "test-agent", config)
+	session, _ := domain.NewSession(uuid.New().String(), "127.0.0.1", "test-agent", config)
+	for _, opt := range opts {
+		opt(session)
+	}
 	return session
 }
 
+// CreateTestUser creates a test user with default values
+func CreateTestUser(id, email string) *userDomain.User {
+	return NewUserFixture(WithID(id), WithEmail(email))
+}
+
+// CreateTestSession creates a test session with default values
+func CreateTestSession(userID string) *domain.Session {
+	return NewSessionFixture(WithUserID(userID))
+}
+
 // CreateExpiredTestSession creates an expired test session
 func CreateExpiredTestSession(userID string) *domain.Session {
-	session := CreateTestSession(userID)
-	session.ExpiresAt = time.Now().Add(-time.Hour) // Expired 1 hour ago
-	return session
+	return NewSessionFixture(WithUserID(userID), ExpiredAt(time.Now().Add(-time.Hour))) // Expired 1 hour ago
 }
 
 // CreateInactiveTestSession creates an inactive test session
 func CreateInactiveTestSession(userID string) *domain.Session {
-	session := CreateTestSession(userID)
-	session.IsActive = false
-	return session
+	return NewSessionFixture(WithUserID(userID), Inactive())
 }
 
 // NewMockUserRepository creates a new mock user repository with optional test data
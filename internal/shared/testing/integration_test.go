@@ -28,6 +28,13 @@ func TestFullAuthFlow_WithAllMocks(t *testing.T) {
 		Email: "test@example.com",
 	}).Return(testUser, nil)
 
+	// Setup UserService mock behavior for recording the login
+	mocks.UserService.On("RecordLogin", mock.Anything, mock.AnythingOfType("*application.RecordLoginCommand")).
+		Run(func(args mock.Arguments) {
+			cmd := args.Get(1).(*userApp.RecordLoginCommand)
+			assert.NoError(t, testUser.RecordLogin(cmd.LoginAt, cmd.IPAddress))
+		}).Return(testUser, nil)
+
 	// Setup SessionRepository mock behavior for Create
 	mocks.SessionRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil)
 
@@ -52,6 +59,12 @@ func TestFullAuthFlow_WithAllMocks(t *testing.T) {
 	assert.NotEmpty(t, result.Session.ID)
 	assert.Equal(t, testUser.ID, result.User.ID)
 
+	// Verify the login was recorded on the user
+	if assert.NotNil(t, result.User.LastLoginAt) {
+		assert.WithinDuration(t, time.Now(), *result.User.LastLoginAt, time.Minute)
+	}
+	assert.Equal(t, "127.0.0.1", result.User.LastLoginIP)
+
 	// Verify all mocks were called as expected
 	mocks.UserService.AssertExpectations(t)
 	mocks.SessionRepo.AssertExpectations(t)
@@ -62,6 +75,13 @@ func TestFullAuthFlow_WithAllMocks(t *testing.T) {
 	publishedEvents := mocks.EventBus.GetPublishedEvents()
 	assert.Len(t, publishedEvents, 1)
 	assert.Equal(t, "auth.user.logged_in", publishedEvents[0].EventType())
+
+	// Verify the event carries the login's IP address and time
+	loggedIn, ok := publishedEvents[0].(*domain.UserLoggedInEvent)
+	if assert.True(t, ok) && result.User.LastLoginAt != nil {
+		assert.Equal(t, "127.0.0.1", loggedIn.IPAddress)
+		assert.True(t, loggedIn.LoginAt.Equal(*result.User.LastLoginAt))
+	}
 }
 
 // TestUserRepository_CRUD_WithMocks demonstrates CRUD operations using MockUserRepository
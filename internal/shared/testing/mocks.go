@@ -3,7 +3,9 @@ package testing
 import (
 	"context"
 	"sync"
+	"time"
 
+	authApp "go-templ-template/internal/modules/auth/application"
 	"go-templ-template/internal/modules/auth/domain"
 	userDomain "go-templ-template/internal/modules/user/domain"
 	userInfra "go-templ-template/internal/modules/user/infrastructure"
@@ -21,6 +23,8 @@ type MockSessionRepository struct {
 	mu       sync.RWMutex
 }
 
+var _ authApp.SessionRepository = (*MockSessionRepository)(nil)
+
 // NewMockSessionRepository creates a new mock session repository
 func NewMockSessionRepository() *MockSessionRepository {
 	return &MockSessionRepository{
@@ -28,15 +32,22 @@ func NewMockSessionRepository() *MockSessionRepository {
 	}
 }
 
-// Create mocks session creation
+// Create mocks session creation, failing with database.ErrDuplicateKey like
+// the real repository when the session ID is already stored
 func (m *MockSessionRepository) Create(ctx context.Context, session *domain.Session) error {
 	args := m.Called(ctx, session)
-	if args.Error(0) == nil {
-		m.mu.Lock()
-		m.sessions[session.ID] = session
-		m.mu.Unlock()
+	if args.Error(0) != nil {
+		return args.Error(0)
 	}
-	return args.Error(0)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sessions[session.ID]; exists {
+		return database.ErrDuplicateKey
+	}
+	m.sessions[session.ID] = session
+	return nil
 }
 
 // GetByID mocks session retrieval by ID
@@ -93,28 +104,61 @@ func (m *MockSessionRepository) GetByUserID(ctx context.Context, userID string)
 	return userSessions, nil
 }
 
-// Update mocks session update
+// Update mocks session update, failing with database.ErrNotFound like the
+// real repository when the session isn't stored
 func (m *MockSessionRepository) Update(ctx context.Context, session *domain.Session) error {
 	args := m.Called(ctx, session)
-	if args.Error(0) == nil {
-		m.mu.Lock()
-		if _, exists := m.sessions[session.ID]; exists {
-			m.sessions[session.ID] = session
-		}
-		m.mu.Unlock()
+	if args.Error(0) != nil {
+		return args.Error(0)
 	}
-	return args.Error(0)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sessions[session.ID]; !exists {
+		return database.ErrNotFound
+	}
+	m.sessions[session.ID] = session
+	return nil
 }
 
-// Delete mocks session deletion by ID
+// UpdateActivity mocks recording session activity, failing with
+// database.ErrNotFound like the real repository when the session isn't stored
+// or is no longer active
+func (m *MockSessionRepository) UpdateActivity(ctx context.Context, session *domain.Session) error {
+	args := m.Called(ctx, session)
+	if args.Error(0) != nil {
+		return args.Error(0)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, exists := m.sessions[session.ID]
+	if !exists || !stored.IsActive {
+		return database.ErrNotFound
+	}
+	stored.LastActivityAt = session.LastActivityAt
+	stored.ExpiresAt = session.ExpiresAt
+	return nil
+}
+
+// Delete mocks session deletion by ID, failing with database.ErrNotFound
+// like the real repository when the session isn't stored
 func (m *MockSessionRepository) Delete(ctx context.Context, sessionID string) error {
 	args := m.Called(ctx, sessionID)
-	if args.Error(0) == nil {
-		m.mu.Lock()
-		delete(m.sessions, sessionID)
-		m.mu.Unlock()
+	if args.Error(0) != nil {
+		return args.Error(0)
 	}
-	return args.Error(0)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.sessions[sessionID]; !exists {
+		return database.ErrNotFound
+	}
+	delete(m.sessions, sessionID)
+	return nil
 }
 
 // DeleteByUserID mocks session deletion by user ID
@@ -169,6 +213,8 @@ type MockEventBus struct {
 	mu              sync.RWMutex
 }
 
+var _ events.EventBus = (*MockEventBus)(nil)
+
 // NewMockEventBus creates a new mock event bus
 func NewMockEventBus() *MockEventBus {
 	return &MockEventBus{
@@ -240,6 +286,96 @@ func (m *MockEventBus) ClearPublishedEvents() {
 	m.mu.Unlock()
 }
 
+// MockEventHandler provides a mock implementation of EventHandler that
+// records the events it receives, for testing code that dispatches events
+type MockEventHandler struct {
+	handlerName string
+	eventType   string
+
+	mu       sync.Mutex
+	err      error
+	received []events.DomainEvent
+	changed  chan struct{}
+}
+
+var _ events.EventHandler = (*MockEventHandler)(nil)
+
+// NewMockEventHandler creates a new mock event handler
+func NewMockEventHandler(handlerName, eventType string) *MockEventHandler {
+	return &MockEventHandler{
+		handlerName: handlerName,
+		eventType:   eventType,
+		received:    make([]events.DomainEvent, 0),
+		changed:     make(chan struct{}),
+	}
+}
+
+// Handle records the event and returns the configured error
+func (m *MockEventHandler) Handle(ctx context.Context, event events.DomainEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.received = append(m.received, event)
+
+	// Wake up WaitForEvents callers
+	close(m.changed)
+	m.changed = make(chan struct{})
+
+	return m.err
+}
+
+// EventType returns the type of event this handler processes
+func (m *MockEventHandler) EventType() string {
+	return m.eventType
+}
+
+// HandlerName returns the name of this handler
+func (m *MockEventHandler) HandlerName() string {
+	return m.handlerName
+}
+
+// SetError sets the error Handle returns; nil makes it succeed again
+func (m *MockEventHandler) SetError(err error) {
+	m.mu.Lock()
+	m.err = err
+	m.mu.Unlock()
+}
+
+// ReceivedEvents returns all received events for testing verification,
+// including those Handle returned an error for
+func (m *MockEventHandler) ReceivedEvents() []events.DomainEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	events := make([]events.DomainEvent, len(m.received))
+	copy(events, m.received)
+	return events
+}
+
+// WaitForEvents waits until at least n events have been received, reporting
+// false if timeout passes first
+func (m *MockEventHandler) WaitForEvents(n int, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		m.mu.Lock()
+		received := len(m.received)
+		changed := m.changed
+		m.mu.Unlock()
+
+		if received >= n {
+			return true
+		}
+
+		select {
+		case <-changed:
+		case <-timer.C:
+			return false
+		}
+	}
+}
+
 // MockRateLimiter provides a mock implementation of RateLimiter for testing
 type MockRateLimiter struct {
 	mock.Mock
@@ -247,6 +383,8 @@ type MockRateLimiter struct {
 	mu       sync.RWMutex
 }
 
+var _ authApp.RateLimiter = (*MockRateLimiter)(nil)
+
 // NewMockRateLimiter creates a new mock rate limiter
 func NewMockRateLimiter() *MockRateLimiter {
 	return &MockRateLimiter{
@@ -304,6 +442,8 @@ type MockUserRepository struct {
 	mu    sync.RWMutex
 }
 
+var _ userInfra.UserRepository = (*MockUserRepository)(nil)
+
 // NewMockUserRepository creates a new mock user repository
 func NewMockUserRepository() *MockUserRepository {
 	return &MockUserRepository{
@@ -322,6 +462,19 @@ func (m *MockUserRepository) Create(ctx context.Context, user *userDomain.User)
 	return args.Error(0)
 }
 
+// CreateBatch mocks bulk user creation
+func (m *MockUserRepository) CreateBatch(ctx context.Context, users []*userDomain.User) error {
+	args := m.Called(ctx, users)
+	if args.Error(0) == nil {
+		m.mu.Lock()
+		for _, user := range users {
+			m.users[user.ID] = user
+		}
+		m.mu.Unlock()
+	}
+	return args.Error(0)
+}
+
 // GetByID mocks user retrieval by ID
 func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*userDomain.User, error) {
 	// Check if there are any expectations set for this method
@@ -444,6 +597,20 @@ func (m *MockUserRepository) List(ctx context.Context, filter userInfra.UserFilt
 	return users[start:end], nil
 }
 
+// ListByCursor mocks cursor-based user listing
+func (m *MockUserRepository) ListByCursor(ctx context.Context, filter userInfra.UserFilter, cursor string, limit int) ([]*userDomain.User, string, error) {
+	args := m.Called(ctx, filter, cursor, limit)
+	if args.Error(2) != nil {
+		return nil, "", args.Error(2)
+	}
+
+	if args.Get(0) == nil {
+		return []*userDomain.User{}, "", nil
+	}
+
+	return args.Get(0).([]*userDomain.User), args.String(1), nil
+}
+
 // Count mocks user count with filtering
 func (m *MockUserRepository) Count(ctx context.Context, filter userInfra.UserFilter) (int64, error) {
 	args := m.Called(ctx, filter)
@@ -527,6 +694,8 @@ type MockAuditLogger struct {
 	mu     sync.RWMutex
 }
 
+var _ audit.AuditLogger = (*MockAuditLogger)(nil)
+
 // NewMockAuditLogger creates a new mock audit logger
 func NewMockAuditLogger() *MockAuditLogger {
 	return &MockAuditLogger{
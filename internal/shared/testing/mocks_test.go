@@ -2,6 +2,7 @@ package testing
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -231,6 +232,72 @@ func TestMockEventBus_ClearPublishedEvents(t *testing.T) {
 	assert.Len(t, mockBus.GetPublishedEvents(), 0)
 }
 
+func TestMockEventHandler_RecordsEvents(t *testing.T) {
+	handler := NewMockEventHandler("test-handler", "auth.user_logged_in")
+	ctx := context.Background()
+
+	first := domain.NewUserLoggedInEvent("user-123", "session-456", "127.0.0.1", "test-agent")
+	second := domain.NewUserLoggedInEvent("user-789", "session-012", "127.0.0.1", "test-agent")
+
+	// Execute
+	assert.NoError(t, handler.Handle(ctx, first))
+	assert.NoError(t, handler.Handle(ctx, second))
+
+	// Verify
+	assert.Equal(t, "test-handler", handler.HandlerName())
+	assert.Equal(t, "auth.user_logged_in", handler.EventType())
+
+	received := handler.ReceivedEvents()
+	assert.Len(t, received, 2)
+	assert.Equal(t, first.EventID(), received[0].EventID())
+	assert.Equal(t, second.EventID(), received[1].EventID())
+}
+
+func TestMockEventHandler_SetError(t *testing.T) {
+	handler := NewMockEventHandler("test-handler", "auth.user_logged_in")
+	ctx := context.Background()
+	event := domain.NewUserLoggedInEvent("user-123", "session-456", "127.0.0.1", "test-agent")
+
+	// Execute with an injected error
+	handlerErr := errors.New("handler failed")
+	handler.SetError(handlerErr)
+	assert.Equal(t, handlerErr, handler.Handle(ctx, event))
+
+	// Clearing the error makes the handler succeed again
+	handler.SetError(nil)
+	assert.NoError(t, handler.Handle(ctx, event))
+
+	// Failed events are recorded too
+	assert.Len(t, handler.ReceivedEvents(), 2)
+}
+
+func TestMockEventHandler_WaitForEvents(t *testing.T) {
+	handler := NewMockEventHandler("test-handler", "auth.user_logged_in")
+	ctx := context.Background()
+	event := domain.NewUserLoggedInEvent("user-123", "session-456", "127.0.0.1", "test-agent")
+
+	// Events delivered asynchronously
+	go func() {
+		for i := 0; i < 3; i++ {
+			time.Sleep(10 * time.Millisecond)
+			_ = handler.Handle(ctx, event)
+		}
+	}()
+
+	start := time.Now()
+	assert.True(t, handler.WaitForEvents(3, 5*time.Second))
+	assert.Less(t, time.Since(start), time.Second, "WaitForEvents should return once the count is reached")
+	assert.Len(t, handler.ReceivedEvents(), 3)
+
+	// Already reached counts return immediately
+	assert.True(t, handler.WaitForEvents(2, 0))
+
+	// Counts never reached time out
+	start = time.Now()
+	assert.False(t, handler.WaitForEvents(4, 50*time.Millisecond))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
 func TestMockRateLimiter_Allow(t *testing.T) {
 	mockLimiter := NewMockRateLimiter()
 	ctx := context.Background()
@@ -337,6 +404,68 @@ func TestCreateInactiveTestSession(t *testing.T) {
 	assert.False(t, session.IsValid())
 }
 
+func TestNewUserFixture(t *testing.T) {
+	user := NewUserFixture()
+
+	assert.NotEmpty(t, user.ID)
+	assert.Equal(t, "test@example.com", user.Email)
+	assert.Equal(t, userDomain.UserRoleUser, user.Role)
+	assert.True(t, user.IsActive())
+	assert.NoError(t, user.Validate())
+}
+
+func TestNewUserFixture_SuspendedAdmin(t *testing.T) {
+	user := NewUserFixture(
+		WithID("user-123"),
+		WithEmail(" Suspended@Example.com "),
+		WithName("Jane", "Doe"),
+		WithStatus(userDomain.UserStatusSuspended),
+		WithRole(userDomain.UserRoleAdmin),
+	)
+
+	assert.Equal(t, "user-123", user.ID)
+	assert.Equal(t, "suspended@example.com", user.Email)
+	assert.Equal(t, "Jane", user.FirstName)
+	assert.Equal(t, "Doe", user.LastName)
+	assert.Equal(t, userDomain.UserStatusSuspended, user.Status)
+	assert.Equal(t, userDomain.UserRoleAdmin, user.Role)
+	assert.False(t, user.IsActive())
+	assert.NoError(t, user.Validate())
+}
+
+func TestNewSessionFixture(t *testing.T) {
+	session := NewSessionFixture()
+
+	assert.NotEmpty(t, session.UserID)
+	assert.True(t, session.IsActive)
+	assert.True(t, session.IsValid())
+}
+
+func TestNewSessionFixture_Expired(t *testing.T) {
+	expiresAt := time.Now().Add(-30 * time.Minute)
+	session := NewSessionFixture(
+		WithUserID("user-123"),
+		WithClient("10.0.0.1", "curl/8.0"),
+		ExpiredAt(expiresAt),
+	)
+
+	assert.Equal(t, "user-123", session.UserID)
+	assert.Equal(t, "10.0.0.1", session.IPAddress)
+	assert.Equal(t, "curl/8.0", session.UserAgent)
+	assert.Equal(t, expiresAt, session.ExpiresAt)
+	assert.True(t, session.IsActive)
+	assert.True(t, session.IsExpired())
+	assert.False(t, session.IsValid())
+}
+
+func TestNewSessionFixture_Inactive(t *testing.T) {
+	session := NewSessionFixture(Inactive())
+
+	assert.False(t, session.IsActive)
+	assert.False(t, session.IsExpired())
+	assert.False(t, session.IsValid())
+}
+
 func TestMockUserRepository_Create(t *testing.T) {
 	mockRepo := NewMockUserRepository()
 	ctx := context.Background()
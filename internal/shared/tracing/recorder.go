@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+)
+
+// Recorder is a Tracer that keeps spans in memory, for tests asserting the
+// spans code creates
+type Recorder struct {
+	mu    sync.Mutex
+	ended []*RecordedSpan
+}
+
+// NewRecorder creates an empty span recorder
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Start implements Tracer
+func (r *Recorder) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &RecordedSpan{
+		Name:       name,
+		Attributes: make(map[string]interface{}),
+		recorder:   r,
+	}
+
+	if parent, ok := ParentFromContext(ctx); ok {
+		span.Parent = parent
+		span.Context = SpanContext{TraceID: parent.TraceID, SpanID: newID(8)}
+	} else {
+		span.Context = SpanContext{TraceID: newID(16), SpanID: newID(8)}
+	}
+
+	return ContextWithSpan(ctx, span), span
+}
+
+// Ended returns the spans ended so far, in the order they ended
+func (r *Recorder) Ended() []*RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	spans := make([]*RecordedSpan, len(r.ended))
+	copy(spans, r.ended)
+	return spans
+}
+
+// Find returns the first ended span named name, or nil
+func (r *Recorder) Find(name string) *RecordedSpan {
+	for _, span := range r.Ended() {
+		if span.Name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+// RecordedSpan is a span started by a Recorder
+type RecordedSpan struct {
+	Name       string
+	Context    SpanContext
+	Parent     SpanContext
+	Attributes map[string]interface{}
+	Err        error
+
+	recorder *Recorder
+	mu       sync.Mutex
+	ended    bool
+}
+
+// SpanContext implements Span
+func (s *RecordedSpan) SpanContext() SpanContext {
+	return s.Context
+}
+
+// SetAttribute implements Span
+func (s *RecordedSpan) SetAttribute(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Attributes[key] = value
+}
+
+// RecordError implements Span
+func (s *RecordedSpan) RecordError(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Err = err
+}
+
+// End implements Span. Only the first call records the span.
+func (s *RecordedSpan) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.mu.Unlock()
+
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.ended = append(s.recorder.ended, s)
+}
+
+// IsChildOf reports whether s was started as a child of parent
+func (s *RecordedSpan) IsChildOf(parent *RecordedSpan) bool {
+	return parent != nil && s.Parent == parent.Context
+}
+
+// newID returns n random bytes, hex encoded
+func newID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
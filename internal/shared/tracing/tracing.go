@@ -0,0 +1,150 @@
+// Package tracing provides the seams for distributed tracing: a Tracer that
+// starts spans for HTTP requests, published and handled events, and database
+// queries. No spans are recorded until a Tracer is configured with SetTracer,
+// e.g. an adapter over an OpenTelemetry tracer provider.
+package tracing
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// SpanContext identifies a span within a trace
+type SpanContext struct {
+	// TraceID is the 32 hex character ID shared by all spans in a trace
+	TraceID string
+
+	// SpanID is the 16 hex character ID of the span
+	SpanID string
+}
+
+// IsValid reports whether the span context names a trace
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != ""
+}
+
+// Span is a unit of work within a trace
+type Span interface {
+	// SpanContext returns the span's trace and span IDs
+	SpanContext() SpanContext
+
+	// SetAttribute records a key/value pair describing the work
+	SetAttribute(key string, value interface{})
+
+	// RecordError records that the work failed with err
+	RecordError(err error)
+
+	// End completes the span
+	End()
+}
+
+// Tracer starts spans
+type Tracer interface {
+	// Start starts a span named name, the child of the span carried by ctx
+	// or, failing that, of the remote parent carried by ctx. It returns a
+	// copy of ctx carrying the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+var (
+	globalTracer    Tracer = noopTracer{}
+	globalTracerMux sync.RWMutex
+)
+
+// SetTracer configures the tracer used by Start. A nil tracer disables
+// tracing.
+func SetTracer(tracer Tracer) {
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+
+	globalTracerMux.Lock()
+	defer globalTracerMux.Unlock()
+	globalTracer = tracer
+}
+
+// GetTracer returns the configured tracer
+func GetTracer() Tracer {
+	globalTracerMux.RLock()
+	defer globalTracerMux.RUnlock()
+	return globalTracer
+}
+
+// Start starts a span with the configured tracer. When no tracer is
+// configured it returns ctx unchanged and a span that does nothing.
+func Start(ctx context.Context, name string) (context.Context, Span) {
+	return GetTracer().Start(ctx, name)
+}
+
+// spanKey is the context key for the current span
+type spanKey struct{}
+
+// remoteParentKey is the context key for a span started in another process
+type remoteParentKey struct{}
+
+// ContextWithSpan returns a copy of ctx carrying span as the current span
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the current span carried by ctx, or a span that
+// does nothing if there is none
+func SpanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span
+	}
+	return noopSpan{}
+}
+
+// ContextWithRemoteParent returns a copy of ctx carrying parent, a span
+// started in another process, e.g. from a traceparent header or an event's
+// trace ID, for new spans to continue
+func ContextWithRemoteParent(ctx context.Context, parent SpanContext) context.Context {
+	if !parent.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteParentKey{}, parent)
+}
+
+// ParentFromContext returns the span context new spans started with ctx
+// should be children of: the current span's, or else the remote parent's
+func ParentFromContext(ctx context.Context) (SpanContext, bool) {
+	if sc := SpanFromContext(ctx).SpanContext(); sc.IsValid() {
+		return sc, true
+	}
+	parent, ok := ctx.Value(remoteParentKey{}).(SpanContext)
+	return parent, ok
+}
+
+// ParseTraceParent returns the span context of a W3C traceparent header
+// value, formatted version-traceid-parentid-flags. It returns an invalid
+// span context if the value is malformed.
+func ParseTraceParent(traceParent string) SpanContext {
+	parts := strings.Split(traceParent, "-")
+	if len(parts) != 4 || len(parts[1]) != 32 || strings.Trim(parts[1], "0") == "" {
+		return SpanContext{}
+	}
+
+	sc := SpanContext{TraceID: parts[1]}
+	if len(parts[2]) == 16 && strings.Trim(parts[2], "0") != "" {
+		sc.SpanID = parts[2]
+	}
+	return sc
+}
+
+// noopTracer is the tracer used until one is configured
+type noopTracer struct{}
+
+// Start implements Tracer
+func (noopTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// noopSpan is a span that records nothing
+type noopSpan struct{}
+
+func (noopSpan) SpanContext() SpanContext                   { return SpanContext{} }
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) RecordError(err error)                      {}
+func (noopSpan) End()                                       {}
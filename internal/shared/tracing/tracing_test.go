@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useRecorder configures a Recorder as the tracer for the rest of the test
+func useRecorder(t *testing.T) *Recorder {
+	t.Helper()
+
+	recorder := NewRecorder()
+	SetTracer(recorder)
+	t.Cleanup(func() { SetTracer(nil) })
+	return recorder
+}
+
+func TestStart_NoTracerConfigured(t *testing.T) {
+	ctx := context.Background()
+
+	spanCtx, span := Start(ctx, "work")
+	span.SetAttribute("key", "value")
+	span.RecordError(errors.New("boom"))
+	span.End()
+
+	assert.Equal(t, ctx, spanCtx)
+	assert.False(t, span.SpanContext().IsValid())
+	assert.False(t, SpanFromContext(spanCtx).SpanContext().IsValid())
+}
+
+func TestRecorder_ChildSpansShareTrace(t *testing.T) {
+	recorder := useRecorder(t)
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.True(t, spans[0].IsChildOf(spans[1]))
+	assert.Equal(t, spans[1].Context.TraceID, spans[0].Context.TraceID)
+	assert.Len(t, spans[1].Context.TraceID, 32)
+	assert.Len(t, spans[1].Context.SpanID, 16)
+	assert.False(t, spans[1].Parent.IsValid())
+}
+
+func TestRecorder_ContinuesRemoteParent(t *testing.T) {
+	recorder := useRecorder(t)
+	remote := SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"}
+
+	_, span := Start(ContextWithRemoteParent(context.Background(), remote), "work")
+	span.End()
+
+	recorded := recorder.Find("work")
+	require.NotNil(t, recorded)
+	assert.Equal(t, remote, recorded.Parent)
+	assert.Equal(t, remote.TraceID, recorded.Context.TraceID)
+}
+
+func TestRecordedSpan_RecordsAttributesAndError(t *testing.T) {
+	recorder := useRecorder(t)
+	err := errors.New("boom")
+
+	_, span := Start(context.Background(), "work")
+	span.SetAttribute("key", "value")
+	span.RecordError(err)
+	span.End()
+	span.End()
+
+	require.Len(t, recorder.Ended(), 1)
+	recorded := recorder.Find("work")
+	assert.Equal(t, "value", recorded.Attributes["key"])
+	assert.Equal(t, err, recorded.Err)
+}
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceParent string
+		expected    SpanContext
+	}{
+		{
+			name:        "valid",
+			traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			expected:    SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"},
+		},
+		{
+			name:        "zero parent ID",
+			traceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+			expected:    SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		},
+		{
+			name:        "zero trace ID",
+			traceParent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		},
+		{
+			name:        "malformed",
+			traceParent: "not-a-traceparent",
+		},
+		{
+			name: "empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseTraceParent(tt.traceParent))
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Package validation checks bound request structs against their validate
+// struct tags, reporting every invalid field as an *errors.ErrorList the
+// error middleware renders with field paths.
+package validation
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"go-templ-template/internal/shared/errors"
+)
+
+// Rule checks value against a rule's param, e.g. "8" for min=8, returning
+// a validation error for field or nil when the value is valid. Rules are
+// only run on non-empty values, except required.
+type Rule func(field, value, param string) *errors.AppError
+
+// Validator validates structs against their validate tags. The supported
+// rules are required, email, min and max, plus those added with WithRule.
+// Only string fields are validated.
+type Validator struct {
+	rules map[string]Rule
+}
+
+// New creates a validator with the built-in rules
+func New() *Validator {
+	return &Validator{
+		rules: map[string]Rule{
+			"email": email,
+			"min":   minLength,
+			"max":   maxLength,
+		},
+	}
+}
+
+// WithRule adds a rule for the tag name, replacing any rule of that name
+func (v *Validator) WithRule(name string, rule Rule) *Validator {
+	v.rules[name] = rule
+	return v
+}
+
+// Struct validates the struct s points to. Each invalid field is reported
+// once, with the first rule it breaks, as a validation error whose
+// "field" detail is the field's JSON name; nil means s is valid.
+func (v *Validator) Struct(s interface{}) error {
+	value := reflect.Indirect(reflect.ValueOf(s))
+	if value.Kind() != reflect.Struct {
+		return fmt.Errorf("validation: %T is not a struct", s)
+	}
+
+	problems := &errors.ErrorList{}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		tag := field.Tag.Get("validate")
+		if tag == "" || field.Type.Kind() != reflect.String || !field.IsExported() {
+			continue
+		}
+
+		if problem := v.field(fieldName(field), value.Field(i).String(), tag); problem != nil {
+			problems.Add(problem)
+		}
+	}
+
+	if problems.HasErrors() {
+		return problems
+	}
+	return nil
+}
+
+// field returns the error for the first rule of tag that value breaks
+func (v *Validator) field(name, value, tag string) *errors.AppError {
+	for _, rule := range strings.Split(tag, ",") {
+		ruleName, param, _ := strings.Cut(rule, "=")
+
+		if ruleName == "required" {
+			if value == "" {
+				return Invalid("FIELD_REQUIRED", name, humanize(name)+" is required")
+			}
+			continue
+		}
+		if value == "" {
+			continue
+		}
+
+		check, ok := v.rules[ruleName]
+		if !ok {
+			// Tags of other validators, such as omitempty, are ignored
+			continue
+		}
+		if problem := check(name, value, param); problem != nil {
+			return problem
+		}
+	}
+	return nil
+}
+
+// Invalid creates the validation error of a rule: code and message with
+// field as its "field" detail
+func Invalid(code, field, message string) *errors.AppError {
+	return errors.NewValidationErrorWithDetails(code, message, map[string]interface{}{
+		"field": field,
+	})
+}
+
+func email(field, value, param string) *errors.AppError {
+	if _, err := mail.ParseAddress(value); err != nil {
+		return Invalid("INVALID_EMAIL", field, humanize(field)+" must be a valid email address")
+	}
+	return nil
+}
+
+func minLength(field, value, param string) *errors.AppError {
+	min, err := strconv.Atoi(param)
+	if err == nil && utf8.RuneCountInString(value) < min {
+		return Invalid("FIELD_TOO_SHORT", field,
+			fmt.Sprintf("%s must be at least %d characters long", humanize(field), min))
+	}
+	return nil
+}
+
+func maxLength(field, value, param string) *errors.AppError {
+	max, err := strconv.Atoi(param)
+	if err == nil && utf8.RuneCountInString(value) > max {
+		return Invalid("FIELD_TOO_LONG", field,
+			fmt.Sprintf("%s cannot exceed %d characters", humanize(field), max))
+	}
+	return nil
+}
+
+// fieldName returns the name a field is bound from: its JSON name, or its
+// Go name without a json tag
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// humanize turns a field name such as first_name into "first name" for
+// messages
+func humanize(field string) string {
+	return strings.ReplaceAll(field, "_", " ")
+}
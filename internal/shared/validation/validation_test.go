@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"testing"
+
+	"go-templ-template/internal/shared/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type signupRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=16"`
+	Nickname string `json:"nickname,omitempty" validate:"max=5,lowercase"`
+	Note     string
+	Age      int `json:"age" validate:"required"`
+}
+
+func fieldCodes(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	list, ok := errors.AsErrorList(err)
+	require.True(t, ok, "expected an *errors.ErrorList, got %T", err)
+
+	codes := make(map[string]string)
+	for _, appErr := range list.Errors {
+		assert.Equal(t, errors.ErrorTypeValidation, appErr.Type)
+		field, _ := appErr.Details["field"].(string)
+		codes[field] = appErr.Code
+	}
+	return codes
+}
+
+func TestValidator_Struct(t *testing.T) {
+	tests := []struct {
+		name string
+		req  signupRequest
+		want map[string]string
+	}{
+		{
+			name: "valid",
+			req:  signupRequest{Email: "jane@example.com", Password: "correct-horse"},
+		},
+		{
+			name: "required fields",
+			req:  signupRequest{},
+			want: map[string]string{"email": "FIELD_REQUIRED", "password": "FIELD_REQUIRED"},
+		},
+		{
+			name: "one error per field",
+			req:  signupRequest{Email: "jane", Password: "short"},
+			want: map[string]string{"email": "INVALID_EMAIL", "password": "FIELD_TOO_SHORT"},
+		},
+		{
+			name: "optional field checked when set",
+			req:  signupRequest{Email: "jane@example.com", Password: "a-much-too-long-password", Nickname: "janedoe"},
+			want: map[string]string{"password": "FIELD_TOO_LONG", "nickname": "FIELD_TOO_LONG"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New().Struct(&tt.req)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.want, fieldCodes(t, err))
+		})
+	}
+}
+
+func TestValidator_WithRule(t *testing.T) {
+	validator := New().WithRule("lowercase", func(field, value, param string) *errors.AppError {
+		for _, r := range value {
+			if r >= 'A' && r <= 'Z' {
+				return Invalid("NOT_LOWERCASE", field, field+" must be lowercase")
+			}
+		}
+		return nil
+	})
+
+	err := validator.Struct(&signupRequest{Email: "jane@example.com", Password: "correct-horse", Nickname: "Jane"})
+	assert.Equal(t, map[string]string{"nickname": "NOT_LOWERCASE"}, fieldCodes(t, err))
+}
+
+func TestValidator_Messages(t *testing.T) {
+	err := New().Struct(&struct {
+		FirstName string `json:"first_name" validate:"required"`
+	}{})
+
+	list, ok := errors.AsErrorList(err)
+	require.True(t, ok)
+	require.Len(t, list.Errors, 1)
+	assert.Equal(t, "first name is required", list.Errors[0].Message)
+}
+
+func TestValidator_StructRejectsNonStructs(t *testing.T) {
+	value := "not a struct"
+	assert.Error(t, New().Struct(&value))
+}
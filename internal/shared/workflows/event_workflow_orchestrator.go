@@ -147,7 +147,7 @@ func (o *EventWorkflowOrchestrator) registerSessionCleanupHandlers(ctx context.C
 		o.logger,
 		auditLogger,
 	)
-	if err := o.eventBus.Subscribe("user.status_changed", statusChangeHandler); err != nil {
+	if err := o.subscribe("user.status_changed", statusChangeHandler); err != nil {
 		return fmt.Errorf("failed to subscribe to user.status_changed: %w", err)
 	}
 
@@ -158,7 +158,7 @@ func (o *EventWorkflowOrchestrator) registerSessionCleanupHandlers(ctx context.C
 		o.logger,
 		auditLogger,
 	)
-	if err := o.eventBus.Subscribe("user.deleted", deletedHandler); err != nil {
+	if err := o.subscribe("user.deleted", deletedHandler); err != nil {
 		return fmt.Errorf("failed to subscribe to user.deleted: %w", err)
 	}
 
@@ -169,7 +169,7 @@ func (o *EventWorkflowOrchestrator) registerSessionCleanupHandlers(ctx context.C
 		o.logger,
 		auditLogger,
 	)
-	if err := o.eventBus.Subscribe("user.deactivated", deactivatedHandler); err != nil {
+	if err := o.subscribe("user.deactivated", deactivatedHandler); err != nil {
 		return fmt.Errorf("failed to subscribe to user.deactivated: %w", err)
 	}
 
@@ -186,7 +186,7 @@ func (o *EventWorkflowOrchestrator) registerActivationWorkflowHandlers(ctx conte
 		o.activationService,
 		o.logger,
 	)
-	if err := o.eventBus.Subscribe("user.activation_requested", activationNotificationHandler); err != nil {
+	if err := o.subscribe("user.activation_requested", activationNotificationHandler); err != nil {
 		return fmt.Errorf("failed to subscribe to user.activation_requested: %w", err)
 	}
 
@@ -195,10 +195,10 @@ func (o *EventWorkflowOrchestrator) registerActivationWorkflowHandlers(ctx conte
 		o.activationService,
 		o.logger,
 	)
-	if err := o.eventBus.Subscribe("user.activated", tokenCleanupHandler); err != nil {
+	if err := o.subscribe("user.activated", tokenCleanupHandler); err != nil {
 		return fmt.Errorf("failed to subscribe to user.activated: %w", err)
 	}
-	if err := o.eventBus.Subscribe("user.activation_token_expired", tokenCleanupHandler); err != nil {
+	if err := o.subscribe("user.activation_token_expired", tokenCleanupHandler); err != nil {
 		return fmt.Errorf("failed to subscribe to user.activation_token_expired: %w", err)
 	}
 
@@ -229,7 +229,7 @@ func (o *EventWorkflowOrchestrator) registerCrossModuleHandlers(ctx context.Cont
 	}
 
 	for _, eventType := range eventTypes {
-		if err := o.eventBus.Subscribe(eventType, lifecycleHandler); err != nil {
+		if err := o.subscribe(eventType, lifecycleHandler); err != nil {
 			return fmt.Errorf("failed to subscribe to %s: %w", eventType, err)
 		}
 	}
@@ -238,6 +238,17 @@ func (o *EventWorkflowOrchestrator) registerCrossModuleHandlers(ctx context.Cont
 	return nil
 }
 
+// subscribe registers a handler wrapped with the configured retry policy
+func (o *EventWorkflowOrchestrator) subscribe(eventType string, handler events.EventHandler) error {
+	if o.config.MaxRetries > 0 {
+		policy := events.DefaultRetryPolicy()
+		policy.MaxRetries = o.config.MaxRetries
+		policy.InitialDelay = o.config.RetryDelay
+		handler = events.NewRetryingHandler(handler, policy)
+	}
+	return o.eventBus.Subscribe(eventType, handler)
+}
+
 // Shutdown gracefully shuts down the workflow orchestrator
 func (o *EventWorkflowOrchestrator) Shutdown(ctx context.Context) error {
 	o.logger.Info("Shutting down event workflow orchestrator")
@@ -0,0 +1,4 @@
+-- Drop event_outbox table and its indexes
+DROP INDEX IF EXISTS idx_event_outbox_unsent;
+
+DROP TABLE IF EXISTS event_outbox;
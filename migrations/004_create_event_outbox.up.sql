@@ -0,0 +1,15 @@
+-- Create event_outbox table for transactional event publishing
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(255) NOT NULL UNIQUE,
+    event_type VARCHAR(255) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Index for the relay, which polls unsent events in insertion order
+CREATE INDEX IF NOT EXISTS idx_event_outbox_unsent ON event_outbox(id) WHERE sent_at IS NULL;
@@ -0,0 +1,7 @@
+-- Unlock locked users so the original constraint can be restored
+UPDATE users SET status = 'active' WHERE status = 'locked';
+
+-- Restore the original status constraint
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('active', 'inactive', 'suspended'));
@@ -0,0 +1,4 @@
+-- Allow users to be locked after repeated failed logins
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('active', 'inactive', 'suspended', 'locked'));
@@ -0,0 +1,5 @@
+-- Drop activation_tokens table and its indexes
+DROP INDEX IF EXISTS idx_activation_tokens_expires_at;
+DROP INDEX IF EXISTS idx_activation_tokens_user_id;
+
+DROP TABLE IF EXISTS activation_tokens;
@@ -0,0 +1,2 @@
+-- Store the URL of each user's uploaded avatar image
+ALTER TABLE users ADD COLUMN avatar_url VARCHAR(500) NOT NULL DEFAULT '';
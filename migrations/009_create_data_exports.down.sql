@@ -0,0 +1,4 @@
+-- Drop data_exports table and its indexes
+DROP INDEX IF EXISTS idx_data_exports_user_id;
+
+DROP TABLE IF EXISTS data_exports;
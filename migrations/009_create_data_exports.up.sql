@@ -0,0 +1,14 @@
+-- Create data_exports table tracking requests for a copy of a user's data.
+-- Bundles are stored in the blob store under blob_key once ready.
+CREATE TABLE IF NOT EXISTS data_exports (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'ready', 'failed')),
+    blob_key VARCHAR(500) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Index for listing a user's exports
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_id ON data_exports(user_id);
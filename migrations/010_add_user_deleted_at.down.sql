@@ -0,0 +1,4 @@
+-- Drop the users soft-delete column and its index
+DROP INDEX IF EXISTS idx_users_deleted_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,6 @@
+-- Soft-delete users who delete their account. Deleted users are hidden from
+-- reads but keep their row, so their email stays reserved.
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Index for excluding deleted users
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users(deleted_at);
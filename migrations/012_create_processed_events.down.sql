@@ -0,0 +1,4 @@
+-- Drop processed_events table and its indexes
+DROP INDEX IF EXISTS idx_processed_events_processed_at;
+
+DROP TABLE IF EXISTS processed_events;
@@ -0,0 +1,11 @@
+-- Create processed_events table recording events each handler has processed,
+-- so redelivered events are skipped
+CREATE TABLE IF NOT EXISTS processed_events (
+    handler_name VARCHAR(255) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (handler_name, event_id)
+);
+
+-- Index for deleting records past the retention window
+CREATE INDEX IF NOT EXISTS idx_processed_events_processed_at ON processed_events(processed_at);
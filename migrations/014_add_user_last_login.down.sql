@@ -0,0 +1,3 @@
+-- Drop the users last login columns
+ALTER TABLE users DROP COLUMN IF EXISTS last_login_ip;
+ALTER TABLE users DROP COLUMN IF EXISTS last_login_at;
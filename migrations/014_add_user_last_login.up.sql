@@ -0,0 +1,4 @@
+-- Record when and from where each user last logged in; NULL and empty until
+-- their first login
+ALTER TABLE users ADD COLUMN last_login_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN last_login_ip VARCHAR(45) NOT NULL DEFAULT '';
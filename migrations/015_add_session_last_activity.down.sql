@@ -0,0 +1,2 @@
+-- Drop the sessions last activity column
+ALTER TABLE sessions DROP COLUMN IF EXISTS last_activity_at;
@@ -0,0 +1,2 @@
+-- Track when each session was last used so its expiry can slide with activity
+ALTER TABLE sessions ADD COLUMN last_activity_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
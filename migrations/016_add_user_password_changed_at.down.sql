@@ -0,0 +1,2 @@
+-- Drop the users password changed column
+ALTER TABLE users DROP COLUMN IF EXISTS password_changed_at;
@@ -0,0 +1,3 @@
+-- Record when each user last changed their password, so tokens issued before
+-- it can be rejected; NULL until the first change
+ALTER TABLE users ADD COLUMN password_changed_at TIMESTAMP WITH TIME ZONE;
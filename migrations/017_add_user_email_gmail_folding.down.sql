@@ -0,0 +1,3 @@
+-- Drop the folded email index and function
+DROP INDEX IF EXISTS idx_users_email_gmail_folded;
+DROP FUNCTION IF EXISTS fold_gmail_alias(TEXT);
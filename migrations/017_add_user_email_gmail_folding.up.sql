@@ -0,0 +1,16 @@
+-- Fold Gmail aliases the same way the application does when
+-- auth.normalize_gmail_aliases is enabled: lowercase, and for gmail.com and
+-- googlemail.com drop dots and any +tag from the local part. Lookups compare
+-- folded addresses, so rows stored before folding was enabled still match.
+CREATE OR REPLACE FUNCTION fold_gmail_alias(email TEXT)
+RETURNS TEXT AS $$
+    SELECT CASE
+        WHEN split_part(lower(email), '@', 2) IN ('gmail.com', 'googlemail.com')
+            AND replace(split_part(split_part(lower(email), '@', 1), '+', 1), '.', '') <> ''
+        THEN replace(split_part(split_part(lower(email), '@', 1), '+', 1), '.', '') || '@gmail.com'
+        ELSE lower(email)
+    END
+$$ LANGUAGE SQL IMMUTABLE STRICT;
+
+-- Not unique: addresses that only differ by a Gmail alias may already exist
+CREATE INDEX idx_users_email_gmail_folded ON users (fold_gmail_alias(email));
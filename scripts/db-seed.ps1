@@ -141,7 +141,7 @@ function Add-Users {
     
     # Admin user
     $adminSQL = @"
-        INSERT INTO users (id, email, password, first_name, last_name, status, created_at, updated_at, version)
+        INSERT INTO users (id, email, password, first_name, last_name, status, role, created_at, updated_at, version)
         VALUES (
             'admin-user-id-1234567890',
             'admin@example.com',
@@ -149,6 +149,7 @@ function Add-Users {
             'Admin',
             'User',
             'active',
+            'admin',
             NOW(),
             NOW(),
             1
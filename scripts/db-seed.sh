@@ -112,7 +112,7 @@ seed_users() {
     
     # Admin user
     execute_sql "
-        INSERT INTO users (id, email, password, first_name, last_name, status, created_at, updated_at, version)
+        INSERT INTO users (id, email, password, first_name, last_name, status, role, created_at, updated_at, version)
         VALUES (
             'admin-user-id-1234567890',
             'admin@example.com',
@@ -120,6 +120,7 @@ seed_users() {
             'Admin',
             'User',
             'active',
+            'admin',
             NOW(),
             NOW(),
             1
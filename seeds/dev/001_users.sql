@@ -0,0 +1,8 @@
+-- Seed: development users, all with the password "password123"
+INSERT INTO users (id, email, password_hash, first_name, last_name, status, role)
+VALUES
+    ('00000000-0000-0000-0000-000000000001', 'admin@example.com', '$2a$10$f93AUHtCf2ebTMIJd8K2z.gmdNerjGwOaqXaeHgb/fCNr76UdsuS.', 'Admin', 'User', 'active', 'admin'),
+    ('00000000-0000-0000-0000-000000000002', 'john.doe@example.com', '$2a$10$f93AUHtCf2ebTMIJd8K2z.gmdNerjGwOaqXaeHgb/fCNr76UdsuS.', 'John', 'Doe', 'active', 'user'),
+    ('00000000-0000-0000-0000-000000000003', 'jane.smith@example.com', '$2a$10$f93AUHtCf2ebTMIJd8K2z.gmdNerjGwOaqXaeHgb/fCNr76UdsuS.', 'Jane', 'Smith', 'active', 'user'),
+    ('00000000-0000-0000-0000-000000000004', 'bob.wilson@example.com', '$2a$10$f93AUHtCf2ebTMIJd8K2z.gmdNerjGwOaqXaeHgb/fCNr76UdsuS.', 'Bob', 'Wilson', 'inactive', 'user')
+ON CONFLICT (email) DO NOTHING;
@@ -0,0 +1,10 @@
+-- Seed: an active session for the admin user
+INSERT INTO sessions (id, user_id, expires_at, ip_address, user_agent, is_active)
+VALUES (
+    '00000000-0000-0000-0000-000000000101',
+    '00000000-0000-0000-0000-000000000001',
+    NOW() + INTERVAL '1 day',
+    '127.0.0.1',
+    'Mozilla/5.0 (Development Seed)',
+    true
+) ON CONFLICT (id) DO NOTHING;
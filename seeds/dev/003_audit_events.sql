@@ -0,0 +1,29 @@
+-- Seed: audit events for the seeded users. event_id isn't unique, so rows
+-- already seeded are skipped explicitly.
+INSERT INTO audit_events (event_id, event_type, aggregate_id, aggregate_type, user_id, action, resource, resource_id, details, occurred_at, metadata)
+SELECT seed.*
+FROM (VALUES
+    ('seed-audit-event-1', 'user.created', '00000000-0000-0000-0000-000000000001', 'user',
+     '00000000-0000-0000-0000-000000000001', 'create', 'user', '00000000-0000-0000-0000-000000000001',
+     '{"email": "admin@example.com", "first_name": "Admin", "last_name": "User"}'::jsonb,
+     NOW() - INTERVAL '1 hour', '{"source": "seed"}'::jsonb),
+    ('seed-audit-event-2', 'user.created', '00000000-0000-0000-0000-000000000002', 'user',
+     '00000000-0000-0000-0000-000000000002', 'create', 'user', '00000000-0000-0000-0000-000000000002',
+     '{"email": "john.doe@example.com", "first_name": "John", "last_name": "Doe"}'::jsonb,
+     NOW() - INTERVAL '30 minutes', '{"source": "seed"}'::jsonb),
+    ('seed-audit-event-3', 'user.created', '00000000-0000-0000-0000-000000000003', 'user',
+     '00000000-0000-0000-0000-000000000003', 'create', 'user', '00000000-0000-0000-0000-000000000003',
+     '{"email": "jane.smith@example.com", "first_name": "Jane", "last_name": "Smith"}'::jsonb,
+     NOW() - INTERVAL '25 minutes', '{"source": "seed"}'::jsonb),
+    ('seed-audit-event-4', 'user.created', '00000000-0000-0000-0000-000000000004', 'user',
+     '00000000-0000-0000-0000-000000000004', 'create', 'user', '00000000-0000-0000-0000-000000000004',
+     '{"email": "bob.wilson@example.com", "first_name": "Bob", "last_name": "Wilson"}'::jsonb,
+     NOW() - INTERVAL '20 minutes', '{"source": "seed"}'::jsonb),
+    ('seed-audit-event-5', 'auth.user.logged_in', '00000000-0000-0000-0000-000000000001', 'session',
+     '00000000-0000-0000-0000-000000000001', 'login', 'session', '00000000-0000-0000-0000-000000000101',
+     '{"ip_address": "127.0.0.1", "user_agent": "Mozilla/5.0 (Development Seed)"}'::jsonb,
+     NOW() - INTERVAL '15 minutes', '{"source": "seed"}'::jsonb)
+) AS seed (event_id, event_type, aggregate_id, aggregate_type, user_id, action, resource, resource_id, details, occurred_at, metadata)
+WHERE NOT EXISTS (
+    SELECT 1 FROM audit_events existing WHERE existing.event_id = seed.event_id
+);
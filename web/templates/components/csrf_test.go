@@ -0,0 +1,31 @@
+package components
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCSRFField(t *testing.T) {
+	var buf strings.Builder
+	err := CSRFField("abc123").Render(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("Failed to render CSRF field: %v", err)
+	}
+
+	expected := `<input type="hidden" name="_csrf_token" value="abc123">`
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestCSRFToken(t *testing.T) {
+	if token := CSRFToken(context.Background()); token != "" {
+		t.Errorf("Expected no token in a bare context, got %q", token)
+	}
+
+	ctx := WithCSRFToken(context.Background(), "abc123")
+	if token := CSRFToken(ctx); token != "abc123" {
+		t.Errorf("Expected token abc123, got %q", token)
+	}
+}
@@ -0,0 +1,110 @@
+package components
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func renderPagination(t *testing.T, currentPage, totalPages int, queryParams map[string]string) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	err := Pagination(currentPage, totalPages, "/admin/users", queryParams).Render(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("Failed to render Pagination: %v", err)
+	}
+	return buf.String()
+}
+
+// TestPagination_FirstPage tests that Previous is disabled on the first page
+func TestPagination_FirstPage(t *testing.T) {
+	html := renderPagination(t, 1, 3, nil)
+
+	if strings.Contains(html, "/admin/users?page=0") {
+		t.Error("Expected no link before the first page")
+	}
+	if !strings.Contains(html, `aria-disabled="true"`) {
+		t.Error("Expected Previous to be disabled")
+	}
+	if !strings.Contains(html, `href="/admin/users?page=2"`) {
+		t.Error("Expected Next link to page 2")
+	}
+	if !strings.Contains(html, `aria-current="page"`) {
+		t.Error("Expected the current page to be marked")
+	}
+}
+
+// TestPagination_MiddlePageWithEllipsis tests a large range around a middle page
+func TestPagination_MiddlePageWithEllipsis(t *testing.T) {
+	html := renderPagination(t, 10, 20, map[string]string{"search": "john doe", "status": ""})
+
+	for _, page := range []string{"1", "9", "11", "20"} {
+		if !strings.Contains(html, `href="/admin/users?page=`+page+`&amp;search=john+doe"`) {
+			t.Errorf("Expected link to page %s keeping the search", page)
+		}
+	}
+
+	// Distant pages are replaced by an ellipsis on each side
+	if strings.Contains(html, "page=5&") || strings.Contains(html, "page=15&") {
+		t.Error("Expected distant pages to be collapsed")
+	}
+	if strings.Count(html, "&hellip;") != 2 {
+		t.Error("Expected an ellipsis on each side of the current page")
+	}
+
+	// Empty params are dropped
+	if strings.Contains(html, "status=") {
+		t.Error("Expected empty query params to be omitted")
+	}
+
+	if strings.Contains(html, `aria-disabled="true"`) {
+		t.Error("Expected Previous and Next to be enabled")
+	}
+}
+
+// TestPagination_LastPage tests that Next is disabled on the last page
+func TestPagination_LastPage(t *testing.T) {
+	html := renderPagination(t, 3, 3, nil)
+
+	if strings.Contains(html, "/admin/users?page=4") {
+		t.Error("Expected no link after the last page")
+	}
+	if !strings.Contains(html, `aria-disabled="true"`) {
+		t.Error("Expected Next to be disabled")
+	}
+	if !strings.Contains(html, `href="/admin/users?page=2"`) {
+		t.Error("Expected Previous link to page 2")
+	}
+}
+
+// TestPagination_SinglePage tests that the control is hidden for one page
+func TestPagination_SinglePage(t *testing.T) {
+	html := renderPagination(t, 1, 1, nil)
+
+	if strings.TrimSpace(html) != "" {
+		t.Errorf("Expected nothing to be rendered, got %q", html)
+	}
+}
+
+func TestPaginationPages(t *testing.T) {
+	testCases := []struct {
+		current, total int
+		expected       []int
+	}{
+		{1, 1, []int{1}},
+		{1, 3, []int{1, 2, 3}},
+		{1, 10, []int{1, 2, 0, 10}},
+		{5, 10, []int{1, 0, 4, 5, 6, 0, 10}},
+		{3, 10, []int{1, 2, 3, 4, 0, 10}},
+		{10, 10, []int{1, 0, 9, 10}},
+	}
+
+	for _, tc := range testCases {
+		if got := paginationPages(tc.current, tc.total); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("paginationPages(%d, %d) = %v, want %v", tc.current, tc.total, got, tc.expected)
+		}
+	}
+}
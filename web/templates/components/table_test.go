@@ -0,0 +1,134 @@
+package components
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func renderDataTable(t *testing.T, columns []Column, rows []Row, sort SortState) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := DataTable(columns, rows, sort).Render(context.Background(), &buf); err != nil {
+		t.Fatalf("Failed to render DataTable: %v", err)
+	}
+	return buf.String()
+}
+
+func testTableColumns() []Column {
+	return []Column{
+		{Label: "Name", Field: "name", Sortable: true},
+		{Label: "Created", Field: "created_at", Sortable: true},
+		{Label: "Actions", Field: "actions"},
+	}
+}
+
+func testTableRows() []Row {
+	return []Row{
+		{"name": TableText("John Doe"), "created_at": TableText("Jan 1, 2024")},
+		{"name": TableText("Jane Smith"), "created_at": TableText("Feb 2, 2024")},
+		{"name": TableText("Bob <script>"), "created_at": TableText("Mar 3, 2024")},
+	}
+}
+
+// TestDataTable_SortLinks tests that header links reflect the current sort
+func TestDataTable_SortLinks(t *testing.T) {
+	testCases := []struct {
+		name        string
+		sort        SortState
+		nameLink    string
+		createdLink string
+		ariaSort    string
+		indicator   string
+	}{
+		{
+			name:        "unsorted",
+			sort:        SortState{},
+			nameLink:    `href="?sort=name&amp;dir=asc"`,
+			createdLink: `href="?sort=created_at&amp;dir=asc"`,
+		},
+		{
+			name:        "sorted ascending",
+			sort:        SortState{Field: "name", Direction: "asc"},
+			nameLink:    `href="?sort=name&amp;dir=desc"`,
+			createdLink: `href="?sort=created_at&amp;dir=asc"`,
+			ariaSort:    `aria-sort="ascending"`,
+			indicator:   "&#9650;",
+		},
+		{
+			name:        "sorted descending",
+			sort:        SortState{Field: "name", Direction: "desc"},
+			nameLink:    `href="?sort=name&amp;dir=asc"`,
+			createdLink: `href="?sort=created_at&amp;dir=asc"`,
+			ariaSort:    `aria-sort="descending"`,
+			indicator:   "&#9660;",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			html := renderDataTable(t, testTableColumns(), testTableRows(), tc.sort)
+
+			if !strings.Contains(html, tc.nameLink) {
+				t.Errorf("Expected name header link %s", tc.nameLink)
+			}
+			if !strings.Contains(html, tc.createdLink) {
+				t.Errorf("Expected created header link %s", tc.createdLink)
+			}
+
+			if tc.ariaSort != "" {
+				if !strings.Contains(html, tc.ariaSort) {
+					t.Errorf("Expected %s on the sorted column", tc.ariaSort)
+				}
+				if !strings.Contains(html, tc.indicator) {
+					t.Errorf("Expected sort indicator %s", tc.indicator)
+				}
+			} else if strings.Contains(html, "aria-sort") {
+				t.Error("Expected no aria-sort without a sort column")
+			}
+
+			// Non-sortable columns have no link
+			if strings.Contains(html, "sort=actions") {
+				t.Error("Expected no sort link for a non-sortable column")
+			}
+		})
+	}
+}
+
+// TestDataTable_Rows tests row rendering and zebra striping
+func TestDataTable_Rows(t *testing.T) {
+	html := renderDataTable(t, testTableColumns(), testTableRows(), SortState{})
+
+	for _, expected := range []string{"John Doe", "Jane Smith", "Mar 3, 2024"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("Expected cell content '%s'", expected)
+		}
+	}
+
+	// Cell text is escaped
+	if strings.Contains(html, "<script>") {
+		t.Error("Expected cell content to be escaped")
+	}
+
+	if strings.Count(html, `<tr class="bg-white"`) != 2 || strings.Count(html, `<tr class="bg-gray-50"`) != 1 {
+		t.Error("Expected alternating row backgrounds")
+	}
+
+	if strings.Contains(html, "No results found") {
+		t.Error("Expected no empty state when there are rows")
+	}
+}
+
+// TestDataTable_EmptyState tests that an empty rows slice renders the empty state
+func TestDataTable_EmptyState(t *testing.T) {
+	html := renderDataTable(t, testTableColumns(), []Row{}, SortState{Field: "name", Direction: "asc"})
+
+	if !strings.Contains(html, "No results found") {
+		t.Error("Expected empty state message")
+	}
+	if strings.Contains(html, "<table") {
+		t.Error("Expected no table when there are no rows")
+	}
+}
@@ -0,0 +1,101 @@
+package components
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-templ-template/internal/shared/flash"
+)
+
+func TestToast(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  flash.Message
+		expected []string
+	}{
+		{
+			name:     "success toast",
+			message:  flash.Message{Type: flash.TypeSuccess, Message: "Profile saved"},
+			expected: []string{"bg-green-50", `role="status"`, "Profile saved"},
+		},
+		{
+			name:     "error toast",
+			message:  flash.Message{Type: flash.TypeError, Message: "Could not save profile"},
+			expected: []string{"bg-red-50", `role="alert"`, "Could not save profile"},
+		},
+		{
+			name:     "info toast",
+			message:  flash.Message{Type: flash.TypeInfo, Message: "Check your inbox"},
+			expected: []string{"bg-blue-50", `role="status"`, "Check your inbox"},
+		},
+		{
+			name:     "unknown type falls back to info",
+			message:  flash.Message{Type: "debug", Message: "Something happened"},
+			expected: []string{"bg-blue-50", `role="status"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf strings.Builder
+			err := Toast([]flash.Message{tt.message}).Render(context.Background(), &buf)
+			if err != nil {
+				t.Fatalf("Failed to render toast: %v", err)
+			}
+
+			output := buf.String()
+
+			expected := append([]string{`id="toasts"`, "<span class=\"sr-only\">Dismiss</span>"}, tt.expected...)
+			for _, element := range expected {
+				if !strings.Contains(output, element) {
+					t.Errorf("Expected toast to contain %q", element)
+				}
+			}
+		})
+	}
+}
+
+func TestToast_MultipleMessages(t *testing.T) {
+	var buf strings.Builder
+	err := Toast([]flash.Message{
+		{Type: flash.TypeSuccess, Message: "First"},
+		{Type: flash.TypeError, Message: "Second"},
+	}).Render(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("Failed to render toast: %v", err)
+	}
+
+	output := buf.String()
+	if count := strings.Count(output, `class="toast `); count != 2 {
+		t.Errorf("Expected 2 toasts, got %d", count)
+	}
+	if strings.Index(output, "First") > strings.Index(output, "Second") {
+		t.Error("Expected toasts in the order they were added")
+	}
+}
+
+func TestToast_EscapesMessage(t *testing.T) {
+	var buf strings.Builder
+	err := Toast([]flash.Message{
+		{Type: flash.TypeInfo, Message: "<script>alert(1)</script>"},
+	}).Render(context.Background(), &buf)
+	if err != nil {
+		t.Fatalf("Failed to render toast: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "<script>") {
+		t.Error("Expected toast message to be escaped")
+	}
+}
+
+func TestToast_Empty(t *testing.T) {
+	var buf strings.Builder
+	if err := Toast(nil).Render(context.Background(), &buf); err != nil {
+		t.Fatalf("Failed to render toast: %v", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output for no messages, got %q", buf.String())
+	}
+}
@@ -58,6 +58,38 @@ func TestUserProfile(t *testing.T) {
 	if !strings.Contains(html, "JD") {
 		t.Error("Expected user initials to be displayed in avatar")
 	}
+
+	if strings.Contains(html, "<img") {
+		t.Error("Expected no avatar image when none is set")
+	}
+}
+
+// TestUserProfile_AvatarImage tests that an uploaded avatar replaces the initials
+func TestUserProfile_AvatarImage(t *testing.T) {
+	user := User{
+		ID:        "user-123",
+		Email:     "john@example.com",
+		FirstName: "John",
+		LastName:  "Doe",
+		Status:    "active",
+		AvatarURL: "/uploads/avatars/user-123/abc.png",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	var buf bytes.Buffer
+	if err := UserProfile(user).Render(context.Background(), &buf); err != nil {
+		t.Fatalf("Failed to render UserProfile: %v", err)
+	}
+
+	html := buf.String()
+	if !strings.Contains(html, `<img src="/uploads/avatars/user-123/abc.png" alt="John Doe"`) {
+		t.Error("Expected avatar image to be displayed")
+	}
+
+	if strings.Contains(html, ">JD<") {
+		t.Error("Expected initials to be replaced by the avatar image")
+	}
 }
 
 // TestUserDashboard tests the UserDashboard component rendering
@@ -124,6 +156,25 @@ func TestUserDashboard(t *testing.T) {
 	if !strings.Contains(html, "Security") {
 		t.Error("Expected Security quick link")
 	}
+
+	// Test last login
+	if !strings.Contains(html, "Dec 1") {
+		t.Error("Expected last login date to be displayed")
+	}
+}
+
+// TestUserDashboard_NeverLoggedIn tests the dashboard of a user without a recorded login
+func TestUserDashboard_NeverLoggedIn(t *testing.T) {
+	user := User{ID: "user-456", FirstName: "Jane", LastName: "Smith", Status: "active"}
+
+	var buf bytes.Buffer
+	if err := UserDashboard(user, DashboardStats{ProfileViews: "0"}).Render(context.Background(), &buf); err != nil {
+		t.Fatalf("Failed to render UserDashboard: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "Never") {
+		t.Error("Expected last login to be shown as never")
+	}
 }
 
 // TestUserEditForm tests the UserEditForm component rendering
@@ -207,7 +258,7 @@ func TestUserList(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	err := UserList(users, 1, 3).Render(context.Background(), &buf)
+	err := UserList(users, 1, 3, nil).Render(context.Background(), &buf)
 	if err != nil {
 		t.Fatalf("Failed to render UserList: %v", err)
 	}
@@ -434,7 +485,7 @@ func TestQuickLinkCard(t *testing.T) {
 // TestPagination tests the Pagination component rendering
 func TestPagination(t *testing.T) {
 	var buf bytes.Buffer
-	err := Pagination(2, 5, "/admin/users").Render(context.Background(), &buf)
+	err := Pagination(2, 5, "/admin/users", nil).Render(context.Background(), &buf)
 	if err != nil {
 		t.Fatalf("Failed to render Pagination: %v", err)
 	}
@@ -470,7 +521,7 @@ func TestEmptyUserList(t *testing.T) {
 	users := []User{}
 
 	var buf bytes.Buffer
-	err := UserList(users, 1, 1).Render(context.Background(), &buf)
+	err := UserList(users, 1, 1, nil).Render(context.Background(), &buf)
 	if err != nil {
 		t.Fatalf("Failed to render empty UserList: %v", err)
 	}
@@ -524,6 +575,6 @@ func BenchmarkUserList(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		var buf bytes.Buffer
-		UserList(users, 1, 5).Render(context.Background(), &buf)
+		UserList(users, 1, 5, nil).Render(context.Background(), &buf)
 	}
 }
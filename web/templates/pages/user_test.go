@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"go-templ-template/web/templates/components"
+
+	"github.com/a-h/templ"
 )
 
 // TestUserProfilePage tests the UserProfilePage component rendering
@@ -553,6 +555,10 @@ func TestPrivacySettingsTab(t *testing.T) {
 		t.Error("Expected Request Data Export button")
 	}
 
+	if !strings.Contains(html, `action="/api/v1/users/user-123/export"`) {
+		t.Error("Expected Request Data Export form to post to the export endpoint")
+	}
+
 	// Test account deletion section
 	if !strings.Contains(html, "Delete Account") {
 		t.Error("Expected Delete Account section")
@@ -646,3 +652,40 @@ func BenchmarkUserListPage(b *testing.B) {
 		UserListPage(users, 1, 5, "").Render(context.Background(), &buf)
 	}
 }
+
+// TestSettingsForms_IncludeCSRFToken tests that every settings form embeds
+// the CSRF token carried by the render context
+func TestSettingsForms_IncludeCSRFToken(t *testing.T) {
+	user := components.User{
+		ID:        "user-123",
+		Email:     "test@example.com",
+		FirstName: "Test",
+		LastName:  "User",
+		Status:    "active",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	tabs := map[string]templ.Component{
+		"profile":       ProfileSettingsTab(user),
+		"security":      SecuritySettingsTab(user),
+		"notifications": NotificationSettingsTab(user),
+		"privacy":       PrivacySettingsTab(user),
+	}
+
+	ctx := components.WithCSRFToken(context.Background(), "test-csrf-token")
+	expected := `<input type="hidden" name="_csrf_token" value="test-csrf-token">`
+
+	for name, tab := range tabs {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tab.Render(ctx, &buf); err != nil {
+				t.Fatalf("Failed to render %s tab: %v", name, err)
+			}
+
+			if !strings.Contains(buf.String(), expected) {
+				t.Errorf("Expected %s form to contain the CSRF field", name)
+			}
+		})
+	}
+}